@@ -17,11 +17,92 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	GroupReadyCondition = "GroupReadyCondition"
+	// GroupSuspendedCondition is True while spec.suspend pauses the reconciliation of the group
+	GroupSuspendedCondition = "Suspended"
+)
+
+// AwaitingApprovalCondition is True while the group waits for an approver to add the approved-by
+// annotation, and False with the Approved reason once the approval was consumed
+const AwaitingApprovalCondition = "AwaitingApproval"
+
+const (
+	// NewGroupApprovalReason is the reason of the AwaitingApprovalCondition of a new group
+	NewGroupApprovalReason = "NewGroup"
+	// LargeChangeApprovalReason is the reason of the AwaitingApprovalCondition of a group whose
+	// members change more than the configured threshold
+	LargeChangeApprovalReason = "LargeChange"
+	// ApprovedReason is the reason of the AwaitingApprovalCondition of an approved group
+	ApprovedReason = "Approved"
+)
+
+// DeletionPreventedReason is the reason of the GroupReadyCondition of a deleted group kept by the
+// prevent-deletion annotation
+const DeletionPreventedReason = "DeletionPrevented"
+
+// DuplicateGroupNameReason is the reason of the GroupReadyCondition of a group whose group_name
+// is already managed by an older Group CR of the namespace
+const DuplicateGroupNameReason = "DuplicateGroupName"
+
+const (
+	// LDAPLookupFailedReason is the reason of the GroupReadyCondition of a group with the Fail
+	// LDAP failure policy whose members could not be looked up in LDAP, and of the LDAPReadyCondition
+	// of any group whose members could not be looked up
+	LDAPLookupFailedReason = "LDAPLookupFailed"
+	// LDAPMembershipFrozenReason is the reason of the GroupReadyCondition of a group with the Freeze
+	// LDAP failure policy whose backend memberships are kept until LDAP recovers
+	LDAPMembershipFrozenReason = "LDAPMembershipFrozen"
+)
+
+// Conditions detailing the GroupReadyCondition, so that alerts can tell an LDAP outage
+// from a cache failure or a backend API error
+const (
+	// LDAPReadyCondition is True when the members of the group were looked up in LDAP
+	LDAPReadyCondition = "LDAPReady"
+	// CacheReadyCondition is True when the cache indexes of the group were updated after the backends synced
+	CacheReadyCondition = "CacheReady"
+	// BackendsReadyCondition is True when all the backends of the group reconciled
+	BackendsReadyCondition = "BackendsReady"
+)
+
+// Reasons of the LDAPReady, CacheReady and BackendsReady conditions
+const (
+	LDAPLookupSucceededReason    = "LDAPLookupSucceeded"
+	LDAPQueryFailedReason        = "LDAPQueryFailed"
+	CacheUpdatedReason           = "CacheUpdated"
+	CacheUpdateFailedReason      = "CacheUpdateFailed"
+	BackendsReconciledReason     = "BackendsReconciled"
+	BackendReconcileFailedReason = "BackendReconcileFailed"
+)
+
+// LDAPFailurePolicy decides how a group handles members whose LDAP lookup fails. Members missing
+// from LDAP are not lookup failures, they are always removed from the backend teams.
+type LDAPFailurePolicy string
+
+const (
+	// LDAPFailurePolicySkip drops the members whose lookup failed, which removes them from the backend teams
+	LDAPFailurePolicySkip LDAPFailurePolicy = "Skip"
+	// LDAPFailurePolicyFail aborts the reconcile, which is retried with a backoff
+	LDAPFailurePolicyFail LDAPFailurePolicy = "Fail"
+	// LDAPFailurePolicyFreeze leaves the backend team memberships untouched until LDAP recovers
+	LDAPFailurePolicyFreeze LDAPFailurePolicy = "Freeze"
+)
+
+// DeletionPolicy decides what happens to the backend teams when the Group CR is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the teams from all the backends of the group
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the teams in the backends and only stops managing them
+	DeletionPolicyRetain DeletionPolicy = "Retain"
 )
 
 type BackendStatus struct {
@@ -29,6 +110,33 @@ type BackendStatus struct {
 	Type    string `json:"type"`
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
+	// ObservedGeneration is the generation of the CR the backend was last reconciled at
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Retries counts the consecutive failed reconciles of the backend at ObservedGeneration
+	Retries int32 `json:"retries,omitempty"`
+	// TeamID is the ID of the team in the backend
+	TeamID string `json:"teamID,omitempty"`
+	// MemberCount is the number of team members after the last sync
+	MemberCount int `json:"memberCount,omitempty"`
+	// UsersAdded is the number of users added to the team in the last sync
+	UsersAdded int `json:"usersAdded,omitempty"`
+	// UsersRemoved is the number of users removed from the team in the last sync
+	UsersRemoved int `json:"usersRemoved,omitempty"`
+	// LastSyncTime is when the backend was last reconciled successfully
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// MembersOnlyInBackend are the team members not in the spec found by the last sync, before removing them
+	MembersOnlyInBackend []string `json:"membersOnlyInBackend,omitempty"`
+	// MembersOnlyInSpec are the members missing from the team found by the last sync, before adding them
+	MembersOnlyInSpec []string `json:"membersOnlyInSpec,omitempty"`
+	// DirectMembers are the members added directly to the team managed by the dependency of the
+	// backend and not in the spec, found by the last direct member audit
+	DirectMembers []string `json:"directMembers,omitempty"`
+	// NestedTeams are the IDs of the teams nested in the team by usernaut for the member groups, only
+	// these are removed from the team once their group is no longer a member group
+	NestedTeams []string `json:"nestedTeams,omitempty"`
+	// DeletionPolicy is set on the backends attached by a backend selector of the configuration, their
+	// team is only deleted once the selectors stop attaching them when the policy is Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 type Backend struct {
@@ -58,16 +166,146 @@ type LDAPOptions struct {
 
 // GroupSpec defines the desired state of Group
 type GroupSpec struct {
-	GroupName   string       `json:"group_name"`
-	Members     Members      `json:"members"`
+	// +optional
+	GroupName string  `json:"group_name"`
+	Members   Members `json:"members"`
+	// Owners are members of the group granted the owner role of each backend team,
+	// e.g. Owner on GitLab. The backends without a team owner role, e.g. Fivetran, add them as members.
+	Owners      []string     `json:"owners,omitempty"`
 	GroupParams []GroupParam `json:"group_params,omitempty"`
-	Backends    []Backend    `json:"backends"`
+	// +optional
+	Backends []Backend `json:"backends"`
+	// TemplateRef is the name of a GroupTemplate in the namespace of the group. The group name, backends
+	// and group params of the template are expanded into the spec by the group template controller.
+	TemplateRef string `json:"template_ref,omitempty"`
+	// BackendOverrides adjusts the members of the group for individual backends
+	BackendOverrides []BackendOverride `json:"backend_overrides,omitempty"`
+	// DeletionPolicy decides whether the backend teams are deleted along with the Group CR
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletion_policy,omitempty"`
+	// Suspend pauses the reconciliation of the group, no backend is called until it is unset.
+	// Deleting a suspended group still runs the finalizer.
+	Suspend bool `json:"suspend,omitempty"`
+	// AdoptExisting only adopts teams which already exist in the backends under the transformed group
+	// name, a backend without such a team fails instead of getting a new one
+	AdoptExisting bool `json:"adopt_existing,omitempty"`
+	// LDAPFailurePolicy decides how members whose LDAP lookup fails are handled
+	// +kubebuilder:validation:Enum=Skip;Fail;Freeze
+	// +kubebuilder:default=Skip
+	LDAPFailurePolicy LDAPFailurePolicy `json:"ldap_failure_policy,omitempty"`
+}
+
+// BackendOverride excludes members from, or adds extra users to, a single backend of the group
+type BackendOverride struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// ExcludeUsers are members of the group which are not onboarded on this backend
+	ExcludeUsers []string `json:"exclude_users,omitempty"`
+	// AdditionalUsers are onboarded on this backend only
+	AdditionalUsers []string `json:"additional_users,omitempty"`
 }
 
 type Members struct {
 	Groups    []string   `json:"groups,omitempty"`
 	Users     []string   `json:"users"`
 	LDAPQuery *LDAPQuery `json:"ldap_query,omitempty"`
+	// LDAPGroups are DNs of LDAP or Rover groups whose members are members of the group,
+	// e.g. cn=data-eng,ou=adhoc,ou=managedGroups,dc=redhat,dc=com
+	LDAPGroups []string `json:"ldap_groups,omitempty"`
+	// Roles optionally assigns a backend role to individual members, whichever source they come from
+	Roles []MemberRole `json:"roles,omitempty"`
+	// UsersWithRoles lists members along with their backend role, e.g. {user: alice, role: maintainer},
+	// they are members of the group like the users
+	UsersWithRoles []MemberRole `json:"users_with_roles,omitempty"`
+	// Expirations optionally makes the membership of individual members temporary
+	Expirations []MemberExpiration `json:"expirations,omitempty"`
+	// FromConfigMap adds the users listed in a ConfigMap maintained outside of the CR
+	FromConfigMap *MemberSource `json:"from_config_map,omitempty"`
+	// FromSecret adds the users listed in a Secret maintained outside of the CR
+	FromSecret *MemberSource `json:"from_secret,omitempty"`
+	// UserRefs are names of User CRs in the namespace of the group, their users are members of the group
+	UserRefs []string `json:"user_refs,omitempty"`
+	// GroupsPolicy controls how the member groups listed in Groups are expanded
+	GroupsPolicy *GroupsPolicy `json:"groups_policy,omitempty"`
+}
+
+// GroupsPolicyMode decides how the members of the member groups reach the backend teams
+type GroupsPolicyMode string
+
+const (
+	// GroupsPolicyFlatten adds the members of the member groups, recursively, to the backend teams
+	GroupsPolicyFlatten GroupsPolicyMode = "Flatten"
+	// GroupsPolicyMirror nests the teams of the member groups in the backend teams of the backends
+	// supporting nested teams (GitLab shared groups), the other backends are flattened
+	GroupsPolicyMirror GroupsPolicyMode = "Mirror"
+)
+
+// GroupsPolicy controls how the member groups of a group are expanded
+type GroupsPolicy struct {
+	// +kubebuilder:validation:Enum=Flatten;Mirror
+	// +kubebuilder:default=Flatten
+	Mode GroupsPolicyMode `json:"mode,omitempty"`
+	// MaxDepth limits the levels of member groups flattened into the members, 1 only expands the
+	// groups listed by the group itself. 0 does not limit the depth.
+	// +kubebuilder:validation:Minimum=0
+	MaxDepth int `json:"max_depth,omitempty"`
+}
+
+// GroupsMode returns the mode of the groups policy, Flatten when unset
+func (m *Members) GroupsMode() GroupsPolicyMode {
+	if m.GroupsPolicy == nil || m.GroupsPolicy.Mode == "" {
+		return GroupsPolicyFlatten
+	}
+	return m.GroupsPolicy.Mode
+}
+
+// AllUsers returns the users of the members followed by the users listed with their role
+func (m *Members) AllUsers() []string {
+	users := slices.Clone(m.Users)
+	for _, userWithRole := range m.UsersWithRoles {
+		if !slices.Contains(users, userWithRole.User) {
+			users = append(users, userWithRole.User)
+		}
+	}
+	return users
+}
+
+// AllRoles returns the roles of the users listed with their role followed by the member roles
+func (m *Members) AllRoles() []MemberRole {
+	return append(slices.Clone(m.UsersWithRoles), m.Roles...)
+}
+
+// MaxGroupsDepth returns the max depth of the groups policy, 0 when the depth is not limited
+func (m *Members) MaxGroupsDepth() int {
+	if m.GroupsPolicy == nil {
+		return 0
+	}
+	return m.GroupsPolicy.MaxDepth
+}
+
+// MemberSource references a key of a ConfigMap or Secret in the namespace of the group. The value lists
+// users separated by newlines, commas or spaces, lines starting with # are ignored.
+type MemberSource struct {
+	Name string `json:"name"`
+	// +kubebuilder:default=users
+	Key string `json:"key,omitempty"`
+}
+
+// MemberExpiration removes a member from the group once ExpiresAt has passed
+type MemberExpiration struct {
+	User      string      `json:"user"`
+	ExpiresAt metav1.Time `json:"expires_at"`
+}
+
+// MemberRole assigns a role to a single member of the group. The role is backend specific,
+// e.g. an access level like "maintainer" for gitlab or an account role like "Account Analyst" for fivetran.
+type MemberRole struct {
+	User string `json:"user"`
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// Backend restricts the role to a backend type, when empty the role applies to all backends of the group
+	Backend string `json:"backend,omitempty"`
 }
 
 type GroupParam struct {
@@ -84,12 +322,53 @@ type GroupStatus struct {
 	Conditions            []metav1.Condition `json:"conditions,omitempty"`
 	LastAppliedGeneration int64              `json:"lastAppliedGeneration,omitempty"`
 	BackendsStatus        []BackendStatus    `json:"backends,omitempty"`
+	// GroupsDepth is the depth of the deepest member group expanded into the members
+	GroupsDepth int `json:"groupsDepth,omitempty"`
+	// TruncatedGroups are the member groups beyond groups_policy.max_depth, their members are not included
+	TruncatedGroups []string `json:"truncatedGroups,omitempty"`
+	// ReadyBackends is the number of backends reconciled successfully out of the backends of the group, e.g. "2/3"
+	ReadyBackends string `json:"readyBackends,omitempty"`
+	// MemberCount is the number of members reconciled
+	MemberCount int `json:"memberCount,omitempty"`
+	// LastSyncTime is when all the backends of the group last reconciled successfully
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// ObservedGeneration is the generation of the group at the last successful sync of all its backends
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// MembershipHash is a hash of the members and backends of the last successful sync of all the backends
+	MembershipHash string `json:"membershipHash,omitempty"`
+	// PendingApproval is the membership hash of the change awaiting an approval, the approved-by
+	// annotation only approves this change
+	PendingApproval string `json:"pendingApproval,omitempty"`
+	// SkippedUsers are the members of the group which could not be provisioned in the backends
+	SkippedUsers []SkippedUser `json:"skippedUsers,omitempty"`
+}
+
+// Reasons of the members listed in GroupStatus.SkippedUsers
+const (
+	// SkippedUserNotFoundInLDAP is the reason of a member missing from LDAP
+	SkippedUserNotFoundInLDAP = "NotFoundInLDAP"
+	// SkippedUserLDAPLookupFailed is the reason of a member whose LDAP lookup failed
+	SkippedUserLDAPLookupFailed = "LDAPLookupFailed"
+	// SkippedUserInvalidLDAPData is the reason of a member whose LDAP entry could not be read
+	SkippedUserInvalidLDAPData = "InvalidLDAPData"
+)
+
+// SkippedUser is a member of the group which could not be provisioned in the backends, and why
+type SkippedUser struct {
+	User    string `json:"user"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="GroupReadyCondition")].status`
-// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="GroupReadyCondition")].message`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="GroupReadyCondition")].status`
+// +kubebuilder:printcolumn:name="Backends",type=string,JSONPath=`.status.readyBackends`
+// +kubebuilder:printcolumn:name="Members",type=integer,JSONPath=`.status.memberCount`
+// +kubebuilder:printcolumn:name="LastSync",type=date,JSONPath=`.status.lastSyncTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="GroupReadyCondition")].message`,priority=1
+// +kubebuilder:selectablefield:JSONPath=`.spec.group_name`
 
 // Group is the Schema for the groups API
 type Group struct {
@@ -154,3 +433,26 @@ func (c *Group) UpdateStatus(isError bool) {
 	}
 	c.Status.Conditions = append(c.Status.Conditions, condition)
 }
+
+// ExpiredUsers returns the members whose membership has expired at the given time
+func (m *Members) ExpiredUsers(now time.Time) map[string]struct{} {
+	expired := make(map[string]struct{})
+	for _, expiration := range m.Expirations {
+		if !expiration.ExpiresAt.Time.After(now) {
+			expired[expiration.User] = struct{}{}
+		}
+	}
+	return expired
+}
+
+// NextExpiration returns the earliest membership expiry after the given time, or nil if there is none
+func (m *Members) NextExpiration(now time.Time) *time.Time {
+	var next *time.Time
+	for _, expiration := range m.Expirations {
+		expiresAt := expiration.ExpiresAt.Time
+		if expiresAt.After(now) && (next == nil || expiresAt.Before(*next)) {
+			next = &expiresAt
+		}
+	}
+	return next
+}
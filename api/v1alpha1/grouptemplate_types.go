@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GroupNamePlaceholder is replaced by the name of the Group CR in GroupTemplateSpec.GroupNameFormat
+const GroupNamePlaceholder = "{name}"
+
+// GroupTemplateSpec defines the backends, group params and naming shared by the Groups referencing the template
+type GroupTemplateSpec struct {
+	// GroupNameFormat builds the group_name of the referencing groups, "{name}" is replaced by the
+	// name of the Group CR. When empty the groups keep their group_name, or use the CR name if unset.
+	// It only applies when the template is first expanded into a group, the expanded groups keep their group_name.
+	// +optional
+	GroupNameFormat string `json:"group_name_format,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Backends    []Backend    `json:"backends"`
+	GroupParams []GroupParam `json:"group_params,omitempty"`
+}
+
+// GroupTemplateStatus defines the observed state of GroupTemplate
+type GroupTemplateStatus struct {
+	// Groups is the number of groups referencing the template
+	Groups int `json:"groups,omitempty"`
+	// ObservedGeneration is the generation of the template last expanded into the groups
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Groups",type=integer,JSONPath=`.status.groups`
+
+// GroupTemplate is the Schema for the grouptemplates API
+type GroupTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GroupTemplateSpec   `json:"spec,omitempty"`
+	Status GroupTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupTemplateList contains a list of GroupTemplate
+type GroupTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GroupTemplate{}, &GroupTemplateList{})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OffboardingPolicySpec lists the users the offboarding job must never delete from the backends,
+// even when they are no longer found in LDAP (shared accounts, break-glass users)
+type OffboardingPolicySpec struct {
+	// Users are the emails of the protected users
+	// +optional
+	Users []string `json:"users,omitempty"`
+	// EmailPatterns are glob patterns matched against the emails of the users, e.g. "svc-*@example.com"
+	// +optional
+	EmailPatterns []string `json:"email_patterns,omitempty"`
+	// Reason documents why the users are protected
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OffboardingPolicy is the Schema for the offboardingpolicies API
+type OffboardingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OffboardingPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OffboardingPolicyList contains a list of OffboardingPolicy
+type OffboardingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OffboardingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OffboardingPolicy{}, &OffboardingPolicyList{})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	UserReadyCondition = "UserReadyCondition"
+)
+
+// UserSpec defines the desired state of User
+type UserSpec struct {
+	// UserID is the LDAP uid of the user
+	UserID   string    `json:"user_id"`
+	Backends []Backend `json:"backends"`
+}
+
+// UserStatus defines the observed state of User
+type UserStatus struct {
+	Email                 string             `json:"email,omitempty"`
+	Conditions            []metav1.Condition `json:"conditions,omitempty"`
+	LastAppliedGeneration int64              `json:"lastAppliedGeneration,omitempty"`
+	BackendsStatus        []BackendStatus    `json:"backends,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Email",type=string,JSONPath=`.status.email`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="UserReadyCondition")].status`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="UserReadyCondition")].message`
+
+// User is the Schema for the users API
+type User struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserSpec   `json:"spec,omitempty"`
+	Status UserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UserList contains a list of User
+type UserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []User `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&User{}, &UserList{})
+}
+
+func (c *User) SetWaiting() {
+	condition := metav1.Condition{
+		Type:               UserReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionUnknown,
+		Message:            "User is getting reconciled",
+		Reason:             "Waiting",
+	}
+	c.setCondition(condition)
+}
+
+func (c *User) UpdateStatus(isError bool) {
+	condition := metav1.Condition{
+		Type:               UserReadyCondition,
+		LastTransitionTime: metav1.Now(),
+	}
+	if !isError {
+		condition.Status = metav1.ConditionTrue
+		condition.Message = "User reconciled successfully"
+		condition.Reason = SuccessfullyReconciled
+
+		c.Status.LastAppliedGeneration = c.Generation
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = "User reconcile failed"
+		condition.Reason = ReconcileFailed
+	}
+	c.setCondition(condition)
+}
+
+func (c *User) setCondition(condition metav1.Condition) {
+	for i, currentCondition := range c.Status.Conditions {
+		if currentCondition.Type == condition.Type {
+			c.Status.Conditions[i] = condition
+			return
+		}
+	}
+	c.Status.Conditions = append(c.Status.Conditions, condition)
+}
@@ -40,9 +40,58 @@ func (in *Backend) DeepCopy() *Backend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendOverride) DeepCopyInto(out *BackendOverride) {
+	*out = *in
+	if in.ExcludeUsers != nil {
+		in, out := &in.ExcludeUsers, &out.ExcludeUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalUsers != nil {
+		in, out := &in.AdditionalUsers, &out.AdditionalUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendOverride.
+func (in *BackendOverride) DeepCopy() *BackendOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
 	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MembersOnlyInBackend != nil {
+		in, out := &in.MembersOnlyInBackend, &out.MembersOnlyInBackend
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MembersOnlyInSpec != nil {
+		in, out := &in.MembersOnlyInSpec, &out.MembersOnlyInSpec
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DirectMembers != nil {
+		in, out := &in.DirectMembers, &out.DirectMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NestedTeams != nil {
+		in, out := &in.NestedTeams, &out.NestedTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendStatus.
@@ -138,6 +187,11 @@ func (in *GroupParam) DeepCopy() *GroupParam {
 func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
 	*out = *in
 	in.Members.DeepCopyInto(&out.Members)
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.GroupParams != nil {
 		in, out := &in.GroupParams, &out.GroupParams
 		*out = make([]GroupParam, len(*in))
@@ -150,6 +204,13 @@ func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
 		*out = make([]Backend, len(*in))
 		copy(*out, *in)
 	}
+	if in.BackendOverrides != nil {
+		in, out := &in.BackendOverrides, &out.BackendOverrides
+		*out = make([]BackendOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSpec.
@@ -180,6 +241,22 @@ func (in *GroupStatus) DeepCopyInto(out *GroupStatus) {
 	if in.BackendsStatus != nil {
 		in, out := &in.BackendsStatus, &out.BackendsStatus
 		*out = make([]BackendStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TruncatedGroups != nil {
+		in, out := &in.TruncatedGroups, &out.TruncatedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SkippedUsers != nil {
+		in, out := &in.SkippedUsers, &out.SkippedUsers
+		*out = make([]SkippedUser, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -194,6 +271,122 @@ func (in *GroupStatus) DeepCopy() *GroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupTemplate) DeepCopyInto(out *GroupTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupTemplate.
+func (in *GroupTemplate) DeepCopy() *GroupTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupTemplateList) DeepCopyInto(out *GroupTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GroupTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupTemplateList.
+func (in *GroupTemplateList) DeepCopy() *GroupTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupTemplateSpec) DeepCopyInto(out *GroupTemplateSpec) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]Backend, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupParams != nil {
+		in, out := &in.GroupParams, &out.GroupParams
+		*out = make([]GroupParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupTemplateSpec.
+func (in *GroupTemplateSpec) DeepCopy() *GroupTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupTemplateStatus) DeepCopyInto(out *GroupTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupTemplateStatus.
+func (in *GroupTemplateStatus) DeepCopy() *GroupTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupsPolicy) DeepCopyInto(out *GroupsPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupsPolicy.
+func (in *GroupsPolicy) DeepCopy() *GroupsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LDAPFilter) DeepCopyInto(out *LDAPFilter) {
 	*out = *in
@@ -249,6 +442,52 @@ func (in *LDAPQuery) DeepCopy() *LDAPQuery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberExpiration) DeepCopyInto(out *MemberExpiration) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberExpiration.
+func (in *MemberExpiration) DeepCopy() *MemberExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberRole) DeepCopyInto(out *MemberRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberRole.
+func (in *MemberRole) DeepCopy() *MemberRole {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberSource) DeepCopyInto(out *MemberSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberSource.
+func (in *MemberSource) DeepCopy() *MemberSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Members) DeepCopyInto(out *Members) {
 	*out = *in
@@ -267,6 +506,48 @@ func (in *Members) DeepCopyInto(out *Members) {
 		*out = new(LDAPQuery)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LDAPGroups != nil {
+		in, out := &in.LDAPGroups, &out.LDAPGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]MemberRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.UsersWithRoles != nil {
+		in, out := &in.UsersWithRoles, &out.UsersWithRoles
+		*out = make([]MemberRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expirations != nil {
+		in, out := &in.Expirations, &out.Expirations
+		*out = make([]MemberExpiration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FromConfigMap != nil {
+		in, out := &in.FromConfigMap, &out.FromConfigMap
+		*out = new(MemberSource)
+		**out = **in
+	}
+	if in.FromSecret != nil {
+		in, out := &in.FromSecret, &out.FromSecret
+		*out = new(MemberSource)
+		**out = **in
+	}
+	if in.UserRefs != nil {
+		in, out := &in.UserRefs, &out.UserRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupsPolicy != nil {
+		in, out := &in.GroupsPolicy, &out.GroupsPolicy
+		*out = new(GroupsPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Members.
@@ -278,3 +559,209 @@ func (in *Members) DeepCopy() *Members {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OffboardingPolicy) DeepCopyInto(out *OffboardingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OffboardingPolicy.
+func (in *OffboardingPolicy) DeepCopy() *OffboardingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OffboardingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OffboardingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OffboardingPolicyList) DeepCopyInto(out *OffboardingPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OffboardingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OffboardingPolicyList.
+func (in *OffboardingPolicyList) DeepCopy() *OffboardingPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(OffboardingPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OffboardingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OffboardingPolicySpec) DeepCopyInto(out *OffboardingPolicySpec) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailPatterns != nil {
+		in, out := &in.EmailPatterns, &out.EmailPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OffboardingPolicySpec.
+func (in *OffboardingPolicySpec) DeepCopy() *OffboardingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OffboardingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedUser) DeepCopyInto(out *SkippedUser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedUser.
+func (in *SkippedUser) DeepCopy() *SkippedUser {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *User) DeepCopyInto(out *User) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
+func (in *User) DeepCopy() *User {
+	if in == nil {
+		return nil
+	}
+	out := new(User)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *User) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserList) DeepCopyInto(out *UserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]User, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserList.
+func (in *UserList) DeepCopy() *UserList {
+	if in == nil {
+		return nil
+	}
+	out := new(UserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserSpec) DeepCopyInto(out *UserSpec) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]Backend, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSpec.
+func (in *UserSpec) DeepCopy() *UserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserStatus) DeepCopyInto(out *UserStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackendsStatus != nil {
+		in, out := &in.BackendsStatus, &out.BackendsStatus
+		*out = make([]BackendStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
+func (in *UserStatus) DeepCopy() *UserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,10 +19,12 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -44,6 +46,9 @@ import (
 
 	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
 	"github.com/redhat-data-and-ai/usernaut/internal/controller"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs"
+	webhookv1alpha1 "github.com/redhat-data-and-ai/usernaut/internal/webhook/v1alpha1"
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
@@ -52,6 +57,7 @@ import (
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+	"github.com/redhat-data-and-ai/usernaut/pkg/tracing"
 	"github.com/sirupsen/logrus"
 
 	// +kubebuilder:scaffold:imports
@@ -147,13 +153,47 @@ func main() {
 		watchedNs = "usernaut"
 	}
 
+	appConf, err := config.GetConfig()
+	if err != nil {
+		setupLog.Error(err, "unable to create config")
+		os.Exit(1)
+	}
+
+	// The reconciles are traced over OTLP when enabled, the spans are flushed on shutdown
+	shutdownTracing, err := tracing.Init(context.Background(), appConf.Tracing, appConf.App.Name, appConf.App.Version)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "failed to flush the traces")
+		}
+	}()
+
+	// Each shard reconciles its share of the groups and elects its own leader
+	shard, err := controllerutils.NewShard(appConf.ControllerConfig.Sharding)
+	if err != nil {
+		setupLog.Error(err, "unable to determine the shard of the replica")
+		os.Exit(1)
+	}
+	if shard.Enabled() {
+		setupLog.Info("reconciling the groups of a shard", "shard", shard.Index, "shards", shard.Count)
+	}
+
+	// The manager waits a bit longer than the grace period for the reconciles and tasks to drain
+	shutdownGracePeriod := controllerutils.DurationOrDefault(context.Background(), "shutdownGracePeriod",
+		appConf.ControllerConfig.ShutdownGracePeriod, controllerutils.DefaultShutdownGracePeriod)
+	gracefulShutdownTimeout := shutdownGracePeriod + 10*time.Second
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		Metrics:                metricsServerOptions,
-		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "dd1e5158.operator.dataverse.redhat.com",
+		Scheme:                  scheme,
+		Metrics:                 metricsServerOptions,
+		WebhookServer:           webhookServer,
+		HealthProbeBindAddress:  probeAddr,
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        shard.LeaderElectionID("dd1e5158.operator.dataverse.redhat.com"),
 		Cache: k8sCache.Options{
 			DefaultNamespaces: map[string]k8sCache.Config{
 				watchedNs: {},
@@ -176,12 +216,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	appConf, err := config.GetConfig()
-	if err != nil {
-		setupLog.Error(err, "unable to create config")
-		os.Exit(1)
-	}
-
 	ldapConn, err := ldap.InitLdap(appConf.LDAP)
 	if err != nil {
 		setupLog.Error(err, "failed to initialize LDAP connection")
@@ -194,11 +228,18 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create shared cache mutex to prevent race conditions between GroupReconciler and UserOffboardingJob
-	sharedCacheMutex := &sync.RWMutex{}
+	// Create shared cache mutex to prevent race conditions between GroupReconciler and UserOffboardingJob,
+	// the shards of a sharded deployment share it through redis
+	sharedCacheMutex, err := controllerutils.NewCacheMutex(shard, cache)
+	if err != nil {
+		setupLog.Error(err, "failed to initialize the cache lock")
+		os.Exit(1)
+	}
 
 	// Create store layer that wraps cache with prefixed keys and encapsulated operations
 	dataStore := store.New(cache)
+	// The backends with a response_cache_ttl serve their teams and team members from the store
+	clients.SetResponseStore(dataStore.BackendResponses)
 
 	if err = preloadCache(*appConf, dataStore, sharedCacheMutex); err != nil {
 		setupLog.Error(err, "failed to preload cache")
@@ -211,14 +252,49 @@ func main() {
 		AppConfig:  appConf,
 		Store:      dataStore,
 		LdapConn:   ldapConn,
+		Recorder:   mgr.GetEventRecorderFor("group-controller"),
 		CacheMutex: sharedCacheMutex,
+		Shard:      shard,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Group")
 		os.Exit(1)
 	}
 
-	// Initialize backend clients for the periodic tasks
+	// Templates and users are not split by group, the primary shard reconciles them
+	if shard.Primary() {
+		if err = (&controller.GroupTemplateReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GroupTemplate")
+			os.Exit(1)
+		}
+
+		if err = (&controller.UserReconciler{
+			Client:     mgr.GetClient(),
+			Scheme:     mgr.GetScheme(),
+			AppConfig:  appConf,
+			Store:      dataStore,
+			LdapConn:   ldapConn,
+			CacheMutex: sharedCacheMutex,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "User")
+			os.Exit(1)
+		}
+	}
+
+	// Webhooks need serving certificates (see config/webhook and config/certmanager),
+	// so they are only registered when explicitly enabled for the deployment
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = webhookv1alpha1.SetupGroupWebhookWithManager(mgr, appConf); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Group")
+			os.Exit(1)
+		}
+	}
+
+	// Initialize backend clients for the periodic tasks and the health checks
 	backendClients := make(map[string]clients.Client)
+	healthClients := make(map[config.BackendRef]clients.Client)
 	for _, backend := range appConf.Backends {
 		if backend.Enabled {
 			client, err := clients.New(backend.Name, backend.Type, appConf.BackendMap)
@@ -228,18 +304,33 @@ func main() {
 				os.Exit(1)
 			}
 			backendClients[fmt.Sprintf("%s_%s", backend.Name, backend.Type)] = client
+			healthClients[config.BackendRef{Name: backend.Name, Type: backend.Type}] = client
 		}
 	}
 
-	ptr, err := controller.NewPeriodicTasksReconciler(
-		mgr.GetClient(), sharedCacheMutex, cache, dataStore, ldapConn, backendClients)
-	if err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "PeriodicTasks")
-		os.Exit(1)
-	}
-	if err = ptr.AddToManager(mgr); err != nil {
-		setupLog.Error(err, "unable to add controller to manager", "controller", "PeriodicTasks")
-		os.Exit(1)
+	// Every replica reports the backend health, the primary shard also checks it periodically
+	backendHealthJob := periodicjobs.NewBackendHealthJob(ldapConn, healthClients,
+		controllerutils.DurationOrDefault(context.Background(), "healthCheck.interval",
+			appConf.ControllerConfig.HealthCheck.Interval, periodicjobs.DefaultBackendHealthJobInterval),
+		controllerutils.DurationOrDefault(context.Background(), "healthCheck.timeout",
+			appConf.ControllerConfig.HealthCheck.Timeout, periodicjobs.DefaultBackendHealthCheckTimeout))
+
+	// The periodic tasks offboard users of all the groups, only the primary shard runs them
+	if shard.Primary() {
+		ptr, err := controller.NewPeriodicTasksReconciler(
+			mgr.GetClient(), sharedCacheMutex, cache, dataStore, ldapConn, backendClients, backendHealthJob)
+		if err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PeriodicTasks")
+			os.Exit(1)
+		}
+		ptr.SetShutdownGracePeriod(shutdownGracePeriod)
+		if appConf.LDAP.Watch.Enabled {
+			ptr.EnableLDAPWatch()
+		}
+		if err = ptr.AddToManager(mgr); err != nil {
+			setupLog.Error(err, "unable to add controller to manager", "controller", "PeriodicTasks")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -251,8 +342,14 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if !appConf.ControllerConfig.HealthCheck.IgnoreInReadiness {
+		if err := mgr.AddReadyzCheck("backends", backendHealthJob.ReadyzCheck); err != nil {
+			setupLog.Error(err, "unable to set up backends ready check")
+			os.Exit(1)
+		}
+	}
 
-	apiServer := server.NewAPIServer(appConf, dataStore)
+	apiServer := server.NewAPIServer(appConf, dataStore, backendHealthJob)
 	go func() {
 		if err := apiServer.Start(); err != nil {
 			setupLog.Error(err, "failed to start HTTP API server")
@@ -267,8 +364,8 @@ func main() {
 }
 
 // storeUsersInCache stores users in the cache and returns an error if any user fails to be stored
-func storeUsersInCache(ctx context.Context, users map[string]*structs.User, dataStore *store.Store,
-	cacheMutex *sync.RWMutex, backendKey string, log *logrus.Entry) error {
+func storeUsersInCache(ctx context.Context, users []*structs.User, dataStore *store.Store,
+	cacheMutex cache.Locker, backendKey string, log *logrus.Entry) error {
 	for _, user := range users {
 		cacheMutex.Lock()
 		err := dataStore.User.SetBackend(ctx, user.GetEmail(), backendKey, user.ID)
@@ -281,6 +378,13 @@ func storeUsersInCache(ctx context.Context, users map[string]*structs.User, data
 	return nil
 }
 
+// snowflakeInitialPreloadUsers is the number of Snowflake users stored before the manager starts,
+// the other users are streamed into the cache by the async continuation
+const snowflakeInitialPreloadUsers = 10000
+
+// errInitialPreloadDone stops the initial Snowflake preload, leaving the users to the async continuation
+var errInitialPreloadDone = errors.New("initial snowflake preload done")
+
 // snowflakeAsyncState holds state needed for Snowflake async continuation after preload
 type snowflakeAsyncState struct {
 	client     *snowflake.SnowflakeClient
@@ -294,7 +398,7 @@ type snowflakeAsyncState struct {
 // This is done only once at the start of the application
 // and the cache is flushed when the application is restarted
 // Optimized to use goroutines for parallel processing of backends
-func preloadCache(appConfig config.AppConfig, dataStore *store.Store, cacheMutex *sync.RWMutex) error {
+func preloadCache(appConfig config.AppConfig, dataStore *store.Store, cacheMutex cache.Locker) error {
 	ctx := context.Background()
 
 	// Add request ID for tracking this cache preload operation in logs
@@ -347,66 +451,70 @@ func preloadCache(appConfig config.AppConfig, dataStore *store.Store, cacheMutex
 
 			// Handle Snowflake specially to capture last user for async continuation
 			if backend.Type == "snowflake" {
-				sfClient := backendClient.(*snowflake.SnowflakeClient)
-				users, _, lastUser, err := sfClient.FetchAllUsersWithCursor(ctx)
+				sfClient, ok := clients.As[*snowflake.SnowflakeClient](backendClient)
+				if !ok {
+					return fmt.Errorf("unexpected client %T for snowflake backend %s", backendClient, backend.Name)
+				}
+				lastUser, userCount, err := preloadSnowflakeUsers(ctx, sfClient, dataStore, cacheMutex, backendKey, log)
 				if err != nil {
 					log.WithError(err).Error("failed to fetch users from Snowflake")
 					return err
 				}
 
-				if err := storeUsersInCache(ctx, users, dataStore, cacheMutex, backendKey, log); err != nil {
-					return err
-				}
-
 				// Save state for async continuation (append to slice for multiple Snowflake backends)
-				snowflakeStateMutex.Lock()
-				snowflakeStates = append(snowflakeStates, &snowflakeAsyncState{
-					client:     sfClient,
-					lastUser:   lastUser,
-					backendKey: backendKey,
-				})
-				snowflakeStateMutex.Unlock()
+				if lastUser != "" {
+					snowflakeStateMutex.Lock()
+					snowflakeStates = append(snowflakeStates, &snowflakeAsyncState{
+						client:     sfClient,
+						lastUser:   lastUser,
+						backendKey: backendKey,
+					})
+					snowflakeStateMutex.Unlock()
+				}
 
 				log.WithFields(logrus.Fields{
-					"users":     len(users),
+					"users":     userCount,
 					"last_user": lastUser,
-				}).Info("Snowflake preload complete, will continue async")
+				}).Info("Snowflake preload complete")
 			} else {
-				// Other backends: existing flow
-				users, _, err := backendClient.FetchAllUsers(ctx)
+				// Other backends: the users are stored page by page, so that only a page is held in memory
+				userCount := 0
+				err := clients.ForEachUserPage(ctx, backendClient, func(users []*structs.User) error {
+					userCount += len(users)
+					return storeUsersInCache(ctx, users, dataStore, cacheMutex, backendKey, log)
+				})
 				if err != nil {
 					log.WithError(err).Error("failed to fetch users from backend")
 					return err
 				}
 
-				if err := storeUsersInCache(ctx, users, dataStore, cacheMutex, backendKey, log); err != nil {
-					return err
-				}
-
-				log.WithField("users", len(users)).Info("preloaded users from backend")
+				log.WithField("users", userCount).Info("preloaded users from backend")
 			}
 
 			// Fetch all the teams and store them in the TeamStore cache
 			// Teams are stored by their transformed name (as returned by the backend)
 			// During reconciliation, if a team is not found in GroupStore, it will
 			// fallback to TeamStore and migrate the data to GroupStore
-			teams, err := backendClient.FetchAllTeams(ctx)
+			teamCount := 0
+			err = clients.ForEachTeamPage(ctx, backendClient, func(teams []structs.Team) error {
+				for _, team := range teams {
+					cacheMutex.Lock()
+					err := dataStore.Team.SetBackend(ctx, team.GetName(), backendKey, team.ID)
+					cacheMutex.Unlock()
+					if err != nil {
+						log.WithError(err).Error("failed to store team in cache")
+						return err
+					}
+				}
+				teamCount += len(teams)
+				return nil
+			})
 			if err != nil {
 				log.WithError(err).Error("failed to fetch teams from backend")
 				return err
 			}
 
-			for _, team := range teams {
-				cacheMutex.Lock()
-				err := dataStore.Team.SetBackend(ctx, team.GetName(), backendKey, team.ID)
-				cacheMutex.Unlock()
-				if err != nil {
-					log.WithError(err).Error("failed to store team in cache")
-					return err
-				}
-			}
-
-			log.WithField("teams", len(teams)).Info("successfully preloaded teams from backend")
+			log.WithField("teams", teamCount).Info("successfully preloaded teams from backend")
 
 			return nil
 		})
@@ -421,21 +529,78 @@ func preloadCache(appConfig config.AppConfig, dataStore *store.Store, cacheMutex
 
 	// Start async continuation for all Snowflake backends (after all preloads done)
 	for _, state := range snowflakeStates {
-		if state.lastUser != "" {
-			startSnowflakeAsyncContinuation(ctx, state, dataStore, cacheMutex)
-		}
+		startSnowflakeAsyncContinuation(ctx, state, dataStore, cacheMutex)
 	}
 
 	return nil
 }
 
-// startSnowflakeAsyncContinuation starts a background goroutine to fetch remaining Snowflake users
-// and write them to cache. This function returns immediately after starting the goroutine.
+// preloadSnowflakeUsers streams the first snowflakeInitialPreloadUsers users of the Snowflake
+// backend into the store page by page, resuming from the checkpoint of an interrupted preload. It
+// returns the name of the last user stored when users are left for the async continuation, empty
+// once all the users are stored, and the number of users stored.
+func preloadSnowflakeUsers(ctx context.Context, sfClient *snowflake.SnowflakeClient, dataStore *store.Store,
+	cacheMutex cache.Locker, backendKey string, log *logrus.Entry) (string, int, error) {
+	cacheMutex.RLock()
+	checkpoint, err := dataStore.Preload.GetCheckpoint(ctx, backendKey)
+	cacheMutex.RUnlock()
+	if err != nil {
+		return "", 0, err
+	}
+	if checkpoint != "" {
+		log.WithField("checkpoint", checkpoint).Info("resuming the Snowflake preload from its checkpoint")
+	}
+
+	lastUser := checkpoint
+	userCount := 0
+	err = sfClient.StreamUsers(ctx, checkpoint, func(users []*structs.User, cursor string) error {
+		if err := storeSnowflakePage(ctx, users, cursor, dataStore, cacheMutex, backendKey, log); err != nil {
+			return err
+		}
+		lastUser = cursor
+		userCount += len(users)
+		if userCount >= snowflakeInitialPreloadUsers {
+			return errInitialPreloadDone
+		}
+		return nil
+	})
+	if errors.Is(err, errInitialPreloadDone) {
+		return lastUser, userCount, nil
+	}
+	if err != nil {
+		return "", userCount, err
+	}
+	return "", userCount, deleteSnowflakeCheckpoint(ctx, dataStore, cacheMutex, backendKey)
+}
+
+// storeSnowflakePage stores a page of the users of the Snowflake backend, then checkpoints the
+// preload at the last user of the page
+func storeSnowflakePage(ctx context.Context, users []*structs.User, cursor string, dataStore *store.Store,
+	cacheMutex cache.Locker, backendKey string, log *logrus.Entry) error {
+	if err := storeUsersInCache(ctx, users, dataStore, cacheMutex, backendKey, log); err != nil {
+		return err
+	}
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	return dataStore.Preload.SetCheckpoint(ctx, backendKey, cursor)
+}
+
+// deleteSnowflakeCheckpoint removes the checkpoint of the Snowflake backend once all its users are stored
+func deleteSnowflakeCheckpoint(ctx context.Context, dataStore *store.Store, cacheMutex cache.Locker,
+	backendKey string) error {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	return dataStore.Preload.DeleteCheckpoint(ctx, backendKey)
+}
+
+// startSnowflakeAsyncContinuation starts a background goroutine streaming the remaining Snowflake
+// users into the cache, checkpointing each page. This function returns immediately after starting
+// the goroutine.
 func startSnowflakeAsyncContinuation(
 	originalCtx context.Context,
 	state *snowflakeAsyncState,
 	dataStore *store.Store,
-	cacheMutex *sync.RWMutex,
+	cacheMutex cache.Locker,
 ) {
 	// Create a fresh context since the errgroup context is canceled after g.Wait() returns
 	// Transfer the logger (with request ID) from original context for traceability
@@ -443,42 +608,33 @@ func startSnowflakeAsyncContinuation(
 	if entry := logger.Logger(originalCtx); entry != nil {
 		asyncCtx = context.WithValue(asyncCtx, logger.RequestIdKey, entry)
 	}
-	userChan, errChan := state.client.FetchRemainingUsersAsync(asyncCtx, state.lastUser)
 
-	// Consumer goroutine - writes to cache
 	go func() {
 		log := logger.Logger(asyncCtx).WithFields(logrus.Fields{
 			"component": "snowflake-async",
 			"backend":   state.backendKey,
 		})
 		count := 0
-		failedCount := 0
-
-		for user := range userChan {
-			cacheMutex.Lock()
-			err := dataStore.User.SetBackend(asyncCtx, user.GetEmail(), state.backendKey, user.ID)
-			cacheMutex.Unlock()
-
-			if err != nil {
-				failedCount++
-				log.WithError(err).WithField("email", user.GetEmail()).Warn("failed to store user in cache")
-				continue
-			}
-			count++
 
-			if count%1000 == 0 {
-				log.WithField("users_loaded", count).Info("Snowflake async progress")
+		err := state.client.StreamUsers(asyncCtx, state.lastUser, func(users []*structs.User, cursor string) error {
+			if err := storeSnowflakePage(asyncCtx, users, cursor, dataStore, cacheMutex, state.backendKey, log); err != nil {
+				return err
 			}
-		}
-
-		if err := <-errChan; err != nil {
-			log.WithError(err).Error("Snowflake async fetch failed")
-		} else {
+			count += len(users)
 			log.WithFields(logrus.Fields{
-				"users_cached":       count,
-				"users_failed_cache": failedCount,
-			}).Info("Snowflake async fetch complete")
+				"users_loaded": count,
+				"checkpoint":   cursor,
+			}).Info("Snowflake async progress")
+			return nil
+		})
+		if err == nil {
+			err = deleteSnowflakeCheckpoint(asyncCtx, dataStore, cacheMutex, state.backendKey)
+		}
+		if err != nil {
+			log.WithError(err).WithField("users_cached", count).Error("Snowflake async fetch failed")
+			return
 		}
+		log.WithField("users_cached", count).Info("Snowflake async fetch complete")
 	}()
 
 	setupLog.Info("Snowflake async continuation started")
@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/snowflake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+)
+
+const testSnowflakeBackend = "snowflake_snowflake"
+
+// fakeSnowflakeUsers lists the users of a Snowflake account in pages of pageSize users, linked from
+// each page to the next one like the users endpoint does
+type fakeSnowflakeUsers struct {
+	mu sync.Mutex
+	// names are the names of the users, in order
+	names    []string
+	pageSize int
+	// failRequest is the listing request the account fails, counted from 1, 0 never fails
+	failRequest int
+
+	requests []string
+	results  map[string][]string
+}
+
+func (f *fakeSnowflakeUsers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.URL.RequestURI())
+	if len(f.requests) == f.failRequest {
+		fakehttp.WriteJSON(w, http.StatusBadRequest, map[string]string{"message": "Result not found"})
+		return
+	}
+
+	var listed []string
+	if resultID, ok := strings.CutPrefix(r.URL.Path, "/api/v2/results/"); ok {
+		listed = f.results[resultID]
+	} else {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("showLimit"))
+		for _, name := range f.names {
+			if name > r.URL.Query().Get("fromName") && len(listed) < limit {
+				listed = append(listed, name)
+			}
+		}
+	}
+	if len(listed) > f.pageSize {
+		if f.results == nil {
+			f.results = map[string][]string{}
+		}
+		resultID := strconv.Itoa(len(f.requests))
+		f.results[resultID] = listed[f.pageSize:]
+		w.Header().Set("Link", fmt.Sprintf(`</api/v2/results/%s>; rel="next"`, resultID))
+		listed = listed[:f.pageSize]
+	}
+
+	users := make([]snowflake.SnowflakeUser, 0, len(listed))
+	for _, name := range listed {
+		users = append(users, snowflake.SnowflakeUser{Name: name, Email: strings.ToLower(name) + "@example.com"})
+	}
+	fakehttp.WriteJSON(w, http.StatusOK, users)
+}
+
+// newFakeSnowflakeUsers returns an account of count users named USER_00000, USER_00001...
+func newFakeSnowflakeUsers(count, pageSize int) *fakeSnowflakeUsers {
+	fake := &fakeSnowflakeUsers{pageSize: pageSize}
+	for i := range count {
+		fake.names = append(fake.names, fmt.Sprintf("USER_%05d", i))
+	}
+	return fake
+}
+
+func newPreloadTest(t *testing.T, fake *fakeSnowflakeUsers) (*snowflake.SnowflakeClient, *store.Store) {
+	t.Helper()
+	server := fakehttp.NewServer(t, fake)
+	client, err := snowflake.NewClient(map[string]interface{}{"base_url": server.URL, "pat": "pat"},
+		fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	c, err := inmemory.NewCache(&inmemory.Config{DefaultExpiration: 300, CleanupInterval: 600})
+	require.NoError(t, err)
+	return client, store.New(c)
+}
+
+// storedUsers returns the users of the account stored with their backend ID, in order
+func storedUsers(t *testing.T, dataStore *store.Store, fake *fakeSnowflakeUsers) []string {
+	t.Helper()
+	var stored []string
+	for _, name := range fake.names {
+		backends, err := dataStore.User.GetBackends(context.Background(), strings.ToLower(name)+"@example.com")
+		require.NoError(t, err)
+		if backends[testSnowflakeBackend] == strings.ToLower(name) {
+			stored = append(stored, name)
+		}
+	}
+	return stored
+}
+
+func checkpoint(t *testing.T, dataStore *store.Store) string {
+	t.Helper()
+	cursor, err := dataStore.Preload.GetCheckpoint(context.Background(), testSnowflakeBackend)
+	require.NoError(t, err)
+	return cursor
+}
+
+func TestPreloadSnowflakeUsers(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.New())
+
+	t.Run("stores all the pages and removes the checkpoint", func(t *testing.T) {
+		fake := newFakeSnowflakeUsers(5, 2)
+		client, dataStore := newPreloadTest(t, fake)
+
+		lastUser, count, err := preloadSnowflakeUsers(ctx, client, dataStore, &sync.RWMutex{},
+			testSnowflakeBackend, log)
+		require.NoError(t, err)
+		assert.Empty(t, lastUser, "no user is left for the async continuation")
+		assert.Equal(t, 5, count)
+		assert.Equal(t, fake.names, storedUsers(t, dataStore, fake))
+		assert.Empty(t, checkpoint(t, dataStore))
+		assert.Len(t, fake.requests, 3)
+	})
+
+	t.Run("keeps the pages stored before the interruption and resumes from the checkpoint", func(t *testing.T) {
+		fake := newFakeSnowflakeUsers(5, 2)
+		fake.failRequest = 2
+		client, dataStore := newPreloadTest(t, fake)
+		cacheMutex := &sync.RWMutex{}
+
+		_, count, err := preloadSnowflakeUsers(ctx, client, dataStore, cacheMutex, testSnowflakeBackend, log)
+		assert.ErrorContains(t, err, "unexpected status during pagination")
+		assert.Equal(t, 2, count)
+		assert.Equal(t, []string{"USER_00000", "USER_00001"}, storedUsers(t, dataStore, fake))
+		assert.Equal(t, "USER_00001", checkpoint(t, dataStore))
+
+		fake.requests, fake.failRequest = nil, 0
+		lastUser, count, err := preloadSnowflakeUsers(ctx, client, dataStore, cacheMutex,
+			testSnowflakeBackend, log)
+		require.NoError(t, err)
+		assert.Empty(t, lastUser)
+		assert.Equal(t, 3, count, "only the users after the checkpoint are fetched again")
+		assert.Equal(t, "/api/v2/users?showLimit=10000&fromName=USER_00001", fake.requests[0])
+		assert.Equal(t, fake.names, storedUsers(t, dataStore, fake))
+		assert.Empty(t, checkpoint(t, dataStore))
+	})
+
+	t.Run("leaves the users past the initial preload to the async continuation", func(t *testing.T) {
+		fake := newFakeSnowflakeUsers(snowflakeInitialPreloadUsers+3, 2500)
+		client, dataStore := newPreloadTest(t, fake)
+		cacheMutex := &sync.RWMutex{}
+
+		lastUser, count, err := preloadSnowflakeUsers(ctx, client, dataStore, cacheMutex,
+			testSnowflakeBackend, log)
+		require.NoError(t, err)
+		assert.Equal(t, "USER_09999", lastUser)
+		assert.Equal(t, snowflakeInitialPreloadUsers, count)
+		assert.Equal(t, "USER_09999", checkpoint(t, dataStore))
+		assert.Equal(t, fake.names[:snowflakeInitialPreloadUsers], storedUsers(t, dataStore, fake))
+
+		startSnowflakeAsyncContinuation(ctx, &snowflakeAsyncState{
+			client: client, lastUser: lastUser, backendKey: testSnowflakeBackend,
+		}, dataStore, cacheMutex)
+		assert.Eventually(t, func() bool {
+			cacheMutex.RLock()
+			defer cacheMutex.RUnlock()
+			return checkpoint(t, dataStore) == ""
+		}, 5*time.Second, 10*time.Millisecond)
+		cacheMutex.RLock()
+		defer cacheMutex.RUnlock()
+		assert.Equal(t, fake.names, storedUsers(t, dataStore, fake))
+	})
+}
@@ -0,0 +1,41 @@
+package controllerutils
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ApprovalPredicate triggers a reconcile when the approved-by annotation is added to a group
+// awaiting an approval
+func ApprovalPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			_, oldExists := e.ObjectOld.GetAnnotations()[constants.ApprovedByAnnotation]
+			_, newExists := e.ObjectNew.GetAnnotations()[constants.ApprovedByAnnotation]
+			return !oldExists && newExists
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// RemoveApprovedByAnnotation removes the approved-by annotation once the approval was consumed,
+// so that the next change needing an approval is approved again
+func RemoveApprovedByAnnotation(ctx context.Context, c client.Client, obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[constants.ApprovedByAnnotation]; !ok {
+		return nil
+	}
+
+	delete(annotations, constants.ApprovedByAnnotation)
+	obj.SetAnnotations(annotations)
+	return c.Update(ctx, obj)
+}
@@ -0,0 +1,91 @@
+package controllerutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// ExistingUsers looks up the users a backend reports as already existing. The users of the backend
+// are fetched on the first lookup only, and shared by the following lookups, so that a backend
+// reconcile creating many users which already exist fetches them once instead of once per user.
+type ExistingUsers struct {
+	backendClient clients.Client
+
+	once         sync.Once
+	usersByID    map[string]*structs.User
+	usersByEmail map[string]*structs.User
+	err          error
+}
+
+// NewExistingUsers returns the lookup of the existing users of the backend
+func NewExistingUsers(backendClient clients.Client) *ExistingUsers {
+	return &ExistingUsers{backendClient: backendClient}
+}
+
+// find returns the existing user matching the user, or nil when there is none
+func (e *ExistingUsers) find(ctx context.Context, user *structs.User) (*structs.User, error) {
+	e.once.Do(func() {
+		e.usersByID, e.usersByEmail, e.err = e.backendClient.FetchAllUsers(ctx)
+	})
+	if e.err != nil {
+		return nil, e.err
+	}
+	return findUser(e.usersByID, e.usersByEmail, user), nil
+}
+
+// CreateOrFindUser creates the user in the backend. When the backend reports that the user already
+// exists, e.g. because the cache lost its ID or the user was created out of band, the existing user
+// with the same email is looked up in existingUsers, so that its ID repopulates the cache instead of
+// failing the backend.
+func CreateOrFindUser(ctx context.Context, backendClient clients.Client, existingUsers *ExistingUsers,
+	user *structs.User) (*structs.User, error) {
+	created, err := backendClient.CreateUser(ctx, user)
+	if err == nil || !errors.Is(err, structs.ErrUserAlreadyExists) {
+		return created, err
+	}
+
+	log := logger.Logger(ctx).WithField("email", user.Email).WithField("username", user.UserName)
+	log.WithError(err).Warn("user already exists in the backend, looking up the existing user")
+
+	existing, fetchErr := existingUsers.find(ctx, user)
+	if fetchErr != nil {
+		return nil, fmt.Errorf("%w, and the existing users could not be fetched: %w", err, fetchErr)
+	}
+	if existing == nil || existing.ID == "" {
+		return nil, fmt.Errorf("%w, but no user with email %s was found", err, user.Email)
+	}
+	log.WithField("user_id", existing.ID).Info("found the existing user in the backend")
+	return existing, nil
+}
+
+// findUser returns the user matching the email of the user, ignoring the case. A user matching its
+// username is only returned when its email matches too, so that a user of the backend sharing the
+// username of another person is never adopted.
+func findUser(usersByID, usersByEmail map[string]*structs.User, user *structs.User) *structs.User {
+	if user.Email == "" {
+		return nil
+	}
+	if existing, ok := usersByEmail[user.Email]; ok {
+		return existing
+	}
+	for email, existing := range usersByEmail {
+		if strings.EqualFold(email, user.Email) {
+			return existing
+		}
+	}
+	if user.UserName != "" {
+		for _, existing := range usersByID {
+			if strings.EqualFold(existing.UserName, user.UserName) && strings.EqualFold(existing.Email, user.Email) {
+				return existing
+			}
+		}
+	}
+	return nil
+}
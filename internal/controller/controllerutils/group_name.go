@@ -0,0 +1,43 @@
+package controllerutils
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+)
+
+// GroupNameIndexField indexes the Group CRs by spec.group_name. The field is also a selectable
+// field of the CRD, so the field selector works with uncached readers as well.
+const GroupNameIndexField = "spec.group_name"
+
+// IndexGroupName is the index function of GroupNameIndexField
+func IndexGroupName(obj client.Object) []string {
+	group := obj.(*usernautdevv1alpha1.Group)
+	if group.Spec.GroupName == "" {
+		return nil
+	}
+	return []string{group.Spec.GroupName}
+}
+
+// GroupsWithSameName lists the other Group CRs of the namespace of the group with the same group name,
+// groups being deleted no longer manage their group name and are ignored
+func GroupsWithSameName(ctx context.Context, reader client.Reader,
+	group *usernautdevv1alpha1.Group) ([]usernautdevv1alpha1.Group, error) {
+	if group.Spec.GroupName == "" {
+		return nil, nil
+	}
+	groups := &usernautdevv1alpha1.GroupList{}
+	if err := reader.List(ctx, groups, client.InNamespace(group.Namespace),
+		client.MatchingFields{GroupNameIndexField: group.Spec.GroupName}); err != nil {
+		return nil, err
+	}
+	others := make([]usernautdevv1alpha1.Group, 0, len(groups.Items))
+	for _, other := range groups.Items {
+		if other.Name != group.Name && other.GetDeletionTimestamp() == nil {
+			others = append(others, other)
+		}
+	}
+	return others, nil
+}
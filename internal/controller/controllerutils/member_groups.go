@@ -0,0 +1,47 @@
+package controllerutils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+)
+
+// MemberGroupsIndexField indexes the Group CRs by the member groups of spec.members.groups
+const MemberGroupsIndexField = "spec.members.groups"
+
+// IndexMemberGroups is the index function of MemberGroupsIndexField
+func IndexMemberGroups(obj client.Object) []string {
+	return obj.(*usernautdevv1alpha1.Group).Spec.Members.Groups
+}
+
+// AncestorGroups returns the Group CRs of the namespace which include the group, directly or through
+// other member groups, so that a membership change anywhere in a hierarchy reaches all the groups above.
+// Cyclic references are only visited once.
+func AncestorGroups(ctx context.Context, reader client.Reader, namespace, name string) ([]types.NamespacedName, error) {
+	visited := map[string]struct{}{name: {}}
+	pending := []string{name}
+	ancestors := make([]types.NamespacedName, 0)
+
+	for len(pending) > 0 {
+		member := pending[0]
+		pending = pending[1:]
+
+		referencingGroups := &usernautdevv1alpha1.GroupList{}
+		if err := reader.List(ctx, referencingGroups, client.InNamespace(namespace),
+			client.MatchingFields{MemberGroupsIndexField: member}); err != nil {
+			return nil, err
+		}
+		for _, referencingGroup := range referencingGroups.Items {
+			if _, ok := visited[referencingGroup.Name]; ok {
+				continue
+			}
+			visited[referencingGroup.Name] = struct{}{}
+			pending = append(pending, referencingGroup.Name)
+			ancestors = append(ancestors, types.NamespacedName{Name: referencingGroup.Name, Namespace: namespace})
+		}
+	}
+	return ancestors, nil
+}
@@ -0,0 +1,29 @@
+package controllerutils
+
+import (
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// DeletionPrevented reports whether the prevent-deletion annotation of the object is "true"
+func DeletionPrevented(obj client.Object) bool {
+	return obj.GetAnnotations()[constants.PreventDeletionAnnotation] == "true"
+}
+
+// PreventDeletionRemovedPredicate triggers a reconcile when a protected object stops being protected,
+// so that a pending deletion proceeds
+func PreventDeletionRemovedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return DeletionPrevented(e.ObjectOld) && !DeletionPrevented(e.ObjectNew)
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
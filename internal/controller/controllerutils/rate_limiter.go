@@ -0,0 +1,57 @@
+package controllerutils
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// defaults of the controller-runtime workqueue rate limiter
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+	defaultRateLimiterQPS       = 10
+	defaultRateLimiterBurst     = 100
+)
+
+// NewRateLimiter returns the workqueue rate limiter of a controller: a per-item exponential
+// backoff between the base and max delay of failed requests, bounded by an overall token bucket.
+// Unset or invalid values fall back to the controller-runtime defaults.
+func NewRateLimiter(ctx context.Context, cfg config.RateLimiterConfig) workqueue.TypedRateLimiter[reconcile.Request] {
+	baseDelay := DurationOrDefault(ctx, "rateLimiter.baseDelay", cfg.BaseDelay, defaultRateLimiterBaseDelay)
+	maxDelay := DurationOrDefault(ctx, "rateLimiter.maxDelay", cfg.MaxDelay, defaultRateLimiterMaxDelay)
+	qps := cfg.QPS
+	if qps <= 0 {
+		qps = defaultRateLimiterQPS
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = defaultRateLimiterBurst
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](baseDelay, maxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
+
+// DurationOrDefault parses a duration of the controller configuration like "30s",
+// it returns the default when the value is unset, invalid or not positive
+func DurationOrDefault(ctx context.Context, field, value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logger.Logger(ctx).WithField("field", field).WithField("value", value).
+			Warn("invalid controller configuration duration, falling back to default")
+		return defaultValue
+	}
+	return duration
+}
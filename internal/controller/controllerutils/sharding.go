@@ -0,0 +1,129 @@
+package controllerutils
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/redis"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+const (
+	// ShardIndexEnv is the environment variable holding the shard of the replica, it defaults to the
+	// ordinal of the StatefulSet pod read from the hostname
+	ShardIndexEnv = "SHARD_INDEX"
+
+	// cacheLockKey is the redis key of the cache lock shared by the shards
+	cacheLockKey = "usernaut:cache-lock"
+	// cacheLockTTL is the lease of the cache lock, renewed while a shard holds it
+	cacheLockTTL = 30 * time.Second
+)
+
+// ErrCacheLockLost is returned by the work whose cache writes may have interleaved with another shard's,
+// because the lease of the cache lock expired while held
+var ErrCacheLockLost = errors.New("the lease of the cache lock expired while held")
+
+// Shard is the share of the groups reconciled by a replica. The zero value owns all the groups.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// NewShard returns the shard of the replica for the sharding config
+func NewShard(cfg config.ShardingConfig) (Shard, error) {
+	if cfg.Shards <= 1 {
+		return Shard{}, nil
+	}
+
+	value := os.Getenv(ShardIndexEnv)
+	if value == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return Shard{}, fmt.Errorf("error reading the hostname for the shard index: %w", err)
+		}
+		value = hostname[strings.LastIndex(hostname, "-")+1:]
+	}
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return Shard{}, fmt.Errorf("invalid shard index %q, set %s or run as a StatefulSet", value, ShardIndexEnv)
+	}
+	if index < 0 || index >= cfg.Shards {
+		return Shard{}, fmt.Errorf("shard index %d out of range for %d shards", index, cfg.Shards)
+	}
+	return Shard{Index: index, Count: cfg.Shards}, nil
+}
+
+// Enabled reports whether the groups are split between several shards
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Primary reports whether the shard runs the controllers and jobs which are not split by group
+func (s Shard) Primary() bool {
+	return s.Index == 0
+}
+
+// OwnsGroup reports whether the group is reconciled by the shard. Groups are assigned by their
+// group name, so that the groups sharing a group name are reconciled by the same shard.
+func (s Shard) OwnsGroup(group *usernautdevv1alpha1.Group) bool {
+	if !s.Enabled() {
+		return true
+	}
+	name := group.Spec.GroupName
+	if name == "" {
+		name = group.Name
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return int(hash.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// LeaderElectionID returns the leader election ID of the shard, the replicas of a shard elect
+// their own leader
+func (s Shard) LeaderElectionID(id string) string {
+	if !s.Enabled() {
+		return id
+	}
+	return fmt.Sprintf("shard-%d.%s", s.Index, id)
+}
+
+// ShardPredicate filters the events of the groups owned by other shards
+func ShardPredicate(shard Shard) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		group, ok := obj.(*usernautdevv1alpha1.Group)
+		return !ok || shard.OwnsGroup(group)
+	})
+}
+
+// NewCacheMutex returns the lock of the cache entries shared by the controllers and jobs. The
+// shards share the cache, so they lock it in redis, a single replica locks it in the process.
+func NewCacheMutex(shard Shard, c cache.Cache) (cache.Locker, error) {
+	if !shard.Enabled() {
+		return &sync.RWMutex{}, nil
+	}
+	redisCache, ok := c.(*redis.RedisCache)
+	if !ok {
+		return nil, fmt.Errorf("sharding requires the %s cache driver", cache.DriverRedis)
+	}
+	return redisCache.NewMutex(cacheLockKey, cacheLockTTL), nil
+}
+
+// LostCacheLeases returns how many leases of the cache lock expired while held, a lock local to the
+// process has no lease to lose
+func LostCacheLeases(locker cache.Locker) uint64 {
+	if leaseLocker, ok := locker.(cache.LeaseLocker); ok {
+		return leaseLocker.LostLeases()
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package controllerutils
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultShutdownGracePeriod is how long in-flight work may run after the operator is asked to stop
+const DefaultShutdownGracePeriod = 30 * time.Second
+
+// ErrShuttingDown is returned for the work left to the next run because the operator is stopping
+var ErrShuttingDown = errors.New("operator is shutting down")
+
+type shutdownKey struct{}
+
+// DrainContext returns a context which outlives the cancellation of ctx by the grace period, so that
+// in-flight work is not interrupted mid-way when the operator stops. The work checks ShuttingDown at
+// its checkpoints and stops there. The returned context must be canceled once the work is done.
+func DrainContext(ctx context.Context, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	drainCtx, cancel := context.WithCancel(context.WithValue(context.WithoutCancel(ctx), shutdownKey{}, ctx.Done()))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-drainCtx.Done():
+		}
+	})
+	return drainCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// ShuttingDown reports whether the operator asked the work of the context to stop
+func ShuttingDown(ctx context.Context) bool {
+	done, ok := ctx.Value(shutdownKey{}).(<-chan struct{})
+	if !ok {
+		return ctx.Err() != nil
+	}
+	select {
+	case <-done:
+		return true
+	default:
+		return ctx.Err() != nil
+	}
+}
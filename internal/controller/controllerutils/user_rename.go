@@ -0,0 +1,40 @@
+package controllerutils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+)
+
+// MigrateRenamedUser detects the users whose email changed in LDAP by their uid and migrates their
+// cache entries to the new email, otherwise the backend IDs stay under the old email and the
+// offboarding job removes the user from the backends. The email of the uid is recorded for the
+// next reconciles. It reports whether the user was renamed.
+// NOTE: Caller must hold CacheMutex lock
+func MigrateRenamedUser(ctx context.Context, dataStore *store.Store, uid, email string) (bool, error) {
+	if uid == "" || email == "" {
+		return false, nil
+	}
+
+	previousEmail, err := dataStore.UserUID.GetEmail(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	renamed := previousEmail != "" && previousEmail != email
+	if renamed {
+		logger.Logger(ctx).WithField("uid", uid).WithField("previous_email", previousEmail).
+			WithField("email", email).Info("user email changed in LDAP, migrating the cache entries")
+		if err := dataStore.RenameUser(ctx, previousEmail, email); err != nil {
+			return false, fmt.Errorf("failed to migrate user %s from %s to %s: %w", uid, previousEmail, email, err)
+		}
+	}
+
+	if previousEmail != email {
+		if err := dataStore.UserUID.SetEmail(ctx, uid, email); err != nil {
+			return renamed, err
+		}
+	}
+	return renamed, nil
+}
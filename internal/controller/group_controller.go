@@ -17,86 +17,179 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"slices"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
 	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fivetran"
-	"github.com/redhat-data-and-ai/usernaut/pkg/clients/gitlab"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/github"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+	"github.com/redhat-data-and-ai/usernaut/pkg/tracing"
 	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	groupFinalizer = "operator.dataverse.redhat.com/finalizer"
 
 	// requeueAfter is the duration after which the group controller will requeue the group for reconciliation
-	// this takes care of updating users in ldap query based groups
+	// when controllerConfig.resyncInterval is not set. This takes care of updating users in ldap query
+	// based groups and of correcting drift in the backend teams
 	requeueAfter = 8 * time.Hour
+
+	// defaultMaxConcurrentBackends is the number of backends of a group processed in parallel
+	// when controllerConfig.maxConcurrentBackends is not set
+	defaultMaxConcurrentBackends = 5
+
+	// backendRetryBaseDelay is the delay before the first retry of a failed backend when
+	// controllerConfig.backendRetryBaseDelay is not set, doubled on each consecutive failure
+	// up to backendRetryMaxDelay
+	backendRetryBaseDelay = 30 * time.Second
+	backendRetryMaxDelay  = time.Hour
+
+	// maxDriftMembers is the maximum number of members listed per drift report in the backend status
+	maxDriftMembers = 100
+
+	// maxSkippedUsers is the maximum number of skipped users listed in the group status
+	maxSkippedUsers = 100
+)
+
+var (
+	// backendOwnerRoles is the role granted to the owners of a group on the backend types with a team
+	// owner role. The Fivetran administrator role is account wide, so Fivetran owners are regular members.
+	backendOwnerRoles = map[string]string{
+		"gitlab": "owner",
+	}
+	// backendDefaultRoles is the role of the team members without an explicit role
+	backendDefaultRoles = map[string]string{
+		"gitlab":   "developer",
+		"github":   github.RoleMember,
+		"fivetran": fivetran.AccountReviewerRole,
+	}
+	// backendDefaultAccountRoles is the role of the users and teams created on the backend types
+	// assigning one at creation, when the backend config sets none
+	backendDefaultAccountRoles = map[string]string{
+		"fivetran": fivetran.AccountReviewerRole,
+	}
+)
+
+// Reasons of the events recorded on Group CRs
+const (
+	eventReasonTeamCreated        = "TeamCreated"
+	eventReasonUsersAdded         = "UsersAdded"
+	eventReasonUsersRemoved       = "UsersRemoved"
+	eventReasonMemberRolesUpdated = "MemberRolesUpdated"
+	eventReasonBackendFailed      = "BackendReconcileFailed"
+	eventReasonTeamDeleted        = "TeamDeleted"
+	eventReasonTeamDeleteFailed   = "TeamDeletionFailed"
+	eventReasonTeamRetained       = "TeamRetained"
+	eventReasonDuplicateGroupName = "DuplicateGroupName"
+	eventReasonTeamAdopted        = "TeamAdopted"
+	eventReasonAwaitingApproval   = "AwaitingApproval"
+	eventReasonApproved           = "Approved"
+	eventReasonDeletionPrevented  = "DeletionPrevented"
 )
 
 // GroupReconciler reconciles a Group object
 type GroupReconciler struct {
 	client.Client
-	Scheme          *runtime.Scheme
-	AppConfig       *config.AppConfig
-	Store           *store.Store
-	log             *logrus.Entry
-	backendLogger   *logrus.Entry
-	LdapConn        ldap.LDAPClient
-	allLdapUserData map[string]*structs.LDAPUser
+	Scheme    *runtime.Scheme
+	AppConfig *config.AppConfig
+	Store     *store.Store
+	LdapConn  ldap.LDAPClient
+	Recorder  record.EventRecorder
 
 	// CacheMutex prevents concurrent access to the cache during group reconciliation.
 	// This shared mutex ensures that the group controller and user offboarding job don't interfere
 	// with each other when reading or modifying user/team data in Redis.
-	// This mutex is shared across components and passed from main.go.
-	CacheMutex *sync.RWMutex
+	// This mutex is shared across components and passed from main.go, the shards of a sharded deployment
+	// share it through Redis. It is held around the cache reads and writes only, never across the LDAP
+	// and backend requests, so the reconciles of other groups and shards don't wait for them. Store
+	// setters read-modify-write a single key (e.g. a user's backend map), the backends processed in
+	// parallel write under the lock so that two of them updating the same user don't lose an update.
+	CacheMutex cache.Locker
+
+	// Shard is the share of the groups reconciled by this replica, the zero value reconciles all the groups
+	Shard controllerutils.Shard
 }
 
 //nolint:lll
 // +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=groups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=groups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=groups/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",namespace=usernaut,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=usernaut,resources=configmaps;secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=users,verbs=get;list;watch
+
+func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	// The LDAP searches, cache operations and backend requests of the reconcile share its trace
+	ctx, span := tracing.Start(ctx, "Group.Reconcile",
+		attribute.String("group", req.NamespacedName.String()),
+		attribute.String("reconcile_id", string(controller.ReconcileIDFromContext(ctx))))
+	defer func() { tracing.End(span, err) }()
 
-func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	ctx = logger.WithRequestId(ctx, controller.ReconcileIDFromContext(ctx))
-	r.log = logger.Logger(ctx).WithFields(logrus.Fields{
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
 		"request": req.NamespacedName.String(),
 	})
+	ctx = logger.WithLogger(ctx, log)
+
+	// A reconcile in flight when the operator stops runs to its next checkpoint, instead of
+	// leaving the backends and the cache half updated
+	ctx, cancel := controllerutils.DrainContext(ctx, r.shutdownGracePeriod(ctx))
+	defer cancel()
 
 	groupCR := &usernautdevv1alpha1.Group{}
 
 	if err := r.Get(ctx, req.NamespacedName, groupCR); err != nil {
-		r.log.WithError(err).Error("Unable to fetch Group CR")
+		log.WithError(err).Error("Unable to fetch Group CR")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Groups enqueued through the watches of other groups may belong to another shard
+	if !r.Shard.OwnsGroup(groupCR) {
+		log.Debug("group is owned by another shard, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	if groupCR.GetDeletionTimestamp() != nil {
 		return ctrl.Result{}, r.handleDeletion(ctx, groupCR)
 	}
@@ -109,31 +202,90 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 
+	// Suspended groups are left untouched until they are resumed
+	if groupCR.Spec.Suspend {
+		log.Info("group reconciliation is suspended, skipping")
+		r.setSuspendedCondition(groupCR, true)
+		if err := r.Status().Update(ctx, groupCR); err != nil {
+			log.WithError(err).Error("error updating the status of the suspended group")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(groupCR.Status.Conditions, usernautdevv1alpha1.GroupSuspendedCondition) {
+		// persisted below together with the waiting status
+		r.setSuspendedCondition(groupCR, false)
+	}
+
 	// set owner reference to the group CR
 	if err := r.setOwnerReference(ctx, groupCR); err != nil {
-		r.log.WithError(err).Error("error setting owner reference")
+		log.WithError(err).Error("error setting owner reference")
 		return ctrl.Result{}, err
 	}
 
 	// set the group status as waiting
 	groupCR.SetWaiting()
 	if err := r.Status().Update(ctx, groupCR); err != nil {
-		r.log.WithError(err).Error("error updating the status")
+		log.WithError(err).Error("error updating the status")
 		return ctrl.Result{}, err
 	}
 
-	r.log = logger.Logger(ctx).WithFields(logrus.Fields{
-		"request":        req.NamespacedName.String(),
+	log = log.WithFields(logrus.Fields{
 		"group":          groupCR.Spec.GroupName,
 		"has_ldap_query": groupCR.Spec.Members.LDAPQuery != nil,
-		"members":        len(groupCR.Spec.Members.Users),
+		"members":        len(groupCR.Spec.Members.AllUsers()),
 		"groups":         groupCR.Spec.Members.Groups,
 	})
+	ctx = logger.WithLogger(ctx, log)
+
+	// Groups referencing a template are reconciled once the template controller expanded it
+	if groupCR.Spec.TemplateRef != "" && len(groupCR.Spec.Backends) == 0 {
+		log.WithField("template", groupCR.Spec.TemplateRef).Info("waiting for the group template to be expanded")
+		r.setCondition(&groupCR.Status.Conditions, metav1.Condition{
+			Type:               usernautdevv1alpha1.GroupReadyCondition,
+			LastTransitionTime: metav1.Now(),
+			Status:             metav1.ConditionFalse,
+			Message:            fmt.Sprintf("Waiting for GroupTemplate %s to be expanded", groupCR.Spec.TemplateRef),
+			Reason:             "TemplatePending",
+			ObservedGeneration: groupCR.Generation,
+		})
+		if err := r.Status().Update(ctx, groupCR); err != nil {
+			log.WithError(err).Error("error updating group status for a pending template")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Another Group CR managing the same group name would fight over the same backend teams
+	owner, err := r.groupNameOwner(ctx, groupCR)
+	if err != nil {
+		log.WithError(err).Error("error listing the groups with the same group name")
+		return ctrl.Result{}, err
+	}
+	if owner != "" {
+		log.WithField("owner", owner).Warn("group name is already managed by another group, skipping")
+		r.Recorder.Eventf(groupCR, corev1.EventTypeWarning, eventReasonDuplicateGroupName,
+			"Group name %s is already managed by Group %s", groupCR.Spec.GroupName, owner)
+		r.setCondition(&groupCR.Status.Conditions, metav1.Condition{
+			Type:               usernautdevv1alpha1.GroupReadyCondition,
+			LastTransitionTime: metav1.Now(),
+			Status:             metav1.ConditionFalse,
+			Message:            fmt.Sprintf("Group name %s is already managed by Group %s", groupCR.Spec.GroupName, owner),
+			Reason:             usernautdevv1alpha1.DuplicateGroupNameReason,
+			ObservedGeneration: groupCR.Generation,
+		})
+		if err := r.Status().Update(ctx, groupCR); err != nil {
+			log.WithError(err).Error("error updating group status for a duplicate group name")
+			return ctrl.Result{}, err
+		}
+		// The group is requeued when the owner is deleted, the resync catches a renamed owner
+		return ctrl.Result{RequeueAfter: r.resyncInterval(ctx)}, nil
+	}
 
 	// Check if the group is configurable (has matching patterns for its backends)
 	isConfigurable := r.isGroupConfigurable(groupCR)
 	if !isConfigurable {
-		r.log.Warn("group is not configurable - no matching patterns found for backends")
+		log.Warn("group is not configurable - no matching patterns found for backends")
 		// Mark as non-configurable in status
 		groupCR.Status.ReconciledUsers = []string{}
 		condition := metav1.Condition{
@@ -146,57 +298,137 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 		r.setCondition(&groupCR.Status.Conditions, condition)
 		if err := r.Status().Update(ctx, groupCR); err != nil {
-			r.log.WithError(err).Error("error updating group status for non-configurable group")
+			log.WithError(err).Error("error updating group status for non-configurable group")
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
 	}
 
-	var err error
 	queryMembers := []string{}
 	if groupCR.Spec.Members.LDAPQuery != nil {
 		includeIndirectReports := groupCR.Spec.Members.LDAPQuery.Options != nil && groupCR.Spec.Members.LDAPQuery.Options.IncludeIndirectReports
 		includeManager := groupCR.Spec.Members.LDAPQuery.Options != nil && groupCR.Spec.Members.LDAPQuery.Options.IncludeManager
 		queryMembers, err = r.fetchQueryMembers(ctx, groupCR.Spec.Members.LDAPQuery, includeIndirectReports, nil)
 		if err != nil {
-			r.log.WithError(err).Error("error fetching query members")
-			return ctrl.Result{}, err
+			log.WithError(err).Error("error fetching query members")
+			return ctrl.Result{}, r.ldapQueryFailed(ctx, groupCR, err)
 		}
 		if includeManager {
 			queryMembers = append(queryMembers, extractManagerUIDsFromQuery(groupCR.Spec.Members.LDAPQuery)...)
 		}
-		r.log.WithField("query_members_count", len(queryMembers)).Info("query members fetched successfully")
+		log.WithField("query_members_count", len(queryMembers)).Info("query members fetched successfully")
+	}
+	for _, groupDN := range groupCR.Spec.Members.LDAPGroups {
+		ldapGroupMembers, err := r.LdapConn.GetGroupMembers(ctx, groupDN)
+		if err != nil {
+			log.WithError(err).WithField("group_dn", groupDN).Error("error fetching LDAP group members")
+			return ctrl.Result{}, r.ldapQueryFailed(ctx, groupCR, err)
+		}
+		queryMembers = append(queryMembers, ldapGroupMembers...)
 	}
 
 	visitedGroups := make(map[string]struct{})
-	allDeclaredMembers, err := r.fetchUniqueGroupMembers(ctx, req.Name, groupCR.Namespace, visitedGroups)
+	traversal := &groupTraversal{maxDepth: groupCR.Spec.Members.MaxGroupsDepth()}
+	allDeclaredMembers, err := r.fetchUniqueGroupMembers(ctx, req.Name, groupCR.Namespace, visitedGroups, traversal)
 	if err != nil {
-		r.log.WithError(err).Error("error fetching unique group members")
+		log.WithError(err).Error("error fetching unique group members")
 		return ctrl.Result{}, err
 	}
 
-	uniqueMembers := r.deduplicateMembers(append(allDeclaredMembers, queryMembers...))
+	// Members whose membership expired are dropped, which removes them from the backend teams
+	now := time.Now()
+	expiredUsers := groupCR.Spec.Members.ExpiredUsers(now)
+	uniqueMembers := removeMembers(r.deduplicateMembers(append(allDeclaredMembers, queryMembers...)), expiredUsers)
 
-	r.log.WithField("unique_members", len(uniqueMembers)).Info("unique members to be reconciled")
-	groupCR.Status.ReconciledUsers = uniqueMembers
+	// Backends mirroring the member groups as nested teams only get the members declared by the group itself
+	var directMembers []string
+	if groupCR.Spec.Members.GroupsMode() == usernautdevv1alpha1.GroupsPolicyMirror {
+		directMembers = removeMembers(r.deduplicateMembers(append(traversal.direct, queryMembers...)), expiredUsers)
+	}
 
-	r.log.Info("fetching LDAP data for the users in the group")
+	// Users added through backend overrides are only onboarded on their backend, but need LDAP data like any member
+	allMembers := removeMembers(
+		r.deduplicateMembers(append(slices.Clone(uniqueMembers), backendOverrideUsers(groupCR)...)), expiredUsers)
 
-	// Lock cache for all read/write operations during reconciliation
-	// This prevents race conditions when multiple Group CRs reference the same users/teams
-	// and their reconciliations run concurrently
-	r.CacheMutex.Lock()
-	defer r.CacheMutex.Unlock()
+	// Groups needing an approval are left untouched until an approver annotates them, the annotation
+	// only approves the change awaiting the approval
+	if reason, message := r.approvalRequired(groupCR, allMembers); reason != "" {
+		pending := membershipHash(allMembers, r.groupBackends(groupCR))
+		approver := groupCR.GetAnnotations()[constants.ApprovedByAnnotation]
+		if approver == "" || groupCR.Status.PendingApproval != pending {
+			if err := r.dropStaleApproval(ctx, groupCR, approver); err != nil {
+				log.WithError(err).Error("error removing the stale approval of the group")
+				return ctrl.Result{}, err
+			}
+			return r.awaitApproval(ctx, groupCR, reason, message, pending)
+		}
+		if err := r.consumeApproval(ctx, groupCR, approver, message); err != nil {
+			log.WithError(err).Error("error consuming the approval of the group")
+			return ctrl.Result{}, err
+		}
+	} else {
+		groupCR.Status.PendingApproval = ""
+	}
+
+	log.WithField("unique_members", len(allMembers)).Info("unique members to be reconciled")
+	groupCR.Status.ReconciledUsers = allMembers
+	groupCR.Status.GroupsDepth = traversal.depth
+	groupCR.Status.TruncatedGroups = traversal.truncated
 
-	r.log.Info("Acquired cache lock for entire reconciliation (LDAP + backends)")
+	log.Info("fetching LDAP data for the users in the group")
 
 	// Step 1: Fetch LDAP data (does NOT update cache indexes)
-	ldapResult := r.fetchLDAPData(ctx, uniqueMembers)
+	// The cache lock is held around the cache reads and writes of the reconcile, a lease of the lock
+	// lost meanwhile fails the reconcile
+	lostLeases := controllerutils.LostCacheLeases(r.CacheMutex)
+
+	ldapResult := r.fetchLDAPData(ctx, allMembers)
+	ctx = withLDAPUsers(ctx, ldapResult.Users)
+	groupCR.Status.SkippedUsers = ldapResult.SkippedUsers
+	if len(ldapResult.FailedUsers) == 0 {
+		r.setGroupCondition(groupCR, usernautdevv1alpha1.LDAPReadyCondition, true,
+			usernautdevv1alpha1.LDAPLookupSucceededReason, "All the members were looked up in LDAP")
+	} else {
+		r.setGroupCondition(groupCR, usernautdevv1alpha1.LDAPReadyCondition, false,
+			usernautdevv1alpha1.LDAPLookupFailedReason,
+			fmt.Sprintf("LDAP lookup failed for %d members", len(ldapResult.FailedUsers)))
+		if stop, result, err := r.applyLDAPFailurePolicy(ctx, groupCR, ldapResult.FailedUsers); stop {
+			return result, err
+		}
+	}
+
+	// Migrate the cache entries of the members whose email changed, before they are looked up by email,
+	// also when the backends are skipped so the offboarding job doesn't see the old email as deleted
+	r.migrateRenamedUsers(ctx)
+
+	// Unchanged groups whose last sync succeeded skip the backend calls until the next resync
+	if r.syncUpToDate(ctx, groupCR, allMembers, now) {
+		log.Info("membership unchanged since the last successful sync, skipping the backends")
+		groupCR.UpdateStatus(false)
+		if err := r.Status().Update(ctx, groupCR); err != nil {
+			log.WithError(err).Error("error updating the status of the unchanged group")
+			return ctrl.Result{}, err
+		}
+		nextSync := groupCR.Status.LastSyncTime.Add(r.resyncInterval(ctx)).Sub(now)
+		return ctrl.Result{RequeueAfter: min(r.groupRequeueAfter(ctx, groupCR, now), nextSync)}, nil
+	}
+
+	// Step 2: Tear down the teams of the backends removed from the spec
+	failedTeardowns := r.teardownRemovedBackends(ctx, groupCR)
 
-	// Step 2: Process all backends (cache operations protected by lock)
-	backendErrors := r.processAllBackends(ctx, groupCR, uniqueMembers)
+	// Step 3: Process the backends (cache operations protected by lock)
+	backends := r.backendsToReconcile(groupCR)
+	log.WithField("backends_to_reconcile", len(backends)).Info("processing group backends")
+	backendErrors, backendResults := r.processAllBackends(ctx, groupCR, backends, uniqueMembers, directMembers, expiredUsers)
 
-	// Step 3: Only update cache indexes if ALL backends succeeded (all-or-nothing)
+	// Another shard may have held the cache lock while its lease was lost, the next reconcile corrects
+	// the cache entries written meanwhile instead of building the indexes on them
+	if controllerutils.LostCacheLeases(r.CacheMutex) != lostLeases {
+		log.WithError(controllerutils.ErrCacheLockLost).Error("failing the reconcile, the cache lock was lost")
+		return ctrl.Result{}, controllerutils.ErrCacheLockLost
+	}
+
+	// Step 4: Only update cache indexes if ALL backends succeeded (all-or-nothing)
 	hasErrors := false
 	for _, m := range backendErrors {
 		if len(m) > 0 {
@@ -206,32 +438,241 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 
 	if !hasErrors {
-		r.log.Info("All backends succeeded, updating cache indexes")
+		log.Info("All backends succeeded, updating cache indexes")
 		if err := r.updateCacheIndexes(ctx, groupCR.Spec.GroupName, ldapResult); err != nil {
-			r.log.WithError(err).Error("error updating cache indexes")
-			// Continue to update status - cache index errors are logged but not fatal
+			log.WithError(err).Error("error updating cache indexes")
+			// Continue to update status - cache index errors are reported but not fatal
+			r.setGroupCondition(groupCR, usernautdevv1alpha1.CacheReadyCondition, false,
+				usernautdevv1alpha1.CacheUpdateFailedReason, err.Error())
+		} else {
+			r.setGroupCondition(groupCR, usernautdevv1alpha1.CacheReadyCondition, true,
+				usernautdevv1alpha1.CacheUpdatedReason, "Cache indexes updated")
 		}
 	} else {
-		r.log.Warn("Backend errors detected, skipping cache index updates (all-or-nothing)")
+		log.Warn("Backend errors detected, skipping cache index updates (all-or-nothing)")
 	}
 
-	// Step 4: Remove force reconcile label if present
+	// Step 5: Remove force reconcile label if present
 	if removeErr := controllerutils.RemoveForceReconcileLabel(ctx, r.Client, groupCR); removeErr != nil {
-		r.log.WithError(removeErr).Error("Failed to remove force reconcile label")
+		log.WithError(removeErr).Error("Failed to remove force reconcile label")
 		return ctrl.Result{}, removeErr
 	}
 
-	// Step 5: Update status and handle errors
-	if err := r.updateStatusAndHandleErrors(ctx, groupCR, backendErrors); err != nil {
+	// Step 6: Update status and handle errors
+	retryAfter, err := r.updateStatusAndHandleErrors(ctx, groupCR, backends, backendErrors, backendResults, failedTeardowns)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	if retryAfter > 0 {
+		log.WithField("retry_after", retryAfter).Warn("failed to reconcile all backends, retrying the failed backends")
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.groupRequeueAfter(ctx, groupCR, now)}, nil
+}
+
+// syncUpToDate reports whether the members and backends of the group are unchanged since the last
+// successful sync of all its backends, within the resync interval which still corrects manual edits
+// of the backend teams
+func (r *GroupReconciler) syncUpToDate(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	members []string, now time.Time) bool {
+	if _, force := groupCR.GetLabels()[constants.ForceReconcileLabel]; force {
+		return false
+	}
+	status := groupCR.Status
+	if status.LastSyncTime == nil || status.ObservedGeneration != groupCR.Generation {
+		return false
+	}
+	if !meta.IsStatusConditionTrue(status.Conditions, usernautdevv1alpha1.BackendsReadyCondition) ||
+		!meta.IsStatusConditionTrue(status.Conditions, usernautdevv1alpha1.CacheReadyCondition) {
+		return false
+	}
+	if now.Sub(status.LastSyncTime.Time) >= r.resyncInterval(ctx) {
+		return false
+	}
+	return status.MembershipHash == membershipHash(members, r.groupBackends(groupCR))
+}
+
+// membershipHash returns a hash of the members and backends of a group, independent of their order
+func membershipHash(members []string, backends []usernautdevv1alpha1.Backend) string {
+	entries := make([]string, 0, len(members)+len(backends))
+	for _, member := range members {
+		entries = append(entries, "member:"+member)
+	}
+	for _, backend := range backends {
+		entries = append(entries, "backend:"+backend.Type+"/"+backend.Name)
+	}
+	slices.Sort(entries)
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// groupBackends returns the backends of the spec followed by the backends attached to the group
+// by the backend selectors of the configuration matching its labels
+func (r *GroupReconciler) groupBackends(groupCR *usernautdevv1alpha1.Group) []usernautdevv1alpha1.Backend {
+	selected := r.AppConfig.SelectedBackends(groupCR.GetLabels())
+	if len(selected) == 0 {
+		return groupCR.Spec.Backends
+	}
+
+	backends := slices.Clone(groupCR.Spec.Backends)
+	for _, ref := range selected {
+		backend := usernautdevv1alpha1.Backend{Name: ref.Name, Type: ref.Type}
+		if !slices.Contains(backends, backend) {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+// selectorDeletionPolicy returns the deletion policy of a backend attached to the group by a backend
+// selector, Retain unless the selector sets Delete. The backends of the spec have no policy of their own.
+func (r *GroupReconciler) selectorDeletionPolicy(groupCR *usernautdevv1alpha1.Group,
+	backend usernautdevv1alpha1.Backend) usernautdevv1alpha1.DeletionPolicy {
+	if slices.Contains(groupCR.Spec.Backends, backend) {
+		return ""
+	}
+	ref := config.BackendRef{Name: backend.Name, Type: backend.Type}
+	if r.AppConfig.SelectorDeletionPolicy(groupCR.GetLabels(), ref) == config.SelectorDeletionPolicyDelete {
+		return usernautdevv1alpha1.DeletionPolicyDelete
+	}
+	return usernautdevv1alpha1.DeletionPolicyRetain
+}
+
+// backendsToReconcile returns the backends to process in this reconcile. While backends failed at the
+// current generation, only those are retried and the backends which already succeeded are skipped.
+// Spec changes, the force reconcile label and the periodic resync process all the backends.
+func (r *GroupReconciler) backendsToReconcile(groupCR *usernautdevv1alpha1.Group) []usernautdevv1alpha1.Backend {
+	groupBackends := r.groupBackends(groupCR)
+	if value, force := groupCR.GetLabels()[constants.ForceReconcileLabel]; force {
+		return forcedBackends(groupCR, groupBackends, value)
+	}
+
+	retrying := false
+	succeeded := make(map[string]bool, len(groupCR.Status.BackendsStatus))
+	for _, status := range groupCR.Status.BackendsStatus {
+		if status.ObservedGeneration != groupCR.Generation {
+			continue
+		}
+		if status.Status {
+			succeeded[status.Name+"_"+status.Type] = true
+		} else {
+			retrying = true
+		}
+	}
+	if !retrying {
+		return groupBackends
+	}
+
+	backends := make([]usernautdevv1alpha1.Backend, 0, len(groupBackends))
+	for _, backend := range groupBackends {
+		if !succeeded[backend.Name+"_"+backend.Type] {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+// forcedBackends returns the backends re-driven by the force reconcile label. A value naming a backend
+// of the group as <type>_<name>, e.g. snowflake_prod, only re-drives that backend and the backends never
+// reconciled yet, any other value re-drives all the backends.
+func forcedBackends(groupCR *usernautdevv1alpha1.Group, groupBackends []usernautdevv1alpha1.Backend,
+	value string) []usernautdevv1alpha1.Backend {
+	forced := func(backend usernautdevv1alpha1.Backend) bool {
+		return backend.Type+"_"+backend.Name == value
+	}
+	if !slices.ContainsFunc(groupBackends, forced) {
+		return groupBackends
+	}
+
+	reconciled := make(map[string]bool, len(groupCR.Status.BackendsStatus))
+	for _, status := range groupCR.Status.BackendsStatus {
+		reconciled[status.Name+"_"+status.Type] = true
+	}
+	backends := make([]usernautdevv1alpha1.Backend, 0, len(groupBackends))
+	for _, backend := range groupBackends {
+		if forced(backend) || !reconciled[backend.Name+"_"+backend.Type] {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+// backendRetryDelay returns the exponential backoff before retrying a backend which failed retries times
+func backendRetryDelay(retries int32, baseDelay, maxDelay time.Duration) time.Duration {
+	delay := baseDelay
+	for i := int32(1); i < retries; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
+
+// backendRetryDelays returns the configured base and max delay of the backend retries
+func (r *GroupReconciler) backendRetryDelays(ctx context.Context) (time.Duration, time.Duration) {
+	controllerConfig := r.AppConfig.ControllerConfig
+	return controllerutils.DurationOrDefault(ctx, "backendRetryBaseDelay",
+			controllerConfig.BackendRetryBaseDelay, backendRetryBaseDelay),
+		controllerutils.DurationOrDefault(ctx, "backendRetryMaxDelay",
+			controllerConfig.BackendRetryMaxDelay, backendRetryMaxDelay)
+}
+
+// groupRequeueAfter returns the resync interval, or the time until the next membership expires if sooner
+func (r *GroupReconciler) groupRequeueAfter(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group, now time.Time) time.Duration {
+	resyncInterval := r.resyncInterval(ctx)
+	next := groupCR.Spec.Members.NextExpiration(now)
+	if next == nil || next.Sub(now) >= resyncInterval {
+		return resyncInterval
+	}
+	return next.Sub(now)
+}
+
+// membershipBatchSize returns the largest number of users changed in a single membership call of the backend
+func (r *GroupReconciler) membershipBatchSize(backend usernautdevv1alpha1.Backend) int {
+	return r.AppConfig.BackendMap[backend.Type][backend.Name].MembershipBatchSize
+}
+
+// resyncInterval returns how often groups are reconciled without spec changes
+func (r *GroupReconciler) resyncInterval(ctx context.Context) time.Duration {
+	return controllerutils.DurationOrDefault(ctx, "resyncInterval",
+		r.AppConfig.ControllerConfig.ResyncInterval, requeueAfter)
+}
+
+// shutdownGracePeriod is how long an in-flight reconcile may run on shutdown to reach a checkpoint
+func (r *GroupReconciler) shutdownGracePeriod(ctx context.Context) time.Duration {
+	return controllerutils.DurationOrDefault(ctx, "shutdownGracePeriod",
+		r.AppConfig.ControllerConfig.ShutdownGracePeriod, controllerutils.DefaultShutdownGracePeriod)
 }
 
 // LDAPFetchResult contains the results of LDAP data fetching
 type LDAPFetchResult struct {
-	CurrentMembers []string // emails of users with valid LDAP data
-	ActiveUserList []string // UIDs of active users
+	CurrentMembers []string                          // emails of users with valid LDAP data
+	ActiveUserList []string                          // UIDs of active users
+	FailedUsers    []string                          // members whose LDAP lookup failed, excluding the ones missing from LDAP
+	SkippedUsers   []usernautdevv1alpha1.SkippedUser // members without LDAP data, and why
+	// Users is the LDAP data of the members, keyed by member
+	Users map[string]*structs.LDAPUser
+}
+
+// ldapUsersKey is the context key of the LDAP data of the members of the group being reconciled
+type ldapUsersKey struct{}
+
+// withLDAPUsers returns a copy of the context carrying the LDAP data of the members, the reconciles
+// running concurrently each carry their own instead of sharing a field of the reconciler
+func withLDAPUsers(ctx context.Context, users map[string]*structs.LDAPUser) context.Context {
+	return context.WithValue(ctx, ldapUsersKey{}, users)
+}
+
+// ldapUsers returns the LDAP data of the members carried by the context
+func ldapUsers(ctx context.Context) map[string]*structs.LDAPUser {
+	users, _ := ctx.Value(ldapUsersKey{}).(map[string]*structs.LDAPUser)
+	return users
 }
 
 // fetchQueryMembers runs the LDAP query and, when the query has a manager filter and
@@ -376,39 +817,62 @@ func extractManagerUIDsFromQuery(query *usernautdevv1alpha1.LDAPQuery) []string
 	return managerUIDs
 }
 
-// fetchLDAPData fetches LDAP data for all unique members and populates allLdapUserData
+// fetchLDAPData fetches LDAP data for all unique members in bulk and returns it in the result Users
 // This function does NOT update any cache indexes - it only fetches data
-// NOTE: This function assumes CacheMutex is already held by the caller
 func (r *GroupReconciler) fetchLDAPData(
 	ctx context.Context,
 	uniqueMembers []string,
 ) *LDAPFetchResult {
+	log := logger.Logger(ctx)
 	// Initialize LDAP user data map
-	r.allLdapUserData = make(map[string]*structs.LDAPUser, len(uniqueMembers))
+	allLdapUserData := make(map[string]*structs.LDAPUser, len(uniqueMembers))
 
 	// Use a map to track unique UIDs to avoid duplicates
 	uniqueUIDs := make(map[string]bool)
 
 	// Track current valid members (users with valid LDAP data)
 	currentMembers := make([]string, 0, len(uniqueMembers))
+	var failedUsers []string
+	var skippedUsers []usernautdevv1alpha1.SkippedUser
+
+	// Fetch the LDAP data of the members in bulk, a failed search leaves the members of the chunks
+	// not searched yet without data
+	usersLDAPData, lookupErr := r.LdapConn.GetUsersLDAPData(ctx, uniqueMembers)
+	if lookupErr != nil {
+		log.WithError(lookupErr).Error("error fetching users data from LDAP")
+	}
 
-	// Process each unique member - fetch LDAP data only
+	// Process each unique member - LDAP data only
 	for _, user := range uniqueMembers {
-		ldapUserData, err := r.LdapConn.GetUserLDAPData(ctx, user)
-		if err != nil {
-			r.log.WithError(err).Error("error fetching user data from LDAP")
+		ldapUserData, found := usersLDAPData[user]
+		if !found {
+			err := lookupErr
+			reason := usernautdevv1alpha1.SkippedUserLDAPLookupFailed
+			if err == nil {
+				err = ldap.ErrNoUserFound
+				reason = usernautdevv1alpha1.SkippedUserNotFoundInLDAP
+			} else {
+				failedUsers = append(failedUsers, user)
+			}
+			log.WithError(err).WithField("user", user).Error("error fetching user data from LDAP")
 			delete(uniqueUIDs, user)
+			skippedUsers = append(skippedUsers, usernautdevv1alpha1.SkippedUser{
+				User: user, Reason: reason, Message: err.Error(),
+			})
 			continue
 		}
 
 		ldapUser := &structs.LDAPUser{}
-		err = utils.MapToStruct(ldapUserData, ldapUser)
+		err := utils.MapToStruct(ldapUserData, ldapUser)
 		if err != nil {
-			r.log.WithError(err).Error("error converting LDAP user data to struct")
+			log.WithError(err).Error("error converting LDAP user data to struct")
+			skippedUsers = append(skippedUsers, usernautdevv1alpha1.SkippedUser{
+				User: user, Reason: usernautdevv1alpha1.SkippedUserInvalidLDAPData, Message: err.Error(),
+			})
 			continue
 		}
 
-		r.allLdapUserData[user] = ldapUser
+		allLdapUserData[user] = ldapUser
 
 		// Only add UID if it's not already in the list
 		if !uniqueUIDs[ldapUser.GetUID()] {
@@ -428,27 +892,93 @@ func (r *GroupReconciler) fetchLDAPData(
 		}
 	}
 
+	// The status only lists the first skipped users of big groups
+	if len(skippedUsers) > maxSkippedUsers {
+		skippedUsers = skippedUsers[:maxSkippedUsers]
+	}
+
 	return &LDAPFetchResult{
 		CurrentMembers: currentMembers,
 		ActiveUserList: activeUserList,
+		FailedUsers:    failedUsers,
+		SkippedUsers:   skippedUsers,
+		Users:          allLdapUserData,
+	}
+}
+
+// migrateRenamedUsers migrates the cache entries of the members whose email changed in LDAP to
+// their new email. A failed migration is logged and retried on the next reconcile.
+func (r *GroupReconciler) migrateRenamedUsers(ctx context.Context) {
+	log := logger.Logger(ctx)
+	r.CacheMutex.Lock()
+	defer r.CacheMutex.Unlock()
+	for user, ldapUser := range ldapUsers(ctx) {
+		if _, err := controllerutils.MigrateRenamedUser(ctx, r.Store, ldapUser.GetUID(), ldapUser.GetEmail()); err != nil {
+			log.WithError(err).WithField("user", user).Error("error migrating the cache entries of the renamed user")
+		}
+	}
+}
+
+// applyLDAPFailurePolicy handles the members whose LDAP lookup failed according to the LDAP failure
+// policy of the group. It reports whether the reconcile stops, along with its result and error.
+func (r *GroupReconciler) applyLDAPFailurePolicy(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	failedUsers []string) (bool, ctrl.Result, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"failed_users": failedUsers,
+		"policy":       groupCR.Spec.LDAPFailurePolicy,
+	})
+
+	condition := metav1.Condition{
+		Type:               usernautdevv1alpha1.GroupReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: groupCR.Generation,
+	}
+	switch groupCR.Spec.LDAPFailurePolicy {
+	case usernautdevv1alpha1.LDAPFailurePolicyFail:
+		condition.Reason = usernautdevv1alpha1.LDAPLookupFailedReason
+		condition.Message = fmt.Sprintf("LDAP lookup failed for %d members", len(failedUsers))
+	case usernautdevv1alpha1.LDAPFailurePolicyFreeze:
+		condition.Reason = usernautdevv1alpha1.LDAPMembershipFrozenReason
+		condition.Message = fmt.Sprintf("LDAP lookup failed for %d members, keeping the backend memberships until LDAP recovers",
+			len(failedUsers))
+	default:
+		log.Warn("dropping the members whose LDAP lookup failed")
+		return false, ctrl.Result{}, nil
+	}
+
+	r.setCondition(&groupCR.Status.Conditions, condition)
+	if err := r.Status().Update(ctx, groupCR); err != nil {
+		log.WithError(err).Error("error updating group status for failed LDAP lookups")
+		return true, ctrl.Result{}, err
+	}
+
+	if groupCR.Spec.LDAPFailurePolicy == usernautdevv1alpha1.LDAPFailurePolicyFail {
+		log.Error("aborting the reconcile due to failed LDAP lookups")
+		return true, ctrl.Result{}, fmt.Errorf("LDAP lookup failed for %d members", len(failedUsers))
 	}
+	retryAfter, _ := r.backendRetryDelays(ctx)
+	log.WithField("retry_after", retryAfter).Warn("freezing the backend memberships due to failed LDAP lookups")
+	return true, ctrl.Result{RequeueAfter: retryAfter}, nil
 }
 
 // updateCacheIndexes updates all cache indexes after successful backend reconciliation
 // This includes: user:groups reverse index, group members, and user list
-// NOTE: This function assumes CacheMutex is already held by the caller
 // Returns an error if critical cache updates fail
 func (r *GroupReconciler) updateCacheIndexes(
 	ctx context.Context,
 	groupName string,
 	ldapResult *LDAPFetchResult,
 ) error {
+	log := logger.Logger(ctx)
+	r.CacheMutex.Lock()
+	defer r.CacheMutex.Unlock()
 	var errors []error
 
 	// Get previous members of this group (for removal detection)
 	previousMembers, err := r.Store.Group.GetMembers(ctx, groupName)
 	if err != nil {
-		r.log.WithError(err).Warn("error fetching previous group members, assuming empty")
+		log.WithError(err).Warn("error fetching previous group members, assuming empty")
 		previousMembers = []string{}
 	}
 	previousMembersSet := make(map[string]struct{}, len(previousMembers))
@@ -465,7 +995,7 @@ func (r *GroupReconciler) updateCacheIndexes(
 	// Update user:groups reverse index - add this group to each current member's group list
 	for _, email := range ldapResult.CurrentMembers {
 		if err := r.Store.UserGroups.AddGroup(ctx, email, groupName); err != nil {
-			r.log.WithError(err).WithField("user", email).Error("error updating user groups index")
+			log.WithError(err).WithField("user", email).Error("error updating user groups index")
 			errors = append(errors, fmt.Errorf("failed to add group %s to user %s: %w", groupName, email, err))
 		}
 	}
@@ -474,9 +1004,9 @@ func (r *GroupReconciler) updateCacheIndexes(
 	for email := range previousMembersSet {
 		if _, stillMember := currentMembersSet[email]; !stillMember {
 			// User was removed from the group - update their user:groups index
-			r.log.WithField("user", email).WithField("group", groupName).Info("removing group from user's group list")
+			log.WithField("user", email).WithField("group", groupName).Info("removing group from user's group list")
 			if err := r.Store.UserGroups.RemoveGroup(ctx, email, groupName); err != nil {
-				r.log.WithError(err).WithField("user", email).Error("error removing group from user's groups index")
+				log.WithError(err).WithField("user", email).Error("error removing group from user's groups index")
 				errors = append(errors, fmt.Errorf("failed to remove group %s from user %s: %w", groupName, email, err))
 			}
 		}
@@ -484,7 +1014,7 @@ func (r *GroupReconciler) updateCacheIndexes(
 
 	// Update group members in consolidated store - this is critical
 	if err := r.Store.Group.SetMembers(ctx, groupName, ldapResult.CurrentMembers); err != nil {
-		r.log.WithError(err).Error("error updating group members")
+		log.WithError(err).Error("error updating group members")
 		return fmt.Errorf("failed to update group members for %s: %w", groupName, err)
 	}
 
@@ -500,18 +1030,28 @@ func (r *GroupReconciler) updateCacheIndexes(
 func (r *GroupReconciler) processAllBackends(
 	ctx context.Context,
 	groupCR *usernautdevv1alpha1.Group,
+	backends []usernautdevv1alpha1.Backend,
 	uniqueMembers []string,
-) map[string]map[string]string {
+	directMembers []string,
+	expiredUsers map[string]struct{},
+) (map[string]map[string]string, map[string]backendSyncResult) {
+	log := logger.Logger(ctx)
 	backendErrors := make(map[string]map[string]string, 0)
+	backendResults := make(map[string]backendSyncResult, len(backends))
 
 	// Create a map of valid backends for validation
 	validBackends := make(map[string]bool)
-	for _, backend := range groupCR.Spec.Backends {
+	for _, backend := range r.groupBackends(groupCR) {
 		validBackends[backend.Name+"_"+backend.Type] = true
 	}
 
-	// Group Params by backend name for direct lookup.
-	groupParamsByBackend := make(map[string]structs.TeamParams)
+	// Group Params by backend name for direct lookup, in the order of the CR. The role params override
+	// the default roles of the backend and are kept apart.
+	groupParamsByBackend := make(map[string][]structs.TeamParams)
+	roleOverridesByBackend := make(map[string]backendRoles)
+	// The params of the members are left as is while the group params of the backend are invalid,
+	// instead of unsetting the params which failed validation
+	invalidGroupParams := make(map[string]bool)
 	for _, param := range groupCR.Spec.GroupParams {
 		backendKey := param.Name + "_" + param.Backend
 		if !validBackends[backendKey] {
@@ -530,162 +1070,631 @@ func (r *GroupReconciler) processAllBackends(
 			backendErrors[param.Backend][param.Name] = fmt.Errorf(
 				"group param property is empty for backend: %s/%s",
 				param.Backend, param.Name).Error()
+			invalidGroupParams[backendKey] = true
+			continue
+		} else if err := clients.ValidateGroupParam(param.Backend, param.Property, param.Value); err != nil {
+			if _, ok := backendErrors[param.Backend]; !ok {
+				backendErrors[param.Backend] = make(map[string]string)
+			}
+			backendErrors[param.Backend][param.Name] = err.Error()
+			invalidGroupParams[backendKey] = true
 			continue
+		} else if clients.IsRoleGroupParam(param.Property) {
+			roleOverridesByBackend[backendKey] = roleOverridesByBackend[backendKey].withParam(param.Property, param.Value)
 		} else {
-			groupParamsByBackend[backendKey] = structs.TeamParams{
+			groupParamsByBackend[backendKey] = append(groupParamsByBackend[backendKey], structs.TeamParams{
 				Property: param.Property,
 				Value:    param.Value,
-			}
+			})
 		}
 	}
 
-	for _, backend := range groupCR.Spec.Backends {
-		r.backendLogger = r.log.WithFields(logrus.Fields{
-			"backend":      backend.Name,
-			"backend_type": backend.Type,
-		})
-		backendKey := backend.Name + "_" + backend.Type
-		backendGroupParams := groupParamsByBackend[backendKey]
-		if err := r.processSingleBackend(ctx, groupCR, backend, uniqueMembers, backendGroupParams); err != nil {
-			r.backendLogger.WithError(err).Error("error processing backend")
-			if _, ok := backendErrors[backend.Type]; !ok {
-				backendErrors[backend.Type] = make(map[string]string)
+	// Backends are processed concurrently, in waves ordered by their dependencies. The backends
+	// that another backend of this group depends on (e.g. gitlab LDAP sync depends on the rover
+	// group) are processed first, so their team exists in the cache before the dependant backend
+	// checks for it.
+	var backendErrorsMu, backendResultsMu sync.Mutex
+	for _, wave := range r.backendWaves(backends) {
+		// The backends of a wave are reconciled to completion, the next waves are left for the
+		// reconcile after the restart when the operator is stopping
+		if controllerutils.ShuttingDown(ctx) {
+			for _, backend := range wave {
+				log.WithField("backend", backend.Name).Info("shutting down, skipping the backend")
+				if _, ok := backendErrors[backend.Type]; !ok {
+					backendErrors[backend.Type] = make(map[string]string)
+				}
+				backendErrors[backend.Type][backend.Name] = controllerutils.ErrShuttingDown.Error()
 			}
-			backendErrors[backend.Type][backend.Name] = err.Error()
+			continue
+		}
+
+		g := new(errgroup.Group)
+		g.SetLimit(r.maxConcurrentBackends())
+
+		for _, backend := range wave {
+			g.Go(func() error {
+				backendLogger := log.WithFields(logrus.Fields{
+					"backend":      backend.Name,
+					"backend_type": backend.Type,
+				})
+				backendCtx := logger.WithLogger(ctx, backendLogger)
+
+				backendKey := backend.Name + "_" + backend.Type
+				backendGroupParams := groupParamsByBackend[backendKey]
+				roles := defaultBackendRoles(r.AppConfig, backend.Name, backend.Type).
+					override(roleOverridesByBackend[backendKey])
+				backendMembers := removeMembers(
+					membersForBackend(groupCR.Spec.BackendOverrides, backend, uniqueMembers), expiredUsers)
+				var backendDirectMembers []string
+				if directMembers != nil {
+					backendDirectMembers = removeMembers(
+						membersForBackend(groupCR.Spec.BackendOverrides, backend, directMembers), expiredUsers)
+				}
+				backendCtx, span := tracing.Start(backendCtx, "Group.Backend",
+					attribute.String("backend.name", backend.Name),
+					attribute.String("backend.type", backend.Type))
+				result, err := r.processSingleBackend(backendCtx, groupCR, backend, backendMembers,
+					backendDirectMembers, backendGroupParams, !invalidGroupParams[backendKey], roles)
+				tracing.End(span, err)
+				backendResultsMu.Lock()
+				backendResults[backendKey] = result
+				backendResultsMu.Unlock()
+				if err != nil {
+					backendLogger.WithError(err).Error("error processing backend")
+					r.Recorder.Eventf(groupCR, corev1.EventTypeWarning, eventReasonBackendFailed,
+						"Failed to reconcile backend %s/%s: %v", backend.Type, backend.Name, err)
+					backendErrorsMu.Lock()
+					if _, ok := backendErrors[backend.Type]; !ok {
+						backendErrors[backend.Type] = make(map[string]string)
+					}
+					backendErrors[backend.Type][backend.Name] = err.Error()
+					backendErrorsMu.Unlock()
+				}
+				// Backend failures are recorded in backendErrors and must not cancel the other backends
+				return nil
+			})
 		}
+		_ = g.Wait()
 	}
 
-	return backendErrors
+	return backendErrors, backendResults
 }
 
-// processSingleBackend handles processing of a single backend
-func (r *GroupReconciler) processSingleBackend(ctx context.Context,
-	groupCR *usernautdevv1alpha1.Group,
-	backend usernautdevv1alpha1.Backend,
-	uniqueMembers []string,
-	backendGroupParams structs.TeamParams,
-) error {
-	// Create backend client
-	backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
-	if err != nil {
-		r.backendLogger.WithError(err).Error("error creating backend client")
-		return err
+// backendWaves orders the group backends topologically by their dependencies: each wave holds the
+// backends whose dependency in the group is in an earlier wave, so a chain of dependencies is
+// reconciled in a single pass. Backends without dependency in the group are processed in the last
+// wave alongside the last dependants, backends in a dependency cycle are processed after all others.
+func (r *GroupReconciler) backendWaves(backends []usernautdevv1alpha1.Backend) [][]usernautdevv1alpha1.Backend {
+	inGroup := make(map[string]bool, len(backends))
+	for _, backend := range backends {
+		inGroup[backend.Name+"_"+backend.Type] = true
 	}
-	r.backendLogger.Debug("created backend client successfully")
 
-	isLdapSync, err := r.setupLdapSync(
-		backend.Type, backend.Name, backendClient, groupCR.Spec.GroupName, groupCR.Spec.Backends,
-	)
-	if err != nil {
-		r.backendLogger.Errorf("failed to setup ldap sync for %s: %v", backend.Type, err)
-		return err
-	}
-	if !isLdapSync {
-		r.backendLogger.Infof("ldap sync is not setup for %s backend", backend.Type)
+	// dependencies maps each backend to the backend of the group it depends on
+	dependencies := make(map[string]string)
+	dependedOn := make(map[string]bool)
+	for _, backend := range backends {
+		backendKey := backend.Name + "_" + backend.Type
+		dependsOn := r.AppConfig.BackendMap[backend.Type][backend.Name].DependsOn
+		dependencyKey := dependsOn.Name + "_" + dependsOn.Type
+		if dependsOn.Name == "" || dependsOn.Type == "" || !inGroup[dependencyKey] || dependencyKey == backendKey {
+			continue
+		}
+		dependencies[backendKey] = dependencyKey
+		dependedOn[dependencyKey] = true
 	}
 
-	// Fetch or create team
-	backendParams := &structs.BackendParams{
-		Name: backend.Name,
-		Type: backend.Type,
+	// depth is the length of the chain of dependencies of a backend, -1 when it ends in a cycle
+	depth := func(backendKey string) int {
+		seen := map[string]bool{backendKey: true}
+		d := 0
+		for next, ok := dependencies[backendKey]; ok; next, ok = dependencies[next] {
+			if seen[next] {
+				return -1
+			}
+			seen[next] = true
+			d++
+		}
+		return d
 	}
-	teamID, err := r.fetchOrCreateTeam(ctx, groupCR.Spec.GroupName, backendClient, backendParams)
-	if err != nil {
-		r.backendLogger.WithError(err).Error("error fetching or creating team")
-		return err
+
+	depths := make(map[string]int, len(backends))
+	maxDepth := 0
+	for _, backend := range backends {
+		backendKey := backend.Name + "_" + backend.Type
+		depths[backendKey] = depth(backendKey)
+		maxDepth = max(maxDepth, depths[backendKey])
 	}
-	r.backendLogger.WithField("team_id", teamID).Info("fetched or created team successfully")
 
-	// Independent reconciliation of Group Params for each backend
-	if backendGroupParams.Property != "" {
-		err = backendClient.ReconcileGroupParams(ctx, teamID, backendGroupParams)
-		if err != nil {
-			r.backendLogger.WithError(err).Error("error reconciling group params")
-			return err
+	waves := make([][]usernautdevv1alpha1.Backend, maxDepth+1)
+	var cyclic []usernautdevv1alpha1.Backend
+	for _, backend := range backends {
+		backendKey := backend.Name + "_" + backend.Type
+		switch d := depths[backendKey]; {
+		case d < 0:
+			cyclic = append(cyclic, backend)
+		case d == 0 && !dependedOn[backendKey]:
+			waves[maxDepth] = append(waves[maxDepth], backend)
+		default:
+			waves[d] = append(waves[d], backend)
 		}
-		r.backendLogger.Info("successfully reconciled group params")
 	}
-
-	// Create users in backend and cache
-	if err := r.createUsersInBackendAndCache(ctx, uniqueMembers, backend.Name, backend.Type, backendClient); err != nil {
-		r.backendLogger.WithError(err).Error("error creating users in backend and cache")
-		return err
+	if len(cyclic) > 0 {
+		waves = append(waves, cyclic)
 	}
-	r.backendLogger.Info("created users in backend and cache successfully")
 
-	// Fetch existing team members
-	members, err := backendClient.FetchTeamMembersByTeamID(ctx, teamID)
-	if err != nil {
-		r.backendLogger.WithError(err).Error("error fetching team members")
-		return err
-	}
-	r.backendLogger.WithField("team_members_count", len(members)).Info("fetched team members successfully")
+	return slices.DeleteFunc(waves, func(wave []usernautdevv1alpha1.Backend) bool {
+		return len(wave) == 0
+	})
+}
 
-	// Process users (determine who to add/remove)
-	usersToAdd, usersToRemove, err := r.processUsers(ctx, uniqueMembers, members, backend.Name, backend.Type)
-	if err != nil {
-		r.backendLogger.WithError(err).Error("error processing users")
-		return err
+// backendOverrideUsers returns the additional users of all the backend overrides of the group
+func backendOverrideUsers(groupCR *usernautdevv1alpha1.Group) []string {
+	users := make([]string, 0)
+	for _, override := range groupCR.Spec.BackendOverrides {
+		users = append(users, override.AdditionalUsers...)
 	}
+	return users
+}
 
-	// Add users to team if needed
-	if !isLdapSync {
-		if len(usersToAdd) > 0 {
-			r.backendLogger.WithField("user_count", len(usersToAdd)).Info("Adding users to the team")
-			if err := backendClient.AddUserToTeam(ctx, teamID, usersToAdd); err != nil {
-				r.backendLogger.WithError(err).Error("error while adding users to the team")
-				return err
-			}
-			r.backendLogger.WithField("users_to_add", usersToAdd).Info("added users to team successfully")
+// membersForBackend applies the overrides of the backend to the group members: excluded users
+// are dropped and additional users are appended
+func membersForBackend(overrides []usernautdevv1alpha1.BackendOverride,
+	backend usernautdevv1alpha1.Backend,
+	members []string) []string {
+	excluded := make(map[string]struct{})
+	additional := make([]string, 0)
+	for _, override := range overrides {
+		if override.Name != backend.Name || override.Type != backend.Type {
+			continue
 		}
-
-		// Remove users from team if needed
-		if len(usersToRemove) > 0 {
-			r.backendLogger.WithField("user_count", len(usersToRemove)).Info("removing users from a team")
-			if err := backendClient.RemoveUserFromTeam(ctx, teamID, usersToRemove); err != nil {
-				r.backendLogger.WithError(err).Error("error while removing users from the team")
-				return err
-			}
-			r.backendLogger.WithField("users_to_remove", usersToRemove).Info("removed users from team successfully")
+		for _, user := range override.ExcludeUsers {
+			excluded[user] = struct{}{}
 		}
+		additional = append(additional, override.AdditionalUsers...)
+	}
+	if len(excluded) == 0 && len(additional) == 0 {
+		return members
 	}
 
-	r.backendLogger.Info("successfully processed backend")
-
-	return nil
+	backendMembers := make([]string, 0, len(members)+len(additional))
+	seen := make(map[string]struct{}, len(members)+len(additional))
+	for _, user := range append(slices.Clone(members), additional...) {
+		if _, skip := excluded[user]; skip {
+			continue
+		}
+		if _, exists := seen[user]; exists {
+			continue
+		}
+		seen[user] = struct{}{}
+		backendMembers = append(backendMembers, user)
+	}
+	return backendMembers
 }
 
-// updateStatusAndHandleErrors updates the CR status and handles any backend errors
-func (r *GroupReconciler) updateStatusAndHandleErrors(ctx context.Context,
+// removeMembers returns the members which are not in the removed set
+func removeMembers(members []string, removed map[string]struct{}) []string {
+	if len(removed) == 0 {
+		return members
+	}
+	remaining := make([]string, 0, len(members))
+	for _, member := range members {
+		if _, ok := removed[member]; !ok {
+			remaining = append(remaining, member)
+		}
+	}
+	return remaining
+}
+
+// memberChanges returns the number of members added and removed between two member lists
+func memberChanges(previous, current []string) int {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, member := range previous {
+		previousSet[member] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, member := range current {
+		currentSet[member] = struct{}{}
+	}
+	return len(removeMembers(current, previousSet)) + len(removeMembers(previous, currentSet))
+}
+
+// maxConcurrentBackends returns the number of backends of a single group processed in parallel
+func (r *GroupReconciler) maxConcurrentBackends() int {
+	if r.AppConfig.ControllerConfig.MaxConcurrentBackends <= 0 {
+		return defaultMaxConcurrentBackends
+	}
+	return r.AppConfig.ControllerConfig.MaxConcurrentBackends
+}
+
+// backendSyncResult summarizes the changes made to the team of a backend, as far as the reconcile got
+type backendSyncResult struct {
+	teamID        string
+	memberCount   int
+	usersAdded    int
+	usersRemoved  int
+	onlyInBackend []string
+	onlyInSpec    []string
+	// driftComputed is set once the diff between the team and the spec is known
+	driftComputed bool
+	directMembers []string
+	// directMembersAudited is set once the direct members of the team were audited
+	directMembersAudited bool
+	nestedTeams          []string
+	// nestedTeamsSynced is set once the nested teams of the member groups were synced
+	nestedTeamsSynced bool
+}
+
+// syncNestedTeams nests the teams of the member groups of the group in its team, and removes
+// the nested teams of groups no longer listed. Only the teams nested by usernaut, recorded in the
+// status of the backend, are removed; the teams nested outside of usernaut are left in place.
+// It returns the nested teams usernaut manages once synced.
+func (r *GroupReconciler) syncNestedTeams(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group,
+	teamID string,
+	backend usernautdevv1alpha1.Backend,
+	nestedClient clients.NestedTeamClient,
+) ([]string, error) {
+	backendLogger := logger.Logger(ctx)
+
+	var managed []string
+	for _, status := range groupCR.Status.BackendsStatus {
+		if status.Name == backend.Name && status.Type == backend.Type {
+			managed = status.NestedTeams
+		}
+	}
+
+	desired := make([]string, 0, len(groupCR.Spec.Members.Groups))
+	for _, memberGroup := range groupCR.Spec.Members.Groups {
+		memberGroupCR := &usernautdevv1alpha1.Group{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: groupCR.Namespace, Name: memberGroup}, memberGroupCR); err != nil {
+			return nil, fmt.Errorf("error fetching member group %s: %w", memberGroup, err)
+		}
+		r.CacheMutex.RLock()
+		nestedTeamID, err := r.Store.Group.GetBackendID(ctx, memberGroupCR.Spec.GroupName, backend.Name, backend.Type)
+		r.CacheMutex.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		if nestedTeamID == "" {
+			return nil, fmt.Errorf("member group %s has no team in backend %s/%s to nest yet", memberGroup, backend.Type, backend.Name)
+		}
+		desired = append(desired, nestedTeamID)
+	}
+
+	current, err := nestedClient.FetchNestedTeams(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	toAdd := make([]string, 0)
+	for _, id := range desired {
+		if !slices.Contains(current, id) && !slices.Contains(toAdd, id) {
+			toAdd = append(toAdd, id)
+		}
+	}
+	toRemove := make([]string, 0)
+	for _, id := range current {
+		if !slices.Contains(desired, id) && slices.Contains(managed, id) {
+			toRemove = append(toRemove, id)
+		}
+	}
+	// The teams nested outside of usernaut stay unmanaged even once they are member groups
+	nested := make([]string, 0, len(desired))
+	for _, id := range desired {
+		if (slices.Contains(toAdd, id) || slices.Contains(managed, id)) && !slices.Contains(nested, id) {
+			nested = append(nested, id)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		if err := nestedClient.AddNestedTeams(ctx, teamID, toAdd); err != nil {
+			return nil, err
+		}
+		backendLogger.WithField("nested_teams", toAdd).Info("nested the teams of the member groups")
+	}
+	if len(toRemove) > 0 {
+		if err := nestedClient.RemoveNestedTeams(ctx, teamID, toRemove); err != nil {
+			return nil, err
+		}
+		backendLogger.WithField("nested_teams", toRemove).Info("removed the nested teams of former member groups")
+	}
+	return nested, nil
+}
+
+// processSingleBackend handles processing of a single backend
+func (r *GroupReconciler) processSingleBackend(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group,
+	backend usernautdevv1alpha1.Backend,
+	uniqueMembers []string,
+	directMembers []string,
+	backendGroupParams []structs.TeamParams,
+	groupParamsValid bool,
+	roles backendRoles,
+) (backendSyncResult, error) {
+	backendLogger := logger.Logger(ctx)
+	result := backendSyncResult{}
+
+	// Create backend client
+	backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+	if err != nil {
+		backendLogger.WithError(err).Error("error creating backend client")
+		return result, err
+	}
+	backendLogger.Debug("created backend client successfully")
+
+	managedByDependency, err := r.setupDependency(ctx,
+		backend.Type, backend.Name, backendClient, groupCR.Spec.GroupName, r.groupBackends(groupCR),
+	)
+	if err != nil {
+		backendLogger.Errorf("failed to setup the dependency of %s: %v", backend.Type, err)
+		return result, err
+	}
+	if !managedByDependency {
+		backendLogger.Infof("membership of %s backend is not managed by a dependency", backend.Type)
+	}
+
+	// Fetch or create team
+	backendParams := &structs.BackendParams{
+		Name: backend.Name,
+		Type: backend.Type,
+	}
+	teamID, err := r.fetchOrCreateTeam(ctx, groupCR, backendClient, backendParams, roles.team)
+	if err != nil {
+		backendLogger.WithError(err).Error("error fetching or creating team")
+		return result, err
+	}
+	backendLogger.WithField("team_id", teamID).Info("fetched or created team successfully")
+	result.teamID = teamID
+
+	// Independent reconciliation of Group Params for each backend
+	for _, groupParams := range backendGroupParams {
+		err = backendClient.ReconcileGroupParams(ctx, teamID, groupParams)
+		if err != nil {
+			backendLogger.WithError(err).WithField("property", groupParams.Property).Error("error reconciling group params")
+			return result, err
+		}
+		backendLogger.WithField("property", groupParams.Property).Info("successfully reconciled group params")
+	}
+
+	// Member groups are mirrored as nested teams on the backends supporting them, the team then
+	// only holds the members declared by the group itself
+	if nestedClient, ok := clients.As[clients.NestedTeamClient](backendClient); ok && directMembers != nil && !managedByDependency {
+		nestedTeams, err := r.syncNestedTeams(ctx, groupCR, teamID, backend, nestedClient)
+		if err != nil {
+			backendLogger.WithError(err).Error("error syncing the nested teams")
+			return result, err
+		}
+		result.nestedTeams, result.nestedTeamsSynced = nestedTeams, true
+		uniqueMembers = directMembers
+	}
+
+	memberRoles := withOwnerRoles(memberRolesForBackend(groupCR.Spec.Members.AllRoles(), backend.Type, backendClient),
+		groupCR.Spec.Owners, backend.Type)
+
+	// Create users in backend and cache
+	if err := r.createUsersInBackendAndCache(ctx, uniqueMembers, memberRoles, roles.user,
+		backend.Name, backend.Type, backendClient); err != nil {
+		backendLogger.WithError(err).Error("error creating users in backend and cache")
+		return result, err
+	}
+	backendLogger.Info("created users in backend and cache successfully")
+
+	// Fetch existing team members
+	members, err := backendClient.FetchTeamMembersByTeamID(ctx, teamID)
+	if err != nil {
+		backendLogger.WithError(err).Error("error fetching team members")
+		return result, err
+	}
+	backendLogger.WithField("team_members_count", len(members)).Info("fetched team members successfully")
+	result.memberCount = len(members)
+
+	// Process users (determine who to add/remove)
+	usersToAdd, usersToRemove, usersToDemote, err := r.processUsers(ctx, uniqueMembers, members,
+		memberRoles, backend.Name, backend.Type)
+	if err != nil {
+		backendLogger.WithError(err).Error("error processing users")
+		return result, err
+	}
+	// The drift is recorded before applying the changes, so it is reported even if they fail
+	result.onlyInBackend, result.onlyInSpec = r.memberDrift(ctx, uniqueMembers, members,
+		usersToAdd, usersToRemove, backend.Name, backend.Type)
+	result.driftComputed = true
+
+	// The members added directly to a team managed by the dependency are not seen by the sync
+	if auditor, ok := clients.As[clients.DirectMemberClient](backendClient); ok && managedByDependency &&
+		r.directMemberAudit(backend) != "" {
+		result.directMembers, err = r.auditDirectMembers(ctx, groupCR, teamID, backend, auditor,
+			uniqueMembers, memberRoles)
+		if err != nil {
+			backendLogger.WithError(err).Error("error auditing the direct team members")
+			return result, err
+		}
+		result.directMembersAudited = true
+	}
+
+	// Add users to team if needed
+	if !managedByDependency {
+		if err := r.checkMassRemoval(groupCR, len(usersToRemove), len(members)); err != nil {
+			backendLogger.WithError(err).Warn("refusing to remove the members of the team")
+			return result, err
+		}
+
+		usersAddedCount := len(usersToAdd)
+		usersToAdd, err = r.syncMemberRoles(ctx, groupCR, teamID, backend, backendClient,
+			uniqueMembers, memberRoles, members, usersToAdd, usersToDemote)
+		if err != nil {
+			backendLogger.WithError(err).Error("error while syncing member roles")
+			return result, err
+		}
+
+		if len(usersToAdd) > 0 {
+			backendLogger.WithField("user_count", len(usersToAdd)).Info("Adding users to the team")
+			if err := clients.InBatches(usersToAdd, r.membershipBatchSize(backend), func(batch []string) error {
+				return backendClient.AddUserToTeam(ctx, teamID, batch)
+			}); err != nil {
+				backendLogger.WithError(err).Error("error while adding users to the team")
+				return result, err
+			}
+			backendLogger.WithField("users_to_add", usersToAdd).Info("added users to team successfully")
+			r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonUsersAdded,
+				"Added %d users to the team in backend %s/%s", len(usersToAdd), backend.Type, backend.Name)
+		}
+		result.usersAdded = usersAddedCount
+		result.memberCount += usersAddedCount
+
+		// Remove users from team if needed
+		if len(usersToRemove) > 0 {
+			backendLogger.WithField("user_count", len(usersToRemove)).Info("removing users from a team")
+			if err := clients.InBatches(usersToRemove, r.membershipBatchSize(backend), func(batch []string) error {
+				return backendClient.RemoveUserFromTeam(ctx, teamID, batch)
+			}); err != nil {
+				backendLogger.WithError(err).Error("error while removing users from the team")
+				return result, err
+			}
+			backendLogger.WithField("users_to_remove", usersToRemove).Info("removed users from team successfully")
+			r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonUsersRemoved,
+				"Removed %d users from the team in backend %s/%s", len(usersToRemove), backend.Type, backend.Name)
+			result.usersRemoved = len(usersToRemove)
+			result.memberCount -= len(usersToRemove)
+		}
+
+		// The group params applying to the members follow the membership, e.g. the network policy
+		// of the Snowflake users is set on the members added and unset from the members removed
+		if paramsClient, ok := clients.As[clients.MemberParamsClient](backendClient); ok && groupParamsValid {
+			if err := paramsClient.ReconcileMemberParams(ctx, teamID, backendGroupParams,
+				resolvedMembers(members, usersToAdd, usersToRemove), usersToRemove); err != nil {
+				backendLogger.WithError(err).Error("error reconciling the group params of the team members")
+				return result, err
+			}
+		}
+
+		if publisher, ok := clients.As[clients.MembershipPublisher](backendClient); ok {
+			if err := publisher.PublishMembership(ctx, structs.TeamMembership{
+				GroupName: groupCR.Spec.GroupName,
+				TeamID:    teamID,
+				Members:   resolvedMembers(members, usersToAdd, usersToRemove),
+				Added:     usersToAdd,
+				Removed:   usersToRemove,
+			}); err != nil {
+				backendLogger.WithError(err).Error("error publishing the team membership")
+				return result, err
+			}
+			backendLogger.Info("published the team membership successfully")
+		}
+	}
+
+	backendLogger.Info("successfully processed backend")
+
+	return result, nil
+}
+
+// updateStatusAndHandleErrors updates the CR status with the result of the processed backends, the
+// status of skipped backends is kept. It returns the backoff before retrying the failed backends, or 0
+// when all the backends succeeded.
+func (r *GroupReconciler) updateStatusAndHandleErrors(ctx context.Context,
 	groupCR *usernautdevv1alpha1.Group,
-	backendErrors map[string]map[string]string) error {
-	backendStatus := make([]usernautdevv1alpha1.BackendStatus, 0, len(groupCR.Spec.Backends))
+	processedBackends []usernautdevv1alpha1.Backend,
+	backendErrors map[string]map[string]string,
+	backendResults map[string]backendSyncResult,
+	failedTeardowns []usernautdevv1alpha1.BackendStatus) (time.Duration, error) {
+	log := logger.Logger(ctx)
+	previousStatus := make(map[string]usernautdevv1alpha1.BackendStatus, len(groupCR.Status.BackendsStatus))
+	for _, status := range groupCR.Status.BackendsStatus {
+		previousStatus[status.Name+"_"+status.Type] = status
+	}
+	processed := make(map[string]bool, len(processedBackends))
+	for _, backend := range processedBackends {
+		processed[backend.Name+"_"+backend.Type] = true
+	}
+
+	groupBackends := r.groupBackends(groupCR)
+	backendStatus := make([]usernautdevv1alpha1.BackendStatus, 0, len(groupBackends))
+	var retryAfter time.Duration
+	retryBaseDelay, retryMaxDelay := r.backendRetryDelays(ctx)
+	now := metav1.Now()
 
 	// Build status for each backend
-	for _, backend := range groupCR.Spec.Backends {
+	for _, backend := range groupBackends {
+		backendKey := backend.Name + "_" + backend.Type
+		previous, hasPrevious := previousStatus[backendKey]
+		if !processed[backendKey] && hasPrevious {
+			previous.DeletionPolicy = r.selectorDeletionPolicy(groupCR, backend)
+			backendStatus = append(backendStatus, previous)
+			continue
+		}
+
+		result := backendResults[backendKey]
 		status := usernautdevv1alpha1.BackendStatus{
-			Name: backend.Name,
-			Type: backend.Type,
+			Name:                 backend.Name,
+			Type:                 backend.Type,
+			Status:               true,
+			Message:              "Successful",
+			ObservedGeneration:   groupCR.Generation,
+			TeamID:               result.teamID,
+			MemberCount:          result.memberCount,
+			UsersAdded:           result.usersAdded,
+			UsersRemoved:         result.usersRemoved,
+			LastSyncTime:         &now,
+			MembersOnlyInBackend: result.onlyInBackend,
+			MembersOnlyInSpec:    result.onlyInSpec,
+			DirectMembers:        result.directMembers,
+			NestedTeams:          result.nestedTeams,
+			DeletionPolicy:       r.selectorDeletionPolicy(groupCR, backend),
 		}
-		if typeMap, ok := backendErrors[backend.Type]; ok {
-			if msg, found := typeMap[backend.Name]; found {
-				status.Status = false
-				status.Message = msg
-			} else {
-				status.Status = true
-				status.Message = "Successful"
+		// The nested teams are remembered while they are not synced, e.g. once the mirror mode is disabled
+		if !result.nestedTeamsSynced && hasPrevious {
+			status.NestedTeams = previous.NestedTeams
+		}
+		if msg, found := backendErrors[backend.Type][backend.Name]; found {
+			// A failed sync keeps the counts of the last successful one
+			status.MemberCount, status.UsersAdded, status.UsersRemoved = 0, 0, 0
+			status.LastSyncTime = nil
+			if hasPrevious {
+				status.MemberCount = previous.MemberCount
+				status.UsersAdded = previous.UsersAdded
+				status.UsersRemoved = previous.UsersRemoved
+				status.LastSyncTime = previous.LastSyncTime
+				if status.TeamID == "" {
+					status.TeamID = previous.TeamID
+				}
+				// Without a fresh diff the last drift report is kept
+				if !result.driftComputed {
+					status.MembersOnlyInBackend = previous.MembersOnlyInBackend
+					status.MembersOnlyInSpec = previous.MembersOnlyInSpec
+				}
+				if !result.directMembersAudited {
+					status.DirectMembers = previous.DirectMembers
+				}
+			}
+			status.Status = false
+			status.Message = msg
+			status.Retries = 1
+			if hasPrevious && !previous.Status && previous.ObservedGeneration == groupCR.Generation {
+				status.Retries = previous.Retries + 1
+			}
+			if delay := backendRetryDelay(status.Retries, retryBaseDelay, retryMaxDelay); retryAfter == 0 || delay < retryAfter {
+				retryAfter = delay
 			}
-		} else {
-			status.Status = true
-			status.Message = "Successful"
 		}
 		backendStatus = append(backendStatus, status)
 	}
 
+	// Backends removed from the spec stay in the status until their team is torn down
+	backendStatus = append(backendStatus, failedTeardowns...)
+
 	// Update CR status
 	groupCR.Status.BackendsStatus = backendStatus
 	groupCR.UpdateStatus(false)
-	hasErrors := false
+	var failedBackends []string
+	for _, status := range backendStatus {
+		if !status.Status {
+			failedBackends = append(failedBackends, status.Type+"/"+status.Name)
+		}
+	}
+	groupCR.Status.ReadyBackends = fmt.Sprintf("%d/%d", len(backendStatus)-len(failedBackends), len(backendStatus))
+	groupCR.Status.MemberCount = len(groupCR.Status.ReconciledUsers)
+	hasErrors := len(failedTeardowns) > 0
 	for _, m := range backendErrors {
 		if len(m) > 0 {
 			hasErrors = true
@@ -693,357 +1702,945 @@ func (r *GroupReconciler) updateStatusAndHandleErrors(ctx context.Context,
 		}
 	}
 	if hasErrors {
+		r.setGroupCondition(groupCR, usernautdevv1alpha1.BackendsReadyCondition, false,
+			usernautdevv1alpha1.BackendReconcileFailedReason,
+			"Failed to reconcile the backends "+strings.Join(failedBackends, ", "))
 		groupCR.UpdateStatus(true)
+		// The backends are out of sync with the membership until all of them are synced again
+		groupCR.Status.MembershipHash = ""
+		if retryAfter == 0 {
+			retryAfter = retryBaseDelay
+		}
+	} else {
+		r.setGroupCondition(groupCR, usernautdevv1alpha1.BackendsReadyCondition, true,
+			usernautdevv1alpha1.BackendsReconciledReason, "All the backends reconciled")
+		// A retry of the failed backends or a forced backend only syncs some of the backends, the
+		// others were synced with the membership of an earlier reconcile
+		allProcessed := !slices.ContainsFunc(groupBackends, func(backend usernautdevv1alpha1.Backend) bool {
+			return !processed[backend.Name+"_"+backend.Type]
+		})
+		if allProcessed {
+			groupCR.Status.LastSyncTime = &now
+			groupCR.Status.ObservedGeneration = groupCR.Generation
+			groupCR.Status.MembershipHash = membershipHash(groupCR.Status.ReconciledUsers, groupBackends)
+		}
 	}
 	if updateStatusErr := r.Status().Update(ctx, groupCR); updateStatusErr != nil {
-		r.log.WithError(updateStatusErr).Error("error while updating final status")
-		return updateStatusErr
+		log.WithError(updateStatusErr).Error("error while updating final status")
+		return 0, updateStatusErr
 	}
 
-	// Return error if any backends failed
-	if hasErrors {
-		return errors.New("failed to reconcile all backends")
-	}
-
-	return nil
+	return retryAfter, nil
 }
 
 // handleDeletion processes the deletion of a Group CR and its finalizer
 func (r *GroupReconciler) handleDeletion(ctx context.Context, groupCR *usernautdevv1alpha1.Group) error {
+	log := logger.Logger(ctx)
 	if controllerutil.ContainsFinalizer(groupCR, groupFinalizer) {
-		// Lock cache for deletion operations
-		// Multiple Group CRs might reference the same team and delete concurrently
-		r.CacheMutex.Lock()
-		defer r.CacheMutex.Unlock()
+		// Protected groups keep their finalizer, the deletion proceeds once the annotation is removed
+		if controllerutils.DeletionPrevented(groupCR) {
+			return r.preventDeletion(ctx, groupCR)
+		}
 
-		// Clean up user:groups reverse index for all members of this group
-		r.cleanupUserGroupsIndex(ctx, groupCR.Spec.GroupName)
+		// The backend teams and cache entries of a duplicate group belong to the group owning its group name
+		owner, err := r.groupNameOwner(ctx, groupCR)
+		if err != nil {
+			log.WithError(err).Error("error listing the groups with the same group name")
+			return err
+		}
+		if owner != "" {
+			log.WithField("owner", owner).Info("group name is managed by another group, keeping its backend teams")
+		} else {
+			// Clean up user:groups reverse index for all members of this group
+			r.cleanupUserGroupsIndex(ctx, groupCR.Spec.GroupName)
 
-		r.deleteBackendsTeam(ctx, groupCR)
+			r.deleteBackendsTeam(ctx, groupCR)
+		}
 
 		controllerutil.RemoveFinalizer(groupCR, groupFinalizer)
 		if err := r.Update(ctx, groupCR); err != nil {
-			r.log.WithError(err).Error("error while updating group CR")
+			log.WithError(err).Error("error while updating group CR")
 			return err
 		}
 	}
 	return nil
 }
 
+// preventDeletion keeps a deleted group protected by the prevent-deletion annotation and its backend teams
+func (r *GroupReconciler) preventDeletion(ctx context.Context, groupCR *usernautdevv1alpha1.Group) error {
+	log := logger.Logger(ctx)
+	log.Warn("group deletion is prevented by the prevent-deletion annotation, keeping the finalizer")
+
+	message := fmt.Sprintf("Deletion is prevented until the %s annotation is removed", constants.PreventDeletionAnnotation)
+	r.Recorder.Event(groupCR, corev1.EventTypeWarning, eventReasonDeletionPrevented, message)
+	r.setCondition(&groupCR.Status.Conditions, metav1.Condition{
+		Type:               usernautdevv1alpha1.GroupReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+		Reason:             usernautdevv1alpha1.DeletionPreventedReason,
+		ObservedGeneration: groupCR.Generation,
+	})
+	if err := r.Status().Update(ctx, groupCR); err != nil {
+		log.WithError(err).Error("error updating the status of the protected group")
+		return err
+	}
+	return nil
+}
+
 // cleanupUserGroupsIndex removes the group from all members' user:groups index
-// NOTE: Caller must hold CacheMutex lock
 // NOTE: This does NOT delete the group entry - that happens in deleteBackendsTeam
 func (r *GroupReconciler) cleanupUserGroupsIndex(ctx context.Context, groupName string) {
+	log := logger.Logger(ctx)
+	r.CacheMutex.Lock()
+	defer r.CacheMutex.Unlock()
 	// Get all members of the group
 	members, err := r.Store.Group.GetMembers(ctx, groupName)
 	if err != nil {
-		r.log.WithError(err).Warn("error fetching group members for cleanup")
+		log.WithError(err).Warn("error fetching group members for cleanup")
 		return // Nothing to clean up
 	}
 
 	// Remove the group from each member's user:groups index
 	for _, email := range members {
-		r.log.WithFields(logrus.Fields{
+		log.WithFields(logrus.Fields{
 			"user":  email,
 			"group": groupName,
 		}).Info("removing group from user's group list during deletion")
 		if err := r.Store.UserGroups.RemoveGroup(ctx, email, groupName); err != nil {
-			r.log.WithError(err).WithField("user", email).Error("error removing group from user's groups index during deletion")
+			log.WithError(err).WithField("user", email).Error("error removing group from user's groups index during deletion")
 			// Continue processing other members
 		}
 	}
 
-	r.log.WithField("group", groupName).Info("cleaned up user groups index successfully")
+	log.WithField("group", groupName).Info("cleaned up user groups index successfully")
 }
 
 // deleteBackendsTeam performs best-effort backend and cache cleanup during deletion.
 // It does not return an error: failures are logged so the finalizer can still be removed.
+// With the Retain deletion policy the backend teams are kept and only the group is removed from the cache.
 func (r *GroupReconciler) deleteBackendsTeam(ctx context.Context, groupCR *usernautdevv1alpha1.Group) {
-	r.log.Info("Finalizer: starting Backends team deletion cleanup")
+	log := logger.Logger(ctx)
+	log.Info("Finalizer: starting Backends team deletion cleanup")
 	groupName := groupCR.Spec.GroupName
 	hasErrors := false
 
-	for _, backend := range groupCR.Spec.Backends {
+	for _, backend := range r.groupBackends(groupCR) {
+		if !r.deleteBackendTeam(ctx, groupCR, backend) {
+			hasErrors = true
+		}
+
+		// Delete team entry from TeamStore (used for preload lookups)
 		// Use graceful fallback for deletion - we want to clean up even if pattern doesn't match
-		transformedGroupName := utils.GetTransformedGroupNameOrFallback(r.AppConfig, backend.Type, groupName)
-		backendLoggerInfo := r.log.WithFields(logrus.Fields{
-			"group_name":            groupName,
-			"transformed_team_name": transformedGroupName,
-			"backend":               backend.Name,
-			"backend_type":          backend.Type,
-		})
-		backendLoggerInfo.Info("Finalizer: Deleting team from backend")
+		transformedGroupName := utils.GetTransformedBackendGroupNameOrFallback(r.AppConfig, backend.Type, backend.Name, groupName)
+		if transformedGroupName != "" {
+			r.CacheMutex.Lock()
+			err := r.Store.Team.Delete(ctx, transformedGroupName)
+			r.CacheMutex.Unlock()
+			if err != nil {
+				log.WithError(err).WithField("backend", backend.Name).Warn("Finalizer: failed to delete team from TeamStore cache")
+				// Continue processing - TeamStore is secondary cache
+			}
+		}
+	}
+
+	// Delete the entire group entry from cache (includes all backends and members)
+	r.CacheMutex.Lock()
+	err := r.Store.Group.Delete(ctx, groupName)
+	r.CacheMutex.Unlock()
+	if err != nil {
+		log.WithError(err).Warn("Finalizer: failed to delete group from cache, may already be deleted")
+		hasErrors = true
+		// Don't return error - allow finalizer to complete
+	} else {
+		log.WithField("group", groupName).Info("Finalizer: Successfully deleted group from cache")
+	}
+
+	if hasErrors {
+		log.Warn("Finalizer: completed with some errors, but allowing deletion to proceed as it is a best-effort cleanup")
+	}
+}
+
+// deleteBackendTeam deletes the team of the group from a single backend, or keeps it with the Retain
+// deletion policy. It reports whether the cleanup completed without errors.
+func (r *GroupReconciler) deleteBackendTeam(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group, backend usernautdevv1alpha1.Backend) bool {
+	groupName := groupCR.Spec.GroupName
+	// Use graceful fallback for deletion - we want to clean up even if pattern doesn't match
+	transformedGroupName := utils.GetTransformedBackendGroupNameOrFallback(r.AppConfig, backend.Type, backend.Name, groupName)
+	backendLoggerInfo := logger.Logger(ctx).WithFields(logrus.Fields{
+		"group_name":            groupName,
+		"transformed_team_name": transformedGroupName,
+		"backend":               backend.Name,
+		"backend_type":          backend.Type,
+	})
+	if groupCR.Spec.DeletionPolicy == usernautdevv1alpha1.DeletionPolicyRetain {
+		backendLoggerInfo.Info("deletion policy is Retain, keeping the team in the backend")
+		r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonTeamRetained,
+			"Retained team %s in backend %s/%s", transformedGroupName, backend.Type, backend.Name)
+		return true
+	}
+
+	backendLoggerInfo.Info("Deleting team from backend")
+
+	backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+	if err != nil {
+		backendLoggerInfo.WithError(err).Warnf("error creating client for backend %s, skipping this backend", backend.Name)
+		return false
+	}
+
+	ok := true
+	// Get team ID from consolidated group store (using original group name)
+	r.CacheMutex.RLock()
+	teamID, err := r.Store.Group.GetBackendID(ctx, groupName, backend.Name, backend.Type)
+	r.CacheMutex.RUnlock()
+	if err != nil {
+		backendLoggerInfo.WithError(err).Warn("error fetching team details from cache, team may not have been created")
+		ok = false
+	}
+
+	// Same resolution order as fetchOrCreateTeam: GroupStore first, then TeamStore (preload) by transformed name
+	if teamID == "" && transformedGroupName != "" {
+		backendKey := backend.Name + "_" + backend.Type
+		r.CacheMutex.RLock()
+		teamBackends, tsErr := r.Store.Team.GetBackends(ctx, transformedGroupName)
+		r.CacheMutex.RUnlock()
+		if tsErr != nil {
+			backendLoggerInfo.WithError(tsErr).Warn("error fetching team from TeamStore during deletion")
+			ok = false
+		} else if id, found := teamBackends[backendKey]; found && id != "" {
+			backendLoggerInfo.WithField("team_id", id).Info("resolved team ID from TeamStore for backend deletion")
+			teamID = id
+		}
+	}
+
+	if teamID != "" {
+		backendLoggerInfo.Infof("Deleting team with (ID: %s) from Backend %s", teamID, backend.Type)
+
+		if err := backendClient.DeleteTeamByID(ctx, teamID); err != nil {
+			backendLoggerInfo.WithError(err).Warn("failed to delete team from the backend, team may already be deleted")
+			r.Recorder.Eventf(groupCR, corev1.EventTypeWarning, eventReasonTeamDeleteFailed,
+				"Failed to delete team %s from backend %s/%s: %v", transformedGroupName, backend.Type, backend.Name, err)
+			return false
+		}
+		backendLoggerInfo.Infof("Successfully deleted team with id '%s' from Backend %s", teamID, backend.Type)
+		r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonTeamDeleted,
+			"Deleted team %s from backend %s/%s", transformedGroupName, backend.Type, backend.Name)
+	} else if strings.EqualFold(backend.Type, "snowflake") && transformedGroupName != "" {
+		// Snowflake uses the role name as the REST identifier (see snowflake.CreateTeam / DeleteTeamByID).
+		roleName := strings.ToLower(transformedGroupName)
+		backendLoggerInfo.WithField("role_name", roleName).Info("no cached team ID; attempting Snowflake role delete by name")
+		if err := backendClient.DeleteTeamByID(ctx, roleName); err != nil {
+			backendLoggerInfo.WithError(err).Warn("Snowflake delete by role name failed; role may not exist or actual name may differ (e.g. pattern changed since create)")
+			return false
+		}
+		backendLoggerInfo.Infof("Successfully deleted Snowflake role '%s'", roleName)
+		r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonTeamDeleted,
+			"Deleted team %s from backend %s/%s", roleName, backend.Type, backend.Name)
+	} else {
+		backendLoggerInfo.Info("No team ID found in cache, skipping backend deletion")
+	}
+	return ok
+}
+
+// removedBackends returns the backends recorded in the status by a previous reconcile
+// that are no longer among the backends of the group
+func removedBackends(groupCR *usernautdevv1alpha1.Group,
+	groupBackends []usernautdevv1alpha1.Backend) []usernautdevv1alpha1.Backend {
+	inSpec := make(map[string]bool, len(groupBackends))
+	for _, backend := range groupBackends {
+		inSpec[backend.Name+"_"+backend.Type] = true
+	}
+
+	removed := make([]usernautdevv1alpha1.Backend, 0)
+	for _, status := range groupCR.Status.BackendsStatus {
+		if !inSpec[status.Name+"_"+status.Type] {
+			removed = append(removed, usernautdevv1alpha1.Backend{Name: status.Name, Type: status.Type})
+		}
+	}
+	return removed
+}
+
+// teardownRemovedBackends deletes the teams of the backends removed from the spec since the last
+// reconcile and drops them from the cache. The teams of the backends no longer attached by a backend
+// selector are retained, unless the selector set the Delete deletion policy. It returns the status of
+// the backends whose teardown failed, so they are kept in the status and retried by the next reconcile.
+func (r *GroupReconciler) teardownRemovedBackends(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group) []usernautdevv1alpha1.BackendStatus {
+	log := logger.Logger(ctx)
+	previousStatus := make(map[string]usernautdevv1alpha1.BackendStatus, len(groupCR.Status.BackendsStatus))
+	for _, status := range groupCR.Status.BackendsStatus {
+		previousStatus[status.Name+"_"+status.Type] = status
+	}
+
+	failed := make([]usernautdevv1alpha1.BackendStatus, 0)
+	for _, backend := range removedBackends(groupCR, r.groupBackends(groupCR)) {
+		backendKey := backend.Name + "_" + backend.Type
+		if previousStatus[backendKey].DeletionPolicy == usernautdevv1alpha1.DeletionPolicyRetain {
+			log.WithField("backend", backendKey).Info("backend is no longer attached by a backend selector, keeping its team")
+			r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonTeamRetained,
+				"Retained the team in backend %s/%s no longer attached by a backend selector", backend.Type, backend.Name)
+		} else {
+			log.WithField("backend", backendKey).Info("backend was removed from the spec, tearing down its team")
+			if !r.deleteBackendTeam(ctx, groupCR, backend) {
+				status := previousStatus[backendKey]
+				status.Status = false
+				status.Message = "failed to delete the team of the backend removed from the spec"
+				failed = append(failed, status)
+				continue
+			}
+		}
+
+		transformedGroupName := utils.GetTransformedBackendGroupNameOrFallback(
+			r.AppConfig, backend.Type, backend.Name, groupCR.Spec.GroupName)
+		r.CacheMutex.Lock()
+		if transformedGroupName != "" {
+			if err := r.Store.Team.DeleteBackend(ctx, transformedGroupName, backendKey); err != nil {
+				log.WithError(err).WithField("backend", backendKey).Warn("failed to delete team from TeamStore cache")
+			}
+		}
+		if err := r.Store.Group.DeleteBackend(ctx, groupCR.Spec.GroupName, backend.Name, backend.Type); err != nil {
+			log.WithError(err).WithField("backend", backendKey).Warn("failed to delete backend from the group cache")
+		}
+		r.CacheMutex.Unlock()
+	}
+	return failed
+}
+
+func (r *GroupReconciler) processUsers(ctx context.Context,
+	groupUsers []string,
+	existingTeamMembers map[string]*structs.User,
+	memberRoles map[string]string,
+	backendName, backendType string) ([]string, []string, []string, error) {
+	backendLogger := logger.Logger(ctx)
+
+	userIDsToSync := make([]string, 0)
+	usersToAdd := make([]string, 0)
+	usersToRemove := make([]string, 0)
+	usersToDemote := make([]string, 0)
+	defaultRole := r.defaultMemberRole(backendName, backendType)
+
+	for _, user := range groupUsers {
+		userDetails := ldapUsers(ctx)[user]
+		if userDetails == nil {
+			backendLogger.WithField("user", user).Warn("user not found in LDAP data, skipping processing for this user")
+
+			// we need to check if the user is already in the existing team members
+			if _, exists := existingTeamMembers[user]; exists {
+				backendLogger.WithField("user", user).Info("user is already in existing team members, skipping user creation")
+				usersToRemove = append(usersToRemove, user)
+			}
+			continue
+		}
 
-		backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+		// Get user backends from cache
+		r.CacheMutex.RLock()
+		userBackends, err := r.Store.User.GetBackends(ctx, userDetails.GetEmail())
+		r.CacheMutex.RUnlock()
 		if err != nil {
-			backendLoggerInfo.WithError(err).Warnf("Finalizer: error creating client for backend %s, skipping this backend", backend.Name)
-			hasErrors = true
-			continue // Skip this backend but continue with others
+			backendLogger.WithError(err).Error("error fetching user details from cache")
+			return nil, nil, nil, err
+		}
+
+		backendKey := backendName + "_" + backendType
+		userID := userBackends[backendKey]
+		if userID == "" {
+			backendLogger.WithField("user", user).Warn("user ID not found in cache, will create user in backend")
+			return nil, nil, nil, errors.New("user ID not found in cache")
+		}
+		userIDsToSync = append(userIDsToSync, userID)
+
+		// Members holding another role than the default one without an explicit role anymore, e.g. former
+		// owners of the group or members whose role was removed, are demoted
+		member, exists := existingTeamMembers[userID]
+		if _, hasRole := memberRoles[user]; exists && !hasRole && defaultRole != "" && member.Role != "" &&
+			!strings.EqualFold(member.Role, defaultRole) {
+			usersToDemote = append(usersToDemote, userID)
+		}
+	}
+
+	// process existing team members to find users to remove
+	for userID := range existingTeamMembers {
+		if !slices.Contains(userIDsToSync, userID) {
+			usersToRemove = append(usersToRemove, userID)
+		}
+	}
+
+	// process group users to find users to add
+	// if user is not present in existing team members, then add the user to the team
+	for _, userID := range userIDsToSync {
+		if _, exists := existingTeamMembers[userID]; !exists {
+			usersToAdd = append(usersToAdd, userID)
 		}
+	}
+
+	return usersToAdd, usersToRemove, usersToDemote, nil
+}
+
+// resolvedMembers returns the sorted IDs of the members of the team once the users are added to and
+// removed from its existing members
+func resolvedMembers(existingTeamMembers map[string]*structs.User, usersToAdd, usersToRemove []string) []string {
+	resolved := make([]string, 0, len(existingTeamMembers)+len(usersToAdd))
+	for userID := range existingTeamMembers {
+		if !slices.Contains(usersToRemove, userID) {
+			resolved = append(resolved, userID)
+		}
+	}
+	for _, userID := range usersToAdd {
+		if _, exists := existingTeamMembers[userID]; !exists {
+			resolved = append(resolved, userID)
+		}
+	}
+	slices.Sort(resolved)
+	return resolved
+}
+
+// checkMassRemoval refuses to remove more members of a team of teamSize members than the mass removal
+// guard of the configuration allows. The force reconcile label overrides the guard.
+func (r *GroupReconciler) checkMassRemoval(groupCR *usernautdevv1alpha1.Group, removals, teamSize int) error {
+	if _, force := groupCR.GetLabels()[constants.ForceReconcileLabel]; force || removals == 0 {
+		return nil
+	}
+	guard := r.AppConfig.ControllerConfig.MassRemovalGuard
+	if guard.MaxRemovals > 0 && removals > guard.MaxRemovals {
+		return fmt.Errorf("refusing to remove %d members, more than the %d allowed at once, "+
+			"add the %s label to override", removals, guard.MaxRemovals, constants.ForceReconcileLabel)
+	}
+	if guard.MaxRemovalPercent > 0 && teamSize > 0 && removals*100 > guard.MaxRemovalPercent*teamSize {
+		return fmt.Errorf("refusing to remove %d of the %d team members, more than the %d%% allowed at once, "+
+			"add the %s label to override", removals, teamSize, guard.MaxRemovalPercent, constants.ForceReconcileLabel)
+	}
+	return nil
+}
+
+// directMemberAudit returns the direct member audit mode of the backend, empty when disabled
+func (r *GroupReconciler) directMemberAudit(backend usernautdevv1alpha1.Backend) string {
+	return r.AppConfig.BackendMap[backend.Type][backend.Name].DirectMemberAudit
+}
+
+// auditDirectMembers returns the direct members of the team managed by the dependency of the
+// backend which are not in the spec, named like the drift report. They are removed from the team
+// when the audit of the backend is "remove", within the limits of the mass removal guard.
+func (r *GroupReconciler) auditDirectMembers(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group,
+	teamID string,
+	backend usernautdevv1alpha1.Backend,
+	auditor clients.DirectMemberClient,
+	uniqueMembers []string,
+	memberRoles map[string]string,
+) ([]string, error) {
+	backendLogger := logger.Logger(ctx)
+
+	directMembers, err := auditor.FetchDirectTeamMembers(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the direct team members: %w", err)
+	}
+	_, unmanagedMembers, _, err := r.processUsers(ctx, uniqueMembers, directMembers, memberRoles,
+		backend.Name, backend.Type)
+	if err != nil {
+		return nil, err
+	}
+	if len(unmanagedMembers) == 0 {
+		return nil, nil
+	}
+	reported, _ := r.memberDrift(ctx, uniqueMembers, directMembers, nil, unmanagedMembers,
+		backend.Name, backend.Type)
+	backendLogger.WithField("direct_members", reported).Warn("found members added directly to the team")
+
+	if r.directMemberAudit(backend) != config.DirectMemberAuditRemove {
+		return reported, nil
+	}
+	if err := r.checkMassRemoval(groupCR, len(unmanagedMembers), len(directMembers)); err != nil {
+		return reported, err
+	}
+	if err := clients.InBatches(unmanagedMembers, r.membershipBatchSize(backend), func(batch []string) error {
+		return auditor.RemoveDirectTeamMembers(ctx, teamID, batch)
+	}); err != nil {
+		return reported, fmt.Errorf("error removing the direct team members: %w", err)
+	}
+	backendLogger.WithField("direct_members", reported).Info("removed the direct members from the team")
+	r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonUsersRemoved,
+		"Removed %d direct members from the team in backend %s/%s", len(unmanagedMembers), backend.Type, backend.Name)
+	return reported, nil
+}
+
+// memberDrift returns the team members not in the spec and the members missing from the team,
+// named by their email or username rather than their backend user ID. The lists are sorted
+// and capped at maxDriftMembers entries to bound the size of the status.
+func (r *GroupReconciler) memberDrift(ctx context.Context,
+	groupUsers []string,
+	existingTeamMembers map[string]*structs.User,
+	usersToAdd, usersToRemove []string,
+	backendName, backendType string) ([]string, []string) {
+	onlyInBackend := make([]string, 0, len(usersToRemove))
+	for _, userID := range usersToRemove {
+		name := userID
+		if member := existingTeamMembers[userID]; member != nil {
+			name = cmp.Or(member.GetEmail(), member.GetUserName(), userID)
+		}
+		onlyInBackend = append(onlyInBackend, name)
+	}
+
+	onlyInSpec := make([]string, 0, len(usersToAdd))
+	if len(usersToAdd) > 0 {
+		toAdd := make(map[string]bool, len(usersToAdd))
+		for _, userID := range usersToAdd {
+			toAdd[userID] = true
+		}
+		backendKey := backendName + "_" + backendType
+		for _, user := range groupUsers {
+			userDetails := ldapUsers(ctx)[user]
+			if userDetails == nil {
+				continue
+			}
+			r.CacheMutex.RLock()
+			userBackends, err := r.Store.User.GetBackends(ctx, userDetails.GetEmail())
+			r.CacheMutex.RUnlock()
+			if err != nil {
+				logger.Logger(ctx).WithError(err).WithField("user", user).Warn("error fetching user details from cache for the drift report")
+				continue
+			}
+			if toAdd[userBackends[backendKey]] {
+				onlyInSpec = append(onlyInSpec, user)
+			}
+		}
+	}
+
+	return capDrift(onlyInBackend), capDrift(onlyInSpec)
+}
+
+// capDrift sorts the members of a drift report and keeps the first maxDriftMembers of them
+func capDrift(members []string) []string {
+	if len(members) == 0 {
+		return nil
+	}
+	slices.Sort(members)
+	members = slices.Compact(members)
+	if len(members) > maxDriftMembers {
+		members = members[:maxDriftMembers]
+	}
+	return members
+}
 
-		// Get team ID from consolidated group store (using original group name)
-		// NOTE: CacheMutex is already held by caller (handleDeletion)
-		teamID, err := r.Store.Group.GetBackendID(ctx, groupName, backend.Name, backend.Type)
-		if err != nil {
-			backendLoggerInfo.WithError(err).Warn("Finalizer: error fetching team details from cache, team may not have been created")
-			hasErrors = true
-		}
+// withOwnerRoles grants the owner role of the backend type to the owners of the group,
+// over any role set for them in the members
+func withOwnerRoles(memberRoles map[string]string, owners []string, backendType string) map[string]string {
+	ownerRole, ok := backendOwnerRoles[backendType]
+	if !ok {
+		return memberRoles
+	}
+	for _, owner := range owners {
+		memberRoles[owner] = ownerRole
+	}
+	return memberRoles
+}
 
-		// Same resolution order as fetchOrCreateTeam: GroupStore first, then TeamStore (preload) by transformed name
-		if teamID == "" && transformedGroupName != "" {
-			backendKey := backend.Name + "_" + backend.Type
-			teamBackends, tsErr := r.Store.Team.GetBackends(ctx, transformedGroupName)
-			if tsErr != nil {
-				backendLoggerInfo.WithError(tsErr).Warn("Finalizer: error fetching team from TeamStore during deletion")
-				hasErrors = true
-			} else if id, ok := teamBackends[backendKey]; ok && id != "" {
-				backendLoggerInfo.WithField("team_id", id).Info("Finalizer: resolved team ID from TeamStore for backend deletion")
-				teamID = id
+// memberRolesForBackend returns the role of each member with an explicit role for the backend type.
+// A role scoped to the backend type takes precedence over a role without a backend, which only
+// applies to the backends accepting it (see clients.MemberRoleValidator).
+func memberRolesForBackend(roles []usernautdevv1alpha1.MemberRole, backendType string,
+	backendClient clients.Client) map[string]string {
+	validator, validates := clients.As[clients.MemberRoleValidator](backendClient)
+	memberRoles := make(map[string]string, len(roles))
+	for _, memberRole := range roles {
+		if memberRole.Backend == "" && validates && validator.ValidMemberRole(memberRole.Role) {
+			if _, exists := memberRoles[memberRole.User]; !exists {
+				memberRoles[memberRole.User] = memberRole.Role
 			}
 		}
+	}
+	for _, memberRole := range roles {
+		if memberRole.Backend == backendType {
+			memberRoles[memberRole.User] = memberRole.Role
+		}
+	}
+	return memberRoles
+}
 
-		if teamID != "" {
-			backendLoggerInfo.Infof("Finalizer: Deleting team with (ID: %s) from Backend %s", teamID, backend.Type)
+// backendRoles are the roles of the users and the team created in a backend for a group
+type backendRoles struct {
+	user string
+	team string
+}
 
-			if err := backendClient.DeleteTeamByID(ctx, teamID); err != nil {
-				backendLoggerInfo.WithError(err).Warn("Finalizer: failed to delete team from the backend, team may already be deleted")
-				hasErrors = true
-				// Continue processing - best effort deletion
-			} else {
-				backendLoggerInfo.Infof("Finalizer: Successfully deleted team with id '%s' from Backend %s", teamID, backend.Type)
-			}
-		} else if strings.EqualFold(backend.Type, "snowflake") && transformedGroupName != "" {
-			// Snowflake uses the role name as the REST identifier (see snowflake.CreateTeam / DeleteTeamByID).
-			roleName := strings.ToLower(transformedGroupName)
-			backendLoggerInfo.WithField("role_name", roleName).Info("Finalizer: no cached team ID; attempting Snowflake role delete by name")
-			if err := backendClient.DeleteTeamByID(ctx, roleName); err != nil {
-				backendLoggerInfo.WithError(err).Warn("Finalizer: Snowflake delete by role name failed; role may not exist or actual name may differ (e.g. pattern changed since create)")
-				hasErrors = true
-			} else {
-				backendLoggerInfo.Infof("Finalizer: Successfully deleted Snowflake role '%s'", roleName)
-			}
-		} else {
-			backendLoggerInfo.Info("Finalizer: No team ID found in cache, skipping backend deletion")
-		}
+// defaultBackendRoles returns the default roles of the backend config, falling back to the default
+// account role of the backend type
+func defaultBackendRoles(appConfig *config.AppConfig, backendName, backendType string) backendRoles {
+	roles := backendRoles{}
+	if appConfig != nil {
+		defaults := appConfig.BackendMap[backendType][backendName].DefaultRoles
+		roles = backendRoles{user: defaults.User, team: defaults.Team}
+	}
+	if roles.user == "" {
+		roles.user = backendDefaultAccountRoles[backendType]
+	}
+	if roles.team == "" {
+		roles.team = backendDefaultAccountRoles[backendType]
+	}
+	return roles
+}
 
-		// Delete team entry from TeamStore (used for preload lookups)
-		if transformedGroupName != "" {
-			if err := r.Store.Team.Delete(ctx, transformedGroupName); err != nil {
-				backendLoggerInfo.WithError(err).Warn("Finalizer: failed to delete team from TeamStore cache")
-				// Continue processing - TeamStore is secondary cache
-			}
-		}
+// withParam sets the role of a user_role or team_role group param
+func (b backendRoles) withParam(property string, values []string) backendRoles {
+	if len(values) == 0 {
+		return b
+	}
+	switch property {
+	case clients.GroupParamUserRole:
+		b.user = values[0]
+	case clients.GroupParamTeamRole:
+		b.team = values[0]
 	}
+	return b
+}
 
-	// Delete the entire group entry from cache (includes all backends and members)
-	if err := r.Store.Group.Delete(ctx, groupName); err != nil {
-		r.log.WithError(err).Warn("Finalizer: failed to delete group from cache, may already be deleted")
-		hasErrors = true
-		// Don't return error - allow finalizer to complete
-	} else {
-		r.log.WithField("group", groupName).Info("Finalizer: Successfully deleted group from cache")
+// override replaces the roles with the non-empty roles of the overrides
+func (b backendRoles) override(overrides backendRoles) backendRoles {
+	if overrides.user != "" {
+		b.user = overrides.user
+	}
+	if overrides.team != "" {
+		b.team = overrides.team
 	}
+	return b
+}
 
-	if hasErrors {
-		r.log.Warn("Finalizer: completed with some errors, but allowing deletion to proceed as it is a best-effort cleanup")
+// defaultMemberRole returns the team role of the members without an explicit role, the member role
+// of the default roles of the backend config or else the default of the backend type
+func (r *GroupReconciler) defaultMemberRole(backendName, backendType string) string {
+	if r.AppConfig != nil {
+		if role := r.AppConfig.BackendMap[backendType][backendName].DefaultRoles.Member; role != "" {
+			return role
+		}
 	}
+	return backendDefaultRoles[backendType]
 }
 
-func (r *GroupReconciler) processUsers(ctx context.Context,
+// syncMemberRoles adds the members with an explicit role to the team with that role and updates the role
+// of existing members when it differs, demoted members get the backend default role back.
+// It returns the users still to be added with the backend default role.
+func (r *GroupReconciler) syncMemberRoles(ctx context.Context,
+	groupCR *usernautdevv1alpha1.Group,
+	teamID string,
+	backend usernautdevv1alpha1.Backend,
+	backendClient clients.Client,
 	groupUsers []string,
+	memberRoles map[string]string,
 	existingTeamMembers map[string]*structs.User,
-	backendName, backendType string) ([]string, []string, error) {
-
-	userIDsToSync := make([]string, 0)
-	usersToAdd := make([]string, 0)
-	usersToRemove := make([]string, 0)
+	usersToAdd []string,
+	usersToDemote []string) ([]string, error) {
+	backendLogger := logger.Logger(ctx)
+
+	// The members added with the default role of the backend config get it explicitly when it is not
+	// the default of the backend type
+	defaultRole := r.defaultMemberRole(backend.Name, backend.Type)
+	configuredDefault := defaultRole != backendDefaultRoles[backend.Type]
+	if len(memberRoles) == 0 && len(usersToDemote) == 0 && !configuredDefault {
+		return usersToAdd, nil
+	}
+	roleClient, ok := clients.As[clients.TeamRoleClient](backendClient)
+	if !ok {
+		backendLogger.Debug("backend does not support team member roles, member roles only apply to user creation")
+		return usersToAdd, nil
+	}
 
+	// Map the backend user IDs to the roles of the corresponding members
+	backendKey := backend.Name + "_" + backend.Type
+	userIDRoles := make(map[string]string, len(memberRoles))
 	for _, user := range groupUsers {
-		userDetails := r.allLdapUserData[user]
+		role, ok := memberRoles[user]
+		if !ok {
+			continue
+		}
+		userDetails := ldapUsers(ctx)[user]
 		if userDetails == nil {
-			r.backendLogger.WithField("user", user).Warn("user not found in LDAP data, skipping processing for this user")
-
-			// we need to check if the user is already in the existing team members
-			if _, exists := existingTeamMembers[user]; exists {
-				r.backendLogger.WithField("user", user).Info("user is already in existing team members, skipping user creation")
-				usersToRemove = append(usersToRemove, user)
-			}
 			continue
 		}
-
-		// NOTE: CacheMutex is already held by caller (Reconcile)
-		// Get user backends from cache
+		r.CacheMutex.RLock()
 		userBackends, err := r.Store.User.GetBackends(ctx, userDetails.GetEmail())
+		r.CacheMutex.RUnlock()
 		if err != nil {
-			r.backendLogger.WithError(err).Error("error fetching user details from cache")
-			return nil, nil, err
+			return nil, err
 		}
+		if userID := userBackends[backendKey]; userID != "" {
+			userIDRoles[userID] = role
+		}
+	}
 
-		backendKey := backendName + "_" + backendType
-		userID := userBackends[backendKey]
-		if userID == "" {
-			r.backendLogger.WithField("user", user).Warn("user ID not found in cache, will create user in backend")
-			return nil, nil, errors.New("user ID not found in cache")
+	defaultRoleUsers := make([]string, 0, len(usersToAdd))
+	usersToAddByRole := make(map[string][]string)
+	for _, userID := range usersToAdd {
+		if role, ok := userIDRoles[userID]; ok {
+			usersToAddByRole[role] = append(usersToAddByRole[role], userID)
+			continue
 		}
-		userIDsToSync = append(userIDsToSync, userID)
+		if configuredDefault {
+			usersToAddByRole[defaultRole] = append(usersToAddByRole[defaultRole], userID)
+			continue
+		}
+		defaultRoleUsers = append(defaultRoleUsers, userID)
 	}
 
-	// process existing team members to find users to remove
-	for userID := range existingTeamMembers {
-		if !slices.Contains(userIDsToSync, userID) {
-			usersToRemove = append(usersToRemove, userID)
+	usersToUpdateByRole := make(map[string][]string)
+	for userID, role := range userIDRoles {
+		member, exists := existingTeamMembers[userID]
+		if exists && !strings.EqualFold(member.Role, role) {
+			usersToUpdateByRole[role] = append(usersToUpdateByRole[role], userID)
 		}
 	}
+	if defaultRole != "" && len(usersToDemote) > 0 {
+		usersToUpdateByRole[defaultRole] = append(usersToUpdateByRole[defaultRole], usersToDemote...)
+	}
 
-	// process group users to find users to add
-	// if user is not present in existing team members, then add the user to the team
-	for _, userID := range userIDsToSync {
-		if _, exists := existingTeamMembers[userID]; !exists {
-			usersToAdd = append(usersToAdd, userID)
+	for role, userIDs := range usersToAddByRole {
+		if err := clients.InBatches(userIDs, r.membershipBatchSize(backend), func(batch []string) error {
+			return roleClient.AddUserToTeamWithRole(ctx, teamID, batch, role)
+		}); err != nil {
+			return nil, err
+		}
+		backendLogger.WithFields(logrus.Fields{
+			"role":         role,
+			"users_to_add": userIDs,
+		}).Info("added users to team with role successfully")
+		r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonUsersAdded,
+			"Added %d users with role %s to the team in backend %s/%s", len(userIDs), role, backend.Type, backend.Name)
+	}
+	for role, userIDs := range usersToUpdateByRole {
+		if err := clients.InBatches(userIDs, r.membershipBatchSize(backend), func(batch []string) error {
+			return roleClient.UpdateTeamMemberRole(ctx, teamID, batch, role)
+		}); err != nil {
+			return nil, err
 		}
+		backendLogger.WithFields(logrus.Fields{
+			"role":            role,
+			"users_to_update": userIDs,
+		}).Info("updated role of team members successfully")
+		r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonMemberRolesUpdated,
+			"Changed the role of %d members to %s in backend %s/%s", len(userIDs), role, backend.Type, backend.Name)
 	}
 
-	return usersToAdd, usersToRemove, nil
+	return defaultRoleUsers, nil
 }
 
 func (r *GroupReconciler) createUsersInBackendAndCache(ctx context.Context,
 	users []string,
+	memberRoles map[string]string,
+	defaultRole string,
 	backendName, backendType string,
 	backendClient clients.Client) error {
+	backendLogger := logger.Logger(ctx)
 
-	// NOTE: CacheMutex is already held by caller (Reconcile)
 	backendKey := backendName + "_" + backendType
+	normalization := r.AppConfig.BackendMap[backendType][backendName].UsernameNormalization
+	// The users of the backend are fetched once for all the members reported to already exist
+	existingUsers := controllerutils.NewExistingUsers(backendClient)
 
 	for _, user := range users {
-		userDetails := r.allLdapUserData[user]
+		userDetails := ldapUsers(ctx)[user]
 		if userDetails == nil {
-			r.backendLogger.WithField("user", user).Warn("user not found in LDAP data, skipping user creation")
+			backendLogger.WithField("user", user).Warn("user not found in LDAP data, skipping user creation")
 			continue
 		}
 
 		// Get user backends from cache
+		r.CacheMutex.RLock()
 		userBackends, err := r.Store.User.GetBackends(ctx, userDetails.GetEmail())
+		r.CacheMutex.RUnlock()
 		if err != nil {
-			r.backendLogger.WithField("user", user).WithError(err).Error("error fetching user details from cache")
+			backendLogger.WithField("user", user).WithError(err).Error("error fetching user details from cache")
 			return err
 		}
 
-		// Check if user already has ID for this backend
-		if userID, exists := userBackends[backendKey]; exists && userID != "" {
-			r.backendLogger.WithField("user", user).Debug("user already exists in cache")
+		// Check if user already has ID for this backend, the users without an account yet are looked
+		// up again
+		if userID, exists := userBackends[backendKey]; exists && userID != "" && !isPendingUser(backendClient, userID) {
+			backendLogger.WithField("user", user).Debug("user already exists in cache")
 			continue
 		}
 
+		// The member role becomes the account role on backends which assign one at creation (e.g. Fivetran),
+		// the other users get the default user role of the backend
+		role := defaultRole
+		if memberRole, ok := memberRoles[user]; ok {
+			role = memberRole
+		}
+
 		// if user details are not found in cache, create a new user in backend
 		// Standardize first/last names for backends (e.g. Fivetran) that do not support ., (, ), or , in names
-		newUser, err := backendClient.CreateUser(ctx, &structs.User{
+		// Users which already exist in the backend are looked up, and their ID repopulates the cache
+		newUser, err := controllerutils.CreateOrFindUser(ctx, backendClient, existingUsers, &structs.User{
 			Email:     userDetails.GetEmail(),
-			UserName:  user,
-			Role:      fivetran.AccountReviewerRole,
+			UserName:  normalization.Normalize(user),
+			Role:      role,
 			FirstName: utils.StandardizeNameForBackend(userDetails.GetDisplayName()),
 			LastName:  utils.StandardizeNameForBackend(userDetails.GetSN()),
 		})
 		if err != nil {
-			// TODO: handle the error in case user already exists in backend, we need to again populate the cache
-			r.backendLogger.WithField("user", user).WithError(err).Error("error creating user in backend")
+			backendLogger.WithField("user", user).WithError(err).Error("error creating user in backend")
 			return err
 		}
-		r.backendLogger.WithField("user", user).Info("created user in backend successfully")
+		backendLogger.WithField("user", user).Info("created user in backend successfully")
 
 		// Update cache with new user ID
-		if err := r.Store.User.SetBackend(ctx, userDetails.GetEmail(), backendKey, newUser.ID); err != nil {
-			r.backendLogger.Error(err, "error updating user details in cache")
+		r.CacheMutex.Lock()
+		err = r.Store.User.SetBackend(ctx, userDetails.GetEmail(), backendKey, newUser.ID)
+		r.CacheMutex.Unlock()
+		if err != nil {
+			backendLogger.Error(err, "error updating user details in cache")
 			return err
 		}
-		r.backendLogger.WithField("user", user).Info("updated user details in cache successfully")
+		backendLogger.WithField("user", user).Info("updated user details in cache successfully")
 	}
 	return nil
 }
 
+// isPendingUser reports whether the backend has no account yet for the user ID
+func isPendingUser(backendClient clients.Client, userID string) bool {
+	pendingClient, ok := clients.As[clients.PendingUserClient](backendClient)
+	return ok && pendingClient.IsPendingUser(userID)
+}
+
 func (r *GroupReconciler) fetchOrCreateTeam(ctx context.Context,
-	groupName string, backendClient clients.Client,
-	backendParams *structs.BackendParams) (string, error) {
+	groupCR *usernautdevv1alpha1.Group, backendClient clients.Client,
+	backendParams *structs.BackendParams, teamRole string) (string, error) {
+	backendLogger := logger.Logger(ctx)
+	groupName := groupCR.Spec.GroupName
 
 	backendName := backendParams.GetName()
 	backendType := backendParams.GetType()
 
 	// Get transformed group name for backend API calls (team name in backend system)
-	transformedGroupName, err := utils.GetTransformedGroupName(r.AppConfig, backendType, groupName)
+	transformedGroupName, err := utils.GetTransformedBackendGroupName(r.AppConfig, backendType, backendName, groupName)
 	if err != nil {
-		r.backendLogger.WithError(err).Error("error transforming the group Name")
+		backendLogger.WithError(err).Error("error transforming the group Name")
 		return "", err
 	}
 
 	backendKey := backendName + "_" + backendType
 
 	// Step 1: Check GroupStore first (using original group name)
+	r.CacheMutex.RLock()
 	teamID, err := r.Store.Group.GetBackendID(ctx, groupName, backendName, backendType)
+	r.CacheMutex.RUnlock()
 	if err != nil {
-		r.backendLogger.WithError(err).Error("error fetching team details from GroupStore")
+		backendLogger.WithError(err).Error("error fetching team details from GroupStore")
 		return "", err
 	}
 
 	if teamID != "" {
-		r.backendLogger.WithField("teamID", teamID).Info("team details found in GroupStore")
+		backendLogger.WithField("teamID", teamID).Info("team details found in GroupStore")
 		return teamID, nil
 	}
 
 	// Step 2: Fallback to TeamStore (using transformed name, populated during preload)
+	r.CacheMutex.RLock()
 	teamBackends, err := r.Store.Team.GetBackends(ctx, transformedGroupName)
+	r.CacheMutex.RUnlock()
 	if err != nil {
-		r.backendLogger.WithError(err).Error("error fetching team details from TeamStore")
+		backendLogger.WithError(err).Error("error fetching team details from TeamStore")
 		return "", err
 	}
 
 	if id, exists := teamBackends[backendKey]; exists && id != "" {
-		r.backendLogger.WithField("teamID", id).Info("team details found in TeamStore, migrating to GroupStore")
+		backendLogger.WithField("teamID", id).Info("team details found in TeamStore, migrating to GroupStore")
 
 		// Migrate data from TeamStore to GroupStore
-		if err := r.Store.Group.SetBackend(ctx, groupName, backendName, backendType, id); err != nil {
-			r.backendLogger.WithError(err).Error("error migrating team details to GroupStore")
+		r.CacheMutex.Lock()
+		err := r.Store.Group.SetBackend(ctx, groupName, backendName, backendType, id)
+		r.CacheMutex.Unlock()
+		if err != nil {
+			backendLogger.WithError(err).Error("error migrating team details to GroupStore")
 			return "", err
 		}
 
-		r.backendLogger.Info("successfully migrated team details from TeamStore to GroupStore")
+		backendLogger.Info("successfully migrated team details from TeamStore to GroupStore")
 		return id, nil
 	}
 
-	// Step 3: Team not found in either store, create a new team
-	r.backendLogger.Info("team details not found in cache, creating a new team")
+	// Step 3: Adopt-only groups never create a team, it must already exist in the backend
+	if groupCR.Spec.AdoptExisting {
+		return r.adoptExistingTeam(ctx, groupCR, backendClient, transformedGroupName, backendName, backendType)
+	}
+
+	// Step 4: Team not found in either store, create a new team
+	backendLogger.Info("team details not found in cache, creating a new team")
 
 	newTeam, err := backendClient.CreateTeam(ctx, &structs.Team{
 		Name:        transformedGroupName, // Use transformed name for backend API
 		Description: "team for " + groupName,
-		Role:        fivetran.AccountReviewerRole,
+		Role:        teamRole,
 	})
 	if err != nil {
-		r.backendLogger.WithError(err).Error("error creating team in backend")
+		backendLogger.WithError(err).Error("error creating team in backend")
 		return "", err
 	}
 
-	r.backendLogger.Info("created team in backend successfully")
+	backendLogger.Info("created team in backend successfully")
+	r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonTeamCreated,
+		"Created team %s in backend %s/%s", transformedGroupName, backendType, backendName)
 
 	// Store in GroupStore only - TeamStore is populated by preloadCache and used as read-only fallback
-	if err := r.Store.Group.SetBackend(ctx, groupName, backendName, backendType, newTeam.ID); err != nil {
-		r.backendLogger.WithError(err).Error("error updating team details in GroupStore")
+	r.CacheMutex.Lock()
+	err = r.Store.Group.SetBackend(ctx, groupName, backendName, backendType, newTeam.ID)
+	r.CacheMutex.Unlock()
+	if err != nil {
+		backendLogger.WithError(err).Error("error updating team details in GroupStore")
 		return "", err
 	}
 
-	r.backendLogger.Info("updated team details in GroupStore successfully")
+	backendLogger.Info("updated team details in GroupStore successfully")
 
 	return newTeam.ID, nil
 }
 
+// adoptExistingTeam looks up the team named after the transformed group name in the backend and
+// records it in the GroupStore. It fails when the backend has no such team.
+func (r *GroupReconciler) adoptExistingTeam(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	backendClient clients.Client, transformedGroupName, backendName, backendType string) (string, error) {
+	backendLogger := logger.Logger(ctx).WithField("team", transformedGroupName)
+	backendLogger.Info("team details not found in cache, looking up the team to adopt in the backend")
+
+	teams, err := backendClient.FetchAllTeams(ctx)
+	if err != nil {
+		backendLogger.WithError(err).Error("error fetching the teams of the backend")
+		return "", err
+	}
+
+	for _, team := range teams {
+		if team.GetName() != transformedGroupName || team.GetID() == "" {
+			continue
+		}
+
+		r.CacheMutex.Lock()
+		err := r.Store.Group.SetBackend(ctx, groupCR.Spec.GroupName, backendName, backendType, team.GetID())
+		r.CacheMutex.Unlock()
+		if err != nil {
+			backendLogger.WithError(err).Error("error updating team details in GroupStore")
+			return "", err
+		}
+
+		backendLogger.WithField("teamID", team.GetID()).Info("adopted existing team in backend")
+		r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonTeamAdopted,
+			"Adopted existing team %s in backend %s/%s", transformedGroupName, backendType, backendName)
+		return team.GetID(), nil
+	}
+
+	backendLogger.Warn("no existing team to adopt in backend")
+	return "", fmt.Errorf("adopt_existing is set but backend %s/%s has no team %s to adopt",
+		backendType, backendName, transformedGroupName)
+}
+
 // isGroupConfigurable checks if a group has matching patterns for all its backends
 // A group is considered configurable if at least one backend has a pattern that matches the group name
 func (r *GroupReconciler) isGroupConfigurable(groupCR *usernautdevv1alpha1.Group) bool {
-	if len(groupCR.Spec.Backends) == 0 {
+	groupBackends := r.groupBackends(groupCR)
+	if len(groupBackends) == 0 {
 		// No backends specified, consider it non-configurable
 		return false
 	}
 
-	for _, backend := range groupCR.Spec.Backends {
-		_, err := utils.GetTransformedGroupName(r.AppConfig, backend.Type, groupCR.Spec.GroupName)
+	for _, backend := range groupBackends {
+		_, err := utils.GetTransformedBackendGroupName(r.AppConfig, backend.Type, backend.Name, groupCR.Spec.GroupName)
 		if err == nil {
 			// At least one backend has a matching pattern
 			return true
@@ -1054,6 +2651,164 @@ func (r *GroupReconciler) isGroupConfigurable(groupCR *usernautdevv1alpha1.Group
 	return false
 }
 
+// groupNameOwner returns the name of the Group CR managing the group name of the group when it is
+// not the group itself. Of the CRs sharing a group name the oldest one owns it, ties are broken by name.
+func (r *GroupReconciler) groupNameOwner(ctx context.Context, groupCR *usernautdevv1alpha1.Group) (string, error) {
+	others, err := controllerutils.GroupsWithSameName(ctx, r.Client, groupCR)
+	if err != nil {
+		return "", err
+	}
+	owner := groupCR
+	for i := range others {
+		other := &others[i]
+		if other.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&owner.CreationTimestamp) && other.Name < owner.Name) {
+			owner = other
+		}
+	}
+	if owner == groupCR {
+		return "", nil
+	}
+	return owner.Name, nil
+}
+
+// setSuspendedCondition records on the status whether the reconciliation of the group is suspended
+func (r *GroupReconciler) setSuspendedCondition(groupCR *usernautdevv1alpha1.Group, suspended bool) {
+	condition := metav1.Condition{
+		Type:               usernautdevv1alpha1.GroupSuspendedCondition,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Message:            "Group reconciliation is active",
+		Reason:             "Resumed",
+		ObservedGeneration: groupCR.Generation,
+	}
+	if suspended {
+		condition.Status = metav1.ConditionTrue
+		condition.Message = "Group reconciliation is suspended, no backend is reconciled"
+		condition.Reason = "Suspended"
+	}
+	r.setCondition(&groupCR.Status.Conditions, condition)
+}
+
+// approvalRequired returns the reason and message of the approval the reconcile of the group needs,
+// the reason is empty when the group can be reconciled without an approval
+func (r *GroupReconciler) approvalRequired(groupCR *usernautdevv1alpha1.Group, members []string) (string, string) {
+	approval := r.AppConfig.ControllerConfig.Approval
+
+	// A new group is approved once, even if its first reconcile fails
+	if groupCR.Status.LastAppliedGeneration == 0 && len(groupCR.Status.ReconciledUsers) == 0 {
+		condition := meta.FindStatusCondition(groupCR.Status.Conditions, usernautdevv1alpha1.AwaitingApprovalCondition)
+		approved := condition != nil && condition.Reason == usernautdevv1alpha1.ApprovedReason
+		if approval.RequireForNewGroups && !approved {
+			return usernautdevv1alpha1.NewGroupApprovalReason, "New group needs an approval"
+		}
+		return "", ""
+	}
+
+	if approval.MaxMemberChanges <= 0 {
+		return "", ""
+	}
+	changes := memberChanges(groupCR.Status.ReconciledUsers, members)
+	if changes > approval.MaxMemberChanges {
+		return usernautdevv1alpha1.LargeChangeApprovalReason, fmt.Sprintf(
+			"%d member changes exceed the approval threshold of %d", changes, approval.MaxMemberChanges)
+	}
+	return "", ""
+}
+
+// awaitApproval marks the group as awaiting an approval of the change with the pending membership
+// hash, the backend teams are left untouched
+func (r *GroupReconciler) awaitApproval(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	reason, message, pending string) (ctrl.Result, error) {
+	log := logger.Logger(ctx)
+	log.WithField("reason", reason).Info("group is awaiting an approval, skipping")
+
+	message = fmt.Sprintf("%s, add the %s annotation to approve", message, constants.ApprovedByAnnotation)
+	if !meta.IsStatusConditionTrue(groupCR.Status.Conditions, usernautdevv1alpha1.AwaitingApprovalCondition) ||
+		groupCR.Status.PendingApproval != pending {
+		r.Recorder.Event(groupCR, corev1.EventTypeNormal, eventReasonAwaitingApproval, message)
+	}
+	groupCR.Status.PendingApproval = pending
+	r.setGroupCondition(groupCR, usernautdevv1alpha1.AwaitingApprovalCondition, true, reason, message)
+	r.setCondition(&groupCR.Status.Conditions, metav1.Condition{
+		Type:               usernautdevv1alpha1.GroupReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+		Reason:             "AwaitingApproval",
+		ObservedGeneration: groupCR.Generation,
+	})
+	if err := r.Status().Update(ctx, groupCR); err != nil {
+		log.WithError(err).Error("error updating the status of the group awaiting an approval")
+		return ctrl.Result{}, err
+	}
+	// Member changes coming from LDAP or member groups are evaluated again on the resync
+	return ctrl.Result{RequeueAfter: r.resyncInterval(ctx)}, nil
+}
+
+// consumeApproval removes the approved-by annotation of the group and records the approval,
+// the status is persisted with the rest of the reconcile
+func (r *GroupReconciler) consumeApproval(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	approver, message string) error {
+	if err := controllerutils.RemoveApprovedByAnnotation(ctx, r.Client, groupCR); err != nil {
+		return err
+	}
+	groupCR.Status.PendingApproval = ""
+	logger.Logger(ctx).WithField("approver", approver).Info("group was approved")
+	r.Recorder.Eventf(groupCR, corev1.EventTypeNormal, eventReasonApproved, "%s, approved by %s", message, approver)
+	r.setGroupCondition(groupCR, usernautdevv1alpha1.AwaitingApprovalCondition, false,
+		usernautdevv1alpha1.ApprovedReason, fmt.Sprintf("%s, approved by %s", message, approver))
+	return nil
+}
+
+// dropStaleApproval removes the approved-by annotation set while another change, or no change, was
+// awaiting an approval, so that an approval is never applied to a change the approver didn't see
+func (r *GroupReconciler) dropStaleApproval(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	approver string) error {
+	if approver == "" {
+		return nil
+	}
+	logger.Logger(ctx).WithField("approver", approver).Info("membership changed since the approval, approving again")
+	r.Recorder.Eventf(groupCR, corev1.EventTypeWarning, eventReasonAwaitingApproval,
+		"Approval by %s dropped, the membership changed since the change awaiting an approval", approver)
+	// the update returns the stored status, the status of the reconcile is restored afterwards
+	status := groupCR.Status.DeepCopy()
+	if err := controllerutils.RemoveApprovedByAnnotation(ctx, r.Client, groupCR); err != nil {
+		return err
+	}
+	groupCR.Status = *status
+	return nil
+}
+
+// setGroupCondition sets a condition of the group at its current generation, the transition
+// time only changes along with the status of the condition
+func (r *GroupReconciler) setGroupCondition(groupCR *usernautdevv1alpha1.Group, conditionType string,
+	ready bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&groupCR.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: groupCR.Generation,
+	})
+}
+
+// ldapQueryFailed reports on the LDAPReadyCondition that the LDAP query or LDAP groups of the group
+// could not be resolved, it returns the error aborting the reconcile
+func (r *GroupReconciler) ldapQueryFailed(ctx context.Context, groupCR *usernautdevv1alpha1.Group, err error) error {
+	r.setGroupCondition(groupCR, usernautdevv1alpha1.LDAPReadyCondition, false,
+		usernautdevv1alpha1.LDAPQueryFailedReason, err.Error())
+	groupCR.UpdateStatus(true)
+	if updateErr := r.Status().Update(ctx, groupCR); updateErr != nil {
+		logger.Logger(ctx).WithError(updateErr).Error("error updating group status for a failed LDAP query")
+	}
+	return err
+}
+
 // setCondition updates or adds a condition to the condition slice
 func (r *GroupReconciler) setCondition(conditions *[]metav1.Condition, newCondition metav1.Condition) {
 	if conditions == nil {
@@ -1073,44 +2828,135 @@ func (r *GroupReconciler) setCondition(conditions *[]metav1.Condition, newCondit
 // SetupWithManager sets up the controller with the Manager.
 func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Add an index field for referenced groups
-	indexField := "spec.members.groups"
 	groupType := &usernautdevv1alpha1.Group{}
-	indexFunc := func(obj client.Object) []string {
-		group := obj.(*usernautdevv1alpha1.Group)
-		return group.Spec.Members.Groups
-	}
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType, indexField, indexFunc); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType,
+		controllerutils.MemberGroupsIndexField, controllerutils.IndexMemberGroups); err != nil {
 		return err
 	}
 
-	// Create a mapping function to find all Group CRs that reference a changed Group CR
+	// Create a mapping function to find all Group CRs that include a changed Group CR, directly or
+	// through nested member groups
 	mapFunc := func(ctx context.Context, obj client.Object) []reconcile.Request {
-		group := obj.(*usernautdevv1alpha1.Group)
-		var referencingGroups usernautdevv1alpha1.GroupList
-
-		// Find all Group CRs that reference this Group in their spec.members.groups
-		if err := r.List(ctx, &referencingGroups, client.MatchingFields{
-			indexField: group.Name,
-		}); err != nil {
-			r.log.WithError(err).Error("error listing referencing groups")
+		ancestors, err := controllerutils.AncestorGroups(ctx, r.Client, obj.GetNamespace(), obj.GetName())
+		if err != nil {
+			logger.Logger(ctx).WithError(err).Error("error listing referencing groups")
 			return nil
 		}
 
 		// Create reconcile requests for each referencing Group
-		var requests []reconcile.Request
-		for _, referencingGroup := range referencingGroups.Items {
+		requests := make([]reconcile.Request, 0, len(ancestors))
+		for _, ancestor := range ancestors {
+			requests = append(requests, reconcile.Request{NamespacedName: ancestor})
+		}
+		return requests
+	}
+
+	// Add index fields for the ConfigMaps and Secrets listing members of groups
+	configMapIndexField := "spec.members.from_config_map.name"
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType, configMapIndexField,
+		func(obj client.Object) []string {
+			if source := obj.(*usernautdevv1alpha1.Group).Spec.Members.FromConfigMap; source != nil {
+				return []string{source.Name}
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+	secretIndexField := "spec.members.from_secret.name"
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType, secretIndexField,
+		func(obj client.Object) []string {
+			if source := obj.(*usernautdevv1alpha1.Group).Spec.Members.FromSecret; source != nil {
+				return []string{source.Name}
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+
+	userRefsIndexField := "spec.members.user_refs"
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType, userRefsIndexField,
+		func(obj client.Object) []string {
+			return obj.(*usernautdevv1alpha1.Group).Spec.Members.UserRefs
+		}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType,
+		controllerutils.GroupNameIndexField, controllerutils.IndexGroupName); err != nil {
+		return err
+	}
+
+	// Create a mapping function to find the duplicates of a deleted Group CR, one of them now owns its group name
+	groupNameMapFunc := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		duplicates, err := controllerutils.GroupsWithSameName(ctx, r.Client, obj.(*usernautdevv1alpha1.Group))
+		if err != nil {
+			logger.Logger(ctx).WithError(err).Error("error listing groups with the same group name")
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(duplicates))
+		for _, duplicate := range duplicates {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      referencingGroup.Name,
-					Namespace: referencingGroup.Namespace,
+					Name:      duplicate.Name,
+					Namespace: duplicate.Namespace,
 				},
 			})
 		}
 		return requests
 	}
+	deletePredicate := predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+
+	// Create a mapping function to find all Group CRs whose members are listed in a changed ConfigMap, Secret
+	// or User
+	memberSourceMapFunc := func(indexField string) handler.MapFunc {
+		return func(ctx context.Context, obj client.Object) []reconcile.Request {
+			var sourcingGroups usernautdevv1alpha1.GroupList
+			if err := r.List(ctx, &sourcingGroups, client.InNamespace(obj.GetNamespace()),
+				client.MatchingFields{indexField: obj.GetName()}); err != nil {
+				logger.Logger(ctx).WithError(err).Error("error listing groups sourcing members")
+				return nil
+			}
+			// The groups including a sourcing group get its members too, the workqueue drops duplicates
+			requests := make([]reconcile.Request, 0, len(sourcingGroups.Items))
+			for _, sourcingGroup := range sourcingGroups.Items {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      sourcingGroup.Name,
+						Namespace: sourcingGroup.Namespace,
+					},
+				})
+				ancestors, err := controllerutils.AncestorGroups(ctx, r.Client, sourcingGroup.Namespace, sourcingGroup.Name)
+				if err != nil {
+					logger.Logger(ctx).WithError(err).Error("error listing groups including a group sourcing members")
+					continue
+				}
+				for _, ancestor := range ancestors {
+					requests = append(requests, reconcile.Request{NamespacedName: ancestor})
+				}
+			}
+			return requests
+		}
+	}
 
 	// force reconcile flag
 	labelPredicate := controllerutils.ForceReconcilePredicate()
+	groupPredicate := predicate.Or(predicate.GenerationChangedPredicate{}, labelPredicate)
+	approvalPredicate := controllerutils.ApprovalPredicate()
+	preventDeletionPredicate := controllerutils.PreventDeletionRemovedPredicate()
+	// Label changes have no generation, a group is requeued when they change its selected backends
+	selectorPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !slices.Equal(r.AppConfig.SelectedBackends(e.ObjectOld.GetLabels()),
+				r.AppConfig.SelectedBackends(e.ObjectNew.GetLabels()))
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
 
 	maxConcurrentReconciles := r.AppConfig.ControllerConfig.MaxConcurrentReconciles
 	if maxConcurrentReconciles <= 0 {
@@ -1123,51 +2969,169 @@ func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}).Info("Configuring MaxConcurrentReconciles for Group controller")
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&usernautdevv1alpha1.Group{}).
-		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, labelPredicate)).
+		For(&usernautdevv1alpha1.Group{}, builder.WithPredicates(controllerutils.ShardPredicate(r.Shard),
+			predicate.Or(groupPredicate, selectorPredicate, approvalPredicate, preventDeletionPredicate))).
 		Watches(
 			client.Object(&usernautdevv1alpha1.Group{}),
 			handler.EnqueueRequestsFromMapFunc(mapFunc),
+			builder.WithPredicates(groupPredicate),
+		).
+		Watches(
+			client.Object(&usernautdevv1alpha1.Group{}),
+			handler.EnqueueRequestsFromMapFunc(groupNameMapFunc),
+			builder.WithPredicates(deletePredicate),
+		).
+		// ConfigMaps and Secrets have no generation, any change of their data requeues the groups
+		Watches(
+			client.Object(&corev1.ConfigMap{}),
+			handler.EnqueueRequestsFromMapFunc(memberSourceMapFunc(configMapIndexField)),
+		).
+		Watches(
+			client.Object(&corev1.Secret{}),
+			handler.EnqueueRequestsFromMapFunc(memberSourceMapFunc(secretIndexField)),
+		).
+		// The status of the User CRs changes on each of their reconciles, only their spec changes their user
+		Watches(
+			client.Object(&usernautdevv1alpha1.User{}),
+			handler.EnqueueRequestsFromMapFunc(memberSourceMapFunc(userRefsIndexField)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             controllerutils.NewRateLimiter(context.Background(), r.AppConfig.ControllerConfig.RateLimiter),
 		}).
 		Complete(r)
 }
 
+// groupTraversal collects how the member groups of a group were expanded by fetchUniqueGroupMembers
+type groupTraversal struct {
+	// maxDepth is the deepest level of member groups expanded, 0 does not limit the depth
+	maxDepth int
+	// depth is the depth of the deepest member group expanded
+	depth int
+	// truncated are the member groups beyond maxDepth
+	truncated []string
+	// direct are the members declared by the group itself, without the members of its member groups
+	direct []string
+}
+
 func (r *GroupReconciler) fetchUniqueGroupMembers(ctx context.Context, groupName,
-	namespace string, visitedOnPath map[string]struct{}) ([]string, error) {
+	namespace string, visitedOnPath map[string]struct{}, traversal *groupTraversal) ([]string, error) {
+	log := logger.Logger(ctx)
 
-	r.log.WithField("group", groupName).Info("fetching group members")
+	log.WithField("group", groupName).Info("fetching group members")
 
 	// Handle cyclic dependencies for the current recursion path.
 	if _, ok := visitedOnPath[groupName]; ok {
-		r.log.WithField("group", groupName).Warn("cyclic group dependency detected; returning empty member list")
+		log.WithField("group", groupName).Warn("cyclic group dependency detected; returning empty member list")
 		return []string{}, nil
 	}
 	visitedOnPath[groupName] = struct{}{}
 	defer delete(visitedOnPath, groupName) // Remove from path when returning.
+	depth := len(visitedOnPath) - 1
 
 	groupCR := &usernautdevv1alpha1.Group{}
 	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: groupName}, groupCR); err != nil {
-		r.log.WithError(err).Error("error fetching the group CR")
+		log.WithError(err).Error("error fetching the group CR")
 		return nil, err
 	}
 
 	members := make([]string, 0)
-	members = append(members, groupCR.Spec.Members.Users...)
+	members = append(members, groupCR.Spec.Members.AllUsers()...)
+	members = append(members, groupCR.Spec.Owners...)
+
+	sourcedMembers, err := r.fetchSourcedMembers(ctx, groupCR)
+	if err != nil {
+		log.WithError(err).Error("error fetching the members listed outside of the group CR")
+		return nil, err
+	}
+	members = append(members, sourcedMembers...)
+	if depth == 0 {
+		traversal.direct = slices.Clone(members)
+	}
 
 	for _, subGroup := range groupCR.Spec.Members.Groups {
-		subMembers, err := r.fetchUniqueGroupMembers(ctx, subGroup, namespace, visitedOnPath)
+		if traversal.maxDepth > 0 && depth >= traversal.maxDepth {
+			log.WithField("group", subGroup).Warn("member group is beyond the max depth of the groups policy; skipping")
+			if !slices.Contains(traversal.truncated, subGroup) {
+				traversal.truncated = append(traversal.truncated, subGroup)
+			}
+			continue
+		}
+		subMembers, err := r.fetchUniqueGroupMembers(ctx, subGroup, namespace, visitedOnPath, traversal)
 		if err != nil {
 			return nil, err
 		}
+		traversal.depth = max(traversal.depth, depth+1)
 		members = append(members, subMembers...)
 	}
 
 	return members, nil
 }
 
+// fetchSourcedMembers returns the users listed in the ConfigMap and Secret referenced by the group,
+// along with the users of the User CRs it references
+func (r *GroupReconciler) fetchSourcedMembers(ctx context.Context, groupCR *usernautdevv1alpha1.Group) ([]string, error) {
+	members := make([]string, 0)
+
+	if source := groupCR.Spec.Members.FromConfigMap; source != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: groupCR.Namespace, Name: source.Name}, configMap); err != nil {
+			return nil, fmt.Errorf("error fetching members ConfigMap %s: %w", source.Name, err)
+		}
+		data, ok := configMap.Data[memberSourceKey(source)]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in members ConfigMap %s", memberSourceKey(source), source.Name)
+		}
+		members = append(members, parseMemberList(data)...)
+	}
+
+	if source := groupCR.Spec.Members.FromSecret; source != nil {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: groupCR.Namespace, Name: source.Name}, secret); err != nil {
+			return nil, fmt.Errorf("error fetching members Secret %s: %w", source.Name, err)
+		}
+		data, ok := secret.Data[memberSourceKey(source)]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in members Secret %s", memberSourceKey(source), source.Name)
+		}
+		members = append(members, parseMemberList(string(data))...)
+	}
+
+	for _, userRef := range groupCR.Spec.Members.UserRefs {
+		userCR := &usernautdevv1alpha1.User{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: groupCR.Namespace, Name: userRef}, userCR); err != nil {
+			return nil, fmt.Errorf("error fetching member User %s: %w", userRef, err)
+		}
+		members = append(members, userCR.Spec.UserID)
+	}
+
+	return members, nil
+}
+
+// memberSourceKey returns the key holding the users in a member source
+func memberSourceKey(source *usernautdevv1alpha1.MemberSource) string {
+	if source.Key == "" {
+		return "users"
+	}
+	return source.Key
+}
+
+// parseMemberList splits a list of users separated by newlines, commas or spaces, skipping # comments
+func parseMemberList(data string) []string {
+	members := make([]string, 0)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		members = append(members, strings.FieldsFunc(line, func(c rune) bool {
+			return c == ',' || unicode.IsSpace(c)
+		})...)
+	}
+	return members
+}
+
 func (r *GroupReconciler) deduplicateMembers(members []string) []string {
 	// Deduplicate groupMembers before setting status
 	uniqueMembersMap := make(map[string]struct{})
@@ -1182,13 +3146,14 @@ func (r *GroupReconciler) deduplicateMembers(members []string) []string {
 }
 
 func (r *GroupReconciler) setOwnerReference(ctx context.Context, groupCR *usernautdevv1alpha1.Group) error {
+	log := logger.Logger(ctx)
 	// Determine the desired owner references from parent groups
 	desiredOwnerRefs := make(map[types.UID]metav1.OwnerReference)
 	for _, parentGroupName := range groupCR.Spec.Members.Groups {
 		parentGroupCR := &usernautdevv1alpha1.Group{}
 		if err := r.Client.Get(ctx,
 			client.ObjectKey{Namespace: groupCR.Namespace, Name: parentGroupName}, parentGroupCR); err != nil {
-			r.log.WithError(err).Error("error fetching the parent group CR")
+			log.WithError(err).Error("error fetching the parent group CR")
 			return err
 		}
 		blockOwnerDeletion := true
@@ -1238,82 +3203,92 @@ func (r *GroupReconciler) setOwnerReference(ctx context.Context, groupCR *userna
 
 	groupCR.OwnerReferences = newOwnerRefs
 	if err := r.Update(ctx, groupCR); err != nil {
-		r.log.WithError(err).Error("error updating the group CR with owner reference")
+		log.WithError(err).Error("error updating the group CR with owner reference")
 		return err
 	}
 
 	return nil
 }
 
-func (r *GroupReconciler) setupLdapSync(backendType string,
+// setupDependency configures a backend client implementing clients.DependentClient with the
+// backend it depends on, and reports whether the team membership is managed by that dependency.
+// The other backends only use depends_on to be processed after their dependency.
+func (r *GroupReconciler) setupDependency(ctx context.Context,
+	backendType string,
 	backendName string,
 	backendClient clients.Client,
 	groupName string,
 	backends []usernautdevv1alpha1.Backend,
 ) (bool, error) {
-	switch backendType {
-	case "gitlab":
-		dependsOn := r.AppConfig.BackendMap["gitlab"][backendName].DependsOn
+	backendLogger := logger.Logger(ctx)
 
-		if dependsOn.Type == "" && dependsOn.Name == "" {
-			r.backendLogger.Infof("no ldap dependant found for %s backend", dependsOn.Type)
-			return false, nil
-		}
+	dependsOn := r.AppConfig.BackendMap[backendType][backendName].DependsOn
+	if dependsOn.Type == "" && dependsOn.Name == "" {
+		backendLogger.Infof("no dependant found for %s backend", backendType)
+		return false, nil
+	}
 
-		// Check if the dependent backend exists in cache (using original group name)
-		err := r.ldapDependantChecks(dependsOn, groupName)
-		if err != nil {
-			return false, err
-		}
+	dependentClient, ok := clients.As[clients.DependentClient](backendClient)
+	if !ok {
+		return false, nil
+	}
 
-		if !isGroupCRHasDependants(backends, dependsOn) {
-			return false, fmt.Errorf("ldap dependants for %s backend doesn't exist in group CR", backendType)
-		}
+	// Check if the dependent backend exists in cache (using original group name)
+	err := r.dependantChecks(ctx, dependsOn, groupName)
+	if err != nil {
+		return false, err
+	}
 
-		gitlabClient, ok := backendClient.(*gitlab.GitlabClient)
-		if !ok {
-			return false, errors.New("backend client is not a GitlabClient")
-		}
-		gitlabClient.SetLdapSync(true, groupName)
-		r.backendLogger.Infof("ldap sync setup successfully for %s", backendType)
-		return true, nil
+	if !isGroupCRHasDependants(backends, dependsOn) {
+		return false, fmt.Errorf("dependants for %s backend doesn't exist in group CR", backendType)
+	}
+
+	managed, err := dependentClient.ConfigureDependency(ctx, dependsOn, groupName)
+	if err != nil {
+		return false, err
 	}
-	return false, nil
+	backendLogger.Infof("dependency setup successfully for %s", backendType)
+	return managed, nil
 }
 
-func (r *GroupReconciler) ldapDependantChecks(dependsOn config.Dependant, groupName string) error {
+func (r *GroupReconciler) dependantChecks(ctx context.Context, dependsOn config.Dependant, groupName string) error {
+	backendLogger := logger.Logger(ctx)
+
 	dependantType, ok := r.AppConfig.BackendMap[dependsOn.Type]
 	if !ok {
-		return fmt.Errorf("ldap dependant type %s not found in BackendMap", dependsOn.Type)
+		return fmt.Errorf("dependant type %s not found in BackendMap", dependsOn.Type)
 	}
 	dependantName, ok := dependantType[dependsOn.Name]
 	if !ok {
-		return fmt.Errorf("ldap dependant name %s not found in BackendMap[%s]", dependsOn.Name, dependsOn.Type)
+		return fmt.Errorf("dependant name %s not found in BackendMap[%s]", dependsOn.Name, dependsOn.Type)
 	}
 	if !dependantName.Enabled {
 		return fmt.Errorf("%s is not enabled", dependsOn.Type)
 	}
 
 	// Check if the group exists in cache with the dependent backend configured
-	// NOTE: This is called without holding CacheMutex (called from ldap sync)
 
 	// First check GroupStore (using original group name)
-	exists, err := r.Store.Group.BackendExists(context.Background(), groupName, dependsOn.Name, dependsOn.Type)
+	r.CacheMutex.RLock()
+	exists, err := r.Store.Group.BackendExists(ctx, groupName, dependsOn.Name, dependsOn.Type)
+	r.CacheMutex.RUnlock()
 	if err == nil && exists {
 		return nil
 	}
 
 	// Fallback to TeamStore (using transformed name)
-	transformedGroupName, err := utils.GetTransformedGroupName(r.AppConfig, dependsOn.Type, groupName)
+	transformedGroupName, err := utils.GetTransformedBackendGroupName(r.AppConfig, dependsOn.Type, dependsOn.Name, groupName)
 	if err != nil {
-		r.backendLogger.WithError(err).Error("error transforming group name for ldap dependant check")
+		backendLogger.WithError(err).Error("error transforming group name for dependant check")
 		return err
 	}
 
 	backendKey := dependsOn.Name + "_" + dependsOn.Type
-	teamBackends, err := r.Store.Team.GetBackends(context.Background(), transformedGroupName)
+	r.CacheMutex.RLock()
+	teamBackends, err := r.Store.Team.GetBackends(ctx, transformedGroupName)
+	r.CacheMutex.RUnlock()
 	if err != nil {
-		r.backendLogger.WithError(err).Error("error fetching team from TeamStore for ldap dependant check")
+		backendLogger.WithError(err).Error("error fetching team from TeamStore for dependant check")
 		return err
 	}
 
@@ -1321,7 +3296,7 @@ func (r *GroupReconciler) ldapDependantChecks(dependsOn config.Dependant, groupN
 		return nil
 	}
 
-	r.backendLogger.Error("dependent backend not found in cache for group, skipping ldap sync")
+	backendLogger.Error("dependent backend not found in cache for group, skipping dependency setup")
 	return fmt.Errorf("dependent backend %s not found in cache for group %s", backendKey, groupName)
 }
 
@@ -18,24 +18,41 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
 	"github.com/redhat-data-and-ai/usernaut/internal/controller/mocks"
+	clientmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs/mocks"
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fivetran"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/github"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/gitlab"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 	"github.com/redhat-data-and-ai/usernaut/pkg/store"
 )
@@ -49,6 +66,15 @@ const (
 	keyParentGroupId    = "parent_group_id"
 )
 
+// testLDAPUserData is the LDAP data returned for the members of the test groups
+var testLDAPUserData = map[string]interface{}{
+	"cn":          "Test",
+	"sn":          "User",
+	"displayName": "Test User",
+	"mail":        "testuser@gmail.com",
+	"uid":         "testuser",
+}
+
 var _ = Describe("Group Controller", func() {
 
 	setupTestReconciler := func(backends []config.Backend, cfgMutators ...func(*config.AppConfig)) (*GroupReconciler, *mocks.MockLDAPClient) {
@@ -100,6 +126,7 @@ var _ = Describe("Group Controller", func() {
 			AppConfig:  appConfig,
 			Store:      store.New(Cache),
 			LdapConn:   ldapClient,
+			Recorder:   record.NewFakeRecorder(100),
 			CacheMutex: &sync.RWMutex{},
 		}, ldapClient
 	}
@@ -192,7 +219,7 @@ var _ = Describe("Group Controller", func() {
 			controllerReconciler, ldapClient := setupTestReconciler([]config.Backend{fivetranBackend})
 
 			// No backend name patterns: group is non-configurable, reconciler returns before LDAP fetch
-			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), gomock.Any()).Times(0)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Times(0)
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
@@ -214,7 +241,8 @@ var _ = Describe("Group Controller", func() {
 					Namespace: "default",
 				},
 				Spec: usernautdevv1alpha1.GroupSpec{
-					GroupName: "test-resource-group",
+					// A group name of its own, the shared example would otherwise own it
+					GroupName: "test-resource-group-ldap",
 					Members: usernautdevv1alpha1.Members{
 						Groups: []string{},
 						Users:  []string{"test-user-1", "test-user-2"},
@@ -239,21 +267,19 @@ var _ = Describe("Group Controller", func() {
 			withTestResourceGroupPattern := func(c *config.AppConfig) {
 				c.Pattern = map[string][]config.PatternEntry{
 					"fivetran": {{
-						Input:  `^test-resource-group$`,
-						Output: "test_resource_group",
+						Input:  `^test-resource-group-ldap$`,
+						Output: "test_resource_group_ldap",
 					}},
 				}
 			}
 
 			controllerReconciler, ldapClient := setupTestReconciler([]config.Backend{fivetranBackend}, withTestResourceGroupPattern)
 
-			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), gomock.Any()).Return(map[string]interface{}{
-				"cn":          "Test",
-				"sn":          "User",
-				"displayName": "Test User",
-				"mail":        "testuser@gmail.com",
-				"uid":         "testuser",
-			}, nil).Times(2)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Return(
+				map[string]map[string]interface{}{
+					"test-user-1": testLDAPUserData,
+					"test-user-2": testLDAPUserData,
+				}, nil).Times(1)
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: ldapNN,
@@ -318,17 +344,16 @@ var _ = Describe("Group Controller", func() {
 			}
 			reconciler, ldapClient := setupTestReconciler([]config.Backend{fivetranA, fivetranB}, withMultiGroupPattern)
 
-			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), gomock.Any()).Return(map[string]interface{}{
-				"cn":          "Test",
-				"sn":          "User",
-				"displayName": "Test User",
-				"mail":        "testuser@gmail.com",
-				"uid":         "testuser",
-			}, nil).Times(2)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Return(
+				map[string]map[string]interface{}{
+					"test-user-1": testLDAPUserData,
+					"test-user-2": testLDAPUserData,
+				}, nil).Times(1)
 
-			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: multiNN})
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("failed to reconcile all backends"))
+			// Failed backends are retried with a backoff instead of failing the whole reconcile
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: multiNN})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(backendRetryBaseDelay))
 
 			// Reload the resource to inspect per-backend status (clear errors for operators)
 			fresh := &usernautdevv1alpha1.Group{}
@@ -345,6 +370,8 @@ var _ = Describe("Group Controller", func() {
 			Expect(statuses["fivetran-b"].Status).To(BeFalse())
 			Expect(statuses["fivetran-a"].Message).To(ContainSubstring("missing required connection parameters"))
 			Expect(statuses["fivetran-b"].Message).To(ContainSubstring("missing required connection parameters"))
+			Expect(statuses["fivetran-a"].Retries).To(Equal(int32(1)))
+			Expect(statuses["fivetran-b"].Retries).To(Equal(int32(1)))
 		})
 
 		It("should handle gitlab backend", func() {
@@ -400,7 +427,7 @@ var _ = Describe("Group Controller", func() {
 
 			// Since there are no matching patterns for gitlab backend, the group is non-configurable
 			// and reconciliation returns without processing backends, so no LDAP calls expected
-			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), gomock.Any()).Times(0)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Times(0)
 
 			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: gitlabNN})
 			Expect(err).NotTo(HaveOccurred())
@@ -476,17 +503,15 @@ var _ = Describe("Group Controller", func() {
 			}
 			reconciler, ldapClient := setupTestReconciler([]config.Backend{gitlabBackend}, withGitlabValPattern)
 
-			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), gomock.Any()).Return(map[string]interface{}{
-				"cn":          "Test",
-				"sn":          "User",
-				"displayName": "Test User",
-				"mail":        "testuser@gmail.com",
-				"uid":         "testuser",
-			}, nil).Times(2)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Return(
+				map[string]map[string]interface{}{
+					"test-user-1": testLDAPUserData,
+					"test-user-2": testLDAPUserData,
+				}, nil).Times(1)
 
-			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: gitlabValNN})
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("failed to reconcile all backends"))
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: gitlabValNN})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(backendRetryBaseDelay))
 
 			fresh := &usernautdevv1alpha1.Group{}
 			Expect(k8sClient.Get(ctx, gitlabValNN, fresh)).To(Succeed())
@@ -495,6 +520,1727 @@ var _ = Describe("Group Controller", func() {
 			Expect(status.Name).To(Equal("gitlab-main"))
 			Expect(status.Status).To(BeFalse())
 			Expect(status.Message).To(ContainSubstring("missing required connection parameters"))
+
+			recorder := reconciler.Recorder.(*record.FakeRecorder)
+			Expect(recorder.Events).To(Receive(ContainSubstring(eventReasonBackendFailed)))
 		})
 	})
-})
+
+	Context("When processing backends in parallel", func() {
+		It("should process backends that others depend on in an earlier wave", func() {
+			roverBackend := config.Backend{Name: "rover", Type: "rover", Enabled: true}
+			gitlabBackend := config.Backend{
+				Name:      "gitlab",
+				Type:      "gitlab",
+				Enabled:   true,
+				DependsOn: config.Dependant{Name: "rover", Type: "rover"},
+			}
+			fivetranBackend := config.Backend{Name: "fivetran", Type: "fivetran", Enabled: true}
+			reconciler, _ := setupTestReconciler([]config.Backend{roverBackend, gitlabBackend, fivetranBackend})
+
+			waves := reconciler.backendWaves([]usernautdevv1alpha1.Backend{
+				{Name: "gitlab", Type: "gitlab"},
+				{Name: "fivetran", Type: "fivetran"},
+				{Name: "rover", Type: "rover"},
+			})
+			Expect(waves).To(HaveLen(2))
+			Expect(waves[0]).To(ConsistOf(usernautdevv1alpha1.Backend{Name: "rover", Type: "rover"}))
+			Expect(waves[1]).To(ConsistOf(
+				usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"},
+				usernautdevv1alpha1.Backend{Name: "fivetran", Type: "fivetran"},
+			))
+		})
+
+		It("should order a chain of dependencies and process dependency cycles last", func() {
+			roverBackend := config.Backend{Name: "rover", Type: "rover", Enabled: true}
+			gitlabBackend := config.Backend{
+				Name:      "gitlab",
+				Type:      "gitlab",
+				Enabled:   true,
+				DependsOn: config.Dependant{Name: "rover", Type: "rover"},
+			}
+			snowflakeBackend := config.Backend{
+				Name:      "snowflake",
+				Type:      "snowflake",
+				Enabled:   true,
+				DependsOn: config.Dependant{Name: "gitlab", Type: "gitlab"},
+			}
+			fivetranBackend := config.Backend{
+				Name:      "fivetran",
+				Type:      "fivetran",
+				Enabled:   true,
+				DependsOn: config.Dependant{Name: "atlan", Type: "atlan"},
+			}
+			atlanBackend := config.Backend{
+				Name:      "atlan",
+				Type:      "atlan",
+				Enabled:   true,
+				DependsOn: config.Dependant{Name: "fivetran", Type: "fivetran"},
+			}
+			reconciler, _ := setupTestReconciler([]config.Backend{
+				roverBackend, gitlabBackend, snowflakeBackend, fivetranBackend, atlanBackend,
+			})
+
+			waves := reconciler.backendWaves([]usernautdevv1alpha1.Backend{
+				{Name: "snowflake", Type: "snowflake"},
+				{Name: "fivetran", Type: "fivetran"},
+				{Name: "gitlab", Type: "gitlab"},
+				{Name: "atlan", Type: "atlan"},
+				{Name: "rover", Type: "rover"},
+			})
+			Expect(waves).To(Equal([][]usernautdevv1alpha1.Backend{
+				{{Name: "rover", Type: "rover"}},
+				{{Name: "gitlab", Type: "gitlab"}},
+				{{Name: "snowflake", Type: "snowflake"}},
+				{{Name: "fivetran", Type: "fivetran"}, {Name: "atlan", Type: "atlan"}},
+			}))
+		})
+
+		It("should leave the backends to the next reconcile when shutting down", func() {
+			fivetranBackend := config.Backend{Name: "fivetran", Type: "fivetran", Enabled: true}
+			reconciler, _ := setupTestReconciler([]config.Backend{fivetranBackend})
+
+			parent, stop := context.WithCancel(context.Background())
+			ctx, cancel := controllerutils.DrainContext(parent, time.Minute)
+			defer cancel()
+			stop()
+
+			group := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-shutdown",
+					Backends:  []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+			}
+			backendErrors, _ := reconciler.processAllBackends(ctx, group, group.Spec.Backends,
+				[]string{"alice"}, nil, nil)
+			Expect(backendErrors).To(Equal(map[string]map[string]string{
+				"fivetran": {"fivetran": controllerutils.ErrShuttingDown.Error()},
+			}))
+		})
+
+		It("should use a single wave when there are no dependencies", func() {
+			fivetranBackend := config.Backend{Name: "fivetran", Type: "fivetran", Enabled: true}
+			reconciler, _ := setupTestReconciler([]config.Backend{fivetranBackend})
+
+			waves := reconciler.backendWaves([]usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}})
+			Expect(waves).To(HaveLen(1))
+			Expect(reconciler.maxConcurrentBackends()).To(Equal(defaultMaxConcurrentBackends))
+		})
+	})
+
+	Context("When a backend depends on another backend", func() {
+		ctx := context.Background()
+		roverBackend := config.Backend{Name: "rover", Type: "rover", Enabled: true}
+		gitlabBackend := config.Backend{
+			Name:      "gitlab",
+			Type:      "gitlab",
+			Enabled:   true,
+			DependsOn: config.Dependant{Name: "rover", Type: "rover"},
+		}
+		groupBackends := []usernautdevv1alpha1.Backend{{Name: "rover", Type: "rover"}, {Name: "gitlab", Type: "gitlab"}}
+
+		It("should configure the clients consuming their dependency once it is reconciled", func() {
+			reconciler, _ := setupTestReconciler([]config.Backend{roverBackend, gitlabBackend})
+			dependentClient := &fakeDependentClient{}
+
+			By("failing while the dependency has no team for the group")
+			_, err := reconciler.setupDependency(ctx, "gitlab", "gitlab", dependentClient,
+				"test-dependency", groupBackends)
+			Expect(err).To(HaveOccurred())
+			Expect(dependentClient.groupName).To(BeEmpty())
+
+			Expect(reconciler.Store.Group.SetBackend(ctx, "test-dependency", "rover", "rover", "data-eng")).
+				To(Succeed())
+			managed, err := reconciler.setupDependency(ctx, "gitlab", "gitlab", dependentClient,
+				"test-dependency", groupBackends)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed).To(BeTrue())
+			Expect(dependentClient.dependsOn).To(Equal(gitlabBackend.DependsOn))
+			Expect(dependentClient.groupName).To(Equal("test-dependency"))
+		})
+
+		It("should only order the clients not consuming their dependency", func() {
+			reconciler, _ := setupTestReconciler([]config.Backend{roverBackend, gitlabBackend})
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+
+			managed, err := reconciler.setupDependency(ctx, "gitlab", "gitlab", backendClient,
+				"test-dependency-unsupported", groupBackends)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed).To(BeFalse())
+		})
+	})
+
+	Context("When sharding the groups between replicas", func() {
+		ctx := context.Background()
+
+		It("should assign each group to a single shard", func() {
+			shards := make([]controllerutils.Shard, 3)
+			for i := range shards {
+				GinkgoT().Setenv(controllerutils.ShardIndexEnv, fmt.Sprint(i))
+				shard, err := controllerutils.NewShard(config.ShardingConfig{Shards: 3})
+				Expect(err).NotTo(HaveOccurred())
+				shards[i] = shard
+			}
+			Expect(shards[0].Primary()).To(BeTrue())
+			Expect(shards[1].LeaderElectionID("usernaut")).To(Equal("shard-1.usernaut"))
+
+			for i := range 20 {
+				group := &usernautdevv1alpha1.Group{Spec: usernautdevv1alpha1.GroupSpec{GroupName: fmt.Sprintf("group-%d", i)}}
+				owners := 0
+				for _, shard := range shards {
+					if shard.OwnsGroup(group) {
+						owners++
+					}
+				}
+				Expect(owners).To(Equal(1))
+			}
+
+			By("rejecting a shard index out of range")
+			GinkgoT().Setenv(controllerutils.ShardIndexEnv, "3")
+			_, err := controllerutils.NewShard(config.ShardingConfig{Shards: 3})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should skip the groups owned by another shard", func() {
+			nn := types.NamespacedName{Name: "test-other-shard", Namespace: "default"}
+			group := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: nn.Name,
+					Backends:  []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, group)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, group) }()
+
+			reconciler, _ := setupTestReconciler(nil)
+			reconciler.Shard = controllerutils.Shard{Count: 2}
+			if reconciler.Shard.OwnsGroup(group) {
+				reconciler.Shard.Index = 1
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &usernautdevv1alpha1.Group{}
+			Expect(k8sClient.Get(ctx, nn, updated)).To(Succeed())
+			Expect(updated.Finalizers).To(BeEmpty())
+		})
+	})
+
+	Context("When resolving member roles", func() {
+		It("should prefer a role scoped to the backend type", func() {
+			roles := []usernautdevv1alpha1.MemberRole{
+				{User: "alice", Role: "developer"},
+				{User: "alice", Role: "maintainer", Backend: "gitlab"},
+				{User: "bob", Role: "Account Administrator", Backend: "fivetran"},
+				{User: "carol", Role: "account analyst"},
+			}
+
+			Expect(memberRolesForBackend(roles, "gitlab", &gitlab.GitlabClient{})).
+				To(Equal(map[string]string{"alice": "maintainer"}))
+			Expect(memberRolesForBackend(roles, "fivetran", &fivetran.FivetranClient{})).To(Equal(map[string]string{
+				"bob":   "Account Administrator",
+				"carol": "account analyst",
+			}))
+			Expect(memberRolesForBackend(nil, "gitlab", &gitlab.GitlabClient{})).To(BeEmpty())
+		})
+
+		It("should only apply the roles without a backend to the backends accepting them", func() {
+			roles := []usernautdevv1alpha1.MemberRole{
+				{User: "alice", Role: "maintainer"},
+				{User: "bob", Role: "admin", Backend: "snowflake"},
+			}
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+
+			Expect(memberRolesForBackend(roles, "github", &github.GithubClient{})).
+				To(Equal(map[string]string{"alice": "maintainer"}))
+			Expect(memberRolesForBackend(roles, "fivetran", &fivetran.FivetranClient{})).To(BeEmpty())
+			Expect(memberRolesForBackend(roles, "snowflake", backendClient)).To(Equal(map[string]string{"bob": "admin"}))
+		})
+
+		It("should grant the owner role of the backend to the owners", func() {
+			roles := []usernautdevv1alpha1.MemberRole{{User: "alice", Role: "maintainer", Backend: "gitlab"}}
+
+			Expect(withOwnerRoles(memberRolesForBackend(roles, "gitlab", &gitlab.GitlabClient{}),
+				[]string{"alice", "bob"}, "gitlab")).To(Equal(map[string]string{"alice": "owner", "bob": "owner"}))
+			Expect(withOwnerRoles(map[string]string{}, []string{"bob"}, "fivetran")).To(BeEmpty())
+			Expect(withOwnerRoles(map[string]string{}, []string{"bob"}, "snowflake")).To(BeEmpty())
+		})
+
+		It("should list the users with a role as members with their role", func() {
+			members := usernautdevv1alpha1.Members{
+				Users:          []string{"alice", "bob"},
+				UsersWithRoles: []usernautdevv1alpha1.MemberRole{{User: "bob", Role: "maintainer"}, {User: "carol", Role: "owner"}},
+				Roles:          []usernautdevv1alpha1.MemberRole{{User: "alice", Role: "reporter", Backend: "gitlab"}},
+			}
+
+			Expect(members.AllUsers()).To(Equal([]string{"alice", "bob", "carol"}))
+			Expect(memberRolesForBackend(members.AllRoles(), "gitlab", &gitlab.GitlabClient{})).To(Equal(map[string]string{
+				"alice": "reporter",
+				"bob":   "maintainer",
+				"carol": "owner",
+			}))
+		})
+
+		It("should demote team owners that are no longer owners of the group", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+				"bob":   {UID: "bob", Email: "bob@example.com"},
+			})
+			Expect(reconciler.Store.User.SetBackend(ctx, "alice@example.com", "gitlab_gitlab", "1")).To(Succeed())
+			Expect(reconciler.Store.User.SetBackend(ctx, "bob@example.com", "gitlab_gitlab", "2")).To(Succeed())
+			existing := map[string]*structs.User{
+				"1": {ID: "1", Role: "owner"},
+				"2": {ID: "2", Role: "owner"},
+			}
+
+			usersToAdd, usersToRemove, usersToDemote, err := reconciler.processUsers(ctx, []string{"alice", "bob"},
+				existing, map[string]string{"alice": "owner"}, "gitlab", "gitlab")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usersToAdd).To(BeEmpty())
+			Expect(usersToRemove).To(BeEmpty())
+			Expect(usersToDemote).To(Equal([]string{"2"}))
+		})
+
+		It("should demote team members whose role was removed", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+				"bob":   {UID: "bob", Email: "bob@example.com"},
+				"carol": {UID: "carol", Email: "carol@example.com"},
+			})
+			Expect(reconciler.Store.User.SetBackend(ctx, "alice@example.com", "gitlab_gitlab", "1")).To(Succeed())
+			Expect(reconciler.Store.User.SetBackend(ctx, "bob@example.com", "gitlab_gitlab", "2")).To(Succeed())
+			Expect(reconciler.Store.User.SetBackend(ctx, "carol@example.com", "gitlab_gitlab", "3")).To(Succeed())
+			existing := map[string]*structs.User{
+				"1": {ID: "1", Role: "maintainer"},
+				"2": {ID: "2", Role: "Developer"},
+				"3": {ID: "3"},
+			}
+
+			_, _, usersToDemote, err := reconciler.processUsers(ctx, []string{"alice", "bob", "carol"},
+				existing, map[string]string{}, "gitlab", "gitlab")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usersToDemote).To(Equal([]string{"1"}))
+		})
+
+		It("should give the members without a role the default member role of the backend config", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler([]config.Backend{{
+				Name: "gitlab", Type: "gitlab", Enabled: true,
+				DefaultRoles: config.BackendDefaultRoles{Member: "reporter"},
+			}})
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+				"bob":   {UID: "bob", Email: "bob@example.com"},
+			})
+			backend := fake.New()
+			team, err := backend.CreateTeam(ctx, &structs.Team{Name: "team"})
+			Expect(err).NotTo(HaveOccurred())
+			alice, err := backend.CreateUser(ctx, &structs.User{Email: "alice@example.com"})
+			Expect(err).NotTo(HaveOccurred())
+			bob, err := backend.CreateUser(ctx, &structs.User{Email: "bob@example.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backend.AddUserToTeamWithRole(ctx, team.ID, []string{alice.ID}, "maintainer")).To(Succeed())
+			Expect(reconciler.Store.User.SetBackend(ctx, "alice@example.com", "gitlab_gitlab", alice.ID)).To(Succeed())
+			Expect(reconciler.Store.User.SetBackend(ctx, "bob@example.com", "gitlab_gitlab", bob.ID)).To(Succeed())
+			existing := map[string]*structs.User{alice.ID: {ID: alice.ID, Role: "maintainer"}}
+
+			usersToAdd, _, usersToDemote, err := reconciler.processUsers(ctx, []string{"alice", "bob"},
+				existing, map[string]string{}, "gitlab", "gitlab")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usersToDemote).To(Equal([]string{alice.ID}))
+
+			groupCR := &usernautdevv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"}}
+			defaultRoleUsers, err := reconciler.syncMemberRoles(ctx, groupCR, team.ID,
+				usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"}, backend, []string{"alice", "bob"},
+				map[string]string{}, existing, usersToAdd, usersToDemote)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaultRoleUsers).To(BeEmpty())
+			Expect(backend.Members(team.ID)).To(Equal(map[string]string{alice.ID: "reporter", bob.ID: "reporter"}))
+		})
+	})
+
+	Context("When the user already exists in the backend", func() {
+		It("should look up the existing user and repopulate the cache", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+			})
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			backendClient.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, structs.ErrUserAlreadyExists)
+			existing := &structs.User{ID: "42", UserName: "alice", Email: "Alice@example.com"}
+			backendClient.EXPECT().FetchAllUsers(gomock.Any()).Return(
+				map[string]*structs.User{"42": existing}, map[string]*structs.User{existing.Email: existing}, nil)
+
+			Expect(reconciler.createUsersInBackendAndCache(ctx, []string{"alice"}, nil, "", "gitlab", "gitlab",
+				backendClient)).To(Succeed())
+			userBackends, err := reconciler.Store.User.GetBackends(ctx, "alice@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userBackends).To(HaveKeyWithValue("gitlab_gitlab", "42"))
+		})
+
+		It("should fail the backend when the existing user cannot be found", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"bob": {UID: "bob", Email: "bob@example.com"},
+			})
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			backendClient.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, structs.ErrUserAlreadyExists)
+			backendClient.EXPECT().FetchAllUsers(gomock.Any()).Return(
+				map[string]*structs.User{}, map[string]*structs.User{}, nil)
+
+			err := reconciler.createUsersInBackendAndCache(ctx, []string{"bob"}, nil, "", "gitlab", "gitlab", backendClient)
+			Expect(err).To(MatchError(structs.ErrUserAlreadyExists))
+		})
+
+		It("should not adopt an existing user sharing only the username", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"carol": {UID: "carol", Email: "carol@example.com"},
+			})
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			backendClient.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, structs.ErrUserAlreadyExists)
+			other := &structs.User{ID: "7", UserName: "carol", Email: "carol@other.example.com"}
+			backendClient.EXPECT().FetchAllUsers(gomock.Any()).Return(
+				map[string]*structs.User{"7": other}, map[string]*structs.User{other.Email: other}, nil)
+
+			err := reconciler.createUsersInBackendAndCache(ctx, []string{"carol"}, nil, "", "gitlab", "gitlab", backendClient)
+			Expect(err).To(MatchError(structs.ErrUserAlreadyExists))
+		})
+
+		It("should fetch the users of the backend once for all the existing users", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"dave": {UID: "dave", Email: "dave@example.com"},
+				"erin": {UID: "erin", Email: "erin@example.com"},
+			})
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			backendClient.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, structs.ErrUserAlreadyExists).Times(2)
+			dave := &structs.User{ID: "1", UserName: "dave", Email: "dave@example.com"}
+			erin := &structs.User{ID: "2", UserName: "erin", Email: "erin@example.com"}
+			backendClient.EXPECT().FetchAllUsers(gomock.Any()).Return(
+				map[string]*structs.User{"1": dave, "2": erin},
+				map[string]*structs.User{dave.Email: dave, erin.Email: erin}, nil).Times(1)
+
+			Expect(reconciler.createUsersInBackendAndCache(ctx, []string{"dave", "erin"}, nil, "", "gitlab", "gitlab",
+				backendClient)).To(Succeed())
+			userBackends, err := reconciler.Store.User.GetBackends(ctx, "erin@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userBackends).To(HaveKeyWithValue("gitlab_gitlab", "2"))
+		})
+	})
+
+	Context("When resolving the default roles of a backend", func() {
+		It("should fall back to the default account role of the backend type", func() {
+			reconciler, _ := setupTestReconciler([]config.Backend{{Name: "fivetran", Type: "fivetran", Enabled: true}})
+			Expect(defaultBackendRoles(reconciler.AppConfig, "fivetran", "fivetran")).To(Equal(
+				backendRoles{user: fivetran.AccountReviewerRole, team: fivetran.AccountReviewerRole}))
+			Expect(defaultBackendRoles(reconciler.AppConfig, "gitlab", "gitlab")).To(Equal(backendRoles{}))
+		})
+
+		It("should use the roles of the backend config and the group param overrides", func() {
+			reconciler, _ := setupTestReconciler([]config.Backend{{
+				Name: "fivetran", Type: "fivetran", Enabled: true,
+				DefaultRoles: config.BackendDefaultRoles{User: "Account Analyst"},
+			}})
+			roles := defaultBackendRoles(reconciler.AppConfig, "fivetran", "fivetran")
+			Expect(roles).To(Equal(backendRoles{user: "Account Analyst", team: fivetran.AccountReviewerRole}))
+
+			overrides := backendRoles{}.withParam(clients.GroupParamTeamRole, []string{"Account Administrator"})
+			Expect(roles.override(overrides)).To(Equal(
+				backendRoles{user: "Account Analyst", team: "Account Administrator"}))
+		})
+
+		It("should create the users with the default role unless they have a member role", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+				"bob":   {UID: "bob", Email: "bob@example.com"},
+			})
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			createdRoles := map[string]string{}
+			backendClient.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, u *structs.User) (*structs.User, error) {
+					createdRoles[u.UserName] = u.Role
+					return &structs.User{ID: u.UserName}, nil
+				}).Times(2)
+
+			Expect(reconciler.createUsersInBackendAndCache(ctx, []string{"alice", "bob"},
+				map[string]string{"bob": "Account Administrator"}, "Account Analyst", "fivetran", "fivetran",
+				backendClient)).To(Succeed())
+			Expect(createdRoles).To(Equal(map[string]string{
+				"alice": "Account Analyst",
+				"bob":   "Account Administrator",
+			}))
+		})
+	})
+
+	Context("When publishing the team membership", func() {
+		It("should resolve the members once the changes are applied", func() {
+			existing := map[string]*structs.User{
+				"1": {ID: "1"},
+				"3": {ID: "3"},
+				"4": {ID: "4"},
+			}
+
+			Expect(resolvedMembers(existing, []string{"2"}, []string{"4"})).To(Equal([]string{"1", "2", "3"}))
+			Expect(resolvedMembers(map[string]*structs.User{}, nil, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("When reporting membership drift", func() {
+		It("should name the members only in the backend and only in the spec", func() {
+			ctx := context.Background()
+			reconciler, _ := setupTestReconciler(nil)
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+				"bob":   {UID: "bob", Email: "bob@example.com"},
+			})
+			Expect(reconciler.Store.User.SetBackend(ctx, "alice@example.com", "gitlab_gitlab", "1")).To(Succeed())
+			Expect(reconciler.Store.User.SetBackend(ctx, "bob@example.com", "gitlab_gitlab", "2")).To(Succeed())
+			existing := map[string]*structs.User{
+				"1": {ID: "1", Email: "alice@example.com"},
+				"3": {ID: "3", UserName: "outofband"},
+				"4": {ID: "4", Email: "manual@example.com"},
+			}
+
+			onlyInBackend, onlyInSpec := reconciler.memberDrift(ctx, []string{"alice", "bob"}, existing,
+				[]string{"2"}, []string{"4", "3"}, "gitlab", "gitlab")
+			Expect(onlyInBackend).To(Equal([]string{"manual@example.com", "outofband"}))
+			Expect(onlyInSpec).To(Equal([]string{"bob"}))
+		})
+
+		It("should report no drift for a team in sync", func() {
+			reconciler, _ := setupTestReconciler(nil)
+			onlyInBackend, onlyInSpec := reconciler.memberDrift(context.Background(), []string{"alice"},
+				map[string]*structs.User{"1": {ID: "1"}}, nil, nil, "gitlab", "gitlab")
+			Expect(onlyInBackend).To(BeNil())
+			Expect(onlyInSpec).To(BeNil())
+		})
+
+		It("should cap the size of a drift report", func() {
+			members := make([]string, 0, maxDriftMembers+10)
+			for i := 0; i < maxDriftMembers+10; i++ {
+				members = append(members, fmt.Sprintf("user%03d", i))
+			}
+			Expect(capDrift(members)).To(HaveLen(maxDriftMembers))
+		})
+	})
+
+	Context("When auditing the direct members of a team managed by its dependency", func() {
+		ctx := context.Background()
+		backend := usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"}
+		groupCR := &usernautdevv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "test-audit", Namespace: "default"}}
+		setupAudit := func(mode string) (*GroupReconciler, *fakeDirectMemberClient) {
+			reconciler, _ := setupTestReconciler([]config.Backend{
+				{Name: "gitlab", Type: "gitlab", DirectMemberAudit: mode},
+			})
+			ctx = withLDAPUsers(ctx, map[string]*structs.LDAPUser{
+				"alice": {UID: "alice", Email: "alice@example.com"},
+			})
+			Expect(reconciler.Store.User.SetBackend(ctx, "alice@example.com", "gitlab_gitlab", "1")).To(Succeed())
+			return reconciler, &fakeDirectMemberClient{members: map[string]*structs.User{
+				"1": {ID: "1", Email: "alice@example.com"},
+				"2": {ID: "2", UserName: "manual"},
+			}}
+		}
+
+		It("should report the direct members not in the spec", func() {
+			reconciler, auditor := setupAudit(config.DirectMemberAuditReport)
+			directMembers, err := reconciler.auditDirectMembers(ctx, groupCR,
+				"100", backend, auditor, []string{"alice"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(directMembers).To(Equal([]string{"manual"}))
+			Expect(auditor.removed).To(BeEmpty())
+		})
+
+		It("should remove the direct members not in the spec", func() {
+			reconciler, auditor := setupAudit(config.DirectMemberAuditRemove)
+			directMembers, err := reconciler.auditDirectMembers(ctx, groupCR,
+				"100", backend, auditor, []string{"alice"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(directMembers).To(Equal([]string{"manual"}))
+			Expect(auditor.removed).To(Equal([]string{"2"}))
+		})
+	})
+
+	Context("When applying backend overrides", func() {
+		overrides := []usernautdevv1alpha1.BackendOverride{
+			{Name: "gitlab", Type: "gitlab", AdditionalUsers: []string{"contractor", "alice"}},
+			{Name: "prod", Type: "snowflake", ExcludeUsers: []string{"contractor", "bob"}},
+		}
+		members := []string{"alice", "bob"}
+
+		It("should add the additional users of the backend only", func() {
+			Expect(membersForBackend(overrides, usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"}, members)).
+				To(Equal([]string{"alice", "bob", "contractor"}))
+		})
+
+		It("should drop the excluded users of the backend", func() {
+			Expect(membersForBackend(overrides, usernautdevv1alpha1.Backend{Name: "prod", Type: "snowflake"}, members)).
+				To(Equal([]string{"alice"}))
+		})
+
+		It("should keep the members of backends without overrides", func() {
+			Expect(membersForBackend(overrides, usernautdevv1alpha1.Backend{Name: "fivetran", Type: "fivetran"}, members)).
+				To(Equal(members))
+		})
+	})
+
+	Context("When members have an expiry", func() {
+		now := time.Now()
+		groupCR := &usernautdevv1alpha1.Group{
+			Spec: usernautdevv1alpha1.GroupSpec{
+				Members: usernautdevv1alpha1.Members{
+					Users: []string{"alice", "bob", "contractor"},
+					Expirations: []usernautdevv1alpha1.MemberExpiration{
+						{User: "bob", ExpiresAt: metav1.NewTime(now.Add(-time.Hour))},
+						{User: "contractor", ExpiresAt: metav1.NewTime(now.Add(time.Hour))},
+					},
+				},
+			},
+		}
+
+		It("should drop the members whose membership expired", func() {
+			Expect(removeMembers(groupCR.Spec.Members.Users, groupCR.Spec.Members.ExpiredUsers(now))).
+				To(Equal([]string{"alice", "contractor"}))
+		})
+
+		It("should requeue when the next membership expires", func() {
+			reconciler, _ := setupTestReconciler(nil)
+			Expect(reconciler.groupRequeueAfter(context.Background(), groupCR, now)).To(Equal(time.Hour))
+			Expect(reconciler.groupRequeueAfter(context.Background(), &usernautdevv1alpha1.Group{}, now)).To(Equal(requeueAfter))
+		})
+	})
+
+	Context("When members are listed in a ConfigMap or Secret", func() {
+		ctx := context.Background()
+
+		It("should parse users separated by newlines, commas or spaces", func() {
+			Expect(parseMemberList("# HR export\nalice, bob\n\ncarol dave\n")).
+				To(Equal([]string{"alice", "bob", "carol", "dave"}))
+		})
+
+		It("should read the users of the referenced ConfigMap and Secret", func() {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-members", Namespace: "default"},
+				Data:       map[string]string{"users": "alice\nbob"},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, configMap) }()
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-members", Namespace: "default"},
+				Data:       map[string][]byte{"contractors": []byte("carol")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, secret) }()
+
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sourced-members", Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Members: usernautdevv1alpha1.Members{
+						FromConfigMap: &usernautdevv1alpha1.MemberSource{Name: "test-members"},
+						FromSecret:    &usernautdevv1alpha1.MemberSource{Name: "test-members", Key: "contractors"},
+					},
+				},
+			}
+			reconciler, _ := setupTestReconciler(nil)
+
+			members, err := reconciler.fetchSourcedMembers(ctx, groupCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]string{"alice", "bob", "carol"}))
+
+			By("failing when the key is missing instead of dropping the members")
+			groupCR.Spec.Members.FromConfigMap.Key = "missing"
+			_, err = reconciler.fetchSourcedMembers(ctx, groupCR)
+			Expect(err).To(MatchError(ContainSubstring("key missing not found")))
+		})
+
+		It("should read the users of the referenced User CRs", func() {
+			userCR := &usernautdevv1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-member-user", Namespace: "default"},
+				Spec: usernautdevv1alpha1.UserSpec{
+					UserID:   "erin",
+					Backends: []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, userCR)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, userCR) }()
+
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-user-refs", Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Members: usernautdevv1alpha1.Members{UserRefs: []string{"test-member-user"}},
+				},
+			}
+			reconciler, _ := setupTestReconciler(nil)
+
+			members, err := reconciler.fetchSourcedMembers(ctx, groupCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]string{"erin"}))
+
+			By("failing when the User CR is missing instead of dropping the member")
+			groupCR.Spec.Members.UserRefs = append(groupCR.Spec.Members.UserRefs, "missing-user")
+			_, err = reconciler.fetchSourcedMembers(ctx, groupCR)
+			Expect(err).To(MatchError(ContainSubstring("error fetching member User missing-user")))
+		})
+	})
+
+	Context("When expanding member groups", func() {
+		ctx := context.Background()
+		newGroup := func(name string, users []string, groups []string) *usernautdevv1alpha1.Group {
+			return &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: name,
+					Members:   usernautdevv1alpha1.Members{Users: users, Groups: groups},
+				},
+			}
+		}
+
+		It("should stop flattening the member groups beyond the max depth", func() {
+			for _, group := range []*usernautdevv1alpha1.Group{
+				newGroup("test-nested-top", []string{"alice"}, []string{"test-nested-middle"}),
+				newGroup("test-nested-middle", []string{"bob"}, []string{"test-nested-leaf"}),
+				newGroup("test-nested-leaf", []string{"carol"}, nil),
+			} {
+				Expect(k8sClient.Create(ctx, group)).To(Succeed())
+				defer func() { _ = k8sClient.Delete(ctx, group) }()
+			}
+			reconciler, _ := setupTestReconciler(nil)
+
+			traversal := &groupTraversal{}
+			members, err := reconciler.fetchUniqueGroupMembers(ctx, "test-nested-top", "default",
+				make(map[string]struct{}), traversal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]string{"alice", "bob", "carol"}))
+			Expect(traversal.depth).To(Equal(2))
+			Expect(traversal.truncated).To(BeEmpty())
+			Expect(traversal.direct).To(Equal([]string{"alice"}))
+
+			By("limiting the expansion to the groups listed by the group")
+			traversal = &groupTraversal{maxDepth: 1}
+			members, err = reconciler.fetchUniqueGroupMembers(ctx, "test-nested-top", "default",
+				make(map[string]struct{}), traversal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]string{"alice", "bob"}))
+			Expect(traversal.depth).To(Equal(1))
+			Expect(traversal.truncated).To(Equal([]string{"test-nested-leaf"}))
+		})
+
+		It("should mirror the member groups as nested teams", func() {
+			for _, group := range []*usernautdevv1alpha1.Group{
+				newGroup("test-mirror-child", []string{"bob"}, nil),
+				newGroup("test-mirror-pending", []string{"carol"}, nil),
+			} {
+				Expect(k8sClient.Create(ctx, group)).To(Succeed())
+				defer func() { _ = k8sClient.Delete(ctx, group) }()
+			}
+			reconciler, _ := setupTestReconciler(nil)
+			backend := usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"}
+			Expect(reconciler.Store.Group.SetBackend(ctx, "test-mirror-child", backend.Name, backend.Type, "200")).
+				To(Succeed())
+
+			nestedClient := &fakeNestedTeamClient{nested: []string{"300", "400"}}
+			parent := newGroup("test-mirror-parent", []string{"alice"}, []string{"test-mirror-child"})
+			parent.Status.BackendsStatus = []usernautdevv1alpha1.BackendStatus{
+				{Name: backend.Name, Type: backend.Type, NestedTeams: []string{"300"}},
+			}
+			nested, err := reconciler.syncNestedTeams(ctx, parent, "100", backend, nestedClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nestedClient.added).To(Equal([]string{"200"}))
+			Expect(nestedClient.removed).To(Equal([]string{"300"}), "Expected the teams not nested by usernaut to stay")
+			Expect(nested).To(Equal([]string{"200"}))
+
+			By("keeping the nested teams of a group without a record of the teams it nested")
+			nestedClient = &fakeNestedTeamClient{nested: []string{"200"}}
+			nested, err = reconciler.syncNestedTeams(ctx, newGroup("test-mirror-parent", nil, nil), "100", backend,
+				nestedClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nestedClient.removed).To(BeEmpty())
+			Expect(nested).To(BeEmpty())
+
+			By("failing while a member group has no team to nest")
+			parent.Spec.Members.Groups = append(parent.Spec.Members.Groups, "test-mirror-pending")
+			_, err = reconciler.syncNestedTeams(ctx, parent, "100", backend, &fakeNestedTeamClient{})
+			Expect(err).To(MatchError(ContainSubstring("member group test-mirror-pending has no team")))
+		})
+	})
+
+	Context("When a nested member group changes", func() {
+		ctx := context.Background()
+		withMembers := func(name string, groups ...string) usernautdevv1alpha1.Group {
+			return usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       usernautdevv1alpha1.GroupSpec{Members: usernautdevv1alpha1.Members{Groups: groups}},
+			}
+		}
+
+		It("should find every group including it, directly or transitively", func() {
+			reader := &memberGroupsReader{groups: []usernautdevv1alpha1.Group{
+				withMembers("top", "middle"),
+				withMembers("middle", "leaf"),
+				withMembers("other", "leaf", "top"),
+				withMembers("unrelated", "elsewhere"),
+				withMembers("leaf", "top"),
+			}}
+
+			ancestors, err := controllerutils.AncestorGroups(ctx, reader, "default", "leaf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ancestors).To(ConsistOf(
+				types.NamespacedName{Name: "middle", Namespace: "default"},
+				types.NamespacedName{Name: "other", Namespace: "default"},
+				types.NamespacedName{Name: "top", Namespace: "default"},
+			))
+		})
+	})
+
+	Context("When resyncing groups", func() {
+		withResyncInterval := func(interval string) func(*config.AppConfig) {
+			return func(c *config.AppConfig) {
+				c.ControllerConfig.ResyncInterval = interval
+			}
+		}
+
+		It("should use the configured resync interval", func() {
+			reconciler, _ := setupTestReconciler(nil, withResyncInterval("30m"))
+			Expect(reconciler.groupRequeueAfter(context.Background(), &usernautdevv1alpha1.Group{}, time.Now())).To(Equal(30 * time.Minute))
+		})
+
+		It("should fall back to the default for an invalid resync interval", func() {
+			reconciler, _ := setupTestReconciler(nil, withResyncInterval("often"))
+			Expect(reconciler.resyncInterval(context.Background())).To(Equal(requeueAfter))
+		})
+	})
+
+	Context("When tuning the backend retries and rate limits", func() {
+		It("should use the configured backend retry delays", func() {
+			reconciler, _ := setupTestReconciler(nil, func(c *config.AppConfig) {
+				c.ControllerConfig.BackendRetryBaseDelay = "10s"
+				c.ControllerConfig.BackendRetryMaxDelay = "1m"
+			})
+			baseDelay, maxDelay := reconciler.backendRetryDelays(context.Background())
+			Expect(baseDelay).To(Equal(10 * time.Second))
+			Expect(maxDelay).To(Equal(time.Minute))
+			Expect(backendRetryDelay(3, baseDelay, maxDelay)).To(Equal(40 * time.Second))
+			Expect(backendRetryDelay(5, baseDelay, maxDelay)).To(Equal(time.Minute))
+		})
+
+		It("should fall back to the default backend retry delays", func() {
+			reconciler, _ := setupTestReconciler(nil, func(c *config.AppConfig) {
+				c.ControllerConfig.BackendRetryMaxDelay = "-1h"
+			})
+			baseDelay, maxDelay := reconciler.backendRetryDelays(context.Background())
+			Expect(baseDelay).To(Equal(backendRetryBaseDelay))
+			Expect(maxDelay).To(Equal(backendRetryMaxDelay))
+		})
+
+		It("should back off failed requests with the configured rate limiter delays", func() {
+			rateLimiter := controllerutils.NewRateLimiter(context.Background(), config.RateLimiterConfig{
+				BaseDelay: "1s",
+				MaxDelay:  "3s",
+			})
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "group", Namespace: "default"}}
+			Expect(rateLimiter.When(req)).To(Equal(time.Second))
+			Expect(rateLimiter.When(req)).To(Equal(2 * time.Second))
+			Expect(rateLimiter.When(req)).To(Equal(3 * time.Second))
+
+			rateLimiter.Forget(req)
+			Expect(rateLimiter.When(req)).To(Equal(time.Second))
+		})
+	})
+
+	Context("When deleting a group with the Retain deletion policy", func() {
+		ctx := context.Background()
+
+		It("should keep the backend teams and remove the finalizer", func() {
+			nn := types.NamespacedName{Name: "test-resource-group-retain", Namespace: "default"}
+			retainGroup := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       nn.Name,
+					Namespace:  nn.Namespace,
+					Finalizers: []string{groupFinalizer},
+				},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-resource-group-retain",
+					Members:   usernautdevv1alpha1.Members{Users: []string{"test-user-1"}},
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+					},
+					DeletionPolicy: usernautdevv1alpha1.DeletionPolicyRetain,
+				},
+			}
+			Expect(k8sClient.Create(ctx, retainGroup)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, retainGroup)).To(Succeed())
+
+			fivetranBackend := config.Backend{
+				Name:    "fivetran",
+				Type:    "fivetran",
+				Enabled: true,
+				Connection: map[string]interface{}{
+					keyApiKey:    "testKey",
+					keyApiSecret: "testSecret",
+				},
+			}
+			reconciler, _ := setupTestReconciler([]config.Backend{fivetranBackend})
+			Expect(reconciler.Store.Group.SetBackend(ctx, "test-resource-group-retain", "fivetran", "fivetran", "team-123")).
+				To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := reconciler.Recorder.(*record.FakeRecorder)
+			Expect(recorder.Events).To(Receive(ContainSubstring(eventReasonTeamRetained)))
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, nn, &usernautdevv1alpha1.Group{}))).To(BeTrue())
+		})
+	})
+
+	Context("When a group is protected from deletion", func() {
+		ctx := context.Background()
+
+		It("should keep the finalizer until the prevent-deletion annotation is removed", func() {
+			nn := types.NamespacedName{Name: "test-resource-group-protected", Namespace: "default"}
+			protectedGroup := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nn.Name,
+					Namespace:   nn.Namespace,
+					Finalizers:  []string{groupFinalizer},
+					Annotations: map[string]string{constants.PreventDeletionAnnotation: "true"},
+				},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName:      "test-resource-group-protected",
+					Members:        usernautdevv1alpha1.Members{Users: []string{"test-user-1"}},
+					Backends:       []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+					DeletionPolicy: usernautdevv1alpha1.DeletionPolicyRetain,
+				},
+			}
+			Expect(k8sClient.Create(ctx, protectedGroup)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, protectedGroup)).To(Succeed())
+
+			reconciler, _ := setupTestReconciler(nil)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := reconciler.Recorder.(*record.FakeRecorder)
+			Expect(recorder.Events).To(Receive(ContainSubstring(eventReasonDeletionPrevented)))
+			Expect(k8sClient.Get(ctx, nn, protectedGroup)).To(Succeed())
+			Expect(protectedGroup.Finalizers).To(ContainElement(groupFinalizer))
+			readyCondition := meta.FindStatusCondition(protectedGroup.Status.Conditions, usernautdevv1alpha1.GroupReadyCondition)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Reason).To(Equal(usernautdevv1alpha1.DeletionPreventedReason))
+
+			By("deleting the group once the annotation is removed")
+			protectedGroup.SetAnnotations(nil)
+			Expect(k8sClient.Update(ctx, protectedGroup)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, nn, &usernautdevv1alpha1.Group{}))).To(BeTrue())
+		})
+	})
+
+	Context("When a group is suspended", func() {
+		ctx := context.Background()
+
+		It("should skip reconciliation and set the Suspended condition", func() {
+			nn := types.NamespacedName{Name: "test-resource-group-suspended", Namespace: "default"}
+			suspendedGroup := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      nn.Name,
+					Namespace: nn.Namespace,
+				},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-resource-group-suspended",
+					Members:   usernautdevv1alpha1.Members{Users: []string{"test-user-1"}},
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+					},
+					Suspend: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, suspendedGroup)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, suspendedGroup) }()
+
+			reconciler, ldapClient := setupTestReconciler(nil)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Times(0)
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updated := &usernautdevv1alpha1.Group{}
+			Expect(k8sClient.Get(ctx, nn, updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, usernautdevv1alpha1.GroupSuspendedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
+	Context("When two groups share a group name", func() {
+		ctx := context.Background()
+
+		It("should only reconcile the oldest group and mark the other as a duplicate", func() {
+			newGroup := func(name string) *usernautdevv1alpha1.Group {
+				return &usernautdevv1alpha1.Group{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec: usernautdevv1alpha1.GroupSpec{
+						GroupName: "test-duplicate-group-name",
+						Members:   usernautdevv1alpha1.Members{Users: []string{"test-user-1"}},
+						Backends: []usernautdevv1alpha1.Backend{
+							{Name: "fivetran", Type: "fivetran"},
+						},
+					},
+				}
+			}
+			ownerGroup := newGroup("test-duplicate-a")
+			Expect(k8sClient.Create(ctx, ownerGroup)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ownerGroup) }()
+			duplicateGroup := newGroup("test-duplicate-b")
+			Expect(k8sClient.Create(ctx, duplicateGroup)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, duplicateGroup) }()
+
+			reconciler, ldapClient := setupTestReconciler(nil)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), gomock.Any()).Times(0)
+
+			owner, err := reconciler.groupNameOwner(ctx, ownerGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owner).To(BeEmpty())
+
+			nn := types.NamespacedName{Name: duplicateGroup.Name, Namespace: duplicateGroup.Namespace}
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &usernautdevv1alpha1.Group{}
+			Expect(k8sClient.Get(ctx, nn, updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, usernautdevv1alpha1.GroupReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(usernautdevv1alpha1.DuplicateGroupNameReason))
+			Expect(condition.Message).To(ContainSubstring(ownerGroup.Name))
+		})
+	})
+
+	Context("When LDAP lookups fail", func() {
+		ctx := context.Background()
+
+		It("should only report the members whose lookup failed as failures", func() {
+			reconciler, ldapClient := setupTestReconciler(nil)
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), []string{"alice", "bob"}).Return(
+				map[string]map[string]interface{}{
+					"alice": {"uid": "alice", "mail": "alice@example.com"},
+				}, nil)
+
+			result := reconciler.fetchLDAPData(ctx, []string{"alice", "bob"})
+			Expect(result.CurrentMembers).To(Equal([]string{"alice@example.com"}))
+			Expect(result.FailedUsers).To(BeEmpty())
+			Expect(result.SkippedUsers).To(Equal([]usernautdevv1alpha1.SkippedUser{
+				{User: "bob", Reason: usernautdevv1alpha1.SkippedUserNotFoundInLDAP, Message: ldap.ErrNoUserFound.Error()},
+			}))
+
+			By("reporting the members left without data by a failed search as failures")
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), []string{"alice", "carol"}).Return(
+				map[string]map[string]interface{}{
+					"alice": {"uid": "alice", "mail": "alice@example.com"},
+				}, fmt.Errorf("connection reset"))
+
+			result = reconciler.fetchLDAPData(ctx, []string{"alice", "carol"})
+			Expect(result.CurrentMembers).To(Equal([]string{"alice@example.com"}))
+			Expect(result.FailedUsers).To(Equal([]string{"carol"}))
+			Expect(result.SkippedUsers).To(Equal([]usernautdevv1alpha1.SkippedUser{
+				{User: "carol", Reason: usernautdevv1alpha1.SkippedUserLDAPLookupFailed, Message: "connection reset"},
+			}))
+
+			By("dropping the failed members with the Skip policy")
+			stop, _, err := reconciler.applyLDAPFailurePolicy(ctx, &usernautdevv1alpha1.Group{}, result.FailedUsers)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stop).To(BeFalse())
+		})
+
+		It("should abort with the Fail policy and requeue with the Freeze policy", func() {
+			nn := types.NamespacedName{Name: "test-ldap-failure-policy", Namespace: "default"}
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName:         "test-ldap-failure-policy",
+					Members:           usernautdevv1alpha1.Members{Users: []string{"carol"}},
+					Backends:          []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+					LDAPFailurePolicy: usernautdevv1alpha1.LDAPFailurePolicyFail,
+				},
+			}
+			Expect(k8sClient.Create(ctx, groupCR)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, groupCR) }()
+
+			reconciler, _ := setupTestReconciler(nil)
+			stop, _, err := reconciler.applyLDAPFailurePolicy(ctx, groupCR, []string{"carol"})
+			Expect(stop).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("LDAP lookup failed for 1 members")))
+			condition := meta.FindStatusCondition(groupCR.Status.Conditions, usernautdevv1alpha1.GroupReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(usernautdevv1alpha1.LDAPLookupFailedReason))
+
+			groupCR.Spec.LDAPFailurePolicy = usernautdevv1alpha1.LDAPFailurePolicyFreeze
+			stop, result, err := reconciler.applyLDAPFailurePolicy(ctx, groupCR, []string{"carol"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stop).To(BeTrue())
+			Expect(result.RequeueAfter).To(Equal(backendRetryBaseDelay))
+			condition = meta.FindStatusCondition(groupCR.Status.Conditions, usernautdevv1alpha1.GroupReadyCondition)
+			Expect(condition.Reason).To(Equal(usernautdevv1alpha1.LDAPMembershipFrozenReason))
+		})
+	})
+
+	Context("When a member's email changes in LDAP", func() {
+		ctx := context.Background()
+
+		It("should migrate the cache entries of the member to the new email", func() {
+			reconciler, ldapClient := setupTestReconciler(nil)
+			oldEmail, newEmail := "alice.old@example.com", "alice@example.com"
+			Expect(reconciler.Store.User.SetBackend(ctx, oldEmail, "fivetran_fivetran", "fivetran-alice")).To(Succeed())
+			Expect(reconciler.Store.UserGroups.AddGroup(ctx, oldEmail, "rename-team")).To(Succeed())
+			Expect(reconciler.Store.Group.SetMembers(ctx, "rename-team", []string{oldEmail, "bob@example.com"})).To(Succeed())
+			Expect(reconciler.Store.UserUID.SetEmail(ctx, "alice", oldEmail)).To(Succeed())
+
+			ldapClient.EXPECT().GetUsersLDAPData(gomock.Any(), []string{"alice"}).Return(
+				map[string]map[string]interface{}{
+					"alice": {"uid": "alice", "mail": newEmail},
+				}, nil)
+			result := reconciler.fetchLDAPData(ctx, []string{"alice"})
+			reconciler.migrateRenamedUsers(withLDAPUsers(ctx, result.Users))
+
+			backends, err := reconciler.Store.User.GetBackends(ctx, newEmail)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backends).To(HaveKeyWithValue("fivetran_fivetran", "fivetran-alice"))
+			exists, err := reconciler.Store.User.Exists(ctx, oldEmail)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+			groups, err := reconciler.Store.UserGroups.GetGroups(ctx, newEmail)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(Equal([]string{"rename-team"}))
+			members, err := reconciler.Store.Group.GetMembers(ctx, "rename-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]string{newEmail, "bob@example.com"}))
+			email, err := reconciler.Store.UserUID.GetEmail(ctx, "alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(email).To(Equal(newEmail))
+		})
+	})
+
+	Context("When the membership is unchanged since the last sync", func() {
+		ctx := context.Background()
+		backends := []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}, {Name: "gitlab", Type: "gitlab"}}
+		syncedGroup := func(now time.Time) *usernautdevv1alpha1.Group {
+			lastSync := metav1.NewTime(now.Add(-time.Minute))
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       usernautdevv1alpha1.GroupSpec{Backends: backends},
+				Status: usernautdevv1alpha1.GroupStatus{
+					LastSyncTime:       &lastSync,
+					ObservedGeneration: 2,
+					MembershipHash:     membershipHash([]string{"alice", "bob"}, backends),
+				},
+			}
+			meta.SetStatusCondition(&groupCR.Status.Conditions, metav1.Condition{
+				Type: usernautdevv1alpha1.BackendsReadyCondition, Status: metav1.ConditionTrue, Reason: "test"})
+			meta.SetStatusCondition(&groupCR.Status.Conditions, metav1.Condition{
+				Type: usernautdevv1alpha1.CacheReadyCondition, Status: metav1.ConditionTrue, Reason: "test"})
+			return groupCR
+		}
+
+		It("should hash the membership independently of the order", func() {
+			Expect(membershipHash([]string{"alice", "bob"}, backends)).
+				To(Equal(membershipHash([]string{"bob", "alice"}, []usernautdevv1alpha1.Backend{backends[1], backends[0]})))
+			Expect(membershipHash([]string{"alice"}, backends)).NotTo(Equal(membershipHash([]string{"alice", "bob"}, backends)))
+		})
+
+		It("should skip the backends only when nothing changed since a successful sync", func() {
+			reconciler, _ := setupTestReconciler(nil)
+			now := time.Now()
+			Expect(reconciler.syncUpToDate(ctx, syncedGroup(now), []string{"bob", "alice"}, now)).To(BeTrue())
+
+			By("syncing when the resolved members changed")
+			Expect(reconciler.syncUpToDate(ctx, syncedGroup(now), []string{"alice"}, now)).To(BeFalse())
+
+			By("syncing when the spec changed")
+			groupCR := syncedGroup(now)
+			groupCR.Generation = 3
+			Expect(reconciler.syncUpToDate(ctx, groupCR, []string{"alice", "bob"}, now)).To(BeFalse())
+
+			By("syncing when the last sync failed")
+			groupCR = syncedGroup(now)
+			meta.SetStatusCondition(&groupCR.Status.Conditions, metav1.Condition{
+				Type: usernautdevv1alpha1.BackendsReadyCondition, Status: metav1.ConditionFalse, Reason: "test"})
+			Expect(reconciler.syncUpToDate(ctx, groupCR, []string{"alice", "bob"}, now)).To(BeFalse())
+
+			By("syncing when the resync is due or forced")
+			Expect(reconciler.syncUpToDate(ctx, syncedGroup(now), []string{"alice", "bob"}, now.Add(requeueAfter))).To(BeFalse())
+			groupCR = syncedGroup(now)
+			groupCR.Labels = map[string]string{constants.ForceReconcileLabel: "true"}
+			Expect(reconciler.syncUpToDate(ctx, groupCR, []string{"alice", "bob"}, now)).To(BeFalse())
+		})
+	})
+
+	Context("When guarding against mass removals", func() {
+		withGuard := func(c *config.AppConfig) {
+			c.ControllerConfig.MassRemovalGuard = config.MassRemovalGuardConfig{MaxRemovalPercent: 30, MaxRemovals: 10}
+		}
+
+		It("should refuse removals above the thresholds", func() {
+			reconciler, _ := setupTestReconciler(nil, withGuard)
+			groupCR := &usernautdevv1alpha1.Group{}
+
+			Expect(reconciler.checkMassRemoval(groupCR, 3, 10)).To(Succeed())
+			Expect(reconciler.checkMassRemoval(groupCR, 4, 10)).To(MatchError(ContainSubstring("more than the 30% allowed")))
+			Expect(reconciler.checkMassRemoval(groupCR, 11, 100)).To(MatchError(ContainSubstring("more than the 10 allowed")))
+		})
+
+		It("should let the force reconcile label override the guard", func() {
+			reconciler, _ := setupTestReconciler(nil, withGuard)
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{constants.ForceReconcileLabel: "true"}},
+			}
+			Expect(reconciler.checkMassRemoval(groupCR, 10, 10)).To(Succeed())
+		})
+
+		It("should not guard removals without a configured threshold", func() {
+			reconciler, _ := setupTestReconciler(nil)
+			Expect(reconciler.checkMassRemoval(&usernautdevv1alpha1.Group{}, 10, 10)).To(Succeed())
+		})
+	})
+
+	Context("When changes need an approval", func() {
+		ctx := context.Background()
+		withApproval := func(c *config.AppConfig) {
+			c.ControllerConfig.Approval = config.ApprovalConfig{RequireForNewGroups: true, MaxMemberChanges: 2}
+		}
+
+		It("should require an approval for new groups and large member changes", func() {
+			reconciler, _ := setupTestReconciler(nil, withApproval)
+
+			groupCR := &usernautdevv1alpha1.Group{}
+			reason, _ := reconciler.approvalRequired(groupCR, []string{"alice"})
+			Expect(reason).To(Equal(usernautdevv1alpha1.NewGroupApprovalReason))
+
+			groupCR.Status.LastAppliedGeneration = 1
+			groupCR.Status.ReconciledUsers = []string{"alice", "bob"}
+			reason, _ = reconciler.approvalRequired(groupCR, []string{"alice", "carol"})
+			Expect(reason).To(BeEmpty())
+
+			reason, message := reconciler.approvalRequired(groupCR, []string{"carol", "dave"})
+			Expect(reason).To(Equal(usernautdevv1alpha1.LargeChangeApprovalReason))
+			Expect(message).To(ContainSubstring("4 member changes exceed the approval threshold of 2"))
+		})
+
+		It("should not require approvals without a configured threshold", func() {
+			reconciler, _ := setupTestReconciler(nil)
+			reason, _ := reconciler.approvalRequired(&usernautdevv1alpha1.Group{}, []string{"alice"})
+			Expect(reason).To(BeEmpty())
+		})
+
+		It("should wait for the approved-by annotation and consume it", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-approval",
+					Members:   usernautdevv1alpha1.Members{Users: []string{"alice"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, groupCR)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, groupCR) }()
+			reconciler, _ := setupTestReconciler(nil, withApproval)
+
+			pending := membershipHash([]string{"alice"}, nil)
+			result, err := reconciler.awaitApproval(ctx, groupCR, usernautdevv1alpha1.NewGroupApprovalReason,
+				"New group needs an approval", pending)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(meta.IsStatusConditionTrue(groupCR.Status.Conditions,
+				usernautdevv1alpha1.AwaitingApprovalCondition)).To(BeTrue())
+			Expect(groupCR.Status.PendingApproval).To(Equal(pending))
+
+			By("dropping an approval given before the membership changed")
+			groupCR.SetAnnotations(map[string]string{constants.ApprovedByAnnotation: "jsmith"})
+			Expect(k8sClient.Update(ctx, groupCR)).To(Succeed())
+			Expect(reconciler.dropStaleApproval(ctx, groupCR, "jsmith")).To(Succeed())
+			Expect(groupCR.GetAnnotations()).NotTo(HaveKey(constants.ApprovedByAnnotation))
+			Expect(groupCR.Status.PendingApproval).To(Equal(pending))
+
+			groupCR.SetAnnotations(map[string]string{constants.ApprovedByAnnotation: "jsmith"})
+			Expect(k8sClient.Update(ctx, groupCR)).To(Succeed())
+			Expect(reconciler.consumeApproval(ctx, groupCR, "jsmith", "New group needs an approval")).To(Succeed())
+			Expect(groupCR.Status.PendingApproval).To(BeEmpty())
+
+			condition := meta.FindStatusCondition(groupCR.Status.Conditions, usernautdevv1alpha1.AwaitingApprovalCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(usernautdevv1alpha1.ApprovedReason))
+			Expect(condition.Message).To(ContainSubstring("approved by jsmith"))
+
+			By("not requiring a new approval for the approved group")
+			reason, _ := reconciler.approvalRequired(groupCR, []string{"alice"})
+			Expect(reason).To(BeEmpty())
+
+			updated := &usernautdevv1alpha1.Group{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: groupCR.Name, Namespace: groupCR.Namespace}, updated)).
+				To(Succeed())
+			Expect(updated.GetAnnotations()).NotTo(HaveKey(constants.ApprovedByAnnotation))
+		})
+	})
+
+	Context("When a group only adopts existing teams", func() {
+		ctx := context.Background()
+		withAdoptPattern := func(c *config.AppConfig) {
+			c.Pattern = map[string][]config.PatternEntry{
+				"fivetran": {{
+					Input:  `^test-adopt-(.*)$`,
+					Output: "test_adopt_$1",
+				}},
+			}
+		}
+		backendParams := &structs.BackendParams{Name: "fivetran", Type: "fivetran"}
+
+		It("should adopt the team named after the transformed group name", func() {
+			reconciler, _ := setupTestReconciler(nil, withAdoptPattern)
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			backendClient.EXPECT().FetchAllTeams(gomock.Any()).Return(map[string]structs.Team{
+				"team-1": {ID: "team-1", Name: "test_adopt_existing"},
+				"team-2": {ID: "team-2", Name: "test_adopt_other"},
+			}, nil)
+			backendClient.EXPECT().CreateTeam(gomock.Any(), gomock.Any()).Times(0)
+
+			groupCR := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{GroupName: "test-adopt-existing", AdoptExisting: true},
+			}
+			teamID, err := reconciler.fetchOrCreateTeam(ctx, groupCR, backendClient, backendParams, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamID).To(Equal("team-1"))
+
+			storedID, err := reconciler.Store.Group.GetBackendID(ctx, "test-adopt-existing", "fivetran", "fivetran")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(storedID).To(Equal("team-1"))
+		})
+
+		It("should fail instead of creating a team missing from the backend", func() {
+			reconciler, _ := setupTestReconciler(nil, withAdoptPattern)
+			backendClient := clientmocks.NewMockClient(gomock.NewController(GinkgoT()))
+			backendClient.EXPECT().FetchAllTeams(gomock.Any()).Return(map[string]structs.Team{
+				"team-2": {ID: "team-2", Name: "test_adopt_other"},
+			}, nil)
+			backendClient.EXPECT().CreateTeam(gomock.Any(), gomock.Any()).Times(0)
+
+			groupCR := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{GroupName: "test-adopt-missing", AdoptExisting: true},
+			}
+			_, err := reconciler.fetchOrCreateTeam(ctx, groupCR, backendClient, backendParams, "")
+			Expect(err).To(MatchError(ContainSubstring("has no team test_adopt_missing to adopt")))
+		})
+	})
+
+	Context("When backend selectors are configured", func() {
+		withBackendSelectors := func(c *config.AppConfig) {
+			c.BackendSelectors = []config.BackendSelector{
+				{Selector: "tier=data", Backends: []config.BackendRef{
+					{Name: "prod", Type: "snowflake"},
+					{Name: "fivetran", Type: "fivetran"},
+				}},
+			}
+		}
+
+		It("should merge the selected backends with the spec backends", func() {
+			reconciler, _ := setupTestReconciler(nil, withBackendSelectors)
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "data"}},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Backends: []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+			}
+
+			Expect(reconciler.groupBackends(groupCR)).To(Equal([]usernautdevv1alpha1.Backend{
+				{Name: "fivetran", Type: "fivetran"},
+				{Name: "prod", Type: "snowflake"},
+			}))
+			Expect(groupCR.Spec.Backends).To(HaveLen(1))
+		})
+
+		It("should keep the spec backends of groups not matching a selector", func() {
+			reconciler, _ := setupTestReconciler(nil, withBackendSelectors)
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "web"}},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Backends: []usernautdevv1alpha1.Backend{{Name: "gitlab", Type: "gitlab"}},
+				},
+			}
+
+			Expect(reconciler.groupBackends(groupCR)).To(Equal(groupCR.Spec.Backends))
+		})
+	})
+
+	Context("When a backend is removed from the spec", func() {
+		ctx := context.Background()
+
+		It("should list the previously reconciled backends missing from the spec", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Backends: []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+				Status: usernautdevv1alpha1.GroupStatus{
+					BackendsStatus: []usernautdevv1alpha1.BackendStatus{
+						{Name: "fivetran", Type: "fivetran", Status: true},
+						{Name: "gitlab", Type: "gitlab", Status: true},
+					},
+				},
+			}
+
+			Expect(removedBackends(groupCR, groupCR.Spec.Backends)).To(Equal([]usernautdevv1alpha1.Backend{{Name: "gitlab", Type: "gitlab"}}))
+		})
+
+		It("should drop the removed backend from the cache", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName:      "test-removed-backend",
+					Backends:       []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+					DeletionPolicy: usernautdevv1alpha1.DeletionPolicyRetain,
+				},
+				Status: usernautdevv1alpha1.GroupStatus{
+					BackendsStatus: []usernautdevv1alpha1.BackendStatus{
+						{Name: "fivetran", Type: "fivetran", Status: true},
+						{Name: "gitlab", Type: "gitlab", Status: true, TeamID: "42"},
+					},
+				},
+			}
+			reconciler, _ := setupTestReconciler(nil)
+			Expect(reconciler.Store.Group.SetBackend(ctx, "test-removed-backend", "fivetran", "fivetran", "ft-team")).To(Succeed())
+			Expect(reconciler.Store.Group.SetBackend(ctx, "test-removed-backend", "gitlab", "gitlab", "42")).To(Succeed())
+
+			Expect(reconciler.teardownRemovedBackends(ctx, groupCR)).To(BeEmpty())
+
+			recorder := reconciler.Recorder.(*record.FakeRecorder)
+			Expect(recorder.Events).To(Receive(ContainSubstring(eventReasonTeamRetained)))
+			backends, err := reconciler.Store.Group.GetBackends(ctx, "test-removed-backend")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backends).To(HaveLen(1))
+			Expect(backends).To(HaveKey("fivetran_fivetran"))
+		})
+
+		It("should retain the team of a backend no longer attached by a backend selector", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-unselected-backend",
+					Backends:  []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+				Status: usernautdevv1alpha1.GroupStatus{
+					BackendsStatus: []usernautdevv1alpha1.BackendStatus{
+						{Name: "fivetran", Type: "fivetran", Status: true},
+						{Name: "gitlab", Type: "gitlab", Status: true, TeamID: "42",
+							DeletionPolicy: usernautdevv1alpha1.DeletionPolicyRetain},
+					},
+				},
+			}
+			// No gitlab backend is configured, so deleting its team would fail
+			reconciler, _ := setupTestReconciler(nil)
+			Expect(reconciler.Store.Group.SetBackend(ctx, "test-unselected-backend", "gitlab", "gitlab", "42")).To(Succeed())
+
+			Expect(reconciler.teardownRemovedBackends(ctx, groupCR)).To(BeEmpty())
+
+			recorder := reconciler.Recorder.(*record.FakeRecorder)
+			Expect(recorder.Events).To(Receive(ContainSubstring("no longer attached by a backend selector")))
+			backends, err := reconciler.Store.Group.GetBackends(ctx, "test-unselected-backend")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backends).NotTo(HaveKey("gitlab_gitlab"))
+		})
+
+		It("should record the deletion policy of the backends attached by a backend selector", func() {
+			reconciler, _ := setupTestReconciler(nil, func(appConfig *config.AppConfig) {
+				appConfig.BackendSelectors = []config.BackendSelector{
+					{Selector: "tier=data", Backends: []config.BackendRef{{Name: "gitlab", Type: "gitlab"}}},
+					{Selector: "env=prod", Backends: []config.BackendRef{{Name: "snowflake", Type: "snowflake"}},
+						DeletionPolicy: config.SelectorDeletionPolicyDelete},
+				}
+			})
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "data", "env": "prod"}},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Backends: []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+			}
+
+			Expect(reconciler.selectorDeletionPolicy(groupCR, usernautdevv1alpha1.Backend{Name: "fivetran", Type: "fivetran"})).
+				To(BeEmpty())
+			Expect(reconciler.selectorDeletionPolicy(groupCR, usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"})).
+				To(Equal(usernautdevv1alpha1.DeletionPolicyRetain))
+			Expect(reconciler.selectorDeletionPolicy(groupCR, usernautdevv1alpha1.Backend{Name: "snowflake", Type: "snowflake"})).
+				To(Equal(usernautdevv1alpha1.DeletionPolicyDelete))
+		})
+
+		It("should keep the backend in the status when its team cannot be deleted", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-removed-backend-failed",
+					Backends:  []usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}},
+				},
+				Status: usernautdevv1alpha1.GroupStatus{
+					BackendsStatus: []usernautdevv1alpha1.BackendStatus{
+						{Name: "gitlab", Type: "gitlab", Status: true, TeamID: "42"},
+					},
+				},
+			}
+			// No gitlab backend is configured, so its client cannot be created
+			reconciler, _ := setupTestReconciler(nil)
+
+			failed := reconciler.teardownRemovedBackends(ctx, groupCR)
+			Expect(failed).To(HaveLen(1))
+			Expect(failed[0].Name).To(Equal("gitlab"))
+			Expect(failed[0].TeamID).To(Equal("42"))
+			Expect(failed[0].Status).To(BeFalse())
+		})
+	})
+
+	Context("When retrying failed backends", func() {
+		It("should only retry the backends which failed at the current generation", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+						{Name: "gitlab", Type: "gitlab"},
+					},
+				},
+				Status: usernautdevv1alpha1.GroupStatus{
+					BackendsStatus: []usernautdevv1alpha1.BackendStatus{
+						{Name: "fivetran", Type: "fivetran", Status: true, ObservedGeneration: 2},
+						{Name: "gitlab", Type: "gitlab", Status: false, ObservedGeneration: 2, Retries: 1},
+					},
+				},
+			}
+			reconciler, _ := setupTestReconciler(nil)
+
+			Expect(reconciler.backendsToReconcile(groupCR)).To(ConsistOf(
+				usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"},
+			))
+
+			By("processing all the backends after a spec change")
+			groupCR.Generation = 3
+			Expect(reconciler.backendsToReconcile(groupCR)).To(HaveLen(2))
+
+			By("processing all the backends when the force reconcile label is set")
+			groupCR.Generation = 2
+			groupCR.Labels = map[string]string{constants.ForceReconcileLabel: "true"}
+			Expect(reconciler.backendsToReconcile(groupCR)).To(HaveLen(2))
+		})
+
+		It("should only re-drive the backend named by the force reconcile label", func() {
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+					Labels:     map[string]string{constants.ForceReconcileLabel: "gitlab_gitlab"},
+				},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+						{Name: "gitlab", Type: "gitlab"},
+						{Name: "prod", Type: "snowflake"},
+					},
+				},
+				Status: usernautdevv1alpha1.GroupStatus{
+					BackendsStatus: []usernautdevv1alpha1.BackendStatus{
+						{Name: "fivetran", Type: "fivetran", Status: true, ObservedGeneration: 2},
+						{Name: "gitlab", Type: "gitlab", Status: true, ObservedGeneration: 2},
+					},
+				},
+			}
+			reconciler, _ := setupTestReconciler(nil)
+
+			By("also processing the backends never reconciled")
+			Expect(reconciler.backendsToReconcile(groupCR)).To(ConsistOf(
+				usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"},
+				usernautdevv1alpha1.Backend{Name: "prod", Type: "snowflake"},
+			))
+
+			By("re-driving all the backends for a value naming no backend of the group")
+			groupCR.Labels[constants.ForceReconcileLabel] = "snowflake_staging"
+			Expect(reconciler.backendsToReconcile(groupCR)).To(HaveLen(3))
+		})
+
+		It("should record the sync details on the backend status", func() {
+			ctx := context.Background()
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-backend-sync-status", Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-backend-sync-status",
+					Members:   usernautdevv1alpha1.Members{Users: []string{"user1"}},
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+						{Name: "gitlab", Type: "gitlab"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, groupCR)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, groupCR) }()
+
+			lastSync := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			groupCR.Status.BackendsStatus = []usernautdevv1alpha1.BackendStatus{
+				{Name: "gitlab", Type: "gitlab", Status: true, TeamID: "42", MemberCount: 3, LastSyncTime: &lastSync},
+			}
+			groupCR.Status.ReconciledUsers = []string{"user1"}
+			reconciler, _ := setupTestReconciler(nil)
+
+			_, err := reconciler.updateStatusAndHandleErrors(ctx, groupCR, groupCR.Spec.Backends,
+				map[string]map[string]string{"gitlab": {"gitlab": "failed to add users"}},
+				map[string]backendSyncResult{
+					"fivetran_fivetran": {teamID: "ft-team", memberCount: 4, usersAdded: 2, usersRemoved: 1},
+					"gitlab_gitlab":     {teamID: "42", memberCount: 5},
+				}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			statuses := map[string]usernautdevv1alpha1.BackendStatus{}
+			for _, s := range groupCR.Status.BackendsStatus {
+				statuses[s.Name] = s
+			}
+			Expect(statuses["fivetran"].TeamID).To(Equal("ft-team"))
+			Expect(statuses["fivetran"].MemberCount).To(Equal(4))
+			Expect(statuses["fivetran"].UsersAdded).To(Equal(2))
+			Expect(statuses["fivetran"].UsersRemoved).To(Equal(1))
+			Expect(statuses["fivetran"].LastSyncTime).NotTo(BeNil())
+
+			By("keeping the details of the last successful sync for a failed backend")
+			Expect(statuses["gitlab"].Status).To(BeFalse())
+			Expect(statuses["gitlab"].MemberCount).To(Equal(3))
+			Expect(statuses["gitlab"].LastSyncTime.Equal(&lastSync)).To(BeTrue())
+
+			By("reporting the failed backends on the BackendsReady condition")
+			condition := meta.FindStatusCondition(groupCR.Status.Conditions, usernautdevv1alpha1.BackendsReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(usernautdevv1alpha1.BackendReconcileFailedReason))
+			Expect(condition.Message).To(ContainSubstring("gitlab/gitlab"))
+
+			By("summarizing the sync for the printer columns")
+			Expect(groupCR.Status.ReadyBackends).To(Equal("1/2"))
+			Expect(groupCR.Status.MemberCount).To(Equal(1))
+			Expect(groupCR.Status.LastSyncTime).To(BeNil())
+			Expect(groupCR.Status.MembershipHash).To(BeEmpty())
+		})
+
+		It("should only record the sync of the group once all its backends were synced", func() {
+			ctx := context.Background()
+			groupCR := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-partial-sync-status", Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					GroupName: "test-partial-sync-status",
+					Members:   usernautdevv1alpha1.Members{Users: []string{"user1"}},
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+						{Name: "gitlab", Type: "gitlab"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, groupCR)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, groupCR) }()
+
+			groupCR.Status.BackendsStatus = []usernautdevv1alpha1.BackendStatus{
+				{Name: "gitlab", Type: "gitlab", Status: true, TeamID: "42"},
+			}
+			groupCR.Status.ReconciledUsers = []string{"user1"}
+			reconciler, _ := setupTestReconciler(nil)
+
+			_, err := reconciler.updateStatusAndHandleErrors(ctx, groupCR, groupCR.Spec.Backends[:1], nil,
+				map[string]backendSyncResult{"fivetran_fivetran": {teamID: "ft-team"}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(groupCR.Status.Conditions,
+				usernautdevv1alpha1.BackendsReadyCondition)).To(BeTrue())
+			Expect(groupCR.Status.LastSyncTime).To(BeNil())
+			Expect(groupCR.Status.MembershipHash).To(BeEmpty())
+
+			By("recording the sync once all the backends were processed")
+			_, err = reconciler.updateStatusAndHandleErrors(ctx, groupCR, groupCR.Spec.Backends, nil,
+				map[string]backendSyncResult{"fivetran_fivetran": {teamID: "ft-team"}, "gitlab_gitlab": {teamID: "42"}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groupCR.Status.LastSyncTime).NotTo(BeNil())
+			Expect(groupCR.Status.MembershipHash).To(Equal(membershipHash([]string{"user1"}, groupCR.Spec.Backends)))
+		})
+
+		It("should back off exponentially up to the maximum delay", func() {
+			Expect(backendRetryDelay(1, backendRetryBaseDelay, backendRetryMaxDelay)).To(Equal(backendRetryBaseDelay))
+			Expect(backendRetryDelay(3, backendRetryBaseDelay, backendRetryMaxDelay)).To(Equal(4 * backendRetryBaseDelay))
+			Expect(backendRetryDelay(20, backendRetryBaseDelay, backendRetryMaxDelay)).To(Equal(backendRetryMaxDelay))
+		})
+	})
+})
+
+// fakeDependentClient records the dependency the reconciler configures it with
+type fakeDependentClient struct {
+	clients.Client
+	dependsOn config.Dependant
+	groupName string
+}
+
+func (f *fakeDependentClient) ConfigureDependency(_ context.Context, dependsOn config.Dependant, groupName string) (bool, error) {
+	f.dependsOn = dependsOn
+	f.groupName = groupName
+	return true, nil
+}
+
+// fakeNestedTeamClient records the nested teams added and removed by the reconciler
+type fakeNestedTeamClient struct {
+	nested  []string
+	added   []string
+	removed []string
+}
+
+func (f *fakeNestedTeamClient) FetchNestedTeams(_ context.Context, _ string) ([]string, error) {
+	return f.nested, nil
+}
+
+func (f *fakeNestedTeamClient) AddNestedTeams(_ context.Context, _ string, nestedTeamIDs []string) error {
+	f.added = append(f.added, nestedTeamIDs...)
+	return nil
+}
+
+func (f *fakeNestedTeamClient) RemoveNestedTeams(_ context.Context, _ string, nestedTeamIDs []string) error {
+	f.removed = append(f.removed, nestedTeamIDs...)
+	return nil
+}
+
+// fakeDirectMemberClient lists the direct members of a team and records the members removed by the audit
+type fakeDirectMemberClient struct {
+	members map[string]*structs.User
+	removed []string
+}
+
+func (f *fakeDirectMemberClient) FetchDirectTeamMembers(_ context.Context, _ string) (map[string]*structs.User, error) {
+	return f.members, nil
+}
+
+func (f *fakeDirectMemberClient) RemoveDirectTeamMembers(_ context.Context, _ string, userIDs []string) error {
+	f.removed = append(f.removed, userIDs...)
+	return nil
+}
+
+// memberGroupsReader lists Group CRs from memory, filtered by the member groups index
+type memberGroupsReader struct {
+	groups []usernautdevv1alpha1.Group
+}
+
+func (r *memberGroupsReader) Get(_ context.Context, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return errors.NewNotFound(schema.GroupResource{Resource: "groups"}, key.Name)
+}
+
+func (r *memberGroupsReader) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	memberGroup, _ := listOpts.FieldSelector.RequiresExactMatch(controllerutils.MemberGroupsIndexField)
+	groupList := list.(*usernautdevv1alpha1.GroupList)
+	for _, group := range r.groups {
+		if slices.Contains(group.Spec.Members.Groups, memberGroup) {
+			groupList.Items = append(groupList.Items, group)
+		}
+	}
+	return nil
+}
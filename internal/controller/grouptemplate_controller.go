@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// GroupTemplateReconciler expands a GroupTemplate into the Groups referencing it
+type GroupTemplateReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	log    *logrus.Entry
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=grouptemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=grouptemplates/status,verbs=get;update;patch
+
+func (r *GroupTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = logger.WithRequestId(ctx, controller.ReconcileIDFromContext(ctx))
+	r.log = logger.Logger(ctx).WithFields(logrus.Fields{
+		"request": req.NamespacedName.String(),
+	})
+
+	template := &usernautdevv1alpha1.GroupTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, template); err != nil {
+		// Groups keep the spec last expanded from a deleted template
+		r.log.WithError(err).Error("Unable to fetch GroupTemplate CR")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	groups := &usernautdevv1alpha1.GroupList{}
+	if err := r.List(ctx, groups, client.InNamespace(template.Namespace)); err != nil {
+		r.log.WithError(err).Error("error listing the groups")
+		return ctrl.Result{}, err
+	}
+
+	referencing := 0
+	failed := 0
+	for i := range groups.Items {
+		group := &groups.Items[i]
+		if group.Spec.TemplateRef != template.Name || group.GetDeletionTimestamp() != nil {
+			continue
+		}
+		referencing++
+		if !applyGroupTemplate(group, template) {
+			continue
+		}
+		if err := r.Update(ctx, group); err != nil {
+			r.log.WithError(err).WithField("group", group.Name).Error("error expanding the template into the group")
+			failed++
+			continue
+		}
+		r.log.WithField("group", group.Name).Info("expanded the template into the group")
+	}
+
+	if failed > 0 {
+		return ctrl.Result{}, fmt.Errorf("failed to expand the template into %d groups", failed)
+	}
+
+	template.Status.Groups = referencing
+	template.Status.ObservedGeneration = template.Generation
+	if err := r.Status().Update(ctx, template); err != nil {
+		r.log.WithError(err).Error("error updating the template status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// applyGroupTemplate sets the group name, backends and group params of the template on the group,
+// it reports whether the group spec changed. The group name is only built when the template is first
+// expanded into the group, renaming an expanded group would leave its backend teams under the previous name.
+func applyGroupTemplate(group *usernautdevv1alpha1.Group, template *usernautdevv1alpha1.GroupTemplate) bool {
+	expanded := group.Spec.DeepCopy()
+
+	// the backends of a group referencing a template are only set by its expansion
+	firstExpansion := len(group.Spec.Backends) == 0
+	switch {
+	case firstExpansion && template.Spec.GroupNameFormat != "":
+		expanded.GroupName = strings.ReplaceAll(template.Spec.GroupNameFormat,
+			usernautdevv1alpha1.GroupNamePlaceholder, group.Name)
+	case expanded.GroupName == "":
+		expanded.GroupName = group.Name
+	}
+	expanded.Backends = template.DeepCopy().Spec.Backends
+	expanded.GroupParams = template.DeepCopy().Spec.GroupParams
+
+	if equality.Semantic.DeepEqual(&group.Spec, expanded) {
+		return false
+	}
+	group.Spec = *expanded
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GroupTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// A group referencing a template is expanded as soon as it is created or starts referencing it
+	mapFunc := func(_ context.Context, obj client.Object) []reconcile.Request {
+		group := obj.(*usernautdevv1alpha1.Group)
+		if group.Spec.TemplateRef == "" {
+			return nil
+		}
+		return []reconcile.Request{{
+			NamespacedName: types.NamespacedName{
+				Name:      group.Spec.TemplateRef,
+				Namespace: group.Namespace,
+			},
+		}}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&usernautdevv1alpha1.GroupTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			client.Object(&usernautdevv1alpha1.Group{}),
+			handler.EnqueueRequestsFromMapFunc(mapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+)
+
+var _ = Describe("GroupTemplate Controller", func() {
+	newTemplate := func() *usernautdevv1alpha1.GroupTemplate {
+		return &usernautdevv1alpha1.GroupTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+			Spec: usernautdevv1alpha1.GroupTemplateSpec{
+				GroupNameFormat: "dataverse-aggregate-{name}",
+				Backends: []usernautdevv1alpha1.Backend{
+					{Name: "fivetran", Type: "fivetran"},
+				},
+				GroupParams: []usernautdevv1alpha1.GroupParam{
+					{Backend: "gitlab", Name: "gitlab", Property: "project_access_paths", Value: []string{"team/project"}},
+				},
+			},
+		}
+	}
+
+	Context("When expanding a template into a group", func() {
+		It("should apply the naming, backends and group params of the template", func() {
+			group := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "mygroup"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					TemplateRef: "test-template",
+					Members:     usernautdevv1alpha1.Members{Users: []string{"user1"}},
+				},
+			}
+
+			Expect(applyGroupTemplate(group, newTemplate())).To(BeTrue())
+			Expect(group.Spec.GroupName).To(Equal("dataverse-aggregate-mygroup"))
+			Expect(group.Spec.Backends).To(Equal([]usernautdevv1alpha1.Backend{{Name: "fivetran", Type: "fivetran"}}))
+			Expect(group.Spec.GroupParams).To(HaveLen(1))
+			Expect(group.Spec.Members.Users).To(Equal([]string{"user1"}))
+
+			By("leaving an already expanded group untouched")
+			Expect(applyGroupTemplate(group, newTemplate())).To(BeFalse())
+		})
+
+		It("should default the group name to the CR name without a naming format", func() {
+			template := newTemplate()
+			template.Spec.GroupNameFormat = ""
+			group := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "mygroup"},
+				Spec:       usernautdevv1alpha1.GroupSpec{TemplateRef: "test-template"},
+			}
+
+			Expect(applyGroupTemplate(group, template)).To(BeTrue())
+			Expect(group.Spec.GroupName).To(Equal("mygroup"))
+		})
+
+		It("should keep the group name of an expanded group when the naming format changes", func() {
+			group := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "mygroup"},
+				Spec:       usernautdevv1alpha1.GroupSpec{TemplateRef: "test-template"},
+			}
+			Expect(applyGroupTemplate(group, newTemplate())).To(BeTrue())
+
+			template := newTemplate()
+			template.Spec.GroupNameFormat = "dataverse-{name}"
+			template.Spec.Backends = append(template.Spec.Backends, usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"})
+
+			Expect(applyGroupTemplate(group, template)).To(BeTrue())
+			Expect(group.Spec.GroupName).To(Equal("dataverse-aggregate-mygroup"))
+			Expect(group.Spec.Backends).To(HaveLen(2))
+		})
+	})
+
+	Context("When reconciling a GroupTemplate resource", func() {
+		ctx := context.Background()
+
+		It("should expand the template into the groups referencing it", func() {
+			template := newTemplate()
+			Expect(k8sClient.Create(ctx, template)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, template) }()
+
+			group := &usernautdevv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-group", Namespace: "default"},
+				Spec: usernautdevv1alpha1.GroupSpec{
+					TemplateRef: template.Name,
+					Members:     usernautdevv1alpha1.Members{Users: []string{"user1"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, group)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, group) }()
+
+			reconciler := &GroupTemplateReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			nn := types.NamespacedName{Name: template.Name, Namespace: template.Namespace}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &usernautdevv1alpha1.Group{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated)).To(Succeed())
+			Expect(updated.Spec.GroupName).To(Equal("dataverse-aggregate-templated-group"))
+			Expect(updated.Spec.Backends).To(HaveLen(1))
+
+			updatedTemplate := &usernautdevv1alpha1.GroupTemplate{}
+			Expect(k8sClient.Get(ctx, nn, updatedTemplate)).To(Succeed())
+			Expect(updatedTemplate.Status.Groups).To(Equal(1))
+		})
+	})
+})
@@ -3,7 +3,6 @@ package controller
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs"
@@ -27,20 +26,27 @@ type PeriodicTasksReconciler struct {
 	taskManager *periodicjobs.PeriodicTaskManager
 	cacheClient cache.Cache // Keep for health checks
 	store       *store.Store
+
+	ldapClient         ldap.LDAPClient
+	userOffboardingJob *periodicjobs.UserOffboardingJob
+	// offboardingWatch offboards the users reported by the LDAP watch, nil when not enabled
+	offboardingWatch *periodicjobs.UserOffboardingWatch
 }
 
 func NewPeriodicTasksReconciler(
 	k8sClient client.Client,
-	sharedCacheMutex *sync.RWMutex,
+	sharedCacheMutex cache.Locker,
 	cacheClient cache.Cache,
 	dataStore *store.Store,
 	ldapClient ldap.LDAPClient,
 	backendClients map[string]clients.Client,
+	backendHealthJob *periodicjobs.BackendHealthJob,
 ) (*PeriodicTasksReconciler, error) {
 	periodicTaskManager := periodicjobs.NewPeriodicTaskManager()
 
 	// Add jobs to the periodic task manager
 	userOffboardingJob := periodicjobs.NewUserOffboardingJob(
+		k8sClient,
 		sharedCacheMutex,
 		dataStore,
 		ldapClient,
@@ -48,11 +54,20 @@ func NewPeriodicTasksReconciler(
 	)
 	userOffboardingJob.AddToPeriodicTaskManager(periodicTaskManager)
 
+	groupMembershipExpiryJob := periodicjobs.NewGroupMembershipExpiryJob(k8sClient)
+	groupMembershipExpiryJob.AddToPeriodicTaskManager(periodicTaskManager)
+
+	if backendHealthJob != nil {
+		backendHealthJob.AddToPeriodicTaskManager(periodicTaskManager)
+	}
+
 	return &PeriodicTasksReconciler{
-		Client:      k8sClient,
-		taskManager: periodicTaskManager,
-		cacheClient: cacheClient,
-		store:       dataStore,
+		Client:             k8sClient,
+		taskManager:        periodicTaskManager,
+		cacheClient:        cacheClient,
+		store:              dataStore,
+		ldapClient:         ldapClient,
+		userOffboardingJob: userOffboardingJob,
 	}, nil
 }
 
@@ -91,9 +106,40 @@ func (ptr *PeriodicTasksReconciler) Start(ctx context.Context) error {
 	}
 
 	logger.Info("All periodic tasks have been started successfully")
+
+	watchDone := make(chan struct{})
+	if ptr.offboardingWatch != nil {
+		logger.Info("Starting the LDAP watch of the offboarded users")
+		ptr.offboardingWatch.ShutdownGracePeriod = ptr.taskManager.ShutdownGracePeriod
+		go func() {
+			defer close(watchDone)
+			if err := ptr.offboardingWatch.Run(ctx); err != nil {
+				logger.Error(err, "LDAP watch of the users failed, users are offboarded by the periodic task only")
+			}
+		}()
+	} else {
+		close(watchDone)
+	}
+
+	// The manager waits for Start to return on shutdown, so the running tasks are drained first
+	<-ctx.Done()
+	logger.Info("Draining the running periodic tasks")
+	ptr.taskManager.Wait()
+	<-watchDone
 	return nil
 }
 
+// SetShutdownGracePeriod sets how long a running periodic task may run on shutdown to reach a checkpoint
+func (ptr *PeriodicTasksReconciler) SetShutdownGracePeriod(gracePeriod time.Duration) {
+	ptr.taskManager.ShutdownGracePeriod = gracePeriod
+}
+
+// EnableLDAPWatch offboards the users as the LDAP watch reports them deleted or inactive, in
+// addition to the periodic offboarding task
+func (ptr *PeriodicTasksReconciler) EnableLDAPWatch() {
+	ptr.offboardingWatch = periodicjobs.NewUserOffboardingWatch(ptr.ldapClient, ptr.userOffboardingJob)
+}
+
 // waitForDependencies waits for all required dependencies to be ready before starting periodic tasks
 func (ptr *PeriodicTasksReconciler) waitForDependencies(ctx context.Context) error {
 	logger := log.FromContext(ctx)
@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file implements the backend health periodic job that checks LDAP and the enabled backends,
+// exporting their health as metrics and to the readiness probe of the operator.
+package periodicjobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// BackendHealthJobName is the unique identifier for the backend health periodic job.
+	BackendHealthJobName = "usernaut_backend_health"
+
+	// DefaultBackendHealthJobInterval is the default interval if not configured.
+	DefaultBackendHealthJobInterval = time.Minute
+
+	// DefaultBackendHealthCheckTimeout is the default timeout of the health check of a backend.
+	DefaultBackendHealthCheckTimeout = 10 * time.Second
+
+	// ldapHealthName is the name and type LDAP is reported with
+	ldapHealthName = "ldap"
+)
+
+// backendHealthy exports the result of the last health check of each backend
+var backendHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "usernaut_backend_healthy",
+	Help: "Whether the last health check of the backend succeeded (1) or failed (0)",
+}, []string{"backend_name", "backend_type"})
+
+func init() {
+	metrics.Registry.MustRegister(backendHealthy)
+}
+
+// BackendHealthJob implements a periodic job that checks the health of LDAP and the enabled
+// backends. The last results are reported by the readiness probe, the metrics and the API server.
+type BackendHealthJob struct {
+	ldapClient ldap.LDAPClient
+	backends   map[config.BackendRef]clients.Client
+	interval   time.Duration
+	timeout    time.Duration
+
+	mu        sync.RWMutex
+	statuses  []clients.HealthStatus
+	checkedAt time.Time
+	// refreshing guards the background checks started by the readiness probe
+	refreshing atomic.Bool
+}
+
+// NewBackendHealthJob creates a new BackendHealthJob instance checking the backends every interval,
+// each check being canceled after timeout. Zero values use the defaults.
+func NewBackendHealthJob(
+	ldapClient ldap.LDAPClient,
+	backends map[config.BackendRef]clients.Client,
+	interval, timeout time.Duration,
+) *BackendHealthJob {
+	if interval <= 0 {
+		interval = DefaultBackendHealthJobInterval
+	}
+	if timeout <= 0 {
+		timeout = DefaultBackendHealthCheckTimeout
+	}
+	return &BackendHealthJob{
+		ldapClient: ldapClient,
+		backends:   backends,
+		interval:   interval,
+		timeout:    timeout,
+	}
+}
+
+// AddToPeriodicTaskManager registers this job with the provided periodic task manager.
+func (bhj *BackendHealthJob) AddToPeriodicTaskManager(mgr *PeriodicTaskManager) {
+	mgr.AddTask(bhj)
+}
+
+// GetInterval returns the execution interval for this periodic job.
+func (bhj *BackendHealthJob) GetInterval() time.Duration {
+	return bhj.interval
+}
+
+// GetName returns the unique name identifier for this periodic job.
+func (bhj *BackendHealthJob) GetName() string {
+	return BackendHealthJobName
+}
+
+// Run checks the health of LDAP and the backends, returning the failed checks
+func (bhj *BackendHealthJob) Run(ctx context.Context) error {
+	var errs []error
+	for _, status := range bhj.Check(ctx) {
+		if !status.Healthy {
+			errs = append(errs, fmt.Errorf("%s backend %s is unhealthy: %s", status.Type, status.Name, status.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check runs the health checks of LDAP and the backends concurrently, records their results and
+// returns them sorted by backend type and name
+func (bhj *BackendHealthJob) Check(ctx context.Context) []clients.HealthStatus {
+	log := logger.Logger(ctx).WithField("job", BackendHealthJobName)
+
+	checks := make(map[config.BackendRef]func(context.Context) error, len(bhj.backends)+1)
+	if bhj.ldapClient != nil {
+		checks[config.BackendRef{Name: ldapHealthName, Type: ldapHealthName}] = bhj.ldapClient.HealthCheck
+	}
+	for ref, client := range bhj.backends {
+		checks[ref] = client.HealthCheck
+	}
+
+	statuses := make([]clients.HealthStatus, 0, len(checks))
+	var statusesMu sync.Mutex
+	var wg sync.WaitGroup
+	for ref, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, bhj.timeout)
+			defer cancel()
+
+			status := clients.HealthStatus{Name: ref.Name, Type: ref.Type, Healthy: true}
+			if err := check(checkCtx); err != nil {
+				log.WithFields(logrus.Fields{
+					"backend_name": ref.Name,
+					"backend_type": ref.Type,
+				}).WithError(err).Warn("backend health check failed")
+				status.Healthy = false
+				status.Error = err.Error()
+			}
+			status.CheckedAt = time.Now()
+
+			statusesMu.Lock()
+			statuses = append(statuses, status)
+			statusesMu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(statuses, func(a, b clients.HealthStatus) int {
+		if c := strings.Compare(a.Type, b.Type); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, status := range statuses {
+		healthy := 0.0
+		if status.Healthy {
+			healthy = 1
+		}
+		backendHealthy.WithLabelValues(status.Name, status.Type).Set(healthy)
+	}
+
+	bhj.mu.Lock()
+	bhj.statuses = statuses
+	bhj.checkedAt = time.Now()
+	bhj.mu.Unlock()
+	return statuses
+}
+
+// HealthStatuses returns the results of the last health checks, nil before the first check
+func (bhj *BackendHealthJob) HealthStatuses() []clients.HealthStatus {
+	bhj.mu.RLock()
+	defer bhj.mu.RUnlock()
+	return slices.Clone(bhj.statuses)
+}
+
+// ReadyzCheck is a readiness checker failing until the backends are checked, and while one of them
+// is unhealthy. The replicas which don't run the periodic tasks refresh the stale results in the
+// background, so that the probe itself never waits for the backends.
+func (bhj *BackendHealthJob) ReadyzCheck(_ *http.Request) error {
+	bhj.mu.RLock()
+	statuses, checkedAt := bhj.statuses, bhj.checkedAt
+	bhj.mu.RUnlock()
+
+	if time.Since(checkedAt) > bhj.interval && bhj.refreshing.CompareAndSwap(false, true) {
+		go func() {
+			defer bhj.refreshing.Store(false)
+			bhj.Check(context.Background())
+		}()
+	}
+
+	if checkedAt.IsZero() {
+		return errors.New("backends not checked yet")
+	}
+	var unhealthy []string
+	for _, status := range statuses {
+		if !status.Healthy {
+			unhealthy = append(unhealthy, status.Type+"/"+status.Name)
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("unhealthy backends: %s", strings.Join(unhealthy, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package periodicjobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ldapmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/mocks"
+	clientmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs/mocks"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+func TestBackendHealthJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLDAPClient := ldapmocks.NewMockLDAPClient(ctrl)
+	mockFivetranClient := clientmocks.NewMockClient(ctrl)
+	mockGitlabClient := clientmocks.NewMockClient(ctrl)
+
+	job := NewBackendHealthJob(mockLDAPClient, map[config.BackendRef]clients.Client{
+		{Name: "fivetran", Type: "fivetran"}: mockFivetranClient,
+		{Name: "gitlab", Type: "gitlab"}:     mockGitlabClient,
+	}, time.Hour, 0)
+	ctx := context.Background()
+
+	t.Run("Not_Ready_Before_The_First_Check", func(t *testing.T) {
+		job.refreshing.Store(true)
+		defer job.refreshing.Store(false)
+
+		assert.Nil(t, job.HealthStatuses())
+		assert.ErrorContains(t, job.ReadyzCheck(nil), "not checked yet")
+	})
+
+	t.Run("All_Backends_Healthy", func(t *testing.T) {
+		mockLDAPClient.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+		mockFivetranClient.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+		mockGitlabClient.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+
+		require.NoError(t, job.Run(ctx))
+		assert.NoError(t, job.ReadyzCheck(nil))
+
+		statuses := job.HealthStatuses()
+		require.Len(t, statuses, 3)
+		for i, name := range []string{"fivetran", "gitlab", "ldap"} {
+			assert.Equal(t, name, statuses[i].Name)
+			assert.True(t, statuses[i].Healthy)
+			assert.False(t, statuses[i].CheckedAt.IsZero())
+		}
+	})
+
+	t.Run("Unhealthy_Backend", func(t *testing.T) {
+		mockLDAPClient.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+		mockFivetranClient.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+		mockGitlabClient.EXPECT().HealthCheck(gomock.Any()).Return(errors.New("401 Unauthorized"))
+
+		err := job.Run(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "gitlab backend gitlab is unhealthy: 401 Unauthorized")
+
+		err = job.ReadyzCheck(nil)
+		require.Error(t, err)
+		assert.Equal(t, "unhealthy backends: gitlab/gitlab", err.Error())
+
+		statuses := job.HealthStatuses()
+		require.Len(t, statuses, 3)
+		assert.False(t, statuses[1].Healthy)
+		assert.Equal(t, "401 Unauthorized", statuses[1].Error)
+	})
+}
+
+func TestBackendHealthJobReadyzRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBackendClient := clientmocks.NewMockClient(ctrl)
+	job := NewBackendHealthJob(nil, map[config.BackendRef]clients.Client{
+		{Name: "fivetran", Type: "fivetran"}: mockBackendClient,
+	}, time.Minute, time.Second)
+
+	assert.Equal(t, time.Minute, job.GetInterval())
+	assert.Equal(t, BackendHealthJobName, job.GetName())
+
+	// The stale results are refreshed in the background without failing the probe on the backend
+	mockBackendClient.EXPECT().HealthCheck(gomock.Any()).Return(nil).Times(1)
+	assert.Error(t, job.ReadyzCheck(nil))
+	require.Eventually(t, func() bool {
+		return job.ReadyzCheck(nil) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
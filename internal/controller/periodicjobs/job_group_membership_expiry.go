@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file implements the group membership expiry periodic job that requeues groups
+// whose temporary memberships expire soon, so expired members are removed on time.
+package periodicjobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// GroupMembershipExpiryJobName is the unique identifier for the group membership expiry periodic job.
+	GroupMembershipExpiryJobName = "usernaut_group_membership_expiry"
+
+	// DefaultGroupMembershipExpiryJobInterval is the default interval if not configured.
+	DefaultGroupMembershipExpiryJobInterval = time.Hour
+)
+
+// GroupMembershipExpiryJob implements a periodic job that force reconciles groups which have
+// expired members still reconciled, or memberships expiring before the next run of the job.
+type GroupMembershipExpiryJob struct {
+	// k8sClient is used to list the Group CRs and label them for reconciliation
+	k8sClient client.Client
+
+	logger *logrus.Entry
+}
+
+// NewGroupMembershipExpiryJob creates a new GroupMembershipExpiryJob instance.
+func NewGroupMembershipExpiryJob(k8sClient client.Client) *GroupMembershipExpiryJob {
+	return &GroupMembershipExpiryJob{
+		k8sClient: k8sClient,
+	}
+}
+
+// AddToPeriodicTaskManager registers this job with the provided periodic task manager.
+func (gej *GroupMembershipExpiryJob) AddToPeriodicTaskManager(mgr *PeriodicTaskManager) {
+	mgr.AddTask(gej)
+}
+
+// GetInterval returns the execution interval for this periodic job, which is also
+// the look-ahead window for memberships expiring soon.
+func (gej *GroupMembershipExpiryJob) GetInterval() time.Duration {
+	logger := logger.Logger(context.TODO()).WithFields(logrus.Fields{
+		"job": GroupMembershipExpiryJobName,
+	})
+	appConf, err := config.GetConfig()
+	if err != nil {
+		logger.WithError(err).Warn("Failed to load configuration, falling back to default group membership expiry interval.")
+		return DefaultGroupMembershipExpiryJobInterval
+	}
+	if appConf.GroupMembershipExpiryInterval == "" {
+		return DefaultGroupMembershipExpiryJobInterval
+	}
+	parsedInterval, parseErr := time.ParseDuration(appConf.GroupMembershipExpiryInterval)
+	if parseErr != nil {
+		logger.
+			WithField("value", appConf.GroupMembershipExpiryInterval).
+			WithError(parseErr).
+			Warn("Invalid format for group membership expiry interval, falling back to default")
+		return DefaultGroupMembershipExpiryJobInterval
+	}
+	return parsedInterval
+}
+
+// GetName returns the unique name identifier for this periodic job.
+func (gej *GroupMembershipExpiryJob) GetName() string {
+	return GroupMembershipExpiryJobName
+}
+
+// Run adds the force reconcile label to every group with memberships to expire
+func (gej *GroupMembershipExpiryJob) Run(ctx context.Context) error {
+	ctx = logger.WithRequestId(ctx, types.UID(uuid.New().String()))
+	gej.logger = logger.Logger(ctx).WithFields(logrus.Fields{
+		"job": GroupMembershipExpiryJobName,
+	})
+	gej.logger.Info("Starting group membership expiry job")
+
+	groups := &usernautdevv1alpha1.GroupList{}
+	if err := gej.k8sClient.List(ctx, groups); err != nil {
+		gej.logger.WithError(err).Error("Failed to list groups")
+		return err
+	}
+
+	now := time.Now()
+	window := gej.GetInterval()
+	failed := 0
+	requeued := 0
+	for i := range groups.Items {
+		group := &groups.Items[i]
+		if !groupHasExpiringMembers(group, now, window) {
+			continue
+		}
+
+		labels := group.GetLabels()
+		if _, ok := labels[constants.ForceReconcileLabel]; ok {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string, 1)
+		}
+		labels[constants.ForceReconcileLabel] = "true"
+		group.SetLabels(labels)
+
+		if err := gej.k8sClient.Update(ctx, group); err != nil {
+			gej.logger.WithError(err).WithField("group", group.Name).Error("Failed to requeue group with expiring members")
+			failed++
+			continue
+		}
+		gej.logger.WithField("group", group.Name).Info("Requeued group with expiring members")
+		requeued++
+	}
+
+	gej.logger.WithFields(logrus.Fields{
+		"total_groups":    len(groups.Items),
+		"requeued_groups": requeued,
+		"failed_groups":   failed,
+	}).Info("Group membership expiry job completed")
+
+	if failed > 0 {
+		return fmt.Errorf("failed to requeue %d groups with expiring members", failed)
+	}
+	return nil
+}
+
+// groupHasExpiringMembers reports whether an expired member is still reconciled on the group,
+// or a membership expires within the window
+func groupHasExpiringMembers(group *usernautdevv1alpha1.Group, now time.Time, window time.Duration) bool {
+	if group.GetDeletionTimestamp() != nil {
+		return false
+	}
+	for user := range group.Spec.Members.ExpiredUsers(now) {
+		if slices.Contains(group.Status.ReconciledUsers, user) {
+			return true
+		}
+	}
+	next := group.Spec.Members.NextExpiration(now)
+	return next != nil && next.Sub(now) <= window
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package periodicjobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+)
+
+func TestGroupHasExpiringMembers(t *testing.T) {
+	now := time.Now()
+	window := time.Hour
+
+	newGroup := func(expiresAt time.Time, reconciledUsers ...string) *usernautdevv1alpha1.Group {
+		return &usernautdevv1alpha1.Group{
+			Spec: usernautdevv1alpha1.GroupSpec{
+				Members: usernautdevv1alpha1.Members{
+					Users: []string{"alice", "contractor"},
+					Expirations: []usernautdevv1alpha1.MemberExpiration{
+						{User: "contractor", ExpiresAt: metav1.NewTime(expiresAt)},
+					},
+				},
+			},
+			Status: usernautdevv1alpha1.GroupStatus{ReconciledUsers: reconciledUsers},
+		}
+	}
+
+	t.Run("membership expiring within the window", func(t *testing.T) {
+		group := newGroup(now.Add(30*time.Minute), "alice", "contractor")
+		assert.True(t, groupHasExpiringMembers(group, now, window))
+	})
+
+	t.Run("membership expiring after the window", func(t *testing.T) {
+		group := newGroup(now.Add(48*time.Hour), "alice", "contractor")
+		assert.False(t, groupHasExpiringMembers(group, now, window))
+	})
+
+	t.Run("expired member still reconciled", func(t *testing.T) {
+		group := newGroup(now.Add(-time.Minute), "alice", "contractor")
+		assert.True(t, groupHasExpiringMembers(group, now, window))
+	})
+
+	t.Run("expired member already removed", func(t *testing.T) {
+		group := newGroup(now.Add(-time.Minute), "alice")
+		assert.False(t, groupHasExpiringMembers(group, now, window))
+	})
+
+	t.Run("group being deleted", func(t *testing.T) {
+		group := newGroup(now.Add(30*time.Minute), "alice", "contractor")
+		deletedAt := metav1.NewTime(now)
+		group.DeletionTimestamp = &deletedAt
+		assert.False(t, groupHasExpiringMembers(group, now, window))
+	})
+}
@@ -22,12 +22,17 @@ package periodicjobs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
@@ -35,6 +40,7 @@ import (
 	"github.com/redhat-data-and-ai/usernaut/pkg/store"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -46,6 +52,10 @@ const (
 	DefaultUserOffboardingJobInterval = 24 * time.Hour
 )
 
+// errUserProtected is returned when a user about to be deleted is protected by the exclusion list
+// or an OffboardingPolicy
+var errUserProtected = errors.New("user is protected from offboarding")
+
 // UserOffboardingJob implements a periodic job that monitors user activity and automatically
 // offboards inactive users from all configured backends.
 //
@@ -59,6 +69,9 @@ const (
 // or become inactive in the LDAP directory.
 type UserOffboardingJob struct {
 
+	// k8sClient is used to list the OffboardingPolicy resources, policies are ignored when nil
+	k8sClient client.Client
+
 	// store provides access to the store layer with prefixed keys
 	store *store.Store
 
@@ -73,12 +86,22 @@ type UserOffboardingJob struct {
 	// This shared mutex ensures that the GroupReconciler and UserOffboardingJob don't interfere
 	// with each other when reading or modifying user data in Redis.
 	// This mutex is shared across components and passed from main.go.
-	cacheMutex *sync.RWMutex
+	cacheMutex cache.Locker
+
+	// runMutex serializes the periodic runs and the targeted offboardings of the LDAP watch, which
+	// share the exclusion list, the policies and the logger of the job
+	runMutex sync.Mutex
 
 	// exclusionList contains email addresses that should be excluded from offboarding
 	// Using a map for O(1) lookup performance instead of O(n) slice iteration
 	exclusionList map[string]bool
 
+	// protectedUsers contains the email addresses listed by the OffboardingPolicy resources
+	protectedUsers map[string]bool
+
+	// protectedPatterns contains the email glob patterns listed by the OffboardingPolicy resources
+	protectedPatterns []string
+
 	logger *logrus.Entry
 }
 
@@ -92,6 +115,7 @@ type UserOffboardingJob struct {
 //   - Returns a fully configured job ready for execution
 //
 // Parameters:
+//   - k8sClient: Client used to list the OffboardingPolicy resources
 //   - sharedCacheMutex: Shared mutex to prevent race conditions with other components
 //   - dataStore: Shared store instance with prefixed keys
 //   - ldapClient: Shared LDAP client instance
@@ -100,17 +124,20 @@ type UserOffboardingJob struct {
 // Returns:
 //   - *UserOffboardingJob: A configured job instance
 func NewUserOffboardingJob(
-	sharedCacheMutex *sync.RWMutex,
+	k8sClient client.Client,
+	sharedCacheMutex cache.Locker,
 	dataStore *store.Store,
 	ldapClient ldap.LDAPClient,
 	backendClients map[string]clients.Client,
 ) *UserOffboardingJob {
 	return &UserOffboardingJob{
+		k8sClient:      k8sClient,
 		store:          dataStore,
 		ldapClient:     ldapClient,
 		backendClients: backendClients,
 		cacheMutex:     sharedCacheMutex,
 		exclusionList:  make(map[string]bool),
+		protectedUsers: make(map[string]bool),
 	}
 }
 
@@ -170,6 +197,61 @@ func (uoj *UserOffboardingJob) loadExclusionList(ctx context.Context) {
 	}).Info("Loaded offboard user exclusion list")
 }
 
+//nolint:lll
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=offboardingpolicies,verbs=get;list;watch
+
+// loadOffboardingPolicies loads the users and email patterns protected by the OffboardingPolicy resources.
+//
+// Unlike the exclusion list, a failure to list the policies is returned: the job must not
+// offboard anyone while the protected users are unknown. Invalid patterns are skipped.
+func (uoj *UserOffboardingJob) loadOffboardingPolicies(ctx context.Context) error {
+	if uoj.k8sClient == nil {
+		return nil
+	}
+
+	policies := &usernautdevv1alpha1.OffboardingPolicyList{}
+	if err := uoj.k8sClient.List(ctx, policies); err != nil {
+		return fmt.Errorf("failed to list offboarding policies: %w", err)
+	}
+	uoj.setOffboardingPolicies(ctx, policies.Items)
+
+	uoj.logger.WithFields(logrus.Fields{
+		"policyCount":       len(policies.Items),
+		"protectedUsers":    len(uoj.protectedUsers),
+		"protectedPatterns": uoj.protectedPatterns,
+	}).Info("Loaded offboarding policies")
+	return nil
+}
+
+// setOffboardingPolicies replaces the users and email patterns protected by the policies
+func (uoj *UserOffboardingJob) setOffboardingPolicies(
+	ctx context.Context, policies []usernautdevv1alpha1.OffboardingPolicy,
+) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"job": UserOffboardingJobName,
+	})
+	uoj.protectedUsers = make(map[string]bool)
+	uoj.protectedPatterns = nil
+	for _, policy := range policies {
+		for _, email := range policy.Spec.Users {
+			if normalizedEmail := strings.ToLower(strings.TrimSpace(email)); normalizedEmail != "" {
+				uoj.protectedUsers[normalizedEmail] = true
+			}
+		}
+		for _, pattern := range policy.Spec.EmailPatterns {
+			normalizedPattern := strings.ToLower(strings.TrimSpace(pattern))
+			if _, err := path.Match(normalizedPattern, ""); err != nil || normalizedPattern == "" {
+				log.WithFields(logrus.Fields{
+					"policy":  policy.Name,
+					"pattern": pattern,
+				}).Warn("Skipping invalid email pattern of offboarding policy")
+				continue
+			}
+			uoj.protectedPatterns = append(uoj.protectedPatterns, normalizedPattern)
+		}
+	}
+}
+
 // AddToPeriodicTaskManager registers this job with the provided periodic task manager.
 //
 // This method integrates the user offboarding job into the controller's periodic
@@ -241,6 +323,9 @@ func (uoj *UserOffboardingJob) GetName() string {
 //   - error: Any fatal error that occurred during execution, or a summary
 //     of non-fatal errors if any users failed to process
 func (uoj *UserOffboardingJob) Run(ctx context.Context) error {
+	uoj.runMutex.Lock()
+	defer uoj.runMutex.Unlock()
+
 	ctx = logger.WithRequestId(ctx, types.UID(uuid.New().String()))
 	uoj.logger = logger.Logger(ctx).WithFields(logrus.Fields{
 		"job": UserOffboardingJobName,
@@ -250,6 +335,11 @@ func (uoj *UserOffboardingJob) Run(ctx context.Context) error {
 	// Reload exclusion list on every run to pick up any changes (especially from HTTP URLs)
 	uoj.loadExclusionList(ctx)
 
+	if err := uoj.loadOffboardingPolicies(ctx); err != nil {
+		uoj.logger.WithError(err).Error("Failed to load offboarding policies, skipping user offboarding")
+		return err
+	}
+
 	userKeys, err := uoj.getUserListFromCache(ctx)
 	if err != nil {
 		uoj.logger.Error(err, "Failed to get user keys from cache")
@@ -270,6 +360,52 @@ func (uoj *UserOffboardingJob) Run(ctx context.Context) error {
 	return nil
 }
 
+// OffboardUsers runs the offboarding of the users with the emails, for the users reported deleted
+// or inactive by the LDAP watch without waiting for the next run. Each user is checked in LDAP
+// again before being offboarded, like on a periodic run, and the users missing from the cache have
+// nothing to offboard and are skipped.
+//
+// Parameters:
+//   - ctx: Context for cancellation and logging
+//   - userEmails: Emails of the users to offboard
+//
+// Returns:
+//   - error: A summary of the errors if any users failed to process
+func (uoj *UserOffboardingJob) OffboardUsers(ctx context.Context, userEmails []string) error {
+	uoj.runMutex.Lock()
+	defer uoj.runMutex.Unlock()
+
+	ctx = logger.WithRequestId(ctx, types.UID(uuid.New().String()))
+	uoj.logger = logger.Logger(ctx).WithFields(logrus.Fields{
+		"job": UserOffboardingJobName,
+	})
+	uoj.logger.WithField("userEmails", userEmails).Info("Starting targeted user offboarding")
+
+	uoj.loadExclusionList(ctx)
+	if err := uoj.loadOffboardingPolicies(ctx); err != nil {
+		uoj.logger.WithError(err).Error("Failed to load offboarding policies, skipping user offboarding")
+		return err
+	}
+
+	userKeys := make([]string, 0, len(userEmails))
+	uoj.cacheMutex.RLock()
+	for _, userEmail := range userEmails {
+		exists, err := uoj.store.User.Exists(ctx, userEmail)
+		if err == nil && exists {
+			userKeys = append(userKeys, userEmail)
+		}
+	}
+	uoj.cacheMutex.RUnlock()
+
+	result := uoj.processUsers(ctx, userKeys)
+	uoj.logJobSummary(result, len(userKeys))
+
+	if len(result.errors) > 0 {
+		return fmt.Errorf("targeted user offboarding completed with %d errors: %v", len(result.errors), result.errors)
+	}
+	return nil
+}
+
 // processingResult holds the results of processing multiple users during a job execution.
 type processingResult struct {
 	// offboardedCount tracks the number of users successfully offboarded
@@ -298,7 +434,16 @@ type processingResult struct {
 func (uoj *UserOffboardingJob) processUsers(ctx context.Context, userKeys []string) processingResult {
 	var result processingResult
 
-	for _, userKey := range userKeys {
+	for i, userKey := range userKeys {
+		// Users are offboarded one at a time from the backends and the cache, stop between two
+		// users when the operator is stopping so no user is left half offboarded
+		if controllerutils.ShuttingDown(ctx) {
+			uoj.logger.WithField("remaining", len(userKeys)-i).
+				Info("Shutting down, leaving the remaining users to the next run")
+			result.errors = append(result.errors, controllerutils.ErrShuttingDown.Error())
+			break
+		}
+
 		normalizedKey := strings.ToLower(strings.TrimSpace(userKey))
 		if normalizedKey == "" {
 			uoj.logger.WithField("userKey", userKey).Info("Skipping user: userKey is empty")
@@ -322,16 +467,25 @@ func (uoj *UserOffboardingJob) processUsers(ctx context.Context, userKeys []stri
 	return result
 }
 
-// isInExclusionList checks if a normalized email address is in the exclusion list.
+// isInExclusionList checks if a normalized email address is in the exclusion list,
+// or protected by an OffboardingPolicy.
 // Uses map lookup for O(1) performance instead of O(n) slice iteration.
 //
 // Parameters:
 //   - normalizedKey: The normalized (lowercase, trimmed) email address to check
 //
 // Returns:
-//   - bool: true if the email is in the exclusion list, false otherwise
+//   - bool: true if the email is excluded from offboarding, false otherwise
 func (uoj *UserOffboardingJob) isInExclusionList(normalizedKey string) bool {
-	return uoj.exclusionList[normalizedKey]
+	if uoj.exclusionList[normalizedKey] || uoj.protectedUsers[normalizedKey] {
+		return true
+	}
+	for _, pattern := range uoj.protectedPatterns {
+		if matched, _ := path.Match(pattern, normalizedKey); matched {
+			return true
+		}
+	}
+	return false
 }
 
 // processUser handles the complete processing workflow for a single user.
@@ -357,8 +511,22 @@ func (uoj *UserOffboardingJob) processUser(ctx context.Context, userKey string)
 	}
 
 	if !isActive {
+		// A user whose email changed in LDAP is no longer found by its cached email, its cache
+		// entries are migrated to the new email instead of offboarding the user
+		renamed, err := uoj.migrateRenamedUser(ctx, userKey)
+		if err != nil {
+			uoj.logger.Error(err, "Failed to check whether the user was renamed in LDAP", "userKey", userKey)
+			return false, fmt.Errorf("failed to check whether user %s was renamed: %v", userKey, err)
+		}
+		if renamed {
+			return false, nil
+		}
+
 		uoj.logger.WithField("userKey", userKey).Info("User is inactive in LDAP, starting offboarding")
 		err = uoj.offboardUser(ctx, userKey)
+		if errors.Is(err, errUserProtected) {
+			return false, nil
+		}
 		if err != nil {
 			uoj.logger.WithField("userKey", userKey).Error(err, "Failed to offboard user")
 			return false, fmt.Errorf("failed to offboard user %s: %v", userKey, err)
@@ -369,6 +537,41 @@ func (uoj *UserOffboardingJob) processUser(ctx context.Context, userKey string)
 	return false, nil
 }
 
+// migrateRenamedUser resolves the uid of a user not found in LDAP by its email through the uid
+// index, and migrates the cache entries of the user to the email LDAP holds for the uid. The
+// migrated user is checked again under its new email by the next run.
+//
+// Returns:
+//   - bool: true if the user was renamed in LDAP and its cache entries were migrated
+//   - error: Any error encountered looking up the uid in the cache or in LDAP
+func (uoj *UserOffboardingJob) migrateRenamedUser(ctx context.Context, userEmail string) (bool, error) {
+	uoj.cacheMutex.Lock()
+	defer uoj.cacheMutex.Unlock()
+
+	uid, err := uoj.store.UserUID.GetUID(ctx, userEmail)
+	if err != nil || uid == "" {
+		return false, err
+	}
+	userData, err := uoj.ldapClient.GetUserLDAPData(ctx, uid)
+	if errors.Is(err, ldap.ErrNoUserFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	email, _ := userData["mail"].(string)
+	if email == "" || strings.EqualFold(email, userEmail) {
+		return false, nil
+	}
+
+	uoj.logger.WithFields(logrus.Fields{
+		"userKey": userEmail,
+		"uid":     uid,
+		"email":   email,
+	}).Info("User email changed in LDAP, migrating the cache entries instead of offboarding")
+	return controllerutils.MigrateRenamedUser(ctx, uoj.store, uid, email)
+}
+
 // offboardUser performs the complete offboarding process for an inactive user.
 //
 // This method:
@@ -389,6 +592,14 @@ func (uoj *UserOffboardingJob) offboardUser(ctx context.Context, userKey string)
 	if err != nil {
 		return fmt.Errorf("failed to get user data from cache: %w", err)
 	}
+	// The cache entry is matched by pattern, so the email found is checked again before deleting anything
+	if uoj.isInExclusionList(strings.ToLower(strings.TrimSpace(userEmail))) {
+		uoj.logger.WithFields(logrus.Fields{
+			"userKey":   userKey,
+			"userEmail": userEmail,
+		}).Info("Excluding user from offboarding")
+		return errUserProtected
+	}
 	err = uoj.offboardUserFromAllBackends(ctx, userKey, userData)
 	if err != nil {
 		uoj.logger.WithField("userKey", userKey).Error(err, "Failed to offboard user from backends")
@@ -509,6 +720,7 @@ func (uoj *UserOffboardingJob) getUserDataFromCache(
 //
 // This method queries the LDAP directory for the specified user ID. If the user
 // is found, they are considered active. If the user is not found (ErrNoUserFound),
+// or its entry is inactive according to the LDAP userStatus config (ErrUserInactive),
 // they are considered inactive and should be offboarded.
 //
 // Parameters:
@@ -517,13 +729,13 @@ func (uoj *UserOffboardingJob) getUserDataFromCache(
 //
 // Returns:
 //   - bool: true if user is active in LDAP, false if inactive
-//   - error: Any LDAP query error (excluding ErrNoUserFound which indicates inactivity)
+//   - error: Any LDAP query error (excluding ErrNoUserFound and ErrUserInactive which indicate inactivity)
 func (uoj *UserOffboardingJob) isUserActiveInLDAP(ctx context.Context, userEmail string) (bool, error) {
 	userData, err := uoj.ldapClient.GetUserLDAPDataByEmail(ctx, userEmail)
 	if err != nil {
-		if err == ldap.ErrNoUserFound {
-			// User not found in LDAP means they're inactive
-			uoj.logger.WithError(err).WithField("userEmail", userEmail).Debug("User not found in LDAP, treating as inactive")
+		if err == ldap.ErrNoUserFound || err == ldap.ErrUserInactive {
+			// User not found in LDAP, or whose entry is disabled, means they're inactive
+			uoj.logger.WithError(err).WithField("userEmail", userEmail).Debug("User not active in LDAP, treating as inactive")
 			return false, nil
 		}
 		return false, err
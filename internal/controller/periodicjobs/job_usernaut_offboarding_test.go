@@ -14,7 +14,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
 	ldapmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/mocks"
 	clientmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs/mocks"
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
@@ -123,6 +126,7 @@ func TestUserOffboardingJob(t *testing.T) {
 	// Create the job
 	sharedCacheMutex := &sync.RWMutex{}
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -180,6 +184,26 @@ func TestUserOffboardingJob(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, exists, "User should remain in cache")
 	})
+
+	t.Run("User_Inactive_In_LDAP_Should_Be_Offboarded", func(t *testing.T) {
+		// Setup: LDAP keeps the entry of the user, but its status is inactive
+		mockLDAPClient.EXPECT().
+			GetUserLDAPDataByEmail(gomock.Any(), testUser.Email).
+			Return(nil, ldap.ErrUserInactive).
+			Times(1)
+
+		mockBackendClient.EXPECT().
+			DeleteUser(gomock.Any(), testUser.ID).
+			Return(nil).
+			Times(1)
+
+		err := job.Run(ctx)
+		assert.NoError(t, err)
+
+		exists, err := dataStore.User.Exists(ctx, testUser.Email)
+		require.NoError(t, err)
+		assert.False(t, exists, "User should be removed from cache")
+	})
 }
 
 // TestUserOffboardingJobBackendErrors tests error handling
@@ -219,6 +243,7 @@ func TestUserOffboardingJobBackendErrors(t *testing.T) {
 
 	sharedCacheMutex := &sync.RWMutex{}
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -272,6 +297,7 @@ func TestUserOffboardingJobEmptyUserList(t *testing.T) {
 
 	sharedCacheMutex := &sync.RWMutex{}
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -324,6 +350,7 @@ func TestUserOffboardingJobMultipleBackends(t *testing.T) {
 
 	sharedCacheMutex := &sync.RWMutex{}
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -379,6 +406,7 @@ func TestUserOffboardingJobInterval(t *testing.T) {
 	sharedCacheMutex := &sync.RWMutex{}
 
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -456,6 +484,7 @@ func TestUserOffboardingJobEmptyUserKey(t *testing.T) {
 
 	sharedCacheMutex := &sync.RWMutex{}
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -487,6 +516,41 @@ func TestUserOffboardingJobEmptyUserKey(t *testing.T) {
 	})
 }
 
+// TestUserOffboardingJobShutdown tests that a job run stops between two users on shutdown
+func TestUserOffboardingJobShutdown(t *testing.T) {
+	defer setupTestConfig(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLDAPClient := ldapmocks.NewMockLDAPClient(ctrl)
+	mockBackendClient := clientmocks.NewMockClient(ctrl)
+
+	inMemCache, err := inmemory.NewCache(&inmemory.Config{DefaultExpiration: 60, CleanupInterval: 120})
+	require.NoError(t, err)
+	dataStore := store.New(inMemCache)
+
+	err = dataStore.User.SetBackend(context.Background(), "testuser@example.com", "fivetran_fivetran", "test_user_123")
+	require.NoError(t, err)
+
+	job := NewUserOffboardingJob(nil, &sync.RWMutex{}, dataStore, mockLDAPClient,
+		map[string]clients.Client{"fivetran_fivetran": mockBackendClient})
+
+	// The operator is stopping, no LDAP lookup nor backend call is expected
+	parent, stop := context.WithCancel(context.Background())
+	ctx, cancel := controllerutils.DrainContext(parent, time.Minute)
+	defer cancel()
+	stop()
+
+	err = job.Run(ctx)
+	assert.ErrorContains(t, err, controllerutils.ErrShuttingDown.Error())
+	assert.NoError(t, ctx.Err(), "the run should not be canceled during the grace period")
+
+	exists, err := dataStore.User.Exists(context.Background(), "testuser@example.com")
+	require.NoError(t, err)
+	assert.True(t, exists, "The user should be left for the next run")
+}
+
 // TestUserOffboardingJobExclusionList tests handling of users in exclusion list
 func TestUserOffboardingJobExclusionList(t *testing.T) {
 	defer setupTestConfig(t)()
@@ -543,6 +607,7 @@ func TestUserOffboardingJobExclusionList(t *testing.T) {
 	sharedCacheMutex := &sync.RWMutex{}
 	// Create job after exclusion list file is created so it loads the exclusion list
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -662,6 +727,7 @@ offboardUserExclusionListConfigPath: "` + server.URL + `/config/exclusion_list.y
 	sharedCacheMutex := &sync.RWMutex{}
 	// Create job after config is updated so it loads the exclusion list from URL
 	job := NewUserOffboardingJob(
+		nil,
 		sharedCacheMutex,
 		dataStore,
 		mockLDAPClient,
@@ -697,3 +763,77 @@ offboardUserExclusionListConfigPath: "` + server.URL + `/config/exclusion_list.y
 		assert.False(t, exists, "Normal user should be removed from cache")
 	})
 }
+
+// TestUserOffboardingJobOffboardingPolicy tests that users protected by an OffboardingPolicy are never deleted
+func TestUserOffboardingJobOffboardingPolicy(t *testing.T) {
+	defer setupTestConfig(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLDAPClient := ldapmocks.NewMockLDAPClient(ctrl)
+	mockBackendClient := clientmocks.NewMockClient(ctrl)
+
+	cacheConfig := &inmemory.Config{
+		DefaultExpiration: 60,
+		CleanupInterval:   120,
+	}
+	inMemCache, err := inmemory.NewCache(cacheConfig)
+	require.NoError(t, err)
+
+	dataStore := store.New(inMemCache)
+	ctx := context.Background()
+
+	users := map[string]string{
+		"breakglass@example.com": "breakglass_123",
+		"svc-etl@example.com":    "svc_etl_456",
+		"normal@example.com":     "normal_789",
+	}
+	for email, id := range users {
+		require.NoError(t, dataStore.User.SetBackend(ctx, email, "fivetran_fivetran", id))
+	}
+
+	job := NewUserOffboardingJob(
+		nil,
+		&sync.RWMutex{},
+		dataStore,
+		mockLDAPClient,
+		map[string]clients.Client{"fivetran_fivetran": mockBackendClient},
+	)
+	job.setOffboardingPolicies(ctx, []usernautdevv1alpha1.OffboardingPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "break-glass"},
+			Spec: usernautdevv1alpha1.OffboardingPolicySpec{
+				Users:         []string{" BreakGlass@example.com "},
+				EmailPatterns: []string{"svc-*@example.com", "["},
+			},
+		},
+	})
+
+	t.Run("Invalid_Patterns_Are_Skipped", func(t *testing.T) {
+		assert.Equal(t, []string{"svc-*@example.com"}, job.protectedPatterns)
+	})
+
+	t.Run("Protected_Users_Should_Be_Skipped", func(t *testing.T) {
+		// Only the unprotected user is checked in LDAP and deleted from the backend
+		mockLDAPClient.EXPECT().
+			GetUserLDAPDataByEmail(gomock.Any(), "normal@example.com").
+			Return(nil, ldap.ErrNoUserFound).
+			Times(1)
+		mockBackendClient.EXPECT().
+			DeleteUser(gomock.Any(), "normal_789").
+			Return(nil).
+			Times(1)
+
+		assert.NoError(t, job.Run(ctx))
+
+		for _, email := range []string{"breakglass@example.com", "svc-etl@example.com"} {
+			exists, err := dataStore.User.Exists(ctx, email)
+			require.NoError(t, err)
+			assert.True(t, exists, "Protected user %s should remain in cache", email)
+		}
+		exists, err := dataStore.User.Exists(ctx, "normal@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists, "Normal user should be removed from cache")
+	})
+}
@@ -183,6 +183,20 @@ func (mr *MockClientMockRecorder) FetchUserDetails(ctx, userID interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserDetails", reflect.TypeOf((*MockClient)(nil).FetchUserDetails), ctx, userID)
 }
 
+// HealthCheck mocks base method.
+func (m *MockClient) HealthCheck(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockClientMockRecorder) HealthCheck(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockClient)(nil).HealthCheck), ctx)
+}
+
 // ReconcileGroupParams mocks base method.
 func (m *MockClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
 	m.ctrl.T.Helper()
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
 )
 
 const (
@@ -16,25 +18,37 @@ const (
 // it launches a new goroutine for each task
 // and stops when the context is canceled
 // it uses a ticker to run the tasks at the specified interval (from the task)
-// and stops when the context is canceled
+// and stops when the context is canceled, use Wait to wait for the running tasks
 // If interval == -1, the task is run only once.
 func (p *PeriodicTaskManager) RunAll(ctx context.Context) error {
 	logger := log.FromContext(ctx)
 	logger.Info("Running periodic tasks", "time", time.Now())
 
 	for _, task := range p.Tasks {
-		go p.runTask(ctx, task)
+		p.running.Add(1)
+		go func() {
+			defer p.running.Done()
+			p.runTask(ctx, task)
+		}()
 	}
 	return nil
 }
 
-func (*PeriodicTaskManager) runTask(ctx context.Context, task PeriodicTask) {
+// Wait blocks until all the tasks started by RunAll have stopped, a task running when the
+// context of RunAll is canceled first gets ShutdownGracePeriod to reach a checkpoint
+func (p *PeriodicTaskManager) Wait() {
+	p.running.Wait()
+}
+
+func (p *PeriodicTaskManager) runTask(ctx context.Context, task PeriodicTask) {
 	logger := log.FromContext(ctx)
 	interval := task.GetInterval()
 
 	run := func() {
 		logger.Info("Running periodic task", "name", task.GetName(), "interval", interval)
-		if err := task.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		runCtx, cancel := controllerutils.DrainContext(ctx, p.ShutdownGracePeriod)
+		defer cancel()
+		if err := task.Run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
 			logger.Error(err, "error running periodic task", "periodic-task-name", task.GetName())
 		}
 	}
@@ -54,6 +68,11 @@ func (*PeriodicTaskManager) runTask(ctx context.Context, task PeriodicTask) {
 			logger.Info("Stopping periodic task", "name", task.GetName())
 			return
 		case <-ticker.C:
+			// a tick may be ready at the same time as the shutdown, don't start a new run then
+			if ctx.Err() != nil {
+				logger.Info("Stopping periodic task", "name", task.GetName())
+				return
+			}
 			run()
 		}
 	}
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
 	"github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -30,6 +31,22 @@ func (m *MockPeriodicTask) Run(_ context.Context) error {
 	return nil
 }
 
+// CheckpointPeriodicTask runs until the operator shuts down, recording whether it was canceled
+type CheckpointPeriodicTask struct {
+	MockPeriodicTask
+	started  chan struct{}
+	canceled bool
+}
+
+func (c *CheckpointPeriodicTask) Run(ctx context.Context) error {
+	close(c.started)
+	for !controllerutils.ShuttingDown(ctx) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.canceled = ctx.Err() != nil
+	return nil
+}
+
 var _ = Describe("PeriodicTaskManager", func() {
 	var (
 		manager *periodicjobs.PeriodicTaskManager
@@ -70,6 +87,24 @@ var _ = Describe("PeriodicTaskManager", func() {
 		}
 	})
 
+	It("should drain a running task until its checkpoint on shutdown", func() {
+		logger := zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true))
+		ctx = log.IntoContext(ctx, logger)
+
+		task := &CheckpointPeriodicTask{
+			MockPeriodicTask: MockPeriodicTask{name: "checkpoint", interval: 10 * time.Millisecond},
+			started:          make(chan struct{}),
+		}
+		manager = periodicjobs.NewPeriodicTaskManager()
+		manager.AddTask(task)
+		Expect(manager.RunAll(ctx)).To(Succeed())
+
+		Eventually(task.started).Should(BeClosed())
+		cancel()
+		manager.Wait()
+		Expect(task.canceled).To(BeFalse(), "Task should reach its checkpoint before being canceled")
+	})
+
 	It("should stop running tasks when context is canceled", func() {
 		logger := zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true))
 		ctx = log.IntoContext(ctx, logger)
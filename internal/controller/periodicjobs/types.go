@@ -2,7 +2,10 @@ package periodicjobs
 
 import (
 	"context"
+	"sync"
 	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
 )
 
 type PeriodicTask interface {
@@ -13,13 +16,19 @@ type PeriodicTask interface {
 
 type PeriodicTaskManager struct {
 	Tasks []PeriodicTask
+	// ShutdownGracePeriod is how long a running task may run after the manager is stopped
+	// to reach a checkpoint, 0 cancels it right away
+	ShutdownGracePeriod time.Duration
+
+	running sync.WaitGroup
 }
 
 // NewPeriodicTaskManager creates a new PeriodicTaskManager
 // used manage interval based tasks
 func NewPeriodicTaskManager() *PeriodicTaskManager {
 	return &PeriodicTaskManager{
-		Tasks: []PeriodicTask{},
+		Tasks:               []PeriodicTask{},
+		ShutdownGracePeriod: controllerutils.DefaultShutdownGracePeriod,
 	}
 }
 
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file implements the event-driven offboarding, which offboards the users reported deleted or
+// inactive by the LDAP watch between the runs of the user offboarding job.
+package periodicjobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// UserOffboardingWatch offboards the users as the LDAP watch reports them deleted or inactive. The
+// periodic runs of the UserOffboardingJob still catch the changes the watch missed while it was
+// disconnected.
+type UserOffboardingWatch struct {
+	ldapClient ldap.LDAPClient
+	job        *UserOffboardingJob
+
+	// ShutdownGracePeriod is how long a running offboarding may run after the watch is stopped
+	// to reach a checkpoint, 0 cancels it right away
+	ShutdownGracePeriod time.Duration
+}
+
+// NewUserOffboardingWatch creates the watch offboarding the users with the offboarding job
+func NewUserOffboardingWatch(ldapClient ldap.LDAPClient, job *UserOffboardingJob) *UserOffboardingWatch {
+	return &UserOffboardingWatch{
+		ldapClient:          ldapClient,
+		job:                 job,
+		ShutdownGracePeriod: controllerutils.DefaultShutdownGracePeriod,
+	}
+}
+
+// Run watches the LDAP users until the context is canceled, offboarding each batch of users
+// reported by the watch with the job
+func (w *UserOffboardingWatch) Run(ctx context.Context) error {
+	return w.ldapClient.WatchUsers(ctx, func(ctx context.Context, emails []string) {
+		runCtx, cancel := controllerutils.DrainContext(ctx, w.ShutdownGracePeriod)
+		defer cancel()
+		if err := w.job.OffboardUsers(runCtx, emails); err != nil {
+			logger.Logger(ctx).WithError(err).WithField("userEmails", emails).
+				Error("failed to offboard the users reported by the LDAP watch")
+		}
+	})
+}
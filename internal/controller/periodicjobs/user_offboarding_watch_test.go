@@ -0,0 +1,139 @@
+package periodicjobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ldapmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/mocks"
+	clientmocks "github.com/redhat-data-and-ai/usernaut/internal/controller/periodicjobs/mocks"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+)
+
+// TestUserOffboardingWatch tests the offboarding of the users reported by the LDAP watch
+func TestUserOffboardingWatch(t *testing.T) {
+	defer setupTestConfig(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLDAPClient := ldapmocks.NewMockLDAPClient(ctrl)
+	mockBackendClient := clientmocks.NewMockClient(ctrl)
+
+	inMemCache, err := inmemory.NewCache(&inmemory.Config{
+		DefaultExpiration: 60,
+		CleanupInterval:   120,
+	})
+	require.NoError(t, err)
+	dataStore := store.New(inMemCache)
+
+	ctx := context.Background()
+	require.NoError(t, dataStore.User.SetBackend(ctx, "deleted@example.com", "fivetran_fivetran", "deleted_123"))
+	require.NoError(t, dataStore.User.SetBackend(ctx, "active@example.com", "fivetran_fivetran", "active_456"))
+
+	job := NewUserOffboardingJob(
+		nil,
+		&sync.RWMutex{},
+		dataStore,
+		mockLDAPClient,
+		map[string]clients.Client{"fivetran_fivetran": mockBackendClient},
+	)
+	watch := NewUserOffboardingWatch(mockLDAPClient, job)
+
+	t.Run("Reported_Users_Should_Be_Offboarded", func(t *testing.T) {
+		// The users are checked in LDAP again, the users missing from the cache are skipped
+		mockLDAPClient.EXPECT().
+			WatchUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, handler func(context.Context, []string)) error {
+				handler(ctx, []string{"deleted@example.com", "unknown@example.com"})
+				return nil
+			}).
+			Times(1)
+		mockLDAPClient.EXPECT().
+			GetUserLDAPDataByEmail(gomock.Any(), "deleted@example.com").
+			Return(nil, ldap.ErrNoUserFound).
+			Times(1)
+		mockBackendClient.EXPECT().
+			DeleteUser(gomock.Any(), "deleted_123").
+			Return(nil).
+			Times(1)
+
+		assert.NoError(t, watch.Run(ctx))
+
+		exists, err := dataStore.User.Exists(ctx, "deleted@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists, "Reported user should be removed from cache")
+		exists, err = dataStore.User.Exists(ctx, "active@example.com")
+		require.NoError(t, err)
+		assert.True(t, exists, "User not reported by the watch should remain in cache")
+	})
+
+	t.Run("Reported_Active_User_Should_Not_Be_Offboarded", func(t *testing.T) {
+		mockLDAPClient.EXPECT().
+			WatchUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, handler func(context.Context, []string)) error {
+				handler(ctx, []string{"active@example.com"})
+				return nil
+			}).
+			Times(1)
+		mockLDAPClient.EXPECT().
+			GetUserLDAPDataByEmail(gomock.Any(), "active@example.com").
+			Return(map[string]interface{}{"mail": "active@example.com"}, nil).
+			Times(1)
+
+		assert.NoError(t, watch.Run(ctx))
+
+		exists, err := dataStore.User.Exists(ctx, "active@example.com")
+		require.NoError(t, err)
+		assert.True(t, exists, "User active in LDAP should remain in cache")
+	})
+
+	t.Run("Renamed_User_Should_Be_Migrated", func(t *testing.T) {
+		require.NoError(t, dataStore.User.SetBackend(ctx, "old@example.com", "fivetran_fivetran", "renamed_789"))
+		require.NoError(t, dataStore.UserUID.SetEmail(ctx, "renamed", "old@example.com"))
+
+		// The user is no longer found by its old email, but its uid holds the new email
+		mockLDAPClient.EXPECT().
+			WatchUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, handler func(context.Context, []string)) error {
+				handler(ctx, []string{"old@example.com"})
+				return nil
+			}).
+			Times(1)
+		mockLDAPClient.EXPECT().
+			GetUserLDAPDataByEmail(gomock.Any(), "old@example.com").
+			Return(nil, ldap.ErrNoUserFound).
+			Times(1)
+		mockLDAPClient.EXPECT().
+			GetUserLDAPData(gomock.Any(), "renamed").
+			Return(map[string]interface{}{"mail": "new@example.com", "uid": "renamed"}, nil).
+			Times(1)
+
+		assert.NoError(t, watch.Run(ctx))
+
+		exists, err := dataStore.User.Exists(ctx, "old@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists, "Renamed user should be migrated from its old email")
+		backends, err := dataStore.User.GetBackends(ctx, "new@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"fivetran_fivetran": "renamed_789"}, backends,
+			"Renamed user should keep its backend IDs under its new email")
+	})
+
+	t.Run("Watch_Error_Should_Be_Returned", func(t *testing.T) {
+		mockLDAPClient.EXPECT().
+			WatchUsers(gomock.Any(), gomock.Any()).
+			Return(errors.New("LDAP server does not support the content synchronization")).
+			Times(1)
+
+		assert.Error(t, watch.Run(ctx))
+	})
+}
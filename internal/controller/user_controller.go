@@ -0,0 +1,347 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+	"github.com/redhat-data-and-ai/usernaut/pkg/tracing"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	userFinalizer = "operator.dataverse.redhat.com/user-finalizer"
+)
+
+// UserReconciler reconciles a User object
+type UserReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	AppConfig *config.AppConfig
+	Store     *store.Store
+	LdapConn  ldap.LDAPClient
+
+	// CacheMutex is the same mutex shared with the GroupReconciler and the periodic jobs,
+	// so that user onboarding does not race with group reconciliation or offboarding.
+	CacheMutex cache.Locker
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=users,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=users/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=users/finalizers,verbs=update
+
+func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "User.Reconcile",
+		attribute.String("user", req.NamespacedName.String()),
+		attribute.String("reconcile_id", string(controller.ReconcileIDFromContext(ctx))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logger.WithRequestId(ctx, controller.ReconcileIDFromContext(ctx))
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"request": req.NamespacedName.String(),
+	})
+
+	userCR := &usernautdevv1alpha1.User{}
+	if err := r.Get(ctx, req.NamespacedName, userCR); err != nil {
+		log.WithError(err).Error("Unable to fetch User CR")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if userCR.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, r.handleDeletion(ctx, userCR)
+	}
+
+	if !controllerutil.ContainsFinalizer(userCR, userFinalizer) {
+		controllerutil.AddFinalizer(userCR, userFinalizer)
+		if err := r.Update(ctx, userCR); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	userCR.SetWaiting()
+	if err := r.Status().Update(ctx, userCR); err != nil {
+		log.WithError(err).Error("error updating the status")
+		return ctrl.Result{}, err
+	}
+
+	log = log.WithField("user", userCR.Spec.UserID)
+	ctx = logger.WithLogger(ctx, log)
+
+	ldapUser, err := r.fetchLDAPUser(ctx, userCR.Spec.UserID)
+	if err != nil {
+		log.WithError(err).Error("error fetching user data from LDAP")
+		condition := metav1.Condition{
+			Type:               usernautdevv1alpha1.UserReadyCondition,
+			LastTransitionTime: metav1.Now(),
+			Status:             metav1.ConditionFalse,
+			Message:            "User not found in LDAP: " + err.Error(),
+			Reason:             "LDAPLookupFailed",
+			ObservedGeneration: userCR.Generation,
+		}
+		setUserCondition(&userCR.Status.Conditions, condition)
+		if updateErr := r.Status().Update(ctx, userCR); updateErr != nil {
+			log.WithError(updateErr).Error("error updating user status after LDAP failure")
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+	userCR.Status.Email = ldapUser.GetEmail()
+
+	r.CacheMutex.Lock()
+	_, err = controllerutils.MigrateRenamedUser(ctx, r.Store, ldapUser.GetUID(), ldapUser.GetEmail())
+	r.CacheMutex.Unlock()
+	if err != nil {
+		log.WithError(err).Error("error migrating the cache entries of the renamed user")
+	}
+
+	backendStatus := make([]usernautdevv1alpha1.BackendStatus, 0, len(userCR.Spec.Backends))
+	hasErrors := false
+	for _, backend := range userCR.Spec.Backends {
+		status := usernautdevv1alpha1.BackendStatus{
+			Name:    backend.Name,
+			Type:    backend.Type,
+			Status:  true,
+			Message: "Successful",
+		}
+		if err := r.onboardUserInBackend(ctx, userCR.Spec.UserID, ldapUser, backend); err != nil {
+			status.Status = false
+			status.Message = err.Error()
+			hasErrors = true
+		}
+		backendStatus = append(backendStatus, status)
+	}
+
+	if removeErr := controllerutils.RemoveForceReconcileLabel(ctx, r.Client, userCR); removeErr != nil {
+		log.WithError(removeErr).Error("Failed to remove force reconcile label")
+		return ctrl.Result{}, removeErr
+	}
+
+	userCR.Status.BackendsStatus = backendStatus
+	userCR.UpdateStatus(hasErrors)
+	if err := r.Status().Update(ctx, userCR); err != nil {
+		log.WithError(err).Error("error while updating final status")
+		return ctrl.Result{}, err
+	}
+
+	if hasErrors {
+		return ctrl.Result{}, errors.New("failed to reconcile all backends")
+	}
+	return ctrl.Result{RequeueAfter: r.resyncInterval(ctx)}, nil
+}
+
+// fetchLDAPUser looks up the user in LDAP and converts the result into an LDAPUser
+func (r *UserReconciler) fetchLDAPUser(ctx context.Context, userID string) (*structs.LDAPUser, error) {
+	ldapUserData, err := r.LdapConn.GetUserLDAPData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ldapUser := &structs.LDAPUser{}
+	if err := utils.MapToStruct(ldapUserData, ldapUser); err != nil {
+		return nil, err
+	}
+	return ldapUser, nil
+}
+
+// onboardUserInBackend creates the user in the backend if the cache has no ID for it yet. CacheMutex
+// is held around the cache reads and writes, not across the backend requests.
+func (r *UserReconciler) onboardUserInBackend(ctx context.Context,
+	userID string,
+	ldapUser *structs.LDAPUser,
+	backend usernautdevv1alpha1.Backend) error {
+
+	backendLogger := logger.Logger(ctx).WithFields(logrus.Fields{
+		"backend":      backend.Name,
+		"backend_type": backend.Type,
+	})
+	backendKey := backend.Name + "_" + backend.Type
+
+	r.CacheMutex.RLock()
+	userBackends, err := r.Store.User.GetBackends(ctx, ldapUser.GetEmail())
+	r.CacheMutex.RUnlock()
+	if err != nil {
+		backendLogger.WithError(err).Error("error fetching user details from cache")
+		return err
+	}
+	if id, exists := userBackends[backendKey]; exists && id != "" {
+		backendLogger.Debug("user already exists in cache")
+		return nil
+	}
+
+	backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+	if err != nil {
+		backendLogger.WithError(err).Error("error creating backend client")
+		return err
+	}
+
+	existingUsers := controllerutils.NewExistingUsers(backendClient)
+	newUser, err := controllerutils.CreateOrFindUser(ctx, backendClient, existingUsers, &structs.User{
+		Email:     ldapUser.GetEmail(),
+		UserName:  r.AppConfig.BackendMap[backend.Type][backend.Name].UsernameNormalization.Normalize(userID),
+		Role:      defaultBackendRoles(r.AppConfig, backend.Name, backend.Type).user,
+		FirstName: utils.StandardizeNameForBackend(ldapUser.GetDisplayName()),
+		LastName:  utils.StandardizeNameForBackend(ldapUser.GetSN()),
+	})
+	if err != nil {
+		backendLogger.WithError(err).Error("error creating user in backend")
+		return err
+	}
+	backendLogger.Info("created user in backend successfully")
+
+	r.CacheMutex.Lock()
+	err = r.Store.User.SetBackend(ctx, ldapUser.GetEmail(), backendKey, newUser.ID)
+	r.CacheMutex.Unlock()
+	if err != nil {
+		backendLogger.WithError(err).Error("error updating user details in cache")
+		return err
+	}
+	return nil
+}
+
+// handleDeletion offboards the user from its backends and removes the finalizer.
+// Users that are still members of a group are left in place, since the group
+// controller owns their backend accounts from then on.
+func (r *UserReconciler) handleDeletion(ctx context.Context, userCR *usernautdevv1alpha1.User) error {
+	log := logger.Logger(ctx)
+	if !controllerutil.ContainsFinalizer(userCR, userFinalizer) {
+		return nil
+	}
+
+	email := userCR.Status.Email
+	if email != "" {
+		r.CacheMutex.RLock()
+		groups, err := r.Store.UserGroups.GetGroups(ctx, email)
+		r.CacheMutex.RUnlock()
+		if err != nil {
+			log.WithError(err).Error("error fetching user groups from cache")
+			return err
+		}
+		if len(groups) > 0 {
+			log.WithField("groups", groups).Info("Finalizer: user is still a member of groups, skipping backend offboarding")
+		} else {
+			r.offboardUserFromBackends(ctx, email, userCR.Spec.Backends)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(userCR, userFinalizer)
+	if err := r.Update(ctx, userCR); err != nil {
+		log.WithError(err).Error("error while updating user CR")
+		return err
+	}
+	return nil
+}
+
+// offboardUserFromBackends performs best-effort deletion of the user from the given backends.
+// CacheMutex is held around the cache reads and writes, not across the backend requests.
+func (r *UserReconciler) offboardUserFromBackends(ctx context.Context, email string, backends []usernautdevv1alpha1.Backend) {
+	log := logger.Logger(ctx)
+	r.CacheMutex.RLock()
+	userBackends, err := r.Store.User.GetBackends(ctx, email)
+	r.CacheMutex.RUnlock()
+	if err != nil {
+		log.WithError(err).Warn("Finalizer: error fetching user details from cache, skipping offboarding")
+		return
+	}
+
+	for _, backend := range backends {
+		backendLogger := logger.Logger(ctx).WithFields(logrus.Fields{
+			"backend":      backend.Name,
+			"backend_type": backend.Type,
+		})
+		backendKey := backend.Name + "_" + backend.Type
+		backendUserID, exists := userBackends[backendKey]
+		if !exists || backendUserID == "" {
+			backendLogger.Info("Finalizer: no user ID found in cache, skipping backend deletion")
+			continue
+		}
+
+		backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+		if err != nil {
+			backendLogger.WithError(err).Warn("Finalizer: error creating client for backend, skipping this backend")
+			continue
+		}
+
+		if err := backendClient.DeleteUser(ctx, backendUserID); err != nil {
+			backendLogger.WithError(err).Warn("Finalizer: failed to delete user from the backend")
+			continue
+		}
+
+		r.CacheMutex.Lock()
+		err = r.Store.User.DeleteBackend(ctx, email, backendKey)
+		r.CacheMutex.Unlock()
+		if err != nil {
+			backendLogger.WithError(err).Warn("Finalizer: failed to delete user backend from cache")
+		}
+		backendLogger.Info("Finalizer: successfully offboarded user from backend")
+	}
+}
+
+// resyncInterval returns how often users are reconciled without spec changes, the same as the groups
+func (r *UserReconciler) resyncInterval(ctx context.Context) time.Duration {
+	return controllerutils.DurationOrDefault(ctx, "resyncInterval",
+		r.AppConfig.ControllerConfig.ResyncInterval, requeueAfter)
+}
+
+// setUserCondition updates or adds a condition to the condition slice
+func setUserCondition(conditions *[]metav1.Condition, newCondition metav1.Condition) {
+	for i, cond := range *conditions {
+		if cond.Type == newCondition.Type {
+			(*conditions)[i] = newCondition
+			return
+		}
+	}
+	*conditions = append(*conditions, newCondition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	maxConcurrentReconciles := r.AppConfig.ControllerConfig.MaxConcurrentReconciles
+	if maxConcurrentReconciles <= 0 {
+		maxConcurrentReconciles = 1 // default value
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&usernautdevv1alpha1.User{}).
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, controllerutils.ForceReconcilePredicate())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             controllerutils.NewRateLimiter(context.Background(), r.AppConfig.ControllerConfig.RateLimiter),
+		}).
+		Complete(r)
+}
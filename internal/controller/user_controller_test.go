@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/mocks"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+)
+
+var _ = Describe("User Controller", func() {
+
+	setupUserReconciler := func(backends []config.Backend) (*UserReconciler, *mocks.MockLDAPClient) {
+		backendMap := make(map[string]map[string]config.Backend)
+		for _, backend := range backends {
+			if _, ok := backendMap[backend.Type]; !ok {
+				backendMap[backend.Type] = make(map[string]config.Backend)
+			}
+			backendMap[backend.Type][backend.Name] = backend
+		}
+
+		appConfig := &config.AppConfig{
+			Backends:   backends,
+			BackendMap: backendMap,
+			Cache: cache.Config{
+				Driver: "memory",
+				InMemory: &inmemory.Config{
+					DefaultExpiration: int32(-1),
+					CleanupInterval:   int32(-1),
+				},
+			},
+		}
+
+		Cache, err := cache.New(&appConfig.Cache)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctrl := gomock.NewController(GinkgoT())
+		ldapClient := mocks.NewMockLDAPClient(ctrl)
+
+		return &UserReconciler{
+			Client:     k8sClient,
+			Scheme:     k8sClient.Scheme(),
+			AppConfig:  appConfig,
+			Store:      store.New(Cache),
+			LdapConn:   ldapClient,
+			CacheMutex: &sync.RWMutex{},
+		}, ldapClient
+	}
+
+	fivetranBackend := config.Backend{
+		Name:    "fivetran",
+		Type:    "fivetran",
+		Enabled: true,
+		Connection: map[string]interface{}{
+			keyApiKey:    "testKey",
+			keyApiSecret: "testSecret",
+		},
+	}
+
+	ldapUserData := map[string]interface{}{
+		"cn":          "Test",
+		"sn":          "User",
+		"displayName": "Test User",
+		"mail":        "testuser@gmail.com",
+		"uid":         "testuser",
+	}
+
+	Context("When reconciling a User resource", func() {
+		ctx := context.Background()
+
+		createUser := func(name string) (*usernautdevv1alpha1.User, types.NamespacedName) {
+			nn := types.NamespacedName{Name: name, Namespace: "default"}
+			userCR := &usernautdevv1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: usernautdevv1alpha1.UserSpec{
+					UserID: "testuser",
+					Backends: []usernautdevv1alpha1.Backend{
+						{Name: "fivetran", Type: "fivetran"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, userCR)).To(Succeed())
+			return userCR, nn
+		}
+
+		It("should skip backend creation when the user is already cached", func() {
+			userCR, nn := createUser("test-user-cached")
+			defer func() { _ = k8sClient.Delete(ctx, userCR) }()
+
+			reconciler, ldapClient := setupUserReconciler([]config.Backend{fivetranBackend})
+			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), "testuser").Return(ldapUserData, nil).Times(1)
+			Expect(reconciler.Store.User.SetBackend(ctx, "testuser@gmail.com", "fivetran_fivetran", "ft-123")).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &usernautdevv1alpha1.User{}
+			Expect(k8sClient.Get(ctx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Email).To(Equal("testuser@gmail.com"))
+			Expect(updated.Status.BackendsStatus).To(HaveLen(1))
+			Expect(updated.Status.BackendsStatus[0].Status).To(BeTrue())
+		})
+
+		It("should surface LDAP lookup failures on the User status", func() {
+			userCR, nn := createUser("test-user-missing")
+			defer func() { _ = k8sClient.Delete(ctx, userCR) }()
+
+			reconciler, ldapClient := setupUserReconciler([]config.Backend{fivetranBackend})
+			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), "testuser").Return(nil, ldap.ErrNoUserFound).Times(1)
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(HaveOccurred())
+
+			updated := &usernautdevv1alpha1.User{}
+			Expect(k8sClient.Get(ctx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Conditions).NotTo(BeEmpty())
+			Expect(updated.Status.Conditions[0].Reason).To(Equal("LDAPLookupFailed"))
+		})
+
+		It("should report a failed backend when the backend is not enabled", func() {
+			userCR, nn := createUser("test-user-disabled-backend")
+			defer func() { _ = k8sClient.Delete(ctx, userCR) }()
+
+			disabled := fivetranBackend
+			disabled.Enabled = false
+			reconciler, ldapClient := setupUserReconciler([]config.Backend{disabled})
+			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), "testuser").Return(ldapUserData, nil).Times(1)
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(MatchError(ContainSubstring("failed to reconcile all backends")))
+
+			updated := &usernautdevv1alpha1.User{}
+			Expect(k8sClient.Get(ctx, nn, updated)).To(Succeed())
+			Expect(updated.Status.BackendsStatus).To(HaveLen(1))
+			Expect(updated.Status.BackendsStatus[0].Status).To(BeFalse())
+		})
+
+		It("should keep backend accounts of users that still belong to a group on deletion", func() {
+			userCR, nn := createUser("test-user-delete")
+
+			reconciler, ldapClient := setupUserReconciler([]config.Backend{fivetranBackend})
+			ldapClient.EXPECT().GetUserLDAPData(gomock.Any(), "testuser").Return(ldapUserData, nil).Times(1)
+			Expect(reconciler.Store.User.SetBackend(ctx, "testuser@gmail.com", "fivetran_fivetran", "ft-123")).To(Succeed())
+			Expect(reconciler.Store.UserGroups.AddGroup(ctx, "testuser@gmail.com", "some-group")).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Delete(ctx, userCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			userBackends, err := reconciler.Store.User.GetBackends(ctx, "testuser@gmail.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userBackends).To(HaveKeyWithValue("fivetran_fivetran", "ft-123"))
+		})
+	})
+})
@@ -23,19 +23,27 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 	"github.com/redhat-data-and-ai/usernaut/pkg/store"
 )
 
+// HealthReporter reports the results of the last health checks of LDAP and the backends
+type HealthReporter interface {
+	HealthStatuses() []clients.HealthStatus
+}
+
 type Handlers struct {
 	config *config.AppConfig
 	store  *store.Store
+	health HealthReporter
 }
 
-func NewHandlers(cfg *config.AppConfig, dataStore *store.Store) *Handlers {
+func NewHandlers(cfg *config.AppConfig, dataStore *store.Store, health HealthReporter) *Handlers {
 	return &Handlers{
 		config: cfg,
 		store:  dataStore,
+		health: health,
 	}
 }
 
@@ -54,6 +62,33 @@ func (h *Handlers) GetBackends(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// BackendHealthResponse represents the response for the backend health endpoint
+type BackendHealthResponse struct {
+	Healthy  bool                   `json:"healthy"`
+	Backends []clients.HealthStatus `json:"backends"`
+}
+
+// GetBackendHealth returns the results of the last health checks, with a 503 status when a backend
+// is unhealthy or not checked yet
+func (h *Handlers) GetBackendHealth(c *gin.Context) {
+	response := BackendHealthResponse{Backends: make([]clients.HealthStatus, 0)}
+	if h.health != nil {
+		response.Backends = append(response.Backends, h.health.HealthStatuses()...)
+	}
+	response.Healthy = len(response.Backends) > 0
+	for _, status := range response.Backends {
+		if !status.Healthy {
+			response.Healthy = false
+		}
+	}
+
+	code := http.StatusOK
+	if !response.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, response)
+}
+
 // UserGroupsResponse represents the response for user groups endpoint
 type UserGroupsResponse struct {
 	Email  string          `json:"email"`
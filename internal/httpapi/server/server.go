@@ -25,7 +25,7 @@ type APIServer struct {
 	handlers *handlers.Handlers
 }
 
-func NewAPIServer(cfg *config.AppConfig, dataStore *store.Store) *APIServer {
+func NewAPIServer(cfg *config.AppConfig, dataStore *store.Store, health handlers.HealthReporter) *APIServer {
 	if cfg.App.Debug {
 		gin.SetMode(gin.DebugMode)
 	} else {
@@ -50,7 +50,7 @@ func NewAPIServer(cfg *config.AppConfig, dataStore *store.Store) *APIServer {
 	s := &APIServer{
 		config:   cfg,
 		router:   router,
-		handlers: handlers.NewHandlers(cfg, dataStore),
+		handlers: handlers.NewHandlers(cfg, dataStore, health),
 	}
 
 	s.setupRoutes()
@@ -71,6 +71,7 @@ func (s *APIServer) setupRoutes() {
 	// add authenticated endpoints accordingly
 
 	v1.GET("/backends", s.handlers.GetBackends)
+	v1.GET("/backends/health", s.handlers.GetBackendHealth)
 	v1.GET("/user/:email/groups", s.handlers.GetUserGroups)
 
 }
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+//nolint:lll
+// +kubebuilder:webhook:path=/mutate-operator-dataverse-redhat-com-v1alpha1-group,mutating=true,failurePolicy=fail,sideEffects=None,groups=operator.dataverse.redhat.com,resources=groups,verbs=create,versions=v1alpha1,name=mgroup-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups="",namespace=usernaut,resources=configmaps,verbs=get
+
+// GroupDefaults is the content of the defaults.yaml key in the namespace defaults ConfigMap
+type GroupDefaults struct {
+	Backends    []usernautdevv1alpha1.Backend    `json:"backends,omitempty"`
+	GroupParams []usernautdevv1alpha1.GroupParam `json:"group_params,omitempty"`
+	Labels      map[string]string                `json:"labels,omitempty"`
+}
+
+// GroupCustomDefaulter applies the namespace defaults to Group CRs on create. Values already present
+// on the CR are never overridden, and updates are left untouched so that a default can be removed.
+type GroupCustomDefaulter struct {
+	// Reader is used to fetch the defaults ConfigMap, an uncached reader avoids
+	// having to watch all ConfigMaps in the namespace
+	Reader client.Reader
+}
+
+var _ webhook.CustomDefaulter = &GroupCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Group.
+func (d *GroupCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	group, ok := obj.(*usernautdevv1alpha1.Group)
+	if !ok {
+		return fmt.Errorf("expected a Group object but got %T", obj)
+	}
+	if group.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	// the defaults are only applied on create, re-applying them would add back a removed default
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"group":     group.Name,
+		"namespace": group.Namespace,
+	})
+
+	defaults, err := d.fetchDefaults(ctx, group.Namespace)
+	if err != nil {
+		log.WithError(err).Error("error fetching namespace group defaults")
+		return err
+	}
+	if defaults == nil {
+		return nil
+	}
+
+	applyGroupDefaults(group, defaults)
+	log.Debug("applied namespace defaults to the group")
+	return nil
+}
+
+// fetchDefaults returns the parsed defaults for the namespace, or nil if the namespace has no defaults ConfigMap
+func (d *GroupCustomDefaulter) fetchDefaults(ctx context.Context, namespace string) (*GroupDefaults, error) {
+	configMap := &corev1.ConfigMap{}
+	err := d.Reader.Get(ctx, types.NamespacedName{
+		Name:      constants.GroupDefaultsConfigMapName,
+		Namespace: namespace,
+	}, configMap)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := configMap.Data[constants.GroupDefaultsConfigMapKey]
+	if !ok {
+		return nil, nil
+	}
+
+	defaults := &GroupDefaults{}
+	if err := yaml.Unmarshal([]byte(data), defaults); err != nil {
+		return nil, fmt.Errorf("invalid %s in ConfigMap %s/%s: %w", constants.GroupDefaultsConfigMapKey,
+			namespace, constants.GroupDefaultsConfigMapName, err)
+	}
+	return defaults, nil
+}
+
+// applyGroupDefaults merges the defaults into the group, keeping whatever the group already declares
+func applyGroupDefaults(group *usernautdevv1alpha1.Group, defaults *GroupDefaults) {
+	existingBackends := make(map[string]bool, len(group.Spec.Backends))
+	for _, backend := range group.Spec.Backends {
+		existingBackends[backend.Name+"_"+backend.Type] = true
+	}
+	for _, backend := range defaults.Backends {
+		if !existingBackends[backend.Name+"_"+backend.Type] {
+			group.Spec.Backends = append(group.Spec.Backends, backend)
+			existingBackends[backend.Name+"_"+backend.Type] = true
+		}
+	}
+
+	// A group param is identified by its backend and property, so a CR can override a default value
+	existingParams := make(map[string]bool, len(group.Spec.GroupParams))
+	for _, param := range group.Spec.GroupParams {
+		existingParams[param.Name+"_"+param.Backend+"_"+param.Property] = true
+	}
+	for _, param := range defaults.GroupParams {
+		key := param.Name + "_" + param.Backend + "_" + param.Property
+		// Only apply params for backends the group actually uses
+		if existingParams[key] || !existingBackends[param.Name+"_"+param.Backend] {
+			continue
+		}
+		group.Spec.GroupParams = append(group.Spec.GroupParams, param)
+		existingParams[key] = true
+	}
+
+	if len(defaults.Labels) > 0 {
+		labels := group.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, len(defaults.Labels))
+		}
+		for key, value := range defaults.Labels {
+			if _, exists := labels[key]; !exists {
+				labels[key] = value
+			}
+		}
+		group.SetLabels(labels)
+	}
+}
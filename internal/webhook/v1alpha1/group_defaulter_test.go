@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+)
+
+// configMapReader serves ConfigMaps from memory, keyed by namespace
+type configMapReader struct {
+	configMaps map[string]*corev1.ConfigMap
+}
+
+func (r *configMapReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	cm, ok := r.configMaps[key.Namespace]
+	if !ok || key.Name != constants.GroupDefaultsConfigMapName {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	cm.DeepCopyInto(obj.(*corev1.ConfigMap))
+	return nil
+}
+
+func (r *configMapReader) List(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+	return nil
+}
+
+var _ = Describe("Group Defaulter", func() {
+	var (
+		ctx       context.Context
+		defaulter *GroupCustomDefaulter
+		group     *usernautdevv1alpha1.Group
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		defaulter = &GroupCustomDefaulter{
+			Reader: &configMapReader{configMaps: map[string]*corev1.ConfigMap{
+				"usernaut": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      constants.GroupDefaultsConfigMapName,
+						Namespace: "usernaut",
+					},
+					Data: map[string]string{
+						constants.GroupDefaultsConfigMapKey: `
+backends:
+  - name: fivetran
+    type: fivetran
+  - name: gitlab
+    type: gitlab
+group_params:
+  - backend: gitlab
+    name: gitlab
+    property: project_access_paths
+    value:
+      - dataverse/default-project
+  - backend: snowflake
+    name: prod
+    property: warehouse
+    value:
+      - default_wh
+labels:
+  team: dataverse
+  env: prod
+`,
+					},
+				},
+				"broken": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      constants.GroupDefaultsConfigMapName,
+						Namespace: "broken",
+					},
+					Data: map[string]string{
+						constants.GroupDefaultsConfigMapKey: "backends: [",
+					},
+				},
+			}},
+		}
+		group = &usernautdevv1alpha1.Group{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dataverse-mygroup",
+				Namespace: "usernaut",
+				Labels:    map[string]string{"env": "dev"},
+			},
+			Spec: usernautdevv1alpha1.GroupSpec{
+				GroupName: "dataverse-mygroup",
+				Members:   usernautdevv1alpha1.Members{Users: []string{"user1"}},
+				Backends: []usernautdevv1alpha1.Backend{
+					{Name: "fivetran", Type: "fivetran"},
+				},
+			},
+		}
+	})
+
+	It("should merge namespace defaults without overriding the CR", func() {
+		Expect(defaulter.Default(ctx, group)).To(Succeed())
+
+		Expect(group.Spec.Backends).To(ConsistOf(
+			usernautdevv1alpha1.Backend{Name: "fivetran", Type: "fivetran"},
+			usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"},
+		))
+		// the snowflake param is dropped since the group does not use that backend
+		Expect(group.Spec.GroupParams).To(HaveLen(1))
+		Expect(group.Spec.GroupParams[0].Property).To(Equal("project_access_paths"))
+		Expect(group.Labels).To(HaveKeyWithValue("team", "dataverse"))
+		Expect(group.Labels).To(HaveKeyWithValue("env", "dev"))
+	})
+
+	It("should keep group params declared on the CR", func() {
+		group.Spec.Backends = append(group.Spec.Backends, usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"})
+		group.Spec.GroupParams = []usernautdevv1alpha1.GroupParam{
+			{Backend: "gitlab", Name: "gitlab", Property: "project_access_paths", Value: []string{"team/project"}},
+		}
+		Expect(defaulter.Default(ctx, group)).To(Succeed())
+
+		Expect(group.Spec.Backends).To(HaveLen(2))
+		Expect(group.Spec.GroupParams).To(HaveLen(1))
+		Expect(group.Spec.GroupParams[0].Value).To(Equal([]string{"team/project"}))
+	})
+
+	It("should not apply the defaults on update", func() {
+		updateCtx := admission.NewContextWithRequest(ctx, admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update},
+		})
+		Expect(defaulter.Default(updateCtx, group)).To(Succeed())
+
+		Expect(group.Spec.Backends).To(ConsistOf(usernautdevv1alpha1.Backend{Name: "fivetran", Type: "fivetran"}))
+		Expect(group.Labels).To(HaveLen(1))
+	})
+
+	It("should leave the group untouched when the namespace has no defaults", func() {
+		group.Namespace = "other"
+		Expect(defaulter.Default(ctx, group)).To(Succeed())
+		Expect(group.Spec.Backends).To(HaveLen(1))
+		Expect(group.Labels).To(HaveLen(1))
+	})
+
+	It("should fail when the defaults are not valid YAML", func() {
+		group.Namespace = "broken"
+		Expect(defaulter.Default(ctx, group)).NotTo(Succeed())
+	})
+})
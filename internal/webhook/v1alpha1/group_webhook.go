@@ -0,0 +1,342 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// SetupGroupWebhookWithManager registers the webhook for Group in the manager.
+func SetupGroupWebhookWithManager(mgr ctrl.Manager, appConfig *config.AppConfig) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&usernautdevv1alpha1.Group{}).
+		WithValidator(&GroupCustomValidator{AppConfig: appConfig, Reader: mgr.GetClient()}).
+		WithDefaulter(&GroupCustomDefaulter{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/validate-operator-dataverse-redhat-com-v1alpha1-group,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.dataverse.redhat.com,resources=groups,verbs=create;update,versions=v1alpha1,name=vgroup-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// GroupCustomValidator rejects Group specs that would otherwise only fail at reconcile time
+type GroupCustomValidator struct {
+	AppConfig *config.AppConfig
+	// Reader lists the groups sharing the group name of a validated group, it relies on the
+	// field index of the Group controller. Duplicate group names are not checked when nil.
+	Reader client.Reader
+}
+
+var _ webhook.CustomValidator = &GroupCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Group.
+func (v *GroupCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	group, ok := obj.(*usernautdevv1alpha1.Group)
+	if !ok {
+		return nil, fmt.Errorf("expected a Group object but got %T", obj)
+	}
+	return v.validateGroup(ctx, group, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Group.
+func (v *GroupCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	group, ok := newObj.(*usernautdevv1alpha1.Group)
+	if !ok {
+		return nil, fmt.Errorf("expected a Group object for the newObj but got %T", newObj)
+	}
+	oldGroup, ok := oldObj.(*usernautdevv1alpha1.Group)
+	if !ok {
+		return nil, fmt.Errorf("expected a Group object for the oldObj but got %T", oldObj)
+	}
+	// Objects being deleted only have their finalizers removed, don't block that
+	if group.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	return v.validateGroup(ctx, group, oldGroup)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Group.
+func (v *GroupCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateGroup runs all the spec checks and aggregates the failures into a single Invalid error,
+// oldGroup is nil on create
+func (v *GroupCustomValidator) validateGroup(ctx context.Context, group,
+	oldGroup *usernautdevv1alpha1.Group) (admission.Warnings, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"group":     group.Name,
+		"namespace": group.Namespace,
+	})
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	allErrs = append(allErrs, validateTemplateRef(group, specPath)...)
+	allErrs = append(allErrs, v.validateGroupName(ctx, group, oldGroup, specPath.Child("group_name"))...)
+	backendsWarnings, backendsErrs := v.validateBackends(group, specPath.Child("backends"))
+	warnings = append(warnings, backendsWarnings...)
+	allErrs = append(allErrs, backendsErrs...)
+	allErrs = append(allErrs, validateGroupParams(group, specPath.Child("group_params"))...)
+	allErrs = append(allErrs, validateMemberGroups(group, specPath.Child("members", "groups"))...)
+	allErrs = append(allErrs, validateMemberRoles(group, specPath.Child("members"))...)
+	allErrs = append(allErrs, validateLDAPGroups(group, specPath.Child("members", "ldap_groups"))...)
+	allErrs = append(allErrs, validateBackendOverrides(group, specPath.Child("backend_overrides"))...)
+	allErrs = append(allErrs, v.validateApprover(ctx, group, oldGroup,
+		field.NewPath("metadata", "annotations").Key(constants.ApprovedByAnnotation))...)
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	log.WithField("errors", allErrs.ToAggregate().Error()).Info("rejecting invalid Group spec")
+	return warnings, apierrors.NewInvalid(
+		schema.GroupKind{Group: usernautdevv1alpha1.GroupVersion.Group, Kind: "Group"},
+		group.Name, allErrs)
+}
+
+// validateApprover only lets the approvers of the approval config set the approved-by annotation,
+// naming themselves. Removing the annotation, as the controller does once the approval is consumed,
+// is always allowed.
+func (v *GroupCustomValidator) validateApprover(ctx context.Context, group, oldGroup *usernautdevv1alpha1.Group,
+	approverPath *field.Path) field.ErrorList {
+	approver := group.GetAnnotations()[constants.ApprovedByAnnotation]
+	if approver == "" || (oldGroup != nil && oldGroup.GetAnnotations()[constants.ApprovedByAnnotation] == approver) {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return field.ErrorList{field.Forbidden(approverPath, "the user approving the group is unknown")}
+	}
+	username := req.UserInfo.Username
+	if !v.AppConfig.ControllerConfig.Approval.IsApprover(username, req.UserInfo.Groups) {
+		return field.ErrorList{field.Forbidden(approverPath, fmt.Sprintf("%s is not allowed to approve groups", username))}
+	}
+	if approver != username {
+		return field.ErrorList{field.Invalid(approverPath, approver, fmt.Sprintf("must name the approver %s", username))}
+	}
+	return nil
+}
+
+// validateGroupName rejects a group name already used by another group of the namespace. Only new
+// and renamed groups are checked, so groups created before the check can still be updated.
+func (v *GroupCustomValidator) validateGroupName(ctx context.Context, group, oldGroup *usernautdevv1alpha1.Group,
+	groupNamePath *field.Path) field.ErrorList {
+	if v.Reader == nil || (oldGroup != nil && oldGroup.Spec.GroupName == group.Spec.GroupName) {
+		return nil
+	}
+	others, err := controllerutils.GroupsWithSameName(ctx, v.Reader, group)
+	if err != nil {
+		return field.ErrorList{field.InternalError(groupNamePath, err)}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(groupNamePath, group.Spec.GroupName,
+		fmt.Sprintf("group_name is already used by Group %s", others[0].Name))}
+}
+
+// validateBackends checks that every backend exists in the app config and that the
+// group name can be transformed for its type. A pattern failing for several backends is
+// reported once, on the first of them.
+func (v *GroupCustomValidator) validateBackends(group *usernautdevv1alpha1.Group,
+	backendsPath *field.Path) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	reported := make(map[string]bool)
+
+	for i, backend := range group.Spec.Backends {
+		backendPath := backendsPath.Index(i)
+
+		backendCfg, ok := v.AppConfig.BackendMap[backend.Type][backend.Name]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(backendPath,
+				fmt.Sprintf("%s/%s", backend.Type, backend.Name)))
+			continue
+		}
+		if !backendCfg.Enabled {
+			warnings = append(warnings,
+				fmt.Sprintf("backend %s/%s is disabled and will fail to reconcile", backend.Type, backend.Name))
+		}
+
+		_, err := utils.GetTransformedBackendGroupName(v.AppConfig, backend.Type, backend.Name, group.Spec.GroupName)
+		if err != nil && !reported[err.Error()] {
+			reported[err.Error()] = true
+			allErrs = append(allErrs, field.Invalid(backendPath, group.Spec.GroupName, err.Error()))
+		}
+	}
+	return warnings, allErrs
+}
+
+// validateGroupParams rejects group params without a property, pointing at a backend the group doesn't use,
+// or whose property or values don't match the group param schema of the backend type
+func validateGroupParams(group *usernautdevv1alpha1.Group, paramsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	validBackends := make(map[string]bool, len(group.Spec.Backends))
+	for _, backend := range group.Spec.Backends {
+		validBackends[backend.Name+"_"+backend.Type] = true
+	}
+
+	for i, param := range group.Spec.GroupParams {
+		paramPath := paramsPath.Index(i)
+		if !validBackends[param.Name+"_"+param.Backend] {
+			allErrs = append(allErrs, field.Invalid(paramPath, fmt.Sprintf("%s/%s", param.Backend, param.Name),
+				"group param refers to a backend that is not listed in spec.backends"))
+		}
+		if strings.TrimSpace(param.Property) == "" {
+			allErrs = append(allErrs, field.Required(paramPath.Child("property"),
+				"group param property must not be empty"))
+			continue
+		}
+		schema, ok := clients.LookupGroupParam(param.Backend, param.Property)
+		if !ok {
+			allErrs = append(allErrs, field.NotSupported(paramPath.Child("property"), param.Property,
+				clients.GroupParamProperties(param.Backend)))
+			continue
+		}
+		if schema.MaxValues > 0 && len(param.Value) > schema.MaxValues {
+			allErrs = append(allErrs, field.TooMany(paramPath.Child("value"), len(param.Value), schema.MaxValues))
+		}
+		for j, value := range param.Value {
+			if err := schema.ValidateValue(value); err != nil {
+				allErrs = append(allErrs, field.Invalid(paramPath.Child("value").Index(j), value, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateTemplateRef requires the group name and backends of a group which does not reference
+// a GroupTemplate, they are otherwise expanded from the template
+func validateTemplateRef(group *usernautdevv1alpha1.Group, specPath *field.Path) field.ErrorList {
+	if group.Spec.TemplateRef != "" {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if group.Spec.GroupName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("group_name"),
+			"group_name is required when template_ref is not set"))
+	}
+	if len(group.Spec.Backends) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("backends"),
+			"backends are required when template_ref is not set"))
+	}
+	return allErrs
+}
+
+// validateLDAPGroups rejects LDAP group DNs that cannot be parsed
+func validateLDAPGroups(group *usernautdevv1alpha1.Group, ldapGroupsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, groupDN := range group.Spec.Members.LDAPGroups {
+		if _, err := ldap.ParseDN(groupDN); err != nil || strings.TrimSpace(groupDN) == "" {
+			allErrs = append(allErrs, field.Invalid(ldapGroupsPath.Index(i), groupDN, "must be a valid LDAP DN"))
+		}
+	}
+	return allErrs
+}
+
+// validateMemberGroups rejects a group that lists itself as a member group
+func validateMemberGroups(group *usernautdevv1alpha1.Group, groupsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, memberGroup := range group.Spec.Members.Groups {
+		if memberGroup == group.Name {
+			allErrs = append(allErrs, field.Invalid(groupsPath.Index(i), memberGroup,
+				"group cannot reference itself as a member group"))
+		}
+	}
+	return allErrs
+}
+
+// validateMemberRoles rejects roles for a backend type the group doesn't use and conflicting roles for a member,
+// across the member roles and the users listed with their role
+func validateMemberRoles(group *usernautdevv1alpha1.Group, membersPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	backendTypes := make(map[string]bool, len(group.Spec.Backends))
+	for _, backend := range group.Spec.Backends {
+		backendTypes[backend.Type] = true
+	}
+
+	seen := make(map[string]bool, len(group.Spec.Members.Roles)+len(group.Spec.Members.UsersWithRoles))
+	allErrs = append(allErrs, validateRoles(group.Spec.Members.UsersWithRoles, backendTypes, seen,
+		membersPath.Child("users_with_roles"))...)
+	allErrs = append(allErrs, validateRoles(group.Spec.Members.Roles, backendTypes, seen,
+		membersPath.Child("roles"))...)
+	return allErrs
+}
+
+// validateRoles validates a list of member roles, seen records the roles already set for a member and backend
+func validateRoles(roles []usernautdevv1alpha1.MemberRole, backendTypes, seen map[string]bool,
+	rolesPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, memberRole := range roles {
+		rolePath := rolesPath.Index(i)
+		if strings.TrimSpace(memberRole.User) == "" {
+			allErrs = append(allErrs, field.Required(rolePath.Child("user"), "member role user must not be empty"))
+		}
+		if memberRole.Backend != "" && !backendTypes[memberRole.Backend] {
+			allErrs = append(allErrs, field.Invalid(rolePath.Child("backend"), memberRole.Backend,
+				"member role refers to a backend type that is not listed in spec.backends"))
+		}
+		key := memberRole.User + "_" + memberRole.Backend
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(rolePath, memberRole.User))
+		}
+		seen[key] = true
+	}
+	return allErrs
+}
+
+// validateBackendOverrides rejects overrides for a backend the group doesn't use
+func validateBackendOverrides(group *usernautdevv1alpha1.Group, overridesPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	validBackends := make(map[string]bool, len(group.Spec.Backends))
+	for _, backend := range group.Spec.Backends {
+		validBackends[backend.Name+"_"+backend.Type] = true
+	}
+
+	for i, override := range group.Spec.BackendOverrides {
+		if !validBackends[override.Name+"_"+override.Type] {
+			allErrs = append(allErrs, field.Invalid(overridesPath.Index(i), fmt.Sprintf("%s/%s", override.Type, override.Name),
+				"backend override refers to a backend that is not listed in spec.backends"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/internal/controller/controllerutils"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+var _ = Describe("Group Webhook", func() {
+	var (
+		ctx       context.Context
+		validator *GroupCustomValidator
+		group     *usernautdevv1alpha1.Group
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &GroupCustomValidator{
+			AppConfig: &config.AppConfig{
+				Pattern: map[string][]config.PatternEntry{
+					"default": {{
+						Input:  `^dataverse-(.*)$`,
+						Output: "dataverse_$1",
+					}},
+				},
+				BackendMap: map[string]map[string]config.Backend{
+					"fivetran": {
+						"fivetran": {Name: "fivetran", Type: "fivetran", Enabled: true},
+					},
+					"gitlab": {
+						"gitlab": {Name: "gitlab", Type: "gitlab", Enabled: false},
+					},
+				},
+			},
+		}
+		group = &usernautdevv1alpha1.Group{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dataverse-mygroup",
+				Namespace: "usernaut",
+			},
+			Spec: usernautdevv1alpha1.GroupSpec{
+				GroupName: "dataverse-mygroup",
+				Members: usernautdevv1alpha1.Members{
+					Users:  []string{"user1"},
+					Groups: []string{"dataverse-other"},
+				},
+				Backends: []usernautdevv1alpha1.Backend{
+					{Name: "fivetran", Type: "fivetran"},
+				},
+			},
+		}
+	})
+
+	Context("When creating or updating a Group", func() {
+		It("should admit a valid group", func() {
+			warnings, err := validator.ValidateCreate(ctx, group)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject a backend that is not configured", func() {
+			group.Spec.Backends = append(group.Spec.Backends, usernautdevv1alpha1.Backend{Name: "prod", Type: "snowflake"})
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.backends[1]"))
+		})
+
+		It("should warn about a disabled backend", func() {
+			group.Spec.Backends = append(group.Spec.Backends, usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"})
+			warnings, err := validator.ValidateCreate(ctx, group)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+		})
+
+		It("should reject a group name with no matching pattern", func() {
+			group.Spec.GroupName = "unmatched-group"
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.backends[0]"))
+		})
+
+		It("should report a failing pattern once for backends of the same type", func() {
+			validator.AppConfig.BackendMap["fivetran"]["other"] = config.Backend{Name: "other", Type: "fivetran", Enabled: true}
+			group.Spec.GroupName = "unmatched-group"
+			group.Spec.Backends = append(group.Spec.Backends, usernautdevv1alpha1.Backend{Name: "other", Type: "fivetran"})
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.backends[0]"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.backends[1]"))
+		})
+
+		It("should reject group params with an empty property", func() {
+			group.Spec.GroupParams = []usernautdevv1alpha1.GroupParam{
+				{Backend: "fivetran", Name: "fivetran", Property: " ", Value: []string{"x"}},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.group_params[0].property"))
+		})
+
+		It("should reject group params for a backend not in spec.backends", func() {
+			group.Spec.GroupParams = []usernautdevv1alpha1.GroupParam{
+				{Backend: "gitlab", Name: "gitlab", Property: "project_access_paths", Value: []string{"x"}},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.group_params[0]"))
+		})
+
+		It("should reject group params unsupported by the backend type", func() {
+			group.Spec.GroupParams = []usernautdevv1alpha1.GroupParam{
+				{Backend: "fivetran", Name: "fivetran", Property: "project_access_paths", Value: []string{"team/project"}},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.group_params[0].property"))
+		})
+
+		It("should validate the group param values against the schema of the backend type", func() {
+			validator.AppConfig.BackendMap["gitlab"]["gitlab"] = config.Backend{Name: "gitlab", Type: "gitlab", Enabled: true}
+			group.Spec.Backends = append(group.Spec.Backends, usernautdevv1alpha1.Backend{Name: "gitlab", Type: "gitlab"})
+			group.Spec.GroupParams = []usernautdevv1alpha1.GroupParam{
+				{Backend: "gitlab", Name: "gitlab", Property: "project_access_paths", Value: []string{"team/project"}},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(err).NotTo(HaveOccurred())
+
+			group.Spec.GroupParams[0].Value = append(group.Spec.GroupParams[0].Value, "project")
+			_, err = validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.group_params[0].value[1]"))
+		})
+
+		It("should accept a single role override for any backend type", func() {
+			group.Spec.GroupParams = []usernautdevv1alpha1.GroupParam{
+				{Backend: "fivetran", Name: "fivetran", Property: "user_role", Value: []string{"Account Analyst"}},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(err).NotTo(HaveOccurred())
+
+			group.Spec.GroupParams[0].Value = append(group.Spec.GroupParams[0].Value, "Account Administrator")
+			_, err = validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.group_params[0].value"))
+		})
+
+		It("should reject a group that references itself", func() {
+			oldGroup := group.DeepCopy()
+			group.Spec.Members.Groups = append(group.Spec.Members.Groups, group.Name)
+			_, err := validator.ValidateUpdate(ctx, oldGroup, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.members.groups[1]"))
+		})
+
+		It("should reject a member role for a backend type the group doesn't use", func() {
+			group.Spec.Members.Roles = []usernautdevv1alpha1.MemberRole{
+				{User: "user1", Role: "maintainer", Backend: "gitlab"},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.members.roles[0].backend"))
+		})
+
+		It("should reject conflicting roles for the same member and backend", func() {
+			group.Spec.Members.Roles = []usernautdevv1alpha1.MemberRole{
+				{User: "user1", Role: "Account Analyst", Backend: "fivetran"},
+				{User: "user1", Role: "Account Administrator", Backend: "fivetran"},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.members.roles[1]"))
+		})
+
+		It("should reject a member role conflicting with the role a user is listed with", func() {
+			group.Spec.Members.UsersWithRoles = []usernautdevv1alpha1.MemberRole{
+				{User: "user2", Role: "Account Analyst", Backend: "fivetran"},
+			}
+			group.Spec.Members.Roles = []usernautdevv1alpha1.MemberRole{
+				{User: "user2", Role: "Account Administrator", Backend: "fivetran"},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.members.roles[0]"))
+		})
+
+		It("should reject an LDAP group that is not a valid DN", func() {
+			group.Spec.Members.LDAPGroups = []string{"cn=data-eng,ou=adhoc,dc=example,dc=com", "data-eng"}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.members.ldap_groups[1]"))
+		})
+
+		It("should reject a backend override for a backend not in spec.backends", func() {
+			group.Spec.BackendOverrides = []usernautdevv1alpha1.BackendOverride{
+				{Name: "prod", Type: "snowflake", ExcludeUsers: []string{"user1"}},
+			}
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.backend_overrides[0]"))
+		})
+
+		It("should require backends unless the group references a template", func() {
+			group.Spec.Backends = nil
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.backends"))
+
+			group.Spec.GroupName = ""
+			group.Spec.TemplateRef = "dataverse-template"
+			_, err = validator.ValidateCreate(ctx, group)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not block updates on a group that is being deleted", func() {
+			oldGroup := group.DeepCopy()
+			now := metav1.Now()
+			group.DeletionTimestamp = &now
+			group.Spec.GroupName = "unmatched-group"
+			_, err := validator.ValidateUpdate(ctx, oldGroup, group)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a group name already used by another group", func() {
+			existing := group.DeepCopy()
+			existing.Name = "dataverse-existing"
+			validator.Reader = &groupReader{groups: []usernautdevv1alpha1.Group{*existing}}
+
+			_, err := validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("already used by Group dataverse-existing"))
+
+			By("rejecting a rename to the group name")
+			oldGroup := group.DeepCopy()
+			oldGroup.Spec.GroupName = "dataverse-renamed"
+			_, err = validator.ValidateUpdate(ctx, oldGroup, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+			By("admitting updates which keep the group name")
+			_, err = validator.ValidateUpdate(ctx, group.DeepCopy(), group)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only let the approvers approve a group, naming themselves", func() {
+			validator.AppConfig.ControllerConfig.Approval = config.ApprovalConfig{
+				RequireForNewGroups: true,
+				Approvers:           []string{"jsmith"},
+				ApproverGroups:      []string{"usernaut-approvers"},
+			}
+			requestBy := func(username string, groups ...string) context.Context {
+				return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: username, Groups: groups},
+				}})
+			}
+			oldGroup := group.DeepCopy()
+			group.SetAnnotations(map[string]string{constants.ApprovedByAnnotation: "jsmith"})
+
+			_, err := validator.ValidateUpdate(requestBy("jsmith"), oldGroup, group)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("rejecting a user who is not an approver")
+			_, err = validator.ValidateUpdate(requestBy("alice"), oldGroup, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("alice is not allowed to approve groups"))
+
+			By("rejecting an approver naming another user")
+			_, err = validator.ValidateUpdate(requestBy("alice", "usernaut-approvers"), oldGroup, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("must name the approver alice"))
+
+			By("rejecting an approval without a known user")
+			_, err = validator.ValidateCreate(ctx, group)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+			By("admitting the removal and the updates keeping the annotation")
+			_, err = validator.ValidateUpdate(requestBy("usernaut"), group.DeepCopy(), oldGroup)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(requestBy("alice"), group.DeepCopy(), group)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+// groupReader lists Group CRs from memory, filtered by the group name index
+type groupReader struct {
+	groups []usernautdevv1alpha1.Group
+}
+
+func (r *groupReader) Get(_ context.Context, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return apierrors.NewNotFound(schema.GroupResource{Resource: "groups"}, key.Name)
+}
+
+func (r *groupReader) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	groupList := list.(*usernautdevv1alpha1.GroupList)
+	for _, group := range r.groups {
+		if listOpts.FieldSelector.Matches(fields.Set{controllerutils.GroupNameIndexField: group.Spec.GroupName}) {
+			groupList.Items = append(groupList.Items, group)
+		}
+	}
+	return nil
+}
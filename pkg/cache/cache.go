@@ -44,6 +44,23 @@ type Cache interface {
 	Delete(ctx context.Context, key string) error
 }
 
+// Locker guards the cache entries shared by the controllers and jobs. A *sync.RWMutex guards them
+// within the process, a *redis.Mutex also between the replicas of a sharded deployment.
+type Locker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+// LeaseLocker is a Locker whose lock is leased, the lease of a lock held by a process that can't renew
+// it in time expires while held
+type LeaseLocker interface {
+	Locker
+	// LostLeases returns how many leases of the lock expired while held
+	LostLeases() uint64
+}
+
 // Config is the configuration for the cache client
 type Config struct {
 	// Driver is the type of cache client
@@ -101,3 +101,68 @@ func TestRedisCacheGetByPattern(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(values))
 }
+
+func TestMutex_SharedBetweenProcesses(t *testing.T) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Error starting miniredis server: %v", err)
+	}
+	defer srv.Close()
+
+	first, err := NewCache(&Config{Host: srv.Host(), Port: srv.Port()})
+	assert.Nil(t, err)
+	second, err := NewCache(&Config{Host: srv.Host(), Port: srv.Port()})
+	assert.Nil(t, err)
+	firstMutex := first.NewMutex("usernaut:lock", time.Minute)
+	secondMutex := second.NewMutex("usernaut:lock", time.Minute)
+
+	// the readers of a process share the lock
+	firstMutex.RLock()
+	firstMutex.RLock()
+	assert.True(t, srv.Exists("usernaut:lock"))
+
+	locked := make(chan struct{})
+	go func() {
+		secondMutex.Lock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("Expected the lock of another process to wait for the readers")
+	case <-time.After(3 * mutexRetryInterval):
+	}
+
+	firstMutex.RUnlock()
+	assert.True(t, srv.Exists("usernaut:lock"), "Expected the lock to be held until the last reader unlocks it")
+	firstMutex.RUnlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the lock to be acquired once released by the other process")
+	}
+
+	secondMutex.Unlock()
+	assert.False(t, srv.Exists("usernaut:lock"))
+}
+
+func TestMutex_LostLease(t *testing.T) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Error starting miniredis server: %v", err)
+	}
+	defer srv.Close()
+
+	cache, err := NewCache(&Config{Host: srv.Host(), Port: srv.Port()})
+	assert.Nil(t, err)
+	mutex := cache.NewMutex("usernaut:lock", 300*time.Millisecond)
+
+	mutex.Lock()
+	assert.Equal(t, uint64(0), mutex.LostLeases())
+
+	// the lease expires while the lock is held
+	srv.Del("usernaut:lock")
+	assert.Eventually(t, func() bool { return mutex.LostLeases() == 1 }, time.Second, 10*time.Millisecond)
+
+	mutex.Unlock()
+	assert.Equal(t, uint64(1), mutex.LostLeases())
+}
@@ -0,0 +1,169 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// mutexRetryInterval is the wait before trying again to acquire a lock held by another process
+	mutexRetryInterval = 100 * time.Millisecond
+)
+
+var (
+	// refreshScript extends the lease of the lock while the process still holds it
+	refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	// releaseScript releases the lock only if the process still holds it
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// Mutex is a read-write mutex shared by the processes using the same redis server. The processes
+// hold the lock in turn, while the goroutines of a process share it as a sync.RWMutex: the lock is
+// acquired in redis by the first goroutine of the process locking it, and released by the last.
+// The lock is leased for ttl and renewed while held, so that a process exiting abruptly doesn't
+// hold it forever. A lease that expires while held, e.g. when redis is unreachable for longer than
+// ttl, is counted by LostLeases, so the holders can fail instead of trusting the entries they guard.
+type Mutex struct {
+	client redis.UniversalClient
+	key    string
+	ttl    time.Duration
+
+	local sync.RWMutex
+
+	// mu guards the holders of the process and the lease
+	mu      sync.Mutex
+	holders int
+	token   string
+	stop    chan struct{}
+
+	lostLeases atomic.Uint64
+}
+
+// NewMutex returns the mutex stored at the key, leased for ttl
+func (rc *RedisCache) NewMutex(key string, ttl time.Duration) *Mutex {
+	return &Mutex{client: rc.client, key: key, ttl: ttl}
+}
+
+// Lock locks the mutex for writing, in the process and in redis
+func (m *Mutex) Lock() {
+	m.local.Lock()
+	m.acquire()
+}
+
+// Unlock unlocks the mutex locked for writing
+func (m *Mutex) Unlock() {
+	m.release()
+	m.local.Unlock()
+}
+
+// RLock locks the mutex for reading, the readers of the process share the lock held in redis
+func (m *Mutex) RLock() {
+	m.local.RLock()
+	m.acquire()
+}
+
+// RUnlock unlocks the mutex locked for reading
+func (m *Mutex) RUnlock() {
+	m.release()
+	m.local.RUnlock()
+}
+
+// acquire holds the lock in redis for the process, waiting until the other processes release it.
+// Errors of the redis server are retried, as the callers of a mutex can't handle them.
+func (m *Mutex) acquire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.holders++
+	if m.holders > 1 {
+		return
+	}
+
+	ctx := context.Background()
+	token := newToken()
+	for {
+		acquired, err := m.client.SetNX(ctx, m.key, token, m.ttl).Result()
+		if err != nil {
+			logger.Logger(ctx).WithError(err).WithField("key", m.key).Warn("failed to acquire the redis lock, retrying")
+		}
+		if acquired {
+			break
+		}
+		time.Sleep(mutexRetryInterval)
+	}
+	m.token = token
+	m.stop = make(chan struct{})
+	go m.refresh(token, m.stop)
+}
+
+// release releases the lock in redis once the last goroutine of the process unlocked it
+func (m *Mutex) release() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.holders--
+	if m.holders > 0 {
+		return
+	}
+
+	close(m.stop)
+	ctx := context.Background()
+	if err := releaseScript.Run(ctx, m.client, []string{m.key}, m.token).Err(); err != nil &&
+		!errors.Is(err, redis.Nil) {
+		// the lease expires on its own
+		logger.Logger(ctx).WithError(err).WithField("key", m.key).Warn("failed to release the redis lock")
+	}
+	m.token = ""
+}
+
+// refresh renews the lease of the lock until stopped, or until the lease expired and another process
+// may hold the lock
+func (m *Mutex) refresh(token string, stop <-chan struct{}) {
+	ticker := time.NewTicker(m.ttl / 3)
+	defer ticker.Stop()
+
+	ctx := context.Background()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			renewed, err := refreshScript.Run(ctx, m.client, []string{m.key}, token, m.ttl.Milliseconds()).Int()
+			if err != nil {
+				logger.Logger(ctx).WithError(err).WithField("key", m.key).Warn("failed to renew the redis lock")
+			} else if renewed == 0 {
+				m.lostLeases.Add(1)
+				logger.Logger(ctx).WithField("key", m.key).Error("redis lock expired while held")
+				return
+			}
+		}
+	}
+}
+
+// LostLeases returns how many leases of the lock expired while the process held it
+func (m *Mutex) LostLeases() uint64 {
+	return m.lostLeases.Load()
+}
+
+// newToken returns a random token identifying the holder of a lock
+func newToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// airflowAPI is the stable REST API of Airflow 2 with the FAB auth manager. The teams are RBAC
+// roles, identified like the users by their names, and the members of a role are the users holding it.
+type airflowAPI struct {
+	aC *AirflowClient
+}
+
+func (a *airflowAPI) healthCheck(ctx context.Context) error {
+	var page airflowRoles
+	return a.aC.get(ctx, airflowAPIPath+"/roles?limit=1", &page, "backend.airflow.HealthCheck")
+}
+
+func (a *airflowAPI) forEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return a.forEachAirflowUser(ctx, "backend.airflow.FetchAllUsers", func(users []airflowUser) error {
+		page := make([]*structs.User, 0, len(users))
+		for _, u := range users {
+			page = append(page, airflowUserDetails(&u))
+		}
+		return fn(page)
+	})
+}
+
+func (a *airflowAPI) forEachAirflowUser(ctx context.Context, methodName string,
+	fn func(users []airflowUser) error) error {
+	return forEachPage(ctx, a.aC, airflowAPIPath+"/users", methodName,
+		func(page *airflowUsers) ([]airflowUser, int) { return page.Users, page.TotalEntries }, fn)
+}
+
+func (a *airflowAPI) fetchUser(ctx context.Context, userID string) (*structs.User, error) {
+	user, err := a.fetchAirflowUser(ctx, userID, "backend.airflow.FetchUserDetails")
+	if err != nil {
+		return nil, err
+	}
+	return airflowUserDetails(user), nil
+}
+
+func (a *airflowAPI) fetchAirflowUser(ctx context.Context, username string, methodName string) (*airflowUser, error) {
+	var user airflowUser
+	if err := a.aC.get(ctx, airflowAPIPath+"/users/"+url.PathEscape(username), &user, methodName); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// createUser creates the user with the default role and a random password, the users sign in
+// through the auth backend of the webserver (e.g. OAuth or LDAP)
+func (a *airflowAPI) createUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	password, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+	role := u.Role
+	if role == "" {
+		role = defaultAirflowUserRole
+	}
+	// Airflow requires a first name
+	firstName := u.FirstName
+	if firstName == "" {
+		firstName = u.UserName
+	}
+	resp, err := a.aC.sendRequest(ctx, airflowAPIPath+"/users", http.MethodPost, airflowUser{
+		Username:  u.UserName,
+		Email:     u.Email,
+		FirstName: firstName,
+		LastName:  u.LastName,
+		Roles:     []airflowName{{Name: role}},
+		Password:  password,
+	}, "backend.airflow.CreateUser")
+	if err != nil {
+		if request.IsConflict(err) {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		return nil, err
+	}
+	var created airflowUser
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	return airflowUserDetails(&created), nil
+}
+
+func (a *airflowAPI) deleteUser(ctx context.Context, userID string) error {
+	_, err := a.aC.sendRequest(ctx, airflowAPIPath+"/users/"+url.PathEscape(userID), http.MethodDelete, nil,
+		"backend.airflow.DeleteUser")
+	return err
+}
+
+func (a *airflowAPI) forEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return forEachPage(ctx, a.aC, airflowAPIPath+"/roles", "backend.airflow.FetchAllTeams",
+		func(page *airflowRoles) ([]airflowRole, int) { return page.Roles, page.TotalEntries },
+		func(roles []airflowRole) error {
+			page := make([]structs.Team, 0, len(roles))
+			for _, role := range roles {
+				page = append(page, structs.Team{ID: role.Name, Name: role.Name})
+			}
+			return fn(page)
+		})
+}
+
+func (a *airflowAPI) fetchTeam(ctx context.Context, teamID string) (*structs.Team, error) {
+	role, err := a.fetchRole(ctx, teamID, "backend.airflow.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	return &structs.Team{ID: role.Name, Name: role.Name}, nil
+}
+
+func (a *airflowAPI) fetchRole(ctx context.Context, name string, methodName string) (*airflowRole, error) {
+	var role airflowRole
+	if err := a.aC.get(ctx, airflowAPIPath+"/roles/"+url.PathEscape(name), &role, methodName); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// createTeam creates the role without permissions, they are granted by the dag_permissions group param
+func (a *airflowAPI) createTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	if _, err := a.aC.sendRequest(ctx, airflowAPIPath+"/roles", http.MethodPost,
+		airflowRole{Name: team.Name, Actions: []airflowPermission{}}, "backend.airflow.CreateTeam"); err != nil {
+		return nil, err
+	}
+	return &structs.Team{ID: team.Name, Name: team.Name}, nil
+}
+
+func (a *airflowAPI) deleteTeam(ctx context.Context, teamID string) error {
+	_, err := a.aC.sendRequest(ctx, airflowAPIPath+"/roles/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.airflow.DeleteTeamByID")
+	return err
+}
+
+// fetchMembers lists the users holding the role, Airflow has no endpoint listing the users of a role
+func (a *airflowAPI) fetchMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	members := make(map[string]*structs.User)
+	err := a.forEachAirflowUser(ctx, "backend.airflow.FetchTeamMembersByTeamID", func(users []airflowUser) error {
+		for _, u := range users {
+			if slices.Contains(u.Roles, airflowName{Name: teamID}) {
+				members[u.Username] = airflowUserDetails(&u)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (a *airflowAPI) addMembers(ctx context.Context, teamID string, userIDs []string) error {
+	return a.updateRoles(ctx, userIDs, "backend.airflow.AddUserToTeam", func(roles []airflowName) []airflowName {
+		if slices.Contains(roles, airflowName{Name: teamID}) {
+			return nil
+		}
+		return append(roles, airflowName{Name: teamID})
+	})
+}
+
+func (a *airflowAPI) removeMembers(ctx context.Context, teamID string, userIDs []string) error {
+	return a.updateRoles(ctx, userIDs, "backend.airflow.RemoveUserFromTeam", func(roles []airflowName) []airflowName {
+		if !slices.Contains(roles, airflowName{Name: teamID}) {
+			return nil
+		}
+		return slices.DeleteFunc(roles, func(role airflowName) bool { return role.Name == teamID })
+	})
+}
+
+// updateRoles updates the roles of the users one at a time, update returns the new roles of a
+// user or nil to leave it as is. A user which no longer exists is skipped.
+func (a *airflowAPI) updateRoles(ctx context.Context, userIDs []string, methodName string,
+	update func(roles []airflowName) []airflowName) error {
+
+	for _, userID := range userIDs {
+		user, err := a.fetchAirflowUser(ctx, userID, methodName)
+		if request.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch user %s: %w", userID, err)
+		}
+		roles := update(slices.Clone(user.Roles))
+		if roles == nil {
+			continue
+		}
+		user.Roles = roles
+		if _, err := a.aC.sendRequest(ctx, airflowAPIPath+"/users/"+url.PathEscape(userID)+"?update_mask=roles",
+			http.MethodPatch, user, methodName); err != nil {
+			return fmt.Errorf("failed to update the roles of user %s: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// reconcileGroupParams grants the role exactly the DAG-level permissions of the dag_permissions
+// group param, the permissions of the role on the other resources are left as is
+func (a *airflowAPI) reconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	if groupParams.Property != groupParamDagPermissions {
+		return fmt.Errorf("unsupported airflow group param: %s", groupParams.Property)
+	}
+
+	var granted []airflowPermission
+	for _, value := range groupParams.Value {
+		dagID, access, err := parseDagPermission(value)
+		if err != nil {
+			return err
+		}
+		for _, action := range dagAccessActions[access] {
+			permission := airflowPermission{
+				Action:   airflowName{Name: action},
+				Resource: airflowName{Name: dagResourcePrefix + dagID},
+			}
+			if !slices.Contains(granted, permission) {
+				granted = append(granted, permission)
+			}
+		}
+	}
+
+	role, err := a.fetchRole(ctx, teamID, "backend.airflow.ReconcileGroupParams")
+	if err != nil {
+		return err
+	}
+	actions := make([]airflowPermission, 0, len(role.Actions)+len(granted))
+	var current []airflowPermission
+	for _, permission := range role.Actions {
+		if strings.HasPrefix(permission.Resource.Name, dagResourcePrefix) {
+			current = append(current, permission)
+		} else {
+			actions = append(actions, permission)
+		}
+	}
+	if sameDagPermissions(current, granted) {
+		return nil
+	}
+
+	role.Actions = append(actions, granted...)
+	_, err = a.aC.sendRequest(ctx, airflowAPIPath+"/roles/"+url.PathEscape(teamID)+"?update_mask=actions",
+		http.MethodPatch, role, "backend.airflow.ReconcileGroupParams")
+	return err
+}
+
+// sameDagPermissions reports whether the permissions hold the same actions on the same DAGs
+func sameDagPermissions(a, b []airflowPermission) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, permission := range a {
+		if !slices.Contains(b, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// airflowUserDetails converts the Airflow user, its username is its ID
+func airflowUserDetails(u *airflowUser) *structs.User {
+	return &structs.User{
+		ID:          u.Username,
+		UserName:    u.Username,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		DisplayName: strings.TrimSpace(u.FirstName + " " + u.LastName),
+	}
+}
+
+// randomPassword generates the password of a new user, which signs in through the auth backend
+// of the webserver instead
+func randomPassword() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate airflow user password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
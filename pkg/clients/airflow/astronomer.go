@@ -0,0 +1,271 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// Entity types of the roles of an Astro team
+const (
+	entityWorkspace  = "WORKSPACE"
+	entityDeployment = "DEPLOYMENT"
+)
+
+// astronomerAPI is the IAM API of an Astro organization. The users, identified by their ID, are
+// invited to the organization and the teams are granted roles on the workspaces.
+type astronomerAPI struct {
+	aC *AirflowClient
+	// path is the path of the organization in the IAM API
+	path string
+}
+
+func (a *astronomerAPI) healthCheck(ctx context.Context) error {
+	var organization struct {
+		ID string `json:"id"`
+	}
+	return a.aC.get(ctx, a.path, &organization, "backend.airflow.HealthCheck")
+}
+
+func (a *astronomerAPI) forEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachPage(ctx, a.aC, a.path+"/users", "backend.airflow.FetchAllUsers",
+		func(page *astroUsers) ([]astroUser, int) { return page.Users, page.TotalCount },
+		func(users []astroUser) error {
+			page := make([]*structs.User, 0, len(users))
+			for _, u := range users {
+				page = append(page, astroUserDetails(&u))
+			}
+			return fn(page)
+		})
+}
+
+func (a *astronomerAPI) fetchUser(ctx context.Context, userID string) (*structs.User, error) {
+	var user astroUser
+	if err := a.aC.get(ctx, a.path+"/users/"+url.PathEscape(userID), &user,
+		"backend.airflow.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return astroUserDetails(&user), nil
+}
+
+// createUser invites the user to the organization with the default organization role, the user
+// created for the invite can join the teams before accepting it. A user who is already a member
+// of the organization is reported as existing.
+func (a *astronomerAPI) createUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	if u.Email == "" {
+		return nil, fmt.Errorf("astronomer users are invited by their email, user %s has none", u.UserName)
+	}
+	role := u.Role
+	if role == "" {
+		role = defaultAstronomerUserRole
+	}
+	resp, err := a.aC.sendRequest(ctx, a.path+"/invites", http.MethodPost,
+		astroInvite{InviteeEmail: u.Email, Role: role}, "backend.airflow.CreateUser")
+	if err != nil {
+		if request.IsConflict(err) {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		return nil, err
+	}
+	var invite astroInviteResponse
+	if err := decode(resp, &invite); err != nil {
+		return nil, err
+	}
+	userID := invite.UserID
+	if userID == "" {
+		userID = invite.Invitee.ID
+	}
+	if userID == "" {
+		return nil, errors.New("no user ID in the astronomer invite response")
+	}
+	return &structs.User{ID: userID, UserName: u.Email, Email: u.Email, DisplayName: u.DisplayName}, nil
+}
+
+// deleteUser removes the user from the organization, with its roles and team memberships
+func (a *astronomerAPI) deleteUser(ctx context.Context, userID string) error {
+	_, err := a.aC.sendRequest(ctx, a.path+"/users/"+url.PathEscape(userID), http.MethodDelete, nil,
+		"backend.airflow.DeleteUser")
+	return err
+}
+
+func (a *astronomerAPI) forEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return forEachPage(ctx, a.aC, a.path+"/teams", "backend.airflow.FetchAllTeams",
+		func(page *astroTeams) ([]astroTeam, int) { return page.Teams, page.TotalCount },
+		func(teams []astroTeam) error {
+			page := make([]structs.Team, 0, len(teams))
+			for _, team := range teams {
+				page = append(page, structs.Team{ID: team.ID, Name: team.Name, Description: team.Description})
+			}
+			return fn(page)
+		})
+}
+
+func (a *astronomerAPI) fetchTeam(ctx context.Context, teamID string) (*structs.Team, error) {
+	team, err := a.fetchAstroTeam(ctx, teamID, "backend.airflow.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	return &structs.Team{ID: team.ID, Name: team.Name, Description: team.Description}, nil
+}
+
+func (a *astronomerAPI) fetchAstroTeam(ctx context.Context, teamID string, methodName string) (*astroTeam, error) {
+	var team astroTeam
+	if err := a.aC.get(ctx, a.path+"/teams/"+url.PathEscape(teamID), &team, methodName); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// createTeam creates the team with the organization member role, the workspace roles are granted
+// by the workspace_roles group param
+func (a *astronomerAPI) createTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	resp, err := a.aC.sendRequest(ctx, a.path+"/teams", http.MethodPost, astroTeam{
+		Name:             team.Name,
+		Description:      team.Description,
+		OrganizationRole: defaultAstronomerUserRole,
+	}, "backend.airflow.CreateTeam")
+	if err != nil {
+		return nil, err
+	}
+	var created astroTeam
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no team ID in the astronomer create team response")
+	}
+	return &structs.Team{ID: created.ID, Name: created.Name, Description: created.Description}, nil
+}
+
+func (a *astronomerAPI) deleteTeam(ctx context.Context, teamID string) error {
+	_, err := a.aC.sendRequest(ctx, a.path+"/teams/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.airflow.DeleteTeamByID")
+	return err
+}
+
+func (a *astronomerAPI) fetchMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	members := make(map[string]*structs.User)
+	err := forEachPage(ctx, a.aC, a.path+"/teams/"+url.PathEscape(teamID)+"/members",
+		"backend.airflow.FetchTeamMembersByTeamID",
+		func(page *astroTeamMembers) ([]astroTeamMember, int) { return page.TeamMembers, page.TotalCount },
+		func(teamMembers []astroTeamMember) error {
+			for _, member := range teamMembers {
+				members[member.UserID] = &structs.User{
+					ID:          member.UserID,
+					UserName:    member.Username,
+					Email:       member.Username,
+					DisplayName: member.FullName,
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// addMembers adds the users to the team in one request
+func (a *astronomerAPI) addMembers(ctx context.Context, teamID string, userIDs []string) error {
+	_, err := a.aC.sendRequest(ctx, a.path+"/teams/"+url.PathEscape(teamID)+"/members", http.MethodPost,
+		astroMembers{MemberIDs: userIDs}, "backend.airflow.AddUserToTeam")
+	return err
+}
+
+// removeMembers removes the users from the team one at a time, skipping the 404 Astronomer answers
+// for a user who already left it
+func (a *astronomerAPI) removeMembers(ctx context.Context, teamID string, userIDs []string) error {
+	for _, userID := range userIDs {
+		_, err := a.aC.sendRequest(ctx, a.path+"/teams/"+url.PathEscape(teamID)+"/members/"+url.PathEscape(userID),
+			http.MethodDelete, nil, "backend.airflow.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// reconcileGroupParams grants the team exactly the workspace roles of the workspace_roles group
+// param. The roles of a team are replaced as a whole, so its organization role and deployment roles
+// are sent back as they are.
+func (a *astronomerAPI) reconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	if groupParams.Property != groupParamWorkspaceRoles {
+		return fmt.Errorf("unsupported astronomer group param: %s", groupParams.Property)
+	}
+
+	granted := make([]astroWorkspaceRole, 0, len(groupParams.Value))
+	for _, value := range groupParams.Value {
+		workspaceID, role, err := parseWorkspaceRole(value)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(granted, func(r astroWorkspaceRole) bool { return r.WorkspaceID == workspaceID }) {
+			granted = append(granted, astroWorkspaceRole{WorkspaceID: workspaceID, Role: role})
+		}
+	}
+
+	team, err := a.fetchAstroTeam(ctx, teamID, "backend.airflow.ReconcileGroupParams")
+	if err != nil {
+		return err
+	}
+	roles := astroTeamRoles{OrganizationRole: team.OrganizationRole, WorkspaceRoles: []astroWorkspaceRole{}}
+	if roles.OrganizationRole == "" {
+		roles.OrganizationRole = defaultAstronomerUserRole
+	}
+	var current []astroWorkspaceRole
+	for _, role := range team.Roles {
+		switch role.EntityType {
+		case entityWorkspace:
+			current = append(current, astroWorkspaceRole{WorkspaceID: role.EntityID, Role: role.Role})
+		case entityDeployment:
+			roles.DeploymentRoles = append(roles.DeploymentRoles,
+				astroDeploymentRole{DeploymentID: role.EntityID, Role: role.Role})
+		}
+	}
+	if len(current) == len(granted) && !slices.ContainsFunc(current, func(r astroWorkspaceRole) bool {
+		return !slices.Contains(granted, r)
+	}) {
+		return nil
+	}
+
+	roles.WorkspaceRoles = append(roles.WorkspaceRoles, granted...)
+	_, err = a.aC.sendRequest(ctx, a.path+"/teams/"+url.PathEscape(teamID)+"/roles", http.MethodPost, roles,
+		"backend.airflow.ReconcileGroupParams")
+	return err
+}
+
+// astroUserDetails converts the Astro user, its username is its email
+func astroUserDetails(u *astroUser) *structs.User {
+	user := &structs.User{
+		ID:          u.ID,
+		UserName:    u.Username,
+		DisplayName: u.FullName,
+	}
+	if strings.Contains(u.Username, "@") {
+		user.Email = u.Username
+	}
+	return user
+}
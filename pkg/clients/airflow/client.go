@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// AirflowClient manages the RBAC roles of an Airflow deployment and their DAG-level permissions,
+// or the teams of an Astronomer organization and their workspace roles
+type AirflowClient struct {
+	client  heimdall.Doer
+	url     string
+	headers map[string]string
+	// api is the API of the flavor of the backend
+	api flavor
+}
+
+// flavor is the API of Airflow or of Astronomer
+type flavor interface {
+	healthCheck(ctx context.Context) error
+	forEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error
+	fetchUser(ctx context.Context, userID string) (*structs.User, error)
+	createUser(ctx context.Context, u *structs.User) (*structs.User, error)
+	deleteUser(ctx context.Context, userID string) error
+	forEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error
+	fetchTeam(ctx context.Context, teamID string) (*structs.Team, error)
+	createTeam(ctx context.Context, team *structs.Team) (*structs.Team, error)
+	deleteTeam(ctx context.Context, teamID string) error
+	fetchMembers(ctx context.Context, teamID string) (map[string]*structs.User, error)
+	addMembers(ctx context.Context, teamID string, userIDs []string) error
+	removeMembers(ctx context.Context, teamID string, userIDs []string) error
+	reconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error
+}
+
+func NewClient(airflowAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*AirflowClient, error) {
+
+	airflowConfig := AirflowConfig{}
+	if err := utils.MapToStruct(airflowAppConfig, &airflowConfig); err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       "application/json",
+	}
+	baseURL := strings.TrimSuffix(airflowConfig.URL, "/")
+	switch airflowConfig.Flavor {
+	case "", FlavorAirflow:
+		if baseURL == "" || airflowConfig.Username == "" || airflowConfig.Password == "" {
+			return nil, errors.New("airflow configuration is missing required fields: url, username or password")
+		}
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString(
+			[]byte(airflowConfig.Username+":"+airflowConfig.Password))
+	case FlavorAstronomer:
+		if airflowConfig.OrganizationID == "" || airflowConfig.Token == "" {
+			return nil, errors.New("astronomer configuration is missing required fields: organization_id or token")
+		}
+		if baseURL == "" {
+			baseURL = defaultAstronomerURL
+		}
+		headers["Authorization"] = "Bearer " + airflowConfig.Token
+	default:
+		return nil, fmt.Errorf("invalid airflow flavor %q, supported: %s, %s", airflowConfig.Flavor,
+			FlavorAirflow, FlavorAstronomer)
+	}
+
+	client, err := httpclient.InitializeClient(
+		"airflow_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	airflowClient := &AirflowClient{
+		client:  client,
+		url:     baseURL,
+		headers: headers,
+	}
+	if airflowConfig.Flavor == FlavorAstronomer {
+		airflowClient.api = &astronomerAPI{
+			aC:   airflowClient,
+			path: "/iam/v1beta1/organizations/" + airflowConfig.OrganizationID,
+		}
+	} else {
+		airflowClient.api = &airflowAPI{aC: airflowClient}
+	}
+	return airflowClient, nil
+}
+
+// HealthCheck lists a single role of Airflow, or fetches the organization on Astronomer
+func (aC *AirflowClient) HealthCheck(ctx context.Context) error {
+	if err := aC.api.healthCheck(ctx); err != nil {
+		return fmt.Errorf("airflow health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path and decodes its response
+func (aC *AirflowClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := aC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of an Airflow or Astronomer request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode airflow response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the API and returns the response body, any response
+// code other than 2xx is returned as a *request.ResponseError
+func (aC *AirflowClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, aC.client, method, aC.url+path, body, aC.headers, methodName,
+		"airflow", errorMessage)
+	return resp, err
+}
+
+// forEachPage calls fn with each page of the resources of the path, both APIs page with an offset
+// and a limit and return the total count of the resources
+func forEachPage[P any, T any](ctx context.Context, aC *AirflowClient, path string, methodName string,
+	resources func(page *P) ([]T, int), fn func(resources []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	for offset := 0; ; {
+		var page P
+		if err := aC.get(ctx, fmt.Sprintf("%s%slimit=%d&offset=%d", path, separator, pageSize, offset), &page,
+			methodName); err != nil {
+			return err
+		}
+		values, total := resources(&page)
+		if len(values) == 0 {
+			return nil
+		}
+		if err := fn(values); err != nil {
+			return err
+		}
+		offset += len(values)
+		if offset >= total {
+			return nil
+		}
+	}
+}
+
+// errorMessage returns the message of an error response, the problem details of Airflow or the
+// message of Astronomer
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	for _, message := range []string{errResp.Detail, errResp.Message, errResp.Title} {
+		if message != "" {
+			return "", message
+		}
+	}
+	return "", ""
+}
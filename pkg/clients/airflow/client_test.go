@@ -0,0 +1,469 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeAirflow is the stable REST API of Airflow holding its users and roles in memory
+type fakeAirflow struct {
+	users []*airflowUser
+	roles []*airflowRole
+}
+
+func (f *fakeAirflow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if user, password, _ := r.BasicAuth(); user != "admin" || password != "admin" {
+		writeProblem(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	switch {
+	case r.Method == http.MethodGet && path == "/users":
+		users, total := offsetPage(r, f.users)
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]any{"users": users, "total_entries": total})
+	case r.Method == http.MethodPost && path == "/users":
+		var user airflowUser
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		if user.FirstName == "" || user.Password == "" {
+			writeProblem(w, http.StatusBadRequest, "first_name and password are required")
+			return
+		}
+		if slices.ContainsFunc(f.users, func(u *airflowUser) bool { return u.Username == user.Username }) {
+			writeProblem(w, http.StatusConflict, fmt.Sprintf("Username `%s` already exists. Use PATCH to update.",
+				user.Username))
+			return
+		}
+		f.users = append(f.users, &user)
+		user.Password = ""
+		fakehttp.WriteJSON(w, http.StatusOK, user)
+	case strings.HasPrefix(path, "/users/"):
+		name := strings.TrimPrefix(path, "/users/")
+		index := slices.IndexFunc(f.users, func(u *airflowUser) bool { return u.Username == name })
+		if index < 0 {
+			writeProblem(w, http.StatusNotFound, "The User with username `x` was not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			fakehttp.WriteJSON(w, http.StatusOK, f.users[index])
+		case http.MethodPatch:
+			var user airflowUser
+			_ = json.NewDecoder(r.Body).Decode(&user)
+			if r.URL.Query().Get("update_mask") == "roles" {
+				f.users[index].Roles = user.Roles
+			}
+			fakehttp.WriteJSON(w, http.StatusOK, f.users[index])
+		case http.MethodDelete:
+			f.users = slices.Delete(f.users, index, index+1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case r.Method == http.MethodGet && path == "/roles":
+		roles, total := offsetPage(r, f.roles)
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]any{"roles": roles, "total_entries": total})
+	case r.Method == http.MethodPost && path == "/roles":
+		var role airflowRole
+		_ = json.NewDecoder(r.Body).Decode(&role)
+		f.roles = append(f.roles, &role)
+		fakehttp.WriteJSON(w, http.StatusOK, role)
+	case strings.HasPrefix(path, "/roles/"):
+		name := strings.TrimPrefix(path, "/roles/")
+		index := slices.IndexFunc(f.roles, func(role *airflowRole) bool { return role.Name == name })
+		if index < 0 {
+			writeProblem(w, http.StatusNotFound, "The Role with name `x` was not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			fakehttp.WriteJSON(w, http.StatusOK, f.roles[index])
+		case http.MethodPatch:
+			var role airflowRole
+			_ = json.NewDecoder(r.Body).Decode(&role)
+			if r.URL.Query().Get("update_mask") == "actions" {
+				f.roles[index].Actions = role.Actions
+			}
+			fakehttp.WriteJSON(w, http.StatusOK, f.roles[index])
+		case http.MethodDelete:
+			f.roles = slices.Delete(f.roles, index, index+1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		writeProblem(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// fakeAstronomer is the IAM API of an Astro organization holding its users and teams in memory
+type fakeAstronomer struct {
+	users   []astroUser
+	teams   []*astroTeam
+	members map[string][]string
+	// roles are the last roles set on the teams by ID
+	roles map[string]astroTeamRoles
+}
+
+func (f *fakeAstronomer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"message": "invalid api token", "statusCode": 401}`)
+		return
+	}
+
+	path, found := strings.CutPrefix(r.URL.Path, "/iam/v1beta1/organizations/org-1")
+	switch {
+	case !found:
+		writeMessage(w, http.StatusNotFound, "organization not found")
+	case r.Method == http.MethodGet && path == "":
+		_, _ = io.WriteString(w, `{"id": "org-1", "name": "Example"}`)
+	case r.Method == http.MethodGet && path == "/users":
+		users, total := offsetPage(r, f.users)
+		fakehttp.WriteJSON(w, http.StatusOK, astroUsers{Users: users, TotalCount: total})
+	case r.Method == http.MethodPost && path == "/invites":
+		var invite astroInvite
+		_ = json.NewDecoder(r.Body).Decode(&invite)
+		if slices.ContainsFunc(f.users, func(u astroUser) bool { return u.Username == invite.InviteeEmail }) {
+			writeMessage(w, http.StatusConflict, "user is already a member of the organization")
+			return
+		}
+		user := astroUser{ID: fmt.Sprintf("usr-%d", len(f.users)+1), Username: invite.InviteeEmail, Status: "PENDING"}
+		f.users = append(f.users, user)
+		fakehttp.WriteJSON(w, http.StatusOK, astroInviteResponse{InviteID: "inv-1", UserID: user.ID})
+	case strings.HasPrefix(path, "/users/"):
+		index := slices.IndexFunc(f.users, func(u astroUser) bool { return u.ID == strings.TrimPrefix(path, "/users/") })
+		switch {
+		case index < 0:
+			writeMessage(w, http.StatusNotFound, "user not found")
+		case r.Method == http.MethodGet:
+			fakehttp.WriteJSON(w, http.StatusOK, f.users[index])
+		case r.Method == http.MethodDelete:
+			f.users = slices.Delete(f.users, index, index+1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case r.Method == http.MethodGet && path == "/teams":
+		teams, total := offsetPage(r, f.teams)
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]any{"teams": teams, "totalCount": total})
+	case r.Method == http.MethodPost && path == "/teams":
+		var team astroTeam
+		_ = json.NewDecoder(r.Body).Decode(&team)
+		team.ID = fmt.Sprintf("team-%d", len(f.teams)+1)
+		f.teams = append(f.teams, &team)
+		fakehttp.WriteJSON(w, http.StatusOK, team)
+	case strings.HasPrefix(path, "/teams/"):
+		f.serveTeam(w, r, strings.Split(strings.TrimPrefix(path, "/teams/"), "/"))
+	default:
+		writeMessage(w, http.StatusNotFound, "not found")
+	}
+}
+
+// serveTeam serves the requests on a team, its members and its roles
+func (f *fakeAstronomer) serveTeam(w http.ResponseWriter, r *http.Request, parts []string) {
+	index := slices.IndexFunc(f.teams, func(team *astroTeam) bool { return team.ID == parts[0] })
+	if index < 0 {
+		writeMessage(w, http.StatusNotFound, "team not found")
+		return
+	}
+	team := f.teams[index]
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		fakehttp.WriteJSON(w, http.StatusOK, team)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		f.teams = slices.Delete(f.teams, index, index+1)
+		w.WriteHeader(http.StatusNoContent)
+	case parts[1] == "members" && len(parts) == 2 && r.Method == http.MethodGet:
+		var members []astroTeamMember
+		for _, userID := range f.members[team.ID] {
+			members = append(members, astroTeamMember{UserID: userID, Username: userID + "@example.com"})
+		}
+		members, total := offsetPage(r, members)
+		fakehttp.WriteJSON(w, http.StatusOK, astroTeamMembers{TeamMembers: members, TotalCount: total})
+	case parts[1] == "members" && len(parts) == 2 && r.Method == http.MethodPost:
+		var add astroMembers
+		_ = json.NewDecoder(r.Body).Decode(&add)
+		f.members[team.ID] = append(f.members[team.ID], add.MemberIDs...)
+		w.WriteHeader(http.StatusNoContent)
+	case parts[1] == "members" && r.Method == http.MethodDelete:
+		if !slices.Contains(f.members[team.ID], parts[2]) {
+			writeMessage(w, http.StatusNotFound, "team member not found")
+			return
+		}
+		f.members[team.ID] = slices.DeleteFunc(f.members[team.ID], func(id string) bool { return id == parts[2] })
+		w.WriteHeader(http.StatusNoContent)
+	case parts[1] == "roles" && r.Method == http.MethodPost:
+		var roles astroTeamRoles
+		_ = json.NewDecoder(r.Body).Decode(&roles)
+		f.roles[team.ID] = roles
+		team.OrganizationRole = roles.OrganizationRole
+		team.Roles = nil
+		for _, role := range roles.WorkspaceRoles {
+			team.Roles = append(team.Roles, astroEntityRole{EntityID: role.WorkspaceID, EntityType: entityWorkspace,
+				Role: role.Role})
+		}
+		for _, role := range roles.DeploymentRoles {
+			team.Roles = append(team.Roles, astroEntityRole{EntityID: role.DeploymentID, EntityType: entityDeployment,
+				Role: role.Role})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, team)
+	}
+}
+
+// offsetPage returns the page of the resources at the offset and limit of the request, and their count
+func offsetPage[T any](r *http.Request, resources []T) ([]T, int) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	start := min(offset, len(resources))
+	end := min(start+limit, len(resources))
+	return resources[start:end], len(resources)
+}
+
+func writeProblem(w http.ResponseWriter, statusCode int, detail string) {
+	fakehttp.WriteJSON(w, statusCode, errorResponse{Title: http.StatusText(statusCode), Detail: detail})
+}
+
+func writeMessage(w http.ResponseWriter, statusCode int, message string) {
+	fakehttp.WriteJSON(w, statusCode, errorResponse{Message: message})
+}
+
+func newTestClient(t *testing.T, handler http.Handler, connection map[string]interface{}) *AirflowClient {
+	t.Helper()
+	server := fakehttp.NewServer(t, handler)
+
+	connection["url"] = server.URL + "/"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func newAirflowClient(t *testing.T, fake *fakeAirflow) *AirflowClient {
+	return newTestClient(t, fake, map[string]interface{}{"username": "admin", "password": "admin"})
+}
+
+func newAstronomerClient(t *testing.T, fake *fakeAstronomer) *AirflowClient {
+	fake.members = map[string][]string{}
+	fake.roles = map[string]astroTeamRoles{}
+	return newTestClient(t, fake, map[string]interface{}{
+		"flavor": "astronomer", "organization_id": "org-1", "token": "token",
+	})
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"url": "https://airflow.example.com", "username": "admin"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "url, username or password")
+
+	_, err = NewClient(map[string]interface{}{"flavor": "astronomer", "token": "token"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "organization_id or token")
+
+	_, err = NewClient(map[string]interface{}{"flavor": "composer"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid airflow flavor")
+}
+
+func TestAirflowUsers(t *testing.T) {
+	fake := &fakeAirflow{}
+	for i := 1; i <= 150; i++ {
+		fake.users = append(fake.users, &airflowUser{Username: fmt.Sprintf("user%d", i),
+			Email: fmt.Sprintf("user%d@example.com", i)})
+	}
+	client := newAirflowClient(t, fake)
+
+	var pages []int
+	require.NoError(t, client.ForEachUserPage(context.Background(), func(users []*structs.User) error {
+		pages = append(pages, len(users))
+		return nil
+	}))
+	assert.Equal(t, []int{100, 50}, pages)
+	_, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "user150", byEmail["user150@example.com"].ID)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", user.ID)
+	assert.Equal(t, []airflowName{{Name: "Viewer"}}, fake.users[150].Roles)
+	assert.Equal(t, "jdoe", fake.users[150].FirstName)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+
+	user, err = client.CreateUser(context.Background(), &structs.User{UserName: "asmith", FirstName: "Alice",
+		Role: "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.DisplayName)
+	assert.Equal(t, []airflowName{{Name: "User"}}, fake.users[151].Roles)
+
+	require.NoError(t, client.DeleteUser(context.Background(), "jdoe"))
+	assert.NoError(t, client.DeleteUser(context.Background(), "jdoe"))
+	assert.Len(t, fake.users, 151)
+}
+
+func TestAirflowRoles(t *testing.T) {
+	fake := &fakeAirflow{
+		users: []*airflowUser{{Username: "jdoe", Roles: []airflowName{{Name: "Viewer"}}}, {Username: "asmith"}},
+		roles: []*airflowRole{{Name: "Viewer"}},
+	}
+	client := newAirflowClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "dataeng", Name: "dataeng"}, team)
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{
+		"Viewer": {ID: "Viewer", Name: "Viewer"}, "dataeng": {ID: "dataeng", Name: "dataeng"},
+	}, teams)
+
+	// the role is added to the roles of the users
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"jdoe", "asmith", "bwayne"}))
+	assert.Equal(t, []airflowName{{Name: "Viewer"}, {Name: "dataeng"}}, fake.users[0].Roles)
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"asmith"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, "jdoe", members["jdoe"].UserName)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+}
+
+func TestAirflowDagPermissions(t *testing.T) {
+	menu := airflowPermission{Action: airflowName{Name: "menu_access"}, Resource: airflowName{Name: "DAGs"}}
+	stale := airflowPermission{Action: airflowName{Name: "can_read"}, Resource: airflowName{Name: "DAG:legacy"}}
+	fake := &fakeAirflow{roles: []*airflowRole{{Name: "dataeng", Actions: []airflowPermission{menu, stale}}}}
+	client := newAirflowClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "dag_permissions", Value: []string{"etl_daily:edit", "reports"},
+	}))
+	assert.Equal(t, []airflowPermission{
+		menu,
+		{Action: airflowName{Name: "can_read"}, Resource: airflowName{Name: "DAG:etl_daily"}},
+		{Action: airflowName{Name: "can_edit"}, Resource: airflowName{Name: "DAG:etl_daily"}},
+		{Action: airflowName{Name: "can_delete"}, Resource: airflowName{Name: "DAG:etl_daily"}},
+		{Action: airflowName{Name: "can_read"}, Resource: airflowName{Name: "DAG:reports"}},
+	}, fake.roles[0].Actions)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "dag_permissions", Value: []string{"reports:trigger"},
+	}), "unknown airflow dag access")
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "workspace_roles", Value: []string{"ws-1"},
+	}), "unsupported airflow group param")
+}
+
+func TestAstronomerUsers(t *testing.T) {
+	fake := &fakeAstronomer{users: []astroUser{{ID: "usr-1", Username: "jdoe@example.com", FullName: "John Doe"}}}
+	client := newAstronomerClient(t, fake)
+
+	_, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "usr-1", UserName: "jdoe@example.com", Email: "jdoe@example.com",
+		DisplayName: "John Doe"},
+		byEmail["jdoe@example.com"])
+
+	// users are invited to the organization
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "asmith", Email: "asmith@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "usr-2", user.ID)
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "bwayne"})
+	assert.ErrorContains(t, err, "has none")
+
+	require.NoError(t, client.DeleteUser(context.Background(), "usr-2"))
+	assert.NoError(t, client.DeleteUser(context.Background(), "usr-2"))
+}
+
+func TestAstronomerTeams(t *testing.T) {
+	fake := &fakeAstronomer{}
+	client := newAstronomerClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "team-1", Name: "dataeng", Description: "team for dataeng"}, team)
+	assert.Equal(t, "ORGANIZATION_MEMBER", fake.teams[0].OrganizationRole)
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", teams["dataeng"].ID)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "team-1", []string{"usr-1", "usr-2"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "team-1", []string{"usr-1", "usr-3"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "team-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"usr-2": {ID: "usr-2", UserName: "usr-2@example.com",
+		Email: "usr-2@example.com"}}, members)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "team-1"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "team-1"))
+}
+
+func TestAstronomerWorkspaceRoles(t *testing.T) {
+	fake := &fakeAstronomer{teams: []*astroTeam{{
+		ID: "team-1", Name: "dataeng", OrganizationRole: "ORGANIZATION_BILLING_ADMIN",
+		Roles: []astroEntityRole{
+			{EntityID: "ws-old", EntityType: entityWorkspace, Role: "WORKSPACE_OWNER"},
+			{EntityID: "dep-1", EntityType: entityDeployment, Role: "DEPLOYMENT_ADMIN"},
+		}}}}
+	client := newAstronomerClient(t, fake)
+
+	params := structs.TeamParams{Property: "workspace_roles", Value: []string{"ws-1:WORKSPACE_OPERATOR", "ws-2"}}
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "team-1", params))
+	assert.Equal(t, astroTeamRoles{
+		OrganizationRole: "ORGANIZATION_BILLING_ADMIN",
+		WorkspaceRoles: []astroWorkspaceRole{
+			{WorkspaceID: "ws-1", Role: "WORKSPACE_OPERATOR"},
+			{WorkspaceID: "ws-2", Role: "WORKSPACE_MEMBER"},
+		},
+		DeploymentRoles: []astroDeploymentRole{{DeploymentID: "dep-1", Role: "DEPLOYMENT_ADMIN"}},
+	}, fake.roles["team-1"])
+
+	// the roles are not replaced again once granted
+	delete(fake.roles, "team-1")
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "team-1", params))
+	assert.Empty(t, fake.roles)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "team-1", structs.TeamParams{
+		Property: "dag_permissions", Value: []string{"etl_daily"},
+	}), "unsupported astronomer group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	assert.NoError(t, newAirflowClient(t, &fakeAirflow{}).HealthCheck(context.Background()))
+	assert.NoError(t, newAstronomerClient(t, &fakeAstronomer{}).HealthCheck(context.Background()))
+
+	client := newTestClient(t, &fakeAstronomer{}, map[string]interface{}{
+		"flavor": "astronomer", "organization_id": "org-1", "token": "wrong",
+	})
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "invalid api token")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// Group param properties of the Airflow backends
+const (
+	// groupParamDagPermissions lists the DAGs the Airflow role has access to, e.g. etl_daily:edit
+	groupParamDagPermissions = "dag_permissions"
+	// groupParamWorkspaceRoles lists the workspaces the Astronomer team has a role on, e.g.
+	// <workspace ID>:WORKSPACE_OPERATOR
+	groupParamWorkspaceRoles = "workspace_roles"
+)
+
+// ReconcileGroupParams grants the role of the group the DAG-level permissions of the
+// dag_permissions group param on Airflow, or the team of the group the workspace roles of the
+// workspace_roles group param on Astronomer
+func (aC *AirflowClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.ReconcileGroupParams")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("property", groupParams.Property).
+		WithField("service", "airflow")
+	log.WithField("value", groupParams.Value).Info("reconciling the airflow group params")
+
+	if err := aC.api.reconcileGroupParams(ctx, teamID, groupParams); err != nil {
+		log.WithError(err).Error("failed to reconcile the airflow group params")
+		return err
+	}
+	return nil
+}
+
+// parseDagPermission parses <dag_id>[:read|edit], the access defaults to read
+func parseDagPermission(value string) (string, string, error) {
+	dagID, access, found := strings.Cut(value, ":")
+	if !found {
+		access = DagAccessRead
+	}
+	if dagID == "" {
+		return "", "", fmt.Errorf("invalid airflow dag permission %q, expected <dag_id>[:read|edit]", value)
+	}
+	if _, ok := dagAccessActions[access]; !ok {
+		return "", "", fmt.Errorf("unknown airflow dag access %q in %q", access, value)
+	}
+	return dagID, access, nil
+}
+
+// parseWorkspaceRole parses <workspace ID>[:role], the role defaults to WORKSPACE_MEMBER
+func parseWorkspaceRole(value string) (string, string, error) {
+	workspaceID, role, found := strings.Cut(value, ":")
+	if !found {
+		role = defaultWorkspaceRole
+	}
+	if workspaceID == "" || role == "" {
+		return "", "", fmt.Errorf("invalid astronomer workspace role %q, expected <workspace ID>[:role]", value)
+	}
+	return workspaceID, role, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the users holding the role, or the members of the team, keyed by
+// user ID
+func (aC *AirflowClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members, err := aC.api.fetchMembers(ctx, teamID)
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch airflow team members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam grants the users the role, or adds them to the team
+func (aC *AirflowClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.AddUserToTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("userIDs", userIDs).
+		WithField("service", "airflow")
+	log.Info("Add users to airflow team")
+
+	if err := aC.api.addMembers(ctx, teamID, userIDs); err != nil {
+		log.WithError(err).Error("failed to add users to airflow team")
+		return err
+	}
+	return nil
+}
+
+// RemoveUserFromTeam revokes the role from the users, or removes them from the team
+func (aC *AirflowClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.RemoveUserFromTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("userIDs", userIDs).
+		WithField("service", "airflow")
+	log.Info("Remove users from airflow team")
+
+	if err := aC.api.removeMembers(ctx, teamID, userIDs); err != nil {
+		log.WithError(err).Error("failed to remove users from airflow team")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the roles of Airflow, or the teams of Astronomer, keyed by name
+func (aC *AirflowClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := aC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch airflow teams")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the roles or teams, 100 at a time
+func (aC *AirflowClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return aC.api.forEachTeamPage(ctx, fn)
+}
+
+// FetchTeamDetails fetches the role by name on Airflow, or the team by ID on Astronomer
+func (aC *AirflowClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.FetchTeamDetails")
+	defer span.Finish()
+
+	return aC.api.fetchTeam(ctx, teamID)
+}
+
+// CreateTeam creates the role without permissions on Airflow, or the team on Astronomer.
+// Airflow roles have no description.
+func (aC *AirflowClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "airflow")
+	log.Info("Create airflow team")
+
+	created, err := aC.api.createTeam(ctx, team)
+	if err != nil {
+		log.WithError(err).Error("failed to create airflow team")
+		return nil, err
+	}
+	return created, nil
+}
+
+// DeleteTeamByID deletes the role or team, its members lose the permissions granted to it
+func (aC *AirflowClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "airflow")
+	log.Info("Delete airflow team")
+
+	err := aC.api.deleteTeam(ctx, teamID)
+	if request.IsNotFound(err) {
+		log.Warn("airflow team not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
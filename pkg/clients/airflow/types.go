@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+// Flavors of Airflow
+const (
+	// FlavorAirflow manages the RBAC roles of an Airflow 2 deployment through its stable REST API
+	FlavorAirflow = "airflow"
+	// FlavorAstronomer manages the teams of an Astronomer (Astro) organization through its IAM API
+	FlavorAstronomer = "astronomer"
+)
+
+const (
+	// airflowAPIPath is the path of the stable REST API of Airflow
+	airflowAPIPath = "/api/v1"
+	// defaultAstronomerURL is the API of Astro
+	defaultAstronomerURL = "https://api.astronomer.io"
+	// pageSize is the number of resources requested per list page
+	pageSize = 100
+	// defaultAirflowUserRole is the role of the Airflow users created without a default role
+	defaultAirflowUserRole = "Viewer"
+	// defaultAstronomerUserRole is the organization role of the invited Astro users and of the teams
+	defaultAstronomerUserRole = "ORGANIZATION_MEMBER"
+	// defaultWorkspaceRole is the role of a team on a workspace of the workspace_roles group param
+	// without a role
+	defaultWorkspaceRole = "WORKSPACE_MEMBER"
+	// dagResourcePrefix prefixes the DAG-level resources of the Airflow permissions
+	dagResourcePrefix = "DAG:"
+)
+
+// Accesses granted on a DAG by the dag_permissions group param
+const (
+	DagAccessRead = "read"
+	DagAccessEdit = "edit"
+)
+
+// dagAccessActions are the Airflow actions granted on a DAG for each access, edit grants the DAG
+// permissions of the User role
+var dagAccessActions = map[string][]string{
+	DagAccessRead: {"can_read"},
+	DagAccessEdit: {"can_read", "can_edit", "can_delete"},
+}
+
+// AirflowConfig is the connection of an Airflow backend, read from the backend configuration
+type AirflowConfig struct {
+	// Flavor is airflow (default) or astronomer
+	Flavor string `json:"flavor"`
+	// URL is the base URL of the Airflow webserver, or of the Astro API
+	// (https://api.astronomer.io by default)
+	URL string `json:"url"`
+	// Username and Password are the credentials of an Airflow admin, for the basic auth backend of
+	// the API
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Token is an Astro organization API token with the Organization Owner role
+	Token string `json:"token"`
+	// OrganizationID is the Astro organization of the teams
+	OrganizationID string `json:"organization_id"`
+}
+
+// airflowUser is a user of Airflow, identified by its username
+type airflowUser struct {
+	Username  string        `json:"username"`
+	Email     string        `json:"email"`
+	FirstName string        `json:"first_name"`
+	LastName  string        `json:"last_name"`
+	Roles     []airflowName `json:"roles"`
+	Password  string        `json:"password,omitempty"`
+}
+
+// airflowName is a reference to an Airflow role, action or resource by name
+type airflowName struct {
+	Name string `json:"name"`
+}
+
+// airflowRole is an RBAC role of Airflow with its permissions
+type airflowRole struct {
+	Name    string              `json:"name"`
+	Actions []airflowPermission `json:"actions"`
+}
+
+// airflowPermission is an action granted on a resource, e.g. can_read on DAG:example
+type airflowPermission struct {
+	Action   airflowName `json:"action"`
+	Resource airflowName `json:"resource"`
+}
+
+// airflowUsers and airflowRoles are the pages of the Airflow list requests
+type airflowUsers struct {
+	Users        []airflowUser `json:"users"`
+	TotalEntries int           `json:"total_entries"`
+}
+
+type airflowRoles struct {
+	Roles        []airflowRole `json:"roles"`
+	TotalEntries int           `json:"total_entries"`
+}
+
+// astroUser is a user of the Astro organization, its username is its email
+type astroUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+	Status   string `json:"status"`
+}
+
+// astroInvite is the invite of a user to the Astro organization
+type astroInvite struct {
+	InviteeEmail string `json:"inviteeEmail"`
+	Role         string `json:"role"`
+}
+
+// astroInviteResponse is the created invite, with the user created for the invitee
+type astroInviteResponse struct {
+	InviteID string `json:"inviteId"`
+	UserID   string `json:"userId"`
+	Invitee  struct {
+		ID string `json:"id"`
+	} `json:"invitee"`
+}
+
+// astroTeam is a team of the Astro organization with its roles
+type astroTeam struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
+	OrganizationRole string            `json:"organizationRole,omitempty"`
+	Roles            []astroEntityRole `json:"roles,omitempty"`
+}
+
+// astroEntityRole is a role of a team on a workspace or a deployment
+type astroEntityRole struct {
+	EntityID   string `json:"entityId"`
+	EntityType string `json:"entityType"`
+	Role       string `json:"role"`
+}
+
+// astroTeamMember is a member of an Astro team
+type astroTeamMember struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+}
+
+// astroMembers adds users to an Astro team
+type astroMembers struct {
+	MemberIDs []string `json:"memberIds"`
+}
+
+// astroTeamRoles replaces the roles of an Astro team
+type astroTeamRoles struct {
+	OrganizationRole string                `json:"organizationRole"`
+	WorkspaceRoles   []astroWorkspaceRole  `json:"workspaceRoles"`
+	DeploymentRoles  []astroDeploymentRole `json:"deploymentRoles,omitempty"`
+}
+
+type astroWorkspaceRole struct {
+	WorkspaceID string `json:"workspaceId"`
+	Role        string `json:"role"`
+}
+
+type astroDeploymentRole struct {
+	DeploymentID string `json:"deploymentId"`
+	Role         string `json:"role"`
+}
+
+// astroUsers, astroTeams and astroTeamMembers are the pages of the Astro list requests
+type astroUsers struct {
+	Users      []astroUser `json:"users"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type astroTeams struct {
+	Teams      []astroTeam `json:"teams"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type astroTeamMembers struct {
+	TeamMembers []astroTeamMember `json:"teamMembers"`
+	TotalCount  int               `json:"totalCount"`
+}
+
+// errorResponse is the error of the Airflow API (a problem detail) or of the Astro API
+type errorResponse struct {
+	Title   string `json:"title"`
+	Detail  string `json:"detail"`
+	Message string `json:"message"`
+}
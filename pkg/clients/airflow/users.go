@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package airflow
+
+import (
+	"context"
+	"errors"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the users of Airflow, or of the Astronomer organization, keyed by ID and by email
+func (aC *AirflowClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := aC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch airflow users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users, 100 users at a time
+func (aC *AirflowClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return aC.api.forEachUserPage(ctx, fn)
+}
+
+// FetchUserDetails fetches the user by username on Airflow, or by ID on Astronomer
+func (aC *AirflowClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.FetchUserDetails")
+	defer span.Finish()
+
+	return aC.api.fetchUser(ctx, userID)
+}
+
+// CreateUser creates the Airflow user with the default role of the backend, Viewer by default, or
+// invites the user to the Astronomer organization with the default organization role,
+// ORGANIZATION_MEMBER by default. An existing user is reported as such.
+func (aC *AirflowClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("username", u.UserName).
+		WithField("service", "airflow")
+	log.Info("Create airflow user")
+
+	user, err := aC.api.createUser(ctx, u)
+	if err != nil {
+		if !errors.Is(err, structs.ErrUserAlreadyExists) {
+			log.WithError(err).Error("failed to create airflow user")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser deletes the Airflow user, or removes the user from the Astronomer organization. A
+// user which does not exist is considered deleted.
+func (aC *AirflowClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.airflow.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "airflow")
+	log.Info("Delete airflow user")
+
+	err := aC.api.deleteUser(ctx, userID)
+	if request.IsNotFound(err) {
+		log.Warn("airflow user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// ArtifactoryClient manages the users and groups of a JFrog Artifactory and the permission targets
+// of the groups through the security API
+type ArtifactoryClient struct {
+	client  heimdall.Doer
+	url     string
+	headers map[string]string
+}
+
+func NewClient(artifactoryAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*ArtifactoryClient, error) {
+
+	artifactoryConfig := ArtifactoryConfig{}
+	if err := utils.MapToStruct(artifactoryAppConfig, &artifactoryConfig); err != nil {
+		return nil, err
+	}
+	if artifactoryConfig.BaseURL == "" || artifactoryConfig.AccessToken == "" {
+		return nil, errors.New("artifactory configuration is missing required fields: base_url or access_token")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"artifactory_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &ArtifactoryClient{
+		client: client,
+		url:    strings.TrimSuffix(strings.TrimSuffix(artifactoryConfig.BaseURL, "/"), "/artifactory") + "/artifactory/api",
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+			"Accept":                       "application/json",
+			"Authorization":                "Bearer " + artifactoryConfig.AccessToken,
+		},
+	}, nil
+}
+
+// HealthCheck fetches the version of Artifactory, which requires a valid access token
+func (aC *ArtifactoryClient) HealthCheck(ctx context.Context) error {
+	if _, err := aC.sendRequest(ctx, "/system/version", http.MethodGet, nil,
+		"backend.artifactory.HealthCheck"); err != nil {
+		return fmt.Errorf("artifactory health check failed: %w", err)
+	}
+	return nil
+}
+
+// securityPath returns the path of the resource of the security API
+func securityPath(resource, name string) string {
+	return "/security/" + resource + "/" + url.PathEscape(name)
+}
+
+// get sends a GET request to the path and decodes its response
+func (aC *ArtifactoryClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := aC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of an Artifactory request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode artifactory response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the Artifactory API and returns the response body,
+// any response code other than 2xx is returned as a *request.ResponseError
+func (aC *ArtifactoryClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, aC.client, method, aC.url+path, body, aC.headers, methodName,
+		"artifactory", errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the messages of an error response of the Artifactory API
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	messages := make([]string, 0, len(errResp.Errors))
+	for _, e := range errResp.Errors {
+		messages = append(messages, e.Message)
+	}
+	return "", strings.Join(messages, ", ")
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeArtifactory is an Artifactory holding its users, groups and permission targets in memory
+type fakeArtifactory struct {
+	users   map[string]User
+	groups  map[string]Group
+	targets map[string]PermissionTarget
+	updated []string
+}
+
+func (f *fakeArtifactory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors": [{"status": 401, "message": "Bad credentials"}]}`))
+		return
+	}
+
+	resource := strings.TrimPrefix(r.URL.Path, "/artifactory/api")
+	name := resource[strings.LastIndex(resource, "/")+1:]
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": [{"status": 404, "message": "Not found"}]}`))
+	}
+	switch {
+	case resource == "/system/version":
+		_, _ = w.Write([]byte(`{"version": "7.90.0"}`))
+	case resource == "/security/users":
+		users := []UserRef{}
+		for userName := range f.users {
+			users = append(users, UserRef{Name: userName, Realm: "saml"})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, users)
+	case strings.HasPrefix(resource, "/security/users/"):
+		user, ok := f.users[name]
+		switch r.Method {
+		case http.MethodGet:
+			if !ok {
+				notFound()
+				return
+			}
+			user.Password = ""
+			fakehttp.WriteJSON(w, http.StatusOK, user)
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&user)
+			for _, existing := range f.users {
+				if existing.Email == user.Email {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+			}
+			f.users[name] = user
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			if !ok {
+				notFound()
+				return
+			}
+			delete(f.users, name)
+		}
+	case resource == "/security/groups":
+		groups := []Group{}
+		for _, group := range f.groups {
+			groups = append(groups, Group{Name: group.Name, Description: group.Description})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, groups)
+	case strings.HasPrefix(resource, "/security/groups/"):
+		group, ok := f.groups[name]
+		if !ok && r.Method != http.MethodPut {
+			notFound()
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("includeUsers") != "true" {
+				group.UserNames = nil
+			}
+			fakehttp.WriteJSON(w, http.StatusOK, group)
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&group)
+			f.groups[name] = group
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&group)
+			f.groups[name] = group
+		case http.MethodDelete:
+			delete(f.groups, name)
+		}
+	case resource == "/v2/security/permissions":
+		targets := []PermissionTargetRef{}
+		for targetName := range f.targets {
+			targets = append(targets, PermissionTargetRef{Name: targetName})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, targets)
+	case strings.HasPrefix(resource, "/v2/security/permissions/"):
+		if r.Method == http.MethodPut {
+			var target PermissionTarget
+			_ = json.NewDecoder(r.Body).Decode(&target)
+			f.targets[name] = target
+			f.updated = append(f.updated, name)
+			return
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, f.targets[name])
+	default:
+		notFound()
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeArtifactory) *ArtifactoryClient {
+	t.Helper()
+	if fake.users == nil {
+		fake.users = map[string]User{}
+	}
+	if fake.groups == nil {
+		fake.groups = map[string]Group{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	client, err := NewClient(map[string]interface{}{"base_url": server.URL + "/artifactory/", "access_token": "token"},
+		fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"base_url": "https://example.jfrog.io"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "base_url or access_token")
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeArtifactory{users: map[string]User{"jdoe": {Name: "jdoe", Email: "jdoe@example.com", Realm: "saml"}}}
+	client := newTestClient(t, fake)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "jdoe", UserName: "jdoe", Email: "jdoe@example.com"}, user)
+
+	user, err = client.CreateUser(context.Background(), &structs.User{UserName: "asmith", Email: "asmith@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "asmith", user.ID)
+	assert.True(t, fake.users["asmith"].InternalPasswordDisabled)
+	assert.NotEmpty(t, fake.users["asmith"].Password)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "john", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "noemail"})
+	assert.ErrorContains(t, err, "require an email")
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+	assert.Empty(t, byEmail)
+
+	require.NoError(t, client.DeleteUser(context.Background(), "asmith"))
+	assert.NoError(t, client.DeleteUser(context.Background(), "asmith"))
+	assert.NotContains(t, fake.users, "asmith")
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeArtifactory{}
+	client := newTestClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "dataeng", Name: "dataeng", Description: "team for dataeng"}, team)
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"dataeng": *team}, teams)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"jdoe", "asmith"}))
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"asmith", "bob"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"jdoe", "gone"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "bob")
+
+	// the last members are removed
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"asmith", "bob"}))
+	assert.Empty(t, fake.groups["dataeng"].UserNames)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.Empty(t, fake.groups)
+}
+
+func TestReconcilePermissionTargets(t *testing.T) {
+	fake := &fakeArtifactory{targets: map[string]PermissionTarget{
+		"docker-dev": {Name: "docker-dev", Repo: &PermissionSection{Repositories: []string{"docker-dev-local"},
+			Actions: PermissionActions{Groups: map[string][]string{"dataeng": {"read"}}}}},
+		"maven": {Name: "maven", Repo: &PermissionSection{Repositories: []string{"maven-local"},
+			Actions: PermissionActions{Users: map[string][]string{"jdoe": {"read"}}}}},
+		"old": {Name: "old", Repo: &PermissionSection{Repositories: []string{"old-local"},
+			Actions: PermissionActions{Groups: map[string][]string{"dataeng": {"read"}, "ops": {"read"}}}}},
+		"builds": {Name: "builds", Build: &PermissionSection{Actions: PermissionActions{}}},
+		"unchanged": {Name: "unchanged", Repo: &PermissionSection{Repositories: []string{"generic"},
+			Actions: PermissionActions{Groups: map[string][]string{"dataeng": {"write", "read"}}}}},
+	}}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "permission_targets", Value: []string{"docker-dev:read,write", "maven", "unchanged:read,write"},
+	}))
+	assert.Equal(t, []string{"read", "write"}, fake.targets["docker-dev"].Repo.Actions.Groups["dataeng"])
+	assert.Equal(t, []string{"read"}, fake.targets["maven"].Repo.Actions.Groups["dataeng"])
+	assert.Equal(t, []string{"read"}, fake.targets["maven"].Repo.Actions.Users["jdoe"])
+	assert.Equal(t, map[string][]string{"ops": {"read"}}, fake.targets["old"].Repo.Actions.Groups)
+	slices.Sort(fake.updated)
+	assert.Equal(t, []string{"docker-dev", "maven", "old"}, fake.updated)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "permission_targets", Value: []string{"missing"},
+	}), "permission target missing not found")
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "permission_targets", Value: []string{"builds"},
+	}), "has no repositories")
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "repositories", Value: []string{"x"},
+	}), "unsupported artifactory group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeArtifactory{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "Bearer wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "response code 401: Bad credentials")
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// groupParamPermissionTargets is the group param property listing the permission targets
+	// granting the group access to their repositories
+	groupParamPermissionTargets = "permission_targets"
+	// defaultPermissionActions are the actions granted on a permission target listed without actions
+	defaultPermissionActions = "read"
+)
+
+// ReconcileGroupParams attaches the group to exactly the permission targets of the
+// permission_targets group param, with the actions of each value granted on the repositories of
+// the target, e.g. docker-dev:read,write. The values without actions grant read. The group is
+// detached from the repositories of the other permission targets.
+func (aC *ArtifactoryClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamPermissionTargets {
+		return fmt.Errorf("unsupported artifactory group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "artifactory")
+
+	desired := make(map[string][]string, len(groupParams.Value))
+	for _, value := range groupParams.Value {
+		target, actions := parsePermissionTarget(value)
+		desired[target] = actions
+	}
+
+	var targets []PermissionTargetRef
+	if err := aC.get(ctx, "/v2/security/permissions", &targets, "backend.artifactory.ReconcileGroupParams"); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(targets))
+	for _, target := range targets {
+		names = append(names, target.Name)
+	}
+	for target := range desired {
+		if !slices.Contains(names, target) {
+			return fmt.Errorf("artifactory permission target %s not found", target)
+		}
+	}
+
+	for _, name := range names {
+		var target PermissionTarget
+		path := "/v2" + securityPath("permissions", name)
+		if err := aC.get(ctx, path, &target, "backend.artifactory.ReconcileGroupParams"); err != nil {
+			return err
+		}
+		actions, attached := desired[name]
+		if attached && target.Repo == nil {
+			return fmt.Errorf("artifactory permission target %s has no repositories", name)
+		}
+		if !target.setGroupActions(teamID, actions, attached) {
+			continue
+		}
+
+		if attached {
+			log.WithField("permissionTarget", name).WithField("actions", actions).
+				Info("Attach artifactory group to permission target")
+		} else {
+			log.WithField("permissionTarget", name).Info("Detach artifactory group from permission target")
+		}
+		if _, err := aC.sendRequest(ctx, path, http.MethodPut, &target,
+			"backend.artifactory.ReconcileGroupParams"); err != nil {
+			return fmt.Errorf("failed to update artifactory permission target %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setGroupActions grants the actions on the repositories of the permission target to the group
+// when attached, or removes the group from them otherwise. The permission targets without
+// repositories are left unchanged. It reports whether the permission
+// target changed.
+func (t *PermissionTarget) setGroupActions(group string, actions []string, attached bool) bool {
+	if t.Repo == nil {
+		return false
+	}
+	current, found := t.Repo.Actions.Groups[group]
+	if !attached {
+		if !found {
+			return false
+		}
+		delete(t.Repo.Actions.Groups, group)
+		return true
+	}
+
+	if found && slices.Equal(sortedActions(current), sortedActions(actions)) {
+		return false
+	}
+	if t.Repo.Actions.Groups == nil {
+		t.Repo.Actions.Groups = make(map[string][]string)
+	}
+	t.Repo.Actions.Groups[group] = actions
+	return true
+}
+
+// parsePermissionTarget splits the value of the permission_targets group param into the name of
+// the permission target and its actions
+func parsePermissionTarget(value string) (string, []string) {
+	target, actions, found := strings.Cut(value, ":")
+	if !found || actions == "" {
+		actions = defaultPermissionActions
+	}
+	return target, strings.Split(actions, ",")
+}
+
+func sortedActions(actions []string) []string {
+	sorted := slices.Clone(actions)
+	slices.Sort(sorted)
+	return sorted
+}
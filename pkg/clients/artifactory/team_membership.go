@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the members of the group by name, keyed by name
+func (aC *ArtifactoryClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	group, err := aC.fetchGroup(ctx, teamID, "backend.artifactory.FetchTeamMembersByTeamID")
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch artifactory group members")
+		return nil, err
+	}
+	members := make(map[string]*structs.User, len(group.UserNames))
+	for _, name := range group.UserNames {
+		members[name] = &structs.User{ID: name, UserName: name}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group. The security API replaces the whole member list of a
+// group, so the users are added to the current members.
+func (aC *ArtifactoryClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.AddUserToTeam")
+	defer span.Finish()
+
+	return aC.updateMembers(ctx, teamID, "backend.artifactory.AddUserToTeam", func(members []string) []string {
+		for _, userID := range userIDs {
+			if !slices.Contains(members, userID) {
+				members = append(members, userID)
+			}
+		}
+		return members
+	})
+}
+
+// RemoveUserFromTeam writes the user names of the group back without the users
+func (aC *ArtifactoryClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.RemoveUserFromTeam")
+	defer span.Finish()
+
+	return aC.updateMembers(ctx, teamID, "backend.artifactory.RemoveUserFromTeam", func(members []string) []string {
+		return slices.DeleteFunc(members, func(name string) bool {
+			return slices.Contains(userIDs, name)
+		})
+	})
+}
+
+// updateMembers replaces the members of the group with the members returned by update, the group
+// is left unchanged when its members are
+func (aC *ArtifactoryClient) updateMembers(ctx context.Context, teamID, methodName string,
+	update func(members []string) []string) error {
+
+	group, err := aC.fetchGroup(ctx, teamID, methodName)
+	if err != nil {
+		return err
+	}
+	members := update(slices.Clone(group.UserNames))
+	if slices.Equal(members, group.UserNames) {
+		return nil
+	}
+
+	logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "artifactory").
+		WithField("members", len(members)).Info("Update artifactory group members")
+	// the last members are removed with an empty list rather than null
+	if members == nil {
+		members = []string{}
+	}
+	_, err = aC.sendRequest(ctx, securityPath("groups", teamID), http.MethodPost,
+		&struct {
+			UserNames []string `json:"userNames"`
+		}{UserNames: members}, methodName)
+	if err != nil {
+		return fmt.Errorf("failed to update the members of artifactory group %s: %w", teamID, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"net/http"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups of Artifactory, keyed by name
+func (aC *ArtifactoryClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.FetchAllTeams")
+	defer span.Finish()
+
+	var groups []Group
+	if err := aC.get(ctx, "/security/groups", &groups, "backend.artifactory.FetchAllTeams"); err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch artifactory groups")
+		return nil, err
+	}
+	teams := make(map[string]structs.Team, len(groups))
+	for _, group := range groups {
+		teams[group.Name] = teamDetails(&group)
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the group by name
+func (aC *ArtifactoryClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.FetchTeamDetails")
+	defer span.Finish()
+
+	group, err := aC.fetchGroup(ctx, teamID, "backend.artifactory.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	team := teamDetails(group)
+	return &team, nil
+}
+
+// CreateTeam creates the internal group with the description of the team, the new users are not
+// added to it automatically
+func (aC *ArtifactoryClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "artifactory")
+	log.Info("Create artifactory group")
+
+	group := Group{Name: team.Name, Description: team.Description}
+	if _, err := aC.sendRequest(ctx, securityPath("groups", team.Name), http.MethodPut, &group,
+		"backend.artifactory.CreateTeam"); err != nil {
+		log.WithError(err).Error("failed to create artifactory group")
+		return nil, err
+	}
+	details := teamDetails(&group)
+	return &details, nil
+}
+
+// DeleteTeamByID deletes the group by name, which removes it from its permission targets. A group
+// which does not exist is considered deleted.
+func (aC *ArtifactoryClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "artifactory")
+	log.Info("Delete artifactory group")
+
+	_, err := aC.sendRequest(ctx, securityPath("groups", teamID), http.MethodDelete, nil,
+		"backend.artifactory.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("artifactory group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// fetchGroup fetches the group by name with its members
+func (aC *ArtifactoryClient) fetchGroup(ctx context.Context, teamID, methodName string) (*Group, error) {
+	var group Group
+	if err := aC.get(ctx, securityPath("groups", teamID)+"?includeUsers=true", &group, methodName); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// teamDetails converts the Artifactory group, the name is the ID of the group
+func teamDetails(g *Group) structs.Team {
+	return structs.Team{
+		ID:          g.Name,
+		Name:        g.Name,
+		Description: g.Description,
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+// ArtifactoryConfig is the connection of an Artifactory backend, read from the backend configuration
+type ArtifactoryConfig struct {
+	// BaseURL is the JFrog Platform URL, e.g. https://example.jfrog.io, the APIs are served under
+	// /artifactory
+	BaseURL string `json:"base_url"`
+	// AccessToken is an admin access token of the platform
+	AccessToken string `json:"access_token"`
+}
+
+// UserRef is a user of the users list
+type UserRef struct {
+	Name  string `json:"name"`
+	Realm string `json:"realm,omitempty"`
+}
+
+// User is an Artifactory user. The users signing in through SAML, OAuth or LDAP get an account of
+// their realm on their first sign-in, the other users are internal.
+type User struct {
+	Name                     string   `json:"name"`
+	Email                    string   `json:"email,omitempty"`
+	Password                 string   `json:"password,omitempty"`
+	Admin                    bool     `json:"admin"`
+	ProfileUpdatable         bool     `json:"profileUpdatable"`
+	InternalPasswordDisabled bool     `json:"internalPasswordDisabled"`
+	Realm                    string   `json:"realm,omitempty"`
+	Groups                   []string `json:"groups,omitempty"`
+}
+
+// Group is an Artifactory group, identified by its name
+type Group struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description,omitempty"`
+	AutoJoin        bool     `json:"autoJoin"`
+	AdminPrivileges bool     `json:"adminPrivileges"`
+	Realm           string   `json:"realm,omitempty"`
+	UserNames       []string `json:"userNames,omitempty"`
+}
+
+// PermissionTargetRef is a permission target of the permission targets list
+type PermissionTargetRef struct {
+	Name string `json:"name"`
+}
+
+// PermissionTarget grants actions on the resources of its sections to users and groups, the
+// repositories section grants the access to the repositories
+type PermissionTarget struct {
+	Name          string             `json:"name"`
+	Repo          *PermissionSection `json:"repo,omitempty"`
+	Build         *PermissionSection `json:"build,omitempty"`
+	ReleaseBundle *PermissionSection `json:"releaseBundle,omitempty"`
+}
+
+// PermissionSection is the section of a permission target for a type of resources
+type PermissionSection struct {
+	IncludePatterns []string          `json:"include-patterns,omitempty"`
+	ExcludePatterns []string          `json:"exclude-patterns,omitempty"`
+	Repositories    []string          `json:"repositories,omitempty"`
+	Actions         PermissionActions `json:"actions"`
+}
+
+// PermissionActions are the actions granted to each user and group by a section
+type PermissionActions struct {
+	Users  map[string][]string `json:"users,omitempty"`
+	Groups map[string][]string `json:"groups,omitempty"`
+}
+
+// errorResponse is the body of the Artifactory API errors
+type errorResponse struct {
+	Errors []struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the users of Artifactory, keyed by name. The users list has no emails, so
+// none are keyed by email.
+func (aC *ArtifactoryClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.FetchAllUsers")
+	defer span.Finish()
+
+	var users []UserRef
+	if err := aC.get(ctx, "/security/users", &users, "backend.artifactory.FetchAllUsers"); err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch artifactory users")
+		return nil, nil, err
+	}
+
+	usersByID := make(map[string]*structs.User, len(users))
+	usersByEmail := make(map[string]*structs.User)
+	for _, user := range users {
+		usersByID[user.Name] = &structs.User{ID: user.Name, UserName: user.Name}
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// FetchUserDetails fetches the user by name
+func (aC *ArtifactoryClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := aC.get(ctx, securityPath("users", userID), &user, "backend.artifactory.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser returns the user of the same name when it exists, e.g. a user who already signed in
+// through SSO. Otherwise the user is created with a random internal password which is disabled, so
+// that the user signs in through SSO.
+func (aC *ArtifactoryClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("username", u.UserName).WithField("service", "artifactory")
+
+	existing, err := aC.FetchUserDetails(ctx, u.UserName)
+	if err == nil {
+		if existing.Email == "" {
+			existing.Email = u.Email
+		}
+		return existing, nil
+	}
+	if !request.IsNotFound(err) {
+		log.WithError(err).Error("failed to fetch artifactory user")
+		return nil, err
+	}
+	if u.Email == "" {
+		return nil, fmt.Errorf("artifactory users require an email, user %s has none", u.UserName)
+	}
+
+	password, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Create artifactory user")
+	user := User{
+		Name:                     u.UserName,
+		Email:                    u.Email,
+		Password:                 password,
+		InternalPasswordDisabled: true,
+	}
+	if _, err := aC.sendRequest(ctx, securityPath("users", u.UserName), http.MethodPut, &user,
+		"backend.artifactory.CreateUser"); err != nil {
+		var respErr *request.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		log.WithError(err).Error("failed to create artifactory user")
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// DeleteUser deletes the user by name, which removes it from its groups and permission targets. A
+// user which does not exist is considered deleted.
+func (aC *ArtifactoryClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.artifactory.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "artifactory")
+	log.Info("Delete artifactory user")
+
+	_, err := aC.sendRequest(ctx, securityPath("users", userID), http.MethodDelete, nil,
+		"backend.artifactory.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("artifactory user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// randomPassword returns a password meeting the default password policy of Artifactory, the
+// internal password of the users is disabled so it is never used
+func randomPassword() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate artifactory user password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(secret) + "Aa1!", nil
+}
+
+// userDetails converts the Artifactory user, the name is the ID of the user
+func userDetails(u *User) *structs.User {
+	return &structs.User{
+		ID:       u.Name,
+		UserName: u.Name,
+		Email:    u.Email,
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlassian
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// AtlassianClient manages the groups of an Atlassian Cloud site and their members through the
+// group management of the site REST API. The groups of a site are shared by its products, so a
+// single client covers the group usage of both Jira and Confluence.
+type AtlassianClient struct {
+	client  heimdall.Doer
+	url     string
+	headers map[string]string
+}
+
+func NewClient(atlassianAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*AtlassianClient, error) {
+
+	atlassianConfig := AtlassianConfig{}
+	if err := utils.MapToStruct(atlassianAppConfig, &atlassianConfig); err != nil {
+		return nil, err
+	}
+	if atlassianConfig.SiteURL == "" || atlassianConfig.Email == "" || atlassianConfig.APIToken == "" {
+		return nil, errors.New("atlassian configuration is missing required fields: site_url, email or api_token")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"atlassian_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(atlassianConfig.Email + ":" + atlassianConfig.APIToken))
+	return &AtlassianClient{
+		client: client,
+		url:    strings.TrimSuffix(atlassianConfig.SiteURL, "/") + "/rest/api/3",
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+			"Accept":                       "application/json",
+			"Authorization":                "Basic " + credentials,
+		},
+	}, nil
+}
+
+// HealthCheck fetches the account of the API token, the site is healthy when it accepts the token
+func (aC *AtlassianClient) HealthCheck(ctx context.Context) error {
+	if _, err := aC.sendRequest(ctx, "/myself", http.MethodGet, nil, "backend.atlassian.HealthCheck"); err != nil {
+		return fmt.Errorf("atlassian health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path and decodes its response
+func (aC *AtlassianClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := aC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of an Atlassian request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode atlassian response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the site REST API and returns the response body,
+// any response code other than 2xx is returned as a *request.ResponseError
+func (aC *AtlassianClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, aC.client, method, aC.url+path, body, aC.headers, methodName,
+		"atlassian", errorMessage)
+	return resp, err
+}
+
+// forEachPage calls fn with each page of the paginated list of the path, until its last page
+func forEachPage[T any](ctx context.Context, aC *AtlassianClient, path string, methodName string,
+	fn func(page []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	for startAt := 0; ; {
+		var page pageOf[T]
+		if err := aC.get(ctx, fmt.Sprintf("%s%sstartAt=%d&maxResults=%d", path, separator, startAt, pageSize),
+			&page, methodName); err != nil {
+			return err
+		}
+		if err := fn(page.Values); err != nil {
+			return err
+		}
+		if page.IsLast || len(page.Values) == 0 {
+			return nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// errorMessage returns the messages of an error response of the site REST API, with the messages
+// of the fields in their order
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	messages := errResp.ErrorMessages
+	fields := make([]string, 0, len(errResp.Errors))
+	for field := range errResp.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+errResp.Errors[field])
+	}
+	if errResp.Message != "" {
+		messages = append(messages, errResp.Message)
+	}
+	return "", strings.Join(messages, ", ")
+}
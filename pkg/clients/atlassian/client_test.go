@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlassian
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeAtlassian is an Atlassian site holding its accounts, groups and group members in memory
+type fakeAtlassian struct {
+	users   []User
+	groups  []Group
+	members map[string][]string
+}
+
+func (f *fakeAtlassian) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	credentials := base64.StdEncoding.EncodeToString([]byte("admin@example.com:token"))
+	if r.Header.Get("Authorization") != "Basic "+credentials {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errorMessages": ["Client must be authenticated to access this resource."]}`))
+		return
+	}
+
+	query := r.URL.Query()
+	startAt, _ := strconv.Atoi(query.Get("startAt"))
+	switch resource := strings.TrimPrefix(r.URL.Path, "/rest/api/3"); {
+	case resource == "/myself":
+		_, _ = w.Write([]byte(`{"accountId": "admin"}`))
+	case resource == "/users/search":
+		// the list ends with an empty page
+		maxResults, _ := strconv.Atoi(query.Get("maxResults"))
+		end := min(startAt+maxResults, len(f.users))
+		fakehttp.WriteJSON(w, http.StatusOK, f.users[min(startAt, end):end])
+	case resource == "/user/search":
+		var users []User
+		for _, u := range f.users {
+			if strings.Contains(u.EmailAddress, query.Get("query")) {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, users)
+	case resource == "/group/bulk":
+		groups := f.groups
+		if query.Has("groupId") {
+			groups = slices.DeleteFunc(slices.Clone(groups), func(g Group) bool { return g.GroupID != query.Get("groupId") })
+		}
+		// pages of a single group
+		end := min(startAt+1, len(groups))
+		fakehttp.WriteJSON(w, http.StatusOK,
+			pageOf[Group]{StartAt: startAt, IsLast: end == len(groups), Values: groups[startAt:end]})
+	case resource == "/group" && r.Method == http.MethodPost:
+		var group Group
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		group.GroupID = "g" + strconv.Itoa(len(f.groups)+1)
+		f.groups = append(f.groups, group)
+		fakehttp.WriteJSON(w, http.StatusOK, group)
+	case resource == "/group" && r.Method == http.MethodDelete:
+		index := slices.IndexFunc(f.groups, func(g Group) bool { return g.GroupID == query.Get("groupId") })
+		if index < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.groups = slices.Delete(f.groups, index, index+1)
+	case resource == "/group/member":
+		var users []User
+		for _, u := range f.users {
+			if slices.Contains(f.members[query.Get("groupId")], u.AccountID) {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, pageOf[User]{IsLast: true, Values: users})
+	case resource == "/group/user" && r.Method == http.MethodPost:
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		groupID := query.Get("groupId")
+		if slices.Contains(f.members[groupID], body["accountId"]) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorMessages": ["Cannot add user. User is already a member of ` + groupID + `"]}`))
+			return
+		}
+		f.members[groupID] = append(f.members[groupID], body["accountId"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	case resource == "/group/user" && r.Method == http.MethodDelete:
+		groupID := query.Get("groupId")
+		index := slices.Index(f.members[groupID], query.Get("accountId"))
+		if index < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.members[groupID] = slices.Delete(f.members[groupID], index, index+1)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeAtlassian) *AtlassianClient {
+	t.Helper()
+	if fake.members == nil {
+		fake.members = map[string][]string{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	client, err := NewClient(map[string]interface{}{
+		"site_url": server.URL + "/", "email": "admin@example.com", "api_token": "token",
+	}, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"site_url": "https://example.atlassian.net"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "site_url, email or api_token")
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeAtlassian{users: []User{
+		{AccountID: "a1", AccountType: "atlassian", EmailAddress: "jdoe@example.com", DisplayName: "John Doe", Active: true},
+		{AccountID: "app1", AccountType: "app", DisplayName: "Automation", Active: true},
+		{AccountID: "a2", AccountType: "atlassian", EmailAddress: "gone@example.com", Active: false},
+		{AccountID: "a3", AccountType: "atlassian", EmailAddress: "bob@example.com", Active: true},
+		{AccountID: "c1", AccountType: "customer", EmailAddress: "customer@example.com", Active: true},
+	}}
+	client := newTestClient(t, fake)
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+	assert.Equal(t, "a3", byEmail["bob@example.com"].ID)
+	assert.Equal(t, "John Doe", byID["a1"].DisplayName)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "a1", user.ID)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "gone@example.com"})
+	assert.ErrorContains(t, err, "atlassian user gone@example.com not found")
+	assert.NoError(t, client.DeleteUser(context.Background(), "a1"))
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeAtlassian{groups: []Group{{GroupID: "g1", Name: "jira-users"}, {GroupID: "g2", Name: "confluence-users"}}}
+	client := newTestClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "g3", Name: "dataeng"}, team)
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, teams, 3)
+	assert.Equal(t, "g2", teams["confluence-users"].ID)
+
+	details, err := client.FetchTeamDetails(context.Background(), "g3")
+	require.NoError(t, err)
+	assert.Equal(t, "dataeng", details.Name)
+	_, err = client.FetchTeamDetails(context.Background(), "g9")
+	assert.True(t, request.IsNotFound(err))
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "g3"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "g3"))
+	assert.Len(t, fake.groups, 2)
+}
+
+func TestMembership(t *testing.T) {
+	fake := &fakeAtlassian{
+		users:   []User{{AccountID: "a1", Active: true}, {AccountID: "a2", Active: true}, {AccountID: "a3", Active: true}},
+		groups:  []Group{{GroupID: "g1", Name: "dataeng"}},
+		members: map[string][]string{"g1": {"a1"}},
+	}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "g1", []string{"a1", "a2", "a3"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "g1", []string{"a2", "a9"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g1")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "a3")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeAtlassian{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "Basic wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()),
+		"response code 401: Client must be authenticated to access this resource.")
+}
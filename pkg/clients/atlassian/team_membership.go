@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlassian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchTeamMembersByTeamID lists the active members of the group by group ID, keyed by account ID
+func (aC *AtlassianClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members := make(map[string]*structs.User)
+	err := forEachPage(ctx, aC, "/group/member?groupId="+url.QueryEscape(teamID),
+		"backend.atlassian.FetchTeamMembersByTeamID", func(users []User) error {
+			for _, u := range users {
+				members[u.AccountID] = userDetails(&u)
+			}
+			return nil
+		})
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch atlassian group members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group, the site REST API has no bulk membership endpoint.
+// The users who are already members are considered added.
+func (aC *AtlassianClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.AddUserToTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "atlassian")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Add user to atlassian group")
+		_, err := aC.sendRequest(ctx, "/group/user?groupId="+url.QueryEscape(teamID), http.MethodPost,
+			map[string]string{"accountId": userID}, "backend.atlassian.AddUserToTeam")
+		if err != nil && !isAlreadyMember(err) {
+			return fmt.Errorf("failed to add user %s to atlassian group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the group one at a time, the 404 of the site for an
+// account which is not in the group is ignored
+func (aC *AtlassianClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.RemoveUserFromTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "atlassian")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Remove user from atlassian group")
+		_, err := aC.sendRequest(ctx, fmt.Sprintf("/group/user?groupId=%s&accountId=%s",
+			url.QueryEscape(teamID), url.QueryEscape(userID)), http.MethodDelete, nil,
+			"backend.atlassian.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s from atlassian group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// isAlreadyMember reports whether the error is the rejection of a user who is already a member
+// of the group
+func isAlreadyMember(err error) bool {
+	var respErr *request.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(respErr.Message, "already a member")
+}
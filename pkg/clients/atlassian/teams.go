@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlassian
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups of the site, keyed by name
+func (aC *AtlassianClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := aC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch atlassian groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the groups of the site, 50 groups at a time
+func (aC *AtlassianClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return forEachPage(ctx, aC, "/group/bulk", "backend.atlassian.FetchAllTeams", func(groups []Group) error {
+		page := make([]structs.Team, 0, len(groups))
+		for _, group := range groups {
+			page = append(page, teamDetails(&group))
+		}
+		return fn(page)
+	})
+}
+
+// FetchTeamDetails fetches the group by group ID
+func (aC *AtlassianClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.FetchTeamDetails")
+	defer span.Finish()
+
+	var page pageOf[Group]
+	if err := aC.get(ctx, "/group/bulk?groupId="+url.QueryEscape(teamID), &page,
+		"backend.atlassian.FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+	if len(page.Values) == 0 {
+		return nil, &request.ResponseError{Service: "atlassian", StatusCode: http.StatusNotFound,
+			Message: "group " + teamID + " not found"}
+	}
+	team := teamDetails(&page.Values[0])
+	return &team, nil
+}
+
+// CreateTeam creates the group of the site, Atlassian groups have no description
+func (aC *AtlassianClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "atlassian")
+	log.Info("Create atlassian group")
+
+	resp, err := aC.sendRequest(ctx, "/group", http.MethodPost, &Group{Name: team.Name}, "backend.atlassian.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create atlassian group")
+		return nil, err
+	}
+
+	var created Group
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.GroupID == "" {
+		return nil, errors.New("no group ID in the atlassian create group response")
+	}
+	details := teamDetails(&created)
+	return &details, nil
+}
+
+// DeleteTeamByID deletes the group by group ID, which revokes the product access and the
+// permissions granted to the group. A group which does not exist is considered deleted.
+func (aC *AtlassianClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "atlassian")
+	log.Info("Delete atlassian group")
+
+	_, err := aC.sendRequest(ctx, "/group?groupId="+url.QueryEscape(teamID), http.MethodDelete, nil,
+		"backend.atlassian.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("atlassian group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// ReconcileGroupParams is a no-op, Atlassian groups have no group params
+func (aC *AtlassianClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	return nil
+}
+
+// teamDetails converts the Atlassian group
+func teamDetails(g *Group) structs.Team {
+	return structs.Team{
+		ID:   g.GroupID,
+		Name: g.Name,
+	}
+}
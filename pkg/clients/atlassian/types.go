@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlassian
+
+const (
+	// pageSize is the number of resources requested per list page, the largest page of the users
+	// and group member lists
+	pageSize = 50
+	// accountTypeAtlassian is the type of the accounts of people, as opposed to the app and
+	// customer accounts
+	accountTypeAtlassian = "atlassian"
+)
+
+// AtlassianConfig is the connection of an Atlassian backend, read from the backend configuration
+type AtlassianConfig struct {
+	// SiteURL is the Atlassian Cloud site of Jira and Confluence, e.g. https://example.atlassian.net
+	SiteURL string `json:"site_url"`
+	// Email is the email of the site admin owning the API token
+	Email string `json:"email"`
+	// APIToken is the API token of the site admin, sent with basic auth
+	APIToken string `json:"api_token"`
+}
+
+// User is an Atlassian account with access to the site
+type User struct {
+	AccountID    string `json:"accountId"`
+	AccountType  string `json:"accountType"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	Active       bool   `json:"active"`
+}
+
+// Group is a group of the site, the groups are shared by Jira and Confluence
+type Group struct {
+	GroupID string `json:"groupId,omitempty"`
+	Name    string `json:"name"`
+}
+
+// pageOf is a page of a paginated list of the site REST API
+type pageOf[T any] struct {
+	StartAt    int  `json:"startAt"`
+	MaxResults int  `json:"maxResults"`
+	IsLast     bool `json:"isLast"`
+	Values     []T  `json:"values"`
+}
+
+// errorResponse is the body of the site REST API errors
+type errorResponse struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+	Message       string            `json:"message"`
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlassian
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers lists the active Atlassian accounts of the site, keyed by account ID and by email
+func (aC *AtlassianClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := aC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch atlassian users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the active Atlassian accounts of the site, 50 accounts
+// at a time. The app and customer accounts are skipped.
+func (aC *AtlassianClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	for startAt := 0; ; startAt += pageSize {
+		var accounts []User
+		if err := aC.get(ctx, fmt.Sprintf("/users/search?startAt=%d&maxResults=%d", startAt, pageSize), &accounts,
+			"backend.atlassian.FetchAllUsers"); err != nil {
+			return err
+		}
+		// the list has no total, it ends with an empty page
+		if len(accounts) == 0 {
+			return nil
+		}
+		page := make([]*structs.User, 0, len(accounts))
+		for _, account := range accounts {
+			if account.isPerson() {
+				page = append(page, userDetails(&account))
+			}
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+}
+
+// FetchUserDetails fetches the Atlassian account by account ID
+func (aC *AtlassianClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := aC.get(ctx, "/user?accountId="+url.QueryEscape(userID), &user,
+		"backend.atlassian.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser looks the Atlassian account up by email, the accounts are managed by the organization
+// of the site and its identity provider. Only the accounts whose email is visible to the site
+// admin are found.
+func (aC *AtlassianClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.atlassian.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "atlassian")
+	if u.Email == "" {
+		return nil, fmt.Errorf("atlassian users are looked up by email, user %s has none", u.UserName)
+	}
+
+	var accounts []User
+	if err := aC.get(ctx, "/user/search?query="+url.QueryEscape(u.Email), &accounts,
+		"backend.atlassian.CreateUser"); err != nil {
+		log.WithError(err).Error("failed to search atlassian user")
+		return nil, err
+	}
+	for _, account := range accounts {
+		if account.isPerson() && strings.EqualFold(account.EmailAddress, u.Email) {
+			return userDetails(&account), nil
+		}
+	}
+	return nil, fmt.Errorf("atlassian user %s not found", u.Email)
+}
+
+// DeleteUser is a no-op, the Atlassian accounts are managed by the organization of the site. The
+// offboarded users are removed from the groups by the reconciles of their groups.
+func (aC *AtlassianClient) DeleteUser(ctx context.Context, userID string) error {
+	logger.Logger(ctx).WithField("userID", userID).WithField("service", "atlassian").
+		Info("atlassian accounts are not managed, skipping user deletion")
+	return nil
+}
+
+// isPerson reports whether the account is the active account of a person
+func (u *User) isPerson() bool {
+	return u.Active && (u.AccountType == "" || u.AccountType == accountTypeAtlassian)
+}
+
+// userDetails converts the Atlassian account, the account ID is the ID of the user
+func userDetails(u *User) *structs.User {
+	return &structs.User{
+		ID:          u.AccountID,
+		UserName:    u.EmailAddress,
+		Email:       u.EmailAddress,
+		DisplayName: u.DisplayName,
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// BitbucketClient manages the groups of Bitbucket Server, or the user groups of a Bitbucket Cloud
+// workspace, their members and the permissions of the groups on repositories and projects
+type BitbucketClient struct {
+	client  heimdall.Doer
+	url     string
+	headers map[string]string
+	// api is the API of the flavor of Bitbucket
+	api flavor
+}
+
+// flavor is the API of Bitbucket Server or of Bitbucket Cloud
+type flavor interface {
+	healthCheck(ctx context.Context) error
+	forEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error
+	fetchUser(ctx context.Context, userID string) (*structs.User, error)
+	// findUser looks an existing user up, Bitbucket users are provisioned by their directory or
+	// sign up themselves
+	findUser(ctx context.Context, u *structs.User) (*structs.User, error)
+	forEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error
+	fetchTeam(ctx context.Context, teamID string) (*structs.Team, error)
+	createTeam(ctx context.Context, name string) (*structs.Team, error)
+	deleteTeam(ctx context.Context, teamID string) error
+	fetchMembers(ctx context.Context, teamID string) (map[string]*structs.User, error)
+	addMembers(ctx context.Context, teamID string, userIDs []string) error
+	removeMembers(ctx context.Context, teamID string, userIDs []string) error
+	// grant grants the group the permission on the repository of the project, or on all the
+	// repositories of the project when repository is empty
+	grant(ctx context.Context, teamID string, target permissionTarget) error
+}
+
+func NewClient(bitbucketAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*BitbucketClient, error) {
+
+	bitbucketConfig := BitbucketConfig{}
+	if err := utils.MapToStruct(bitbucketAppConfig, &bitbucketConfig); err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       "application/json",
+	}
+	baseURL := strings.TrimSuffix(bitbucketConfig.URL, "/")
+	switch bitbucketConfig.Flavor {
+	case "", FlavorServer:
+		if baseURL == "" || bitbucketConfig.Token == "" {
+			return nil, errors.New("bitbucket server configuration is missing required fields: url or token")
+		}
+		headers["Authorization"] = "Bearer " + bitbucketConfig.Token
+	case FlavorCloud:
+		if bitbucketConfig.Workspace == "" || bitbucketConfig.Username == "" || bitbucketConfig.AppPassword == "" {
+			return nil, errors.New("bitbucket cloud configuration is missing required fields: " +
+				"workspace, username or app_password")
+		}
+		if baseURL == "" {
+			baseURL = defaultCloudURL
+		}
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString(
+			[]byte(bitbucketConfig.Username+":"+bitbucketConfig.AppPassword))
+	default:
+		return nil, fmt.Errorf("invalid bitbucket flavor %q, supported: %s, %s", bitbucketConfig.Flavor,
+			FlavorServer, FlavorCloud)
+	}
+
+	client, err := httpclient.InitializeClient(
+		"bitbucket_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	bitbucketClient := &BitbucketClient{
+		client:  client,
+		url:     baseURL,
+		headers: headers,
+	}
+	if bitbucketConfig.Flavor == FlavorCloud {
+		bitbucketClient.api = &cloudAPI{bC: bitbucketClient, workspace: bitbucketConfig.Workspace}
+	} else {
+		bitbucketClient.api = &serverAPI{bC: bitbucketClient}
+	}
+	return bitbucketClient, nil
+}
+
+// errStopPaging stops a listing once the resource looked up is found
+var errStopPaging = errors.New("stop paging")
+
+// HealthCheck lists a single group on Bitbucket Server, or fetches the workspace on Bitbucket Cloud
+func (bC *BitbucketClient) HealthCheck(ctx context.Context) error {
+	if err := bC.api.healthCheck(ctx); err != nil {
+		return fmt.Errorf("bitbucket health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path, or to the URL of a next page, and decodes its response
+func (bC *BitbucketClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := bC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a Bitbucket request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode bitbucket response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the JSON request to the path of the API and returns the response body
+func (bC *BitbucketClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bC.send(ctx, path, method, requestBody, bC.headers, methodName)
+}
+
+// send sends the request to the path of the API, or to an absolute URL, and returns the response
+// body, any response code other than 2xx is returned as a *request.ResponseError
+func (bC *BitbucketClient) send(ctx context.Context, path string, method string, requestBody []byte,
+	headers map[string]string, methodName string) ([]byte, error) {
+
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = bC.url + path
+	}
+	resp, _, err := request.Send(ctx, bC.client, method, url, requestBody, headers, methodName, "bitbucket",
+		errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the messages of an error response of Bitbucket Server, or the message of
+// Bitbucket Cloud
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	messages := make([]string, 0, len(errResp.Errors))
+	for _, e := range errResp.Errors {
+		messages = append(messages, e.Message)
+	}
+	if len(messages) > 0 {
+		return "", strings.Join(messages, "; ")
+	}
+	return "", errResp.Error.Message
+}
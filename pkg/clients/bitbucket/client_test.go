@@ -0,0 +1,426 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeServer is the REST API of Bitbucket Server holding its users, groups and group permissions
+// in memory
+type fakeServer struct {
+	users []serverUser
+	// groups are the member names of the groups by group name
+	groups map[string][]string
+	// grants are the permissions of the groups by project or project/repository and group name
+	grants map[string]string
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w,
+			`{"errors": [{"message": "Authentication failed. Please check your credentials and try again."}]}`)
+		return
+	}
+
+	query := r.URL.Query()
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/1.0")
+	switch {
+	case r.Method == http.MethodGet && path == "/admin/users":
+		var users []serverUser
+		for _, u := range f.users {
+			if strings.Contains(u.Name, query.Get("filter")) || strings.Contains(u.EmailAddress, query.Get("filter")) {
+				users = append(users, u)
+			}
+		}
+		writeServerPage(w, r, users)
+	case r.Method == http.MethodGet && path == "/admin/groups":
+		var groups []serverGroup
+		for name := range f.groups {
+			if strings.Contains(name, query.Get("filter")) {
+				groups = append(groups, serverGroup{Name: name})
+			}
+		}
+		slices.SortFunc(groups, func(a, b serverGroup) int { return strings.Compare(a.Name, b.Name) })
+		writeServerPage(w, r, groups)
+	case r.Method == http.MethodPost && path == "/admin/groups":
+		if _, ok := f.groups[query.Get("name")]; ok {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, `{"errors": [{"message": "A group with this name already exists"}]}`)
+			return
+		}
+		f.groups[query.Get("name")] = nil
+		fakehttp.WriteJSON(w, http.StatusOK, serverGroup{Name: query.Get("name")})
+	case r.Method == http.MethodDelete && path == "/admin/groups":
+		if _, ok := f.groups[query.Get("name")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors": [{"message": "The group does not exist"}]}`)
+			return
+		}
+		delete(f.groups, query.Get("name"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && path == "/admin/groups/more-members":
+		var members []serverUser
+		for _, u := range f.users {
+			if slices.Contains(f.groups[query.Get("context")], u.Name) {
+				members = append(members, u)
+			}
+		}
+		writeServerPage(w, r, members)
+	case r.Method == http.MethodPost && path == "/admin/groups/add-users":
+		var add serverAddUsers
+		_ = json.NewDecoder(r.Body).Decode(&add)
+		for _, name := range add.Users {
+			if !slices.Contains(f.groups[add.Group], name) {
+				f.groups[add.Group] = append(f.groups[add.Group], name)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && path == "/admin/groups/remove-user":
+		var remove serverRemoveUser
+		_ = json.NewDecoder(r.Body).Decode(&remove)
+		f.groups[remove.Context] = slices.DeleteFunc(f.groups[remove.Context], func(name string) bool {
+			return name == remove.ItemName
+		})
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && strings.HasSuffix(path, "/permissions/groups"):
+		target := strings.TrimSuffix(strings.TrimPrefix(path, "/projects/"), "/permissions/groups")
+		f.grants[strings.Replace(target, "/repos/", "/", 1)+" "+query.Get("name")] = query.Get("permission")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"errors": [{"message": "not found"}]}`)
+	}
+}
+
+// writeServerPage writes the page of the resources at the start and limit of the request
+func writeServerPage[T any](w http.ResponseWriter, r *http.Request, resources []T) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	start = min(start, len(resources))
+	end := min(start+limit, len(resources))
+	fakehttp.WriteJSON(w, http.StatusOK, serverPage[T]{
+		Values: resources[start:end], IsLastPage: end == len(resources), NextPageStart: end,
+	})
+}
+
+// fakeCloud is the API of a Bitbucket Cloud workspace holding its members, user groups and group
+// permissions in memory
+type fakeCloud struct {
+	members []cloudUser
+	groups  []*cloudGroup
+	// grants are the permissions of the groups by repository or project and group slug
+	grants map[string]string
+}
+
+func (f *fakeCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if user, password, _ := r.BasicAuth(); user != "admin" || password != "app-password" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"type": "error", "error": {"message": "Invalid credentials"}}`)
+		return
+	}
+
+	switch path := r.URL.Path; {
+	case r.Method == http.MethodGet && path == "/2.0/workspaces/ws":
+		_, _ = io.WriteString(w, `{"uuid": "{ws}", "slug": "ws"}`)
+	case r.Method == http.MethodGet && path == "/2.0/workspaces/ws/members":
+		pageLen, _ := strconv.Atoi(r.URL.Query().Get("pagelen"))
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := min(max(pageNumber-1, 0)*pageLen, len(f.members))
+		end := min(start+pageLen, len(f.members))
+		page := cloudPage[cloudMembership]{}
+		for _, u := range f.members[start:end] {
+			page.Values = append(page.Values, cloudMembership{User: u})
+		}
+		if end < len(f.members) {
+			page.Next = fmt.Sprintf("http://%s%s?pagelen=%d&page=%d", r.Host, path, pageLen, max(pageNumber, 1)+1)
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, page)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/2.0/workspaces/ws/members/"):
+		for _, u := range f.members {
+			if u.UUID == strings.TrimPrefix(path, "/2.0/workspaces/ws/members/") {
+				fakehttp.WriteJSON(w, http.StatusOK, cloudMembership{User: u})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"type": "error", "error": {"message": "No workspace member found"}}`)
+	case r.Method == http.MethodGet && path == "/1.0/groups/ws":
+		fakehttp.WriteJSON(w, http.StatusOK, f.groups)
+	case r.Method == http.MethodPost && path == "/1.0/groups/ws":
+		_ = r.ParseForm()
+		group := &cloudGroup{Name: r.PostForm.Get("name"), Slug: strings.ToLower(r.PostForm.Get("name")),
+			Members: []cloudUser{}}
+		f.groups = append(f.groups, group)
+		fakehttp.WriteJSON(w, http.StatusOK, group)
+	case r.Method == http.MethodPut && strings.Contains(path, "/permissions-config/groups/"):
+		var permission cloudPermission
+		_ = json.NewDecoder(r.Body).Decode(&permission)
+		target, slug, _ := strings.Cut(path, "/permissions-config/groups/")
+		f.grants[strings.TrimPrefix(strings.TrimPrefix(target, "/2.0/repositories/ws/"), "/2.0/workspaces/ws/")+" "+slug] =
+			permission.Permission
+		fakehttp.WriteJSON(w, http.StatusOK, permission)
+	case strings.HasPrefix(path, "/1.0/groups/ws/"):
+		slug, member, _ := strings.Cut(strings.TrimPrefix(path, "/1.0/groups/ws/"), "/members")
+		index := slices.IndexFunc(f.groups, func(g *cloudGroup) bool { return g.Slug == slug })
+		if index < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, "Group not found")
+			return
+		}
+		f.serveGroup(w, r, index, strings.TrimPrefix(member, "/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"type": "error", "error": {"message": "Resource not found"}}`)
+	}
+}
+
+// serveGroup serves the requests on the group, or on its member when uuid is set
+func (f *fakeCloud) serveGroup(w http.ResponseWriter, r *http.Request, index int, uuid string) {
+	group := f.groups[index]
+	member := slices.IndexFunc(group.Members, func(u cloudUser) bool { return u.UUID == uuid })
+	switch {
+	case r.Method == http.MethodDelete && uuid == "" && !strings.HasSuffix(r.URL.Path, "/members"):
+		f.groups = slices.Delete(f.groups, index, index+1)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		fakehttp.WriteJSON(w, http.StatusOK, group.Members)
+	case r.Method == http.MethodPut && member >= 0:
+		w.WriteHeader(http.StatusConflict)
+		_, _ = io.WriteString(w, "User is already a member of the group")
+	case r.Method == http.MethodPut:
+		for _, u := range f.members {
+			if u.UUID == uuid {
+				group.Members = append(group.Members, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, group.Members[len(group.Members)-1])
+	case r.Method == http.MethodDelete && member < 0:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "User is not a member of the group")
+	case r.Method == http.MethodDelete:
+		group.Members = slices.Delete(group.Members, member, member+1)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestClient(t *testing.T, handler http.Handler, connection map[string]interface{}) *BitbucketClient {
+	t.Helper()
+	server := fakehttp.NewServer(t, handler)
+
+	connection["url"] = server.URL + "/"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func newServerClient(t *testing.T, fake *fakeServer) *BitbucketClient {
+	if fake.groups == nil {
+		fake.groups = map[string][]string{}
+	}
+	fake.grants = map[string]string{}
+	return newTestClient(t, fake, map[string]interface{}{"token": "token"})
+}
+
+func newCloudClient(t *testing.T, fake *fakeCloud) *BitbucketClient {
+	fake.grants = map[string]string{}
+	return newTestClient(t, fake, map[string]interface{}{
+		"flavor": "cloud", "workspace": "ws", "username": "admin", "app_password": "app-password",
+	})
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"url": "https://bitbucket.example.com"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "url or token")
+
+	_, err = NewClient(map[string]interface{}{"flavor": "cloud", "workspace": "ws", "username": "admin"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "workspace, username or app_password")
+
+	_, err = NewClient(map[string]interface{}{"flavor": "datacenter"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid bitbucket flavor")
+}
+
+func TestServerUsers(t *testing.T) {
+	fake := &fakeServer{}
+	for i := 1; i <= 150; i++ {
+		fake.users = append(fake.users, serverUser{ID: int64(i), Name: fmt.Sprintf("user%d", i),
+			EmailAddress: fmt.Sprintf("user%d@example.com", i), Active: true})
+	}
+	client := newServerClient(t, fake)
+
+	var pages []int
+	require.NoError(t, client.ForEachUserPage(context.Background(), func(users []*structs.User) error {
+		pages = append(pages, len(users))
+		return nil
+	}))
+	assert.Equal(t, []int{100, 50}, pages)
+	_, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "user150", byEmail["user150@example.com"].ID)
+
+	// users are looked up in the user directory of Bitbucket, never created
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "user15@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "user15", user.ID)
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	assert.ErrorContains(t, err, "bitbucket user jdoe not found")
+
+	user, err = client.FetchUserDetails(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, "user1@example.com", user.Email)
+	_, err = client.FetchUserDetails(context.Background(), "jdoe")
+	assert.True(t, request.IsNotFound(err))
+	assert.NoError(t, client.DeleteUser(context.Background(), "user1"))
+	assert.Len(t, fake.users, 150)
+}
+
+func TestServerGroups(t *testing.T) {
+	fake := &fakeServer{users: []serverUser{{Name: "jdoe"}, {Name: "asmith"}}}
+	client := newServerClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "dataeng", Name: "dataeng"}, team)
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"dataeng": {ID: "dataeng", Name: "dataeng"}}, teams)
+	team, err = client.FetchTeamDetails(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Equal(t, "dataeng", team.ID)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"jdoe", "asmith"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"jdoe"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"asmith": {ID: "asmith", UserName: "asmith"}}, members)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+}
+
+func TestServerRepositoryPermissions(t *testing.T) {
+	fake := &fakeServer{groups: map[string][]string{"dataeng": nil}}
+	client := newServerClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "repository_permissions", Value: []string{"DATA/pipelines:write", "DATA", "OPS/infra:admin"},
+	}))
+	assert.Equal(t, map[string]string{
+		"DATA/pipelines dataeng": "REPO_WRITE",
+		"DATA dataeng":           "PROJECT_READ",
+		"OPS/infra dataeng":      "REPO_ADMIN",
+	}, fake.grants)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "repository_permissions", Value: []string{"DATA/pipelines:owner"},
+	}), "unknown bitbucket permission")
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "branch_permissions", Value: []string{"DATA"},
+	}), "unsupported bitbucket group param")
+}
+
+func TestCloudUsers(t *testing.T) {
+	fake := &fakeCloud{}
+	for i := 1; i <= 150; i++ {
+		fake.members = append(fake.members, cloudUser{UUID: fmt.Sprintf("{u-%d}", i), Nickname: fmt.Sprintf("user%d", i)})
+	}
+	client := newCloudClient(t, fake)
+
+	var pages []int
+	require.NoError(t, client.ForEachUserPage(context.Background(), func(users []*structs.User) error {
+		pages = append(pages, len(users))
+		return nil
+	}))
+	assert.Equal(t, []int{100, 50}, pages)
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 150)
+	assert.Empty(t, byEmail)
+
+	// workspace members are matched by nickname, Bitbucket Cloud has no emails
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "user120", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "{u-120}", user.ID)
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe"})
+	assert.ErrorContains(t, err, "bitbucket user jdoe not found")
+
+	user, err = client.FetchUserDetails(context.Background(), "{u-2}")
+	require.NoError(t, err)
+	assert.Equal(t, "user2", user.UserName)
+}
+
+func TestCloudGroups(t *testing.T) {
+	fake := &fakeCloud{members: []cloudUser{{UUID: "{u-1}", Nickname: "jdoe"}, {UUID: "{u-2}", Nickname: "asmith"}}}
+	client := newCloudClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "DataEng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "dataeng", Name: "DataEng"}, team)
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"DataEng": {ID: "dataeng", Name: "DataEng"}}, teams)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"{u-1}", "{u-2}"}))
+	// adding a member again and removing a non member are no-ops
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"{u-1}"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"{u-1}", "{u-3}"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"{u-2}": {ID: "{u-2}", UserName: "asmith"}}, members)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "repository_permissions", Value: []string{"DATA/pipelines:write", "DATA:admin"},
+	}))
+	assert.Equal(t, map[string]string{
+		"pipelines dataeng":     "write",
+		"projects/DATA dataeng": "admin",
+	}, fake.grants)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.Empty(t, fake.groups)
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+}
+
+func TestHealthCheck(t *testing.T) {
+	assert.NoError(t, newServerClient(t, &fakeServer{}).HealthCheck(context.Background()))
+	assert.NoError(t, newCloudClient(t, &fakeCloud{}).HealthCheck(context.Background()))
+
+	client := newTestClient(t, &fakeServer{}, map[string]interface{}{"token": "wrong"})
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "Authentication failed")
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// cloudAPI is the API of Bitbucket Cloud. Users are the Atlassian accounts of the workspace
+// members, identified by their UUID, and the user groups of the workspace are managed through the
+// 1.0 groups API, identified by their slug.
+type cloudAPI struct {
+	bC        *BitbucketClient
+	workspace string
+}
+
+func (c *cloudAPI) workspacePath() string {
+	return "/2.0/workspaces/" + url.PathEscape(c.workspace)
+}
+
+func (c *cloudAPI) groupsPath() string {
+	return "/1.0/groups/" + url.PathEscape(c.workspace)
+}
+
+func (c *cloudAPI) healthCheck(ctx context.Context) error {
+	var workspace struct {
+		UUID string `json:"uuid"`
+	}
+	return c.bC.get(ctx, c.workspacePath(), &workspace, "backend.bitbucket.HealthCheck")
+}
+
+func (c *cloudAPI) forEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachCloudPage(ctx, c.bC, c.workspacePath()+"/members", "backend.bitbucket.FetchAllUsers",
+		func(memberships []cloudMembership) error {
+			page := make([]*structs.User, 0, len(memberships))
+			for _, membership := range memberships {
+				page = append(page, cloudUserDetails(&membership.User))
+			}
+			return fn(page)
+		})
+}
+
+func (c *cloudAPI) fetchUser(ctx context.Context, userID string) (*structs.User, error) {
+	var membership cloudMembership
+	if err := c.bC.get(ctx, c.workspacePath()+"/members/"+url.PathEscape(userID), &membership,
+		"backend.bitbucket.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return cloudUserDetails(&membership.User), nil
+}
+
+// findUser looks the workspace member up by nickname, Bitbucket Cloud does not expose the emails
+// of the users
+func (c *cloudAPI) findUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	if u.UserName == "" {
+		return nil, nil
+	}
+	var found *structs.User
+	err := c.forEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			if strings.EqualFold(user.UserName, u.UserName) {
+				found = user
+				return errStopPaging
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, err
+	}
+	return found, nil
+}
+
+// forEachTeamPage returns the user groups as a single page, the 1.0 groups API is not paginated
+func (c *cloudAPI) forEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	groups, err := c.listGroups(ctx, "backend.bitbucket.FetchAllTeams")
+	if err != nil {
+		return err
+	}
+	page := make([]structs.Team, 0, len(groups))
+	for _, group := range groups {
+		page = append(page, structs.Team{ID: group.Slug, Name: group.Name})
+	}
+	return fn(page)
+}
+
+func (c *cloudAPI) fetchTeam(ctx context.Context, teamID string) (*structs.Team, error) {
+	groups, err := c.listGroups(ctx, "backend.bitbucket.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.Slug == teamID {
+			return &structs.Team{ID: group.Slug, Name: group.Name}, nil
+		}
+	}
+	return nil, &request.ResponseError{Service: "bitbucket", StatusCode: http.StatusNotFound,
+		Message: "group " + teamID + " not found"}
+}
+
+func (c *cloudAPI) listGroups(ctx context.Context, methodName string) ([]cloudGroup, error) {
+	var groups []cloudGroup
+	if err := c.bC.get(ctx, c.groupsPath(), &groups, methodName); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// createTeam creates the user group, the 1.0 groups API takes a form
+func (c *cloudAPI) createTeam(ctx context.Context, name string) (*structs.Team, error) {
+	headers := make(map[string]string, len(c.bC.headers))
+	for key, value := range c.bC.headers {
+		headers[key] = value
+	}
+	headers[constants.ContentTypeHeaderKey] = "application/x-www-form-urlencoded"
+
+	resp, err := c.bC.send(ctx, c.groupsPath(), http.MethodPost, []byte(url.Values{"name": {name}}.Encode()),
+		headers, "backend.bitbucket.CreateTeam")
+	if err != nil {
+		return nil, err
+	}
+	var group cloudGroup
+	if err := decode(resp, &group); err != nil {
+		return nil, err
+	}
+	if group.Slug == "" {
+		return nil, errors.New("no group slug in the bitbucket create group response")
+	}
+	return &structs.Team{ID: group.Slug, Name: group.Name}, nil
+}
+
+func (c *cloudAPI) deleteTeam(ctx context.Context, teamID string) error {
+	_, err := c.bC.sendRequest(ctx, c.groupsPath()+"/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.bitbucket.DeleteTeamByID")
+	return err
+}
+
+func (c *cloudAPI) fetchMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	var users []cloudUser
+	if err := c.bC.get(ctx, c.groupsPath()+"/"+url.PathEscape(teamID)+"/members", &users,
+		"backend.bitbucket.FetchTeamMembersByTeamID"); err != nil {
+		return nil, err
+	}
+	members := make(map[string]*structs.User, len(users))
+	for _, u := range users {
+		members[u.UUID] = cloudUserDetails(&u)
+	}
+	return members, nil
+}
+
+// addMembers adds the users to the group one at a time, a user who is already a member is left as is
+func (c *cloudAPI) addMembers(ctx context.Context, teamID string, userIDs []string) error {
+	for _, userID := range userIDs {
+		_, err := c.bC.sendRequest(ctx, c.memberPath(teamID, userID), http.MethodPut, struct{}{},
+			"backend.bitbucket.AddUserToTeam")
+		if err != nil && !request.IsConflict(err) {
+			return fmt.Errorf("failed to add user %s: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// removeMembers removes the users from the group one at a time, skipping the 404 of Bitbucket
+// Cloud for a user who already left it
+func (c *cloudAPI) removeMembers(ctx context.Context, teamID string, userIDs []string) error {
+	for _, userID := range userIDs {
+		_, err := c.bC.sendRequest(ctx, c.memberPath(teamID, userID), http.MethodDelete, nil,
+			"backend.bitbucket.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+func (c *cloudAPI) memberPath(teamID, userID string) string {
+	return c.groupsPath() + "/" + url.PathEscape(teamID) + "/members/" + url.PathEscape(userID)
+}
+
+// grant sets the permission of the group on the repository of the workspace, or on the project.
+// Repository slugs are unique in a workspace, so the project of a repository is not part of its path.
+func (c *cloudAPI) grant(ctx context.Context, teamID string, target permissionTarget) error {
+	path := c.workspacePath() + "/projects/" + url.PathEscape(target.Project)
+	if target.Repository != "" {
+		path = "/2.0/repositories/" + url.PathEscape(c.workspace) + "/" + url.PathEscape(target.Repository)
+	}
+	_, err := c.bC.sendRequest(ctx, path+"/permissions-config/groups/"+url.PathEscape(teamID), http.MethodPut,
+		cloudPermission{Permission: target.Permission}, "backend.bitbucket.ReconcileGroupParams")
+	return err
+}
+
+// cloudUserDetails converts the Bitbucket Cloud account, its nickname is its username
+func cloudUserDetails(u *cloudUser) *structs.User {
+	return &structs.User{
+		ID:          u.UUID,
+		UserName:    u.Nickname,
+		DisplayName: u.DisplayName,
+	}
+}
+
+// forEachCloudPage calls fn with each page of the resources of the path, following the next links
+// of the pages
+func forEachCloudPage[T any](ctx context.Context, bC *BitbucketClient, path string, methodName string,
+	fn func(resources []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	for next := fmt.Sprintf("%s%spagelen=%d", path, separator, pageSize); next != ""; {
+		var page cloudPage[T]
+		if err := bC.get(ctx, next, &page, methodName); err != nil {
+			return err
+		}
+		if err := fn(page.Values); err != nil {
+			return err
+		}
+		next = page.Next
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamRepositoryPermissions is the group param property listing the repositories and
+// projects of the group, e.g. PROJ/repo:write or PROJ:read
+const groupParamRepositoryPermissions = "repository_permissions"
+
+// permissionTarget is the permission granted to a group on a repository, or on a project when
+// Repository is empty
+type permissionTarget struct {
+	Project    string
+	Repository string
+	Permission string
+}
+
+// parsePermissionTarget parses <project>/<repository>[:permission] or <project>[:permission], the
+// permission defaults to read
+func parsePermissionTarget(value string) (permissionTarget, error) {
+	path, permission, found := strings.Cut(value, ":")
+	if !found {
+		permission = PermissionRead
+	}
+	if _, ok := serverPermissions[permission]; !ok {
+		return permissionTarget{}, fmt.Errorf("unknown bitbucket permission %q in %q", permission, value)
+	}
+	project, repository, _ := strings.Cut(path, "/")
+	if project == "" || strings.Contains(repository, "/") || (strings.Contains(path, "/") && repository == "") {
+		return permissionTarget{}, fmt.Errorf("invalid bitbucket repository permission %q, expected "+
+			"<project>/<repository>[:permission] or <project>[:permission]", value)
+	}
+	return permissionTarget{Project: project, Repository: repository, Permission: permission}, nil
+}
+
+// ReconcileGroupParams grants the group the permissions of the repository_permissions group param,
+// updating the permission of the repositories and projects the group already has access to.
+// Neither flavor of Bitbucket lists the repositories a group has access to, so the permissions on
+// the repositories and projects no longer listed are left as is.
+func (bC *BitbucketClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamRepositoryPermissions {
+		return fmt.Errorf("unsupported bitbucket group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "bitbucket")
+
+	for _, value := range groupParams.Value {
+		target, err := parsePermissionTarget(value)
+		if err != nil {
+			return err
+		}
+		log.WithField("permission", value).Info("granting the bitbucket group the permission")
+		if err := bC.api.grant(ctx, teamID, target); err != nil {
+			log.WithField("permission", value).WithError(err).Error("failed to grant the bitbucket group the permission")
+			return fmt.Errorf("failed to grant %s: %w", value, err)
+		}
+	}
+	return nil
+}
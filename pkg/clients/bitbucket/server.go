@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// serverAPIPath is the path of the REST API of Bitbucket Server
+const serverAPIPath = "/rest/api/1.0"
+
+// serverPermissions are the Bitbucket Server permissions of a group on a repository or a project
+var serverPermissions = map[string]string{
+	PermissionRead:  "READ",
+	PermissionWrite: "WRITE",
+	PermissionAdmin: "ADMIN",
+}
+
+// serverAPI is the API of Bitbucket Server and Data Center. Users and groups are identified by
+// their names, and the users are provisioned by the user directory of Bitbucket.
+type serverAPI struct {
+	bC *BitbucketClient
+}
+
+func (s *serverAPI) healthCheck(ctx context.Context) error {
+	var page serverPage[serverGroup]
+	return s.bC.get(ctx, serverAPIPath+"/admin/groups?limit=1", &page, "backend.bitbucket.HealthCheck")
+}
+
+func (s *serverAPI) forEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachServerPage(ctx, s.bC, serverAPIPath+"/admin/users", "backend.bitbucket.FetchAllUsers",
+		func(users []serverUser) error {
+			page := make([]*structs.User, 0, len(users))
+			for _, u := range users {
+				page = append(page, serverUserDetails(&u))
+			}
+			return fn(page)
+		})
+}
+
+func (s *serverAPI) fetchUser(ctx context.Context, userID string) (*structs.User, error) {
+	user, err := s.filterUser(ctx, userID, func(u *serverUser) bool { return u.Name == userID },
+		"backend.bitbucket.FetchUserDetails")
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, &request.ResponseError{Service: "bitbucket", StatusCode: http.StatusNotFound,
+			Message: "user " + userID + " not found"}
+	}
+	return serverUserDetails(user), nil
+}
+
+func (s *serverAPI) findUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	filter := u.Email
+	if filter == "" {
+		filter = u.UserName
+	}
+	user, err := s.filterUser(ctx, filter, func(user *serverUser) bool {
+		return (u.Email != "" && strings.EqualFold(user.EmailAddress, u.Email)) ||
+			(u.UserName != "" && user.Name == u.UserName)
+	}, "backend.bitbucket.CreateUser")
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return serverUserDetails(user), nil
+}
+
+// filterUser returns the first user matching the filter, the filter of Bitbucket Server matches
+// the usernames, display names and emails containing it
+func (s *serverAPI) filterUser(ctx context.Context, filter string, match func(u *serverUser) bool,
+	methodName string) (*serverUser, error) {
+
+	var found *serverUser
+	err := forEachServerPage(ctx, s.bC, serverAPIPath+"/admin/users?filter="+url.QueryEscape(filter), methodName,
+		func(users []serverUser) error {
+			for _, u := range users {
+				if match(&u) {
+					found = &u
+					return errStopPaging
+				}
+			}
+			return nil
+		})
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (s *serverAPI) forEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return forEachServerPage(ctx, s.bC, serverAPIPath+"/admin/groups", "backend.bitbucket.FetchAllTeams",
+		func(groups []serverGroup) error {
+			page := make([]structs.Team, 0, len(groups))
+			for _, group := range groups {
+				page = append(page, structs.Team{ID: group.Name, Name: group.Name})
+			}
+			return fn(page)
+		})
+}
+
+func (s *serverAPI) fetchTeam(ctx context.Context, teamID string) (*structs.Team, error) {
+	var team *structs.Team
+	err := forEachServerPage(ctx, s.bC, serverAPIPath+"/admin/groups?filter="+url.QueryEscape(teamID),
+		"backend.bitbucket.FetchTeamDetails", func(groups []serverGroup) error {
+			for _, group := range groups {
+				if group.Name == teamID {
+					team = &structs.Team{ID: group.Name, Name: group.Name}
+					return errStopPaging
+				}
+			}
+			return nil
+		})
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, err
+	}
+	if team == nil {
+		return nil, &request.ResponseError{Service: "bitbucket", StatusCode: http.StatusNotFound,
+			Message: "group " + teamID + " not found"}
+	}
+	return team, nil
+}
+
+func (s *serverAPI) createTeam(ctx context.Context, name string) (*structs.Team, error) {
+	resp, err := s.bC.sendRequest(ctx, serverAPIPath+"/admin/groups?name="+url.QueryEscape(name), http.MethodPost,
+		nil, "backend.bitbucket.CreateTeam")
+	if err != nil {
+		return nil, err
+	}
+	var group serverGroup
+	if err := decode(resp, &group); err != nil {
+		return nil, err
+	}
+	if group.Name == "" {
+		group.Name = name
+	}
+	return &structs.Team{ID: group.Name, Name: group.Name}, nil
+}
+
+func (s *serverAPI) deleteTeam(ctx context.Context, teamID string) error {
+	_, err := s.bC.sendRequest(ctx, serverAPIPath+"/admin/groups?name="+url.QueryEscape(teamID), http.MethodDelete,
+		nil, "backend.bitbucket.DeleteTeamByID")
+	return err
+}
+
+func (s *serverAPI) fetchMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	members := make(map[string]*structs.User)
+	err := forEachServerPage(ctx, s.bC, serverAPIPath+"/admin/groups/more-members?context="+url.QueryEscape(teamID),
+		"backend.bitbucket.FetchTeamMembersByTeamID", func(users []serverUser) error {
+			for _, u := range users {
+				members[u.Name] = serverUserDetails(&u)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// addMembers adds the users to the group in one request
+func (s *serverAPI) addMembers(ctx context.Context, teamID string, userIDs []string) error {
+	_, err := s.bC.sendRequest(ctx, serverAPIPath+"/admin/groups/add-users", http.MethodPost,
+		serverAddUsers{Group: teamID, Users: userIDs}, "backend.bitbucket.AddUserToTeam")
+	return err
+}
+
+// removeMembers removes the users from the group one at a time, Bitbucket Server has no bulk
+// endpoint for it
+func (s *serverAPI) removeMembers(ctx context.Context, teamID string, userIDs []string) error {
+	for _, userID := range userIDs {
+		if _, err := s.bC.sendRequest(ctx, serverAPIPath+"/admin/groups/remove-user", http.MethodPost,
+			serverRemoveUser{Context: teamID, ItemName: userID}, "backend.bitbucket.RemoveUserFromTeam"); err != nil {
+			return fmt.Errorf("failed to remove user %s: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// grant sets the REPO_* permission of the group on the repository, or the PROJECT_* permission on
+// the project
+func (s *serverAPI) grant(ctx context.Context, teamID string, target permissionTarget) error {
+	path := serverAPIPath + "/projects/" + url.PathEscape(target.Project)
+	permission := "PROJECT_" + serverPermissions[target.Permission]
+	if target.Repository != "" {
+		path += "/repos/" + url.PathEscape(target.Repository)
+		permission = "REPO_" + serverPermissions[target.Permission]
+	}
+	query := url.Values{"permission": {permission}, "name": {teamID}}
+	_, err := s.bC.sendRequest(ctx, path+"/permissions/groups?"+query.Encode(), http.MethodPut, nil,
+		"backend.bitbucket.ReconcileGroupParams")
+	return err
+}
+
+// serverUserDetails converts the Bitbucket Server user, its name is its ID
+func serverUserDetails(u *serverUser) *structs.User {
+	return &structs.User{
+		ID:          u.Name,
+		UserName:    u.Name,
+		Email:       u.EmailAddress,
+		DisplayName: u.DisplayName,
+	}
+}
+
+// forEachServerPage calls fn with each page of the resources of the path, Bitbucket Server pages
+// start at the nextPageStart of the previous page
+func forEachServerPage[T any](ctx context.Context, bC *BitbucketClient, path string, methodName string,
+	fn func(resources []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	for start := 0; ; {
+		var page serverPage[T]
+		if err := bC.get(ctx, fmt.Sprintf("%s%sstart=%d&limit=%d", path, separator, start, pageSize), &page,
+			methodName); err != nil {
+			return err
+		}
+		if err := fn(page.Values); err != nil {
+			return err
+		}
+		if page.IsLastPage || len(page.Values) == 0 {
+			return nil
+		}
+		start = page.NextPageStart
+	}
+}
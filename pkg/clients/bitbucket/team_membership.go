@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the members of the group keyed by user ID
+func (bC *BitbucketClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members, err := bC.api.fetchMembers(ctx, teamID)
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch bitbucket group members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group
+func (bC *BitbucketClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.AddUserToTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("userIDs", userIDs).
+		WithField("service", "bitbucket")
+	log.Info("Add users to bitbucket group")
+
+	if err := bC.api.addMembers(ctx, teamID, userIDs); err != nil {
+		log.WithError(err).Error("failed to add users to bitbucket group")
+		return err
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the group
+func (bC *BitbucketClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.RemoveUserFromTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("userIDs", userIDs).
+		WithField("service", "bitbucket")
+	log.Info("Remove users from bitbucket group")
+
+	if err := bC.api.removeMembers(ctx, teamID, userIDs); err != nil {
+		log.WithError(err).Error("failed to remove users from bitbucket group")
+		return err
+	}
+	return nil
+}
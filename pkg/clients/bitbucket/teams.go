@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups without their members, keyed by name
+func (bC *BitbucketClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := bC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch bitbucket groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the groups, 100 groups at a time on Bitbucket Server
+// and all the user groups of the workspace at once on Bitbucket Cloud
+func (bC *BitbucketClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return bC.api.forEachTeamPage(ctx, fn)
+}
+
+// FetchTeamDetails fetches the group by name on Bitbucket Server, or by slug on Bitbucket Cloud
+func (bC *BitbucketClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.FetchTeamDetails")
+	defer span.Finish()
+
+	return bC.api.fetchTeam(ctx, teamID)
+}
+
+// CreateTeam creates the group, Bitbucket groups have no description
+func (bC *BitbucketClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "bitbucket")
+	log.Info("Create bitbucket group")
+
+	created, err := bC.api.createTeam(ctx, team.Name)
+	if err != nil {
+		log.WithError(err).Error("failed to create bitbucket group")
+		return nil, err
+	}
+	return created, nil
+}
+
+// DeleteTeamByID deletes the group, the permissions of the group go with it
+func (bC *BitbucketClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "bitbucket")
+	log.Info("Delete bitbucket group")
+
+	err := bC.api.deleteTeam(ctx, teamID)
+	if request.IsNotFound(err) {
+		log.Warn("bitbucket group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+// Flavors of Bitbucket
+const (
+	FlavorServer = "server"
+	FlavorCloud  = "cloud"
+)
+
+const (
+	// defaultCloudURL is the API of Bitbucket Cloud
+	defaultCloudURL = "https://api.bitbucket.org"
+	// pageSize is the number of resources requested per list page
+	pageSize = 100
+)
+
+// Permissions granted to a group on a repository or a project by the repository_permissions
+// group param
+const (
+	PermissionRead  = "read"
+	PermissionWrite = "write"
+	PermissionAdmin = "admin"
+)
+
+// BitbucketConfig is the connection of a Bitbucket backend, read from the backend configuration
+type BitbucketConfig struct {
+	// Flavor is server (default) for Bitbucket Server and Data Center, or cloud for Bitbucket Cloud
+	Flavor string `json:"flavor"`
+	// URL is the base URL of Bitbucket Server, or of the API of Bitbucket Cloud
+	// (https://api.bitbucket.org by default)
+	URL string `json:"url"`
+	// Workspace is the workspace of the user groups on Bitbucket Cloud
+	Workspace string `json:"workspace"`
+	// Token is an HTTP access token of an admin on Bitbucket Server
+	Token string `json:"token"`
+	// Username and AppPassword are the credentials of an admin of the workspace on Bitbucket Cloud,
+	// the user groups API does not accept access tokens
+	Username    string `json:"username"`
+	AppPassword string `json:"app_password"`
+}
+
+// serverPage is a page of the resources of a Bitbucket Server list request
+type serverPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// serverUser is a user of Bitbucket Server, its name is its username
+type serverUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	Active       bool   `json:"active"`
+}
+
+// serverGroup is a group of Bitbucket Server, identified by its name
+type serverGroup struct {
+	Name string `json:"name"`
+}
+
+// serverAddUsers adds users to a group of Bitbucket Server
+type serverAddUsers struct {
+	Group string   `json:"group"`
+	Users []string `json:"users"`
+}
+
+// serverRemoveUser removes a user from a group of Bitbucket Server
+type serverRemoveUser struct {
+	Context  string `json:"context"`
+	ItemName string `json:"itemName"`
+}
+
+// cloudPage is a page of the resources of a Bitbucket Cloud 2.0 list request
+type cloudPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// cloudUser is an Atlassian account of Bitbucket Cloud, identified by its UUID. The API does not
+// expose the emails of the users.
+type cloudUser struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+}
+
+// cloudMembership is a membership of a user in the workspace
+type cloudMembership struct {
+	User cloudUser `json:"user"`
+}
+
+// cloudGroup is a user group of a Bitbucket Cloud workspace, identified by its slug
+type cloudGroup struct {
+	Name    string      `json:"name"`
+	Slug    string      `json:"slug"`
+	Members []cloudUser `json:"members,omitempty"`
+}
+
+// cloudPermission is the permission of a group on a repository or a project of Bitbucket Cloud
+type cloudPermission struct {
+	Permission string `json:"permission"`
+}
+
+// errorResponse is the error response of Bitbucket Server, with a list of errors, or of Bitbucket
+// Cloud, with one error
+type errorResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
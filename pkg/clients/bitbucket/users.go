@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers lists the users of Bitbucket Server, or the members of the workspace on Bitbucket
+// Cloud, keyed by ID and by email. Bitbucket Cloud users have no email.
+func (bC *BitbucketClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := bC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch bitbucket users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users, 100 users at a time
+func (bC *BitbucketClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return bC.api.forEachUserPage(ctx, fn)
+}
+
+// FetchUserDetails fetches the user by name on Bitbucket Server, or the workspace member by UUID
+// on Bitbucket Cloud
+func (bC *BitbucketClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.FetchUserDetails")
+	defer span.Finish()
+
+	return bC.api.fetchUser(ctx, userID)
+}
+
+// CreateUser looks the existing user up, Bitbucket has no API creating users: the users of
+// Bitbucket Server come from its user directory and the Atlassian accounts join the workspace of
+// Bitbucket Cloud on invitation. Server users are matched by email or username, Cloud members by
+// nickname.
+func (bC *BitbucketClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("username", u.UserName).
+		WithField("service", "bitbucket")
+	log.Info("Look up bitbucket user")
+
+	user, err := bC.api.findUser(ctx, u)
+	if err != nil {
+		log.WithError(err).Error("failed to look up bitbucket user")
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("bitbucket user %s not found, bitbucket users are provisioned outside of usernaut",
+			u.UserName)
+	}
+	return user, nil
+}
+
+// DeleteUser leaves the user as is, the users are provisioned outside of usernaut and lose their
+// access with their group memberships
+func (bC *BitbucketClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.bitbucket.DeleteUser")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("userID", userID).WithField("service", "bitbucket").
+		Info("bitbucket users are not deleted by usernaut, skipping")
+	return nil
+}
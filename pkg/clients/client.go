@@ -19,14 +19,37 @@ package clients
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/airflow"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/artifactory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/atlassian"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/bitbucket"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/databricks"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/dbtcloud"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/entraid"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fivetran"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/genericrest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/github"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/gitlab"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/kafka"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/keycloak"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/minio"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/okta"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/openshift"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/quay"
 	redhatrover "github.com/redhat-data-and-ai/usernaut/pkg/clients/redhat_rover"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/scim"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/slack"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/snowflake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/webhook"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
 )
 
 var (
@@ -34,6 +57,12 @@ var (
 	ErrInvalidBackend = errors.New("invalid backend")
 )
 
+// The plugins serve any backend client, and are backend clients themselves
+var (
+	_ plugin.Backend = Client(nil)
+	_ Client         = (*plugin.PluginClient)(nil)
+)
+
 type Client interface {
 	// Fetches all the users onboarded over the platform
 	// returns 2 maps where:
@@ -60,12 +89,124 @@ type Client interface {
 	FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error)
 	// ReconcileGroupParams reconciles backend-specific parameters for a group/team.
 	ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error
-	// Adds a member to the team
+	// Adds the members to the team. The membership calls receive batches of at most the
+	// membership_batch_size of the backend (see InBatches), which implementations apply with the
+	// bulk endpoint of the backend when it has one, or one request per user otherwise.
 	AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error
-	// Removes a member from the team
+	// Removes the members from the team, in batches like AddUserToTeam
 	RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error
+
+	// Checks that the backend API is reachable with the configured credentials, it is called by the
+	// readiness probe and the backend health job so it must be cheap
+	HealthCheck(ctx context.Context) error
+}
+
+// TeamRoleClient is implemented by backends whose team memberships carry a role,
+// e.g. gitlab access levels. Backend roles are passed through as named in the Group CR.
+type TeamRoleClient interface {
+	// Adds members to the team with the given role
+	AddUserToTeamWithRole(ctx context.Context, teamID string, userIDs []string, role string) error
+	// Changes the role of existing team members
+	UpdateTeamMemberRole(ctx context.Context, teamID string, userIDs []string, role string) error
+}
+
+// MemberRoleValidator is implemented by backends which know the member roles they accept, e.g. the
+// gitlab access levels. A member role without a backend in the Group CR only reaches the backends
+// accepting it, the other backends only get the roles scoped to their backend type.
+type MemberRoleValidator interface {
+	// Reports whether the role is a member role of the backend
+	ValidMemberRole(role string) bool
+}
+
+// NestedTeamClient is implemented by backends whose teams can contain other teams, e.g. gitlab
+// groups shared with other groups. It mirrors the member groups of a group with the Mirror groups policy.
+type NestedTeamClient interface {
+	// Returns the IDs of the teams nested in the team
+	FetchNestedTeams(ctx context.Context, teamID string) ([]string, error)
+	// Nests the teams in the team
+	AddNestedTeams(ctx context.Context, teamID string, nestedTeamIDs []string) error
+	// Removes the nested teams from the team
+	RemoveNestedTeams(ctx context.Context, teamID string, nestedTeamIDs []string) error
+}
+
+// DependentClient is implemented by backends which can consume the backend they depend on through
+// depends_on, e.g. gitlab syncing its group membership from the LDAP group managed by rover.
+type DependentClient interface {
+	// Configures the client for the team of the group, once the team of the group in the backend it
+	// depends on exists. Reports whether the team membership is then managed by the dependency
+	// instead of being reconciled by usernaut.
+	ConfigureDependency(ctx context.Context, dependsOn config.Dependant, groupName string) (bool, error)
+}
+
+// PendingUserClient is implemented by backends whose users may not have an account yet, e.g. gitlab
+// users invited to the groups by email until they first sign in. The cached ID of a pending user
+// is resolved again with CreateUser on each reconcile, until the account replaces the invitation.
+type PendingUserClient interface {
+	// Reports whether the user ID is the one of a user without an account
+	IsPendingUser(userID string) bool
+}
+
+// The gitlab backend invites the users without an account by email
+var _ PendingUserClient = (*gitlab.GitlabClient)(nil)
+
+// The backends with a fixed set of member roles only get the unscoped roles they accept
+var (
+	_ MemberRoleValidator = (*gitlab.GitlabClient)(nil)
+	_ MemberRoleValidator = (*github.GithubClient)(nil)
+	_ MemberRoleValidator = (*fivetran.FivetranClient)(nil)
+	_ MemberRoleValidator = (*fake.Backend)(nil)
+)
+
+// DirectMemberClient is implemented by backends which can list the members added to a team itself,
+// e.g. gitlab groups whose membership is synced from LDAP. It audits the teams managed by their
+// dependency with the direct_member_audit of the backend.
+type DirectMemberClient interface {
+	// Returns the members of the team added to it directly, without the inherited or shared members
+	FetchDirectTeamMembers(ctx context.Context, teamID string) (map[string]*structs.User, error)
+	// Removes the direct members from the team, also when its membership is managed by the dependency
+	RemoveDirectTeamMembers(ctx context.Context, teamID string, userIDs []string) error
+}
+
+// The gitlab backend audits the members added to the groups outside of their LDAP or SAML link
+var _ DirectMemberClient = (*gitlab.GitlabClient)(nil)
+
+// The fake backend exercises the member roles of the reconcile
+var (
+	_ Client         = (*fake.Backend)(nil)
+	_ TeamRoleClient = (*fake.Backend)(nil)
+)
+
+// The github backend manages the member and maintainer roles of the teams
+var _ TeamRoleClient = (*github.GithubClient)(nil)
+
+// The webhook backend pushes the membership of the teams it is given
+var _ MembershipPublisher = (*webhook.WebhookClient)(nil)
+
+// MemberParamsClient is implemented by backends whose group params apply to the members of the team
+// rather than to the team, e.g. the network policy of the Snowflake users. It is called once the
+// members of the team are added and removed, including when the backend has no group params so
+// that the params dropped from the group are unset.
+type MemberParamsClient interface {
+	// Applies the group params of the backend to the members of the team and unsets them from the
+	// users removed from the team
+	ReconcileMemberParams(ctx context.Context, teamID string, groupParams []structs.TeamParams,
+		memberIDs, removedIDs []string) error
 }
 
+// The snowflake backend sets the network policy of the group on the users of the role
+var _ MemberParamsClient = (*snowflake.SnowflakeClient)(nil)
+
+// MembershipPublisher is implemented by backends which push the membership of the teams to systems
+// usernaut does not integrate with, e.g. the webhook backend. It is called at the end of every
+// reconcile of the team, whether its membership changed or not.
+type MembershipPublisher interface {
+	// Publishes the membership of the team resolved by the reconcile
+	PublishMembership(ctx context.Context, membership structs.TeamMembership) error
+}
+
+// New creates the client of the backend, bounded by the timeouts of the backend config (see WithTimeouts)
+// and serving the teams from the response cache of the backend (see WithResponseCache). The
+// capabilities of the client are looked up with As.
 func New(backendName, backendType string, backends map[string]map[string]config.Backend) (Client, error) {
 	backend, ok := backends[backendType][backendName]
 	if !ok {
@@ -74,45 +215,306 @@ func New(backendName, backendType string, backends map[string]map[string]config.
 	if !backend.Enabled {
 		return nil, errors.New("backend is not enabled")
 	}
+	backendClient, err := newClient(backendName, backendType, backend)
+	if err != nil {
+		return nil, err
+	}
+	backendClient, err = WithTimeouts(backendClient, backend.Timeouts)
+	if err != nil {
+		return nil, err
+	}
+	// The cached responses are served before the timeouts, which only bound the calls to the backend
+	return withConfiguredResponseCache(backendClient, backendName+"_"+backendType, backend.ResponseCacheTTL)
+}
+
+// newClient creates the client of the backend type
+func newClient(backendName, backendType string, backend config.Backend) (Client, error) {
+	// The rate limiter of the backend is shared by all its clients, whichever reconcile created them
+	rateLimiter := httpclient.SharedRateLimiter(backendType+"/"+backendName,
+		backend.RateLimit.RequestsPerSecond, backend.RateLimit.Burst)
+	// The transport settings of the http section of the connection override the pool config
+	overrides, err := connectionOverrides(backend)
+	if err != nil {
+		return nil, err
+	}
+	// withBackend applies the transport settings of the backend, labels the request metrics with the
+	// backend and throttles them with its rate limiter
+	withBackend := func(poolCfg httpclient.ConnectionPoolConfig) httpclient.ConnectionPoolConfig {
+		poolCfg = poolCfg.WithOverrides(overrides)
+		poolCfg.RateLimiter = rateLimiter
+		poolCfg.BackendName = backendName
+		poolCfg.BackendType = backendType
+		return poolCfg
+	}
 	switch strings.ToLower(backendType) {
 	case "fivetran":
-		apiKey := backend.GetStringConnection("apikey", "")
-		apiSecret := backend.GetStringConnection("apisecret", "")
-		if apiKey == "" || apiSecret == "" {
-			return nil, errors.New("missing required connection parameters for fivetran backend")
+		fivetranConfig := fivetran.FivetranConfig{}
+		if err := utils.MapToStruct(backend.Connection, &fivetranConfig); err != nil {
+			return nil, err
 		}
 		// Create and return a new Fivetran client
 		// using the API key and secret from the backend configuration
-		return fivetran.NewClient(apiKey, apiSecret), nil
+		fivetranClient, err := fivetran.NewClient(fivetranConfig, withBackend(httpclient.ConnectionPoolConfig{}))
+		if err != nil {
+			return nil, err
+		}
+		return fivetranClient, nil
 	case "rover":
 		appConfig, err := config.GetConfig()
 		if err != nil {
 			return nil, err
 		}
 
-		return redhatrover.NewClient(backend.Connection,
-			appConfig.HttpClient.ConnectionPoolConfig, appConfig.HttpClient.HystrixResiliencyConfig)
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		return redhatrover.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
 	case "snowflake":
 		appConfig, err := config.GetConfig()
 		if err != nil {
 			return nil, err
 		}
 
-		return snowflake.NewClient(backend.Connection,
-			appConfig.HttpClient.ConnectionPoolConfig, appConfig.HttpClient.HystrixResiliencyConfig)
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		return snowflake.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
 	case "gitlab":
 		appConfig, err := config.GetConfig()
 		if err != nil {
 			return nil, err
 		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
 		gitlabClient, err := gitlab.NewClient(backend.Connection, backend.DependsOn,
-			appConfig.HttpClient.ConnectionPoolConfig, appConfig.HttpClient.HystrixResiliencyConfig)
+			poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
 		if err != nil {
 			return nil, err
 		}
 		return gitlabClient, nil
+	case "github":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		githubClient, err := github.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return githubClient, nil
+	case "keycloak":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		keycloakClient, err := keycloak.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return keycloakClient, nil
+	case "okta":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		oktaClient, err := okta.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return oktaClient, nil
+	case "entraid":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		entraIDClient, err := entraid.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return entraIDClient, nil
+	case "slack":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		slackClient, err := slack.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return slackClient, nil
+	case "quay":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		quayClient, err := quay.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return quayClient, nil
+	case "atlassian":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		atlassianClient, err := atlassian.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return atlassianClient, nil
+	case "artifactory":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		artifactoryClient, err := artifactory.NewClient(backend.Connection, poolCfg,
+			appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return artifactoryClient, nil
+	case "bitbucket":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		bitbucketClient, err := bitbucket.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return bitbucketClient, nil
+	case "databricks":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		databricksClient, err := databricks.NewClient(backend.Connection, poolCfg,
+			appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return databricksClient, nil
+	case "dbtcloud":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		dbtCloudClient, err := dbtcloud.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return dbtCloudClient, nil
+	case "kafka":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		kafkaClient, err := kafka.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return kafkaClient, nil
+	case "minio":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		minioClient, err := minio.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return minioClient, nil
+	case "airflow":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		airflowClient, err := airflow.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return airflowClient, nil
+	case "openshift":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		openshiftClient, err := openshift.NewClient(backend.Connection, poolCfg)
+		if err != nil {
+			return nil, err
+		}
+		return openshiftClient, nil
+	case "generic-rest":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		// The simple services are driven by the endpoints declared in their connection
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		restClient, err := genericrest.NewClient(backend.Connection,
+			poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return restClient, nil
+	case "scim":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		scimClient, err := scim.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return scimClient, nil
+	case "webhook":
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		poolCfg := withBackend(appConfig.HttpClient.ConnectionPoolConfig)
+		webhookClient, err := webhook.NewClient(backend.Connection, poolCfg, appConfig.HttpClient.HystrixResiliencyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return webhookClient, nil
+	case "fake":
+		// The in-memory state of the fake backend is shared by its clients, for the e2e tests and the
+		// local development
+		return fake.Shared(backendName, backend.Connection)
+	case "plugin":
+		// Out-of-tree backends are served by a plugin process implementing the plugin service
+		pluginClient, err := plugin.NewClient(backend.Connection)
+		if err != nil {
+			return nil, err
+		}
+		return pluginClient, nil
 	default:
 		// If no valid backend type is matched, return an error
 		return nil, ErrInvalidBackend
 	}
 }
+
+// connectionOverrides reads the transport settings of the http section of the backend connection
+func connectionOverrides(backend config.Backend) (httpclient.ConnectionOverrides, error) {
+	overrides := httpclient.ConnectionOverrides{}
+	section, ok := backend.Connection["http"].(map[string]interface{})
+	if !ok {
+		return overrides, nil
+	}
+	if err := utils.MapToStruct(section, &overrides); err != nil {
+		return overrides, fmt.Errorf("invalid http connection settings of backend %s: %w", backend.Name, err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// DatabricksClient manages the users and groups of a Databricks workspace or account through its
+// SCIM API, and the entitlements of the groups
+type DatabricksClient struct {
+	client heimdall.Doer
+	// scimURL is the SCIM API of the workspace or of the account
+	scimURL string
+	// workspaceAssignmentURL is the permission assignments of the workspace the account groups are
+	// assigned to, empty when the groups are not assigned
+	workspaceAssignmentURL string
+	auth                   *tokenSource
+}
+
+func NewClient(databricksAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*DatabricksClient, error) {
+
+	databricksConfig := DatabricksConfig{}
+	if err := utils.MapToStruct(databricksAppConfig, &databricksConfig); err != nil {
+		return nil, err
+	}
+	if databricksConfig.Host == "" {
+		return nil, errors.New("databricks configuration is missing required field: host")
+	}
+	if databricksConfig.Token == "" && (databricksConfig.ClientID == "" || databricksConfig.ClientSecret == "") {
+		return nil, errors.New("databricks configuration is missing required fields: token, or client_id and client_secret")
+	}
+	if databricksConfig.WorkspaceID != "" && databricksConfig.AccountID == "" {
+		return nil, errors.New("databricks workspace_id is only supported with account_id")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"databricks_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	host := strings.TrimSuffix(databricksConfig.Host, "/")
+	databricksClient := &DatabricksClient{
+		client:  client,
+		scimURL: host + "/api/2.0/preview/scim/v2",
+		auth: &tokenSource{
+			client: client,
+			token:  databricksConfig.Token,
+			static: databricksConfig.Token != "",
+			url:    host + "/oidc/v1/token",
+			basicAuth: base64.StdEncoding.EncodeToString(
+				[]byte(databricksConfig.ClientID + ":" + databricksConfig.ClientSecret)),
+		},
+	}
+	if databricksConfig.AccountID != "" {
+		accountID := url.PathEscape(databricksConfig.AccountID)
+		databricksClient.scimURL = fmt.Sprintf("%s/api/2.0/accounts/%s/scim/v2", host, accountID)
+		databricksClient.auth.url = fmt.Sprintf("%s/oidc/accounts/%s/v1/token", host, accountID)
+		if databricksConfig.WorkspaceID != "" {
+			databricksClient.workspaceAssignmentURL = fmt.Sprintf(
+				"%s/api/2.0/accounts/%s/workspaces/%s/permissionassignments/principals", host, accountID,
+				url.PathEscape(databricksConfig.WorkspaceID))
+		}
+	}
+	return databricksClient, nil
+}
+
+// HealthCheck lists a single user, the SCIM API is healthy when it answers the authenticated list
+func (dC *DatabricksClient) HealthCheck(ctx context.Context) error {
+	var page listResponse[User]
+	if err := dC.get(ctx, "/Users?attributes=id&startIndex=1&count=1", &page,
+		"backend.databricks.HealthCheck"); err != nil {
+		return fmt.Errorf("databricks health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path of the SCIM API and decodes its response
+func (dC *DatabricksClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := dC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a Databricks request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode databricks response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the SCIM API and returns the response body
+func (dC *DatabricksClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	return dC.send(ctx, dC.scimURL+path, method, body, methodName)
+}
+
+// send sends the request to the URL with the access token and returns the response body, any
+// response code other than 2xx is returned as a *request.ResponseError
+func (dC *DatabricksClient) send(ctx context.Context, url string, method string, body any,
+	methodName string) ([]byte, error) {
+
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := dC.auth.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return doRequest(ctx, dC.client, url, method, requestBody, map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       "application/json",
+		"Authorization":                "Bearer " + token,
+	}, methodName)
+}
+
+func doRequest(ctx context.Context, client heimdall.Doer, url string, method string, requestBody []byte,
+	headers map[string]string, methodName string) ([]byte, error) {
+	resp, _, err := request.Send(ctx, client, method, url, requestBody, headers, methodName, "databricks", errorMessage)
+	return resp, err
+}
+
+// tokenSource hands out the personal access token, or the OAuth access token of the service
+// principal renewed shortly before it expires
+type tokenSource struct {
+	client heimdall.Doer
+	// static is set for a personal access token, which is never renewed
+	static bool
+	url    string
+	// basicAuth is the encoded client ID and secret of the service principal
+	basicAuth string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// Token returns the access token, requesting a new one for the service principal when the current
+// one is about to expire
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	if ts.static {
+		return ts.token, nil
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && time.Until(ts.expiresAt) > tokenRefreshMargin {
+		return ts.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", oauthScope)
+	resp, err := doRequest(ctx, ts.client, ts.url, http.MethodPost, []byte(form.Encode()), map[string]string{
+		constants.ContentTypeHeaderKey: "application/x-www-form-urlencoded",
+		"Accept":                       "application/json",
+		"Authorization":                "Basic " + ts.basicAuth,
+	}, "backend.databricks.FetchToken")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch databricks access token: %w", err)
+	}
+	var token tokenResponse
+	if err := decode(resp, &token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("no access token in the databricks token response")
+	}
+	ts.token = token.AccessToken
+	ts.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return ts.token, nil
+}
+
+// errorMessage returns the message of an error response of a Databricks API, or of its token
+// endpoint
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	for _, message := range []string{errResp.Detail, errResp.Message, errResp.ErrorDescription, errResp.Error} {
+		if message != "" {
+			return "", message
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// removeFilter matches the path of a PATCH remove operation, e.g. members[value eq "u-1"]
+var removeFilter = regexp.MustCompile(`^(\w+)\[value eq "(.*)"\]$`)
+
+// fakeDatabricks is the SCIM API of a workspace or account holding its users and groups in memory
+type fakeDatabricks struct {
+	users  []User
+	groups map[string]*Group
+	// assigned are the groups assigned to the workspace, failAssignment rejects the assignments
+	assigned       []string
+	failAssignment bool
+	tokens         int
+}
+
+func (f *fakeDatabricks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/oidc/accounts/acc-1/v1/token" {
+		user, password, _ := r.BasicAuth()
+		_ = r.ParseForm()
+		if user != "sp" || password != "secret" || r.Form.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error": "invalid_client", "error_description": "Client authentication failed"}`)
+			return
+		}
+		f.tokens++
+		_, _ = io.WriteString(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error_code": "401", "message": "Credential was not sent or was of an unsupported type"}`)
+		return
+	}
+
+	if groupID, found := strings.CutPrefix(r.URL.Path,
+		"/api/2.0/accounts/acc-1/workspaces/42/permissionassignments/principals/"); found {
+		if f.failAssignment {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"error_code": "PERMISSION_DENIED", "message": "not an account admin"}`)
+			return
+		}
+		f.assigned = append(f.assigned, groupID)
+		_, _ = io.WriteString(w, `{}`)
+		return
+	}
+
+	path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/2.0/preview/scim/v2"),
+		"/api/2.0/accounts/acc-1/scim/v2")
+	switch {
+	case r.Method == http.MethodGet && path == "/Users":
+		startIndex, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		start := min(startIndex-1, len(f.users))
+		end := min(start+count, len(f.users))
+		fakehttp.WriteJSON(w, http.StatusOK, listResponse[User]{
+			TotalResults: len(f.users), StartIndex: startIndex, ItemsPerPage: end - start, Resources: f.users[start:end],
+		})
+	case r.Method == http.MethodPost && path == "/Users":
+		var user User
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		for _, existing := range f.users {
+			if existing.UserName == user.UserName {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = io.WriteString(w, `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"],
+					"detail": "User already exists in another account", "status": "409"}`)
+				return
+			}
+		}
+		user.ID = fmt.Sprintf("u-%d", len(f.users)+1)
+		f.users = append(f.users, user)
+		fakehttp.WriteJSON(w, http.StatusCreated, user)
+	case r.Method == http.MethodGet && path == "/Groups":
+		groups := make([]Group, 0, len(f.groups))
+		for id := 1; id <= len(f.groups)+1; id++ {
+			if group, ok := f.groups[fmt.Sprintf("g-%d", id)]; ok {
+				groups = append(groups, Group{ID: group.ID, DisplayName: group.DisplayName})
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, listResponse[Group]{TotalResults: len(groups), StartIndex: 1, Resources: groups})
+	case r.Method == http.MethodPost && path == "/Groups":
+		var group Group
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		group.ID = fmt.Sprintf("g-%d", len(f.groups)+1)
+		f.groups[group.ID] = &group
+		fakehttp.WriteJSON(w, http.StatusCreated, group)
+	case strings.HasPrefix(path, "/Groups/"):
+		group, ok := f.groups[strings.TrimPrefix(path, "/Groups/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"detail": "Group not found", "status": "404"}`)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			fakehttp.WriteJSON(w, http.StatusOK, group)
+		case http.MethodPatch:
+			var patch patchRequest
+			_ = json.NewDecoder(r.Body).Decode(&patch)
+			f.patch(group, patch)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(f.groups, group.ID)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"detail": "not found", "status": "404"}`)
+	}
+}
+
+// patch applies the add and remove operations of the members and entitlements to the group
+func (f *fakeDatabricks) patch(group *Group, patch patchRequest) {
+	for _, operation := range patch.Operations {
+		switch operation.Op {
+		case "add":
+			values, _ := json.Marshal(operation.Value)
+			if operation.Path == "members" {
+				var members []Member
+				_ = json.Unmarshal(values, &members)
+				for _, member := range members {
+					group.Members = append(group.Members, Member{Value: member.Value, Ref: "Users/" + member.Value})
+				}
+			} else {
+				var entitlements []Value
+				_ = json.Unmarshal(values, &entitlements)
+				group.Entitlements = append(group.Entitlements, entitlements...)
+			}
+		case "remove":
+			match := removeFilter.FindStringSubmatch(operation.Path)
+			if match[1] == "members" {
+				var members []Member
+				for _, member := range group.Members {
+					if member.Value != match[2] {
+						members = append(members, member)
+					}
+				}
+				group.Members = members
+			} else {
+				var entitlements []Value
+				for _, entitlement := range group.Entitlements {
+					if entitlement.Value != match[2] {
+						entitlements = append(entitlements, entitlement)
+					}
+				}
+				group.Entitlements = entitlements
+			}
+		}
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeDatabricks, connection map[string]interface{}) *DatabricksClient {
+	t.Helper()
+	if fake.groups == nil {
+		fake.groups = map[string]*Group{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["host"] = server.URL + "/"
+	if connection["client_id"] == nil {
+		connection["token"] = "token"
+	}
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"token": "token"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "host")
+
+	_, err = NewClient(map[string]interface{}{"host": "https://dbc.cloud.databricks.com", "client_id": "sp"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "token, or client_id and client_secret")
+
+	_, err = NewClient(map[string]interface{}{"host": "https://dbc.cloud.databricks.com", "token": "token",
+		"workspace_id": "42"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "workspace_id is only supported with account_id")
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeDatabricks{}
+	for i := 1; i <= 150; i++ {
+		fake.users = append(fake.users, User{ID: fmt.Sprintf("u-%d", i), UserName: fmt.Sprintf("user%d@example.com", i)})
+	}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	var pages []int
+	require.NoError(t, client.ForEachUserPage(context.Background(), func(users []*structs.User) error {
+		pages = append(pages, len(users))
+		return nil
+	}))
+	assert.Equal(t, []int{100, 50}, pages)
+	_, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "u-150", byEmail["user150@example.com"].ID)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{
+		UserName: "jdoe", Email: "jdoe@example.com", FirstName: "John", LastName: "Doe",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u-151", user.ID)
+	assert.Equal(t, "John Doe", fake.users[150].DisplayName)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+	assert.ErrorContains(t, err, "User already exists in another account")
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe"})
+	assert.ErrorContains(t, err, "has none")
+	assert.NoError(t, client.DeleteUser(context.Background(), "u-404"))
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeDatabricks{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "g-1", Name: "dataeng", Description: "team for dataeng"}, team)
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"dataeng": {ID: "g-1", Name: "dataeng"}}, teams)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "g-1", []string{"u-1", "u-2"}))
+	fake.groups["g-1"].Members = append(fake.groups["g-1"].Members, Member{Value: "sp-1", Ref: "ServicePrincipals/sp-1"})
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "g-1", []string{"u-1"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"u-2": {ID: "u-2"}}, members)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "g-1"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "g-1"))
+}
+
+func TestAccountGroupsAssignedToWorkspace(t *testing.T) {
+	fake := &fakeDatabricks{}
+	client := newTestClient(t, fake, map[string]interface{}{
+		"account_id": "acc-1", "workspace_id": "42", "client_id": "sp", "client_secret": "secret",
+	})
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{team.ID}, fake.assigned)
+
+	// the group is deleted again when it cannot be assigned to the workspace
+	fake.failAssignment = true
+	_, err = client.CreateTeam(context.Background(), &structs.Team{Name: "analytics"})
+	assert.ErrorContains(t, err, "not an account admin")
+	assert.Len(t, fake.groups, 1)
+	// the access token of the service principal is reused until it expires
+	assert.Equal(t, 1, fake.tokens)
+}
+
+func TestReconcileEntitlements(t *testing.T) {
+	fake := &fakeDatabricks{groups: map[string]*Group{
+		"g-1": {ID: "g-1", DisplayName: "dataeng", Entitlements: []Value{{Value: "workspace-access"}}},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "g-1", structs.TeamParams{
+		Property: "entitlements", Value: []string{"allow-cluster-create", "databricks-sql-access"},
+	}))
+	assert.Equal(t, []Value{{Value: "allow-cluster-create"}, {Value: "databricks-sql-access"}},
+		fake.groups["g-1"].Entitlements)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "g-1", structs.TeamParams{
+		Property: "entitlements", Value: []string{},
+	}))
+	assert.Empty(t, fake.groups["g-1"].Entitlements)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "g-1", structs.TeamParams{
+		Property: "cluster_policies", Value: []string{"x"},
+	}), "unsupported databricks group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeDatabricks{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client = newTestClient(t, &fakeDatabricks{}, map[string]interface{}{
+		"account_id": "acc-1", "client_id": "sp", "client_secret": "wrong",
+	})
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "Client authentication failed")
+}
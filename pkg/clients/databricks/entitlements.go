@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamEntitlements is the group param property listing the entitlements of the group, e.g.
+// allow-cluster-create or databricks-sql-access
+const groupParamEntitlements = "entitlements"
+
+// ReconcileGroupParams grants the group exactly the entitlements of the entitlements group param,
+// which its members inherit. The other entitlements of the group are revoked.
+func (dC *DatabricksClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamEntitlements {
+		return fmt.Errorf("unsupported databricks group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "databricks")
+
+	var group Group
+	if err := dC.get(ctx, "/Groups/"+url.PathEscape(teamID)+"?attributes=entitlements", &group,
+		"backend.databricks.ReconcileGroupParams"); err != nil {
+		log.WithError(err).Error("failed to fetch the databricks group entitlements")
+		return err
+	}
+	granted := make([]string, 0, len(group.Entitlements))
+	for _, entitlement := range group.Entitlements {
+		granted = append(granted, entitlement.Value)
+	}
+
+	var operations []patchOperation
+	var added []Value
+	for _, entitlement := range groupParams.Value {
+		if !slices.Contains(granted, entitlement) && !slices.Contains(added, Value{Value: entitlement}) {
+			added = append(added, Value{Value: entitlement})
+		}
+	}
+	if len(added) > 0 {
+		operations = append(operations, patchOperation{Op: "add", Path: "entitlements", Value: added})
+	}
+	for _, entitlement := range granted {
+		if !slices.Contains(groupParams.Value, entitlement) {
+			operations = append(operations, patchOperation{
+				Op:   "remove",
+				Path: fmt.Sprintf("entitlements[value eq %q]", entitlement),
+			})
+		}
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	log.WithField("entitlements", groupParams.Value).Info("updating the entitlements of the databricks group")
+	return dC.patchGroup(ctx, teamID, operations, "backend.databricks.ReconcileGroupParams")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID fetches the user members of the group, keyed by ID. The service
+// principals and nested groups of the group are not reconciled.
+func (dC *DatabricksClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	var group Group
+	if err := dC.get(ctx, "/Groups/"+url.PathEscape(teamID)+"?attributes=members", &group,
+		"backend.databricks.FetchTeamMembersByTeamID"); err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch databricks group members")
+		return nil, err
+	}
+
+	members := make(map[string]*structs.User, len(group.Members))
+	for _, member := range group.Members {
+		if member.Ref != "" && !strings.HasPrefix(member.Ref, "Users/") {
+			continue
+		}
+		members[member.Value] = &structs.User{
+			ID:          member.Value,
+			DisplayName: member.Display,
+		}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the batch of users to the group with a single PATCH add operation
+func (dC *DatabricksClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.AddUserToTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	logger.Logger(ctx).WithField("teamID", teamID).Info("adding team users to the databricks group")
+
+	members := make([]Member, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, Member{Value: userID})
+	}
+	return dC.patchGroup(ctx, teamID, []patchOperation{{Op: "add", Path: "members", Value: members}},
+		"backend.databricks.AddUserToTeam")
+}
+
+// RemoveUserFromTeam removes the batch of users from the group with a PATCH remove operation per
+// user, filtering the members by value
+func (dC *DatabricksClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.RemoveUserFromTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	logger.Logger(ctx).WithField("teamID", teamID).Info("removing team users from the databricks group")
+
+	operations := make([]patchOperation, 0, len(userIDs))
+	for _, userID := range userIDs {
+		operations = append(operations, patchOperation{
+			Op:   "remove",
+			Path: fmt.Sprintf("members[value eq %q]", userID),
+		})
+	}
+	return dC.patchGroup(ctx, teamID, operations, "backend.databricks.RemoveUserFromTeam")
+}
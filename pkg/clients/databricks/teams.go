@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups without their members, keyed by display name
+func (dC *DatabricksClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := dC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch databricks groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the groups, 100 groups at a time
+func (dC *DatabricksClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return forEachPage(ctx, dC, "/Groups?attributes=id,displayName", "backend.databricks.FetchAllTeams",
+		func(groups []Group) error {
+			page := make([]structs.Team, 0, len(groups))
+			for _, group := range groups {
+				page = append(page, structs.Team{ID: group.ID, Name: group.DisplayName})
+			}
+			return fn(page)
+		})
+}
+
+// FetchTeamDetails fetches the group by ID
+func (dC *DatabricksClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.FetchTeamDetails")
+	defer span.Finish()
+
+	var group Group
+	if err := dC.get(ctx, "/Groups/"+url.PathEscape(teamID)+"?attributes=id,displayName", &group,
+		"backend.databricks.FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+	return &structs.Team{ID: group.ID, Name: group.DisplayName}, nil
+}
+
+// CreateTeam creates the group, Databricks groups have no description. An account group is
+// assigned to the workspace of the backend, the group is deleted again when the assignment fails
+// so that the next reconcile creates and assigns it.
+func (dC *DatabricksClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "databricks")
+	log.Info("Create databricks group")
+
+	resp, err := dC.sendRequest(ctx, "/Groups", http.MethodPost, &Group{
+		Schemas:     []string{schemaGroup},
+		DisplayName: team.Name,
+	}, "backend.databricks.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create databricks group")
+		return nil, err
+	}
+
+	var created Group
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no group ID in the databricks create group response")
+	}
+
+	if dC.workspaceAssignmentURL != "" {
+		if err := dC.assignToWorkspace(ctx, created.ID); err != nil {
+			log.WithError(err).Error("failed to assign the databricks group to the workspace")
+			if deleteErr := dC.DeleteTeamByID(ctx, created.ID); deleteErr != nil {
+				return nil, fmt.Errorf("%w, and the unassigned group could not be deleted: %w", err, deleteErr)
+			}
+			return nil, err
+		}
+	}
+	return &structs.Team{
+		ID:          created.ID,
+		Name:        team.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// assignToWorkspace gives the account group access to the workspace of the backend
+func (dC *DatabricksClient) assignToWorkspace(ctx context.Context, groupID string) error {
+	_, err := dC.send(ctx, dC.workspaceAssignmentURL+"/"+url.PathEscape(groupID), http.MethodPut,
+		&permissionAssignment{Permissions: []string{workspacePermission}}, "backend.databricks.AssignToWorkspace")
+	return err
+}
+
+// DeleteTeamByID deletes the group by ID, a group which does not exist is considered deleted
+func (dC *DatabricksClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "databricks")
+	log.Info("Delete databricks group")
+
+	_, err := dC.sendRequest(ctx, "/Groups/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.databricks.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("databricks group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// patchGroup applies the PATCH operations to the group
+func (dC *DatabricksClient) patchGroup(ctx context.Context, teamID string, operations []patchOperation,
+	methodName string) error {
+	_, err := dC.sendRequest(ctx, "/Groups/"+url.PathEscape(teamID), http.MethodPatch, &patchRequest{
+		Schemas:    []string{schemaPatchOp},
+		Operations: operations,
+	}, methodName)
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to patch the databricks group")
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import "time"
+
+// SCIM 2.0 schemas of the Databricks SCIM API
+const (
+	schemaUser    = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup   = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaPatchOp = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+const (
+	// pageSize is the number of resources requested per list page
+	pageSize = 100
+	// tokenRefreshMargin is how long before its expiry the OAuth access token is renewed
+	tokenRefreshMargin = time.Minute
+	// oauthScope is the scope of the OAuth access token of a service principal
+	oauthScope = "all-apis"
+	// workspacePermission is the permission of the account groups assigned to the workspace
+	workspacePermission = "USER"
+)
+
+// DatabricksConfig is the connection of a Databricks backend, read from the backend configuration.
+// The users and groups are managed through the SCIM API of the workspace, or of the account when
+// account_id is set.
+type DatabricksConfig struct {
+	// Host is the URL of the workspace, or of the account console for the account API, e.g.
+	// https://accounts.cloud.databricks.com
+	Host string `json:"host"`
+	// AccountID selects the account SCIM API, the users and groups are account users and groups
+	AccountID string `json:"account_id"`
+	// WorkspaceID is the workspace the account groups are assigned to, only with account_id
+	WorkspaceID string `json:"workspace_id"`
+	// Token is a personal access token, or ClientID and ClientSecret the OAuth secret of a
+	// service principal
+	Token        string `json:"token"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// User is a Databricks SCIM user, its userName is its email
+type User struct {
+	Schemas     []string `json:"schemas,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	UserName    string   `json:"userName"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Name        *Name    `json:"name,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// Name is the name of a Databricks user
+type Name struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Email is an email address of a Databricks user
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Group is a Databricks SCIM group
+type Group struct {
+	Schemas      []string `json:"schemas,omitempty"`
+	ID           string   `json:"id,omitempty"`
+	DisplayName  string   `json:"displayName"`
+	Members      []Member `json:"members,omitempty"`
+	Entitlements []Value  `json:"entitlements,omitempty"`
+}
+
+// Member is a member of a group, a user, a service principal or a nested group told apart by
+// their reference, e.g. Users/123
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// Value is a multi-valued attribute of a group, e.g. an entitlement
+type Value struct {
+	Value string `json:"value"`
+}
+
+// listResponse is a page of the resources of a list request
+type listResponse[T any] struct {
+	TotalResults int `json:"totalResults"`
+	StartIndex   int `json:"startIndex"`
+	ItemsPerPage int `json:"itemsPerPage"`
+	Resources    []T `json:"Resources"`
+}
+
+// patchRequest is a SCIM PATCH request
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+// patchOperation is an operation of a SCIM PATCH request
+type patchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// permissionAssignment is the assignment of a principal of the account to a workspace
+type permissionAssignment struct {
+	Permissions []string `json:"permissions"`
+}
+
+// tokenResponse is the response of the OAuth token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// errorResponse is the error response of the Databricks APIs, SCIM errors carry a detail and the
+// other APIs an error code and message
+type errorResponse struct {
+	Detail           string `json:"detail"`
+	ErrorCode        string `json:"error_code"`
+	Message          string `json:"message"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// primaryEmail returns the primary email of the user, or its first email, or its userName
+func (u *User) primaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return u.UserName
+}
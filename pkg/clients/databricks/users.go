@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// userAttributes are the attributes of the users read from the SCIM API
+const userAttributes = "id,userName,displayName,name,emails"
+
+// FetchAllUsers lists the users of the workspace or account, keyed by ID and by email
+func (dC *DatabricksClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := dC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch databricks users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users, 100 users at a time
+func (dC *DatabricksClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachPage(ctx, dC, "/Users?attributes="+userAttributes, "backend.databricks.FetchAllUsers",
+		func(databricksUsers []User) error {
+			page := make([]*structs.User, 0, len(databricksUsers))
+			for _, u := range databricksUsers {
+				page = append(page, userDetails(&u))
+			}
+			return fn(page)
+		})
+}
+
+// FetchUserDetails fetches the user by ID
+func (dC *DatabricksClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := dC.get(ctx, "/Users/"+url.PathEscape(userID)+"?attributes="+userAttributes, &user,
+		"backend.databricks.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser adds the user to the workspace or account, the userName of a Databricks user is its
+// email. A user who signed in through SSO already exists and is reported as such.
+func (dC *DatabricksClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.CreateUser")
+	defer span.Finish()
+
+	if u.Email == "" {
+		return nil, fmt.Errorf("databricks users are named by their email, user %s has none", u.UserName)
+	}
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "databricks")
+	log.Info("Create databricks user")
+
+	active := true
+	databricksUser := &User{
+		Schemas:     []string{schemaUser},
+		UserName:    u.Email,
+		DisplayName: u.DisplayName,
+		Name:        &Name{GivenName: u.FirstName, FamilyName: u.LastName},
+		Emails:      []Email{{Value: u.Email, Type: "work", Primary: true}},
+		Active:      &active,
+	}
+	if databricksUser.DisplayName == "" {
+		databricksUser.DisplayName = strings.TrimSpace(u.FirstName + " " + u.LastName)
+	}
+
+	resp, err := dC.sendRequest(ctx, "/Users", http.MethodPost, databricksUser, "backend.databricks.CreateUser")
+	if err != nil {
+		if request.IsConflict(err) {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		log.WithError(err).Error("failed to create databricks user")
+		return nil, err
+	}
+
+	var created User
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no user ID in the databricks create user response")
+	}
+	return userDetails(&created), nil
+}
+
+// DeleteUser removes the user from the workspace or account, a user which does not exist is
+// considered deleted
+func (dC *DatabricksClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.databricks.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "databricks")
+	log.Info("Delete databricks user")
+
+	_, err := dC.sendRequest(ctx, "/Users/"+url.PathEscape(userID), http.MethodDelete, nil,
+		"backend.databricks.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("databricks user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// userDetails converts the Databricks user, its userName is its username and its fallback email
+func userDetails(u *User) *structs.User {
+	user := &structs.User{
+		ID:          u.ID,
+		UserName:    u.UserName,
+		Email:       u.primaryEmail(),
+		DisplayName: u.DisplayName,
+	}
+	if u.Name != nil {
+		user.FirstName = u.Name.GivenName
+		user.LastName = u.Name.FamilyName
+	}
+	return user
+}
+
+// forEachPage calls fn with each page of the resources of the path, SCIM pages are indexed from 1
+func forEachPage[T any](ctx context.Context, dC *DatabricksClient, path string, methodName string,
+	fn func(resources []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	for startIndex, read := 1, 0; ; {
+		var page listResponse[T]
+		pagePath := fmt.Sprintf("%s%sstartIndex=%d&count=%d", path, separator, startIndex, pageSize)
+		if err := dC.get(ctx, pagePath, &page, methodName); err != nil {
+			return err
+		}
+		if len(page.Resources) == 0 {
+			return nil
+		}
+		if err := fn(page.Resources); err != nil {
+			return err
+		}
+
+		// the listing ends with an empty page or once all the results are read
+		read += len(page.Resources)
+		if read >= page.TotalResults {
+			return nil
+		}
+		startIndex += len(page.Resources)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// DbtCloudClient manages the groups of a dbt Cloud account, their members and project permission
+// sets, and the licenses of the users, through the Admin API
+type DbtCloudClient struct {
+	client    heimdall.Doer
+	accountID int64
+	// url is the Admin API of the account
+	url     string
+	headers map[string]string
+}
+
+func NewClient(dbtCloudAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*DbtCloudClient, error) {
+
+	dbtCloudConfig := DbtCloudConfig{}
+	if err := utils.MapToStruct(dbtCloudAppConfig, &dbtCloudConfig); err != nil {
+		return nil, err
+	}
+	if dbtCloudConfig.AccountID == "" || dbtCloudConfig.Token == "" {
+		return nil, errors.New("dbt cloud configuration is missing required fields: account_id or token")
+	}
+	accountID, err := strconv.ParseInt(dbtCloudConfig.AccountID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dbt cloud account_id %q: %w", dbtCloudConfig.AccountID, err)
+	}
+
+	baseURL := strings.TrimSuffix(dbtCloudConfig.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	client, err := httpclient.InitializeClient(
+		"dbtcloud_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &DbtCloudClient{
+		client:    client,
+		accountID: accountID,
+		url:       fmt.Sprintf("%s/api/v3/accounts/%d", baseURL, accountID),
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+			"Accept":                       "application/json",
+			"Authorization":                "Token " + dbtCloudConfig.Token,
+		},
+	}, nil
+}
+
+// HealthCheck fetches the account, the Admin API is healthy when the token can read it
+func (dC *DbtCloudClient) HealthCheck(ctx context.Context) error {
+	if _, err := dC.sendRequest(ctx, "/", http.MethodGet, nil, "backend.dbtcloud.HealthCheck"); err != nil {
+		return fmt.Errorf("dbt cloud health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path of the account and decodes the data of its response
+func get[T any](ctx context.Context, dC *DbtCloudClient, path string, methodName string) (*envelope[T], error) {
+	resp, err := dC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return nil, err
+	}
+	var result envelope[T]
+	if err := decode(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// decode decodes the response of a dbt Cloud request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode dbt cloud response: %w", err)
+	}
+	return nil
+}
+
+// forEachPage calls fn with each page of the resources of the path, the pages are requested by
+// offset
+func forEachPage[T any](ctx context.Context, dC *DbtCloudClient, path string, methodName string,
+	fn func(resources []T) error) error {
+
+	for offset := 0; ; {
+		page, err := get[[]T](ctx, dC, fmt.Sprintf("%s?limit=%d&offset=%d", path, pageSize, offset), methodName)
+		if err != nil {
+			return err
+		}
+		if len(page.Data) == 0 {
+			return nil
+		}
+		if err := fn(page.Data); err != nil {
+			return err
+		}
+
+		offset += len(page.Data)
+		if offset >= page.Extra.Pagination.TotalCount {
+			return nil
+		}
+	}
+}
+
+// sendRequest sends the request to the path of the Admin API of the account and returns the
+// response body, any response code other than 2xx is returned as a *request.ResponseError
+func (dC *DbtCloudClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, dC.client, method, dC.url+path, body, dC.headers, methodName,
+		"dbtcloud", errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the user and developer messages of an error response of the Admin API
+func errorMessage(body []byte) (string, string) {
+	var errResp envelope[json.RawMessage]
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	message := errResp.Status.UserMessage
+	if errResp.Status.DeveloperMessage != "" {
+		message = strings.TrimSpace(message + " " + errResp.Status.DeveloperMessage)
+	}
+	return "", message
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeDbtCloud is the Admin API of account 1 holding its users, invites and groups in memory
+type fakeDbtCloud struct {
+	users   []User
+	invites []Invite
+	groups  map[int64]*Group
+	// permissionPosts counts the replacements of the group permissions
+	permissionPosts int
+}
+
+func (f *fakeDbtCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Token token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"status": {"code": 401, "is_success": false, "user_message": "Invalid token.",
+			"developer_message": ""}, "data": null}`)
+		return
+	}
+
+	path, _ := strings.CutPrefix(r.URL.Path, "/api/v3/accounts/1")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case path == "/" && r.Method == http.MethodGet:
+		f.respond(w, map[string]any{"id": 1, "name": "example"})
+	case path == "/users/" && r.Method == http.MethodGet:
+		f.page(w, r, f.users)
+	case segments[0] == "users" && len(segments) == 2:
+		if user := f.user(segments[1]); user != nil {
+			f.respond(w, user)
+			return
+		}
+		f.notFound(w)
+	case path == "/invites/" && r.Method == http.MethodGet:
+		f.page(w, r, f.invites)
+	case path == "/invites/" && r.Method == http.MethodPost:
+		var invite Invite
+		_ = json.NewDecoder(r.Body).Decode(&invite)
+		invite.ID = int64(len(f.invites) + 1)
+		f.invites = append(f.invites, invite)
+		f.respond(w, invite)
+	case segments[0] == "permissions" && r.Method == http.MethodPost:
+		var permission Permission
+		_ = json.NewDecoder(r.Body).Decode(&permission)
+		for _, user := range f.users {
+			for i := range user.Permissions {
+				if strconv.FormatInt(user.Permissions[i].ID, 10) == segments[1] {
+					user.Permissions[i].State = permission.State
+					user.Permissions[i].Groups = nil
+				}
+			}
+		}
+		f.respond(w, permission)
+	case path == "/groups/" && r.Method == http.MethodGet:
+		ids := make([]int64, 0, len(f.groups))
+		for id := range f.groups {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		groups := make([]Group, 0, len(ids))
+		for _, id := range ids {
+			groups = append(groups, *f.groups[id])
+		}
+		f.page(w, r, groups)
+	case path == "/groups/" && r.Method == http.MethodPost:
+		var group Group
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		group.ID = int64(len(f.groups) + 100)
+		f.groups[group.ID] = &group
+		w.WriteHeader(http.StatusCreated)
+		f.respond(w, group)
+	case segments[0] == "groups" && len(segments) == 2:
+		id, _ := strconv.ParseInt(segments[1], 10, 64)
+		group, ok := f.groups[id]
+		if !ok {
+			f.notFound(w)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.groups, id)
+		}
+		f.respond(w, group)
+	case segments[0] == "group-permissions" && r.Method == http.MethodPost:
+		id, _ := strconv.ParseInt(segments[1], 10, 64)
+		var permissions []GroupPermission
+		_ = json.NewDecoder(r.Body).Decode(&permissions)
+		f.groups[id].GroupPermissions = permissions
+		f.permissionPosts++
+		f.respond(w, permissions)
+	case path == "/assign-groups/" && r.Method == http.MethodPost:
+		var assign assignGroups
+		_ = json.NewDecoder(r.Body).Decode(&assign)
+		user := f.user(strconv.FormatInt(assign.UserID, 10))
+		groups := []Group{}
+		for _, id := range assign.DesiredGroupIDs {
+			groups = append(groups, Group{ID: id, AccountID: 1})
+		}
+		for i := range user.Permissions {
+			if user.Permissions[i].AccountID == 1 {
+				user.Permissions[i].Groups = groups
+			}
+		}
+		f.respond(w, groups)
+	default:
+		f.notFound(w)
+	}
+}
+
+func (f *fakeDbtCloud) user(id string) *User {
+	for i := range f.users {
+		if strconv.FormatInt(f.users[i].ID, 10) == id {
+			return &f.users[i]
+		}
+	}
+	return nil
+}
+
+func (f *fakeDbtCloud) respond(w http.ResponseWriter, data any) {
+	fakehttp.WriteJSON(w, http.StatusOK,
+		map[string]any{"status": map[string]any{"code": 200, "is_success": true}, "data": data})
+}
+
+func (f *fakeDbtCloud) notFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = io.WriteString(w, `{"status": {"code": 404, "is_success": false,
+		"user_message": "The requested resource could not be found.", "developer_message": ""}, "data": null}`)
+}
+
+// page responds with the page of the resources of the limit and offset of the request
+func (f *fakeDbtCloud) page(w http.ResponseWriter, r *http.Request, resources any) {
+	all, _ := json.Marshal(resources)
+	var items []json.RawMessage
+	_ = json.Unmarshal(all, &items)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	start := min(offset, len(items))
+	end := min(start+limit, len(items))
+	fakehttp.WriteJSON(w, http.StatusOK, map[string]any{
+		"status": map[string]any{"code": 200, "is_success": true},
+		"data":   items[start:end],
+		"extra":  map[string]any{"pagination": map[string]any{"count": end - start, "total_count": len(items)}},
+	})
+}
+
+func newTestClient(t *testing.T, fake *fakeDbtCloud) *DbtCloudClient {
+	t.Helper()
+	if fake.groups == nil {
+		fake.groups = map[int64]*Group{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	client, err := NewClient(map[string]interface{}{"base_url": server.URL + "/", "account_id": "1", "token": "token"},
+		fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+// accountUser returns a user of account 1 and of another account
+func accountUser(id int64, email string, groups ...int64) User {
+	permission := Permission{ID: id * 10, AccountID: 1, UserID: id, LicenseType: "developer", State: 1, Groups: []Group{}}
+	for _, group := range groups {
+		permission.Groups = append(permission.Groups, Group{ID: group, AccountID: 1})
+	}
+	return User{ID: id, Email: email, FirstName: "First", LastName: fmt.Sprint(id), Permissions: []Permission{
+		{ID: id*10 + 1, AccountID: 2, UserID: id, LicenseType: "read_only", State: 1},
+		permission,
+	}}
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"token": "token"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "account_id or token")
+
+	_, err = NewClient(map[string]interface{}{"account_id": "acme", "token": "token"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid dbt cloud account_id")
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeDbtCloud{}
+	for i := int64(1); i <= 120; i++ {
+		fake.users = append(fake.users, accountUser(i, fmt.Sprintf("user%d@example.com", i)))
+	}
+	// a user removed from the account is not listed
+	fake.users[119].Permissions[1].State = permissionStateDeleted
+	client := newTestClient(t, fake)
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 119)
+	assert.Equal(t, &structs.User{ID: "7", UserName: "user7@example.com", Email: "user7@example.com",
+		FirstName: "First", LastName: "7", DisplayName: "First 7", Role: "developer"}, byEmail["user7@example.com"])
+
+	user, err := client.CreateUser(context.Background(), &structs.User{Email: "USER101@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "101", user.ID)
+
+	// the users which are not in the account are invited once with the license of their role
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "jdoe@example.com", Role: "developer"})
+	assert.ErrorContains(t, err, "invited")
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "jdoe@example.com"})
+	assert.ErrorContains(t, err, "invited")
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "user120@example.com"})
+	assert.ErrorContains(t, err, "invited")
+	assert.Equal(t, []Invite{
+		{ID: 1, Email: "jdoe@example.com", LicenseType: "developer"},
+		{ID: 2, Email: "user120@example.com", LicenseType: "read_only"},
+	}, fake.invites)
+
+	require.NoError(t, client.DeleteUser(context.Background(), "7"))
+	assert.Equal(t, permissionStateDeleted, fake.users[6].Permissions[1].State)
+	assert.Equal(t, 1, fake.users[6].Permissions[0].State)
+	assert.NoError(t, client.DeleteUser(context.Background(), "7"))
+	assert.NoError(t, client.DeleteUser(context.Background(), "404"))
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeDbtCloud{users: []User{accountUser(1, "jdoe@example.com", 7), accountUser(2, "asmith@example.com")}}
+	client := newTestClient(t, fake)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "100", Name: "dataeng", Description: "team for dataeng"}, team)
+	assert.Equal(t, []string{}, fake.groups[100].SSOMappingGroups)
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"dataeng": {ID: "100", Name: "dataeng"}}, teams)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "100", []string{"1", "2"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	// the other groups of the users are kept
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "100", []string{"1"}))
+	assert.Equal(t, []Group{{ID: 7, AccountID: 1}}, fake.users[0].Permissions[1].Groups)
+	members, err = client.FetchTeamMembersByTeamID(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, keys(members))
+
+	assert.ErrorContains(t, client.AddUserToTeam(context.Background(), "100", []string{"404"}), "404")
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "100"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "100"))
+}
+
+func TestReconcilePermissionSets(t *testing.T) {
+	fake := &fakeDbtCloud{groups: map[int64]*Group{100: {ID: 100, AccountID: 1, Name: "dataeng"}}}
+	client := newTestClient(t, fake)
+
+	params := structs.TeamParams{Property: "permission_sets", Value: []string{"analyst", "developer:1234", "analyst"}}
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "100", params))
+	project := int64(1234)
+	assert.Equal(t, []GroupPermission{
+		{AccountID: 1, GroupID: 100, PermissionSet: "analyst", AllProjects: true},
+		{AccountID: 1, GroupID: 100, PermissionSet: "developer", ProjectID: &project},
+	}, fake.groups[100].GroupPermissions)
+
+	// unchanged permissions are not sent again
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "100", params))
+	assert.Equal(t, 1, fake.permissionPosts)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "100", structs.TeamParams{
+		Property: "permission_sets", Value: []string{},
+	}))
+	assert.Empty(t, fake.groups[100].GroupPermissions)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "100", structs.TeamParams{
+		Property: "permission_sets", Value: []string{"developer:main"},
+	}), "invalid dbt cloud project ID")
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "100", structs.TeamParams{
+		Property: "licenses", Value: []string{"x"},
+	}), "unsupported dbt cloud group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeDbtCloud{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "Token wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "Invalid token.")
+}
+
+func keys(users map[string]*structs.User) []string {
+	ids := make([]string, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
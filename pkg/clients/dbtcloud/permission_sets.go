@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamPermissionSets is the group param property listing the permission sets granted to the
+// group, on all the projects or on a project
+const groupParamPermissionSets = "permission_sets"
+
+// ReconcileGroupParams grants the group exactly the permission sets of the permission_sets group
+// param, each value being a permission set granted on all the projects, e.g. analyst, or on a
+// project by its ID, e.g. developer:1234. The Admin API replaces the whole list of the group
+// permissions, which is only sent when it changed.
+func (dC *DbtCloudClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamPermissionSets {
+		return fmt.Errorf("unsupported dbt cloud group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "dbtcloud")
+
+	groupID, err := strconv.ParseInt(teamID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid dbt cloud group ID %q: %w", teamID, err)
+	}
+	desired := make([]GroupPermission, 0, len(groupParams.Value))
+	for _, value := range groupParams.Value {
+		permission, err := dC.groupPermission(groupID, value)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(desired, permission.equal) {
+			desired = append(desired, permission)
+		}
+	}
+
+	group, err := get[Group](ctx, dC, "/groups/"+url.PathEscape(teamID)+"/", "backend.dbtcloud.ReconcileGroupParams")
+	if err != nil {
+		log.WithError(err).Error("failed to fetch the dbt cloud group permissions")
+		return err
+	}
+	current := group.Data.GroupPermissions
+	if len(current) == len(desired) && !slices.ContainsFunc(desired, func(permission GroupPermission) bool {
+		return !slices.ContainsFunc(current, permission.equal)
+	}) {
+		return nil
+	}
+
+	log.WithField("permission_sets", groupParams.Value).Info("updating the permission sets of the dbt cloud group")
+	_, err = dC.sendRequest(ctx, fmt.Sprintf("/group-permissions/%d/", groupID), http.MethodPost, desired,
+		"backend.dbtcloud.ReconcileGroupParams")
+	if err != nil {
+		log.WithError(err).Error("failed to update the dbt cloud group permissions")
+	}
+	return err
+}
+
+// groupPermission parses the permission set of the value, granted on all the projects or on the
+// project after the colon
+func (dC *DbtCloudClient) groupPermission(groupID int64, value string) (GroupPermission, error) {
+	permission := GroupPermission{AccountID: dC.accountID, GroupID: groupID, AllProjects: true}
+	permissionSet, project, found := strings.Cut(value, ":")
+	permission.PermissionSet = permissionSet
+	if found {
+		projectID, err := strconv.ParseInt(project, 10, 64)
+		if err != nil {
+			return GroupPermission{}, fmt.Errorf("invalid dbt cloud project ID %q of permission set %s", project, value)
+		}
+		permission.ProjectID = &projectID
+		permission.AllProjects = false
+	}
+	return permission, nil
+}
+
+// equal reports whether the permissions grant the same permission set on the same projects
+func (p GroupPermission) equal(other GroupPermission) bool {
+	if p.PermissionSet != other.PermissionSet || p.AllProjects != other.AllProjects {
+		return false
+	}
+	return p.AllProjects || (p.ProjectID != nil && other.ProjectID != nil && *p.ProjectID == *other.ProjectID)
+}
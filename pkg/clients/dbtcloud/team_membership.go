@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID fetches the members of the group, keyed by ID. The groups of a user are
+// held by its account permission, so the users of the account are listed for the members.
+func (dC *DbtCloudClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	groupID, err := strconv.ParseInt(teamID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dbt cloud group ID %q: %w", teamID, err)
+	}
+
+	members := make(map[string]*structs.User)
+	err = forEachPage(ctx, dC, "/users/", "backend.dbtcloud.FetchTeamMembersByTeamID", func(users []User) error {
+		for _, u := range users {
+			permission := dC.accountPermission(&u)
+			if permission != nil && slices.ContainsFunc(permission.Groups, func(group Group) bool {
+				return group.ID == groupID
+			}) {
+				member := userDetails(&u, permission)
+				members[member.ID] = member
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch dbt cloud group members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the group to the groups of each user
+func (dC *DbtCloudClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.AddUserToTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("teamID", teamID).Info("adding team users to the dbt cloud group")
+	return dC.updateGroups(ctx, teamID, userIDs, func(groupIDs []int64, groupID int64) []int64 {
+		if slices.Contains(groupIDs, groupID) {
+			return groupIDs
+		}
+		return append(groupIDs, groupID)
+	}, "backend.dbtcloud.AddUserToTeam")
+}
+
+// RemoveUserFromTeam removes the group from the groups of each user
+func (dC *DbtCloudClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.RemoveUserFromTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("teamID", teamID).Info("removing team users from the dbt cloud group")
+	return dC.updateGroups(ctx, teamID, userIDs, func(groupIDs []int64, groupID int64) []int64 {
+		return slices.DeleteFunc(groupIDs, func(id int64) bool { return id == groupID })
+	}, "backend.dbtcloud.RemoveUserFromTeam")
+}
+
+// updateGroups replaces the groups of each user with its groups updated for the group, the Admin
+// API assigns the whole list of the groups of a user. The users whose groups are unchanged are
+// skipped.
+func (dC *DbtCloudClient) updateGroups(ctx context.Context, teamID string, userIDs []string,
+	update func(groupIDs []int64, groupID int64) []int64, methodName string) error {
+
+	groupID, err := strconv.ParseInt(teamID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid dbt cloud group ID %q: %w", teamID, err)
+	}
+
+	for _, userID := range userIDs {
+		user, err := dC.fetchUser(ctx, userID, methodName)
+		if err != nil {
+			return err
+		}
+		permission := dC.accountPermission(user)
+		if permission == nil {
+			return fmt.Errorf("dbt cloud user %s is not in the account", userID)
+		}
+
+		current := make([]int64, 0, len(permission.Groups))
+		for _, group := range permission.Groups {
+			current = append(current, group.ID)
+		}
+		desired := update(slices.Clone(current), groupID)
+		if slices.Equal(current, desired) {
+			continue
+		}
+
+		if _, err := dC.sendRequest(ctx, "/assign-groups/", http.MethodPost, &assignGroups{
+			UserID:          user.ID,
+			DesiredGroupIDs: desired,
+		}, methodName); err != nil {
+			logger.Logger(ctx).WithField("teamID", teamID).WithField("userID", userID).WithError(err).
+				Error("failed to assign the dbt cloud groups of the user")
+			return err
+		}
+	}
+	return nil
+}
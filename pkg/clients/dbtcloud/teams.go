@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups of the account, keyed by name
+func (dC *DbtCloudClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := dC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch dbt cloud groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the groups of the account, 100 groups at a time
+func (dC *DbtCloudClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return forEachPage(ctx, dC, "/groups/", "backend.dbtcloud.FetchAllTeams", func(groups []Group) error {
+		page := make([]structs.Team, 0, len(groups))
+		for _, group := range groups {
+			page = append(page, teamDetails(&group))
+		}
+		return fn(page)
+	})
+}
+
+// FetchTeamDetails fetches the group by ID
+func (dC *DbtCloudClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.FetchTeamDetails")
+	defer span.Finish()
+
+	resp, err := get[Group](ctx, dC, "/groups/"+url.PathEscape(teamID)+"/", "backend.dbtcloud.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	team := teamDetails(&resp.Data)
+	return &team, nil
+}
+
+// CreateTeam creates the group, dbt Cloud groups have no description. The group is not assigned
+// to the new users by default nor mapped to SSO groups, its members are managed by usernaut.
+func (dC *DbtCloudClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "dbtcloud")
+	log.Info("Create dbt cloud group")
+
+	resp, err := dC.sendRequest(ctx, "/groups/", http.MethodPost, &Group{
+		AccountID:        dC.accountID,
+		Name:             team.Name,
+		SSOMappingGroups: []string{},
+	}, "backend.dbtcloud.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create dbt cloud group")
+		return nil, err
+	}
+
+	var created envelope[Group]
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.Data.ID == 0 {
+		return nil, errors.New("no group ID in the dbt cloud create group response")
+	}
+	return &structs.Team{
+		ID:          strconv.FormatInt(created.Data.ID, 10),
+		Name:        team.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// DeleteTeamByID deletes the group by ID, a group which does not exist is considered deleted
+func (dC *DbtCloudClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "dbtcloud")
+	log.Info("Delete dbt cloud group")
+
+	_, err := dC.sendRequest(ctx, "/groups/"+url.PathEscape(teamID)+"/", http.MethodDelete, nil,
+		"backend.dbtcloud.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("dbt cloud group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+func teamDetails(group *Group) structs.Team {
+	return structs.Team{
+		ID:   strconv.FormatInt(group.ID, 10),
+		Name: group.Name,
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+const (
+	// defaultBaseURL is the dbt Cloud multi-tenant instance of North America
+	defaultBaseURL = "https://cloud.getdbt.com"
+	// pageSize is the number of resources requested per list page, the largest page of the API
+	pageSize = 100
+	// DefaultLicenseType is the license of the users invited without a role
+	DefaultLicenseType = "read_only"
+	// permissionStateDeleted is the state of a deactivated account permission, which frees the
+	// license of the user
+	permissionStateDeleted = 2
+)
+
+// LicenseTypes are the licenses a dbt Cloud user is given in the account
+var LicenseTypes = []string{"developer", "read_only", "it"}
+
+// DbtCloudConfig is the connection of a dbt Cloud backend, read from the backend configuration
+type DbtCloudConfig struct {
+	// BaseURL is the dbt Cloud instance, https://cloud.getdbt.com (default) or the access URL of
+	// the account for the other regions and single tenant instances
+	BaseURL string `json:"base_url"`
+	// AccountID is the ID of the managed account
+	AccountID string `json:"account_id"`
+	// Token is a service token of the account with the Account Admin permission set
+	Token string `json:"token"`
+}
+
+// envelope is the response of the Admin API, its data holds the resource or the page of resources
+type envelope[T any] struct {
+	Status status `json:"status"`
+	Data   T      `json:"data"`
+	Extra  extra  `json:"extra"`
+}
+
+// status is the status of a response of the Admin API
+type status struct {
+	Code             int    `json:"code"`
+	IsSuccess        bool   `json:"is_success"`
+	UserMessage      string `json:"user_message"`
+	DeveloperMessage string `json:"developer_message"`
+}
+
+// extra holds the pagination of a list response
+type extra struct {
+	Pagination struct {
+		Count      int `json:"count"`
+		TotalCount int `json:"total_count"`
+	} `json:"pagination"`
+}
+
+// User is a user of dbt Cloud, with its permissions in the accounts it belongs to
+type User struct {
+	ID          int64        `json:"id"`
+	FirstName   string       `json:"first_name"`
+	LastName    string       `json:"last_name"`
+	Email       string       `json:"email"`
+	IsActive    bool         `json:"is_active"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// Permission is the membership of a user in an account, holding its license and groups
+type Permission struct {
+	ID          int64   `json:"id"`
+	AccountID   int64   `json:"account_id"`
+	UserID      int64   `json:"user_id"`
+	LicenseType string  `json:"license_type"`
+	State       int     `json:"state"`
+	Groups      []Group `json:"groups,omitempty"`
+}
+
+// Invite is an invitation of a user to the account, pending until the user accepts it
+type Invite struct {
+	ID          int64  `json:"id,omitempty"`
+	Email       string `json:"email"`
+	LicenseType string `json:"license_type,omitempty"`
+}
+
+// Group is a group of the account, granting its members the permission sets of its group
+// permissions
+type Group struct {
+	ID               int64             `json:"id,omitempty"`
+	AccountID        int64             `json:"account_id"`
+	Name             string            `json:"name"`
+	AssignByDefault  bool              `json:"assign_by_default"`
+	SSOMappingGroups []string          `json:"sso_mapping_groups"`
+	GroupPermissions []GroupPermission `json:"group_permissions,omitempty"`
+}
+
+// GroupPermission is a permission set granted to a group on a project, or on all the projects
+type GroupPermission struct {
+	AccountID     int64  `json:"account_id"`
+	GroupID       int64  `json:"group_id"`
+	PermissionSet string `json:"permission_set"`
+	ProjectID     *int64 `json:"project_id"`
+	AllProjects   bool   `json:"all_projects"`
+}
+
+// assignGroups replaces the groups of a user
+type assignGroups struct {
+	UserID          int64   `json:"user_id"`
+	DesiredGroupIDs []int64 `json:"desired_group_ids"`
+}
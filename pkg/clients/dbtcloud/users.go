@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbtcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// errFound stops the listing of the users once the looked up user is found
+var errFound = errors.New("found")
+
+// FetchAllUsers lists the users of the account, keyed by ID and by email
+func (dC *DbtCloudClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := dC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch dbt cloud users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users of the account, 100 users at a time. The
+// users removed from the account are skipped.
+func (dC *DbtCloudClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachPage(ctx, dC, "/users/", "backend.dbtcloud.FetchAllUsers", func(dbtUsers []User) error {
+		page := make([]*structs.User, 0, len(dbtUsers))
+		for _, u := range dbtUsers {
+			if permission := dC.accountPermission(&u); permission != nil {
+				page = append(page, userDetails(&u, permission))
+			}
+		}
+		return fn(page)
+	})
+}
+
+// FetchUserDetails fetches the user by ID
+func (dC *DbtCloudClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.FetchUserDetails")
+	defer span.Finish()
+
+	user, err := dC.fetchUser(ctx, userID, "backend.dbtcloud.FetchUserDetails")
+	if err != nil {
+		return nil, err
+	}
+	return userDetails(user, dC.accountPermission(user)), nil
+}
+
+// CreateUser looks the user up by email among the users of the account. dbt Cloud users sign up
+// themselves, a user who is not yet in the account is invited with the license of its role, or
+// read_only, and is added to its groups once it accepted the invite.
+func (dC *DbtCloudClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.CreateUser")
+	defer span.Finish()
+
+	if u.Email == "" {
+		return nil, fmt.Errorf("dbt cloud users are looked up by email, user %s has none", u.UserName)
+	}
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "dbtcloud")
+	log.Info("Look up dbt cloud user")
+
+	var found *structs.User
+	err := dC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			if strings.EqualFold(user.Email, u.Email) {
+				found = user
+				return errFound
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		log.WithError(err).Error("failed to look up dbt cloud user")
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	if err := dC.invite(ctx, u); err != nil {
+		log.WithError(err).Error("failed to invite dbt cloud user")
+		return nil, err
+	}
+	return nil, fmt.Errorf("dbt cloud user %s is invited to the account and is added once the invite is accepted", u.Email)
+}
+
+// invite invites the user to the account with the license of its role, unless an invite of the
+// user is pending
+func (dC *DbtCloudClient) invite(ctx context.Context, u *structs.User) error {
+	pending := false
+	err := forEachPage(ctx, dC, "/invites/", "backend.dbtcloud.CreateUser", func(invites []Invite) error {
+		for _, invite := range invites {
+			if strings.EqualFold(invite.Email, u.Email) {
+				pending = true
+				return errFound
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return err
+	}
+	if pending {
+		return nil
+	}
+
+	licenseType := u.Role
+	if licenseType == "" {
+		licenseType = DefaultLicenseType
+	}
+	logger.Logger(ctx).WithField("email", u.Email).WithField("license_type", licenseType).
+		WithField("service", "dbtcloud").Info("Invite dbt cloud user")
+	_, err = dC.sendRequest(ctx, "/invites/", http.MethodPost, &Invite{Email: u.Email, LicenseType: licenseType},
+		"backend.dbtcloud.CreateUser")
+	return err
+}
+
+// DeleteUser removes the user from the account by deactivating its account permission, which
+// frees its license and removes it from its groups. A user which is not in the account is
+// considered deleted.
+func (dC *DbtCloudClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.dbtcloud.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "dbtcloud")
+	log.Info("Remove dbt cloud user from the account")
+
+	user, err := dC.fetchUser(ctx, userID, "backend.dbtcloud.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("dbt cloud user not found, considering deletion successful")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	permission := dC.accountPermission(user)
+	if permission == nil {
+		log.Warn("dbt cloud user not in the account, considering deletion successful")
+		return nil
+	}
+
+	deactivated := *permission
+	deactivated.State = permissionStateDeleted
+	deactivated.Groups = nil
+	_, err = dC.sendRequest(ctx, fmt.Sprintf("/permissions/%d/", permission.ID), http.MethodPost, &deactivated,
+		"backend.dbtcloud.DeleteUser")
+	return err
+}
+
+// fetchUser fetches the user by ID with its permissions
+func (dC *DbtCloudClient) fetchUser(ctx context.Context, userID string, methodName string) (*User, error) {
+	resp, err := get[User](ctx, dC, "/users/"+url.PathEscape(userID)+"/", methodName)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// accountPermission returns the active permission of the user in the account, nil when the user
+// is not in the account
+func (dC *DbtCloudClient) accountPermission(u *User) *Permission {
+	for i := range u.Permissions {
+		if u.Permissions[i].AccountID == dC.accountID && u.Permissions[i].State != permissionStateDeleted {
+			return &u.Permissions[i]
+		}
+	}
+	return nil
+}
+
+// userDetails converts the dbt Cloud user, its email is its username and its license its role
+func userDetails(u *User, permission *Permission) *structs.User {
+	user := &structs.User{
+		ID:          strconv.FormatInt(u.ID, 10),
+		UserName:    u.Email,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		DisplayName: strings.TrimSpace(u.FirstName + " " + u.LastName),
+	}
+	if permission != nil {
+		user.Role = permission.LicenseType
+	}
+	return user
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entraid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// EntraIDClient manages the security groups of an Entra ID directory and their members through
+// Microsoft Graph
+type EntraIDClient struct {
+	client   heimdall.Doer
+	graphURL string
+	auth     *tokenSource
+
+	// maxThrottleRetries and maxRetryAfter bound the retries of the requests throttled by Graph
+	maxThrottleRetries int
+	maxRetryAfter      time.Duration
+	// wait waits before retrying a throttled request, replaced in tests
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+func NewClient(entraIDAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*EntraIDClient, error) {
+
+	entraIDConfig := EntraIDConfig{}
+	if err := utils.MapToStruct(entraIDAppConfig, &entraIDConfig); err != nil {
+		return nil, err
+	}
+	if entraIDConfig.TenantID == "" || entraIDConfig.ClientID == "" || entraIDConfig.ClientSecret == "" {
+		return nil, errors.New("entra id configuration is missing required fields: tenant_id, client_id or client_secret")
+	}
+
+	maxThrottleRetries := entraIDConfig.MaxThrottleRetries
+	if maxThrottleRetries == 0 {
+		maxThrottleRetries = defaultMaxThrottleRetries
+	}
+	maxRetryAfter := defaultMaxRetryAfter
+	if entraIDConfig.MaxRetryAfter != "" {
+		var err error
+		maxRetryAfter, err = time.ParseDuration(entraIDConfig.MaxRetryAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entra id max_retry_after %q: %w", entraIDConfig.MaxRetryAfter, err)
+		}
+	}
+
+	graphURL := strings.TrimSuffix(entraIDConfig.GraphURL, "/")
+	if graphURL == "" {
+		graphURL = defaultGraphURL
+	}
+	loginURL := strings.TrimSuffix(entraIDConfig.LoginURL, "/")
+	if loginURL == "" {
+		loginURL = defaultLoginURL
+	}
+
+	client, err := httpclient.InitializeClient(
+		"entraid_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", entraIDConfig.ClientID)
+	form.Set("client_secret", entraIDConfig.ClientSecret)
+	form.Set("scope", graphScope)
+
+	return &EntraIDClient{
+		client:   client,
+		graphURL: graphURL,
+		auth: &tokenSource{
+			client: client,
+			url:    fmt.Sprintf("%s/%s/oauth2/v2.0/token", loginURL, url.PathEscape(entraIDConfig.TenantID)),
+			form:   form.Encode(),
+		},
+		maxThrottleRetries: maxThrottleRetries,
+		maxRetryAfter:      maxRetryAfter,
+		wait:               wait,
+	}, nil
+}
+
+// HealthCheck lists a single group, Graph is healthy when it answers with a token of the app
+func (eC *EntraIDClient) HealthCheck(ctx context.Context) error {
+	if _, err := eC.sendRequest(ctx, "/groups?$top=1&$select=id", http.MethodGet, nil,
+		"backend.entraid.HealthCheck"); err != nil {
+		return fmt.Errorf("entra id health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path of Graph and decodes its response
+func (eC *EntraIDClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := eC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a Graph request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode entra id response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of Graph and returns the response body
+func (eC *EntraIDClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	return eC.send(ctx, eC.graphURL+path, method, body, methodName)
+}
+
+// send sends the request to the URL with the access token of the app and returns the response body,
+// any response code other than 2xx is returned as a *request.ResponseError. The requests throttled by Graph
+// are retried after the delay of their Retry-After header.
+func (eC *EntraIDClient) send(ctx context.Context, url string, method string, body any,
+	methodName string) ([]byte, error) {
+
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		token, err := eC.auth.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, _, err := request.Send(ctx, eC.client, method, url, requestBody, map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+			"Accept":                       "application/json",
+			"Authorization":                "Bearer " + token,
+		}, methodName, "entraid", errorMessage)
+		var respErr *request.ResponseError
+		if !errors.As(err, &respErr) || !isThrottled(respErr.StatusCode) || attempt >= eC.maxThrottleRetries {
+			return resp, err
+		}
+
+		delay := eC.retryAfter(respErr.Header, attempt)
+		logger.Logger(ctx).WithField("service", "entraid").WithField("method", methodName).
+			WithField("responseCode", respErr.StatusCode).WithField("retryAfter", delay.String()).
+			Warn("entra id request throttled, retrying")
+		if err := eC.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isThrottled reports whether Graph throttled the request, it answers 429 and sometimes 503 or
+// 504 when a service is overloaded
+func isThrottled(respCode int) bool {
+	return respCode == http.StatusTooManyRequests || respCode == http.StatusServiceUnavailable ||
+		respCode == http.StatusGatewayTimeout
+}
+
+// retryAfter returns the delay before retrying a throttled request: the seconds of its Retry-After
+// header, or an exponential backoff from a second when Graph gives none, capped by maxRetryAfter
+func (eC *EntraIDClient) retryAfter(headers http.Header, attempt int) time.Duration {
+	delay := time.Second << attempt
+	if seconds, err := strconv.Atoi(headers.Get("Retry-After")); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	return min(delay, eC.maxRetryAfter)
+}
+
+// wait waits for the delay or the cancellation of the context
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tokenSource hands out the access token of the app, renewed shortly before it expires
+type tokenSource struct {
+	client heimdall.Doer
+	url    string
+	// form is the encoded form of the client credentials grant
+	form string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// Token returns the access token of the app, requesting a new one when the current one is about
+// to expire
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && time.Until(ts.expiresAt) > tokenRefreshMargin {
+		return ts.token, nil
+	}
+
+	resp, _, err := request.Send(ctx, ts.client, http.MethodPost, ts.url, []byte(ts.form), map[string]string{
+		constants.ContentTypeHeaderKey: "application/x-www-form-urlencoded",
+		"Accept":                       "application/json",
+	}, "backend.entraid.FetchToken", "entraid", errorMessage)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch entra id access token: %w", err)
+	}
+	var token tokenResponse
+	if err := decode(resp, &token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("no access token in the entra id token response")
+	}
+	ts.token = token.AccessToken
+	ts.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return ts.token, nil
+}
+
+// errorMessage returns the error code and message of an error response of Graph, e.g.
+// Request_ResourceNotFound, or of the identity platform
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	var graphErr graphError
+	if json.Unmarshal(errResp.Error, &graphErr) == nil {
+		return graphErr.Code, graphErr.Message
+	}
+	var code string
+	if json.Unmarshal(errResp.Error, &code) == nil {
+		return code, errResp.ErrorDescription
+	}
+	return "", ""
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entraid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeGraph is a directory holding its users and group members in memory, throttling the first
+// requests of a path when asked to
+type fakeGraph struct {
+	url       string
+	tokens    int
+	users     []User
+	members   map[string]bool
+	throttled map[string]int
+	patches   int
+	created   []Group
+	deleted   []string
+}
+
+func (f *fakeGraph) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/tenant-1/oauth2/v2.0/token" {
+		_ = r.ParseForm()
+		if r.PostForm.Get("client_secret") != "secret" || r.PostForm.Get("scope") != graphScope {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client",
+				"error_description": "AADSTS7000215: Invalid client secret provided."}`))
+			return
+		}
+		f.tokens++
+		_, _ = w.Write([]byte(`{"access_token": "access-token", "expires_in": 3599}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if f.throttled[r.URL.Path] > 0 {
+		f.throttled[r.URL.Path]--
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": {"code": "TooManyRequests", "message": "Too many requests"}}`))
+		return
+	}
+
+	resource := strings.TrimPrefix(r.URL.Path, "/v1.0")
+	switch {
+	case r.Method == http.MethodGet && resource == "/users" && r.URL.Query().Has("$filter"):
+		var users []User
+		for _, u := range f.users {
+			if strings.Contains(r.URL.Query().Get("$filter"), "'"+u.UserPrincipalName+"'") {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, listResponse[User]{Value: users})
+	case r.Method == http.MethodGet && resource == "/users":
+		// a page of a single user, with the link of the next one
+		skip := 0
+		_, _ = fmt.Sscan(r.URL.Query().Get("$skiptoken"), &skip)
+		page := listResponse[User]{Value: f.users[skip : skip+1]}
+		if skip+1 < len(f.users) {
+			page.NextLink = fmt.Sprintf("%s/v1.0/users?$skiptoken=%d", f.url, skip+1)
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, page)
+	case r.Method == http.MethodGet && resource == "/groups":
+		if r.URL.Query().Get("$filter") != securityGroupsFilter && r.URL.Query().Has("$filter") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"value": [{"id": "g-1", "displayName": "team-a"}]}`))
+	case r.Method == http.MethodPost && resource == "/groups":
+		var group Group
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		f.created = append(f.created, group)
+		group.ID = "g-2"
+		fakehttp.WriteJSON(w, http.StatusCreated, group)
+	case r.Method == http.MethodGet && resource == "/groups/g-1/members/microsoft.graph.user":
+		var users []User
+		for _, u := range f.users {
+			if f.members[u.ID] {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, listResponse[User]{Value: users})
+	case r.Method == http.MethodPatch && resource == "/groups/g-1":
+		f.patches++
+		var body map[string][]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, ref := range body["members@odata.bind"] {
+			if f.members[ref[strings.LastIndex(ref, "/")+1:]] {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error": {"code": "Request_BadRequest", "message":
+					"One or more added object references already exist for the following modified properties: 'members'."}}`)
+				return
+			}
+		}
+		for _, ref := range body["members@odata.bind"] {
+			f.members[ref[strings.LastIndex(ref, "/")+1:]] = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && resource == "/groups/g-1/members/$ref":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		userID := body["@odata.id"][strings.LastIndex(body["@odata.id"], "/")+1:]
+		if f.members[userID] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error": {"code": "Request_BadRequest",
+				"message": "One or more added object references already exist"}}`)
+			return
+		}
+		f.members[userID] = true
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && strings.HasPrefix(resource, "/groups/g-1/members/"):
+		userID := strings.Split(resource, "/")[4]
+		if !f.members[userID] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.members, userID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		f.deleted = append(f.deleted, resource)
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// newTestClient returns a client of the fake directory, recording the waits of the throttled
+// requests instead of waiting
+func newTestClient(t *testing.T, fake *fakeGraph,
+	connection map[string]interface{}) (*EntraIDClient, *[]time.Duration) {
+	t.Helper()
+	if fake.members == nil {
+		fake.members = map[string]bool{}
+	}
+	server := fakehttp.NewServer(t, fake)
+	fake.url = server.URL
+
+	connection["tenant_id"] = "tenant-1"
+	connection["client_id"] = "app-1"
+	if _, ok := connection["client_secret"]; !ok {
+		connection["client_secret"] = "secret"
+	}
+	connection["graph_url"] = server.URL + "/v1.0"
+	connection["login_url"] = server.URL
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+
+	waits := &[]time.Duration{}
+	client.wait = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return client, waits
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"tenant_id": "tenant-1", "client_id": "app-1"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "tenant_id, client_id or client_secret")
+
+	_, err = NewClient(map[string]interface{}{"tenant_id": "tenant-1", "client_id": "app-1", "client_secret": "secret",
+		"max_retry_after": "soon"}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid entra id max_retry_after")
+
+	client, err := NewClient(map[string]interface{}{"tenant_id": "tenant-1", "client_id": "app-1",
+		"client_secret": "secret"}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultGraphURL, client.graphURL)
+	assert.Equal(t, "https://login.microsoftonline.com/tenant-1/oauth2/v2.0/token", client.auth.url)
+}
+
+func TestThrottledRequests(t *testing.T) {
+	fake := &fakeGraph{throttled: map[string]int{"/v1.0/groups": 2}}
+	client, waits := newTestClient(t, fake, map[string]interface{}{"max_retry_after": "5s"})
+
+	require.NoError(t, client.HealthCheck(context.Background()))
+	// the Retry-After of 7s is capped by max_retry_after
+	assert.Equal(t, []time.Duration{5 * time.Second, 5 * time.Second}, *waits)
+
+	fake.throttled["/v1.0/groups"] = 3
+	client, _ = newTestClient(t, fake, map[string]interface{}{"max_throttle_retries": 2})
+	err := client.HealthCheck(context.Background())
+	var respErr *request.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusTooManyRequests, respErr.StatusCode)
+	assert.Equal(t, "TooManyRequests", respErr.Code)
+}
+
+func TestRetryAfter(t *testing.T) {
+	client := &EntraIDClient{maxRetryAfter: time.Minute}
+	assert.Equal(t, 3*time.Second, client.retryAfter(http.Header{"Retry-After": {"3"}}, 0))
+	assert.Equal(t, 4*time.Second, client.retryAfter(http.Header{}, 2))
+	assert.Equal(t, time.Minute, client.retryAfter(http.Header{}, 10))
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeGraph{users: []User{
+		{ID: "u-1", UserPrincipalName: "jdoe@example.com", Mail: "john.doe@example.com", DisplayName: "John Doe"},
+		{ID: "u-2", UserPrincipalName: "asmith@example.com"},
+		{ID: "u-3", UserPrincipalName: "bob@example.com"},
+	}}
+	client, _ := newTestClient(t, fake, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 3)
+	assert.Equal(t, "u-1", byEmail["john.doe@example.com"].ID)
+	assert.Equal(t, "u-2", byEmail["asmith@example.com"].ID)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{Email: "bob@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "u-3", user.ID)
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "unknown@example.com"})
+	assert.ErrorContains(t, err, "entra id user unknown@example.com not found")
+	// the access token is reused until it expires
+	assert.Equal(t, 1, fake.tokens)
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeGraph{}
+	client, _ := newTestClient(t, fake, map[string]interface{}{})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"team-a": {ID: "g-1", Name: "team-a"}}, teams)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "Data Platform (EU)"})
+	require.NoError(t, err)
+	assert.Equal(t, "g-2", team.ID)
+	require.Len(t, fake.created, 1)
+	assert.Equal(t, "Data-Platform-EU", fake.created[0].MailNickname)
+	assert.True(t, fake.created[0].SecurityEnabled)
+	assert.False(t, fake.created[0].MailEnabled)
+
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "g-2"))
+	assert.Equal(t, []string{"/groups/g-2"}, fake.deleted)
+}
+
+func TestTeamMembership(t *testing.T) {
+	fake := &fakeGraph{members: map[string]bool{"u-5": true}}
+	for i := 1; i <= 25; i++ {
+		fake.users = append(fake.users, User{ID: fmt.Sprintf("u-%d", i)})
+	}
+	client, _ := newTestClient(t, fake, map[string]interface{}{})
+
+	userIDs := make([]string, 0, 25)
+	for _, u := range fake.users {
+		userIDs = append(userIDs, u.ID)
+	}
+	// the first 20 users are added one by one as u-5 is already a member
+	require.NoError(t, client.AddUserToTeam(context.Background(), "g-1", userIDs))
+	assert.Equal(t, 2, fake.patches)
+	assert.Len(t, fake.members, 25)
+
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "g-1", []string{"u-1", "u-30"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g-1")
+	require.NoError(t, err)
+	assert.Len(t, members, 24)
+	assert.NotContains(t, members, "u-1")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client, _ := newTestClient(t, &fakeGraph{}, map[string]interface{}{"client_secret": "wrong"})
+	err := client.HealthCheck(context.Background())
+	var respErr *request.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "invalid_client", respErr.Code)
+	assert.Contains(t, respErr.Message, "Invalid client secret provided")
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entraid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchTeamMembersByTeamID lists the user members of the group by ID, keyed by ID. The groups and
+// devices members of the group are left out.
+func (eC *EntraIDClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members := make(map[string]*structs.User)
+	path := fmt.Sprintf("/groups/%s/members/microsoft.graph.user?$select=%s&$top=%d", url.PathEscape(teamID),
+		userSelect, pageSize)
+	err := forEachPage(ctx, eC, path, "backend.entraid.FetchTeamMembersByTeamID", func(users []User) error {
+		for _, u := range users {
+			members[u.ID] = userDetails(&u)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch entra id group members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group, 20 users per request. When some of the users of a
+// request are already members, Graph rejects the whole request and its users are added one by one.
+func (eC *EntraIDClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.AddUserToTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "entraid")
+	for start := 0; start < len(userIDs); start += maxMembersPerRequest {
+		chunk := userIDs[start:min(start+maxMembersPerRequest, len(userIDs))]
+		log.WithField("userIDs", chunk).Info("Add users to entra id group")
+
+		references := make([]string, 0, len(chunk))
+		for _, userID := range chunk {
+			references = append(references, eC.directoryObject(userID))
+		}
+		_, err := eC.sendRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodPatch,
+			map[string][]string{"members@odata.bind": references}, "backend.entraid.AddUserToTeam")
+		if err == nil {
+			continue
+		}
+		if !isAlreadyMember(err) {
+			return fmt.Errorf("failed to add users to entra id group %s: %w", teamID, err)
+		}
+		for _, userID := range chunk {
+			_, err := eC.sendRequest(ctx, "/groups/"+url.PathEscape(teamID)+"/members/$ref", http.MethodPost,
+				map[string]string{"@odata.id": eC.directoryObject(userID)}, "backend.entraid.AddUserToTeam")
+			if err != nil && !isAlreadyMember(err) {
+				return fmt.Errorf("failed to add user %s to entra id group %s: %w", userID, teamID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveUserFromTeam deletes the member references of the users from the group one at a time, as
+// Graph has no bulk removal. Graph answers 404 for a user without a reference, which is skipped.
+func (eC *EntraIDClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.RemoveUserFromTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "entraid")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Remove user from entra id group")
+		_, err := eC.sendRequest(ctx,
+			fmt.Sprintf("/groups/%s/members/%s/$ref", url.PathEscape(teamID), url.PathEscape(userID)),
+			http.MethodDelete, nil, "backend.entraid.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s from entra id group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// directoryObject returns the reference of the directory object of the ID
+func (eC *EntraIDClient) directoryObject(id string) string {
+	return eC.graphURL + "/directoryObjects/" + url.PathEscape(id)
+}
+
+// isAlreadyMember reports whether the error is a Graph response for a member added twice
+func isAlreadyMember(err error) bool {
+	var respErr *request.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(respErr.Message, "already exist")
+}
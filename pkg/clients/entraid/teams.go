@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entraid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// securityGroupsFilter selects the security groups which are not mail enabled, the groups managed
+// by usernaut
+const securityGroupsFilter = "securityEnabled eq true and mailEnabled eq false"
+
+// FetchAllTeams lists the security groups of the directory, keyed by display name
+func (eC *EntraIDClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := eC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch entra id groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the security groups of the directory, 999 groups at
+// a time
+func (eC *EntraIDClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	path := fmt.Sprintf("/groups?$filter=%s&$select=id,displayName,description&$top=%d",
+		url.QueryEscape(securityGroupsFilter), pageSize)
+	return forEachPage(ctx, eC, path, "backend.entraid.FetchAllTeams", func(groups []Group) error {
+		page := make([]structs.Team, 0, len(groups))
+		for _, group := range groups {
+			page = append(page, teamDetails(&group))
+		}
+		return fn(page)
+	})
+}
+
+// FetchTeamDetails fetches the group by ID
+func (eC *EntraIDClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.FetchTeamDetails")
+	defer span.Finish()
+
+	var group Group
+	if err := eC.get(ctx, "/groups/"+url.PathEscape(teamID)+"?$select=id,displayName,description", &group,
+		"backend.entraid.FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+	team := teamDetails(&group)
+	return &team, nil
+}
+
+// CreateTeam creates the security group, its mail nickname is derived from its name as Graph
+// requires one for the groups which are not mail enabled too
+func (eC *EntraIDClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "entraid")
+	log.Info("Create entra id security group")
+
+	resp, err := eC.sendRequest(ctx, "/groups", http.MethodPost, &Group{
+		DisplayName:     team.Name,
+		Description:     team.Description,
+		MailNickname:    mailNickname(team.Name),
+		MailEnabled:     false,
+		SecurityEnabled: true,
+	}, "backend.entraid.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create entra id security group")
+		return nil, err
+	}
+
+	var created Group
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no group ID in the entra id create group response")
+	}
+	details := teamDetails(&created)
+	return &details, nil
+}
+
+// DeleteTeamByID deletes the group by ID, Entra ID keeps the deleted groups restorable for 30 days.
+// A group which does not exist is considered deleted.
+func (eC *EntraIDClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "entraid")
+	log.Info("Delete entra id security group")
+
+	_, err := eC.sendRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.entraid.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("entra id group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// ReconcileGroupParams is a no-op, Entra ID groups have no group params
+func (eC *EntraIDClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	return nil
+}
+
+// teamDetails converts the Graph group
+func teamDetails(g *Group) structs.Team {
+	return structs.Team{
+		ID:          g.ID,
+		Name:        g.DisplayName,
+		Description: g.Description,
+	}
+}
+
+// mailNickname returns the mail nickname of the group name, the ASCII letters, digits, dots,
+// dashes and underscores of the name up to 64 characters
+func mailNickname(name string) string {
+	var nickname strings.Builder
+	for _, r := range name {
+		if nickname.Len() == 64 {
+			break
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			nickname.WriteRune(r)
+		case r == ' ':
+			nickname.WriteRune('-')
+		}
+	}
+	if nickname.Len() == 0 {
+		return "usernaut-group"
+	}
+	return nickname.String()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entraid
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// defaultGraphURL is the Microsoft Graph API of the global cloud
+	defaultGraphURL = "https://graph.microsoft.com/v1.0"
+	// defaultLoginURL is the Microsoft identity platform of the global cloud
+	defaultLoginURL = "https://login.microsoftonline.com"
+	// graphScope is the scope of the client credentials, the application permissions of the app
+	graphScope = "https://graph.microsoft.com/.default"
+	// pageSize is the number of resources requested per list page, the largest page of Graph
+	pageSize = 999
+	// maxMembersPerRequest is the largest number of members Graph adds to a group in one request
+	maxMembersPerRequest = 20
+	// tokenRefreshMargin is how long before its expiry the access token is renewed
+	tokenRefreshMargin = time.Minute
+	// userSelect are the properties of the users read from Graph
+	userSelect = "id,userPrincipalName,mail,givenName,surname,displayName"
+)
+
+// Defaults of the retries of the throttled requests
+const (
+	defaultMaxThrottleRetries = 5
+	defaultMaxRetryAfter      = time.Minute
+)
+
+// EntraIDConfig is the connection of an Entra ID backend, read from the backend configuration. The
+// client authenticates as an app registration with the client credentials grant.
+type EntraIDConfig struct {
+	// TenantID is the directory of the app registration and of the managed groups
+	TenantID string `json:"tenant_id"`
+	// ClientID and ClientSecret are the credentials of the app registration
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	// GraphURL is the Graph API, https://graph.microsoft.com/v1.0 (default) or the one of a
+	// national cloud
+	GraphURL string `json:"graph_url"`
+	// LoginURL is the identity platform issuing the tokens, https://login.microsoftonline.com
+	// (default) or the one of a national cloud
+	LoginURL string `json:"login_url"`
+	// MaxThrottleRetries is how many times a throttled request is retried, 5 by default, a
+	// negative value disables the retries
+	MaxThrottleRetries int `json:"max_throttle_retries"`
+	// MaxRetryAfter caps the wait before retrying a throttled request, e.g. 30s, 1m by default
+	MaxRetryAfter string `json:"max_retry_after"`
+}
+
+// User is a user of the directory
+type User struct {
+	ID                string `json:"id"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	Mail              string `json:"mail"`
+	GivenName         string `json:"givenName"`
+	Surname           string `json:"surname"`
+	DisplayName       string `json:"displayName"`
+}
+
+// Group is a group of the directory
+type Group struct {
+	ID              string `json:"id,omitempty"`
+	DisplayName     string `json:"displayName"`
+	Description     string `json:"description,omitempty"`
+	MailNickname    string `json:"mailNickname,omitempty"`
+	MailEnabled     bool   `json:"mailEnabled"`
+	SecurityEnabled bool   `json:"securityEnabled"`
+}
+
+// listResponse is a page of a Graph collection
+type listResponse[T any] struct {
+	Value    []T    `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// tokenResponse is the response of the token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// errorResponse is the body of the Graph errors, whose error is a graphError, and of the token
+// endpoint errors, whose error is a code described by error_description
+type errorResponse struct {
+	Error            json.RawMessage `json:"error"`
+	ErrorDescription string          `json:"error_description"`
+}
+
+// graphError is the error of a Graph error response
+type graphError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entraid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers lists the users of the directory, keyed by ID and by email
+func (eC *EntraIDClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := eC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch entra id users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users of the directory, 999 users at a time
+func (eC *EntraIDClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachPage(ctx, eC, fmt.Sprintf("/users?$select=%s&$top=%d", userSelect, pageSize),
+		"backend.entraid.FetchAllUsers", func(graphUsers []User) error {
+			page := make([]*structs.User, 0, len(graphUsers))
+			for _, u := range graphUsers {
+				page = append(page, userDetails(&u))
+			}
+			return fn(page)
+		})
+}
+
+// FetchUserDetails fetches the user by ID or user principal name
+func (eC *EntraIDClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := eC.get(ctx, fmt.Sprintf("/users/%s?$select=%s", url.PathEscape(userID), userSelect), &user,
+		"backend.entraid.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser looks the user up by its email, the mail or the user principal name of a user of the
+// directory. The users of Entra ID are provisioned by its directory synchronization, and are not
+// created by usernaut.
+func (eC *EntraIDClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.entraid.CreateUser")
+	defer span.Finish()
+
+	if u.Email == "" {
+		return nil, fmt.Errorf("entra id users are looked up by email, user %s has none", u.UserName)
+	}
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "entraid")
+	log.Info("Look up entra id user")
+
+	email := strings.ReplaceAll(u.Email, "'", "''")
+	filter := url.QueryEscape(fmt.Sprintf("mail eq '%s' or userPrincipalName eq '%s'", email, email))
+	var page listResponse[User]
+	if err := eC.get(ctx, fmt.Sprintf("/users?$filter=%s&$select=%s", filter, userSelect), &page,
+		"backend.entraid.CreateUser"); err != nil {
+		log.WithError(err).Error("failed to look up entra id user")
+		return nil, err
+	}
+	if len(page.Value) == 0 {
+		return nil, fmt.Errorf("entra id user %s not found", u.Email)
+	}
+	return userDetails(&page.Value[0]), nil
+}
+
+// DeleteUser is a no-op, the users of Entra ID are deprovisioned by its directory synchronization.
+// The offboarded users are removed from the groups by the reconciles of their groups.
+func (eC *EntraIDClient) DeleteUser(ctx context.Context, userID string) error {
+	logger.Logger(ctx).WithField("userID", userID).WithField("service", "entraid").
+		Info("entra id users are deprovisioned by the directory synchronization, skipping user deletion")
+	return nil
+}
+
+// userDetails converts the Graph user, the user principal name is the username of the user and its
+// fallback email
+func userDetails(u *User) *structs.User {
+	email := u.Mail
+	if email == "" {
+		email = u.UserPrincipalName
+	}
+	return &structs.User{
+		ID:          u.ID,
+		UserName:    u.UserPrincipalName,
+		Email:       email,
+		FirstName:   u.GivenName,
+		LastName:    u.Surname,
+		DisplayName: u.DisplayName,
+	}
+}
+
+// forEachPage calls fn with each page of the collection of the path, following the next links of
+// the pages
+func forEachPage[T any](ctx context.Context, eC *EntraIDClient, path string, methodName string,
+	fn func(page []T) error) error {
+
+	for pageURL := eC.graphURL + path; pageURL != ""; {
+		resp, err := eC.send(ctx, pageURL, http.MethodGet, nil, methodName)
+		if err != nil {
+			return err
+		}
+		var page listResponse[T]
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		if err := fn(page.Value); err != nil {
+			return err
+		}
+		pageURL = page.NextLink
+	}
+	return nil
+}
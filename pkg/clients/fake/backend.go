@@ -0,0 +1,392 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// ErrInjected is returned by the operations listed in the fail_operations of the backend
+var ErrInjected = errors.New("fake backend failure")
+
+// FakeConfig is the connection of a fake backend, read from the backend configuration
+type FakeConfig struct {
+	// Users are created with the backend, e.g. to exercise the preload of existing users
+	Users []SeedUser `json:"users"`
+	// Teams are created with the backend, e.g. to exercise the adoption of existing teams
+	Teams []string `json:"teams"`
+	// FailOperations are the names of the client methods which fail with ErrInjected, e.g.
+	// AddUserToTeam
+	FailOperations []string `json:"fail_operations"`
+}
+
+// SeedUser is a user created with the backend
+type SeedUser struct {
+	UserName string `json:"username"`
+	Email    string `json:"email"`
+}
+
+var (
+	sharedMu sync.Mutex
+	// shared are the backends of the configured fake backends, shared by all their clients so that
+	// the state outlives the reconciles
+	shared = make(map[string]*Backend)
+)
+
+// Backend is a backend holding its users, teams and team memberships in memory. It implements
+// clients.Client and clients.TeamRoleClient, for the e2e tests and the local development.
+type Backend struct {
+	mu      sync.Mutex
+	nextID  int
+	users   map[string]*structs.User
+	teams   map[string]structs.Team
+	members map[string]map[string]string
+	params  map[string]structs.TeamParams
+	fail    map[string]bool
+}
+
+// New returns an empty backend
+func New() *Backend {
+	return &Backend{
+		users:   make(map[string]*structs.User),
+		teams:   make(map[string]structs.Team),
+		members: make(map[string]map[string]string),
+		params:  make(map[string]structs.TeamParams),
+		fail:    make(map[string]bool),
+	}
+}
+
+// Shared returns the backend of the fake backend of the name, created with the connection on first
+// use. The later connections are ignored, the state of the backend is kept until Reset.
+func Shared(name string, connection map[string]interface{}) (*Backend, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if backend, ok := shared[name]; ok {
+		return backend, nil
+	}
+
+	fakeConfig := FakeConfig{}
+	if err := utils.MapToStruct(connection, &fakeConfig); err != nil {
+		return nil, err
+	}
+	backend := New()
+	for _, user := range fakeConfig.Users {
+		_, _ = backend.CreateUser(context.Background(), &structs.User{UserName: user.UserName, Email: user.Email})
+	}
+	for _, team := range fakeConfig.Teams {
+		_, _ = backend.CreateTeam(context.Background(), &structs.Team{Name: team})
+	}
+	for _, operation := range fakeConfig.FailOperations {
+		backend.FailOn(operation, true)
+	}
+	shared[name] = backend
+	return backend, nil
+}
+
+// Reset forgets the shared backends, e.g. between the e2e tests
+func Reset() {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	shared = make(map[string]*Backend)
+}
+
+// FailOn makes the client method of the operation fail with ErrInjected, or succeed again
+func (b *Backend) FailOn(operation string, fail bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fail[strings.ToLower(operation)] = fail
+}
+
+// Members returns the IDs of the members of the team with their role, e.g. to assert the
+// membership reconciled by the e2e tests
+func (b *Backend) Members(teamID string) map[string]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return maps.Clone(b.members[teamID])
+}
+
+// TeamByName returns the team of the name
+func (b *Backend) TeamByName(name string) (structs.Team, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, team := range b.teams {
+		if team.Name == name {
+			return team, true
+		}
+	}
+	return structs.Team{}, false
+}
+
+// GroupParams returns the group params reconciled for the team
+func (b *Backend) GroupParams(teamID string) (structs.TeamParams, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	params, ok := b.params[teamID]
+	return params, ok
+}
+
+// check returns ErrInjected when the operation fails, it must be called with the lock held
+func (b *Backend) check(operation string) error {
+	if b.fail[strings.ToLower(operation)] {
+		return fmt.Errorf("%s: %w", operation, ErrInjected)
+	}
+	return nil
+}
+
+// newID returns the ID of the next user or team, it must be called with the lock held
+func (b *Backend) newID(prefix string) string {
+	b.nextID++
+	return prefix + "-" + strconv.Itoa(b.nextID)
+}
+
+func (b *Backend) HealthCheck(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.check("HealthCheck")
+}
+
+func (b *Backend) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("FetchAllUsers"); err != nil {
+		return nil, nil, err
+	}
+
+	usersByID := make(map[string]*structs.User, len(b.users))
+	usersByEmail := make(map[string]*structs.User, len(b.users))
+	for id, user := range b.users {
+		copied := *user
+		usersByID[id] = &copied
+		usersByEmail[user.Email] = &copied
+	}
+	return usersByID, usersByEmail, nil
+}
+
+func (b *Backend) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("FetchUserDetails"); err != nil {
+		return nil, err
+	}
+
+	user, ok := b.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %s not found", userID)
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// CreateUser creates the user, or returns the user of the same email when it already exists
+func (b *Backend) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("CreateUser"); err != nil {
+		return nil, err
+	}
+
+	for _, user := range b.users {
+		if user.Email == u.Email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	created := *u
+	created.ID = b.newID("user")
+	b.users[created.ID] = &created
+	copied := created
+	return &copied, nil
+}
+
+// DeleteUser deletes the user and its team memberships
+func (b *Backend) DeleteUser(ctx context.Context, userID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("DeleteUser"); err != nil {
+		return err
+	}
+
+	delete(b.users, userID)
+	for _, members := range b.members {
+		delete(members, userID)
+	}
+	return nil
+}
+
+// FetchAllTeams returns the teams keyed by name
+func (b *Backend) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("FetchAllTeams"); err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(b.teams))
+	for _, team := range b.teams {
+		teams[team.Name] = team
+	}
+	return teams, nil
+}
+
+func (b *Backend) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+
+	team, ok := b.teams[teamID]
+	if !ok {
+		return nil, fmt.Errorf("team %s not found", teamID)
+	}
+	return &team, nil
+}
+
+// CreateTeam creates the team, or returns the team of the same name when it already exists
+func (b *Backend) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("CreateTeam"); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range b.teams {
+		if existing.Name == team.Name {
+			return &existing, nil
+		}
+	}
+	created := *team
+	created.ID = b.newID("team")
+	b.teams[created.ID] = created
+	b.members[created.ID] = make(map[string]string)
+	return &created, nil
+}
+
+func (b *Backend) DeleteTeamByID(ctx context.Context, teamID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("DeleteTeamByID"); err != nil {
+		return err
+	}
+
+	delete(b.teams, teamID)
+	delete(b.members, teamID)
+	delete(b.params, teamID)
+	return nil
+}
+
+// FetchTeamMembersByTeamID returns the members of the team with their role, keyed by ID
+func (b *Backend) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("FetchTeamMembersByTeamID"); err != nil {
+		return nil, err
+	}
+	if _, ok := b.teams[teamID]; !ok {
+		return nil, fmt.Errorf("team %s not found", teamID)
+	}
+
+	members := make(map[string]*structs.User, len(b.members[teamID]))
+	for userID, role := range b.members[teamID] {
+		member := structs.User{ID: userID, Role: role}
+		if user, ok := b.users[userID]; ok {
+			member = *user
+			member.Role = role
+		}
+		members[userID] = &member
+	}
+	return members, nil
+}
+
+// ReconcileGroupParams records the group params of the team, see GroupParams
+func (b *Backend) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("ReconcileGroupParams"); err != nil {
+		return err
+	}
+
+	groupParams.Value = slices.Clone(groupParams.Value)
+	b.params[teamID] = groupParams
+	return nil
+}
+
+func (b *Backend) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return b.setMembers("AddUserToTeam", teamID, userIDs, "", false)
+}
+
+func (b *Backend) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check("RemoveUserFromTeam"); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		delete(b.members[teamID], userID)
+	}
+	return nil
+}
+
+func (b *Backend) AddUserToTeamWithRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	return b.setMembers("AddUserToTeamWithRole", teamID, userIDs, role, false)
+}
+
+// ValidMemberRole accepts any role, the fake backend records the roles as given
+func (b *Backend) ValidMemberRole(role string) bool {
+	return role != ""
+}
+
+func (b *Backend) UpdateTeamMemberRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	return b.setMembers("UpdateTeamMemberRole", teamID, userIDs, role, true)
+}
+
+// setMembers sets the role of the users in the team, adding them unless only existing members are
+// updated
+func (b *Backend) setMembers(operation, teamID string, userIDs []string, role string, existingOnly bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(operation); err != nil {
+		return err
+	}
+
+	members, ok := b.members[teamID]
+	if !ok {
+		return fmt.Errorf("team %s not found", teamID)
+	}
+	for _, userID := range userIDs {
+		if _, ok := b.users[userID]; !ok {
+			return fmt.Errorf("user %s not found", userID)
+		}
+		if _, isMember := members[userID]; existingOnly && !isMember {
+			return fmt.Errorf("user %s is not a member of team %s", userID, teamID)
+		}
+		members[userID] = role
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+func TestTeamMembership(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+
+	alice, err := backend.CreateUser(ctx, &structs.User{UserName: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	bob, err := backend.CreateUser(ctx, &structs.User{UserName: "bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+	again, err := backend.CreateUser(ctx, &structs.User{UserName: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, again.ID)
+
+	team, err := backend.CreateTeam(ctx, &structs.Team{Name: "team-a"})
+	require.NoError(t, err)
+	teams, err := backend.FetchAllTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, team.ID, teams["team-a"].ID)
+
+	require.NoError(t, backend.AddUserToTeam(ctx, team.ID, []string{alice.ID}))
+	require.NoError(t, backend.AddUserToTeamWithRole(ctx, team.ID, []string{bob.ID}, "maintainer"))
+	assert.Error(t, backend.AddUserToTeam(ctx, team.ID, []string{"user-404"}))
+
+	members, err := backend.FetchTeamMembersByTeamID(ctx, team.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "alice@example.com", members[alice.ID].Email)
+	assert.Equal(t, "maintainer", members[bob.ID].Role)
+
+	require.NoError(t, backend.RemoveUserFromTeam(ctx, team.ID, []string{alice.ID}))
+	assert.Equal(t, map[string]string{bob.ID: "maintainer"}, backend.Members(team.ID))
+
+	require.NoError(t, backend.DeleteTeamByID(ctx, team.ID))
+	_, err = backend.FetchTeamMembersByTeamID(ctx, team.ID)
+	assert.Error(t, err)
+}
+
+func TestShared(t *testing.T) {
+	t.Cleanup(Reset)
+	ctx := context.Background()
+
+	backend, err := Shared("fake-a", map[string]interface{}{
+		"users":           []interface{}{map[string]interface{}{"username": "alice", "email": "alice@example.com"}},
+		"teams":           []interface{}{"existing-team"},
+		"fail_operations": []interface{}{"DeleteUser"},
+	})
+	require.NoError(t, err)
+
+	same, err := Shared("fake-a", nil)
+	require.NoError(t, err)
+	assert.Same(t, backend, same)
+
+	_, byEmail, err := backend.FetchAllUsers(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, byEmail, "alice@example.com")
+	_, exists := backend.TeamByName("existing-team")
+	assert.True(t, exists)
+
+	assert.ErrorIs(t, backend.DeleteUser(ctx, byEmail["alice@example.com"].ID), ErrInjected)
+	backend.FailOn("DeleteUser", false)
+	assert.NoError(t, backend.DeleteUser(ctx, byEmail["alice@example.com"].ID))
+
+	Reset()
+	other, err := Shared("fake-a", nil)
+	require.NoError(t, err)
+	assert.NotSame(t, backend, other)
+}
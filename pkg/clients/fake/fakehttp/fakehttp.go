@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakehttp holds the fixtures shared by the tests of the HTTP backend clients, which
+// serve a fake API of the backend from an httptest server.
+package fakehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// NewServer starts a server serving the handler, closed when the test ends
+func NewServer(t testing.TB, handler http.Handler) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ConnectionPoolConfig returns the connection pool of a client of the test. The backend is named
+// after the test, so that the clients of the tests don't share a circuit breaker.
+func ConnectionPoolConfig(t testing.TB) httpclient.ConnectionPoolConfig {
+	return httpclient.ConnectionPoolConfig{
+		Timeout: 1000, KeepAliveTimeout: 1000, MaxIdleConnections: 1, BackendName: t.Name(),
+	}
+}
+
+// HystrixResiliencyConfig returns the circuit breaker of a client of a test, which never opens on
+// the errors the fake APIs answer with
+func HystrixResiliencyConfig() httpclient.HystrixResiliencyConfig {
+	return httpclient.HystrixResiliencyConfig{
+		MaxConcurrentRequests: 10, RequestVolumeThreshold: 100, CircuitBreakerSleepWindow: 1000,
+		ErrorPercentThreshold: 100, CircuitBreakerTimeout: 1000,
+	}
+}
+
+// WriteJSON writes the response with the status code and the JSON encoding of the body
+func WriteJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set(constants.ContentTypeHeaderKey, "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakeserver serves the SCIM 2.0 API of a fake backend over HTTP, so that the SCIM
+// backends and the HTTP path of the clients can be exercised without a real service.
+package fakeserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/scim"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// Token is the bearer token accepted by the server
+const Token = "fake-token"
+
+// removeMemberPath matches the path of the PATCH operations removing a member
+var removeMemberPath = regexp.MustCompile(`^members\[value eq "([^"]+)"\]$`)
+
+// NewSCIMServer starts a server serving the SCIM API of the backend under /scim/v2, to be closed by
+// the caller. The base_url of a scim backend is the URL of the server followed by /scim/v2.
+func NewSCIMServer(backend *fake.Backend) *httptest.Server {
+	return httptest.NewServer(NewSCIMHandler(backend))
+}
+
+// NewSCIMHandler returns the handler of the SCIM API of the backend
+func NewSCIMHandler(backend *fake.Backend) http.Handler {
+	h := &handler{backend: backend}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Users", h.listUsers)
+	mux.HandleFunc("POST /scim/v2/Users", h.createUser)
+	mux.HandleFunc("GET /scim/v2/Users/{id}", h.getUser)
+	mux.HandleFunc("DELETE /scim/v2/Users/{id}", h.deleteUser)
+	mux.HandleFunc("GET /scim/v2/Groups", h.listGroups)
+	mux.HandleFunc("POST /scim/v2/Groups", h.createGroup)
+	mux.HandleFunc("GET /scim/v2/Groups/{id}", h.getGroup)
+	mux.HandleFunc("PATCH /scim/v2/Groups/{id}", h.patchGroup)
+	mux.HandleFunc("DELETE /scim/v2/Groups/{id}", h.deleteGroup)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "", "invalid token")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+type handler struct {
+	backend *fake.Backend
+}
+
+func (h *handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	users, _, err := h.backend.FetchAllUsers(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	resources := make([]scim.User, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, toSCIMUser(user))
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	writeList(w, r, resources)
+}
+
+func (h *handler) createUser(w http.ResponseWriter, r *http.Request) {
+	var user scim.User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	email := user.UserName
+	if len(user.Emails) > 0 {
+		email = user.Emails[0].Value
+	}
+	_, existing, err := h.backend.FetchAllUsers(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	if _, exists := existing[email]; exists {
+		writeError(w, http.StatusConflict, "uniqueness", "user already exists")
+		return
+	}
+
+	created := &structs.User{UserName: user.UserName, Email: email, DisplayName: user.DisplayName}
+	if user.Name != nil {
+		created.FirstName = user.Name.GivenName
+		created.LastName = user.Name.FamilyName
+	}
+	created, err = h.backend.CreateUser(r.Context(), created)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSCIMUser(created))
+}
+
+func (h *handler) getUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.backend.FetchUserDetails(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toSCIMUser(user))
+}
+
+func (h *handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.backend.FetchUserDetails(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusNotFound, "", err.Error())
+		return
+	}
+	if err := h.backend.DeleteUser(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.backend.FetchAllTeams(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	resources := make([]scim.Group, 0, len(teams))
+	for _, team := range teams {
+		resources = append(resources, scim.Group{Schemas: []string{scim.SchemaGroup}, ID: team.ID, DisplayName: team.Name})
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	writeList(w, r, resources)
+}
+
+func (h *handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	var group scim.Group
+	if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if _, exists := h.backend.TeamByName(group.DisplayName); exists {
+		writeError(w, http.StatusConflict, "uniqueness", "group already exists")
+		return
+	}
+	team, err := h.backend.CreateTeam(r.Context(), &structs.Team{Name: group.DisplayName})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, scim.Group{Schemas: []string{scim.SchemaGroup}, ID: team.ID, DisplayName: team.Name})
+}
+
+func (h *handler) getGroup(w http.ResponseWriter, r *http.Request) {
+	team, err := h.backend.FetchTeamDetails(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "", err.Error())
+		return
+	}
+	members, err := h.backend.FetchTeamMembersByTeamID(r.Context(), team.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+
+	group := scim.Group{Schemas: []string{scim.SchemaGroup}, ID: team.ID, DisplayName: team.Name}
+	for userID, member := range members {
+		group.Members = append(group.Members, scim.Member{Value: userID, Display: member.DisplayName, Type: "User"})
+	}
+	sort.Slice(group.Members, func(i, j int) bool { return group.Members[i].Value < group.Members[j].Value })
+	writeJSON(w, http.StatusOK, group)
+}
+
+func (h *handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+	teamID := r.PathValue("id")
+	if _, err := h.backend.FetchTeamDetails(r.Context(), teamID); err != nil {
+		writeError(w, http.StatusNotFound, "", err.Error())
+		return
+	}
+
+	var patch struct {
+		Schemas    []string `json:"schemas"`
+		Operations []struct {
+			Op    string          `json:"op"`
+			Path  string          `json:"path"`
+			Value json.RawMessage `json:"value"`
+		} `json:"Operations"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	for _, operation := range patch.Operations {
+		var err error
+		switch {
+		case strings.EqualFold(operation.Op, "add") && operation.Path == "members":
+			var members []scim.Member
+			if err := json.Unmarshal(operation.Value, &members); err != nil {
+				writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+				return
+			}
+			userIDs := make([]string, 0, len(members))
+			for _, member := range members {
+				userIDs = append(userIDs, member.Value)
+			}
+			err = h.backend.AddUserToTeam(r.Context(), teamID, userIDs)
+		case strings.EqualFold(operation.Op, "remove") && removeMemberPath.MatchString(operation.Path):
+			userID := removeMemberPath.FindStringSubmatch(operation.Path)[1]
+			err = h.backend.RemoveUserFromTeam(r.Context(), teamID, []string{userID})
+		default:
+			writeError(w, http.StatusBadRequest, "invalidPath", "unsupported operation "+operation.Op+" "+operation.Path)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.backend.FetchTeamDetails(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusNotFound, "", err.Error())
+		return
+	}
+	if err := h.backend.DeleteTeamByID(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toSCIMUser(user *structs.User) scim.User {
+	return scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          user.ID,
+		UserName:    user.UserName,
+		Name:        &scim.Name{GivenName: user.FirstName, FamilyName: user.LastName},
+		DisplayName: user.DisplayName,
+		Emails:      []scim.Email{{Value: user.Email, Primary: true}},
+	}
+}
+
+// writeList writes the page of the resources requested by startIndex and count
+func writeList[T any](w http.ResponseWriter, r *http.Request, resources []T) {
+	startIndex, err := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 0 {
+		count = len(resources)
+	}
+	start := min(startIndex-1, len(resources))
+	end := min(start+count, len(resources))
+
+	writeJSON(w, http.StatusOK, scim.ListResponse[T]{
+		Schemas:      []string{scim.SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: end - start,
+		Resources:    resources[start:end],
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeJSON(w, status, scim.ErrorResponse{
+		Schemas:  []string{scim.SchemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/scim"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+func TestSCIMServer(t *testing.T) {
+	ctx := context.Background()
+	backend := fake.New()
+	server := NewSCIMServer(backend)
+	t.Cleanup(server.Close)
+
+	client, err := scim.NewClient(map[string]interface{}{"base_url": server.URL + "/scim/v2", "token": Token,
+		"page_size": 1},
+		fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	require.NoError(t, client.HealthCheck(ctx))
+
+	alice, err := client.CreateUser(ctx, &structs.User{UserName: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	bob, err := client.CreateUser(ctx, &structs.User{UserName: "bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "bob", Email: "bob@example.com"})
+	var respErr *request.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "uniqueness", respErr.Code)
+
+	byID, _, err := client.FetchAllUsers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "team-a"})
+	require.NoError(t, err)
+	teams, err := client.FetchAllTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, team.ID, teams["team-a"].ID)
+
+	require.NoError(t, client.AddUserToTeam(ctx, team.ID, []string{alice.ID, bob.ID}))
+	require.NoError(t, client.RemoveUserFromTeam(ctx, team.ID, []string{alice.ID}))
+	members, err := client.FetchTeamMembersByTeamID(ctx, team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{bob.ID}, keys(members))
+	assert.Equal(t, map[string]string{bob.ID: ""}, backend.Members(team.ID))
+
+	require.NoError(t, client.DeleteTeamByID(ctx, team.ID))
+	require.NoError(t, client.DeleteTeamByID(ctx, team.ID))
+	_, exists := backend.TeamByName("team-a")
+	assert.False(t, exists)
+}
+
+func keys(members map[string]*structs.User) []string {
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	return ids
+}
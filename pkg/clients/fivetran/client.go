@@ -17,15 +17,62 @@ limitations under the License.
 package fivetran
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/fivetran/go-fivetran"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
 )
 
 type FivetranClient struct {
 	fivetranClient *fivetran.Client
 }
 
-func NewClient(apiKey, apiSecret string) *FivetranClient {
+// NewClient creates a FivetranClient, its requests are sent with the transport settings of the pool
+// config and are recorded and throttled for its backend (see httpclient.NewHTTPClient). The requests
+// rate limited by the API are retried after the delay it asks for.
+func NewClient(fivetranConfig FivetranConfig, poolCfg httpclient.ConnectionPoolConfig) (*FivetranClient, error) {
+	if fivetranConfig.ApiKey == "" || fivetranConfig.ApiSecret == "" {
+		return nil, errors.New("missing required connection parameters for fivetran backend")
+	}
+
+	maxThrottleRetries := fivetranConfig.MaxThrottleRetries
+	if maxThrottleRetries == 0 {
+		maxThrottleRetries = defaultMaxThrottleRetries
+	}
+	maxRetryAfter := defaultMaxRetryAfter
+	if fivetranConfig.MaxRetryAfter != "" {
+		var err error
+		maxRetryAfter, err = time.ParseDuration(fivetranConfig.MaxRetryAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fivetran max_retry_after %q: %w", fivetranConfig.MaxRetryAfter, err)
+		}
+	}
+
+	httpClient, err := httpclient.NewHTTPClient(poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+	client := fivetran.New(fivetranConfig.ApiKey, fivetranConfig.ApiSecret)
+	client.SetHttpClient(&throttledClient{
+		client:        httpClient,
+		maxRetries:    maxThrottleRetries,
+		maxRetryAfter: maxRetryAfter,
+		wait:          wait,
+	})
 	return &FivetranClient{
-		fivetranClient: fivetran.New(apiKey, apiSecret),
+		fivetranClient: client,
+	}, nil
+}
+
+// HealthCheck lists a single user to check that the API accepts the credentials
+func (fc *FivetranClient) HealthCheck(ctx context.Context) error {
+	resp, err := fc.fivetranClient.NewUsersList().Limit(1).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("fivetran health check failed: %w, %s", err, resp.Message)
 	}
+	return nil
 }
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fivetran
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+)
+
+// fakeFivetran is a Fivetran account holding the roles of the teams in the groups and connectors in
+// memory, listed one per page. It records the requests changing them.
+type fakeFivetran struct {
+	// memberships are the roles of the team in each group and connector, by "groups" or "connectors"
+	memberships map[string]map[string]string
+	// failID is the group or connector whose memberships can't be changed
+	failID string
+
+	changes []string
+}
+
+func (f *fakeFivetran) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("key:secret")) {
+		fakehttp.WriteJSON(w, http.StatusUnauthorized, map[string]string{"code": "AuthFailed", "message": "Unauthorized"})
+		return
+	}
+
+	// /v1/teams/{teamID}/{groups|connectors}[/{id}]
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/teams/"), "/")
+	if len(parts) < 2 || (parts[1] != "groups" && parts[1] != "connectors") {
+		fakehttp.WriteJSON(w, http.StatusNotFound, map[string]string{"code": "NotFound", "message": "Not found"})
+		return
+	}
+	kind := parts[1]
+	if f.memberships[kind] == nil {
+		f.memberships[kind] = map[string]string{}
+	}
+	roles := f.memberships[kind]
+
+	var body struct {
+		ID   string `json:"id"`
+		Role string `json:"role"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	id := body.ID
+	if len(parts) == 3 {
+		id = parts[2]
+	}
+	if r.Method != http.MethodGet {
+		f.changes = append(f.changes, strings.TrimSpace(r.Method+" "+kind+" "+id+" "+body.Role))
+		if id == f.failID {
+			fakehttp.WriteJSON(w, http.StatusBadRequest, map[string]string{"code": "InvalidInput", "message": "Invalid role"})
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// a page of a single membership, with the cursor of the next one
+		ids := slices.Sorted(maps.Keys(roles))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		items := []map[string]string{}
+		nextCursor := ""
+		if offset < len(ids) {
+			items = append(items, map[string]string{"id": ids[offset], "role": roles[ids[offset]]})
+			if offset+1 < len(ids) {
+				nextCursor = strconv.Itoa(offset + 1)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"code": "Success", "data": map[string]interface{}{"items": items, "next_cursor": nextCursor},
+		})
+	case http.MethodPost:
+		roles[id] = body.Role
+		fakehttp.WriteJSON(w, http.StatusCreated, map[string]interface{}{
+			"code": "Success", "data": map[string]string{"id": id, "role": body.Role},
+		})
+	case http.MethodPatch:
+		roles[id] = body.Role
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]string{"code": "Success", "message": "Membership updated"})
+	case http.MethodDelete:
+		delete(roles, id)
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]string{"code": "Success", "message": "Membership deleted"})
+	}
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *FivetranClient {
+	t.Helper()
+	server := fakehttp.NewServer(t, handler)
+
+	client, err := NewClient(FivetranConfig{ApiKey: "key", ApiSecret: "secret"}, fakehttp.ConnectionPoolConfig(t))
+	require.NoError(t, err)
+	client.fivetranClient.BaseURL(server.URL + "/v1")
+	return client
+}
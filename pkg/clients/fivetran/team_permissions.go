@@ -0,0 +1,181 @@
+package fivetran
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// Group param properties granting the team access to the Fivetran groups, which hold a destination
+// and its connectors, and to single connectors
+const (
+	GroupParamDestinations = "destinations"
+	GroupParamConnectors   = "connectors"
+)
+
+// teamMemberships are the memberships of a team in the groups or in the connectors, keyed by ID
+type teamMemberships struct {
+	kind   string
+	list   func(ctx context.Context) (map[string]string, error)
+	create func(ctx context.Context, id, role string) error
+	modify func(ctx context.Context, id, role string) error
+	delete func(ctx context.Context, id string) error
+}
+
+// ReconcileGroupParams grants the team exactly the group memberships of the destinations group
+// param or the connector memberships of the connectors group param, each value being the ID of the
+// group or connector followed by the role of the team, e.g. decent_dropsy:Destination Administrator.
+// The memberships of the team not listed are deleted.
+func (fc *FivetranClient) ReconcileGroupParams(
+	ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":  "fivetran",
+		"teamID":   teamID,
+		"property": groupParams.Property,
+	})
+
+	var memberships teamMemberships
+	var defaultRole string
+	switch groupParams.Property {
+	case GroupParamDestinations:
+		memberships = fc.groupMemberships(teamID)
+		defaultRole = DestinationReviewerRole
+	case GroupParamConnectors:
+		memberships = fc.connectorMemberships(teamID)
+		defaultRole = ConnectorReviewerRole
+	default:
+		return fmt.Errorf("unsupported fivetran group param: %s", groupParams.Property)
+	}
+
+	desired := make(map[string]string, len(groupParams.Value))
+	for _, value := range groupParams.Value {
+		id, role, found := strings.Cut(value, ":")
+		if id == "" || (found && role == "") {
+			return fmt.Errorf("invalid fivetran %s membership %q, expected <id>[:role]", memberships.kind, value)
+		}
+		if !found {
+			role = defaultRole
+		}
+		desired[id] = role
+	}
+
+	current, err := memberships.list(ctx)
+	if err != nil {
+		log.WithError(err).Errorf("error fetching the %s memberships of the team", memberships.kind)
+		return err
+	}
+
+	for id, role := range desired {
+		currentRole, ok := current[id]
+		switch {
+		case !ok:
+			log.WithField("id", id).WithField("role", role).Infof("adding the team to the %s", memberships.kind)
+			err = memberships.create(ctx, id, role)
+		case currentRole != role:
+			log.WithField("id", id).WithField("role", role).Infof("updating the role of the team in the %s", memberships.kind)
+			err = memberships.modify(ctx, id, role)
+		default:
+			continue
+		}
+		if err != nil {
+			log.WithField("id", id).WithError(err).Errorf("error updating the %s membership of the team", memberships.kind)
+			return fmt.Errorf("%s %s: %w", memberships.kind, id, err)
+		}
+	}
+	for id := range current {
+		if _, ok := desired[id]; ok {
+			continue
+		}
+		log.WithField("id", id).Infof("removing the team from the %s", memberships.kind)
+		if err := memberships.delete(ctx, id); err != nil {
+			log.WithField("id", id).WithError(err).Errorf("error removing the %s membership of the team", memberships.kind)
+			return fmt.Errorf("%s %s: %w", memberships.kind, id, err)
+		}
+	}
+	return nil
+}
+
+// groupMemberships are the memberships of the team in the Fivetran groups (destinations)
+func (fc *FivetranClient) groupMemberships(teamID string) teamMemberships {
+	return teamMemberships{
+		kind: "group",
+		list: func(ctx context.Context) (map[string]string, error) {
+			roles := make(map[string]string)
+			var cursor string
+			for {
+				req := fc.fivetranClient.NewTeamGroupMembershipsList().TeamId(teamID)
+				if cursor != "" {
+					req.Cursor(cursor)
+				}
+				resp, err := req.Do(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range resp.Data.Items {
+					roles[item.GroupId] = item.Role
+				}
+				if resp.Data.NextCursor == "" {
+					return roles, nil
+				}
+				cursor = resp.Data.NextCursor
+			}
+		},
+		create: func(ctx context.Context, id, role string) error {
+			_, err := fc.fivetranClient.NewTeamGroupMembershipCreate().TeamId(teamID).GroupId(id).Role(role).Do(ctx)
+			return err
+		},
+		modify: func(ctx context.Context, id, role string) error {
+			_, err := fc.fivetranClient.NewTeamGroupMembershipModify().TeamId(teamID).GroupId(id).Role(role).Do(ctx)
+			return err
+		},
+		delete: func(ctx context.Context, id string) error {
+			_, err := fc.fivetranClient.NewTeamGroupMembershipDelete().TeamId(teamID).GroupId(id).Do(ctx)
+			return err
+		},
+	}
+}
+
+// connectorMemberships are the memberships of the team in the Fivetran connectors
+func (fc *FivetranClient) connectorMemberships(teamID string) teamMemberships {
+	return teamMemberships{
+		kind: "connector",
+		list: func(ctx context.Context) (map[string]string, error) {
+			roles := make(map[string]string)
+			var cursor string
+			for {
+				req := fc.fivetranClient.NewTeamConnectorMembershipsList().TeamId(teamID)
+				if cursor != "" {
+					req.Cursor(cursor)
+				}
+				resp, err := req.Do(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range resp.Data.Items {
+					roles[item.ConnectorId] = item.Role
+				}
+				if resp.Data.NextCursor == "" {
+					return roles, nil
+				}
+				cursor = resp.Data.NextCursor
+			}
+		},
+		create: func(ctx context.Context, id, role string) error {
+			_, err := fc.fivetranClient.NewTeamConnectorMembershipCreate().TeamId(teamID).ConnectorId(id).Role(role).Do(ctx)
+			return err
+		},
+		modify: func(ctx context.Context, id, role string) error {
+			_, err := fc.fivetranClient.NewTeamConnectorMembershipModify().TeamId(teamID).ConnectorId(id).Role(role).Do(ctx)
+			return err
+		},
+		delete: func(ctx context.Context, id string) error {
+			_, err := fc.fivetranClient.NewTeamConnectorMembershipDelete().TeamId(teamID).ConnectorId(id).Do(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fivetran
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+func TestReconcileGroupParamsDestinations(t *testing.T) {
+	t.Run("grants the missing group memberships", func(t *testing.T) {
+		fake := &fakeFivetran{memberships: map[string]map[string]string{}}
+		client := newTestClient(t, fake)
+
+		require.NoError(t, client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+			Property: GroupParamDestinations,
+			Value:    []string{"decent_dropsy:Destination Administrator", "warm_tulips"},
+		}))
+		assert.Equal(t, map[string]string{
+			"decent_dropsy": "Destination Administrator",
+			"warm_tulips":   DestinationReviewerRole,
+		}, fake.memberships["groups"])
+		assert.ElementsMatch(t, []string{
+			"POST groups decent_dropsy Destination Administrator",
+			"POST groups warm_tulips Destination Reviewer",
+		}, fake.changes)
+	})
+
+	t.Run("updates the drifted roles and revokes the unlisted memberships", func(t *testing.T) {
+		fake := &fakeFivetran{memberships: map[string]map[string]string{"groups": {
+			"decent_dropsy": DestinationReviewerRole,
+			"warm_tulips":   DestinationReviewerRole,
+			"cold_daisies":  "Destination Administrator",
+		}}}
+		client := newTestClient(t, fake)
+
+		require.NoError(t, client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+			Property: GroupParamDestinations,
+			Value:    []string{"decent_dropsy:Destination Administrator", "warm_tulips"},
+		}))
+		assert.Equal(t, map[string]string{
+			"decent_dropsy": "Destination Administrator",
+			"warm_tulips":   DestinationReviewerRole,
+		}, fake.memberships["groups"])
+		assert.Equal(t, []string{
+			"PATCH groups decent_dropsy Destination Administrator",
+			"DELETE groups cold_daisies",
+		}, fake.changes)
+	})
+
+	t.Run("leaves the memberships in sync as is", func(t *testing.T) {
+		fake := &fakeFivetran{memberships: map[string]map[string]string{"groups": {
+			"decent_dropsy": "Destination Administrator",
+		}}}
+		client := newTestClient(t, fake)
+
+		require.NoError(t, client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+			Property: GroupParamDestinations, Value: []string{"decent_dropsy:Destination Administrator"},
+		}))
+		assert.Empty(t, fake.changes)
+	})
+
+	t.Run("reports the membership failing to be granted", func(t *testing.T) {
+		fake := &fakeFivetran{memberships: map[string]map[string]string{}, failID: "decent_dropsy"}
+		client := newTestClient(t, fake)
+
+		err := client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+			Property: GroupParamDestinations, Value: []string{"decent_dropsy:Destination Owner"},
+		})
+		assert.ErrorContains(t, err, "group decent_dropsy")
+		assert.Empty(t, fake.memberships["groups"])
+	})
+}
+
+func TestReconcileGroupParamsConnectors(t *testing.T) {
+	fake := &fakeFivetran{memberships: map[string]map[string]string{
+		"connectors": {
+			"sheets_1":   ConnectorReviewerRole,
+			"postgres_1": ConnectorReviewerRole,
+			"salesforce": "Connector Administrator",
+		},
+		"groups": {"decent_dropsy": DestinationReviewerRole},
+	}}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+		Property: GroupParamConnectors,
+		Value:    []string{"sheets_1", "postgres_1:Connector Administrator", "mysql_1"},
+	}))
+	assert.Equal(t, map[string]string{
+		"sheets_1":   ConnectorReviewerRole,
+		"postgres_1": "Connector Administrator",
+		"mysql_1":    ConnectorReviewerRole,
+	}, fake.memberships["connectors"])
+	assert.Equal(t, map[string]string{"decent_dropsy": DestinationReviewerRole}, fake.memberships["groups"],
+		"the group memberships are left to the destinations group param")
+	assert.ElementsMatch(t, []string{
+		"PATCH connectors postgres_1 Connector Administrator",
+		"POST connectors mysql_1 Connector Reviewer",
+		"DELETE connectors salesforce",
+	}, fake.changes)
+}
+
+func TestReconcileGroupParamsEmptyRevokesAll(t *testing.T) {
+	fake := &fakeFivetran{memberships: map[string]map[string]string{"connectors": {
+		"sheets_1": ConnectorReviewerRole, "postgres_1": ConnectorReviewerRole,
+	}}}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+		Property: GroupParamConnectors,
+	}))
+	assert.Empty(t, fake.memberships["connectors"])
+	assert.ElementsMatch(t, []string{"DELETE connectors sheets_1", "DELETE connectors postgres_1"}, fake.changes)
+}
+
+func TestReconcileGroupParamsInvalid(t *testing.T) {
+	fake := &fakeFivetran{memberships: map[string]map[string]string{}}
+	client := newTestClient(t, fake)
+
+	err := client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+		Property: "warehouses", Value: []string{"decent_dropsy"},
+	})
+	assert.ErrorContains(t, err, "unsupported fivetran group param: warehouses")
+
+	for _, value := range []string{":Destination Administrator", "decent_dropsy:"} {
+		err = client.ReconcileGroupParams(context.Background(), "team_1", structs.TeamParams{
+			Property: GroupParamDestinations, Value: []string{value},
+		})
+		assert.ErrorContains(t, err, "invalid fivetran group membership")
+	}
+	assert.Empty(t, fake.changes)
+}
@@ -9,16 +9,18 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// populateTeamsMap populates the provided teams map with team data from the response items
-func populateTeamsMap(teamsMap map[string]structs.Team, items []teams.TeamData) {
+// teamsFromResponse converts the team data of a response page
+func teamsFromResponse(items []teams.TeamData) []structs.Team {
+	page := make([]structs.Team, 0, len(items))
 	for _, team := range items {
-		teamsMap[team.Name] = structs.Team{
+		page = append(page, structs.Team{
 			ID:          team.Id,
 			Name:        team.Name,
 			Description: team.Description,
 			Role:        team.Role,
-		}
+		})
 	}
+	return page
 }
 
 func (fc *FivetranClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
@@ -27,8 +29,28 @@ func (fc *FivetranClient) FetchAllTeams(ctx context.Context) (map[string]structs
 	log.Info("fetching all the teams")
 
 	teams := make(map[string]structs.Team)
-	var cursor string
+	err := fc.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(logrus.Fields{
+		"total_teams_count": len(teams),
+	}).Info("found teams")
+
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the teams, following the cursor until the last page
+func (fc *FivetranClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	log := logger.Logger(ctx).WithField("service", "fivetran")
 
+	var cursor string
 	for {
 		req := fc.fivetranClient.NewTeamsList()
 		if cursor != "" {
@@ -38,22 +60,18 @@ func (fc *FivetranClient) FetchAllTeams(ctx context.Context) (map[string]structs
 		resp, err := req.Do(ctx)
 		if err != nil {
 			log.WithError(err).Error("error fetching list of teams")
-			return nil, err
+			return err
 		}
 
-		populateTeamsMap(teams, resp.Data.Items)
+		if err := fn(teamsFromResponse(resp.Data.Items)); err != nil {
+			return err
+		}
 
 		if resp.Data.NextCursor == "" {
-			break
+			return nil
 		}
 		cursor = resp.Data.NextCursor
 	}
-
-	log.WithFields(logrus.Fields{
-		"total_teams_count": len(teams),
-	}).Info("found teams")
-
-	return teams, nil
 }
 
 func (fc *FivetranClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fivetran
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	defaultMaxThrottleRetries = 5
+	defaultMaxRetryAfter      = time.Minute
+)
+
+// throttledClient retries the requests rate limited by the Fivetran API, which answers 429 with
+// the seconds to wait in its Retry-After header once the API quota of the account is used up. Each
+// attempt runs with the timeout of the http client, so the waits do not count towards it.
+type throttledClient struct {
+	client *http.Client
+
+	// maxRetries and maxRetryAfter bound the retries of the rate limited requests
+	maxRetries    int
+	maxRetryAfter time.Duration
+	// wait waits before retrying a rate limited request
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+// Do sends the request, retrying it while it is rate limited
+func (c *throttledClient) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.client.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= c.maxRetries ||
+			(req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		delay := c.retryAfter(resp.Header, attempt)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		logger.Logger(req.Context()).WithFields(logrus.Fields{
+			"service":    "fivetran",
+			"method":     req.Method,
+			"path":       req.URL.Path,
+			"retryAfter": delay.String(),
+		}).Warn("fivetran request rate limited, retrying")
+		if err := c.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter returns the delay before retrying a rate limited request: its Retry-After header, in
+// seconds or as a date, or an exponential backoff from a second when there is none, capped by
+// maxRetryAfter
+func (c *throttledClient) retryAfter(headers http.Header, attempt int) time.Duration {
+	delay := time.Second << attempt
+	value := headers.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = max(time.Until(date), 0)
+	}
+	return min(delay, c.maxRetryAfter)
+}
+
+// wait waits for the delay or the cancellation of the context
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fivetran
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+)
+
+// rateLimitedServer answers 429 with the Retry-After header to the first limited requests, it
+// records the bodies of the requests
+type rateLimitedServer struct {
+	limited    int
+	retryAfter string
+	bodies     []string
+}
+
+func (s *rateLimitedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, string(body))
+	if len(s.bodies) <= s.limited {
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		fakehttp.WriteJSON(w, http.StatusTooManyRequests, map[string]string{"code": "TooManyRequests"})
+		return
+	}
+	fakehttp.WriteJSON(w, http.StatusOK, map[string]string{"code": "Success"})
+}
+
+// newThrottledClient returns a client of the server recording the waits instead of waiting
+func newThrottledClient(t *testing.T, server *rateLimitedServer, maxRetries int) (*throttledClient, string,
+	*[]time.Duration) {
+	t.Helper()
+	httpServer := fakehttp.NewServer(t, server)
+	waits := &[]time.Duration{}
+	return &throttledClient{
+		client:        httpServer.Client(),
+		maxRetries:    maxRetries,
+		maxRetryAfter: time.Minute,
+		wait: func(_ context.Context, d time.Duration) error {
+			*waits = append(*waits, d)
+			return nil
+		},
+	}, httpServer.URL, waits
+}
+
+func TestThrottledClientRetryAfter(t *testing.T) {
+	client := &throttledClient{maxRetryAfter: 30 * time.Second}
+	header := func(value string) http.Header {
+		return http.Header{"Retry-After": []string{value}}
+	}
+
+	assert.Equal(t, 5*time.Second, client.retryAfter(header("5"), 0), "seconds")
+	assert.Equal(t, time.Duration(0), client.retryAfter(header("0"), 3), "no wait")
+	assert.Equal(t, 30*time.Second, client.retryAfter(header("3600"), 0), "capped by max_retry_after")
+
+	date := client.retryAfter(header(time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat)), 0)
+	assert.LessOrEqual(t, date, 10*time.Second, "HTTP date")
+	assert.Greater(t, date, 8*time.Second, "HTTP date")
+	assert.Equal(t, time.Duration(0), client.retryAfter(header("Wed, 21 Oct 2015 07:28:00 GMT"), 0), "past date")
+	assert.Equal(t, 30*time.Second,
+		client.retryAfter(header(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)), 0), "capped date")
+
+	assert.Equal(t, time.Second, client.retryAfter(http.Header{}, 0), "backoff without header")
+	assert.Equal(t, 4*time.Second, client.retryAfter(http.Header{}, 2), "backoff without header")
+	assert.Equal(t, 2*time.Second, client.retryAfter(header("soon"), 1), "backoff with an invalid header")
+	assert.Equal(t, 2*time.Second, client.retryAfter(header("-5"), 1), "backoff with a negative header")
+	assert.Equal(t, 30*time.Second, client.retryAfter(http.Header{}, 6), "capped backoff")
+}
+
+func TestThrottledClientDo(t *testing.T) {
+	t.Run("retries the rate limited requests after the delay", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 2, retryAfter: "3"}
+		client, url, waits := newThrottledClient(t, server, 5)
+
+		req, err := http.NewRequest(http.MethodPost, url+"/v1/teams", strings.NewReader(`{"name":"data"}`))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second}, *waits)
+		assert.Equal(t, []string{`{"name":"data"}`, `{"name":"data"}`, `{"name":"data"}`}, server.bodies,
+			"the body is sent again with each retry")
+	})
+
+	t.Run("returns the rate limited response past the max retries", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 10}
+		client, url, waits := newThrottledClient(t, server, 3)
+
+		req, err := http.NewRequest(http.MethodGet, url+"/v1/users", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Len(t, server.bodies, 4, "the request and its retries")
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, *waits)
+	})
+
+	t.Run("does not retry with the retries disabled", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 1, retryAfter: "1"}
+		client, url, waits := newThrottledClient(t, server, -1)
+
+		req, err := http.NewRequest(http.MethodGet, url+"/v1/users", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Len(t, server.bodies, 1)
+		assert.Empty(t, *waits)
+	})
+
+	t.Run("stops waiting once the context is canceled", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 10, retryAfter: "30"}
+		client, url, _ := newThrottledClient(t, server, 5)
+		ctx, cancel := context.WithCancel(context.Background())
+		client.wait = func(ctx context.Context, d time.Duration) error {
+			cancel()
+			return wait(ctx, d)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/v1/users", nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, server.bodies, 1, "the request is not retried")
+	})
+}
+
+func TestNewClientThrottling(t *testing.T) {
+	client, err := NewClient(FivetranConfig{ApiKey: "key", ApiSecret: "secret", MaxRetryAfter: "30s"},
+		fakehttp.ConnectionPoolConfig(t))
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+
+	_, err = NewClient(FivetranConfig{ApiKey: "key", ApiSecret: "secret", MaxRetryAfter: "soon"},
+		fakehttp.ConnectionPoolConfig(t))
+	assert.ErrorContains(t, err, `invalid fivetran max_retry_after "soon"`)
+}
@@ -17,9 +17,22 @@ limitations under the License.
 package fivetran
 
 const (
-	AccountReviewerRole  = "Account Reviewer"
-	ConnectorAdminRole   = "Connector Administrator"
-	ConnectorCreatorRole = "Connector Creator"
+	AccountAdministratorRole = "Account Administrator"
+	AccountAnalystRole       = "Account Analyst"
+	AccountBillingRole       = "Account Billing"
+	AccountReviewerRole      = "Account Reviewer"
+	ConnectorAdminRole       = "Connector Administrator"
+	ConnectorCreatorRole     = "Connector Creator"
+)
+
+// accountRoles are the account roles of the users, the member roles of a Group CR
+var accountRoles = []string{AccountAdministratorRole, AccountAnalystRole, AccountBillingRole, AccountReviewerRole}
+
+// Roles of a team on the destinations and connectors granted by the group params when the value
+// has no role
+const (
+	DestinationReviewerRole = "Destination Reviewer"
+	ConnectorReviewerRole   = "Connector Reviewer"
 )
 
 type UpdateTeam struct {
@@ -29,7 +42,13 @@ type UpdateTeam struct {
 	NewDescription string
 }
 
+// FivetranConfig is the connection of a Fivetran backend, read from the backend configuration
 type FivetranConfig struct {
-	ApiKey    string
-	ApiSecret string
+	ApiKey    string `json:"apikey"`
+	ApiSecret string `json:"apisecret"`
+	// MaxThrottleRetries is how many times a rate limited request is retried, 5 by default, a
+	// negative value disables the retries
+	MaxThrottleRetries int `json:"max_throttle_retries"`
+	// MaxRetryAfter caps the wait before retrying a rate limited request, e.g. 30s, 1m by default
+	MaxRetryAfter string `json:"max_retry_after"`
 }
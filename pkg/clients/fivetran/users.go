@@ -3,6 +3,9 @@ package fivetran
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/fivetran/go-fivetran/users"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
@@ -22,37 +25,59 @@ func (fc *FivetranClient) FetchAllUsers(ctx context.Context) (
 	userIDMap := make(map[string]*structs.User, 0)
 
 	log.Info("fetching all the users")
-	resp, err := fc.fivetranClient.NewUsersList().Do(ctx)
+	err := fc.ForEachUserPage(ctx, func(page []*structs.User) error {
+		for _, user := range page {
+			usersEmailMap[user.Email] = user
+			userIDMap[user.ID] = user
+		}
+		return nil
+	})
 	if err != nil {
-		log.WithField("response", resp.CommonResponse).WithError(err).Error("error fetching list of users")
 		return nil, nil, err
 	}
-	for _, item := range resp.Data.Items {
-		usersEmailMap[item.Email] = userDetailsFromResponse(item)
-		userIDMap[item.ID] = userDetailsFromResponse(item)
-	}
-	cursor := resp.Data.NextCursor
 
-	// paginate over the cursor until last page
-	for len(cursor) != 0 {
-		resp, err := fc.fivetranClient.NewUsersList().Cursor(cursor).Do(ctx)
+	log.WithField("total_user_count", len(usersEmailMap)).Info("found users")
+
+	return usersEmailMap, userIDMap, nil
+}
+
+// ForEachUserPage calls fn with each page of the users, following the cursor until the last page
+func (fc *FivetranClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	log := logger.Logger(ctx).WithField("service", "fivetran")
+
+	var cursor string
+	for {
+		req := fc.fivetranClient.NewUsersList()
+		if cursor != "" {
+			req.Cursor(cursor)
+		}
+
+		resp, err := req.Do(ctx)
 		if err != nil {
 			log.WithField("response", resp.CommonResponse).WithError(err).Error("error fetching list of users")
-			return nil, nil, err
+			return err
 		}
+
+		page := make([]*structs.User, 0, len(resp.Data.Items))
 		for _, item := range resp.Data.Items {
-			usersEmailMap[item.Email] = userDetailsFromResponse(item)
-			userIDMap[item.ID] = userDetailsFromResponse(item)
+			page = append(page, userDetailsFromResponse(item))
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		if resp.Data.NextCursor == "" {
+			return nil
 		}
 		cursor = resp.Data.NextCursor
 	}
+}
 
-	log.WithFields(logrus.Fields{
-		"total_user_count": len(usersEmailMap),
-		"response":         resp.CommonResponse,
-	}).Info("found users")
-
-	return usersEmailMap, userIDMap, nil
+// ValidMemberRole reports whether the role is an account role of the users, roles are case insensitive
+func (fc *FivetranClient) ValidMemberRole(role string) bool {
+	return slices.ContainsFunc(accountRoles, func(accountRole string) bool {
+		return strings.EqualFold(accountRole, role)
+	})
 }
 
 // Onboards the user on fivetran
@@ -63,12 +88,19 @@ func (fc *FivetranClient) CreateUser(ctx context.Context, u *structs.User) (*str
 	})
 
 	log.Info("inviting user")
-	resp, err := fc.fivetranClient.NewUserInvite().
+	invite := fc.fivetranClient.NewUserInvite().
 		Email(u.Email).
 		FamilyName(u.LastName).
-		GivenName(u.FirstName).
-		Do(ctx)
+		GivenName(u.FirstName)
+	if u.Role != "" {
+		invite = invite.Role(u.Role)
+	}
+	resp, err := invite.Do(ctx)
 	if err != nil {
+		// the SDK only reports the status code of the rejected invites
+		if strings.Contains(err.Error(), fmt.Sprintf("status code: %d;", http.StatusConflict)) {
+			return &structs.User{}, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
 		log.WithField("response", resp.CommonResponse).WithError(err).Error("error inviting the user")
 		return &structs.User{}, err
 	}
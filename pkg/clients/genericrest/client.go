@@ -0,0 +1,272 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// ErrUnsupported is returned for the operations whose endpoint is not configured
+var ErrUnsupported = errors.New("operation not supported by the generic REST backend")
+
+// RestClient is a backend client driven by the endpoints of its RestConfig, for the simple services
+// which do not need a dedicated client
+type RestClient struct {
+	client  heimdall.Doer
+	baseURL string
+	headers map[string]string
+
+	userFields map[string]string
+	teamFields map[string]string
+
+	listUsers, getUser, createUser, deleteUser endpoint
+	listTeams, getTeam, createTeam, deleteTeam endpoint
+	listMembers, addMembers, removeMembers     endpoint
+	health                                     endpoint
+}
+
+// templateFuncs are available to the path and body templates of the endpoints
+var templateFuncs = template.FuncMap{
+	// json encodes the value as JSON, e.g. {"members": {{ json .UserIDs }}}
+	"json": func(v any) (string, error) {
+		body, err := json.Marshal(v)
+		return string(body), err
+	},
+	// path escapes the value for a path segment
+	"path": url.PathEscape,
+	// query escapes the value for a query parameter
+	"query": url.QueryEscape,
+}
+
+func NewClient(connection map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*RestClient, error) {
+
+	restConfig := RestConfig{}
+	if err := utils.MapToStruct(connection, &restConfig); err != nil {
+		return nil, err
+	}
+	if restConfig.BaseURL == "" {
+		return nil, errors.New("generic REST configuration is missing required field: base_url")
+	}
+
+	headers, err := requestHeaders(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	rC := &RestClient{
+		baseURL:    strings.TrimSuffix(restConfig.BaseURL, "/"),
+		headers:    headers,
+		userFields: withDefaults(restConfig.Users.Fields, defaultUserFields),
+		teamFields: withDefaults(restConfig.Teams.Fields, defaultTeamFields),
+	}
+
+	// templates are compiled upfront, so that a mistake in the configuration fails the client
+	// creation rather than the reconciles
+	endpoints := []struct {
+		target        *endpoint
+		name          string
+		config        Endpoint
+		defaultMethod string
+	}{
+		{&rC.listUsers, "users.list", restConfig.Users.List, http.MethodGet},
+		{&rC.getUser, "users.get", restConfig.Users.Get, http.MethodGet},
+		{&rC.createUser, "users.create", restConfig.Users.Create, http.MethodPost},
+		{&rC.deleteUser, "users.delete", restConfig.Users.Delete, http.MethodDelete},
+		{&rC.listTeams, "teams.list", restConfig.Teams.List, http.MethodGet},
+		{&rC.getTeam, "teams.get", restConfig.Teams.Get, http.MethodGet},
+		{&rC.createTeam, "teams.create", restConfig.Teams.Create, http.MethodPost},
+		{&rC.deleteTeam, "teams.delete", restConfig.Teams.Delete, http.MethodDelete},
+		{&rC.listMembers, "members.list", restConfig.Members.List, http.MethodGet},
+		{&rC.addMembers, "members.add", restConfig.Members.Add, http.MethodPost},
+		{&rC.removeMembers, "members.remove", restConfig.Members.Remove, http.MethodDelete},
+		{&rC.health, "health", restConfig.Health, http.MethodGet},
+	}
+	for _, e := range endpoints {
+		compiled, err := compileEndpoint(e.name, e.config, e.defaultMethod)
+		if err != nil {
+			return nil, err
+		}
+		*e.target = compiled
+	}
+	if rC.health.path == nil {
+		rC.health = rC.listUsers
+	}
+
+	rC.client, err = httpclient.InitializeClient(
+		"generic_rest_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+	return rC, nil
+}
+
+// requestHeaders returns the headers sent with every request, authentication included
+func requestHeaders(restConfig RestConfig) (map[string]string, error) {
+	headers := map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       "application/json",
+	}
+	for key, value := range restConfig.Headers {
+		headers[key] = value
+	}
+
+	auth := restConfig.Auth
+	switch strings.ToLower(auth.Type) {
+	case AuthTypeNone:
+	case AuthTypeBearer:
+		if auth.Token == "" {
+			return nil, errors.New("generic REST bearer auth is missing required field: token")
+		}
+		headers["Authorization"] = "Bearer " + auth.Token
+	case AuthTypeBasic:
+		if auth.Username == "" {
+			return nil, errors.New("generic REST basic auth is missing required field: username")
+		}
+		headers["Authorization"] = "Basic " +
+			base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password))
+	case AuthTypeHeader:
+		if auth.Header == "" || auth.Token == "" {
+			return nil, errors.New("generic REST header auth is missing required fields: header or token")
+		}
+		headers[auth.Header] = auth.Token
+	default:
+		return nil, fmt.Errorf("unsupported generic REST auth type: %s", auth.Type)
+	}
+	return headers, nil
+}
+
+// compileEndpoint parses the templates of the endpoint. An endpoint without a path is left
+// unconfigured, its operation then returns ErrUnsupported.
+func compileEndpoint(name string, config Endpoint, defaultMethod string) (endpoint, error) {
+	compiled := endpoint{
+		name:       name,
+		method:     strings.ToUpper(config.Method),
+		resultPath: config.ResultPath,
+		batch:      config.Batch,
+	}
+	if compiled.method == "" {
+		compiled.method = defaultMethod
+	}
+	if config.Path == "" {
+		return compiled, nil
+	}
+
+	var err error
+	compiled.path, err = template.New(name + ".path").Funcs(templateFuncs).Option("missingkey=error").Parse(config.Path)
+	if err != nil {
+		return endpoint{}, fmt.Errorf("invalid path of the %s endpoint: %w", name, err)
+	}
+	if config.Body != "" {
+		compiled.body, err = template.New(name + ".body").Funcs(templateFuncs).Option("missingkey=error").Parse(config.Body)
+		if err != nil {
+			return endpoint{}, fmt.Errorf("invalid body of the %s endpoint: %w", name, err)
+		}
+	}
+	return compiled, nil
+}
+
+// withDefaults returns the fields overriding the default fields
+func withDefaults(fields, defaults map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults))
+	for key, path := range defaults {
+		merged[key] = path
+	}
+	for key, path := range fields {
+		if path != "" {
+			merged[strings.ToLower(key)] = path
+		}
+	}
+	return merged
+}
+
+// HealthCheck calls the health endpoint, or lists the users when it is not configured
+func (rC *RestClient) HealthCheck(ctx context.Context) error {
+	if _, _, err := rC.call(ctx, rC.health, templateData{}, nil); err != nil {
+		return fmt.Errorf("generic REST health check failed: %w", err)
+	}
+	return nil
+}
+
+// call sends the request of the endpoint and returns the response body with its status code. The
+// body of the request is rendered from the body template of the endpoint, or is defaultBody
+// encoded as JSON when the endpoint has none. Any response code other than 2xx is an error.
+func (rC *RestClient) call(ctx context.Context, e endpoint, data templateData,
+	defaultBody any) ([]byte, int, error) {
+	if e.path == nil {
+		return nil, 0, fmt.Errorf("%w: %s endpoint is not configured", ErrUnsupported, e.name)
+	}
+
+	var path bytes.Buffer
+	if err := e.path.Execute(&path, data); err != nil {
+		return nil, 0, fmt.Errorf("failed to render the path of the %s endpoint: %w", e.name, err)
+	}
+
+	var requestBody []byte
+	switch {
+	case e.body != nil:
+		var body bytes.Buffer
+		if err := e.body.Execute(&body, data); err != nil {
+			return nil, 0, fmt.Errorf("failed to render the body of the %s endpoint: %w", e.name, err)
+		}
+		requestBody = body.Bytes()
+	case defaultBody != nil:
+		var err error
+		requestBody, err = json.Marshal(defaultBody)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	req, err := request.NewRequest(ctx, e.method, rC.baseURL+path.String(), requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.SetHeaders(rC.headers)
+
+	resp, respCode, err := req.MakeRequest(rC.client, "backend.genericrest."+e.name, "generic_rest")
+	if err != nil {
+		return resp, respCode, fmt.Errorf("%s request failed: %w", e.name, err)
+	}
+	if respCode < http.StatusOK || respCode >= http.StatusMultipleChoices {
+		return resp, respCode, fmt.Errorf("%s request failed with response code %d: %s",
+			e.name, respCode, string(resp))
+	}
+	return resp, respCode, nil
+}
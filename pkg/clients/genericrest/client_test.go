@@ -0,0 +1,292 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// recordedRequest is a request received by the test server
+type recordedRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+// testServer answers the requests of the routes and records them
+type testServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newTestServer(t *testing.T, routes map[string]func(w http.ResponseWriter)) *testServer {
+	t.Helper()
+	server := &testServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		server.mu.Lock()
+		server.requests = append(server.requests, recordedRequest{method: r.Method, path: r.URL.Path, body: string(body)})
+		server.mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		route, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		route(w)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func respond(code int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(code)
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+func newTestClient(t *testing.T, baseURL string, connection map[string]interface{}) *RestClient {
+	t.Helper()
+	connection["base_url"] = baseURL
+	connection["auth"] = map[string]interface{}{"type": "bearer", "token": "secret"}
+	client, err := NewClient(connection,
+		httpclient.ConnectionPoolConfig{Timeout: 1000, KeepAliveTimeout: 1000, MaxIdleConnections: 1, BackendName: t.Name()},
+		httpclient.HystrixResiliencyConfig{MaxConcurrentRequests: 10, RequestVolumeThreshold: 100,
+			CircuitBreakerSleepWindow: 1000, ErrorPercentThreshold: 100, CircuitBreakerTimeout: 1000})
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection map[string]interface{}
+		wantErr    string
+	}{
+		{
+			name:       "missing base url",
+			connection: map[string]interface{}{},
+			wantErr:    "base_url",
+		},
+		{
+			name: "unsupported auth type",
+			connection: map[string]interface{}{
+				"base_url": "http://localhost",
+				"auth":     map[string]interface{}{"type": "oauth"},
+			},
+			wantErr: "unsupported generic REST auth type",
+		},
+		{
+			name: "bearer auth without token",
+			connection: map[string]interface{}{
+				"base_url": "http://localhost",
+				"auth":     map[string]interface{}{"type": "bearer"},
+			},
+			wantErr: "token",
+		},
+		{
+			name: "invalid path template",
+			connection: map[string]interface{}{
+				"base_url": "http://localhost",
+				"users":    map[string]interface{}{"get": map[string]interface{}{"path": "/users/{{ .UserID"}},
+			},
+			wantErr: "invalid path of the users.get endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.connection, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFetchAllUsers(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){
+		"GET /api/users": respond(http.StatusOK,
+			`{"data": [{"id": 42, "login": "jdoe", "emails": [{"value": "jdoe@example.com"}]}, {"login": "no-id"}]}`),
+	})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"users": map[string]interface{}{
+			"list":   map[string]interface{}{"path": "/api/users", "result_path": "data"},
+			"fields": map[string]interface{}{"username": "login", "email": "emails.0.value"},
+		},
+	})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, byID, 1)
+	assert.Equal(t, &structs.User{ID: "42", UserName: "jdoe", Email: "jdoe@example.com"}, byID["42"])
+	assert.Same(t, byID["42"], byEmail["jdoe@example.com"])
+}
+
+func TestCreateUser(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){
+		"POST /api/users": respond(http.StatusCreated, `{"user": {"id": "u-1"}}`),
+	})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"users": map[string]interface{}{
+			"create": map[string]interface{}{
+				"path":        "/api/users",
+				"body":        `{"login": {{ json .User.UserName }}, "mail": {{ json .User.Email }}}`,
+				"result_path": "user",
+			},
+		},
+	})
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "u-1", UserName: "jdoe", Email: "jdoe@example.com"}, user)
+
+	require.Len(t, server.requests, 1)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal([]byte(server.requests[0].body), &body))
+	assert.Equal(t, map[string]string{"login": "jdoe", "mail": "jdoe@example.com"}, body)
+}
+
+func TestCreateUserConflict(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){
+		"POST /api/users": respond(http.StatusConflict, `{"error": "user exists"}`),
+	})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"users": map[string]interface{}{"create": map[string]interface{}{"path": "/api/users"}},
+	})
+
+	_, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+}
+
+func TestCreateTeamDefaultBody(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){
+		"POST /api/teams": respond(http.StatusOK, `{"id": 7, "name": "team-a"}`),
+	})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"teams": map[string]interface{}{"create": map[string]interface{}{"path": "/api/teams"}},
+	})
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "team-a", Description: "Team A"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "7", Name: "team-a", Description: "Team A"}, team)
+	assert.JSONEq(t, `{"name": "team-a", "description": "Team A"}`, server.requests[0].body)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"users": map[string]interface{}{"delete": map[string]interface{}{"path": "/api/users/{{ .UserID | path }}"}},
+		"teams": map[string]interface{}{"delete": map[string]interface{}{"path": "/api/teams/{{ .TeamID | path }}"}},
+	})
+
+	assert.NoError(t, client.DeleteUser(context.Background(), "a/b"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "7"))
+	assert.Equal(t, "/api/users/a/b", server.requests[0].path)
+	assert.Equal(t, http.MethodDelete, server.requests[1].method)
+}
+
+func TestTeamMembership(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){
+		"GET /api/teams/7/members":    respond(http.StatusOK, `[{"id": "u-1"}, {"id": "u-2"}]`),
+		"PUT /api/teams/7/members":    respond(http.StatusNoContent, ""),
+		"DELETE /api/teams/7/members": respond(http.StatusNoContent, ""),
+	})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"members": map[string]interface{}{
+			"list": map[string]interface{}{"path": "/api/teams/{{ .TeamID }}/members"},
+			"add": map[string]interface{}{
+				"method": "put",
+				"path":   "/api/teams/{{ .TeamID }}/members",
+				"body":   `{"members": {{ json .UserIDs }}}`,
+				"batch":  true,
+			},
+			"remove": map[string]interface{}{
+				"path": "/api/teams/{{ .TeamID }}/members",
+				"body": `{"member": {{ json .UserID }}}`,
+			},
+		},
+	})
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "7")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "u-2")
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "7", []string{"u-3", "u-4"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "7", []string{"u-1", "u-2"}))
+
+	require.Len(t, server.requests, 4)
+	assert.Equal(t, http.MethodPut, server.requests[1].method)
+	assert.JSONEq(t, `{"members": ["u-3", "u-4"]}`, server.requests[1].body)
+	assert.JSONEq(t, `{"member": "u-1"}`, server.requests[2].body)
+	assert.JSONEq(t, `{"member": "u-2"}`, server.requests[3].body)
+}
+
+func TestErrors(t *testing.T) {
+	server := newTestServer(t, map[string]func(w http.ResponseWriter){
+		"GET /api/teams": respond(http.StatusBadRequest, `{"error": "bad request"}`),
+	})
+	client := newTestClient(t, server.URL, map[string]interface{}{
+		"teams": map[string]interface{}{"list": map[string]interface{}{"path": "/api/teams"}},
+	})
+
+	_, err := client.FetchAllTeams(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "response code 400")
+
+	_, err = client.FetchTeamDetails(context.Background(), "7")
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	// the health check lists the users when no health endpoint is configured
+	assert.ErrorIs(t, client.HealthCheck(context.Background()), ErrUnsupported)
+}
+
+func TestLookup(t *testing.T) {
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(`{"a": {"b": [{"c": "found"}]}}`), &value))
+
+	found, ok := lookup(value, "a.b.0.c")
+	assert.True(t, ok)
+	assert.Equal(t, "found", found)
+
+	_, ok = lookup(value, "a.b.1.c")
+	assert.False(t, ok)
+	_, ok = lookup(value, "a.x")
+	assert.False(t, ok)
+
+	whole, ok := lookup(value, "")
+	assert.True(t, ok)
+	assert.Equal(t, value, whole)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// decodeResult decodes the response of the endpoint and returns the value at its result path
+func decodeResult(e endpoint, resp []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(resp))
+	// numbers are kept as is so that numeric IDs are not formatted as floats
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of the %s endpoint: %w", e.name, err)
+	}
+	result, ok := lookup(value, e.resultPath)
+	if !ok {
+		return nil, fmt.Errorf("result path %q not found in the response of the %s endpoint", e.resultPath, e.name)
+	}
+	return result, nil
+}
+
+// decodeList decodes the response of a list endpoint, whose result must be an array
+func decodeList(e endpoint, resp []byte) ([]any, error) {
+	result, err := decodeResult(e, resp)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := result.([]any)
+	if !ok {
+		return nil, fmt.Errorf("result of the %s endpoint is not an array", e.name)
+	}
+	return items, nil
+}
+
+// lookup returns the value at the dot separated path of the decoded JSON value, the elements of
+// the arrays are selected by their index, e.g. "data.emails.0.value". An empty path is the value
+// itself.
+func lookup(value any, path string) (any, bool) {
+	if path == "" {
+		return value, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = v[key]; !ok {
+				return nil, false
+			}
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// stringAt returns the value at the path as a string, numbers and booleans are formatted and any
+// other value is empty
+func stringAt(value any, path string) string {
+	v, _ := lookup(value, path)
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+// toUser reads the user from the object of a response with the user fields
+func (rC *RestClient) toUser(object any) *structs.User {
+	return &structs.User{
+		ID:          stringAt(object, rC.userFields["id"]),
+		UserName:    stringAt(object, rC.userFields["username"]),
+		Email:       stringAt(object, rC.userFields["email"]),
+		FirstName:   stringAt(object, rC.userFields["first_name"]),
+		LastName:    stringAt(object, rC.userFields["last_name"]),
+		DisplayName: stringAt(object, rC.userFields["display_name"]),
+	}
+}
+
+// toTeam reads the team from the object of a response with the team fields
+func (rC *RestClient) toTeam(object any) *structs.Team {
+	return &structs.Team{
+		ID:          stringAt(object, rC.teamFields["id"]),
+		Name:        stringAt(object, rC.teamFields["name"]),
+		Description: stringAt(object, rC.teamFields["description"]),
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the members of the team, read with the user fields and keyed by ID
+func (rC *RestClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	resp, _, err := rC.call(ctx, rC.listMembers, templateData{TeamID: teamID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	items, err := decodeList(rC.listMembers, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*structs.User, len(items))
+	for _, item := range items {
+		user := rC.toUser(item)
+		if user.ID == "" {
+			continue
+		}
+		members[user.ID] = user
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the members to the team
+func (rC *RestClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.AddUserToTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("teamID", teamID).Info("adding team users to the generic REST team")
+	return rC.modify(ctx, rC.addMembers, teamID, userIDs)
+}
+
+// RemoveUserFromTeam removes the members from the team
+func (rC *RestClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.RemoveUserFromTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("teamID", teamID).Info("removing team users from the generic REST team")
+	return rC.modify(ctx, rC.removeMembers, teamID, userIDs)
+}
+
+// modify calls the membership endpoint once for the batch of users when it is a batch endpoint,
+// or once per user otherwise
+func (rC *RestClient) modify(ctx context.Context, e endpoint, teamID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if e.batch {
+		_, _, err := rC.call(ctx, e, templateData{TeamID: teamID, UserIDs: userIDs}, nil)
+		return err
+	}
+	for _, userID := range userIDs {
+		if _, _, err := rC.call(ctx, e, templateData{TeamID: teamID, UserID: userID, UserIDs: []string{userID}},
+			nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllTeams lists the teams of the backend, keyed by name
+func (rC *RestClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.FetchAllTeams")
+	defer span.Finish()
+
+	resp, _, err := rC.call(ctx, rC.listTeams, templateData{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	items, err := decodeList(rC.listTeams, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(items))
+	for _, item := range items {
+		team := rC.toTeam(item)
+		if team.ID == "" || team.Name == "" {
+			continue
+		}
+		teams[team.Name] = *team
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the team by ID
+func (rC *RestClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.FetchTeamDetails")
+	defer span.Finish()
+
+	resp, _, err := rC.call(ctx, rC.getTeam, templateData{TeamID: teamID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := decodeResult(rC.getTeam, resp)
+	if err != nil {
+		return nil, err
+	}
+	return rC.toTeam(result), nil
+}
+
+// CreateTeam creates the team, the team is sent as is when the create endpoint has no body
+func (rC *RestClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.CreateTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("team", team.Name).Info("Create generic REST team")
+
+	resp, _, err := rC.call(ctx, rC.createTeam, templateData{Team: team}, team)
+	if err != nil {
+		return nil, err
+	}
+	result, err := decodeResult(rC.createTeam, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	created := rC.toTeam(result)
+	if created.ID == "" {
+		return nil, errors.New("no team ID in the response of the teams.create endpoint")
+	}
+	if created.Name == "" {
+		created.Name = team.Name
+	}
+	if created.Description == "" {
+		created.Description = team.Description
+	}
+	return created, nil
+}
+
+// DeleteTeamByID deletes the team by ID, a team which does not exist is considered deleted
+func (rC *RestClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.DeleteTeamByID")
+	defer span.Finish()
+
+	_, respCode, err := rC.call(ctx, rC.deleteTeam, templateData{TeamID: teamID}, nil)
+	if respCode == http.StatusNotFound {
+		logger.Logger(ctx).WithField("teamID", teamID).Warn("generic REST team not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// ReconcileGroupParams is a no-op, the generic REST backends have no group params
+func (rC *RestClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"text/template"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// Auth types of the generic REST backends
+const (
+	AuthTypeNone   = ""
+	AuthTypeBearer = "bearer"
+	AuthTypeBasic  = "basic"
+	AuthTypeHeader = "header"
+)
+
+// RestConfig is the connection of a generic REST backend, read from the backend configuration
+type RestConfig struct {
+	// BaseURL prefixes the paths of all the endpoints
+	BaseURL string `json:"base_url"`
+	Auth    Auth   `json:"auth"`
+	// Headers are sent with every request
+	Headers map[string]string `json:"headers"`
+
+	Users   Resource `json:"users"`
+	Teams   Resource `json:"teams"`
+	Members Members  `json:"members"`
+	// Health is the endpoint called by HealthCheck, it defaults to the users list endpoint
+	Health Endpoint `json:"health"`
+}
+
+// Auth authenticates the requests of the backend
+type Auth struct {
+	// Type is bearer, basic, header or empty for no authentication
+	Type     string `json:"type"`
+	Token    string `json:"token"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Header is the name of the header holding the token for the header auth type
+	Header string `json:"header"`
+}
+
+// Resource describes the endpoints of the users or teams of the backend
+type Resource struct {
+	List   Endpoint `json:"list"`
+	Get    Endpoint `json:"get"`
+	Create Endpoint `json:"create"`
+	Delete Endpoint `json:"delete"`
+	// Fields are the JSON paths of the attributes in the objects of the responses
+	Fields map[string]string `json:"fields"`
+}
+
+// Members describes the endpoints of the team memberships, the members are read with the fields
+// of the users
+type Members struct {
+	List   Endpoint `json:"list"`
+	Add    Endpoint `json:"add"`
+	Remove Endpoint `json:"remove"`
+}
+
+// Endpoint is an API call of the backend. Path and Body are Go templates executed with the
+// templateData of the call, e.g. "/groups/{{ .TeamID | path }}/members".
+type Endpoint struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Body is the JSON body of the request, the user or team is sent as is when not set
+	Body string `json:"body"`
+	// ResultPath is the JSON path of the result in the response, the array of a list endpoint or
+	// the object of a get or create endpoint. The whole response is the result when not set.
+	ResultPath string `json:"result_path"`
+	// Batch sends a single add or remove request for the batch of members in .UserIDs, instead of
+	// one request per member in .UserID
+	Batch bool `json:"batch"`
+}
+
+// templateData holds the values of the call available to the endpoint templates
+type templateData struct {
+	UserID  string
+	UserIDs []string
+	TeamID  string
+	User    *structs.User
+	Team    *structs.Team
+}
+
+// endpoint is an endpoint with its compiled templates
+type endpoint struct {
+	name       string
+	method     string
+	path       *template.Template
+	body       *template.Template
+	resultPath string
+	batch      bool
+}
+
+// Default JSON paths of the user and team fields
+var (
+	defaultUserFields = map[string]string{
+		"id":           "id",
+		"username":     "username",
+		"email":        "email",
+		"first_name":   "first_name",
+		"last_name":    "last_name",
+		"display_name": "display_name",
+	}
+	defaultTeamFields = map[string]string{
+		"id":          "id",
+		"name":        "name",
+		"description": "description",
+	}
+)
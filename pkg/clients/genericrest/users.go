@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package genericrest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers lists the users of the backend, keyed by ID and by email
+func (rC *RestClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.FetchAllUsers")
+	defer span.Finish()
+
+	resp, _, err := rC.call(ctx, rC.listUsers, templateData{}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := decodeList(rC.listUsers, resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usersByID := make(map[string]*structs.User, len(items))
+	usersByEmail := make(map[string]*structs.User, len(items))
+	for _, item := range items {
+		user := rC.toUser(item)
+		if user.ID == "" {
+			continue
+		}
+		usersByID[user.ID] = user
+		if user.Email != "" {
+			usersByEmail[user.Email] = user
+		}
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// FetchUserDetails fetches the user by ID
+func (rC *RestClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.FetchUserDetails")
+	defer span.Finish()
+
+	resp, _, err := rC.call(ctx, rC.getUser, templateData{UserID: userID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := decodeResult(rC.getUser, resp)
+	if err != nil {
+		return nil, err
+	}
+	return rC.toUser(result), nil
+}
+
+// CreateUser creates the user, the user is sent as is when the create endpoint has no body. The
+// fields missing from the response are kept from the user.
+func (rC *RestClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.CreateUser")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("email", u.Email).Info("Create generic REST user")
+
+	resp, respCode, err := rC.call(ctx, rC.createUser, templateData{User: u}, u)
+	if respCode == http.StatusConflict {
+		return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, err := decodeResult(rC.createUser, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	created := rC.toUser(result)
+	if created.ID == "" {
+		return nil, errors.New("no user ID in the response of the users.create endpoint")
+	}
+	mergeUser(created, u)
+	return created, nil
+}
+
+// DeleteUser deletes the user by ID, a user which does not exist is considered deleted
+func (rC *RestClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.genericrest.DeleteUser")
+	defer span.Finish()
+
+	_, respCode, err := rC.call(ctx, rC.deleteUser, templateData{UserID: userID}, nil)
+	if respCode == http.StatusNotFound {
+		logger.Logger(ctx).WithField("userID", userID).Warn("generic REST user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// mergeUser fills the fields of the user which are empty with the fields of the source
+func mergeUser(user, source *structs.User) {
+	if user.UserName == "" {
+		user.UserName = source.UserName
+	}
+	if user.Email == "" {
+		user.Email = source.Email
+	}
+	if user.FirstName == "" {
+		user.FirstName = source.FirstName
+	}
+	if user.LastName == "" {
+		user.LastName = source.LastName
+	}
+	if user.DisplayName == "" {
+		user.DisplayName = source.DisplayName
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// GithubClient manages the teams of a GitHub organization through the REST API, and reads its
+// members through the GraphQL API
+type GithubClient struct {
+	client            heimdall.Doer
+	url               string
+	graphQLURL        string
+	org               string
+	parentTeam        string
+	privacy           string
+	useSAMLIdentities bool
+	removeOrgMembers  bool
+	auth              *tokenSource
+}
+
+func NewClient(githubAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*GithubClient, error) {
+
+	githubConfig := GithubConfig{}
+	if err := utils.MapToStruct(githubAppConfig, &githubConfig); err != nil {
+		return nil, err
+	}
+	if githubConfig.Org == "" {
+		return nil, errors.New("github configuration is missing required field: org")
+	}
+
+	baseURL := strings.TrimSuffix(githubConfig.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	graphQLURL := strings.TrimSuffix(githubConfig.GraphQLURL, "/")
+	if graphQLURL == "" {
+		graphQLURL = defaultGraphQLURL(baseURL)
+	}
+
+	privacy := strings.ToLower(githubConfig.Privacy)
+	switch privacy {
+	case "":
+		privacy = defaultPrivacy
+	case "closed", "secret":
+	default:
+		return nil, fmt.Errorf("unsupported github team privacy: %s", githubConfig.Privacy)
+	}
+
+	client, err := httpclient.InitializeClient(
+		"github_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	auth, err := newTokenSource(githubConfig, baseURL, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GithubClient{
+		client:            client,
+		url:               baseURL,
+		graphQLURL:        graphQLURL,
+		org:               githubConfig.Org,
+		parentTeam:        githubConfig.ParentTeam,
+		privacy:           privacy,
+		useSAMLIdentities: githubConfig.UseSAMLIdentities,
+		removeOrgMembers:  githubConfig.RemoveOrgMembers,
+		auth:              auth,
+	}, nil
+}
+
+// defaultGraphQLURL returns the GraphQL API of the REST API, GitHub Enterprise Server serves it
+// under /api/graphql
+func defaultGraphQLURL(baseURL string) string {
+	if host, ok := strings.CutSuffix(baseURL, "/api/v3"); ok {
+		return host + "/api/graphql"
+	}
+	return baseURL + "/graphql"
+}
+
+// HealthCheck fetches the organization, the API is healthy when it answers with the configured
+// credentials
+func (gC *GithubClient) HealthCheck(ctx context.Context) error {
+	if _, err := gC.sendRequest(ctx, "/orgs/"+gC.org, http.MethodGet, nil, "backend.github.HealthCheck"); err != nil {
+		return fmt.Errorf("github health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path of the REST API and decodes its response
+func (gC *GithubClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := gC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a GitHub request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode github response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the REST API and returns the response body, any
+// response code other than 2xx is returned as a *request.ResponseError
+func (gC *GithubClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	return gC.send(ctx, gC.url+path, method, body, methodName)
+}
+
+// query runs the GraphQL query and decodes its data, the errors of the query are returned as an error
+func query[T any](ctx context.Context, gC *GithubClient, graphQLQuery string, variables map[string]any,
+	methodName string) (*T, error) {
+
+	resp, err := gC.send(ctx, gC.graphQLURL, http.MethodPost,
+		&graphQLRequest{Query: graphQLQuery, Variables: variables}, methodName)
+	if err != nil {
+		return nil, err
+	}
+	var result graphQLResponse[T]
+	if err := decode(resp, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		messages := make([]string, 0, len(result.Errors))
+		for _, queryErr := range result.Errors {
+			messages = append(messages, queryErr.Message)
+		}
+		return nil, fmt.Errorf("github graphql query failed: %s", strings.Join(messages, "; "))
+	}
+	return &result.Data, nil
+}
+
+func (gC *GithubClient) send(ctx context.Context, url string, method string, body any,
+	methodName string) ([]byte, error) {
+
+	token, err := gC.auth.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var requestBody []byte
+	if body != nil {
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doRequest(ctx, gC.client, url, method, requestBody, "Bearer "+token, methodName)
+}
+
+// doRequest sends the request with the authorization, any response code other than 2xx is
+// returned as a *request.ResponseError
+func doRequest(ctx context.Context, client heimdall.Doer, url string, method string, requestBody []byte,
+	authorization string, methodName string) ([]byte, error) {
+
+	resp, _, err := request.Send(ctx, client, method, url, requestBody, map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       mediaType,
+		"X-GitHub-Api-Version":         apiVersion,
+		"Authorization":                authorization,
+	}, methodName, "github", errorMessage)
+	return resp, err
+}
+
+// tokenSource hands out the token of the requests, the installation tokens of the app are
+// renewed shortly before they expire
+type tokenSource struct {
+	client         heimdall.Doer
+	url            string
+	appID          string
+	installationID string
+	privateKey     *rsa.PrivateKey
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newTokenSource(githubConfig GithubConfig, baseURL string, client heimdall.Doer) (*tokenSource, error) {
+	if githubConfig.AppID == "" && githubConfig.InstallationID == "" && githubConfig.PrivateKey == "" {
+		if githubConfig.Token == "" {
+			return nil, errors.New("github configuration is missing the app installation or a token")
+		}
+		return &tokenSource{token: githubConfig.Token}, nil
+	}
+	if githubConfig.AppID == "" || githubConfig.InstallationID == "" || githubConfig.PrivateKey == "" {
+		return nil, errors.New("github configuration is missing required fields: app_id, installation_id or private_key")
+	}
+
+	privateKey, err := parsePrivateKey(githubConfig.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenSource{
+		client:         client,
+		url:            baseURL,
+		appID:          githubConfig.AppID,
+		installationID: githubConfig.InstallationID,
+		privateKey:     privateKey,
+	}, nil
+}
+
+// parsePrivateKey parses the PEM encoded private key of the app, GitHub issues PKCS#1 keys
+func parsePrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("invalid github app private key: no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid github app private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid github app private key: not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// Token returns the token of the requests, exchanging an app JWT for a new installation token
+// when the current one is about to expire
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	if ts.privateKey == nil {
+		return ts.token, nil
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && time.Until(ts.expiresAt) > tokenRefreshMargin {
+		return ts.token, nil
+	}
+
+	appJWT, err := ts.appJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+	resp, err := doRequest(ctx, ts.client,
+		fmt.Sprintf("%s/app/installations/%s/access_tokens", ts.url, ts.installationID),
+		http.MethodPost, nil, "Bearer "+appJWT, "backend.github.CreateInstallationToken")
+	if err != nil {
+		return "", fmt.Errorf("failed to create github installation token: %w", err)
+	}
+	var token installationToken
+	if err := decode(resp, &token); err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		return "", errors.New("no token in the github installation token response")
+	}
+	ts.token = token.Token
+	ts.expiresAt = token.ExpiresAt
+	return ts.token, nil
+}
+
+// appJWT returns the RS256 JWT authenticating as the app, its issue time is set in the past to
+// allow for the clock drift
+func (ts *tokenSource) appJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": ts.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, ts.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign github app JWT: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// errorMessage returns the message of an error response of GitHub
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	return "", errResp.Message
+}
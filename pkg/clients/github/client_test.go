@@ -0,0 +1,301 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeGithub is a GitHub organization holding its members and teams in memory, authenticating
+// the requests with the installation tokens it issues
+type fakeGithub struct {
+	publicKey    *rsa.PublicKey
+	tokens       int
+	members      []User
+	teams        []Team
+	created      []createTeamRequest
+	memberships  map[string]string
+	deleted      []string
+	graphQLPages int
+}
+
+func (f *fakeGithub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/app/installations/42/access_tokens" {
+		if !f.validJWT(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.tokens++
+		fakehttp.WriteJSON(w, http.StatusCreated, installationToken{Token: "installation-token",
+			ExpiresAt: time.Now().Add(time.Hour)})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer installation-token" || r.Header.Get("Accept") != mediaType {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme":
+		_, _ = w.Write([]byte(`{"login": "acme"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/users/jdoe":
+		_, _ = w.Write([]byte(`{"id": 1, "login": "jdoe", "name": "John Doe"}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/graphql":
+		var req graphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.graphQLPages++
+		// the members are served one per page
+		index := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			index, _ = strconv.Atoi(after)
+		}
+		var resp graphQLResponse[membersResponse]
+		resp.Data.Organization.MembersWithRole.Nodes = f.members[index : index+1]
+		resp.Data.Organization.MembersWithRole.PageInfo = pageInfo{
+			HasNextPage: index+1 < len(f.members),
+			EndCursor:   strconv.Itoa(index + 1),
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, resp)
+	case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/teams/platform/teams":
+		start := min((page-1)*perPage, len(f.teams))
+		fakehttp.WriteJSON(w, http.StatusOK, f.teams[start:min(start+perPage, len(f.teams))])
+	case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/teams/platform":
+		fakehttp.WriteJSON(w, http.StatusOK, Team{ID: 7, Slug: "platform", Name: "Platform"})
+	case r.Method == http.MethodPost && r.URL.Path == "/orgs/acme/teams":
+		var req createTeamRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.created = append(f.created, req)
+		fakehttp.WriteJSON(w, http.StatusCreated, Team{ID: 8, Slug: strings.ToLower(strings.ReplaceAll(req.Name, " ", "-")),
+			Name: req.Name})
+	case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/teams/team-a/members":
+		var users []User
+		for login, role := range f.memberships {
+			if role == r.URL.Query().Get("role") {
+				users = append(users, User{Login: login})
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, users)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/orgs/acme/teams/team-a/memberships/"):
+		var req membershipRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.memberships[strings.TrimPrefix(r.URL.Path, "/orgs/acme/teams/team-a/memberships/")] = req.Role
+		_, _ = w.Write([]byte(`{"state": "active"}`))
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/orgs/acme/teams/team-a/memberships/"):
+		login := strings.TrimPrefix(r.URL.Path, "/orgs/acme/teams/team-a/memberships/")
+		if _, ok := f.memberships[login]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.memberships, login)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		f.deleted = append(f.deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	}
+}
+
+// validJWT verifies the RS256 signature and the issuer of the app JWT
+func (f *fakeGithub) validJWT(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(f.publicKey, crypto.SHA256, digest[:], signature) != nil {
+		return false
+	}
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	var decoded struct {
+		Issuer string `json:"iss"`
+		Expiry int64  `json:"exp"`
+	}
+	return json.Unmarshal(claims, &decoded) == nil && decoded.Issuer == "1234" &&
+		decoded.Expiry > time.Now().Unix()
+}
+
+func newTestClient(t *testing.T, fake *fakeGithub, connection map[string]interface{}) *GithubClient {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	fake.publicKey = &key.PublicKey
+	if fake.memberships == nil {
+		fake.memberships = map[string]string{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["base_url"] = server.URL + "/"
+	connection["org"] = "acme"
+	connection["app_id"] = "1234"
+	connection["installation_id"] = "42"
+	connection["private_key"] = string(pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"token": "token"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "required field: org")
+
+	_, err = NewClient(map[string]interface{}{"org": "acme", "app_id": "1234"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "app_id, installation_id or private_key")
+
+	_, err = NewClient(map[string]interface{}{"org": "acme", "app_id": "1234", "installation_id": "42",
+		"private_key": "not a key"}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "no PEM block found")
+
+	_, err = NewClient(map[string]interface{}{"org": "acme", "token": "token", "privacy": "public"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "unsupported github team privacy")
+}
+
+func TestDefaultGraphQLURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/graphql", defaultGraphQLURL("https://api.github.com"))
+	assert.Equal(t, "https://github.example.com/api/graphql", defaultGraphQLURL("https://github.example.com/api/v3"))
+}
+
+func TestFetchAllUsersPages(t *testing.T) {
+	fake := &fakeGithub{members: []User{
+		{ID: 1, Login: "jdoe", Name: "John Doe", Email: "jdoe@example.com"},
+		{ID: 2, Login: "asmith"},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+	assert.Len(t, byEmail, 1)
+	assert.Equal(t, "jdoe", byEmail["jdoe@example.com"].ID)
+	assert.Equal(t, "John Doe", byID["jdoe"].DisplayName)
+	assert.Equal(t, 2, fake.graphQLPages)
+	// the installation token is reused until it expires
+	assert.Equal(t, 1, fake.tokens)
+}
+
+func TestCreateUser(t *testing.T) {
+	client := newTestClient(t, &fakeGithub{}, map[string]interface{}{})
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "jdoe", UserName: "jdoe", Email: "jdoe@example.com", DisplayName: "John Doe"}, user)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "unknown"})
+	assert.True(t, request.IsNotFound(err))
+}
+
+func TestTeams(t *testing.T) {
+	fake := &fakeGithub{}
+	for i := 1; i <= pageSize+1; i++ {
+		fake.teams = append(fake.teams, Team{ID: int64(i), Slug: fmt.Sprintf("team-%d", i), Name: fmt.Sprintf("Team %d", i)})
+	}
+	client := newTestClient(t, fake, map[string]interface{}{"parent_team": "platform"})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, teams, pageSize+1)
+	assert.Equal(t, "team-101", teams["Team 101"].ID)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "Data Eng", Description: "data"})
+	require.NoError(t, err)
+	assert.Equal(t, "data-eng", team.ID)
+	assert.Equal(t, []createTeamRequest{{Name: "Data Eng", Description: "data", Privacy: "closed", ParentTeamID: 7}},
+		fake.created)
+
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "data-eng"))
+	assert.Equal(t, []string{"/orgs/acme/teams/data-eng"}, fake.deleted)
+}
+
+func TestTeamMembership(t *testing.T) {
+	fake := &fakeGithub{memberships: map[string]string{"jdoe": RoleMaintainer}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "team-a", []string{"asmith"}))
+	require.NoError(t, client.UpdateTeamMemberRole(context.Background(), "team-a", []string{"jdoe"}, "Member"))
+	assert.ErrorContains(t, client.AddUserToTeamWithRole(context.Background(), "team-a", []string{"bob"}, "owner"),
+		"unsupported github team member role")
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "team-a")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, RoleMember, members["jdoe"].Role)
+
+	require.NoError(t, client.AddUserToTeamWithRole(context.Background(), "team-a", []string{"bob"}, RoleMaintainer))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "team-a", []string{"asmith", "unknown"}))
+	assert.Equal(t, map[string]string{"jdoe": RoleMember, "bob": RoleMaintainer}, fake.memberships)
+}
+
+func TestDeleteUser(t *testing.T) {
+	fake := &fakeGithub{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+	assert.NoError(t, client.DeleteUser(context.Background(), "jdoe"))
+	assert.Empty(t, fake.deleted)
+
+	client = newTestClient(t, fake, map[string]interface{}{"remove_org_members": true})
+	assert.NoError(t, client.DeleteUser(context.Background(), "jdoe"))
+	assert.Equal(t, []string{"/orgs/acme/memberships/jdoe"}, fake.deleted)
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeGithub{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.auth = &tokenSource{token: "wrong"}
+	err := client.HealthCheck(context.Background())
+	var respErr *request.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusUnauthorized, respErr.StatusCode)
+	assert.Equal(t, "Bad credentials", respErr.Message)
+}
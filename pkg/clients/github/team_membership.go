@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchTeamMembersByTeamID lists the members of the team by slug, keyed by login, with their
+// member or maintainer role. GitHub includes the members of the child teams.
+func (gC *GithubClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members := make(map[string]*structs.User)
+	// The role filter is the only way to read the roles without a request per member
+	for _, role := range []string{RoleMember, RoleMaintainer} {
+		path := fmt.Sprintf("%s/members?role=%s", gC.teamPath(teamID), role)
+		err := forEachPage(ctx, gC, path, "backend.github.FetchTeamMembersByTeamID", func(users []User) error {
+			for _, u := range users {
+				member := userDetails(&u)
+				member.Role = role
+				members[member.ID] = member
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch github team members")
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the team as members
+func (gC *GithubClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return gC.setTeamMemberships(ctx, teamID, userIDs, RoleMember, "backend.github.AddUserToTeam")
+}
+
+// AddUserToTeamWithRole adds the users to the team with the member or maintainer role
+func (gC *GithubClient) AddUserToTeamWithRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	return gC.setTeamMemberships(ctx, teamID, userIDs, role, "backend.github.AddUserToTeam")
+}
+
+// ValidMemberRole reports whether the role is the member or maintainer role of the teams
+func (gC *GithubClient) ValidMemberRole(role string) bool {
+	role = strings.ToLower(role)
+	return role == RoleMember || role == RoleMaintainer
+}
+
+// UpdateTeamMemberRole changes the role of the team members, the membership request of an existing
+// member updates its role
+func (gC *GithubClient) UpdateTeamMemberRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	return gC.setTeamMemberships(ctx, teamID, userIDs, role, "backend.github.UpdateTeamMemberRole")
+}
+
+// setTeamMemberships sets the team membership of each user, GitHub has no bulk membership
+// endpoint. The users who are not members of the organization are invited to it, and join the
+// team once they accept the invitation.
+func (gC *GithubClient) setTeamMemberships(ctx context.Context, teamID string, userIDs []string, role string,
+	methodName string) error {
+
+	span, ctx := ot.StartSpanFromContext(ctx, methodName)
+	defer span.Finish()
+
+	role = strings.ToLower(role)
+	if role != RoleMember && role != RoleMaintainer {
+		return fmt.Errorf("unsupported github team member role: %s", role)
+	}
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "github")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).WithField("role", role).Info("Set github team membership")
+		_, err := gC.sendRequest(ctx, gC.membershipPath(teamID, userID), http.MethodPut,
+			&membershipRequest{Role: role}, methodName)
+		if err != nil {
+			return fmt.Errorf("failed to add user %s to github team %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveUserFromTeam deletes the team memberships of the users one at a time, GitHub answers 404
+// for a user without a membership, which is skipped
+func (gC *GithubClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.RemoveUserFromTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "github")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Remove github team membership")
+		_, err := gC.sendRequest(ctx, gC.membershipPath(teamID, userID), http.MethodDelete, nil,
+			"backend.github.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s from github team %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// membershipPath returns the path of the membership of the user in the team
+func (gC *GithubClient) membershipPath(teamID, userID string) string {
+	return gC.teamPath(teamID) + "/memberships/" + url.PathEscape(userID)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the teams of the organization, or the child teams of the parent team,
+// keyed by name
+func (gC *GithubClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := gC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch github teams")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the teams, 100 teams at a time. The teams are
+// identified by their slug.
+func (gC *GithubClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	path := fmt.Sprintf("/orgs/%s/teams", gC.org)
+	if gC.parentTeam != "" {
+		path = fmt.Sprintf("/orgs/%s/teams/%s/teams", gC.org, url.PathEscape(gC.parentTeam))
+	}
+	return forEachPage(ctx, gC, path, "backend.github.FetchAllTeams", func(githubTeams []Team) error {
+		page := make([]structs.Team, 0, len(githubTeams))
+		for _, team := range githubTeams {
+			page = append(page, teamDetails(&team))
+		}
+		return fn(page)
+	})
+}
+
+// FetchTeamDetails fetches the team by slug
+func (gC *GithubClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.FetchTeamDetails")
+	defer span.Finish()
+
+	team, err := gC.fetchTeam(ctx, teamID, "backend.github.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	details := teamDetails(team)
+	return &details, nil
+}
+
+// CreateTeam creates the team in the organization, nested in the parent team when the backend
+// has one. GitHub derives the slug of the team, its ID, from the name.
+func (gC *GithubClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "github")
+	log.Info("Create github team")
+
+	createRequest := &createTeamRequest{
+		Name:        team.Name,
+		Description: team.Description,
+		Privacy:     gC.privacy,
+	}
+	if gC.parentTeam != "" {
+		parent, err := gC.fetchTeam(ctx, gC.parentTeam, "backend.github.CreateTeam")
+		if err != nil {
+			if request.IsNotFound(err) {
+				return nil, fmt.Errorf("github parent team %s not found: %w", gC.parentTeam, err)
+			}
+			return nil, err
+		}
+		createRequest.ParentTeamID = parent.ID
+	}
+
+	resp, err := gC.sendRequest(ctx, fmt.Sprintf("/orgs/%s/teams", gC.org), http.MethodPost, createRequest,
+		"backend.github.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create github team")
+		return nil, err
+	}
+
+	var created Team
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.Slug == "" {
+		return nil, errors.New("no team slug in the github create team response")
+	}
+	return &structs.Team{
+		ID:          created.Slug,
+		Name:        created.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// DeleteTeamByID deletes the team by slug, its child teams are deleted with it. A team which does
+// not exist is considered deleted.
+func (gC *GithubClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "github")
+	log.Info("Delete github team")
+
+	_, err := gC.sendRequest(ctx, gC.teamPath(teamID), http.MethodDelete, nil, "backend.github.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("github team not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// ReconcileGroupParams is a no-op, GitHub teams have no group params
+func (gC *GithubClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	return nil
+}
+
+// fetchTeam fetches the team of the organization by slug
+func (gC *GithubClient) fetchTeam(ctx context.Context, slug string, methodName string) (*Team, error) {
+	var team Team
+	if err := gC.get(ctx, gC.teamPath(slug), &team, methodName); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// teamPath returns the path of the team of the organization
+func (gC *GithubClient) teamPath(slug string) string {
+	return fmt.Sprintf("/orgs/%s/teams/%s", gC.org, url.PathEscape(slug))
+}
+
+// teamDetails converts the GitHub team, the slug is the ID of the team
+func teamDetails(t *Team) structs.Team {
+	return structs.Team{
+		ID:          t.Slug,
+		Name:        t.Name,
+		Description: t.Description,
+	}
+}
+
+// forEachPage calls fn with each page of the resources of the path, the REST API pages are
+// indexed from 1 and the listing ends with a short page
+func forEachPage[T any](ctx context.Context, gC *GithubClient, path string, methodName string,
+	fn func(page []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	for pageNumber := 1; ; pageNumber++ {
+		var page []T
+		pagePath := fmt.Sprintf("%s%sper_page=%d&page=%d", path, separator, pageSize, pageNumber)
+		if err := gC.get(ctx, pagePath, &page, methodName); err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
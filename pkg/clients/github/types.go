@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import "time"
+
+// Roles of the team members, GitHub teams have members and maintainers
+const (
+	RoleMember     = "member"
+	RoleMaintainer = "maintainer"
+)
+
+const (
+	// defaultBaseURL is the REST API of github.com, GitHub Enterprise Server serves it under /api/v3
+	defaultBaseURL = "https://api.github.com"
+	// apiVersion is the version of the REST API the client is written against
+	apiVersion = "2022-11-28"
+	// mediaType is the media type of the REST API requests and responses
+	mediaType = "application/vnd.github+json"
+	// pageSize is the maximum number of resources of a list page of the REST and GraphQL APIs
+	pageSize = 100
+	// defaultPrivacy is the privacy of the created teams, closed teams are visible to the org members
+	defaultPrivacy = "closed"
+	// tokenRefreshMargin is how long before its expiry the installation token is renewed
+	tokenRefreshMargin = time.Minute
+	// jwtLifetime is the lifetime of the app JWTs, GitHub rejects the JWTs living over 10 minutes
+	jwtLifetime = 9 * time.Minute
+)
+
+// GithubConfig is the connection of a GitHub backend, read from the backend configuration. The
+// client authenticates as an installation of a GitHub App, with its app_id, installation_id and
+// private_key, or with a token.
+type GithubConfig struct {
+	// BaseURL is the REST API of GitHub, https://api.github.com (default) or
+	// https://<host>/api/v3 for GitHub Enterprise Server
+	BaseURL string `json:"base_url"`
+	// GraphQLURL is the GraphQL API of GitHub, it defaults to the one of the REST API
+	GraphQLURL string `json:"graphql_url"`
+	// Org is the login of the organization whose teams are managed
+	Org string `json:"org"`
+	// AppID is the ID of the GitHub App
+	AppID string `json:"app_id"`
+	// InstallationID is the ID of the installation of the app in the organization
+	InstallationID string `json:"installation_id"`
+	// PrivateKey is the PEM encoded private key of the app
+	PrivateKey string `json:"private_key"`
+	// Token is a personal access token used instead of the app installation
+	Token string `json:"token"`
+	// ParentTeam is the slug of the team the created teams are nested in, only its child teams are
+	// then listed
+	ParentTeam string `json:"parent_team"`
+	// Privacy of the created teams, closed (default) or secret
+	Privacy string `json:"privacy"`
+	// UseSAMLIdentities matches the users by the NameID of their SAML identity in the organization,
+	// their email, instead of their username
+	UseSAMLIdentities bool `json:"use_saml_identities"`
+	// RemoveOrgMembers removes the deleted users from the organization, otherwise the deleted users
+	// are only removed from the teams
+	RemoveOrgMembers bool `json:"remove_org_members"`
+}
+
+// User is a GitHub user account
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Team is a team of the organization
+type Team struct {
+	ID          int64  `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Privacy     string `json:"privacy,omitempty"`
+}
+
+// createTeamRequest is the body of a team creation
+type createTeamRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Privacy      string `json:"privacy"`
+	ParentTeamID int64  `json:"parent_team_id,omitempty"`
+}
+
+// membershipRequest is the body of a team membership, it adds the user or changes its role
+type membershipRequest struct {
+	Role string `json:"role"`
+}
+
+// installationToken is the response of an installation access token request
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// errorResponse is the body of the REST API errors
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// graphQLRequest is a query of the GraphQL API
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the response of a GraphQL query, the errors are reported with a 200
+type graphQLResponse[T any] struct {
+	Data   T `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// pageInfo is the cursor of a GraphQL connection
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// membersResponse is a page of the members of the organization
+type membersResponse struct {
+	Organization struct {
+		MembersWithRole struct {
+			Nodes    []User   `json:"nodes"`
+			PageInfo pageInfo `json:"pageInfo"`
+		} `json:"membersWithRole"`
+	} `json:"organization"`
+}
+
+// externalIdentity is the SAML identity of a member of the organization
+type externalIdentity struct {
+	SAMLIdentity struct {
+		NameID string `json:"nameId"`
+	} `json:"samlIdentity"`
+	User *User `json:"user"`
+}
+
+// identityConnection is a page of the SAML identities
+type identityConnection struct {
+	Nodes    []externalIdentity `json:"nodes"`
+	PageInfo pageInfo           `json:"pageInfo"`
+}
+
+// identitiesResponse is a page of the SAML identities of the organization
+type identitiesResponse struct {
+	Organization struct {
+		SAMLIdentityProvider *struct {
+			ExternalIdentities identityConnection `json:"externalIdentities"`
+		} `json:"samlIdentityProvider"`
+	} `json:"organization"`
+}
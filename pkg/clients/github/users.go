@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// The GraphQL queries of the members of the organization, the REST API does not return the
+// emails. The database IDs are aliased to the id of the REST API.
+const (
+	membersQuery = `query($org: String!, $first: Int!, $after: String) {
+  organization(login: $org) {
+    membersWithRole(first: $first, after: $after) {
+      nodes { id: databaseId login name email }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+	identitiesQuery = `query($org: String!, $first: Int!, $after: String, $userName: String) {
+  organization(login: $org) {
+    samlIdentityProvider {
+      externalIdentities(first: $first, after: $after, userName: $userName, membersOnly: true) {
+        nodes {
+          samlIdentity { nameId }
+          user { id: databaseId login name email }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+)
+
+// FetchAllUsers lists the members of the organization, keyed by login and by email
+func (gC *GithubClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := gC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch github organization members")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the members of the organization, 100 members at a
+// time. The emails of the members are the NameIDs of their SAML identities when the backend uses
+// them, otherwise their public emails.
+func (gC *GithubClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	variables := map[string]any{"org": gC.org, "first": pageSize}
+	for {
+		var page []*structs.User
+		var next pageInfo
+		if gC.useSAMLIdentities {
+			identities, err := gC.fetchIdentities(ctx, variables, "backend.github.FetchAllUsers")
+			if err != nil {
+				return err
+			}
+			page = make([]*structs.User, 0, len(identities.Nodes))
+			for _, identity := range identities.Nodes {
+				if identity.User != nil {
+					page = append(page, identityDetails(identity))
+				}
+			}
+			next = identities.PageInfo
+		} else {
+			members, err := query[membersResponse](ctx, gC, membersQuery, variables, "backend.github.FetchAllUsers")
+			if err != nil {
+				return err
+			}
+			page = make([]*structs.User, 0, len(members.Organization.MembersWithRole.Nodes))
+			for _, member := range members.Organization.MembersWithRole.Nodes {
+				page = append(page, userDetails(&member))
+			}
+			next = members.Organization.MembersWithRole.PageInfo
+		}
+
+		if err := fn(page); err != nil {
+			return err
+		}
+		if !next.HasNextPage {
+			return nil
+		}
+		variables["after"] = next.EndCursor
+	}
+}
+
+// fetchIdentities fetches a page of the SAML identities of the members of the organization
+func (gC *GithubClient) fetchIdentities(ctx context.Context, variables map[string]any,
+	methodName string) (*identityConnection, error) {
+	identities, err := query[identitiesResponse](ctx, gC, identitiesQuery, variables, methodName)
+	if err != nil {
+		return nil, err
+	}
+	provider := identities.Organization.SAMLIdentityProvider
+	if provider == nil {
+		return nil, fmt.Errorf("github organization %s has no SAML identity provider", gC.org)
+	}
+	return &provider.ExternalIdentities, nil
+}
+
+// FetchUserDetails fetches the user by login
+func (gC *GithubClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := gC.get(ctx, "/users/"+url.PathEscape(userID), &user, "backend.github.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser resolves the GitHub account of the user, GitHub accounts are owned by their users and
+// cannot be created by the organization. The account is the one of the SAML identity of the email
+// when the backend uses them, otherwise the one whose login is the username. The users who are not
+// members are invited to the organization when they are added to a team.
+func (gC *GithubClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "github")
+	log.Info("Resolve github user")
+
+	var user *structs.User
+	if gC.useSAMLIdentities {
+		identities, err := gC.fetchIdentities(ctx, map[string]any{"org": gC.org, "first": 1, "userName": u.Email},
+			"backend.github.CreateUser")
+		if err != nil {
+			log.WithError(err).Error("failed to fetch the github SAML identity of the user")
+			return nil, err
+		}
+		for _, identity := range identities.Nodes {
+			if identity.User != nil && strings.EqualFold(identity.SAMLIdentity.NameID, u.Email) {
+				user = identityDetails(identity)
+			}
+		}
+		if user == nil {
+			return nil, fmt.Errorf("no github SAML identity found for %s in organization %s", u.Email, gC.org)
+		}
+	} else {
+		var err error
+		user, err = gC.FetchUserDetails(ctx, u.UserName)
+		if err != nil {
+			if request.IsNotFound(err) {
+				return nil, fmt.Errorf("github user %s not found: %w", u.UserName, err)
+			}
+			log.WithError(err).Error("failed to fetch github user")
+			return nil, err
+		}
+	}
+
+	if user.Email == "" {
+		user.Email = u.Email
+	}
+	return user, nil
+}
+
+// DeleteUser removes the user from the organization when the backend removes the members, the
+// GitHub account itself is never deleted. A user who is not a member is considered deleted.
+func (gC *GithubClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.github.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "github")
+	if !gC.removeOrgMembers {
+		log.Info("github organization members are not removed, skipping user deletion")
+		return nil
+	}
+
+	log.Info("Remove github organization member")
+	_, err := gC.sendRequest(ctx, fmt.Sprintf("/orgs/%s/memberships/%s", gC.org, url.PathEscape(userID)),
+		http.MethodDelete, nil, "backend.github.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("github organization member not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// userDetails converts the GitHub user, the login is the ID and the username of the user
+func userDetails(u *User) *structs.User {
+	return &structs.User{
+		ID:          u.Login,
+		UserName:    u.Login,
+		Email:       u.Email,
+		DisplayName: u.Name,
+	}
+}
+
+// identityDetails converts the user of the SAML identity, whose email is the NameID
+func identityDetails(identity externalIdentity) *structs.User {
+	user := userDetails(identity.User)
+	if identity.SAMLIdentity.NameID != "" {
+		user.Email = identity.SAMLIdentity.NameID
+	}
+	return user
+}
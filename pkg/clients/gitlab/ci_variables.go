@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// reconcileCIVariables creates the CI/CD variables of the ci_variables group param in the group,
+// each value being KEY=value, and updates the variables whose value differs. The other variables
+// of the group are left untouched.
+func (g *GitlabClient) reconcileCIVariables(ctx context.Context, groupID int, values []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"groupID": groupID,
+	})
+
+	for _, variable := range values {
+		key, value, found := strings.Cut(variable, "=")
+		if !found || key == "" {
+			return errors.New("invalid gitlab ci variable, expected KEY=value")
+		}
+
+		existing, resp, err := g.gitlabClient.GroupVariables.GetVariable(groupID, key, nil, gitlab.WithContext(ctx))
+		if err != nil {
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return fmt.Errorf("failed to fetch ci variable %s of group %d: %w", key, groupID, err)
+			}
+			if _, _, err := g.gitlabClient.GroupVariables.CreateVariable(groupID, &gitlab.CreateGroupVariableOptions{
+				Key:   &key,
+				Value: &value,
+			}, gitlab.WithContext(ctx)); err != nil {
+				return fmt.Errorf("failed to create ci variable %s of group %d: %w", key, groupID, err)
+			}
+			log.WithField("key", key).Info("ci variable created")
+			continue
+		}
+
+		if existing.Value == value {
+			continue
+		}
+		if _, _, err := g.gitlabClient.GroupVariables.UpdateVariable(groupID, key, &gitlab.UpdateGroupVariableOptions{
+			Value: &value,
+		}, gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to update ci variable %s of group %d: %w", key, groupID, err)
+		}
+		log.WithField("key", key).Info("ci variable updated")
+	}
+	return nil
+}
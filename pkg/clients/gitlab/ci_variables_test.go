@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// fakeGroupVariables is a gitlab group holding the values of its CI/CD variables by key
+type fakeGroupVariables struct {
+	variables map[string]string
+	// failKey is a variable the API fails to fetch with a server error
+	failKey string
+
+	writes []string
+}
+
+func (f *fakeGroupVariables) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	variablesPath := "/api/v4/groups/100/variables"
+	key := strings.TrimPrefix(r.URL.Path, variablesPath+"/")
+	var variable struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&variable)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/groups/100":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 100, "name": "team"})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, variablesPath+"/"):
+		value, ok := f.variables[key]
+		switch {
+		case key == f.failKey:
+			w.WriteHeader(http.StatusInternalServerError)
+		case !ok:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "404 Variable Not Found"}`))
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"key": key, "value": value})
+		}
+	case r.Method == http.MethodPost && r.URL.Path == variablesPath:
+		f.writes = append(f.writes, "create "+variable.Key)
+		f.variables[variable.Key] = variable.Value
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(variable)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, variablesPath+"/"):
+		f.writes = append(f.writes, "update "+key)
+		f.variables[key] = variable.Value
+		_ = json.NewEncoder(w).Encode(map[string]string{"key": key, "value": variable.Value})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestReconcileGroupParams_CIVariables(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGroupVariables{variables: map[string]string{"KEPT": "same", "CHANGED": "old"}}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamCIVariables,
+		Value:    []string{"KEPT=same", "CHANGED=new", "CREATED=a=b"},
+	}))
+	assert.Equal(t, map[string]string{"KEPT": "same", "CHANGED": "new", "CREATED": "a=b"}, fake.variables)
+	assert.Equal(t, []string{"update CHANGED", "create CREATED"}, fake.writes,
+		"Expected the variables holding their value to be left as is")
+
+	for _, invalid := range []string{"NOVALUE", "=value"} {
+		assert.ErrorContains(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+			Property: GroupParamCIVariables,
+			Value:    []string{invalid},
+		}), "invalid gitlab ci variable, expected KEY=value")
+	}
+
+	fake.failKey = "BROKEN"
+	assert.ErrorContains(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamCIVariables,
+		Value:    []string{"BROKEN=value"},
+	}), "failed to fetch ci variable BROKEN of group 100")
+	assert.NotContains(t, fake.variables, "BROKEN")
+}
@@ -45,12 +45,44 @@ func NewClient(
 	if gitlabConfig.URL == "" || gitlabConfig.Token == "" {
 		return nil, fmt.Errorf("missing required connection parameters for gitlab backend")
 	}
+	switch gitlabConfig.GroupLink {
+	case "":
+		gitlabConfig.GroupLink = GroupLinkLDAP
+	case GroupLinkLDAP, GroupLinkSAML:
+	default:
+		return nil, fmt.Errorf("unsupported gitlab group_link %q, supported: %s, %s",
+			gitlabConfig.GroupLink, GroupLinkLDAP, GroupLinkSAML)
+	}
+
+	maxRetryAfter := defaultMaxRetryAfter
+	if gitlabConfig.MaxRetryAfter != "" {
+		var err error
+		maxRetryAfter, err = time.ParseDuration(gitlabConfig.MaxRetryAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gitlab max_retry_after %q: %w", gitlabConfig.MaxRetryAfter, err)
+		}
+	}
+	// The SDK retries the rate limited requests and the server errors
+	retryOptions := []gitlab.ClientOptionFunc{gitlab.WithCustomBackoff(throttleBackoff(maxRetryAfter))}
+	switch {
+	case gitlabConfig.MaxThrottleRetries < 0:
+		retryOptions = append(retryOptions, gitlab.WithoutRetries())
+	case gitlabConfig.MaxThrottleRetries == 0:
+		retryOptions = append(retryOptions, gitlab.WithCustomRetryMax(defaultMaxThrottleRetries))
+	default:
+		retryOptions = append(retryOptions, gitlab.WithCustomRetryMax(gitlabConfig.MaxThrottleRetries))
+	}
 
 	baseUrl := fmt.Sprintf("%s/api/v4", gitlabConfig.URL)
 	gitlabConfig.URL = baseUrl
 
 	// Gitlab SDK Client
-	client, err := gitlab.NewClient(gitlabConfig.Token, gitlab.WithBaseURL(baseUrl))
+	httpClient, err := httpclient.NewHTTPClient(poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+	client, err := gitlab.NewClient(gitlabConfig.Token,
+		append(retryOptions, gitlab.WithBaseURL(baseUrl), gitlab.WithHTTPClient(httpClient))...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +112,26 @@ func NewClient(
 	}, nil
 }
 
+// HealthCheck fetches the user of the token to check that the API accepts it
+func (g *GitlabClient) HealthCheck(ctx context.Context) error {
+	if _, _, err := g.gitlabClient.Users.CurrentUser(gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("gitlab health check failed: %w", err)
+	}
+	return nil
+}
+
 func (g *GitlabClient) SetLdapSync(ldapSync bool, cn string) {
 	g.ldapSync = ldapSync
 	g.cn = cn
 }
 
+// ConfigureDependency enables the LDAP sync of the group from the LDAP group of its dependency (rover),
+// GitLab then manages the members of the group
+func (g *GitlabClient) ConfigureDependency(_ context.Context, _ config.Dependant, groupName string) (bool, error) {
+	g.SetLdapSync(true, groupName)
+	return true, nil
+}
+
 func (g *GitlabClient) sendLdapSyncRequest(ctx context.Context) ([]byte, int, error) {
 	url := fmt.Sprintf("%s/groups/%d/ldap_sync", g.gitlabConfig.URL, g.gitlabConfig.ParentGroupId)
 	requestBody := []byte{}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// FetchDirectTeamMembers returns the direct members of the group by their ID, without the members
+// inherited from its parent groups or shared from other groups. With LDAP sync, the members added
+// manually in GitLab are the direct members which are not members of the linked group.
+func (g *GitlabClient) FetchDirectTeamMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+	})
+	log.Info("fetching direct team members")
+
+	opt := &gitlab.ListGroupMembersOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+			Page:    1,
+		},
+	}
+
+	members := make(map[string]*structs.User)
+	for {
+		page, resp, err := g.gitlabClient.Groups.ListGroupMembers(teamID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			members[fmt.Sprintf("%d", m.ID)] = &structs.User{
+				ID:       fmt.Sprintf("%d", m.ID),
+				Email:    m.PublicEmail,
+				UserName: m.Username,
+				Role:     accessLevelName(m.AccessLevel),
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return members, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// RemoveDirectTeamMembers removes the direct members from the group, also when its membership is
+// synced from LDAP or SAML
+func (g *GitlabClient) RemoveDirectTeamMembers(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+		"userIDs": userIDs,
+	})
+	log.Info("removing direct members from team")
+
+	userIDs, _ = splitInvitedUsers(userIDs)
+	return g.removeGroupMembers(ctx, teamID, userIDs)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+func TestFetchDirectTeamMembers(t *testing.T) {
+	fake := newFakeGroupMembers()
+	fake.directMembers = fake.members[:2]
+	fake.pageSize = 1
+	client := newTestClient(t, fake)
+
+	members, err := client.FetchDirectTeamMembers(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{
+		"1": fakeGroupMembersUsers["1"],
+		"2": fakeGroupMembersUsers["2"],
+	}, members, "Expected only the direct members to be listed, across all the pages")
+	assert.Equal(t, 2, fake.restRequests)
+}
+
+func TestRemoveDirectTeamMembers(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{
+		1: int(gitlab.DeveloperPermissions),
+		2: int(gitlab.DeveloperPermissions),
+	})
+	fake.invitations["new@example.com"] = int(gitlab.DeveloperPermissions)
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.RemoveDirectTeamMembers(ctx, "100", []string{"1", "invite:new@example.com"}))
+	assert.Equal(t, map[int]int{2: int(gitlab.DeveloperPermissions)}, fake.members)
+	assert.Contains(t, fake.invitations, "new@example.com", "Expected the invitations not to be direct members")
+
+	assert.Error(t, client.RemoveDirectTeamMembers(ctx, "100", []string{"1"}))
+}
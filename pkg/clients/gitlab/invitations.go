@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// invitePrefix prefixes the email of the users without a gitlab account to form their ID, they are
+// invited to the groups by email and become members when they first sign in
+const invitePrefix = "invite:"
+
+// pendingUser returns the user invited by email until its gitlab account exists
+func pendingUser(u *structs.User) *structs.User {
+	return &structs.User{
+		ID:       invitePrefix + u.Email,
+		Email:    u.Email,
+		UserName: u.UserName,
+	}
+}
+
+// invitedEmail returns the email of the ID of a user invited by email
+func invitedEmail(userID string) (string, bool) {
+	return strings.CutPrefix(userID, invitePrefix)
+}
+
+// IsPendingUser reports whether the ID is the one of a user invited by email, the user is looked
+// up again on the next reconciles until its account exists
+func (g *GitlabClient) IsPendingUser(userID string) bool {
+	_, ok := invitedEmail(userID)
+	return ok
+}
+
+// splitInvitedUsers splits the user IDs into the IDs of the gitlab accounts and the emails of the
+// invited users
+func splitInvitedUsers(userIDs []string) ([]string, []string) {
+	accountIDs := make([]string, 0, len(userIDs))
+	emails := make([]string, 0)
+	for _, userID := range userIDs {
+		if email, ok := invitedEmail(userID); ok {
+			emails = append(emails, email)
+			continue
+		}
+		accountIDs = append(accountIDs, userID)
+	}
+	return accountIDs, emails
+}
+
+// inviteUsersToTeam invites the emails to the group at the access level, in one request
+func (g *GitlabClient) inviteUsersToTeam(ctx context.Context, teamID string, emails []string,
+	accessLevel gitlab.AccessLevelValue) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+		"emails":  emails,
+	})
+	if len(emails) == 0 {
+		return nil
+	}
+
+	email := strings.Join(emails, ",")
+	result, resp, err := g.gitlabClient.Invites.GroupInvites(teamID, &gitlab.InvitesOptions{
+		Email:       &email,
+		AccessLevel: &accessLevel,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to invite users %v to team %s, status: %s", emails, teamID, resp.Status)
+	}
+	// Emails which could not be invited are reported in the message with a created status, the
+	// emails already invited or members are not errors
+	for invited, message := range result.Message {
+		if strings.Contains(strings.ToLower(message), "already") {
+			continue
+		}
+		return fmt.Errorf("failed to invite user %s to team %s: %s", invited, teamID, message)
+	}
+	log.Info("invited users without gitlab account to team")
+	return nil
+}
+
+// fetchPendingInvitations returns the users invited to the group by email, by their ID
+func (g *GitlabClient) fetchPendingInvitations(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	opt := &gitlab.ListPendingInvitationsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+			Page:    1,
+		},
+	}
+
+	invited := make(map[string]*structs.User)
+	for {
+		invites, resp, err := g.gitlabClient.Invites.ListPendingGroupInvitations(teamID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, invite := range invites {
+			user := pendingUser(&structs.User{Email: invite.InviteEmail})
+			user.Role = accessLevelName(invite.AccessLevel)
+			invited[user.ID] = user
+		}
+
+		if resp.NextPage == 0 {
+			return invited, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// deleteInvitation revokes the invitation of the email to the group
+func (g *GitlabClient) deleteInvitation(ctx context.Context, teamID, email string) error {
+	req, err := g.gitlabClient.NewRequest(http.MethodDelete,
+		fmt.Sprintf("groups/%s/invitations/%s", gitlab.PathEscape(teamID), gitlab.PathEscape(email)), nil,
+		[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	resp, err := g.gitlabClient.Do(req, nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			logger.Logger(ctx).WithField("service", "gitlab").WithField("teamID", teamID).
+				Warn("invitation not found, considering deletion successful")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
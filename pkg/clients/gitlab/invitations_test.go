@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestAddUserToTeam_Invitations(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{})
+	client := newTestClient(t, fake)
+
+	assert.True(t, client.IsPendingUser("invite:new@example.com"))
+	assert.False(t, client.IsPendingUser("1"))
+
+	require.NoError(t, client.AddUserToTeamWithRole(ctx, "100",
+		[]string{"1", "invite:new@example.com", "invite:other@example.com"}, "reporter"))
+	assert.Equal(t, map[int]int{1: int(gitlab.ReporterPermissions)}, fake.members)
+	assert.Equal(t, map[string]int{
+		"new@example.com":   int(gitlab.ReporterPermissions),
+		"other@example.com": int(gitlab.ReporterPermissions),
+	}, fake.invitations, "Expected the users without gitlab account to be invited by email")
+
+	require.NoError(t, client.AddUserToTeam(ctx, "100", []string{"invite:only@example.com"}))
+	assert.Equal(t, int(gitlab.DeveloperPermissions), fake.invitations["only@example.com"])
+	assert.Equal(t, 1, fake.bulkAdds, "Expected no bulk add without users holding a gitlab account")
+
+	fake.inviteErrors = map[string]string{"new@example.com": "Invite email has already been taken"}
+	require.NoError(t, client.AddUserToTeam(ctx, "100", []string{"invite:new@example.com"}),
+		"Expected the users already invited to be skipped")
+
+	fake.inviteErrors = map[string]string{"bad@example.com": "Invite email is invalid"}
+	assert.ErrorContains(t, client.AddUserToTeam(ctx, "100", []string{"invite:bad@example.com"}),
+		"failed to invite user bad@example.com to team 100: Invite email is invalid")
+}
+
+func TestRemoveUserFromTeam_Invitations(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{1: int(gitlab.DeveloperPermissions)})
+	fake.invitations["new@example.com"] = int(gitlab.DeveloperPermissions)
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "100",
+		[]string{"invite:new@example.com", "invite:gone@example.com", "1"}))
+	assert.Empty(t, fake.invitations, "Expected the invitation to be revoked, the missing one skipped")
+	assert.Empty(t, fake.members)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// graphQLMembersPageSize is the number of members requested per GraphQL page, the largest page
+// of the API
+const graphQLMembersPageSize = 100
+
+// groupMembersQuery lists a page of the direct, inherited and invited members of a group, like the
+// members/all REST endpoint
+const groupMembersQuery = `query {
+  group(fullPath: %s) {
+    groupMembers(first: %d, after: %s, relations: [DIRECT, INHERITED, SHARED_FROM_GROUPS]) {
+      nodes {
+        accessLevel { integerValue }
+        user { id username publicEmail }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// groupMembersResponse is a page of members of groupMembersQuery
+type groupMembersResponse struct {
+	Data struct {
+		Group *struct {
+			GroupMembers struct {
+				Nodes []struct {
+					AccessLevel struct {
+						IntegerValue int `json:"integerValue"`
+					} `json:"accessLevel"`
+					User *struct {
+						ID          string `json:"id"`
+						Username    string `json:"username"`
+						PublicEmail string `json:"publicEmail"`
+					} `json:"user"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"groupMembers"`
+		} `json:"group"`
+	} `json:"data"`
+	gitlab.GenericGraphQLErrors
+}
+
+// fetchGroupMembersGraphQL returns the members of the group by their ID, listed through the GraphQL
+// API 100 members per request
+func (g *GitlabClient) fetchGroupMembersGraphQL(ctx context.Context,
+	groupFullPath string) (map[string]*structs.User, error) {
+	members := make(map[string]*structs.User)
+	after := "null"
+	for {
+		query := fmt.Sprintf(groupMembersQuery, strconv.Quote(groupFullPath), graphQLMembersPageSize, after)
+		response := &groupMembersResponse{}
+		if _, err := g.gitlabClient.GraphQL.Do(gitlab.GraphQLQuery{Query: query}, response,
+			gitlab.WithContext(ctx)); err != nil {
+			return nil, err
+		}
+		// GraphQL reports the errors of a query with a 200 status
+		if len(response.Errors) > 0 {
+			return nil, &gitlab.GraphQLResponseError{
+				Err:    errors.New("gitlab group members query failed"),
+				Errors: response.GenericGraphQLErrors,
+			}
+		}
+		if response.Data.Group == nil {
+			return nil, fmt.Errorf("group %s not found in gitlab graphql api", groupFullPath)
+		}
+
+		page := response.Data.Group.GroupMembers
+		for _, node := range page.Nodes {
+			if node.User == nil {
+				continue
+			}
+			// User IDs are global IDs, e.g. gid://gitlab/User/123
+			userID := node.User.ID[strings.LastIndex(node.User.ID, "/")+1:]
+			members[userID] = &structs.User{
+				ID:       userID,
+				Email:    node.User.PublicEmail,
+				UserName: node.User.Username,
+				Role:     accessLevelName(gitlab.AccessLevelValue(node.AccessLevel.IntegerValue)),
+			}
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return members, nil
+		}
+		after = strconv.Quote(page.PageInfo.EndCursor)
+	}
+}
+
+// fetchGroupMembersREST returns the members of the group by their ID, listed through the REST API
+// 100 members per request
+func (g *GitlabClient) fetchGroupMembersREST(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	opt := &gitlab.ListGroupMembersOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+			Page:    1,
+		},
+	}
+
+	members := make(map[string]*structs.User)
+	for {
+		page, resp, err := g.gitlabClient.Groups.ListAllGroupMembers(teamID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			members[fmt.Sprintf("%d", m.ID)] = &structs.User{
+				ID:       fmt.Sprintf("%d", m.ID),
+				Email:    m.PublicEmail,
+				UserName: m.Username,
+				Role:     accessLevelName(m.AccessLevel),
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return members, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// fetchGroupMembers returns the members of the group by their ID, through the GraphQL API. The REST
+// API is used when the GraphQL query fails, e.g. on instances where it is disabled.
+func (g *GitlabClient) fetchGroupMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+	})
+
+	group, _, err := g.gitlabClient.Groups.GetGroup(teamID, &gitlab.GetGroupOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	members, err := g.fetchGroupMembersGraphQL(ctx, group.FullPath)
+	if err == nil {
+		return members, nil
+	}
+	log.WithError(err).Warn("failed to fetch the group members with graphql, falling back to the rest api")
+	return g.fetchGroupMembersREST(ctx, teamID)
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+var (
+	graphQLFullPath = regexp.MustCompile(`group\(fullPath: ("[^"]*")\)`)
+	graphQLAfter    = regexp.MustCompile(`after: (null|"[^"]*")`)
+)
+
+// fakeGroupMembers is a gitlab group listing its members through the GraphQL API and the REST API,
+// pageSize members per page, along with its direct members and its pending invitations
+type fakeGroupMembers struct {
+	groupID       string
+	fullPath      string
+	members       []gitlab.GroupMember
+	directMembers []gitlab.GroupMember
+	pageSize      int
+	invites       []gitlab.PendingInvite
+	// graphQLErrors are the errors the GraphQL API answers the queries with, with a 200 status
+	graphQLErrors []string
+
+	// cursors are the after arguments of the GraphQL queries
+	cursors      []string
+	restRequests int
+}
+
+func (f *fakeGroupMembers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	groupPath := "/api/v4/groups/" + f.groupID
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == gitlab.GraphQLAPIEndpoint:
+		f.query(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == groupPath:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 100, "full_path": f.fullPath})
+	case r.Method == http.MethodGet && r.URL.Path == groupPath+"/members/all":
+		f.listMembers(w, r, f.members)
+	case r.Method == http.MethodGet && r.URL.Path == groupPath+"/members":
+		f.listMembers(w, r, f.directMembers)
+	case r.Method == http.MethodGet && r.URL.Path == groupPath+"/invitations":
+		_ = json.NewEncoder(w).Encode(f.invites)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// listMembers answers a REST request with its page of the members
+func (f *fakeGroupMembers) listMembers(w http.ResponseWriter, r *http.Request, members []gitlab.GroupMember) {
+	f.restRequests++
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	start := min((page-1)*f.pageSize, len(members))
+	end := min(start+f.pageSize, len(members))
+	if end < len(members) {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	_ = json.NewEncoder(w).Encode(members[start:end])
+}
+
+// query answers a group members query with the page of members following its cursor. Each page also
+// lists a member without a user, as GraphQL lists the members whose account was removed.
+func (f *fakeGroupMembers) query(w http.ResponseWriter, r *http.Request) {
+	var body gitlab.GraphQLQuery
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	if len(f.graphQLErrors) > 0 {
+		errs := make([]map[string]string, 0, len(f.graphQLErrors))
+		for _, message := range f.graphQLErrors {
+			errs = append(errs, map[string]string{"message": message})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": nil, "errors": errs})
+		return
+	}
+
+	if match := graphQLFullPath.FindStringSubmatch(body.Query); match == nil || match[1] != strconv.Quote(f.fullPath) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"group": nil}})
+		return
+	}
+	after := graphQLAfter.FindStringSubmatch(body.Query)[1]
+	f.cursors = append(f.cursors, after)
+	start := 0
+	if cursor, err := strconv.Unquote(after); err == nil {
+		start, _ = strconv.Atoi(strings.TrimPrefix(cursor, "cursor-"))
+	}
+	end := min(start+f.pageSize, len(f.members))
+
+	nodes := []map[string]interface{}{{"accessLevel": map[string]int{"integerValue": 10}, "user": nil}}
+	for _, member := range f.members[start:end] {
+		nodes = append(nodes, map[string]interface{}{
+			"accessLevel": map[string]int{"integerValue": int(member.AccessLevel)},
+			"user": map[string]string{
+				"id":          fmt.Sprintf("gid://gitlab/User/%d", member.ID),
+				"username":    member.Username,
+				"publicEmail": member.PublicEmail,
+			},
+		})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+		"group": map[string]interface{}{"groupMembers": map[string]interface{}{
+			"nodes": nodes,
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": end < len(f.members),
+				"endCursor":   fmt.Sprintf("cursor-%d", end),
+			},
+		}},
+	}})
+}
+
+func newFakeGroupMembers() *fakeGroupMembers {
+	return &fakeGroupMembers{
+		groupID:  "100",
+		fullPath: "org/team",
+		pageSize: 2,
+		members: []gitlab.GroupMember{
+			{ID: 1, Username: "alice", PublicEmail: "alice@example.com", AccessLevel: gitlab.OwnerPermissions},
+			{ID: 2, Username: "bob", AccessLevel: gitlab.DeveloperPermissions},
+			{ID: 3, Username: "carol", PublicEmail: "carol@example.com", AccessLevel: gitlab.ReporterPermissions},
+		},
+		invites: []gitlab.PendingInvite{{InviteEmail: "dave@example.com", AccessLevel: gitlab.GuestPermissions}},
+	}
+}
+
+var fakeGroupMembersUsers = map[string]*structs.User{
+	"1": {ID: "1", Email: "alice@example.com", UserName: "alice", Role: "owner"},
+	"2": {ID: "2", UserName: "bob", Role: "developer"},
+	"3": {ID: "3", Email: "carol@example.com", UserName: "carol", Role: "reporter"},
+}
+
+func TestFetchTeamMembersByTeamID(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMembers()
+	client := newTestClient(t, fake)
+
+	members, err := client.FetchTeamMembersByTeamID(ctx, "100")
+	require.NoError(t, err)
+	expected := map[string]*structs.User{
+		"invite:dave@example.com": {ID: "invite:dave@example.com", Email: "dave@example.com", Role: "guest"},
+	}
+	for userID, user := range fakeGroupMembersUsers {
+		expected[userID] = user
+	}
+	assert.Equal(t, expected, members)
+	assert.Equal(t, []string{"null", `"cursor-2"`}, fake.cursors,
+		"Expected the second page to be fetched from the end cursor of the first one")
+	assert.Zero(t, fake.restRequests, "Expected the members to be fetched through GraphQL only")
+}
+
+func TestFetchTeamMembersByTeamID_LDAPSync(t *testing.T) {
+	fake := newFakeGroupMembers()
+	client := newTestClient(t, fake)
+	client.ldapSync = true
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, fakeGroupMembersUsers, members,
+		"Expected the pending invitations not to be listed for the teams synced through LDAP")
+}
+
+func TestFetchTeamMembersByTeamID_RESTFallback(t *testing.T) {
+	fake := newFakeGroupMembers()
+	fake.graphQLErrors = []string{"Field 'groupMembers' doesn't exist on type 'Group'"}
+	client := newTestClient(t, fake)
+	client.ldapSync = true
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, fakeGroupMembersUsers, members)
+	assert.Equal(t, 2, fake.restRequests, "Expected the REST API to be paged 2 members at a time")
+}
+
+func TestFetchGroupMembersGraphQL_Errors(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMembers()
+	client := newTestClient(t, fake)
+
+	_, err := client.fetchGroupMembersGraphQL(ctx, "org/missing")
+	assert.EqualError(t, err, "group org/missing not found in gitlab graphql api")
+
+	fake.graphQLErrors = []string{"Internal server error"}
+	_, err = client.fetchGroupMembersGraphQL(ctx, "org/team")
+	var graphQLErr *gitlab.GraphQLResponseError
+	require.True(t, errors.As(err, &graphQLErr), "Expected a GraphQL response error, got %v", err)
+	assert.Equal(t, "Internal server error", graphQLErr.Errors.Errors[0].Message)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// FetchNestedTeams returns the IDs of the groups the team is shared with
+func (g *GitlabClient) FetchNestedTeams(ctx context.Context, teamID string) ([]string, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+	})
+	log.Info("fetching nested teams")
+
+	group, _, err := g.gitlabClient.Groups.GetGroup(teamID, &gitlab.GetGroupOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nestedTeamIDs := make([]string, 0, len(group.SharedWithGroups))
+	for _, shared := range group.SharedWithGroups {
+		nestedTeamIDs = append(nestedTeamIDs, strconv.Itoa(shared.GroupID))
+	}
+	return nestedTeamIDs, nil
+}
+
+// AddNestedTeams shares the team with the nested teams, their members get the developer access level
+func (g *GitlabClient) AddNestedTeams(ctx context.Context, teamID string, nestedTeamIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":       "gitlab",
+		"teamID":        teamID,
+		"nestedTeamIDs": nestedTeamIDs,
+	})
+	log.Info("adding nested teams")
+
+	if g.ldapSync || len(nestedTeamIDs) == 0 {
+		return nil
+	}
+
+	for _, nestedTeamID := range nestedTeamIDs {
+		nestedTeamIDInt, convErr := strconv.Atoi(nestedTeamID)
+		if convErr != nil {
+			return convErr
+		}
+		shareOpts := &gitlab.ShareGroupWithGroupOptions{
+			GroupID:     &nestedTeamIDInt,
+			GroupAccess: gitlab.Ptr(gitlab.DeveloperPermissions),
+		}
+		_, resp, err := g.gitlabClient.Groups.ShareGroupWithGroup(teamID, shareOpts)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusCreated {
+			return fmt.Errorf("failed to share team %s with team %s, status: %s", teamID, nestedTeamID, resp.Status)
+		}
+	}
+	return nil
+}
+
+// RemoveNestedTeams stops sharing the team with the nested teams
+func (g *GitlabClient) RemoveNestedTeams(ctx context.Context, teamID string, nestedTeamIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":       "gitlab",
+		"teamID":        teamID,
+		"nestedTeamIDs": nestedTeamIDs,
+	})
+	log.Info("removing nested teams")
+
+	if g.ldapSync || len(nestedTeamIDs) == 0 {
+		return nil
+	}
+
+	for _, nestedTeamID := range nestedTeamIDs {
+		nestedTeamIDInt, convErr := strconv.Atoi(nestedTeamID)
+		if convErr != nil {
+			return convErr
+		}
+		resp, err := g.gitlabClient.Groups.UnshareGroupFromGroup(teamID, nestedTeamIDInt)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusNoContent {
+			return fmt.Errorf("failed to unshare team %s from team %s, status: %s", teamID, nestedTeamID, resp.Status)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// fakeGroupShares is a gitlab group shared with other groups, holding the access level of each share
+type fakeGroupShares struct {
+	groupID string
+	shares  map[int]int
+}
+
+func (f *fakeGroupShares) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	groupPath := "/api/v4/groups/" + f.groupID
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == groupPath:
+		shared := make([]map[string]int, 0, len(f.shares))
+		for groupID, access := range f.shares {
+			shared = append(shared, map[string]int{"group_id": groupID, "group_access_level": access})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 100, "shared_with_groups": shared})
+	case r.Method == http.MethodPost && r.URL.Path == groupPath+"/share":
+		var share struct {
+			GroupID     int `json:"group_id"`
+			GroupAccess int `json:"group_access"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&share); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.shares[share.GroupID] = share.GroupAccess
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id": %s}`, f.groupID)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, groupPath+"/share/"):
+		groupID, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, groupPath+"/share/"))
+		if _, shared := f.shares[groupID]; err != nil || !shared {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.shares, groupID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *GitlabClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4"), gitlab.WithoutRetries())
+	require.NoError(t, err)
+	return &GitlabClient{gitlabClient: client, gitlabConfig: &GitlabConfig{URL: server.URL + "/api/v4"}}
+}
+
+func TestNestedTeams(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGroupShares{groupID: "100", shares: map[int]int{300: int(gitlab.ReporterPermissions)}}
+	client := newTestClient(t, fake)
+
+	nested, err := client.FetchNestedTeams(ctx, "100")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"300"}, nested)
+
+	require.NoError(t, client.AddNestedTeams(ctx, "100", []string{"200"}))
+	assert.Equal(t, map[int]int{200: int(gitlab.DeveloperPermissions), 300: int(gitlab.ReporterPermissions)},
+		fake.shares)
+
+	require.NoError(t, client.RemoveNestedTeams(ctx, "100", []string{"200"}))
+	assert.Equal(t, map[int]int{300: int(gitlab.ReporterPermissions)}, fake.shares,
+		"Expected only the nested team being removed to be unshared")
+
+	assert.Error(t, client.RemoveNestedTeams(ctx, "100", []string{"200"}))
+	assert.Error(t, client.AddNestedTeams(ctx, "100", []string{"not-an-id"}))
+}
+
+func TestNestedTeams_LDAPSync(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGroupShares{groupID: "100", shares: map[int]int{300: int(gitlab.DeveloperPermissions)}}
+	client := newTestClient(t, fake)
+	client.ldapSync = true
+
+	require.NoError(t, client.AddNestedTeams(ctx, "100", []string{"200"}))
+	require.NoError(t, client.RemoveNestedTeams(ctx, "100", []string{"300"}))
+	assert.Equal(t, map[int]int{300: int(gitlab.DeveloperPermissions)}, fake.shares,
+		"Expected the teams synced through LDAP to keep their shares")
+}
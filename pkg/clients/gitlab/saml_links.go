@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// addSamlLink links the group to the SAML group of its dependency, at the access level of the group
+func (g *GitlabClient) addSamlLink(ctx context.Context, groupID int) (string, int, error) {
+	accessLevel := g.groupAccess()
+	samlLink, response, err := g.gitlabClient.Groups.AddGroupSAMLLink(groupID, &gitlab.AddGroupSAMLLinkOptions{
+		SAMLGroupName: &g.cn,
+		AccessLevel:   &accessLevel,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		return "", statusCode, err
+	}
+	return samlLink.Name, response.StatusCode, nil
+}
+
+// updateSamlLinkAccess sets the access level of the SAML link of the group, the link is replaced
+// when its access level differs. The members get it when they next sign in.
+func (g *GitlabClient) updateSamlLinkAccess(ctx context.Context, groupID int) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"groupID": groupID,
+		"cn":      g.cn,
+	})
+
+	links, _, err := g.gitlabClient.Groups.ListGroupSAMLLinks(groupID, gitlab.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to list the SAML links of group %d: %w", groupID, err)
+	}
+	for _, link := range links {
+		if link.Name != g.cn {
+			continue
+		}
+		if link.AccessLevel == g.groupAccess() {
+			return nil
+		}
+		if _, err := g.gitlabClient.Groups.DeleteGroupSAMLLink(groupID, g.cn, gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to delete the SAML link %s of group %d: %w", g.cn, groupID, err)
+		}
+		break
+	}
+
+	if _, statusCode, err := g.addSamlLink(ctx, groupID); err != nil {
+		return fmt.Errorf("failed to add group SAML link: %v, status code: %d", err, statusCode)
+	}
+	log.WithField("accessLevel", accessLevelName(g.groupAccess())).Info("saml link access level updated")
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// fakeGroupSAMLLinks is a gitlab group holding the access level of each of its SAML links by SAML
+// group name
+type fakeGroupSAMLLinks struct {
+	links map[string]int
+
+	changes int
+}
+
+func (f *fakeGroupSAMLLinks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	linksPath := "/api/v4/groups/100/saml_group_links"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/groups/100":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 100, "name": "team"})
+	case r.Method == http.MethodGet && r.URL.Path == linksPath:
+		links := make([]gitlab.SAMLGroupLink, 0, len(f.links))
+		for name, access := range f.links {
+			links = append(links, gitlab.SAMLGroupLink{Name: name, AccessLevel: gitlab.AccessLevelValue(access)})
+		}
+		_ = json.NewEncoder(w).Encode(links)
+	case r.Method == http.MethodPost && r.URL.Path == linksPath:
+		var link struct {
+			SAMLGroupName string `json:"saml_group_name"`
+			AccessLevel   int    `json:"access_level"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&link)
+		f.changes++
+		f.links[link.SAMLGroupName] = link.AccessLevel
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"name": link.SAMLGroupName, "access_level": link.AccessLevel,
+		})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, linksPath+"/"):
+		f.changes++
+		delete(f.links, strings.TrimPrefix(r.URL.Path, linksPath+"/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestReconcileGroupParams_SAMLLink(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGroupSAMLLinks{links: map[string]int{
+		"team": int(gitlab.DeveloperPermissions), "other": int(gitlab.GuestPermissions),
+	}}
+	client := newTestClient(t, fake)
+	client.gitlabConfig.GroupLink = GroupLinkSAML
+	client.SetLdapSync(true, "team")
+
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamGroupAccessLevel,
+		Value:    []string{"maintainer"},
+	}))
+	assert.Equal(t, map[string]int{
+		"team": int(gitlab.MaintainerPermissions), "other": int(gitlab.GuestPermissions),
+	}, fake.links, "Expected only the SAML link of the team to be replaced")
+
+	changes := fake.changes
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamGroupAccessLevel,
+		Value:    []string{"Maintainer"},
+	}))
+	assert.Equal(t, changes, fake.changes, "Expected the SAML link at the access level to be left as is")
+
+	delete(fake.links, "team")
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamGroupAccessLevel,
+		Value:    []string{"reporter"},
+	}))
+	assert.Equal(t, int(gitlab.ReporterPermissions), fake.links["team"], "Expected the missing SAML link to be added")
+}
+
+func TestNewClientGroupLink(t *testing.T) {
+	connection := func(groupLink string) map[string]interface{} {
+		return map[string]interface{}{"url": "https://gitlab.example.com", "token": "token", "group_link": groupLink}
+	}
+
+	for groupLink, expected := range map[string]string{"": GroupLinkLDAP, "ldap": GroupLinkLDAP, "saml": GroupLinkSAML} {
+		client, err := NewClient(connection(groupLink), config.Dependant{}, fakehttp.ConnectionPoolConfig(t),
+			fakehttp.HystrixResiliencyConfig())
+		require.NoError(t, err)
+		assert.Equal(t, expected, client.gitlabConfig.GroupLink)
+	}
+
+	_, err := NewClient(connection("oidc"), config.Dependant{}, fakehttp.ConnectionPoolConfig(t),
+		fakehttp.HystrixResiliencyConfig())
+	assert.EqualError(t, err, "unsupported gitlab group_link \"oidc\", supported: ldap, saml")
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
@@ -35,49 +36,126 @@ func (g *GitlabClient) FetchTeamMembersByTeamID(ctx context.Context, teamID stri
 	})
 	log.Info("fetching team members by team ID")
 
-	teamMembers := make(map[string]*structs.User)
-	members, _, err := g.gitlabClient.Groups.ListAllGroupMembers(teamID, nil)
+	teamMembers, err := g.fetchGroupMembers(ctx, teamID)
 	if err != nil {
 		return nil, err
 	}
-	for _, m := range members {
-		teamMembers[fmt.Sprintf("%d", m.ID)] = &structs.User{
-			ID:       fmt.Sprintf("%d", m.ID),
-			Email:    m.PublicEmail,
-			UserName: m.Username,
+
+	// The users invited by email are members once they sign in, they are listed meanwhile so that
+	// they are not invited again
+	if !g.ldapSync {
+		invited, err := g.fetchPendingInvitations(ctx, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the pending invitations of team %s: %w", teamID, err)
+		}
+		for userID, user := range invited {
+			teamMembers[userID] = user
 		}
 	}
 	return teamMembers, nil
 }
 
 func (g *GitlabClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return g.addUsersToTeam(ctx, teamID, userIDs, gitlab.DeveloperPermissions)
+}
+
+// AddUserToTeamWithRole adds the users to the team with the access level named by role
+func (g *GitlabClient) AddUserToTeamWithRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	accessLevel, err := accessLevelFromRole(role)
+	if err != nil {
+		return err
+	}
+	return g.addUsersToTeam(ctx, teamID, userIDs, accessLevel)
+}
+
+func (g *GitlabClient) addUsersToTeam(ctx context.Context, teamID string, userIDs []string,
+	accessLevel gitlab.AccessLevelValue) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "gitlab",
+		"teamID":      teamID,
+		"userIDs":     userIDs,
+		"accessLevel": accessLevel,
+	})
+	log.Info("adding users to team")
+
+	if g.ldapSync || len(userIDs) == 0 {
+		return nil
+	}
+
+	userIDs, emails := splitInvitedUsers(userIDs)
+	if err := g.inviteUsersToTeam(ctx, teamID, emails, accessLevel); err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	for _, userID := range userIDs {
+		if _, convErr := strconv.Atoi(userID); convErr != nil {
+			return convErr
+		}
+	}
+
+	// The members API adds all the users of a comma separated user_id in a single request
+	req, err := g.gitlabClient.NewRequest(http.MethodPost,
+		fmt.Sprintf("groups/%s/members", gitlab.PathEscape(teamID)),
+		&bulkAddGroupMembersOptions{UserID: strings.Join(userIDs, ","), AccessLevel: &accessLevel}, nil)
+	if err != nil {
+		return err
+	}
+	result := &bulkAddGroupMembersResult{}
+	resp, err := g.gitlabClient.Do(req, result)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to add users %v to team %s, status: %s", userIDs, teamID, resp.Status)
+	}
+	// Users which could not be added are reported in the body with a created status
+	if result.Status == "error" {
+		return fmt.Errorf("failed to add users to team %s: %s", teamID, string(result.Message))
+	}
+	return nil
+}
+
+// ValidMemberRole reports whether the role names a gitlab access level
+func (g *GitlabClient) ValidMemberRole(role string) bool {
+	_, err := accessLevelFromRole(role)
+	return err == nil
+}
+
+// UpdateTeamMemberRole changes the access level of existing team members to the one named by role
+func (g *GitlabClient) UpdateTeamMemberRole(ctx context.Context, teamID string, userIDs []string, role string) error {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
 		"service": "gitlab",
 		"teamID":  teamID,
 		"userIDs": userIDs,
+		"role":    role,
 	})
-	log.Info("adding users to team")
+	log.Info("updating role of team members")
 
 	if g.ldapSync || len(userIDs) == 0 {
 		return nil
 	}
 
-	accessLevel := gitlab.DeveloperPermissions
+	accessLevel, err := accessLevelFromRole(role)
+	if err != nil {
+		return err
+	}
+	// The invitations keep the access level they were sent with
+	userIDs, _ = splitInvitedUsers(userIDs)
 	for _, userID := range userIDs {
 		userIDInt, convErr := strconv.Atoi(userID)
 		if convErr != nil {
 			return convErr
 		}
-		addMemberOpts := &gitlab.AddGroupMemberOptions{
-			UserID:      &userIDInt,
+		_, resp, err := g.gitlabClient.GroupMembers.EditGroupMember(teamID, userIDInt, &gitlab.EditGroupMemberOptions{
 			AccessLevel: &accessLevel,
-		}
-		_, resp, err := g.gitlabClient.GroupMembers.AddGroupMember(teamID, addMemberOpts)
+		})
 		if err != nil {
 			return err
 		}
-		if resp.StatusCode != http.StatusCreated {
-			return fmt.Errorf("failed to add user %s to team %s, status: %s", userID, teamID, resp.Status)
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to update role of user %s in team %s, status: %s", userID, teamID, resp.Status)
 		}
 	}
 	return nil
@@ -95,12 +173,23 @@ func (g *GitlabClient) RemoveUserFromTeam(ctx context.Context, teamID string, us
 		return nil
 	}
 
+	userIDs, emails := splitInvitedUsers(userIDs)
+	for _, email := range emails {
+		if err := g.deleteInvitation(ctx, teamID, email); err != nil {
+			return fmt.Errorf("failed to revoke the invitation of %s to team %s: %w", email, teamID, err)
+		}
+	}
+	return g.removeGroupMembers(ctx, teamID, userIDs)
+}
+
+// removeGroupMembers removes the members from the group by their ID
+func (g *GitlabClient) removeGroupMembers(ctx context.Context, teamID string, userIDs []string) error {
 	for _, userID := range userIDs {
 		userIDInt, convErr := strconv.Atoi(userID)
 		if convErr != nil {
 			return convErr
 		}
-		resp, err := g.gitlabClient.GroupMembers.RemoveGroupMember(teamID, userIDInt, nil)
+		resp, err := g.gitlabClient.GroupMembers.RemoveGroupMember(teamID, userIDInt, nil, gitlab.WithContext(ctx))
 		if err != nil {
 			return err
 		}
@@ -132,22 +221,37 @@ func (g *GitlabClient) ReconcileGroupParams(ctx context.Context, teamID string,
 		return convErr
 	}
 	switch groupParams.Property {
-	case "project_access_paths":
-		for _, value := range groupParams.Value {
-			statusCode, err := g.addGroupAsProjectDeveloper(teamIDInt, value)
-			if err != nil {
-				if statusCode == http.StatusConflict {
-					log.Infof("group %s already has access to Project Path %s, skipping", team.Name, value)
-					continue
-				}
-				return fmt.Errorf("failed to add group %s as project developer to Project Path %s: %w", team.Name, value, err)
+	case GroupParamGroupAccessLevel:
+		if len(groupParams.Value) != 1 {
+			return fmt.Errorf("gitlab group param %s takes a single access level", GroupParamGroupAccessLevel)
+		}
+		accessLevel, err := accessLevelFromRole(groupParams.Value[0])
+		if err != nil {
+			return err
+		}
+		g.groupAccessLevel = accessLevel
+
+		if g.ldapSync && g.gitlabConfig.GroupLink == GroupLinkSAML {
+			if err := g.updateSamlLinkAccess(ctx, teamIDInt); err != nil {
+				return err
 			}
-			if statusCode != http.StatusCreated {
-				return fmt.Errorf("failed to add group %s as project developer to Project Path %s: unexpected status code %d",
-					team.Name, value, statusCode)
+		} else if g.ldapSync {
+			if err := g.updateLdapLinkAccess(ctx, teamIDInt); err != nil {
+				return err
 			}
-			log.Infof("group %s added as project developer to Project Path %s successfully with status: %d",
-				team.Name, value, statusCode)
+		}
+		for _, projectPath := range g.sharedProjects {
+			if err := g.shareProjectWithGroup(ctx, teamIDInt, projectPath, accessLevel); err != nil {
+				return fmt.Errorf("failed to update the access of group %s: %w", team.Name, err)
+			}
+		}
+	case GroupParamProjectAccessPaths:
+		if err := g.reconcileProjectAccess(ctx, teamIDInt, groupParams.Value); err != nil {
+			return fmt.Errorf("failed to reconcile the projects of group %s: %w", team.Name, err)
+		}
+	case GroupParamCIVariables:
+		if err := g.reconcileCIVariables(ctx, teamIDInt, groupParams.Value); err != nil {
+			return fmt.Errorf("failed to reconcile the ci variables of group %s: %w", team.Name, err)
 		}
 	default:
 		log.WithField("property", groupParams.Property).Warn("unsupported group property for gitlab backend")
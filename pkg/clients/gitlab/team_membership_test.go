@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// fakeGroupMemberships is a gitlab group holding the access level of its members and of its
+// pending invitations by email
+type fakeGroupMemberships struct {
+	groupID     string
+	members     map[int]int
+	invitations map[string]int
+	// addErrors are the errors the bulk add reports for users, with a created status
+	addErrors map[string]string
+	// inviteErrors are the errors the invitations report for emails, with a created status
+	inviteErrors map[string]string
+
+	bulkAdds int
+}
+
+func (f *fakeGroupMemberships) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	membersPath := "/api/v4/groups/" + f.groupID + "/members"
+	invitationsPath := "/api/v4/groups/" + f.groupID + "/invitations"
+	var body struct {
+		UserID      string `json:"user_id"`
+		Email       string `json:"email"`
+		AccessLevel int    `json:"access_level"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == membersPath:
+		f.bulkAdds++
+		f.answerCreated(w, strings.Split(body.UserID, ","), f.addErrors, func(userID string) {
+			id, _ := strconv.Atoi(userID)
+			f.members[id] = body.AccessLevel
+		})
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, membersPath+"/"):
+		userID, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, membersPath+"/"))
+		if _, ok := f.members[userID]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.members[userID] = body.AccessLevel
+		_ = json.NewEncoder(w).Encode(map[string]int{"id": userID, "access_level": body.AccessLevel})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, membersPath+"/"):
+		userID, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, membersPath+"/"))
+		if _, ok := f.members[userID]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.members, userID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && r.URL.Path == invitationsPath:
+		f.answerCreated(w, strings.Split(body.Email, ","), f.inviteErrors, func(email string) {
+			f.invitations[email] = body.AccessLevel
+		})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, invitationsPath+"/"):
+		email := strings.TrimPrefix(r.URL.Path, invitationsPath+"/")
+		if _, ok := f.invitations[email]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.invitations, email)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// answerCreated adds each of the keys without an error, and answers with the errors of the others
+// in the body of a created status, like the bulk members and invitations APIs
+func (f *fakeGroupMemberships) answerCreated(w http.ResponseWriter, keys []string, errs map[string]string,
+	add func(key string)) {
+	messages := map[string]string{}
+	for _, key := range keys {
+		if message, failed := errs[key]; failed {
+			messages[key] = message
+			continue
+		}
+		add(key)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if len(messages) > 0 {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": "error", "message": messages})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func newFakeGroupMemberships(members map[int]int) *fakeGroupMemberships {
+	return &fakeGroupMemberships{groupID: "100", members: members, invitations: map[string]int{}}
+}
+
+func TestAccessLevelFromRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		expected gitlab.AccessLevelValue
+	}{
+		{role: "guest", expected: gitlab.GuestPermissions},
+		{role: "reporter", expected: gitlab.ReporterPermissions},
+		{role: "developer", expected: gitlab.DeveloperPermissions},
+		{role: "maintainer", expected: gitlab.MaintainerPermissions},
+		{role: "owner", expected: gitlab.OwnerPermissions},
+		{role: "Maintainer", expected: gitlab.MaintainerPermissions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			accessLevel, err := accessLevelFromRole(tt.role)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, accessLevel)
+			assert.Equal(t, strings.ToLower(tt.role), accessLevelName(accessLevel))
+		})
+	}
+
+	_, err := accessLevelFromRole("admin")
+	assert.EqualError(t, err, "unsupported gitlab member role: admin")
+	assert.Empty(t, accessLevelName(gitlab.MinimalAccessPermissions))
+
+	client := &GitlabClient{}
+	assert.True(t, client.ValidMemberRole("Owner"))
+	assert.False(t, client.ValidMemberRole("admin"))
+	assert.Equal(t, gitlab.DeveloperPermissions, client.groupAccess(),
+		"Expected the group access level to default to developer")
+}
+
+func TestAddUserToTeamWithRole(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{})
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.AddUserToTeamWithRole(ctx, "100", []string{"1", "2"}, "maintainer"))
+	require.NoError(t, client.AddUserToTeam(ctx, "100", []string{"3"}))
+	assert.Equal(t, map[int]int{
+		1: int(gitlab.MaintainerPermissions),
+		2: int(gitlab.MaintainerPermissions),
+		3: int(gitlab.DeveloperPermissions),
+	}, fake.members)
+	assert.Equal(t, 2, fake.bulkAdds, "Expected the users of each call to be added in a single request")
+
+	assert.Error(t, client.AddUserToTeamWithRole(ctx, "100", []string{"4"}, "admin"))
+	assert.Error(t, client.AddUserToTeam(ctx, "100", []string{"not-an-id"}))
+	assert.Equal(t, 2, fake.bulkAdds, "Expected no request for invalid roles and user IDs")
+
+	fake.addErrors = map[string]string{"5": "Member already exists"}
+	assert.ErrorContains(t, client.AddUserToTeam(ctx, "100", []string{"4", "5"}), "failed to add users to team 100")
+}
+
+func TestUpdateTeamMemberRole(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{
+		1: int(gitlab.DeveloperPermissions),
+		2: int(gitlab.DeveloperPermissions),
+	})
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.UpdateTeamMemberRole(ctx, "100", []string{"1", "invite:new@example.com"}, "owner"))
+	assert.Equal(t, map[int]int{1: int(gitlab.OwnerPermissions), 2: int(gitlab.DeveloperPermissions)}, fake.members,
+		"Expected only the role of the existing member being updated to change")
+
+	assert.Error(t, client.UpdateTeamMemberRole(ctx, "100", []string{"2"}, "admin"))
+	assert.Error(t, client.UpdateTeamMemberRole(ctx, "100", []string{"9"}, "reporter"))
+}
+
+func TestRemoveUserFromTeam(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{
+		1: int(gitlab.DeveloperPermissions),
+		2: int(gitlab.DeveloperPermissions),
+	})
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "100", []string{"1"}))
+	assert.Equal(t, map[int]int{2: int(gitlab.DeveloperPermissions)}, fake.members)
+
+	assert.Error(t, client.RemoveUserFromTeam(ctx, "100", []string{"1"}))
+	assert.Error(t, client.RemoveUserFromTeam(ctx, "100", []string{"not-an-id"}))
+}
+
+func TestTeamMembership_LDAPSync(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGroupMemberships(map[int]int{1: int(gitlab.DeveloperPermissions)})
+	client := newTestClient(t, fake)
+	client.ldapSync = true
+
+	require.NoError(t, client.AddUserToTeamWithRole(ctx, "100", []string{"2"}, "owner"))
+	require.NoError(t, client.UpdateTeamMemberRole(ctx, "100", []string{"1"}, "owner"))
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "100", []string{"1"}))
+	assert.Equal(t, map[int]int{1: int(gitlab.DeveloperPermissions)}, fake.members,
+		"Expected the members of the teams synced through LDAP to be left to GitLab")
+}
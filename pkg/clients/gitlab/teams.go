@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
@@ -33,6 +35,22 @@ func (g *GitlabClient) FetchAllTeams(ctx context.Context) (map[string]structs.Te
 	log.Info("fetching all teams")
 
 	teams := make(map[string]structs.Team)
+	err := g.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the subgroups of the parent group, 100 groups at a time
+func (g *GitlabClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
 	opt := &gitlab.ListSubGroupsOptions{
 		ListOptions: gitlab.ListOptions{
 			PerPage: 100,
@@ -41,26 +59,27 @@ func (g *GitlabClient) FetchAllTeams(ctx context.Context) (map[string]structs.Te
 	}
 
 	for {
-		groups, resp, err := g.gitlabClient.Groups.ListSubGroups(g.gitlabConfig.ParentGroupId, opt)
+		groups, resp, err := g.gitlabClient.Groups.ListSubGroups(g.gitlabConfig.ParentGroupId, opt, gitlab.WithContext(ctx))
 		if err != nil {
-			return nil, err
+			return err
 		}
 
+		page := make([]structs.Team, 0, len(groups))
 		for _, group := range groups {
-			teams[group.Name] = structs.Team{
+			page = append(page, structs.Team{
 				ID:   fmt.Sprintf("%d", group.ID),
 				Name: group.Name,
-			}
+			})
+		}
+		if err := fn(page); err != nil {
+			return err
 		}
 
 		if resp.NextPage == 0 {
-			break
+			return nil
 		}
 		opt.Page = resp.NextPage
 	}
-
-	log.WithField("total_teams_count", len(teams)).Info("found teams")
-	return teams, nil
 }
 
 func (g *GitlabClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
@@ -87,24 +106,42 @@ func (g *GitlabClient) CreateTeam(ctx context.Context, team *structs.Team) (*str
 	})
 	log.Info("creating team")
 
-	groupName := team.Name
-	visibility := gitlab.PublicVisibility
-	createGroupOptions := &gitlab.CreateGroupOptions{
-		ParentID:   &g.gitlabConfig.ParentGroupId,
-		Name:       &groupName,
-		Path:       &groupName,
-		Visibility: &visibility,
-	}
-	group, response, err := g.gitlabClient.Groups.CreateGroup(createGroupOptions)
-	if err != nil {
-		if response.StatusCode == http.StatusConflict || response.StatusCode == http.StatusBadRequest {
-			log.Infof("team %s already exists, fetching team details", group.Name)
-		} else {
-			return nil, fmt.Errorf("failed to create team: %v, status code: %d", err, response.StatusCode)
+	var group *gitlab.Group
+	if strings.Contains(team.Name, "/") {
+		// Nested team names create the subgroup hierarchy of their path under the parent group
+		var err error
+		if group, err = g.createNestedGroup(ctx, team.Name); err != nil {
+			return nil, err
+		}
+	} else {
+		groupName := team.Name
+		visibility := gitlab.PublicVisibility
+		createGroupOptions := &gitlab.CreateGroupOptions{
+			ParentID:   &g.gitlabConfig.ParentGroupId,
+			Name:       &groupName,
+			Path:       &groupName,
+			Visibility: &visibility,
+		}
+		var response *gitlab.Response
+		var err error
+		group, response, err = g.gitlabClient.Groups.CreateGroup(createGroupOptions)
+		if err != nil {
+			if response.StatusCode == http.StatusConflict || response.StatusCode == http.StatusBadRequest {
+				log.Infof("team %s already exists, fetching team details", group.Name)
+			} else {
+				return nil, fmt.Errorf("failed to create team: %v, status code: %d", err, response.StatusCode)
+			}
 		}
 	}
 
-	if g.ldapSync {
+	if g.ldapSync && g.gitlabConfig.GroupLink == GroupLinkSAML {
+		// Add group SAML link, the members are synced when they sign in
+		samlLink, statusCode, err := g.addSamlLink(ctx, group.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add group SAML link: %v, status code: %d", err, statusCode)
+		}
+		log.Infof("saml link %s added successfully with status: %d", samlLink, statusCode)
+	} else if g.ldapSync {
 		// Add group to LDAP
 		ldapLink, statusCode, err := g.addToLdapGroup(group.ID)
 		if err != nil {
@@ -122,10 +159,81 @@ func (g *GitlabClient) CreateTeam(ctx context.Context, team *structs.Team) (*str
 
 	return &structs.Team{
 		ID:   fmt.Sprintf("%d", group.ID),
-		Name: group.Name,
+		Name: team.Name,
 	}, nil
 }
 
+// createNestedGroup creates the subgroup of a team name holding slashes, e.g. org/data/analysts,
+// with each segment of the name a subgroup of the previous one under the parent group. The
+// intermediate subgroups, and the subgroup itself, are reused when they already exist.
+func (g *GitlabClient) createNestedGroup(ctx context.Context, teamName string) (*gitlab.Group, error) {
+	segments := strings.Split(teamName, "/")
+	if slices.Contains(segments, "") {
+		return nil, fmt.Errorf("invalid nested team name %q, it must not hold empty segments", teamName)
+	}
+
+	parentID := g.gitlabConfig.ParentGroupId
+	var group *gitlab.Group
+	for _, segment := range segments {
+		var err error
+		if group, err = g.ensureSubgroup(ctx, parentID, segment); err != nil {
+			return nil, err
+		}
+		parentID = group.ID
+	}
+	return group, nil
+}
+
+// ensureSubgroup returns the subgroup of the parent group with the path, creating it when missing
+func (g *GitlabClient) ensureSubgroup(ctx context.Context, parentID int, path string) (*gitlab.Group, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":  "gitlab",
+		"parentID": parentID,
+		"path":     path,
+	})
+
+	opt := &gitlab.ListSubGroupsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+			Page:    1,
+		},
+		Search: &path,
+	}
+	for {
+		groups, resp, err := g.gitlabClient.Groups.ListSubGroups(parentID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the subgroups of group %d: %w", parentID, err)
+		}
+		for _, group := range groups {
+			if strings.EqualFold(group.Path, path) {
+				return group, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	visibility := gitlab.PublicVisibility
+	group, response, err := g.gitlabClient.Groups.CreateGroup(&gitlab.CreateGroupOptions{
+		ParentID:   &parentID,
+		Name:       &path,
+		Path:       &path,
+		Visibility: &visibility,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		return nil, fmt.Errorf("failed to create subgroup %s of group %d: %v, status code: %d", path, parentID, err,
+			statusCode)
+	}
+	log.WithField("groupID", group.ID).Info("subgroup created")
+	return group, nil
+}
+
 func (g *GitlabClient) DeleteTeamByID(ctx context.Context, teamID string) error {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
 		"service": "gitlab",
@@ -136,10 +244,19 @@ func (g *GitlabClient) DeleteTeamByID(ctx context.Context, teamID string) error
 	// 1. Initiate Soft Delete
 	resp, err := g.gitlabClient.Groups.DeleteGroup(teamID, &gitlab.DeleteGroupOptions{})
 	if err != nil {
+		if g.gitlabConfig.SoftDeleteOnly && g.markedForDeletion(ctx, teamID) {
+			log.Infof("team %v already marked for deletion", teamID)
+			return nil
+		}
 		return fmt.Errorf("failed to initiate soft delete: %w", err)
 	}
 	log.Infof("team %v soft-deleted with status: %s", teamID, resp.Status)
 
+	if g.gitlabConfig.SoftDeleteOnly {
+		log.Infof("soft delete only, team %v can be restored until its deletion", teamID)
+		return nil
+	}
+
 	// 2. Poll until pending deletion status is confirmed
 	groupFullPath, err := g.pollForPendingDeletion(ctx, teamID, 5, 5*time.Second)
 	if err != nil {
@@ -160,8 +277,14 @@ func (g *GitlabClient) DeleteTeamByID(ctx context.Context, teamID string) error
 	return nil
 }
 
+// markedForDeletion reports whether the group is marked for deletion
+func (g *GitlabClient) markedForDeletion(ctx context.Context, teamID string) bool {
+	group, _, err := g.gitlabClient.Groups.GetGroup(teamID, &gitlab.GetGroupOptions{}, gitlab.WithContext(ctx))
+	return err == nil && group.MarkedForDeletionOn != nil
+}
+
 func (g *GitlabClient) addToLdapGroup(groupID int) (string, int, error) {
-	accessLevel := gitlab.DeveloperPermissions
+	accessLevel := g.groupAccess()
 	ldapLink, response, err := g.gitlabClient.Groups.AddGroupLDAPLink(groupID, &gitlab.AddGroupLDAPLinkOptions{
 		GroupAccess: &accessLevel,
 		CN:          &g.cn,
@@ -216,16 +339,199 @@ func (g *GitlabClient) pollForPendingDeletion(ctx context.Context,
 	return "", fmt.Errorf("timeout: Group %v was not marked for deletion after %d attempts", teamID, maxAttempts)
 }
 
-func (g *GitlabClient) addGroupAsProjectDeveloper(groupID int, projectPathString string) (int, error) {
-	developerAccess := gitlab.DeveloperPermissions
+// updateLdapLinkAccess sets the access level of the LDAP link of the group, the link is replaced
+// when its access level differs and the LDAP sync is initiated to apply it to the members
+func (g *GitlabClient) updateLdapLinkAccess(ctx context.Context, groupID int) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"groupID": groupID,
+		"cn":      g.cn,
+	})
+
+	links, _, err := g.gitlabClient.Groups.ListGroupLDAPLinks(groupID, gitlab.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to list the LDAP links of group %d: %w", groupID, err)
+	}
+	for _, link := range links {
+		if link.CN != g.cn || link.Provider != ldapProvider {
+			continue
+		}
+		if link.GroupAccess == g.groupAccess() {
+			return nil
+		}
+		if _, err := g.gitlabClient.Groups.DeleteGroupLDAPLinkForProvider(groupID, ldapProvider, g.cn,
+			gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to delete the LDAP link %s of group %d: %w", g.cn, groupID, err)
+		}
+		break
+	}
+
+	if _, statusCode, err := g.addToLdapGroup(groupID); err != nil {
+		return fmt.Errorf("failed to add group to LDAP: %v, status code: %d", err, statusCode)
+	}
+	log.WithField("accessLevel", accessLevelName(g.groupAccess())).Info("ldap link access level updated")
+
+	if statusCode, err := g.initiateSync(ctx); err != nil {
+		return fmt.Errorf("failed to initiate LDAP sync: %v, status code: %d", err, statusCode)
+	}
+	return nil
+}
+
+// reconcileProjectAccess shares exactly the projects of the project_access_paths group param with
+// the group, each path optionally followed by the access level of the group on the project, e.g.
+// team/project:maintainer, the group_access_level otherwise. The projects no longer listed are
+// unshared from the group.
+func (g *GitlabClient) reconcileProjectAccess(ctx context.Context, groupID int, values []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"groupID": groupID,
+	})
+
+	desired := make(map[string]gitlab.AccessLevelValue, len(values))
+	paths := make(map[string]string, len(values))
+	for _, value := range values {
+		projectPath, role, found := strings.Cut(value, ":")
+		accessLevel := g.groupAccess()
+		if found {
+			var err error
+			if accessLevel, err = accessLevelFromRole(role); err != nil {
+				return err
+			}
+		} else {
+			g.sharedProjects = append(g.sharedProjects, projectPath)
+		}
+		desired[strings.ToLower(projectPath)] = accessLevel
+		paths[strings.ToLower(projectPath)] = projectPath
+	}
+
+	current, err := g.sharedProjectAccess(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	for key, accessLevel := range desired {
+		share, ok := current[key]
+		if ok && share.accessLevel == accessLevel {
+			continue
+		}
+		if ok {
+			log.WithField("projectPath", paths[key]).Info("updating the access level of the group to Project Path")
+			if _, err := g.gitlabClient.Projects.DeleteSharedProjectFromGroup(share.projectID, groupID,
+				gitlab.WithContext(ctx)); err != nil {
+				return fmt.Errorf("failed to unshare Project Path %s from group %d: %w", paths[key], groupID, err)
+			}
+		}
+		if err := g.shareProjectWithGroup(ctx, groupID, paths[key], accessLevel); err != nil {
+			return err
+		}
+	}
+
+	for key, share := range current {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		log.WithField("projectPath", share.path).Info("unsharing Project Path no longer listed from the group")
+		resp, err := g.gitlabClient.Projects.DeleteSharedProjectFromGroup(share.projectID, groupID,
+			gitlab.WithContext(ctx))
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				log.WithField("projectPath", share.path).Warn("project share not found, considering deletion successful")
+				continue
+			}
+			return fmt.Errorf("failed to unshare Project Path %s from group %d: %w", share.path, groupID, err)
+		}
+	}
+	return nil
+}
+
+// projectShare is a project shared with a group
+type projectShare struct {
+	projectID   int
+	path        string
+	accessLevel gitlab.AccessLevelValue
+}
+
+// sharedProjectAccess returns the projects shared with the group, by their lowercase full path
+func (g *GitlabClient) sharedProjectAccess(ctx context.Context, groupID int) (map[string]projectShare, error) {
+	opt := &gitlab.ListGroupSharedProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+			Page:    1,
+		},
+	}
+
+	access := make(map[string]projectShare)
+	for {
+		projects, resp, err := g.gitlabClient.Groups.ListGroupSharedProjects(groupID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the projects shared with group %d: %w", groupID, err)
+		}
+		for _, project := range projects {
+			for _, shared := range project.SharedWithGroups {
+				if shared.GroupID == groupID {
+					access[strings.ToLower(project.PathWithNamespace)] = projectShare{
+						projectID:   project.ID,
+						path:        project.PathWithNamespace,
+						accessLevel: gitlab.AccessLevelValue(shared.GroupAccessLevel),
+					}
+				}
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return access, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// shareProjectWithGroup shares the project with the group at the access level. A project already
+// shared with the group at another access level is unshared and shared again.
+func (g *GitlabClient) shareProjectWithGroup(ctx context.Context, groupID int, projectPath string,
+	accessLevel gitlab.AccessLevelValue) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "gitlab",
+		"groupID":     groupID,
+		"projectPath": projectPath,
+		"accessLevel": accessLevelName(accessLevel),
+	})
+
 	opt := &gitlab.ShareWithGroupOptions{
 		GroupID:     &groupID,
-		GroupAccess: &developerAccess,
+		GroupAccess: &accessLevel,
 	}
-
-	response, err := g.gitlabClient.Projects.ShareProjectWithGroup(projectPathString, opt)
+	response, err := g.gitlabClient.Projects.ShareProjectWithGroup(projectPath, opt, gitlab.WithContext(ctx))
 	if err != nil {
-		return response.StatusCode, err
+		if response == nil || response.StatusCode != http.StatusConflict {
+			return fmt.Errorf("failed to share Project Path %s with group %d: %w", projectPath, groupID, err)
+		}
+
+		project, _, err := g.gitlabClient.Projects.GetProject(projectPath, &gitlab.GetProjectOptions{},
+			gitlab.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to fetch Project Path %s: %w", projectPath, err)
+		}
+		for _, shared := range project.SharedWithGroups {
+			if shared.GroupID == groupID && gitlab.AccessLevelValue(shared.GroupAccessLevel) == accessLevel {
+				log.Info("group already has access to Project Path, skipping")
+				return nil
+			}
+		}
+
+		log.Info("updating the access level of the group to Project Path")
+		if _, err := g.gitlabClient.Projects.DeleteSharedProjectFromGroup(projectPath, groupID,
+			gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to unshare Project Path %s from group %d: %w", projectPath, groupID, err)
+		}
+		response, err = g.gitlabClient.Projects.ShareProjectWithGroup(projectPath, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to share Project Path %s with group %d: %w", projectPath, groupID, err)
+		}
 	}
-	return response.StatusCode, nil
+	if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to share Project Path %s with group %d: unexpected status code %d",
+			projectPath, groupID, response.StatusCode)
+	}
+	log.Infof("group added to Project Path successfully with status: %d", response.StatusCode)
+	return nil
 }
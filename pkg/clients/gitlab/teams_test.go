@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// fakeGroupProjects is a gitlab group holding the access level of the group on each project shared
+// with it, by project path
+type fakeGroupProjects struct {
+	groupID    int
+	projectIDs map[string]int
+	shared     map[string]int
+
+	unshares int
+}
+
+func (f *fakeGroupProjects) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	groupPath := "/api/v4/groups/" + strconv.Itoa(f.groupID)
+	project, projectPath := strings.CutPrefix(r.URL.Path, "/api/v4/projects/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == groupPath:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": f.groupID, "name": "team"})
+	case r.Method == http.MethodGet && r.URL.Path == groupPath+"/projects/shared":
+		projects := make([]map[string]interface{}, 0, len(f.shared))
+		for path := range f.shared {
+			projects = append(projects, f.project(path))
+		}
+		_ = json.NewEncoder(w).Encode(projects)
+	case projectPath && r.Method == http.MethodPost && strings.HasSuffix(project, "/share"):
+		var share struct {
+			GroupID     int `json:"group_id"`
+			GroupAccess int `json:"group_access"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&share)
+		path := strings.TrimSuffix(project, "/share")
+		if _, ok := f.shared[path]; ok {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message": "The group has already been shared with this project"}`))
+			return
+		}
+		f.shared[path] = share.GroupAccess
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"group_id": share.GroupID})
+	case projectPath && r.Method == http.MethodDelete:
+		path := strings.TrimSuffix(project, "/share/"+strconv.Itoa(f.groupID))
+		for name, id := range f.projectIDs {
+			if strconv.Itoa(id) == path {
+				path = name
+			}
+		}
+		if _, ok := f.shared[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.unshares++
+		delete(f.shared, path)
+		w.WriteHeader(http.StatusNoContent)
+	case projectPath && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.project(project))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// project returns the project of the path, along with the share of the group when it has one
+func (f *fakeGroupProjects) project(path string) map[string]interface{} {
+	shares := []map[string]int{}
+	if access, ok := f.shared[path]; ok {
+		shares = append(shares, map[string]int{"group_id": f.groupID, "group_access_level": access})
+	}
+	return map[string]interface{}{
+		"id": f.projectIDs[path], "path_with_namespace": path, "shared_with_groups": shares,
+	}
+}
+
+func TestReconcileGroupParams_ProjectAccessPaths(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGroupProjects{
+		groupID: 100,
+		projectIDs: map[string]int{
+			"team/keep": 1, "team/upgrade": 2, "team/old": 3, "team/new": 4,
+		},
+		shared: map[string]int{
+			"team/keep":    int(gitlab.DeveloperPermissions),
+			"team/upgrade": int(gitlab.ReporterPermissions),
+			"team/old":     int(gitlab.DeveloperPermissions),
+		},
+	}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamProjectAccessPaths,
+		Value:    []string{"team/keep", "team/upgrade:maintainer", "team/new:reporter"},
+	}))
+	assert.Equal(t, map[string]int{
+		"team/keep":    int(gitlab.DeveloperPermissions),
+		"team/upgrade": int(gitlab.MaintainerPermissions),
+		"team/new":     int(gitlab.ReporterPermissions),
+	}, fake.shared, "Expected the projects no longer listed to be unshared from the group")
+	assert.Equal(t, []string{"team/keep"}, client.sharedProjects)
+
+	// The projects listed without an access level follow the access level of the group
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamGroupAccessLevel,
+		Value:    []string{"maintainer"},
+	}))
+	assert.Equal(t, map[string]int{
+		"team/keep":    int(gitlab.MaintainerPermissions),
+		"team/upgrade": int(gitlab.MaintainerPermissions),
+		"team/new":     int(gitlab.ReporterPermissions),
+	}, fake.shared)
+
+	unshares := fake.unshares
+	require.NoError(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamProjectAccessPaths,
+		Value:    []string{"team/keep", "team/upgrade:maintainer", "team/new:reporter"},
+	}))
+	assert.Equal(t, unshares, fake.unshares, "Expected the projects already shared to be left as is")
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamProjectAccessPaths,
+		Value:    []string{"team/keep:admin"},
+	}), "unsupported gitlab member role: admin")
+	assert.Error(t, client.ReconcileGroupParams(ctx, "100", structs.TeamParams{
+		Property: GroupParamGroupAccessLevel,
+		Value:    []string{"developer", "maintainer"},
+	}))
+}
+
+// fakeGroupDeletion is a gitlab group deleted in two steps, marked for deletion by a first delete,
+// then removed by a permanent delete
+type fakeGroupDeletion struct {
+	markedForDeletion bool
+	removed           bool
+
+	deletes []string
+}
+
+func (f *fakeGroupDeletion) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v4/groups/100" || f.removed {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		group := map[string]interface{}{"id": 100, "name": "team", "full_path": "org/team"}
+		if f.markedForDeletion {
+			group["marked_for_deletion_on"] = "2026-10-21"
+		}
+		_ = json.NewEncoder(w).Encode(group)
+	case http.MethodDelete:
+		f.deletes = append(f.deletes, r.URL.RawQuery)
+		switch {
+		case r.URL.Query().Get("permanently_remove") == "true" && r.URL.Query().Get("full_path") == "org/team":
+			f.removed = true
+		case f.markedForDeletion:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "Group has been already marked for deletion"}`))
+			return
+		default:
+			f.markedForDeletion = true
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestDeleteTeamByID(t *testing.T) {
+	fake := &fakeGroupDeletion{}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "100"))
+	assert.True(t, fake.removed, "Expected the group to be removed permanently")
+	assert.Equal(t, []string{"", "full_path=org%2Fteam&permanently_remove=true"}, fake.deletes)
+}
+
+func TestDeleteTeamByID_SoftDeleteOnly(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGroupDeletion{}
+	client := newTestClient(t, fake)
+	client.gitlabConfig.SoftDeleteOnly = true
+
+	require.NoError(t, client.DeleteTeamByID(ctx, "100"))
+	assert.True(t, fake.markedForDeletion)
+	assert.False(t, fake.removed, "Expected the group to be kept restorable")
+
+	require.NoError(t, client.DeleteTeamByID(ctx, "100"),
+		"Expected the groups already marked for deletion to be considered deleted")
+	assert.False(t, fake.removed)
+
+	client.gitlabConfig.SoftDeleteOnly = false
+	assert.ErrorContains(t, client.DeleteTeamByID(ctx, "100"), "failed to initiate soft delete")
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMaxThrottleRetries = 5
+	defaultMaxRetryAfter      = time.Minute
+)
+
+// throttleBackoff returns the backoff of the SDK retries, which retry the requests rate limited by
+// GitLab (429) and its server errors. A rate limited request is retried after its Retry-After
+// header, or else the RateLimit-Reset time, and the other retries back off exponentially from a
+// second, each wait capped by maxRetryAfter.
+func throttleBackoff(
+	maxRetryAfter time.Duration) func(_, _ time.Duration, attempt int, resp *http.Response) time.Duration {
+	return func(_, _ time.Duration, attempt int, resp *http.Response) time.Duration {
+		delay := time.Second << attempt
+		if resp == nil {
+			return min(delay, maxRetryAfter)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+				delay = time.Duration(seconds) * time.Second
+			} else if reset, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+				delay = max(time.Until(time.Unix(reset, 0)), 0)
+			}
+		}
+		delay = min(delay, maxRetryAfter)
+
+		if resp.Request != nil {
+			logger.Logger(resp.Request.Context()).WithFields(logrus.Fields{
+				"service":      "gitlab",
+				"method":       resp.Request.Method,
+				"path":         resp.Request.URL.Path,
+				"responseCode": resp.StatusCode,
+				"retryAfter":   delay.String(),
+			}).Warn("gitlab request throttled or failed, retrying")
+		}
+		return delay
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// rateLimitedServer answers 429 with a Retry-After of 0 to the first limited requests, then
+// answers with the user of the token
+type rateLimitedServer struct {
+	limited  int
+	requests int
+}
+
+func (s *rateLimitedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	if s.requests <= s.limited {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message": "429 Too Many Requests"}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "username": "usernaut"})
+}
+
+func TestThrottleBackoff(t *testing.T) {
+	backoff := throttleBackoff(time.Minute)
+	response := func(statusCode int, header http.Header) *http.Response {
+		return &http.Response{StatusCode: statusCode, Header: header}
+	}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		resp     *http.Response
+		expected time.Duration
+	}{
+		{name: "connection error", attempt: 2, expected: 4 * time.Second},
+		{name: "connection error capped", attempt: 7, expected: time.Minute},
+		{name: "server error", attempt: 1, resp: response(http.StatusBadGateway, http.Header{}), expected: 2 * time.Second},
+		{
+			name: "retry after", attempt: 1, expected: 7 * time.Second,
+			resp: response(http.StatusTooManyRequests, http.Header{"Retry-After": {"7"}}),
+		},
+		{
+			name: "retry after capped", attempt: 1, expected: time.Minute,
+			resp: response(http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}}),
+		},
+		{
+			name: "rate limit reset passed", attempt: 1, expected: 0,
+			resp: response(http.StatusTooManyRequests, http.Header{"Ratelimit-Reset": {"1"}}),
+		},
+		{
+			name: "invalid retry after", attempt: 3, expected: 8 * time.Second,
+			resp: response(http.StatusTooManyRequests, http.Header{"Retry-After": {"soon"}}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, backoff(time.Second, time.Minute, tt.attempt, tt.resp))
+		})
+	}
+
+	reset := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+	delay := backoff(time.Second, time.Minute, 1, response(http.StatusTooManyRequests,
+		http.Header{"Ratelimit-Reset": {reset}}))
+	assert.InDelta(t, 30*time.Second, delay, float64(2*time.Second), "Expected the wait until the rate limit reset")
+}
+
+func TestNewClientThrottleRetries(t *testing.T) {
+	newClient := func(t *testing.T, server *rateLimitedServer, connection map[string]interface{}) *GitlabClient {
+		connection["url"] = fakehttp.NewServer(t, server).URL
+		connection["token"] = "token"
+		client, err := NewClient(connection, config.Dependant{}, fakehttp.ConnectionPoolConfig(t),
+			fakehttp.HystrixResiliencyConfig())
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("retried", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 2}
+		client := newClient(t, server, map[string]interface{}{})
+		require.NoError(t, client.HealthCheck(context.Background()))
+		assert.Equal(t, 3, server.requests)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 5}
+		client := newClient(t, server, map[string]interface{}{"max_throttle_retries": 2})
+		assert.Error(t, client.HealthCheck(context.Background()))
+		assert.Equal(t, 3, server.requests, "Expected the request to be retried twice")
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		server := &rateLimitedServer{limited: 1}
+		client := newClient(t, server, map[string]interface{}{"max_throttle_retries": -1})
+		assert.Error(t, client.HealthCheck(context.Background()))
+		assert.Equal(t, 1, server.requests)
+	})
+
+	_, err := NewClient(map[string]interface{}{
+		"url": "https://gitlab.example.com", "token": "token", "max_retry_after": "soon",
+	}, config.Dependant{}, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	assert.ErrorContains(t, err, "invalid gitlab max_retry_after \"soon\"")
+}
@@ -17,14 +17,83 @@ limitations under the License.
 package gitlab
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/gojek/heimdall/v7"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
+// Group links syncing the members of a group from its dependency
+const (
+	GroupLinkLDAP = "ldap"
+	GroupLinkSAML = "saml"
+)
+
+// Group param properties of the gitlab backend
+const (
+	GroupParamProjectAccessPaths = "project_access_paths"
+	GroupParamGroupAccessLevel   = "group_access_level"
+	GroupParamCIVariables        = "ci_variables"
+)
+
+// errUserNotFound is returned when a username has no gitlab account, the user never signed in
+var errUserNotFound = errors.New("user not found in gitlab")
+
 var (
 	ldapProvider = "ldapmain"
+
+	// accessLevels maps the member roles accepted in a Group CR to gitlab access levels
+	accessLevels = map[string]gitlab.AccessLevelValue{
+		"guest":      gitlab.GuestPermissions,
+		"reporter":   gitlab.ReporterPermissions,
+		"developer":  gitlab.DeveloperPermissions,
+		"maintainer": gitlab.MaintainerPermissions,
+		"owner":      gitlab.OwnerPermissions,
+	}
 )
 
+// accessLevelFromRole returns the gitlab access level for a member role, roles are case insensitive
+func accessLevelFromRole(role string) (gitlab.AccessLevelValue, error) {
+	accessLevel, ok := accessLevels[strings.ToLower(role)]
+	if !ok {
+		return gitlab.NoPermissions, fmt.Errorf("unsupported gitlab member role: %s", role)
+	}
+	return accessLevel, nil
+}
+
+// groupAccess returns the access level of the group on its LDAP link and shared projects
+func (g *GitlabClient) groupAccess() gitlab.AccessLevelValue {
+	if g.groupAccessLevel == gitlab.NoPermissions {
+		return gitlab.DeveloperPermissions
+	}
+	return g.groupAccessLevel
+}
+
+// accessLevelName returns the member role for a gitlab access level, or an empty string if it has none
+func accessLevelName(accessLevel gitlab.AccessLevelValue) string {
+	for role, level := range accessLevels {
+		if level == accessLevel {
+			return role
+		}
+	}
+	return ""
+}
+
+// bulkAddGroupMembersOptions adds several users to a group, user_id holds their comma separated IDs
+type bulkAddGroupMembersOptions struct {
+	UserID      string                   `url:"user_id" json:"user_id"`
+	AccessLevel *gitlab.AccessLevelValue `url:"access_level" json:"access_level"`
+}
+
+// bulkAddGroupMembersResult is the response of a bulk add, the message lists the error of each failed user
+type bulkAddGroupMembersResult struct {
+	Status  string          `json:"status"`
+	Message json.RawMessage `json:"message"`
+}
+
 type GitlabClient struct {
 	gitlabClient    *gitlab.Client
 	gitlabConfig    *GitlabConfig
@@ -32,10 +101,29 @@ type GitlabClient struct {
 	dependantExists bool
 	cn              string
 	httpClient      heimdall.Doer
+
+	// groupAccessLevel is the access level of the group on its LDAP link and the projects shared
+	// with it, set by the group_access_level group param, developer when unset
+	groupAccessLevel gitlab.AccessLevelValue
+	// sharedProjects are the project paths shared with the group by the project_access_paths group
+	// param without an access level of their own, updated when the group_access_level group param
+	// is reconciled after them
+	sharedProjects []string
 }
 
 type GitlabConfig struct {
 	URL           string `json:"url"`
 	Token         string `json:"token"`
 	ParentGroupId int    `json:"parent_group_id"`
+	// GroupLink is the link syncing the members of the groups from their dependency: ldap (default)
+	// links the LDAP group of the same name, saml the SAML group of the same name
+	GroupLink string `json:"group_link"`
+	// SoftDeleteOnly stops the deletion of the groups at the soft delete, the groups marked for
+	// deletion can then be restored until the deletion delay of the instance expires
+	SoftDeleteOnly bool `json:"soft_delete_only"`
+	// MaxThrottleRetries is how many times a request rate limited by GitLab, or failing with a
+	// server error, is retried, 5 by default, a negative value disables the retries
+	MaxThrottleRetries int `json:"max_throttle_retries"`
+	// MaxRetryAfter caps the wait before retrying a request, e.g. 30s, 1m by default
+	MaxRetryAfter string `json:"max_retry_after"`
 }
@@ -36,10 +36,27 @@ func (g *GitlabClient) FetchAllUsers(ctx context.Context) (map[string]*structs.U
 	userEmailMap := make(map[string]*structs.User)
 	userIDMap := make(map[string]*structs.User)
 
+	err := g.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			userEmailMap[user.Email] = user
+			userIDMap[user.ID] = user
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.WithField("total_user_count", len(userIDMap)).Info("found users")
+	return userEmailMap, userIDMap, nil
+}
+
+// ForEachUserPage calls fn with each page of the active human users, 100 users at a time
+func (g *GitlabClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
 	if g.dependantExists {
 		// Since users will be fetched using Rover as LDAP, we don't need to fetch users from Gitlab API
 		// if it has dependant in gitlab backend configs
-		return userEmailMap, userIDMap, nil
+		return nil
 	}
 
 	human := true
@@ -54,30 +71,30 @@ func (g *GitlabClient) FetchAllUsers(ctx context.Context) (map[string]*structs.U
 	}
 
 	for {
-		users, resp, err := g.gitlabClient.Users.ListUsers(opt)
+		users, resp, err := g.gitlabClient.Users.ListUsers(opt, gitlab.WithContext(ctx))
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 
+		page := make([]*structs.User, 0, len(users))
 		for _, user := range users {
-			userEmailMap[user.Email] = userDetails(user)
-			userIDMap[fmt.Sprintf("%d", user.ID)] = userDetails(user)
+			page = append(page, userDetails(user))
+		}
+		if err := fn(page); err != nil {
+			return err
 		}
 
 		// Check if we got fewer users than requested (last page)
 		if len(users) < opt.PerPage {
-			break
+			return nil
 		}
 
 		// For offset pagination, check NextPage
 		if resp.NextPage == 0 {
-			break
+			return nil
 		}
 		opt.Page = resp.NextPage
 	}
-
-	log.WithField("total_user_count", len(userIDMap)).Info("found users")
-	return userEmailMap, userIDMap, nil
 }
 
 func (g *GitlabClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
@@ -88,6 +105,10 @@ func (g *GitlabClient) FetchUserDetails(ctx context.Context, userID string) (*st
 	log.Info("fetching user details")
 	var user *gitlab.User
 
+	if email, ok := invitedEmail(userID); ok {
+		return pendingUser(&structs.User{Email: email}), nil
+	}
+
 	userIDInt, err := strconv.Atoi(userID)
 	if err != nil {
 		var numErr *strconv.NumError
@@ -111,12 +132,8 @@ func (g *GitlabClient) FetchUserDetails(ctx context.Context, userID string) (*st
 			} else {
 				// this handles the case where user never logged in to gitlab
 				// so user details like userID is not found in gitlab
-				// TODO: need to handle the case when the user login in gitlab
 				log.Warnf("unable to find user %s details in gitlab backend", userID)
-				return &structs.User{
-					ID:       userID,
-					UserName: userID,
-				}, nil
+				return nil, fmt.Errorf("%w: %s", errUserNotFound, userID)
 			}
 		} else {
 			// The userID is not a valid username-like string or is a number out of range.
@@ -143,6 +160,11 @@ func (g *GitlabClient) CreateUser(ctx context.Context, u *structs.User) (*struct
 
 	if g.ldapSync {
 		user, err := g.FetchUserDetails(ctx, u.UserName)
+		if errors.Is(err, errUserNotFound) {
+			// The user gets its account when it first signs in, it is looked up again until then
+			log.Info("user has no gitlab account yet")
+			return pendingUser(u), nil
+		}
 		if err != nil {
 			log.WithError(err).Error("Failed to fetch user details")
 			return nil, err
@@ -163,12 +185,32 @@ func (g *GitlabClient) CreateUser(ctx context.Context, u *structs.User) (*struct
 	}
 	user, resp, err := g.gitlabClient.Users.CreateUser(createUserOptions)
 	if err != nil {
-		if resp.StatusCode == http.StatusForbidden {
-			log.WithError(err).Error(
-				"user creation forbidden, check ldapSync for gitlab backend or obtain admin privileges",
-			)
+		if resp == nil {
 			return nil, err
 		}
+		// GitLab answers 409 when the email or the username is already taken
+		if resp.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		if resp.StatusCode == http.StatusForbidden {
+			// Without admin privileges the existing account of the user is used, and the users
+			// without account are invited to the groups by email
+			user, fetchErr := g.FetchUserDetails(ctx, u.UserName)
+			if errors.Is(fetchErr, errUserNotFound) && u.Email != "" {
+				log.Info("user creation forbidden and user has no gitlab account, inviting the user by email")
+				return pendingUser(u), nil
+			}
+			if fetchErr != nil {
+				log.WithError(err).Error(
+					"user creation forbidden, check ldapSync for gitlab backend or obtain admin privileges",
+				)
+				return nil, err
+			}
+			if user.Email == "" {
+				user.Email = u.Email
+			}
+			return user, nil
+		}
 		return nil, err
 	}
 
@@ -182,7 +224,7 @@ func (g *GitlabClient) DeleteUser(ctx context.Context, userID string) error {
 	})
 	log.Info("deleting user")
 
-	if g.ldapSync {
+	if g.ldapSync || g.IsPendingUser(userID) {
 		return nil
 	}
 
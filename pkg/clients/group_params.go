@@ -0,0 +1,521 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedGroupParam is returned for a group param property the backend type doesn't support
+	ErrUnsupportedGroupParam = errors.New("unsupported group param property")
+)
+
+// Group params overriding the default roles of the backend for the users and the team created for
+// the group, supported by every backend type. They are applied by the reconcile when the users and
+// the team are created, not by ReconcileGroupParams.
+const (
+	GroupParamUserRole = "user_role"
+	GroupParamTeamRole = "team_role"
+)
+
+// GroupParamSchema describes a group param property supported by a backend type
+type GroupParamSchema struct {
+	// Description of the values of the property
+	Description string
+	// MaxValues is the largest number of values of the property, 0 for no limit
+	MaxValues int
+	// validate checks a single value of the property, values only need to be non-empty when nil
+	validate func(value string) error
+}
+
+// groupParamSchemas are the group param properties supported by each backend type,
+// they are applied by the ReconcileGroupParams of the backend client
+var groupParamSchemas = map[string]map[string]GroupParamSchema{
+	"airflow": {
+		"dag_permissions": {
+			Description: "DAGs the role of the group has access to on Airflow, with the access granted, e.g. etl_daily:edit, " +
+				"read when omitted; the other DAG permissions of the role are revoked",
+			validate: validateAirflowDagPermission,
+		},
+		"workspace_roles": {
+			Description: "workspaces the team of the group has a role on with the astronomer flavor, e.g. " +
+				"<workspace ID>:WORKSPACE_OPERATOR, WORKSPACE_MEMBER when omitted; the other workspace roles of the team " +
+				"are revoked",
+			validate: validateAstronomerWorkspaceRole,
+		},
+	},
+	"artifactory": {
+		"permission_targets": {
+			Description: "permission targets granting the group access to their repositories, with the actions granted, e.g. " +
+				"docker-dev:read,write, read when omitted; the group is detached from the other permission targets",
+			validate: validateArtifactoryPermissionTarget,
+		},
+	},
+	"bitbucket": {
+		"repository_permissions": {
+			Description: "repositories and projects the group is granted access to, e.g. PROJ/repo:write or PROJ:read, read " +
+				"when omitted; the permissions no longer listed are not revoked",
+			validate: validateBitbucketRepositoryPermission,
+		},
+	},
+	"databricks": {
+		"entitlements": {
+			Description: "entitlements granted to the group and inherited by its members, e.g. allow-cluster-create or " +
+				"databricks-sql-access, the other entitlements of the group are revoked",
+			validate: validateDatabricksEntitlement,
+		},
+	},
+	"dbtcloud": {
+		"permission_sets": {
+			Description: "permission sets granted to the group, on all the projects, e.g. analyst, or on a project by its ID, " +
+				"e.g. developer:1234; the other permissions of the group are revoked",
+			validate: validateDbtCloudPermissionSet,
+		},
+	},
+	"fivetran": {
+		"destinations": {
+			Description: "IDs of the Fivetran groups, each holding a destination and its connectors, the team is given access " +
+				"to, followed by the role of the team, e.g. decent_dropsy:Destination Administrator, Destination Reviewer when " +
+				"omitted; the team is removed from the other groups",
+			validate: validateFivetranMembership,
+		},
+		"connectors": {
+			Description: "IDs of the Fivetran connectors the team is given access to, followed by the role of the team, e.g. " +
+				"mobile_haircut:Connector Administrator, Connector Reviewer when omitted; the team is removed from the other " +
+				"connectors",
+			validate: validateFivetranMembership,
+		},
+	},
+	"gitlab": {
+		"project_access_paths": {
+			Description: "full paths of the projects the group is given access to, optionally followed by the access level of " +
+				"the group on the project, e.g. team/project:maintainer, the group_access_level when omitted; the other projects " +
+				"are unshared from the group",
+			validate: validateProjectPath,
+		},
+		"group_access_level": {
+			Description: "access level of the group on its LDAP link and the projects shared with it: guest, reporter, " +
+				"developer or maintainer, developer when omitted",
+			MaxValues: 1,
+			validate:  validateGitlabGroupAccessLevel,
+		},
+		"ci_variables": {
+			Description: "CI/CD variables of the group, as KEY=value, e.g. TEAM_BUCKET=s3://team-data, created or updated to " +
+				"the value; the other variables of the group are left untouched",
+			validate: validateGitlabCIVariable,
+		},
+	},
+	"kafka": {
+		"topics": {
+			Description: "topics the group principal is granted access to, followed by read, write or read-write (read when " +
+				"omitted), a name ending with * matching the topics by prefix, e.g. orders.*:read-write; the access to the other " +
+				"topics is revoked",
+			validate: validateKafkaTopic,
+		},
+		"consumer_groups": {
+			Description: "consumer groups the group principal can consume with, a name ending with * matching the consumer " +
+				"groups by prefix, e.g. analytics-*; the access to the other consumer groups is revoked",
+			validate: validateKafkaResourceName,
+		},
+	},
+	"keycloak": {
+		"client_roles": {
+			Description: "names of the roles of the role_client of the backend mapped to the group, the other roles of the " +
+				"client are unmapped",
+		},
+	},
+	"minio": {
+		"policies": {
+			Description: "names of the policies attached to the group and granting its members access to the buckets, e.g. " +
+				"readwrite; the other policies of the group are detached",
+			validate: validateMinioPolicy,
+		},
+	},
+	"okta": {
+		"app_assignments": {
+			Description: "IDs of the apps the group is assigned to, the group is unassigned from the other apps",
+			validate:    validateOktaAppID,
+		},
+	},
+	"openshift": {
+		"role_bindings": {
+			Description: "cluster roles bound to the group, in a namespace as <namespace>/<cluster role> or cluster wide as " +
+				"<cluster role>, the other role bindings of the group are deleted",
+			validate: validateOpenShiftRoleBinding,
+		},
+	},
+	"quay": {
+		"robot_accounts": {
+			Description: "robot accounts of the organization made members of the team, by short name or full name, the other " +
+				"robot accounts are removed",
+			validate: validateQuayRobotAccount,
+		},
+	},
+	"slack": {
+		"handle": {
+			Description: "@handle of the user group, overrides the handle derived from the name of the group",
+			MaxValues:   1,
+			validate:    validateSlackHandle,
+		},
+	},
+	"snowflake": {
+		"network_policy": {
+			Description: "network policy set on the users of the group, restricting the networks they can log in " +
+				"from, e.g. REGULATED_DATA; it is unset from the users removed from the group",
+			MaxValues: 1,
+			validate:  validateSnowflakeIdentifier,
+		},
+	},
+}
+
+// roleGroupParamSchemas are the group param properties of the roles, supported by every backend type
+var roleGroupParamSchemas = map[string]GroupParamSchema{
+	GroupParamUserRole: {
+		Description: "role of the users created in the backend for the group, overrides the default user role of the backend",
+		MaxValues:   1,
+	},
+	GroupParamTeamRole: {
+		Description: "role of the team created in the backend for the group, overrides the default team role of the backend",
+		MaxValues:   1,
+	},
+}
+
+// IsRoleGroupParam reports whether the group param property overrides a default role of the backend
+func IsRoleGroupParam(property string) bool {
+	_, ok := roleGroupParamSchemas[property]
+	return ok
+}
+
+// LookupGroupParam returns the schema of a group param property of the backend type
+func LookupGroupParam(backendType, property string) (GroupParamSchema, bool) {
+	if schema, ok := roleGroupParamSchemas[property]; ok {
+		return schema, true
+	}
+	schema, ok := groupParamSchemas[strings.ToLower(backendType)][property]
+	return schema, ok
+}
+
+// GroupParamProperties returns the sorted group param properties supported by the backend type
+func GroupParamProperties(backendType string) []string {
+	properties := make([]string, 0, len(groupParamSchemas[strings.ToLower(backendType)])+len(roleGroupParamSchemas))
+	for property := range groupParamSchemas[strings.ToLower(backendType)] {
+		properties = append(properties, property)
+	}
+	for property := range roleGroupParamSchemas {
+		properties = append(properties, property)
+	}
+	slices.Sort(properties)
+	return properties
+}
+
+// ValidateValue checks a single value of the property
+func (s GroupParamSchema) ValidateValue(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("value must not be empty")
+	}
+	if s.validate == nil {
+		return nil
+	}
+	return s.validate(value)
+}
+
+// ValidateGroupParam checks that the backend type supports the group param property and that all
+// its values are valid
+func ValidateGroupParam(backendType, property string, values []string) error {
+	schema, ok := LookupGroupParam(backendType, property)
+	if !ok {
+		return fmt.Errorf("%w %s for %s backend, supported: %s", ErrUnsupportedGroupParam, property,
+			backendType, strings.Join(GroupParamProperties(backendType), ", "))
+	}
+	if schema.MaxValues > 0 && len(values) > schema.MaxValues {
+		return fmt.Errorf("group param %s accepts at most %d values, got %d", property, schema.MaxValues, len(values))
+	}
+	for _, value := range values {
+		if err := schema.ValidateValue(value); err != nil {
+			return fmt.Errorf("invalid value %q of group param %s: %w", value, property, err)
+		}
+	}
+	return nil
+}
+
+// validateProjectPath accepts the full path of a gitlab project, its namespace and name separated
+// by slashes, optionally followed by the access level of the group on the project
+func validateProjectPath(value string) error {
+	projectPath, accessLevel, found := strings.Cut(value, ":")
+	if strings.ContainsAny(projectPath, " \t\n") {
+		return errors.New("project path must not contain whitespaces")
+	}
+	segments := strings.Split(projectPath, "/")
+	if len(segments) < 2 || slices.Contains(segments, "") {
+		return errors.New("project path must be the full path of the project, e.g. team/project")
+	}
+	if found {
+		return validateGitlabGroupAccessLevel(accessLevel)
+	}
+	return nil
+}
+
+// gitlabGroupAccessLevels are the access levels of a gitlab group on its LDAP link and shared projects
+var gitlabGroupAccessLevels = []string{"guest", "reporter", "developer", "maintainer"}
+
+// validateGitlabGroupAccessLevel accepts a gitlab group access level, access levels are case insensitive
+func validateGitlabGroupAccessLevel(value string) error {
+	if !slices.Contains(gitlabGroupAccessLevels, strings.ToLower(value)) {
+		return fmt.Errorf("unknown group access level %q, supported: %s", value, strings.Join(gitlabGroupAccessLevels, ", "))
+	}
+	return nil
+}
+
+// validateGitlabCIVariable accepts a gitlab CI/CD variable as KEY=value, the key holding letters,
+// digits and underscores only
+func validateGitlabCIVariable(value string) error {
+	key, _, found := strings.Cut(value, "=")
+	if !found {
+		return errors.New("ci variable must be KEY=value, e.g. TEAM_BUCKET=s3://team-data")
+	}
+	if key == "" || strings.Trim(key, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_") != "" {
+		return fmt.Errorf("ci variable key %q must hold letters, digits and underscores only", key)
+	}
+	return nil
+}
+
+// validateOktaAppID accepts the ID of an okta app, e.g. 0oa1bcd2efGHIJklm3n4
+func validateOktaAppID(value string) error {
+	if strings.ContainsAny(value, " \t\n/") {
+		return errors.New("app ID must not contain whitespaces or slashes, use the ID of the app rather than its label")
+	}
+	return nil
+}
+
+// validateSlackHandle accepts the @handle of a slack user group, without the @
+func validateSlackHandle(value string) error {
+	if len(value) > 21 {
+		return errors.New("handle must be at most 21 characters long")
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '.' && r != '_' {
+			return errors.New("handle must only contain lower case letters, digits, dashes, dots and underscores")
+		}
+	}
+	return nil
+}
+
+// validateQuayRobotAccount accepts the short name of a quay robot account, e.g. ci, or its full
+// name prefixed by the organization, e.g. myorg+ci
+func validateQuayRobotAccount(value string) error {
+	org, name, found := strings.Cut(value, "+")
+	if !found {
+		name = value
+	} else if org == "" {
+		return errors.New("robot account must be named by its short name or <organization>+<short name>")
+	}
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return errors.New("robot account short name must start with a lower case letter")
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return errors.New("robot account short name must only contain lower case letters, digits and underscores")
+		}
+	}
+	return nil
+}
+
+// artifactoryPermissionActions are the actions a permission target grants on its repositories
+var artifactoryPermissionActions = []string{"read", "annotate", "write", "delete", "manage", "managedXrayMeta",
+	"distribute"}
+
+// validateArtifactoryPermissionTarget accepts the name of an artifactory permission target,
+// optionally followed by the comma separated actions granted to the group, e.g. docker-dev:read,write
+func validateArtifactoryPermissionTarget(value string) error {
+	target, actions, found := strings.Cut(value, ":")
+	if target == "" || strings.ContainsAny(target, " \t\n/") {
+		return errors.New("permission target must be the name of the permission target, e.g. docker-dev:read,write")
+	}
+	if !found {
+		return nil
+	}
+	for _, action := range strings.Split(actions, ",") {
+		if !slices.Contains(artifactoryPermissionActions, action) {
+			return fmt.Errorf("unknown permission action %q, supported: %s", action,
+				strings.Join(artifactoryPermissionActions, ", "))
+		}
+	}
+	return nil
+}
+
+// validateOpenShiftRoleBinding accepts a cluster role bound in a namespace, e.g. analytics/edit, or
+// a cluster role bound cluster wide, e.g. cluster-reader
+func validateOpenShiftRoleBinding(value string) error {
+	namespace, role, found := strings.Cut(value, "/")
+	if !found {
+		role = value
+	}
+	if (found && namespace == "") || role == "" || strings.Contains(role, "/") || strings.ContainsAny(value, " \t\n") {
+		return errors.New("role binding must be <namespace>/<cluster role> or <cluster role>")
+	}
+	return nil
+}
+
+// databricksEntitlements are the entitlements a Databricks group can be granted
+var databricksEntitlements = []string{"workspace-access", "databricks-sql-access", "allow-cluster-create",
+	"allow-instance-pool-create"}
+
+// validateDatabricksEntitlement accepts the entitlements of the Databricks groups
+func validateDatabricksEntitlement(value string) error {
+	if !slices.Contains(databricksEntitlements, value) {
+		return fmt.Errorf("unknown entitlement %q, supported: %s", value, strings.Join(databricksEntitlements, ", "))
+	}
+	return nil
+}
+
+// dbtCloudPermissionSets are the permission sets a dbt Cloud group can be granted
+var dbtCloudPermissionSets = []string{
+	"account_admin", "account_viewer", "admin", "analyst", "billing_admin", "database_admin", "developer",
+	"git_admin", "job_admin", "job_runner", "job_viewer", "manage_marketplace_apps", "metadata_only",
+	"project_creator", "security_admin", "semantic_layer_only", "stakeholder", "team_admin", "webhooks_only",
+}
+
+// validateDbtCloudPermissionSet accepts a dbt Cloud permission set, optionally followed by the ID of
+// the project it is granted on, e.g. developer:1234
+func validateDbtCloudPermissionSet(value string) error {
+	permissionSet, project, found := strings.Cut(value, ":")
+	if !slices.Contains(dbtCloudPermissionSets, permissionSet) {
+		return fmt.Errorf("unknown permission set %q, supported: %s", permissionSet,
+			strings.Join(dbtCloudPermissionSets, ", "))
+	}
+	if found && (project == "" || strings.Trim(project, "0123456789") != "") {
+		return errors.New("permission set project must be the numeric ID of the project, e.g. developer:1234")
+	}
+	return nil
+}
+
+// kafkaTopicAccesses are the accesses granted on a kafka topic
+var kafkaTopicAccesses = []string{"read", "write", "read-write"}
+
+// validateKafkaTopic accepts a kafka topic name or prefix, optionally followed by the access granted
+// on it, e.g. orders.*:read-write
+func validateKafkaTopic(value string) error {
+	name, access, found := strings.Cut(value, ":")
+	if err := validateKafkaResourceName(name); err != nil {
+		return err
+	}
+	if found && !slices.Contains(kafkaTopicAccesses, access) {
+		return fmt.Errorf("unknown topic access %q, supported: %s", access, strings.Join(kafkaTopicAccesses, ", "))
+	}
+	return nil
+}
+
+// validateKafkaResourceName accepts the name of a kafka topic or consumer group, or a prefix of the
+// names ending with *
+func validateKafkaResourceName(value string) error {
+	name := strings.TrimSuffix(value, "*")
+	if name == "" {
+		return errors.New("kafka resource name must not be empty, a prefix must not be a single *")
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '.' && r != '_' && r != '-' {
+			return errors.New("kafka resource name must only contain letters, digits, '.', '_' and '-'")
+		}
+	}
+	return nil
+}
+
+// bitbucketPermissions are the permissions granted to a group on a bitbucket repository or project
+var bitbucketPermissions = []string{"read", "write", "admin"}
+
+// validateBitbucketRepositoryPermission accepts <project>/<repository> or <project>, optionally
+// followed by the permission granted
+func validateBitbucketRepositoryPermission(value string) error {
+	path, permission, found := strings.Cut(value, ":")
+	if found && !slices.Contains(bitbucketPermissions, permission) {
+		return fmt.Errorf("unknown bitbucket permission %q, supported: %s", permission,
+			strings.Join(bitbucketPermissions, ", "))
+	}
+	project, repository, hasRepository := strings.Cut(path, "/")
+	if project == "" || (hasRepository && repository == "") || strings.Contains(repository, "/") {
+		return errors.New("bitbucket repository permission must be <project>/<repository> or <project>")
+	}
+	return nil
+}
+
+// validateMinioPolicy accepts the name of a minio policy, the policies of a group are joined with
+// commas
+func validateMinioPolicy(value string) error {
+	if value == "" || strings.ContainsAny(value, ", \t\n") {
+		return errors.New("minio policy name must not be empty or contain commas or whitespace")
+	}
+	return nil
+}
+
+// airflowDagAccesses are the accesses granted on an airflow DAG
+var airflowDagAccesses = []string{"read", "edit"}
+
+// validateAirflowDagPermission accepts a DAG ID, optionally followed by the access granted
+func validateAirflowDagPermission(value string) error {
+	dagID, access, found := strings.Cut(value, ":")
+	if dagID == "" {
+		return errors.New("airflow dag permission must start with the DAG ID")
+	}
+	if found && !slices.Contains(airflowDagAccesses, access) {
+		return fmt.Errorf("unknown dag access %q, supported: %s", access, strings.Join(airflowDagAccesses, ", "))
+	}
+	return nil
+}
+
+// astronomerWorkspaceRoles are the roles of a team on an astronomer workspace
+var astronomerWorkspaceRoles = []string{"WORKSPACE_MEMBER", "WORKSPACE_AUTHOR", "WORKSPACE_OPERATOR", "WORKSPACE_OWNER"}
+
+// validateAstronomerWorkspaceRole accepts a workspace ID, optionally followed by the role of the team
+func validateAstronomerWorkspaceRole(value string) error {
+	workspaceID, role, found := strings.Cut(value, ":")
+	if workspaceID == "" {
+		return errors.New("astronomer workspace role must start with the workspace ID")
+	}
+	if found && !slices.Contains(astronomerWorkspaceRoles, role) {
+		return fmt.Errorf("unknown workspace role %q, supported: %s", role, strings.Join(astronomerWorkspaceRoles, ", "))
+	}
+	return nil
+}
+
+// validateFivetranMembership accepts the ID of a Fivetran group or connector, optionally followed by
+// the role of the team on it
+func validateFivetranMembership(value string) error {
+	id, role, found := strings.Cut(value, ":")
+	if id == "" || strings.ContainsAny(id, " /") {
+		return errors.New("fivetran membership must start with the ID of the group or connector")
+	}
+	if found && strings.TrimSpace(role) == "" {
+		return errors.New("fivetran membership role must not be empty, e.g. decent_dropsy:Destination Administrator")
+	}
+	return nil
+}
+
+// validateSnowflakeIdentifier accepts an unquoted snowflake identifier, e.g. the name of a network policy
+func validateSnowflakeIdentifier(value string) error {
+	for i, r := range value {
+		letter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		if !letter && (i == 0 || ((r < '0' || r > '9') && r != '$')) {
+			return errors.New("snowflake identifier must start with a letter or '_' " +
+				"and only contain letters, digits, '_' and '$'")
+		}
+	}
+	return nil
+}
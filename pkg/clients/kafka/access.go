@@ -0,0 +1,289 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// Group param properties of the Kafka backends
+const (
+	// groupParamTopics lists the topics the group is granted access to, e.g. orders.*:read-write
+	groupParamTopics = "topics"
+	// groupParamConsumerGroups lists the consumer groups the group can consume with, e.g. analytics-*
+	groupParamConsumerGroups = "consumer_groups"
+)
+
+// Confluent RBAC roles granted in rbac mode, the other roles of the group principals are left alone
+const (
+	roleDeveloperRead  = "DeveloperRead"
+	roleDeveloperWrite = "DeveloperWrite"
+)
+
+// grant is an operation of an ACL, or a role of a role binding, on the resources of a pattern
+type grant struct {
+	resourceType string
+	name         string
+	patternType  string
+	operation    string
+}
+
+// ReconcileGroupParams grants the group principal exactly the access of the topics or
+// consumer_groups group param, with ACLs or role bindings depending on the mode of the backend. The
+// access to the other resources of the same type is revoked.
+func (kC *KafkaClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.kafka.ReconcileGroupParams")
+	defer span.Finish()
+
+	var resourceType string
+	switch groupParams.Property {
+	case groupParamTopics:
+		resourceType = resourceTopic
+	case groupParamConsumerGroups:
+		resourceType = resourceGroup
+	default:
+		return fmt.Errorf("unsupported kafka group param: %s", groupParams.Property)
+	}
+
+	grants, err := kC.grants(resourceType, groupParams.Value)
+	if err != nil {
+		return err
+	}
+	if kC.mode == ModeRBAC {
+		return kC.reconcileRoleBindings(ctx, teamID, resourceType, grants)
+	}
+	return kC.reconcileACLs(ctx, teamID, resourceType, grants)
+}
+
+// grants returns the grants of the values of a group param. A topic is followed by the access
+// granted on it (read, write or read-write), read when omitted, and the consumer groups are granted
+// read. The names ending with * match the resources by prefix.
+func (kC *KafkaClient) grants(resourceType string, values []string) ([]grant, error) {
+	var grants []grant
+	for _, value := range values {
+		name, access := value, AccessRead
+		if resourceType == resourceTopic {
+			var found bool
+			if name, access, found = strings.Cut(value, ":"); !found {
+				access = AccessRead
+			}
+		}
+		patternType := patternLiteral
+		if prefix, ok := strings.CutSuffix(name, prefixWildcard); ok {
+			name, patternType = prefix, patternPrefixed
+		}
+
+		operations, err := kC.operations(resourceType, access)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kafka access of %s: %w", value, err)
+		}
+		for _, operation := range operations {
+			g := grant{resourceType: resourceType, name: name, patternType: patternType, operation: operation}
+			if !slices.Contains(grants, g) {
+				grants = append(grants, g)
+			}
+		}
+	}
+	return grants, nil
+}
+
+// operations returns the ACL operations, or the roles in rbac mode, of the access to a resource
+func (kC *KafkaClient) operations(resourceType, access string) ([]string, error) {
+	if kC.mode == ModeRBAC {
+		switch access {
+		case AccessRead:
+			return []string{roleDeveloperRead}, nil
+		case AccessWrite:
+			return []string{roleDeveloperWrite}, nil
+		case AccessReadWrite:
+			return []string{roleDeveloperRead, roleDeveloperWrite}, nil
+		}
+	} else {
+		if resourceType == resourceGroup {
+			return []string{"READ"}, nil
+		}
+		switch access {
+		case AccessRead:
+			return []string{"READ", "DESCRIBE"}, nil
+		case AccessWrite:
+			return []string{"WRITE", "DESCRIBE"}, nil
+		case AccessReadWrite:
+			return []string{"READ", "WRITE", "DESCRIBE"}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown access %q, supported: %s, %s, %s", access, AccessRead, AccessWrite, AccessReadWrite)
+}
+
+// reconcileACLs creates the missing ACLs of the grants and deletes the other ACLs of the principal
+// on the resource type. Only the ACLs allowing the operations from any host are managed, the
+// others (e.g. the DENY ACLs) are left alone.
+func (kC *KafkaClient) reconcileACLs(ctx context.Context, principal, resourceType string, desired []grant) error {
+	log := logger.Logger(ctx).WithField("teamID", principal).WithField("service", "kafka")
+
+	query := url.Values{}
+	query.Set("principal", principal)
+	query.Set("resource_type", resourceType)
+	resp, err := kC.sendRequest(ctx, kC.aclsPath()+"?"+query.Encode(), http.MethodGet, nil,
+		"backend.kafka.ReconcileGroupParams")
+	if err != nil {
+		log.WithError(err).Error("failed to fetch the kafka acls of the group principal")
+		return err
+	}
+	var acls aclList
+	if err := decode(resp, &acls); err != nil {
+		return err
+	}
+	var current []grant
+	for _, acl := range acls.Data {
+		if acl.Permission == permissionAllow && acl.Host == anyHost {
+			current = append(current, grant{resourceType: acl.ResourceType, name: acl.ResourceName,
+				patternType: acl.PatternType, operation: acl.Operation})
+		}
+	}
+
+	for _, g := range missing(desired, current) {
+		log.WithField("resource", g.name).WithField("operation", g.operation).Info("Create kafka acl")
+		if _, err := kC.sendRequest(ctx, kC.aclsPath(), http.MethodPost, g.acl(principal),
+			"backend.kafka.ReconcileGroupParams"); err != nil {
+			log.WithError(err).Error("failed to create kafka acl")
+			return err
+		}
+	}
+	for _, g := range missing(current, desired) {
+		log.WithField("resource", g.name).WithField("operation", g.operation).Info("Delete kafka acl")
+		acl := g.acl(principal)
+		query := url.Values{}
+		query.Set("resource_type", acl.ResourceType)
+		query.Set("resource_name", acl.ResourceName)
+		query.Set("pattern_type", acl.PatternType)
+		query.Set("principal", acl.Principal)
+		query.Set("host", acl.Host)
+		query.Set("operation", acl.Operation)
+		query.Set("permission", acl.Permission)
+		if _, err := kC.sendRequest(ctx, kC.aclsPath()+"?"+query.Encode(), http.MethodDelete, nil,
+			"backend.kafka.ReconcileGroupParams"); err != nil && !request.IsNotFound(err) {
+			log.WithError(err).Error("failed to delete kafka acl")
+			return err
+		}
+	}
+	return nil
+}
+
+// acl returns the ACL of the grant to the principal
+func (g grant) acl(principal string) *ACL {
+	return &ACL{
+		ResourceType: g.resourceType,
+		ResourceName: g.name,
+		PatternType:  g.patternType,
+		Principal:    principal,
+		Host:         anyHost,
+		Operation:    g.operation,
+		Permission:   permissionAllow,
+	}
+}
+
+// reconcileRoleBindings binds the missing developer roles of the grants and unbinds the other
+// developer roles of the principal on the resource type in the cluster
+func (kC *KafkaClient) reconcileRoleBindings(ctx context.Context, principal, resourceType string,
+	desired []grant) error {
+	log := logger.Logger(ctx).WithField("teamID", principal).WithField("service", "kafka")
+	clusterScope := scope{Clusters: map[string]string{kafkaClusterKind: kC.clusterID}}
+
+	var bindings map[string]map[string][]ResourcePattern
+	resp, err := kC.sendRequest(ctx, "/security/1.0/lookup/principals/"+url.PathEscape(principal)+"/resources",
+		http.MethodPost, clusterScope, "backend.kafka.ReconcileGroupParams")
+	if err != nil && !request.IsNotFound(err) {
+		log.WithError(err).Error("failed to fetch the kafka role bindings of the group principal")
+		return err
+	}
+	if err == nil {
+		if err := decode(resp, &bindings); err != nil {
+			return err
+		}
+	}
+	var current []grant
+	for _, role := range []string{roleDeveloperRead, roleDeveloperWrite} {
+		for _, pattern := range bindings[principal][role] {
+			if strings.EqualFold(pattern.ResourceType, resourceType) {
+				current = append(current, grant{resourceType: resourceType, name: pattern.Name,
+					patternType: pattern.PatternType, operation: role})
+			}
+		}
+	}
+
+	for _, change := range []struct {
+		method string
+		grants []grant
+	}{
+		{http.MethodPost, missing(desired, current)},
+		{http.MethodDelete, missing(current, desired)},
+	} {
+		for _, role := range []string{roleDeveloperRead, roleDeveloperWrite} {
+			var patterns []ResourcePattern
+			for _, g := range change.grants {
+				if g.operation == role {
+					patterns = append(patterns, g.resourcePattern())
+				}
+			}
+			if len(patterns) == 0 {
+				continue
+			}
+			log.WithField("role", role).WithField("method", change.method).Info("Update kafka role bindings")
+			path := fmt.Sprintf("/security/1.0/principals/%s/roles/%s/bindings", url.PathEscape(principal), role)
+			if _, err := kC.sendRequest(ctx, path, change.method, &roleBindings{
+				Scope:            clusterScope,
+				ResourcePatterns: patterns,
+			}, "backend.kafka.ReconcileGroupParams"); err != nil {
+				log.WithError(err).Error("failed to update kafka role bindings")
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resourcePattern returns the resource pattern of the grant, RBAC names the resource types in title
+// case, e.g. Topic
+func (g grant) resourcePattern() ResourcePattern {
+	return ResourcePattern{
+		ResourceType: g.resourceType[:1] + strings.ToLower(g.resourceType[1:]),
+		Name:         g.name,
+		PatternType:  g.patternType,
+	}
+}
+
+// missing returns the grants which are not in the others
+func missing(grants, others []grant) []grant {
+	var result []grant
+	for _, g := range grants {
+		if !slices.Contains(others, g) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// KafkaClient grants the principals of the groups access to the topics and consumer groups of a
+// Kafka cluster, with ACLs or Confluent RBAC role bindings. Kafka has no groups of its own, the
+// brokers resolve the group principals of the users from the directory.
+type KafkaClient struct {
+	client          heimdall.Doer
+	mode            string
+	url             string
+	clusterID       string
+	principalPrefix string
+	headers         map[string]string
+}
+
+func NewClient(kafkaAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*KafkaClient, error) {
+
+	kafkaConfig := KafkaConfig{}
+	if err := utils.MapToStruct(kafkaAppConfig, &kafkaConfig); err != nil {
+		return nil, err
+	}
+	if kafkaConfig.URL == "" || kafkaConfig.ClusterID == "" {
+		return nil, errors.New("kafka configuration is missing required fields: url or cluster_id")
+	}
+	mode := kafkaConfig.Mode
+	switch mode {
+	case "":
+		mode = ModeACL
+	case ModeACL, ModeRBAC:
+	default:
+		return nil, fmt.Errorf("invalid kafka mode %q, supported: %s, %s", kafkaConfig.Mode, ModeACL, ModeRBAC)
+	}
+	principalPrefix := kafkaConfig.PrincipalPrefix
+	if principalPrefix == "" {
+		principalPrefix = defaultPrincipalPrefix
+	}
+
+	client, err := httpclient.InitializeClient(
+		"kafka_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	headers := map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       "application/json",
+	}
+	if kafkaConfig.APIKey != "" {
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString(
+			[]byte(kafkaConfig.APIKey+":"+kafkaConfig.APISecret))
+	}
+
+	return &KafkaClient{
+		client:          client,
+		mode:            mode,
+		url:             strings.TrimSuffix(kafkaConfig.URL, "/"),
+		clusterID:       kafkaConfig.ClusterID,
+		principalPrefix: principalPrefix,
+		headers:         headers,
+	}, nil
+}
+
+// HealthCheck fetches the cluster from the Kafka REST API, or authenticates against MDS
+func (kC *KafkaClient) HealthCheck(ctx context.Context) error {
+	path := "/kafka/v3/clusters/" + url.PathEscape(kC.clusterID)
+	if kC.mode == ModeRBAC {
+		path = "/security/1.0/authenticate"
+	}
+	if _, err := kC.sendRequest(ctx, path, http.MethodGet, nil, "backend.kafka.HealthCheck"); err != nil {
+		return fmt.Errorf("kafka health check failed: %w", err)
+	}
+	return nil
+}
+
+// aclsPath is the path of the ACLs of the cluster in the Kafka REST API
+func (kC *KafkaClient) aclsPath() string {
+	return "/kafka/v3/clusters/" + url.PathEscape(kC.clusterID) + "/acls"
+}
+
+// decode decodes the response of a Kafka request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode kafka response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the API and returns the response body, any response
+// code other than 2xx is returned as a *request.ResponseError
+func (kC *KafkaClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, kC.client, method, kC.url+path, body, kC.headers, methodName,
+		"kafka", errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the message of an error response of the API
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	if errResp.Message != "" {
+		return "", errResp.Message
+	}
+	return "", errResp.ErrorMessage
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeKafka is the Kafka REST API of cluster lkc-1 and its Metadata Service, holding the ACLs and
+// the role bindings in memory
+type fakeKafka struct {
+	acls []ACL
+	// bindings are the resource patterns bound to the principals by role
+	bindings map[string]map[string][]ResourcePattern
+}
+
+func (f *fakeKafka) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if user, password, _ := r.BasicAuth(); user != "key" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error_code": 401, "message": "Unauthorized"}`)
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case r.URL.Path == "/kafka/v3/clusters/lkc-1" || r.URL.Path == "/security/1.0/authenticate":
+		_, _ = io.WriteString(w, `{}`)
+	case r.URL.Path == "/kafka/v3/clusters/lkc-1/acls" && r.Method == http.MethodGet:
+		acls := []ACL{}
+		for _, acl := range f.acls {
+			if matches(query, "principal", acl.Principal) && matches(query, "resource_type", acl.ResourceType) {
+				acls = append(acls, acl)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, aclList{Data: acls})
+	case r.URL.Path == "/kafka/v3/clusters/lkc-1/acls" && r.Method == http.MethodPost:
+		var acl ACL
+		_ = json.NewDecoder(r.Body).Decode(&acl)
+		f.acls = append(f.acls, acl)
+		w.WriteHeader(http.StatusCreated)
+	case r.URL.Path == "/kafka/v3/clusters/lkc-1/acls" && r.Method == http.MethodDelete:
+		f.acls = slices.DeleteFunc(f.acls, func(acl ACL) bool {
+			return matches(query, "principal", acl.Principal) && matches(query, "resource_type", acl.ResourceType) &&
+				matches(query, "resource_name", acl.ResourceName) && matches(query, "pattern_type", acl.PatternType) &&
+				matches(query, "operation", acl.Operation) && matches(query, "permission", acl.Permission) &&
+				matches(query, "host", acl.Host)
+		})
+		_, _ = io.WriteString(w, `{"data": []}`)
+	case strings.HasPrefix(r.URL.Path, "/security/1.0/lookup/principals/"):
+		principal := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/security/1.0/lookup/principals/"), "/resources")
+		fakehttp.WriteJSON(w, http.StatusOK, map[string]map[string][]ResourcePattern{principal: f.bindings[principal]})
+	case strings.HasPrefix(r.URL.Path, "/security/1.0/principals/"):
+		// /security/1.0/principals/{principal}/roles/{role}/bindings
+		segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/security/1.0/principals/"), "/")
+		principal, role := segments[0], segments[2]
+		var request roleBindings
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.Scope.Clusters["kafka-cluster"] != "lkc-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if f.bindings[principal] == nil {
+			f.bindings[principal] = map[string][]ResourcePattern{}
+		}
+		if r.Method == http.MethodPost {
+			f.bindings[principal][role] = append(f.bindings[principal][role], request.ResourcePatterns...)
+		} else {
+			f.bindings[principal][role] = slices.DeleteFunc(f.bindings[principal][role], func(pattern ResourcePattern) bool {
+				return slices.Contains(request.ResourcePatterns, pattern)
+			})
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error_code": 404, "message": "HTTP 404 Not Found"}`)
+	}
+}
+
+// matches reports whether the value matches the filter of the query, an absent filter or ANY
+// matching any value
+func matches(query map[string][]string, filter, value string) bool {
+	expected := ""
+	if values := query[filter]; len(values) > 0 {
+		expected = values[0]
+	}
+	return expected == "" || expected == "ANY" || expected == value
+}
+
+func newTestClient(t *testing.T, fake *fakeKafka, connection map[string]interface{}) *KafkaClient {
+	t.Helper()
+	if fake.bindings == nil {
+		fake.bindings = map[string]map[string][]ResourcePattern{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["url"] = server.URL + "/"
+	connection["cluster_id"] = "lkc-1"
+	connection["api_key"] = "key"
+	connection["api_secret"] = "secret"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"url": "http://localhost"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "url or cluster_id")
+
+	_, err = NewClient(map[string]interface{}{"url": "http://localhost", "cluster_id": "lkc-1", "mode": "zookeeper"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid kafka mode")
+}
+
+func TestUsersAndTeams(t *testing.T) {
+	fake := &fakeKafka{acls: []ACL{
+		{ResourceType: "TOPIC", ResourceName: "orders", PatternType: "LITERAL", Principal: "Group:dataeng",
+			Host: "*", Operation: "READ", Permission: "ALLOW"},
+		{ResourceType: "TOPIC", ResourceName: "orders", PatternType: "LITERAL", Principal: "User:jdoe",
+			Host: "*", Operation: "READ", Permission: "ALLOW"},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "User:jdoe", user.ID)
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "jdoe@example.com"})
+	assert.ErrorContains(t, err, "has none")
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"dataeng": {ID: "Group:dataeng", Name: "dataeng"}}, teams)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "analytics"})
+	require.NoError(t, err)
+	assert.Equal(t, "Group:analytics", team.ID)
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "Group:dataeng"))
+	assert.Len(t, fake.acls, 1)
+	assert.Equal(t, "User:jdoe", fake.acls[0].Principal)
+}
+
+func TestReconcileACLs(t *testing.T) {
+	fake := &fakeKafka{acls: []ACL{
+		{ResourceType: "TOPIC", ResourceName: "legacy", PatternType: "LITERAL", Principal: "Group:dataeng",
+			Host: "*", Operation: "READ", Permission: "ALLOW"},
+		// the DENY ACLs are not managed
+		{ResourceType: "TOPIC", ResourceName: "payments", PatternType: "LITERAL", Principal: "Group:dataeng",
+			Host: "*", Operation: "READ", Permission: "DENY"},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "Group:dataeng", structs.TeamParams{
+		Property: "topics", Value: []string{"orders.*:read-write", "clicks"},
+	}))
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "Group:dataeng", structs.TeamParams{
+		Property: "consumer_groups", Value: []string{"dataeng-*"},
+	}))
+
+	var granted []string
+	for _, acl := range fake.acls {
+		granted = append(granted, strings.Join([]string{acl.ResourceType, acl.ResourceName, acl.PatternType,
+			acl.Operation, acl.Permission}, " "))
+	}
+	assert.ElementsMatch(t, []string{
+		"TOPIC payments LITERAL READ DENY",
+		"TOPIC orders. PREFIXED READ ALLOW",
+		"TOPIC orders. PREFIXED WRITE ALLOW",
+		"TOPIC orders. PREFIXED DESCRIBE ALLOW",
+		"TOPIC clicks LITERAL READ ALLOW",
+		"TOPIC clicks LITERAL DESCRIBE ALLOW",
+		"GROUP dataeng- PREFIXED READ ALLOW",
+	}, granted)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "Group:dataeng", structs.TeamParams{
+		Property: "topics", Value: []string{"orders:admin"},
+	}), "unknown access")
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "Group:dataeng", structs.TeamParams{
+		Property: "clusters", Value: []string{"x"},
+	}), "unsupported kafka group param")
+}
+
+func TestReconcileRoleBindings(t *testing.T) {
+	fake := &fakeKafka{bindings: map[string]map[string][]ResourcePattern{
+		"Group:dataeng": {
+			"DeveloperRead": {{ResourceType: "Topic", Name: "legacy", PatternType: "LITERAL"}},
+			// the other roles are not managed
+			"ResourceOwner": {{ResourceType: "Topic", Name: "owned", PatternType: "LITERAL"}},
+		},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{"mode": "rbac"})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "Group:dataeng", structs.TeamParams{
+		Property: "topics", Value: []string{"orders.*:read-write", "clicks:write"},
+	}))
+	assert.Equal(t, map[string][]ResourcePattern{
+		"DeveloperRead": {{ResourceType: "Topic", Name: "orders.", PatternType: "PREFIXED"}},
+		"DeveloperWrite": {
+			{ResourceType: "Topic", Name: "orders.", PatternType: "PREFIXED"},
+			{ResourceType: "Topic", Name: "clicks", PatternType: "LITERAL"},
+		},
+		"ResourceOwner": {{ResourceType: "Topic", Name: "owned", PatternType: "LITERAL"}},
+	}, fake.bindings["Group:dataeng"])
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "Group:dataeng", structs.TeamParams{
+		Property: "consumer_groups", Value: []string{"dataeng"},
+	}))
+	assert.Contains(t, fake.bindings["Group:dataeng"]["DeveloperRead"],
+		ResourcePattern{ResourceType: "Group", Name: "dataeng", PatternType: "LITERAL"})
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "Group:dataeng"))
+	assert.Empty(t, fake.bindings["Group:dataeng"]["DeveloperRead"])
+	assert.Empty(t, fake.bindings["Group:dataeng"]["DeveloperWrite"])
+	assert.Len(t, fake.bindings["Group:dataeng"]["ResourceOwner"], 1)
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeKafka{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+	client = newTestClient(t, &fakeKafka{}, map[string]interface{}{"mode": "rbac"})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "Basic d3Jvbmc6d3Jvbmc="
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "Unauthorized")
+}
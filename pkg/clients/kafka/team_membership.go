@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID returns no members, the members of a group principal are resolved by the
+// brokers from the directory
+func (kC *KafkaClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	return map[string]*structs.User{}, nil
+}
+
+// AddUserToTeam is a no-op, the members of a group principal are resolved by the brokers from the
+// directory
+func (kC *KafkaClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "kafka").
+		Debug("kafka group members are resolved from the directory, skipping")
+	return nil
+}
+
+// RemoveUserFromTeam is a no-op, the members of a group principal are resolved by the brokers from
+// the directory
+func (kC *KafkaClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "kafka").
+		Debug("kafka group members are resolved from the directory, skipping")
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the group principals holding ACLs of the cluster, keyed by group name. The
+// role bindings of MDS cannot be listed by principal type, in rbac mode no teams are listed and the
+// group principals are created again by each reconcile, which makes no API call.
+func (kC *KafkaClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.kafka.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	if kC.mode == ModeRBAC {
+		return teams, nil
+	}
+
+	resp, err := kC.sendRequest(ctx, kC.aclsPath(), http.MethodGet, nil, "backend.kafka.FetchAllTeams")
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch kafka acls")
+		return nil, err
+	}
+	var acls aclList
+	if err := decode(resp, &acls); err != nil {
+		return nil, err
+	}
+	for _, acl := range acls.Data {
+		if name, ok := strings.CutPrefix(acl.Principal, kC.principalPrefix); ok {
+			teams[name] = structs.Team{ID: acl.Principal, Name: name}
+		}
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails returns the team of the group principal
+func (kC *KafkaClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	return &structs.Team{ID: teamID, Name: strings.TrimPrefix(teamID, kC.principalPrefix)}, nil
+}
+
+// CreateTeam makes no API call and returns the group principal of the team, which is granted its
+// access by the group params
+func (kC *KafkaClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	return &structs.Team{
+		ID:          kC.principalPrefix + team.Name,
+		Name:        team.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// DeleteTeamByID revokes all the access of the group principal, its ACLs or its developer role
+// bindings
+func (kC *KafkaClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.kafka.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "kafka")
+	log.Info("Revoke the kafka access of the group principal")
+
+	if kC.mode == ModeRBAC {
+		for _, resourceType := range []string{resourceTopic, resourceGroup} {
+			if err := kC.reconcileRoleBindings(ctx, teamID, resourceType, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	query := url.Values{}
+	query.Set("principal", teamID)
+	for _, filter := range []string{"resource_type", "pattern_type", "operation", "permission"} {
+		query.Set(filter, "ANY")
+	}
+	_, err := kC.sendRequest(ctx, kC.aclsPath()+"?"+query.Encode(), http.MethodDelete, nil,
+		"backend.kafka.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("kafka acls not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
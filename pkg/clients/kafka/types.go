@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+// Modes of a Kafka backend, how the access of the group principals is granted
+const (
+	// ModeACL grants the access with the ACLs of the cluster, through the Kafka REST API v3
+	ModeACL = "acl"
+	// ModeRBAC grants the access with the role bindings of Confluent RBAC, through the Metadata
+	// Service (MDS) API
+	ModeRBAC = "rbac"
+)
+
+const (
+	// defaultPrincipalPrefix is the type of the principals of the groups
+	defaultPrincipalPrefix = "Group:"
+	// userPrincipalPrefix is the type of the principals of the users
+	userPrincipalPrefix = "User:"
+	// prefixWildcard ends the resource names matched by prefix, e.g. orders.*
+	prefixWildcard = "*"
+)
+
+// Kafka resource types and pattern types of the ACLs and role bindings
+const (
+	resourceTopic    = "TOPIC"
+	resourceGroup    = "GROUP"
+	patternLiteral   = "LITERAL"
+	patternPrefixed  = "PREFIXED"
+	permissionAllow  = "ALLOW"
+	anyHost          = "*"
+	kafkaClusterKind = "kafka-cluster"
+)
+
+// Accesses granted on a topic by the topics group param
+const (
+	AccessRead      = "read"
+	AccessWrite     = "write"
+	AccessReadWrite = "read-write"
+)
+
+// KafkaConfig is the connection of a Kafka backend, read from the backend configuration
+type KafkaConfig struct {
+	// Mode is acl (default) to manage the ACLs of the cluster, or rbac to manage Confluent RBAC
+	// role bindings
+	Mode string `json:"mode"`
+	// URL is the Kafka REST API of the cluster (Confluent Cloud or Confluent REST Proxy) in acl
+	// mode, or the Metadata Service in rbac mode
+	URL string `json:"url"`
+	// ClusterID is the ID of the Kafka cluster
+	ClusterID string `json:"cluster_id"`
+	// APIKey and APISecret are the credentials of the API, a Confluent Cloud API key or the user
+	// and password of the REST Proxy or MDS
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret"`
+	// PrincipalPrefix is the type of the principals of the groups, Group: by default
+	PrincipalPrefix string `json:"principal_prefix"`
+}
+
+// ACL is an ACL of the cluster, an operation allowed to a principal on the resources of a pattern
+type ACL struct {
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	PatternType  string `json:"pattern_type"`
+	Principal    string `json:"principal"`
+	Host         string `json:"host"`
+	Operation    string `json:"operation"`
+	Permission   string `json:"permission"`
+}
+
+// aclList is the response of the ACL search of the Kafka REST API
+type aclList struct {
+	Data []ACL `json:"data"`
+}
+
+// ResourcePattern is a resource pattern of a Confluent RBAC role binding
+type ResourcePattern struct {
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	PatternType  string `json:"patternType"`
+}
+
+// roleBindings is a request binding a role to a principal on resource patterns of the cluster
+type roleBindings struct {
+	Scope            scope             `json:"scope"`
+	ResourcePatterns []ResourcePattern `json:"resourcePatterns"`
+}
+
+// scope is the scope of a role binding, the Kafka cluster
+type scope struct {
+	Clusters map[string]string `json:"clusters"`
+}
+
+// errorResponse is the error response of the Kafka REST API and of MDS
+type errorResponse struct {
+	ErrorCode    int    `json:"error_code"`
+	Message      string `json:"message"`
+	StatusCode   int    `json:"status_code"`
+	ErrorMessage string `json:"error_message"`
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers returns no users, Kafka has no users of its own, its principals are authenticated
+// by the brokers
+func (kC *KafkaClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	return map[string]*structs.User{}, map[string]*structs.User{}, nil
+}
+
+// FetchUserDetails returns the user of the principal
+func (kC *KafkaClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	return &structs.User{ID: userID, UserName: userID}, nil
+}
+
+// CreateUser makes no API call and returns the user principal of the username, the user is known
+// to the brokers once it authenticates
+func (kC *KafkaClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	if u.UserName == "" {
+		return nil, fmt.Errorf("kafka users are principals named by their username, user %s has none", u.Email)
+	}
+	return &structs.User{
+		ID:          userPrincipalPrefix + u.UserName,
+		UserName:    u.UserName,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		DisplayName: u.DisplayName,
+	}, nil
+}
+
+// DeleteUser is a no-op, the access of an offboarded user is revoked by removing it from the groups
+// of the directory
+func (kC *KafkaClient) DeleteUser(ctx context.Context, userID string) error {
+	logger.Logger(ctx).WithField("userID", userID).WithField("service", "kafka").
+		Info("kafka users are not managed, skipping user deletion")
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// KeycloakClient manages the groups of a Keycloak realm and their members through the Admin REST
+// API, and maps the client roles of the role client to the groups
+type KeycloakClient struct {
+	client      heimdall.Doer
+	adminURL    string
+	parentGroup string
+	roleClient  string
+	deleteUsers bool
+	auth        *tokenSource
+}
+
+func NewClient(keycloakAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*KeycloakClient, error) {
+
+	keycloakConfig := KeycloakConfig{}
+	if err := utils.MapToStruct(keycloakAppConfig, &keycloakConfig); err != nil {
+		return nil, err
+	}
+	if keycloakConfig.BaseURL == "" || keycloakConfig.Realm == "" {
+		return nil, errors.New("keycloak configuration is missing required fields: base_url or realm")
+	}
+
+	form := url.Values{}
+	switch {
+	case keycloakConfig.ClientID != "" && keycloakConfig.ClientSecret != "":
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_id", keycloakConfig.ClientID)
+		form.Set("client_secret", keycloakConfig.ClientSecret)
+	case keycloakConfig.Username != "" && keycloakConfig.Password != "":
+		clientID := keycloakConfig.ClientID
+		if clientID == "" {
+			clientID = adminClientID
+		}
+		form.Set("grant_type", "password")
+		form.Set("client_id", clientID)
+		form.Set("username", keycloakConfig.Username)
+		form.Set("password", keycloakConfig.Password)
+	default:
+		return nil, errors.New("keycloak configuration is missing the client_id and client_secret, or the username and " +
+			"password")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"keycloak_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	baseURL := strings.TrimSuffix(keycloakConfig.BaseURL, "/")
+	authRealm := keycloakConfig.AuthRealm
+	if authRealm == "" {
+		authRealm = keycloakConfig.Realm
+	}
+	parentGroup := keycloakConfig.ParentGroup
+	if parentGroup != "" && !strings.HasPrefix(parentGroup, "/") {
+		parentGroup = "/" + parentGroup
+	}
+
+	return &KeycloakClient{
+		client:      client,
+		adminURL:    fmt.Sprintf("%s/admin/realms/%s", baseURL, url.PathEscape(keycloakConfig.Realm)),
+		parentGroup: parentGroup,
+		roleClient:  keycloakConfig.RoleClient,
+		deleteUsers: keycloakConfig.DeleteUsers,
+		auth: &tokenSource{
+			client: client,
+			url: fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", baseURL,
+				url.PathEscape(authRealm)),
+			form: form.Encode(),
+		},
+	}, nil
+}
+
+// HealthCheck lists a single group, the Admin REST API is healthy when it answers with a token of
+// the configured credentials
+func (kC *KeycloakClient) HealthCheck(ctx context.Context) error {
+	var groups []Group
+	if err := kC.get(ctx, "/groups?briefRepresentation=true&first=0&max=1", &groups,
+		"backend.keycloak.HealthCheck"); err != nil {
+		return fmt.Errorf("keycloak health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path of the Admin REST API and decodes its response
+func (kC *KeycloakClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := kC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a Keycloak request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode keycloak response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the Admin REST API and returns the response body
+func (kC *KeycloakClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := kC.send(ctx, path, method, body, methodName)
+	return resp, err
+}
+
+// create sends the creation request to the path of the Admin REST API and returns the ID of the
+// created resource, read from the Location header of the response
+func (kC *KeycloakClient) create(ctx context.Context, resourcePath string, body any,
+	methodName string) (string, error) {
+	resp, headers, err := kC.send(ctx, resourcePath, http.MethodPost, body, methodName)
+	if err != nil {
+		return "", err
+	}
+	if location := headers.Get("Location"); location != "" {
+		return path.Base(location), nil
+	}
+	// the older servers answer the creation of a subgroup with the group instead
+	var created struct {
+		ID string `json:"id"`
+	}
+	if len(resp) > 0 && json.Unmarshal(resp, &created) == nil && created.ID != "" {
+		return created.ID, nil
+	}
+	return "", fmt.Errorf("no location in the keycloak response of %s", methodName)
+}
+
+// send sends the request with the access token of the client, any response code other than 2xx
+// is returned as a *request.ResponseError
+func (kC *KeycloakClient) send(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, http.Header, error) {
+
+	token, err := kC.auth.Token(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var requestBody []byte
+	if body != nil {
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return doRequest(ctx, kC.client, kC.adminURL+path, method, requestBody, map[string]string{
+		constants.ContentTypeHeaderKey: "application/json",
+		"Accept":                       "application/json",
+		"Authorization":                "Bearer " + token,
+	}, methodName)
+}
+
+func doRequest(ctx context.Context, client heimdall.Doer, url string, method string, requestBody []byte,
+	headers map[string]string, methodName string) ([]byte, http.Header, error) {
+	return request.Send(ctx, client, method, url, requestBody, headers, methodName, "keycloak", errorMessage)
+}
+
+// tokenSource hands out the access token of the requests, renewed shortly before it expires
+type tokenSource struct {
+	client heimdall.Doer
+	url    string
+	// form is the encoded form of the token request, with the grant of the credentials
+	form string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// Token returns the access token of the requests, requesting a new one when the current one is
+// about to expire
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && time.Until(ts.expiresAt) > tokenRefreshMargin {
+		return ts.token, nil
+	}
+
+	resp, _, err := doRequest(ctx, ts.client, ts.url, http.MethodPost, []byte(ts.form), map[string]string{
+		constants.ContentTypeHeaderKey: "application/x-www-form-urlencoded",
+		"Accept":                       "application/json",
+	}, "backend.keycloak.FetchToken")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch keycloak access token: %w", err)
+	}
+	var token tokenResponse
+	if err := decode(resp, &token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("no access token in the keycloak token response")
+	}
+	ts.token = token.AccessToken
+	ts.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return ts.token, nil
+}
+
+// errorMessage returns the message of an error response of Keycloak, or of its token endpoint
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	switch {
+	case errResp.ErrorMessage != "":
+		return "", errResp.ErrorMessage
+	case errResp.ErrorDescription != "":
+		return "", errResp.ErrorDescription
+	default:
+		return "", errResp.Error
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// groupParamClientRoles is the group param property listing the roles of the role client mapped
+// to the group
+const groupParamClientRoles = "client_roles"
+
+// ReconcileGroupParams maps exactly the client roles of the client_roles group param to the group,
+// the roles of the role client mapped to the group and missing from the group param are unmapped
+func (kC *KeycloakClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamClientRoles {
+		return fmt.Errorf("unsupported keycloak group param: %s", groupParams.Property)
+	}
+	if kC.roleClient == "" {
+		return errors.New("keycloak backend has no role_client to map the client roles of")
+	}
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "keycloak").
+		WithField("roleClient", kC.roleClient)
+
+	client, err := kC.fetchRoleClient(ctx)
+	if err != nil {
+		return err
+	}
+	mappingsPath := fmt.Sprintf("/groups/%s/role-mappings/clients/%s", url.PathEscape(teamID), url.PathEscape(client.ID))
+
+	var mapped []Role
+	if err := kC.get(ctx, mappingsPath, &mapped, "backend.keycloak.ReconcileGroupParams"); err != nil {
+		return err
+	}
+
+	var added, removed []Role
+	for _, roleName := range groupParams.Value {
+		if slices.ContainsFunc(mapped, func(role Role) bool { return role.Name == roleName }) {
+			continue
+		}
+		var role Role
+		if err := kC.get(ctx, fmt.Sprintf("/clients/%s/roles/%s", url.PathEscape(client.ID), url.PathEscape(roleName)),
+			&role, "backend.keycloak.ReconcileGroupParams"); err != nil {
+			if request.IsNotFound(err) {
+				return fmt.Errorf("keycloak client %s has no role %s: %w", kC.roleClient, roleName, err)
+			}
+			return err
+		}
+		added = append(added, role)
+	}
+	for _, role := range mapped {
+		if !slices.Contains(groupParams.Value, role.Name) {
+			removed = append(removed, role)
+		}
+	}
+
+	if len(added) > 0 {
+		log.WithField("roles", roleNames(added)).Info("Map keycloak client roles to the group")
+		if _, err := kC.sendRequest(ctx, mappingsPath, http.MethodPost, added,
+			"backend.keycloak.ReconcileGroupParams"); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		log.WithField("roles", roleNames(removed)).Info("Unmap keycloak client roles from the group")
+		if _, err := kC.sendRequest(ctx, mappingsPath, http.MethodDelete, removed,
+			"backend.keycloak.ReconcileGroupParams"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchRoleClient fetches the role client by its client ID
+func (kC *KeycloakClient) fetchRoleClient(ctx context.Context) (*Client, error) {
+	var clients []Client
+	if err := kC.get(ctx, "/clients?clientId="+url.QueryEscape(kC.roleClient), &clients,
+		"backend.keycloak.ReconcileGroupParams"); err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		if client.ClientID == kC.roleClient {
+			return &client, nil
+		}
+	}
+	return nil, fmt.Errorf("keycloak role client %s not found", kC.roleClient)
+}
+
+func roleNames(roles []Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const adminPath = "/admin/realms/corp"
+
+// fakeKeycloak is a Keycloak realm holding its users, groups and client role mappings in memory
+type fakeKeycloak struct {
+	tokens      int
+	users       []User
+	members     map[string]bool
+	roleMapping []Role
+	deleted     []string
+}
+
+func (f *fakeKeycloak) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/realms/master/protocol/openid-connect/token" {
+		_ = r.ParseForm()
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "unauthorized_client", "error_description": "Invalid client credentials"}`))
+			return
+		}
+		f.tokens++
+		_, _ = w.Write([]byte(`{"access_token": "access-token", "expires_in": 300}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("max"))
+	resource := strings.TrimPrefix(r.URL.Path, adminPath)
+	switch {
+	case r.Method == http.MethodGet && resource == "/users":
+		start := min(first, len(f.users))
+		fakehttp.WriteJSON(w, http.StatusOK, f.users[start:min(start+count, len(f.users))])
+	case r.Method == http.MethodPost && resource == "/users":
+		var user User
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		for _, existing := range f.users {
+			if existing.Username == user.Username {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"errorMessage": "User exists with same username"}`))
+				return
+			}
+		}
+		user.ID = fmt.Sprintf("u-%d", len(f.users)+1)
+		f.users = append(f.users, user)
+		w.Header().Set("Location", "http://"+r.Host+adminPath+"/users/"+user.ID)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && resource == "/groups":
+		_, _ = w.Write([]byte(`[{"id": "g-0", "name": "admins", "path": "/admins"}]`))
+	case r.Method == http.MethodGet && resource == "/group-by-path/usernaut":
+		_, _ = w.Write([]byte(`{"id": "g-parent", "name": "usernaut", "path": "/usernaut"}`))
+	case r.Method == http.MethodGet && resource == "/groups/g-parent/children":
+		_, _ = w.Write([]byte(`[{"id": "g-1", "name": "team-a", "path": "/usernaut/team-a"}]`))
+	case r.Method == http.MethodPost && resource == "/groups/g-parent/children":
+		w.Header().Set("Location", "http://"+r.Host+adminPath+"/groups/g-2")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && resource == "/groups/g-1/members":
+		var users []User
+		for _, u := range f.users {
+			if f.members[u.ID] {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, users)
+	case strings.HasPrefix(resource, "/users/") && strings.HasSuffix(resource, "/groups/g-1"):
+		userID := strings.Split(resource, "/")[2]
+		if r.Method == http.MethodPut {
+			f.members[userID] = true
+		} else {
+			delete(f.members, userID)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && resource == "/clients" && r.URL.Query().Get("clientId") == "portal":
+		_, _ = w.Write([]byte(`[{"id": "c-1", "clientId": "portal"}]`))
+	case r.Method == http.MethodGet && strings.HasPrefix(resource, "/clients/c-1/roles/"):
+		name := strings.TrimPrefix(resource, "/clients/c-1/roles/")
+		if name == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "Could not find role"}`))
+			return
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, Role{ID: "r-" + name, Name: name})
+	case resource == "/groups/g-1/role-mappings/clients/c-1":
+		var roles []Role
+		_ = json.NewDecoder(r.Body).Decode(&roles)
+		switch r.Method {
+		case http.MethodGet:
+			fakehttp.WriteJSON(w, http.StatusOK, f.roleMapping)
+			return
+		case http.MethodPost:
+			f.roleMapping = append(f.roleMapping, roles...)
+		case http.MethodDelete:
+			for _, role := range roles {
+				for i, mapped := range f.roleMapping {
+					if mapped.ID == role.ID {
+						f.roleMapping = append(f.roleMapping[:i], f.roleMapping[i+1:]...)
+						break
+					}
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		f.deleted = append(f.deleted, resource)
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeKeycloak, connection map[string]interface{}) *KeycloakClient {
+	t.Helper()
+	if fake.members == nil {
+		fake.members = map[string]bool{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["base_url"] = server.URL + "/"
+	connection["realm"] = "corp"
+	connection["auth_realm"] = "master"
+	connection["client_id"] = "usernaut"
+	if _, ok := connection["client_secret"]; !ok {
+		connection["client_secret"] = "secret"
+	}
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"base_url": "http://localhost"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "base_url or realm")
+
+	_, err = NewClient(map[string]interface{}{"base_url": "http://localhost", "realm": "corp", "client_id": "usernaut"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "missing the client_id and client_secret")
+
+	client, err := NewClient(map[string]interface{}{"base_url": "http://localhost", "realm": "corp",
+		"username": "admin", "password": "admin"}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	require.NoError(t, err)
+	assert.Contains(t, client.auth.form, "client_id=admin-cli")
+	assert.Contains(t, client.auth.form, "grant_type=password")
+	assert.Equal(t, "http://localhost/realms/corp/protocol/openid-connect/token", client.auth.url)
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeKeycloak{}
+	for i := 1; i <= pageSize+1; i++ {
+		fake.users = append(fake.users, User{ID: fmt.Sprintf("u-%d", i), Username: fmt.Sprintf("user%d", i),
+			Email: fmt.Sprintf("user%d@example.com", i)})
+	}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, pageSize+1)
+	assert.Equal(t, "u-101", byEmail["user101@example.com"].ID)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com",
+		FirstName: "John", LastName: "Doe"})
+	require.NoError(t, err)
+	assert.Equal(t, "u-102", user.ID)
+	assert.Equal(t, "John Doe", user.DisplayName)
+	assert.True(t, fake.users[pageSize+1].Enabled)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+	assert.ErrorContains(t, err, "User exists with same username")
+	// the access token is reused until it expires
+	assert.Equal(t, 1, fake.tokens)
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeKeycloak{}
+	client := newTestClient(t, fake, map[string]interface{}{"parent_group": "usernaut"})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"team-a": {ID: "g-1", Name: "team-a"}}, teams)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "team-b"})
+	require.NoError(t, err)
+	assert.Equal(t, "g-2", team.ID)
+
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "g-2"))
+	assert.NoError(t, client.DeleteUser(context.Background(), "u-9"))
+	assert.Equal(t, []string{"/groups/g-2"}, fake.deleted, "Expected the users not to be deleted by default")
+
+	client = newTestClient(t, fake, map[string]interface{}{"delete_users": true})
+	assert.NoError(t, client.DeleteUser(context.Background(), "u-9"))
+	assert.Equal(t, []string{"/groups/g-2", "/users/u-9"}, fake.deleted)
+}
+
+func TestTeamMembership(t *testing.T) {
+	fake := &fakeKeycloak{users: []User{{ID: "u-1", Username: "jdoe"}, {ID: "u-2", Username: "asmith"}}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "g-1", []string{"u-1", "u-2"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "g-1", []string{"u-2"}))
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g-1")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, "jdoe", members["u-1"].UserName)
+}
+
+func TestReconcileClientRoles(t *testing.T) {
+	fake := &fakeKeycloak{roleMapping: []Role{{ID: "r-viewer", Name: "viewer"}, {ID: "r-admin", Name: "admin"}}}
+	client := newTestClient(t, fake, map[string]interface{}{"role_client": "portal"})
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "g-1", structs.TeamParams{
+		Property: "client_roles", Value: []string{"viewer", "editor"},
+	}))
+	assert.Equal(t, []Role{{ID: "r-viewer", Name: "viewer"}, {ID: "r-editor", Name: "editor"}}, fake.roleMapping)
+
+	err := client.ReconcileGroupParams(context.Background(), "g-1", structs.TeamParams{
+		Property: "client_roles", Value: []string{"unknown"},
+	})
+	assert.ErrorContains(t, err, "keycloak client portal has no role unknown")
+
+	client.roleClient = ""
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "g-1", structs.TeamParams{
+		Property: "client_roles", Value: []string{"viewer"},
+	}), "no role_client")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeKeycloak{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client = newTestClient(t, &fakeKeycloak{}, map[string]interface{}{"client_secret": "wrong"})
+	err := client.HealthCheck(context.Background())
+	var respErr *request.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusUnauthorized, respErr.StatusCode)
+	assert.Equal(t, "Invalid client credentials", respErr.Message)
+}
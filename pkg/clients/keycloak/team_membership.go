@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchTeamMembersByTeamID lists the direct members of the group by ID, keyed by ID
+func (kC *KeycloakClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members := make(map[string]*structs.User)
+	err := forEachPage(ctx, kC, fmt.Sprintf("/groups/%s/members?briefRepresentation=true", url.PathEscape(teamID)),
+		"backend.keycloak.FetchTeamMembersByTeamID", func(users []User) error {
+			for _, u := range users {
+				members[u.ID] = userDetails(&u)
+			}
+			return nil
+		})
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch keycloak group members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam joins the users to the group, Keycloak has no bulk membership endpoint
+func (kC *KeycloakClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.AddUserToTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "keycloak")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Join keycloak group")
+		_, err := kC.sendRequest(ctx, membershipPath(teamID, userID), http.MethodPut, nil,
+			"backend.keycloak.AddUserToTeam")
+		if err != nil {
+			return fmt.Errorf("failed to add user %s to keycloak group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveUserFromTeam makes the users leave the group one at a time, Keycloak answers 404 for a user
+// who is not in the group, which is skipped
+func (kC *KeycloakClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.RemoveUserFromTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "keycloak")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Leave keycloak group")
+		_, err := kC.sendRequest(ctx, membershipPath(teamID, userID), http.MethodDelete, nil,
+			"backend.keycloak.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s from keycloak group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// membershipPath returns the path of the membership of the user in the group
+func membershipPath(teamID, userID string) string {
+	return fmt.Sprintf("/users/%s/groups/%s", url.PathEscape(userID), url.PathEscape(teamID))
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the top level groups of the realm, or the subgroups of the parent group,
+// keyed by name
+func (kC *KeycloakClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := kC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch keycloak groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the groups, 100 groups at a time
+func (kC *KeycloakClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	groupsPath := "/groups?briefRepresentation=true"
+	if kC.parentGroup != "" {
+		parent, err := kC.fetchParentGroup(ctx, "backend.keycloak.FetchAllTeams")
+		if err != nil {
+			return err
+		}
+		groupsPath = fmt.Sprintf("/groups/%s/children?briefRepresentation=true", url.PathEscape(parent.ID))
+	}
+	return forEachPage(ctx, kC, groupsPath, "backend.keycloak.FetchAllTeams", func(groups []Group) error {
+		page := make([]structs.Team, 0, len(groups))
+		for _, group := range groups {
+			page = append(page, structs.Team{ID: group.ID, Name: group.Name})
+		}
+		return fn(page)
+	})
+}
+
+// FetchTeamDetails fetches the group by ID
+func (kC *KeycloakClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.FetchTeamDetails")
+	defer span.Finish()
+
+	var group Group
+	if err := kC.get(ctx, "/groups/"+url.PathEscape(teamID), &group, "backend.keycloak.FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+	return &structs.Team{ID: group.ID, Name: group.Name}, nil
+}
+
+// CreateTeam creates the group, as a subgroup of the parent group when the backend has one.
+// Keycloak groups have no description.
+func (kC *KeycloakClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "keycloak")
+	log.Info("Create keycloak group")
+
+	groupsPath := "/groups"
+	if kC.parentGroup != "" {
+		parent, err := kC.fetchParentGroup(ctx, "backend.keycloak.CreateTeam")
+		if err != nil {
+			return nil, err
+		}
+		groupsPath = fmt.Sprintf("/groups/%s/children", url.PathEscape(parent.ID))
+	}
+
+	groupID, err := kC.create(ctx, groupsPath, &Group{Name: team.Name}, "backend.keycloak.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create keycloak group")
+		return nil, err
+	}
+	return &structs.Team{
+		ID:          groupID,
+		Name:        team.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// DeleteTeamByID deletes the group by ID with its subgroups, a group which does not exist is
+// considered deleted
+func (kC *KeycloakClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "keycloak")
+	log.Info("Delete keycloak group")
+
+	_, err := kC.sendRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.keycloak.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("keycloak group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// fetchParentGroup fetches the parent group of the backend by path
+func (kC *KeycloakClient) fetchParentGroup(ctx context.Context, methodName string) (*Group, error) {
+	segments := strings.Split(strings.TrimPrefix(kC.parentGroup, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	var parent Group
+	if err := kC.get(ctx, "/group-by-path/"+strings.Join(segments, "/"), &parent, methodName); err != nil {
+		if request.IsNotFound(err) {
+			return nil, fmt.Errorf("keycloak parent group %s not found: %w", kC.parentGroup, err)
+		}
+		return nil, err
+	}
+	return &parent, nil
+}
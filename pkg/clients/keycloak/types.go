@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import "time"
+
+const (
+	// pageSize is the number of resources requested per list page of the Admin REST API
+	pageSize = 100
+	// adminClientID is the client of the admin console, used for the password grant when no
+	// client_id is configured
+	adminClientID = "admin-cli"
+	// tokenRefreshMargin is how long before its expiry the access token is renewed
+	tokenRefreshMargin = 30 * time.Second
+)
+
+// KeycloakConfig is the connection of a Keycloak backend, read from the backend configuration. The
+// client authenticates with the client credentials of a service account client, or with the
+// username and password of an admin user.
+type KeycloakConfig struct {
+	// BaseURL is the URL of the Keycloak server, including the /auth context path of the servers
+	// serving it
+	BaseURL string `json:"base_url"`
+	// Realm is the realm whose groups are managed
+	Realm string `json:"realm"`
+	// AuthRealm is the realm the client authenticates against, it defaults to the managed realm
+	AuthRealm string `json:"auth_realm"`
+	// ClientID is the client authenticating the requests
+	ClientID string `json:"client_id"`
+	// ClientSecret is the secret of the client, for the client credentials grant
+	ClientSecret string `json:"client_secret"`
+	// Username and Password are the credentials of an admin user, for the password grant
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// ParentGroup is the path of the group the groups are created in, e.g. /usernaut, only its
+	// subgroups are then listed
+	ParentGroup string `json:"parent_group"`
+	// RoleClient is the client ID of the client whose roles are mapped to the groups with the
+	// client_roles group param
+	RoleClient string `json:"role_client"`
+	// DeleteUsers deletes the offboarded users from the realm, otherwise they are only removed from
+	// the groups. The users of a realm federating the LDAP directory are usually not its own to
+	// delete.
+	DeleteUsers bool `json:"delete_users"`
+}
+
+// User is a user of the realm
+type User struct {
+	ID        string `json:"id,omitempty"`
+	Username  string `json:"username"`
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// Group is a group of the realm
+type Group struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+// Client is a client of the realm, its ID is the internal ID of the client and its ClientID the
+// one of the OAuth flows
+type Client struct {
+	ID       string `json:"id"`
+	ClientID string `json:"clientId"`
+}
+
+// Role is a role of a client
+type Role struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// tokenResponse is the response of the token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// errorResponse is the body of the Admin REST API errors, and of the token endpoint errors
+type errorResponse struct {
+	ErrorMessage     string `json:"errorMessage"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
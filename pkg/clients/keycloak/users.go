@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the users of the realm, keyed by ID and by email
+func (kC *KeycloakClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := kC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch keycloak users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users of the realm, 100 users at a time
+func (kC *KeycloakClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachPage(ctx, kC, "/users?briefRepresentation=true", "backend.keycloak.FetchAllUsers",
+		func(keycloakUsers []User) error {
+			page := make([]*structs.User, 0, len(keycloakUsers))
+			for _, u := range keycloakUsers {
+				page = append(page, userDetails(&u))
+			}
+			return fn(page)
+		})
+}
+
+// FetchUserDetails fetches the user by ID
+func (kC *KeycloakClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := kC.get(ctx, "/users/"+url.PathEscape(userID), &user, "backend.keycloak.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser creates the enabled user in the realm. The users of the realms federating an LDAP
+// directory usually exist already, Keycloak then answers with a conflict.
+func (kC *KeycloakClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "keycloak")
+	log.Info("Create keycloak user")
+
+	user := &User{
+		Username:  u.UserName,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Enabled:   true,
+	}
+	userID, err := kC.create(ctx, "/users", user, "backend.keycloak.CreateUser")
+	if err != nil {
+		if request.IsConflict(err) {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		log.WithError(err).Error("failed to create keycloak user")
+		return nil, err
+	}
+	user.ID = userID
+	return userDetails(user), nil
+}
+
+// DeleteUser deletes the user by ID when the backend deletes the users, a user which does not
+// exist is considered deleted
+func (kC *KeycloakClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.keycloak.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "keycloak")
+	if !kC.deleteUsers {
+		log.Info("keycloak users are not deleted, skipping user deletion")
+		return nil
+	}
+
+	log.Info("Delete keycloak user")
+
+	_, err := kC.sendRequest(ctx, "/users/"+url.PathEscape(userID), http.MethodDelete, nil, "backend.keycloak.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("keycloak user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// userDetails converts the Keycloak user
+func userDetails(u *User) *structs.User {
+	displayName := u.FirstName
+	if u.LastName != "" {
+		displayName = fmt.Sprintf("%s %s", u.FirstName, u.LastName)
+	}
+	return &structs.User{
+		ID:          u.ID,
+		UserName:    u.Username,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		DisplayName: displayName,
+	}
+}
+
+// forEachPage calls fn with each page of the resources of the path, the Admin REST API pages are
+// offsets and the listing ends with a short page
+func forEachPage[T any](ctx context.Context, kC *KeycloakClient, path string, methodName string,
+	fn func(page []T) error) error {
+
+	for first := 0; ; first += pageSize {
+		var page []T
+		if err := kC.get(ctx, fmt.Sprintf("%s&first=%d&max=%d", path, first, pageSize), &page, methodName); err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
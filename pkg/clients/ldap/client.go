@@ -2,12 +2,21 @@ package ldap
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	v1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type LDAP struct {
@@ -17,12 +26,66 @@ type LDAP struct {
 	UserDN           string   `yaml:"userDN"`
 	UserSearchFilter string   `yaml:"userSearchFilter"`
 	Attributes       []string `yaml:"attributes"`
+	// BindDN and BindPassword authenticate the connection with a simple bind, the connection is
+	// bound anonymously when BindDN is empty. The password is read from a secret with 'file|/path'
+	// or 'env|VAR'.
+	BindDN       string   `yaml:"bindDN"`
+	BindPassword string   `yaml:"bindPassword"`
+	SASL         LDAPSASL `yaml:"sasl"`
+	TLS          LDAPTLS  `yaml:"tls"`
+	// PageSize is the number of entries per page of the subtree searches, which are sent with the
+	// paged results control to stay under the size limit of the server. Defaults to 500.
+	PageSize uint32 `yaml:"pageSize"`
+	// UserStatus decides which entries are inactive users, for the offboarding
+	UserStatus LDAPUserStatus `yaml:"userStatus"`
+	// FollowReferrals follows the referrals returned by the searches, up to MaxReferralHops
+	// referrals in a row (3 by default), instead of failing the search
+	FollowReferrals bool `yaml:"followReferrals"`
+	MaxReferralHops int  `yaml:"maxReferralHops"`
+	// Retry retries the searches failing with a transient error
+	Retry LDAPRetry `yaml:"retry"`
+	// Watch reports the users deleted or disabled in LDAP to the offboarding as they change
+	Watch LDAPWatch `yaml:"watch"`
 }
 
+// LDAPTLS configures the TLS of the connection, used by the ldaps:// servers and by StartTLS
+type LDAPTLS struct {
+	// StartTLS upgrades the plaintext connection of an ldap:// server to TLS before binding
+	StartTLS bool `yaml:"startTLS"`
+	// CAPath is the PEM bundle of the CAs trusted for the server certificate, in addition to the
+	// system pool
+	CAPath string `yaml:"caPath"`
+	// InsecureSkipVerify disables the verification of the server certificate, for tests only
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify"`
+}
+
+// LDAPSASL configures a SASL bind of the connection, which takes precedence over the simple bind
+type LDAPSASL struct {
+	// Mechanism is EXTERNAL, authenticating with the TLS client certificate, or DIGEST-MD5,
+	// authenticating Username with the BindPassword
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	// CertPath and KeyPath are the TLS client certificate presented to an ldaps server
+	CertPath string `yaml:"certPath"`
+	KeyPath  string `yaml:"keyPath"`
+}
+
+const (
+	SASLMechanismExternal  = "EXTERNAL"
+	SASLMechanismDigestMD5 = "DIGEST-MD5"
+
+	defaultPageSize        uint32 = 500
+	defaultMaxReferralHops        = 3
+)
+
 type LDAPConnClient interface {
 	IsClosing() bool
 	Search(*ldap.SearchRequest) (*ldap.SearchResult, error)
+	SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
 	UnauthenticatedBind(username string) error
+	Bind(username, password string) error
+	ExternalBind() error
+	MD5Bind(host, username, password string) error
 }
 
 type LDAPConn struct {
@@ -33,62 +96,280 @@ type LDAPConn struct {
 	server           string
 	userSearchFilter string
 	attributes       []string
+	bindDN           string
+	bindPassword     string
+	sasl             LDAPSASL
+	tlsConfig        *tls.Config
+	startTLS         bool
+	pageSize         uint32
+	userStatus       userStatus
+	// maxReferralHops is the number of referrals followed in a row, 0 when not following them
+	maxReferralHops int
+	// dialReferral opens a bound connection to the server of a referral
+	dialReferral func(server string) (referralConn, error)
+	retry        retryPolicy
+	// dialWatch opens the dedicated connection of the watch, nil when the watch is not enabled
+	dialWatch    func() (syncConn, error)
+	watchBackoff time.Duration
 }
 
 type LDAPClient interface {
 	GetUserLDAPData(ctx context.Context, userID string) (map[string]interface{}, error)
+	GetUsersLDAPData(ctx context.Context, userIDs []string) (map[string]map[string]interface{}, error)
 	GetQueryMembers(ctx context.Context, query string) ([]string, error)
 	BuildLDAPQueryFromSpec(ctx context.Context, query *v1alpha1.LDAPQuery) (string, error)
 	GetUserLDAPDataByEmail(ctx context.Context, email string) (map[string]interface{}, error)
+	GetGroupMembers(ctx context.Context, groupDN string) ([]string, error)
+	HealthCheck(ctx context.Context) error
+	WatchUsers(ctx context.Context, handler func(ctx context.Context, emails []string)) error
 }
 
 // InitLdap initializes a connection to the LDAP server using the provided configuration.
 func InitLdap(ldapConfig LDAP) (LDAPClient, error) {
-	ldapConn, err := ldap.DialURL(ldapConfig.Server, ldap.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}))
+	l := &LDAPConn{
+		server:           ldapConfig.Server,
+		userDN:           ldapConfig.UserDN,
+		baseDN:           ldapConfig.BaseDN,
+		baseUserDN:       ldapConfig.BaseUserDN,
+		userSearchFilter: ldapConfig.UserSearchFilter,
+		attributes:       ldapConfig.Attributes,
+		bindDN:           ldapConfig.BindDN,
+		bindPassword:     ldapConfig.BindPassword,
+		sasl:             ldapConfig.SASL,
+		pageSize:         ldapConfig.PageSize,
+	}
+	if l.pageSize == 0 {
+		l.pageSize = defaultPageSize
+	}
+	if ldapConfig.FollowReferrals {
+		l.maxReferralHops = ldapConfig.MaxReferralHops
+		if l.maxReferralHops <= 0 {
+			l.maxReferralHops = defaultMaxReferralHops
+		}
+		l.dialReferral = func(server string) (referralConn, error) {
+			return l.dialURL(server)
+		}
+	}
+
+	if err := l.validateBind(); err != nil {
+		return nil, err
+	}
+	status, err := newUserStatus(ldapConfig.UserStatus)
+	if err != nil {
+		return nil, err
+	}
+	l.userStatus = status
+	retry, err := newRetryPolicy(ldapConfig.Retry)
+	if err != nil {
+		return nil, err
+	}
+	l.retry = retry
+	if ldapConfig.Watch.Enabled {
+		backoff, err := newWatchBackoff(ldapConfig.Watch)
+		if err != nil {
+			return nil, err
+		}
+		l.watchBackoff = backoff
+		l.dialWatch = func() (syncConn, error) {
+			return l.dial()
+		}
+	}
+	tlsConfig, err := newTLSConfig(ldapConfig)
+	if err != nil {
+		return nil, err
+	}
+	l.tlsConfig = tlsConfig
+	l.startTLS = ldapConfig.TLS.StartTLS
+
+	ldapConn, err := l.dial()
 	if err != nil {
 		return nil, err
 	}
+	l.conn = ldapConn
+	return l, nil
+}
+
+// validateBind checks the bind config of the connection
+func (l *LDAPConn) validateBind() error {
+	switch l.sasl.Mechanism {
+	case "":
+		if l.bindDN != "" && l.bindPassword == "" {
+			return errors.New("LDAP bindPassword is required with bindDN")
+		}
+	case SASLMechanismExternal:
+	case SASLMechanismDigestMD5:
+		if l.sasl.Username == "" || l.bindPassword == "" {
+			return errors.New("LDAP sasl username and bindPassword are required with DIGEST-MD5")
+		}
+	default:
+		return fmt.Errorf("unsupported LDAP sasl mechanism %q, must be %s or %s",
+			l.sasl.Mechanism, SASLMechanismExternal, SASLMechanismDigestMD5)
+	}
+	return nil
+}
+
+// newTLSConfig builds the TLS config of the connection from the TLS and SASL config, it returns nil
+// when they configure nothing so that the defaults of the LDAP library apply
+func newTLSConfig(ldapConfig LDAP) (*tls.Config, error) {
+	tlsOptions := ldapConfig.TLS
+	if tlsOptions.StartTLS && strings.HasPrefix(ldapConfig.Server, "ldaps://") {
+		return nil, errors.New("LDAP startTLS cannot be used with an ldaps:// server")
+	}
+	if !tlsOptions.StartTLS && tlsOptions.CAPath == "" && !tlsOptions.InsecureSkipVerify &&
+		ldapConfig.SASL.CertPath == "" && ldapConfig.SASL.KeyPath == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: tlsOptions.InsecureSkipVerify,
+	}
+	if serverURL, err := url.Parse(ldapConfig.Server); err == nil {
+		tlsConfig.ServerName = serverURL.Hostname()
+	}
+
+	if tlsOptions.CAPath != "" {
+		caBundle, err := os.ReadFile(tlsOptions.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read LDAP CA bundle: %w", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificate found in LDAP CA bundle %s", tlsOptions.CAPath)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
 
-	// Perform anonymous bind (equivalent to ldapsearch -x)
-	err = ldapConn.UnauthenticatedBind("")
+	if ldapConfig.SASL.CertPath != "" || ldapConfig.SASL.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(ldapConfig.SASL.CertPath, ldapConfig.SASL.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load LDAP client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// dial opens a new connection to the LDAP server, upgrades it with StartTLS when enabled and binds it
+func (l *LDAPConn) dial() (*ldap.Conn, error) {
+	return l.dialURL(l.server)
+}
+
+// dialURL opens a new bound connection to the LDAP server at the URL, like dial
+func (l *LDAPConn) dialURL(server string) (*ldap.Conn, error) {
+	opts := []ldap.DialOpt{ldap.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second})}
+	tlsConfig := l.tlsConfig
+	if tlsConfig != nil && server != l.server {
+		// the certificate of a referral server is verified against its own host name
+		tlsConfig = tlsConfig.Clone()
+		if serverURL, err := url.Parse(server); err == nil {
+			tlsConfig.ServerName = serverURL.Hostname()
+		}
+	}
+	if tlsConfig != nil {
+		opts = append(opts, ldap.DialWithTLSConfig(tlsConfig))
+	}
+	ldapConn, err := ldap.DialURL(server, opts...)
 	if err != nil {
+		return nil, err
+	}
+
+	if l.startTLS {
+		if err := ldapConn.StartTLS(tlsConfig); err != nil {
+			_ = ldapConn.Close()
+			return nil, fmt.Errorf("failed to start TLS on LDAP connection: %w", err)
+		}
+	}
+
+	if err := l.bind(ldapConn); err != nil {
 		_ = ldapConn.Close()
 		return nil, fmt.Errorf("failed to bind LDAP connection: %w", err)
 	}
+	return ldapConn, nil
+}
 
-	return &LDAPConn{
-		conn:             ldapConn,
-		server:           ldapConfig.Server,
-		userDN:           ldapConfig.UserDN,
-		baseDN:           ldapConfig.BaseDN,
-		baseUserDN:       ldapConfig.BaseUserDN,
-		userSearchFilter: ldapConfig.UserSearchFilter,
-		attributes:       ldapConfig.Attributes,
-	}, nil
+// bind authenticates the connection with the SASL mechanism or the bindDN of the config, and
+// binds anonymously (equivalent to ldapsearch -x) without them
+func (l *LDAPConn) bind(conn LDAPConnClient) error {
+	switch {
+	case l.sasl.Mechanism == SASLMechanismExternal:
+		return conn.ExternalBind()
+	case l.sasl.Mechanism == SASLMechanismDigestMD5:
+		host := l.server
+		if serverURL, err := url.Parse(l.server); err == nil {
+			host = serverURL.Hostname()
+		}
+		return conn.MD5Bind(host, l.sasl.Username, l.bindPassword)
+	case l.bindDN != "":
+		return conn.Bind(l.bindDN, l.bindPassword)
+	default:
+		return conn.UnauthenticatedBind("")
+	}
 }
 
 // getConn returns the underlying LDAP connection.
 func (l *LDAPConn) getConn() LDAPConnClient {
 	if l.conn != nil && l.conn.IsClosing() {
-		newConn, err := ldap.DialURL(l.server, ldap.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}))
+		newConn, err := l.dial()
 		if err != nil {
 			// Log the error and return the existing connection (or nil if no valid connection exists)
 			fmt.Printf("Failed to re-establish LDAP connection: %v\n", err)
 			return nil
 		}
-		// Perform anonymous bind (equivalent to ldapsearch -x)
-		err = newConn.UnauthenticatedBind("")
-		if err != nil {
-			fmt.Printf("Failed to bind re-established LDAP connection: %v\n", err)
-			_ = newConn.Close()
-			return nil
-		}
 		l.conn = newConn
 	}
 
 	return l.conn
 }
 
+// search runs the search request on the connection in a span of the trace of the context. The
+// subtree searches are paged with the page size of the connection, so that the searches matching
+// many entries are not cut by the size limit of the server. The searches failing with a transient
+// error are retried, and the referrals are followed when enabled.
+func (l *LDAPConn) search(ctx context.Context, conn LDAPConnClient,
+	searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	_, span := tracing.Start(ctx, "ldap.search",
+		attribute.String("ldap.base_dn", searchRequest.BaseDN),
+		attribute.String("ldap.filter", searchRequest.Filter))
+	resp, err := l.searchWithRetry(ctx, conn, searchRequest)
+	if l.maxReferralHops > 0 {
+		resp, err = l.followReferrals(ctx, searchRequest, resp, err, 1, map[string]bool{})
+	}
+	tracing.End(span, err)
+	return resp, err
+}
+
+// searchConn sends the search request on the connection, paging the subtree searches
+func (l *LDAPConn) searchConn(conn LDAPConnClient, searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if searchRequest.Scope != ldap.ScopeBaseObject && l.pageSize > 0 {
+		return conn.SearchWithPaging(searchRequest, l.pageSize)
+	}
+	return conn.Search(searchRequest)
+}
+
+// HealthCheck reports whether the LDAP server answers searches, by reading the entry of the base DN
+func (l *LDAPConn) HealthCheck(ctx context.Context) error {
+	conn := l.getConn()
+	if conn == nil {
+		return errors.New("LDAP connection is nil")
+	}
+	searchRequest := ldap.NewSearchRequest(
+		l.baseDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)",
+		[]string{"1.1"}, // no attributes
+		nil,
+	)
+	if _, err := l.search(ctx, conn, searchRequest); err != nil {
+		logger.Logger(ctx).WithError(err).Warn("LDAP health check failed")
+		return fmt.Errorf("LDAP health check failed: %w", err)
+	}
+	return nil
+}
+
 // GetUserDN returns the user DN for the LDAP connection.
 func (l *LDAPConn) GetUserDN() string {
 	return l.userDN
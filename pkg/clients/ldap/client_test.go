@@ -1,11 +1,18 @@
 package ldap
 
 import (
+	"encoding/pem"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -84,3 +91,106 @@ func startMockLDAPServer(t *testing.T) (addr string, stop func()) {
 		_ = ln.Close()
 	}
 }
+
+func (suite *LDAPTestSuite) TestHealthCheck() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:   suite.ldapClient,
+		server: "ldap://ldap.com:389",
+		baseDN: "dc=example,dc=com",
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(2)
+	var capturedReq *ldap.SearchRequest
+	suite.ldapClient.EXPECT().Search(gomock.Any()).
+		DoAndReturn(func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			capturedReq = req
+			return &ldap.SearchResult{}, nil
+		}).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).
+		Return(nil, ldap.NewError(ldap.LDAPResultUnavailable, nil)).Times(1)
+
+	assertions.NoError(ldapConn.HealthCheck(suite.ctx))
+	if assertions.NotNil(capturedReq) {
+		assertions.Equal("dc=example,dc=com", capturedReq.BaseDN)
+		assertions.Equal(ldap.ScopeBaseObject, capturedReq.Scope)
+	}
+	assertions.ErrorContains(ldapConn.HealthCheck(suite.ctx), "LDAP health check failed")
+}
+
+func TestInitLdap_InvalidBind(t *testing.T) {
+	for name, ldapConfig := range map[string]LDAP{
+		"bindDN without password": {BindDN: "cn=usernaut,dc=example,dc=com"},
+		"unsupported mechanism":   {SASL: LDAPSASL{Mechanism: "PLAIN"}},
+		"digest without username": {BindPassword: "secret", SASL: LDAPSASL{Mechanism: SASLMechanismDigestMD5}},
+		"missing certificate": {SASL: LDAPSASL{
+			Mechanism: SASLMechanismExternal, CertPath: "/nonexistent/tls.crt", KeyPath: "/nonexistent/tls.key",
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ldapConfig.Server = "ldap://127.0.0.1:1"
+			_, err := InitLdap(ldapConfig)
+			assert.Error(t, err)
+			assert.NotContains(t, err.Error(), "connection refused", "Expected the config to be rejected before dialing")
+		})
+	}
+}
+
+func (suite *LDAPTestSuite) TestBind() {
+	assertions := assert.New(suite.T())
+
+	suite.ldapClient.EXPECT().UnauthenticatedBind("").Return(nil).Times(1)
+	assertions.NoError((&LDAPConn{}).bind(suite.ldapClient))
+
+	suite.ldapClient.EXPECT().Bind("cn=usernaut,dc=example,dc=com", "secret").Return(nil).Times(1)
+	assertions.NoError((&LDAPConn{
+		bindDN:       "cn=usernaut,dc=example,dc=com",
+		bindPassword: "secret",
+	}).bind(suite.ldapClient))
+
+	suite.ldapClient.EXPECT().ExternalBind().Return(nil).Times(1)
+	assertions.NoError((&LDAPConn{
+		bindDN: "cn=usernaut,dc=example,dc=com",
+		sasl:   LDAPSASL{Mechanism: SASLMechanismExternal},
+	}).bind(suite.ldapClient))
+
+	suite.ldapClient.EXPECT().MD5Bind("ldap.example.com", "usernaut", "secret").
+		Return(ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)).Times(1)
+	assertions.Error((&LDAPConn{
+		server:       "ldaps://ldap.example.com:636",
+		bindPassword: "secret",
+		sasl:         LDAPSASL{Mechanism: SASLMechanismDigestMD5, Username: "usernaut"},
+	}).bind(suite.ldapClient))
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	tlsConfig, err := newTLSConfig(LDAP{Server: "ldap://ldap.example.com:389"})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig, "Expected the library defaults without TLS config")
+
+	_, err = newTLSConfig(LDAP{Server: "ldaps://ldap.example.com:636", TLS: LDAPTLS{StartTLS: true}})
+	assert.ErrorContains(t, err, "startTLS")
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caPath, caBundle, 0o600))
+
+	tlsConfig, err = newTLSConfig(LDAP{
+		Server: "ldap://ldap.example.com:389",
+		TLS:    LDAPTLS{StartTLS: true, CAPath: caPath},
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, tlsConfig) {
+		assert.Equal(t, "ldap.example.com", tlsConfig.ServerName)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+	}
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte("not a certificate"), 0o600))
+	_, err = newTLSConfig(LDAP{Server: "ldaps://ldap.example.com:636", TLS: LDAPTLS{CAPath: invalidPath}})
+	assert.ErrorContains(t, err, "no certificate found")
+}
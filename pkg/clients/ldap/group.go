@@ -0,0 +1,72 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+var (
+	ErrNoGroupFound = errors.New("no LDAP entry found for group")
+)
+
+// groupMemberAttributes are the attributes listing the members of groupOfNames,
+// groupOfUniqueNames and posixGroup entries
+var groupMemberAttributes = []string{"member", "uniqueMember", "memberUid"}
+
+// GetGroupMembers returns the uids of the members of the LDAP group with the given DN.
+// Members listed by DN (member, uniqueMember) are resolved to the uid of their DN.
+func (l *LDAPConn) GetGroupMembers(ctx context.Context, groupDN string) ([]string, error) {
+	log := logger.Logger(ctx).WithField("groupDN", groupDN)
+	log.Info("fetching LDAP group members")
+
+	searchRequest := ldap.NewSearchRequest(
+		groupDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		groupMemberAttributes,
+		nil,
+	)
+
+	conn := l.getConn()
+	if conn == nil {
+		log.Error("LDAP connection is nil, cannot perform search")
+		return nil, errors.New("LDAP connection is nil")
+	}
+	resp, err := l.search(ctx, conn, searchRequest)
+	if err != nil {
+		var ldapErr *ldap.Error
+		if errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultNoSuchObject {
+			log.WithError(err).Warn("LDAP group not found")
+			return nil, ErrNoGroupFound
+		}
+		log.WithError(err).Error("failed to search LDAP for group members")
+		return nil, err
+	}
+	if len(resp.Entries) == 0 {
+		log.Warn("LDAP group not found")
+		return nil, ErrNoGroupFound
+	}
+
+	entry := resp.Entries[0]
+	members := make([]string, 0)
+	for _, attr := range []string{"member", "uniqueMember"} {
+		for _, memberDN := range entry.GetAttributeValues(attr) {
+			dn, parseErr := ldap.ParseDN(memberDN)
+			if parseErr != nil {
+				log.WithError(parseErr).WithField("memberDN", memberDN).Warn("skipping invalid member DN")
+				continue
+			}
+			// Nested groups and other non-user members have no uid
+			if uid := parseUIDFromDN(dn); uid != "" {
+				members = append(members, uid)
+			}
+		}
+	}
+	members = append(members, entry.GetAttributeValues("memberUid")...)
+
+	log.WithField("members_count", len(members)).Info("fetched LDAP group members")
+	return members, nil
+}
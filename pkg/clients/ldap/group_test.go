@@ -0,0 +1,69 @@
+package ldap
+
+import (
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *LDAPTestSuite) TestGetGroupMembers() {
+	assertions := assert.New(suite.T())
+
+	groupDN := "cn=data-eng,ou=adhoc,ou=managedGroups,dc=example,dc=com"
+	searchResult := &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: groupDN,
+				Attributes: []*ldap.EntryAttribute{
+					{Name: "uniqueMember", Values: []string{
+						"uid=alice,ou=users,dc=example,dc=com",
+						"cn=nested-group,ou=adhoc,ou=managedGroups,dc=example,dc=com",
+					}},
+					{Name: "memberUid", Values: []string{"bob"}},
+				},
+			},
+		},
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	var capturedReq *ldap.SearchRequest
+	suite.ldapClient.EXPECT().
+		Search(gomock.Any()).
+		DoAndReturn(func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			capturedReq = req
+			return searchResult, nil
+		}).
+		Times(1)
+
+	ldapConn := &LDAPConn{
+		conn:   suite.ldapClient,
+		server: "ldap://ldap.com:389",
+	}
+
+	resp, err := ldapConn.GetGroupMembers(suite.ctx, groupDN)
+
+	assertions.NoError(err)
+	assertions.Equal([]string{"alice", "bob"}, resp)
+	if assertions.NotNil(capturedReq) {
+		assertions.Equal(groupDN, capturedReq.BaseDN)
+		assertions.Equal(ldap.ScopeBaseObject, capturedReq.Scope)
+	}
+}
+
+func (suite *LDAPTestSuite) TestGetGroupMembers_NoSuchObject() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:   suite.ldapClient,
+		server: "ldap://ldap.com:389",
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).
+		Return(nil, ldap.NewError(ldap.LDAPResultNoSuchObject, nil)).Times(1)
+
+	resp, err := ldapConn.GetGroupMembers(suite.ctx, "cn=missing,dc=example,dc=com")
+
+	assertions.ErrorIs(err, ErrNoGroupFound)
+	assertions.Nil(resp)
+}
@@ -36,6 +36,34 @@ func (m *MockLDAPConnClient) EXPECT() *MockLDAPConnClientMockRecorder {
 	return m.recorder
 }
 
+// Bind mocks base method.
+func (m *MockLDAPConnClient) Bind(username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bind", username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bind indicates an expected call of Bind.
+func (mr *MockLDAPConnClientMockRecorder) Bind(username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bind", reflect.TypeOf((*MockLDAPConnClient)(nil).Bind), username, password)
+}
+
+// ExternalBind mocks base method.
+func (m *MockLDAPConnClient) ExternalBind() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExternalBind")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExternalBind indicates an expected call of ExternalBind.
+func (mr *MockLDAPConnClientMockRecorder) ExternalBind() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExternalBind", reflect.TypeOf((*MockLDAPConnClient)(nil).ExternalBind))
+}
+
 // IsClosing mocks base method.
 func (m *MockLDAPConnClient) IsClosing() bool {
 	m.ctrl.T.Helper()
@@ -50,6 +78,20 @@ func (mr *MockLDAPConnClientMockRecorder) IsClosing() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClosing", reflect.TypeOf((*MockLDAPConnClient)(nil).IsClosing))
 }
 
+// MD5Bind mocks base method.
+func (m *MockLDAPConnClient) MD5Bind(host, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MD5Bind", host, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MD5Bind indicates an expected call of MD5Bind.
+func (mr *MockLDAPConnClientMockRecorder) MD5Bind(host, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MD5Bind", reflect.TypeOf((*MockLDAPConnClient)(nil).MD5Bind), host, username, password)
+}
+
 // Search mocks base method.
 func (m *MockLDAPConnClient) Search(arg0 *ldap.SearchRequest) (*ldap.SearchResult, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +107,21 @@ func (mr *MockLDAPConnClientMockRecorder) Search(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockLDAPConnClient)(nil).Search), arg0)
 }
 
+// SearchWithPaging mocks base method.
+func (m *MockLDAPConnClient) SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchWithPaging", searchRequest, pagingSize)
+	ret0, _ := ret[0].(*ldap.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchWithPaging indicates an expected call of SearchWithPaging.
+func (mr *MockLDAPConnClientMockRecorder) SearchWithPaging(searchRequest, pagingSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchWithPaging", reflect.TypeOf((*MockLDAPConnClient)(nil).SearchWithPaging), searchRequest, pagingSize)
+}
+
 // UnauthenticatedBind mocks base method.
 func (m *MockLDAPConnClient) UnauthenticatedBind(username string) error {
 	m.ctrl.T.Helper()
@@ -117,6 +174,21 @@ func (mr *MockLDAPClientMockRecorder) BuildLDAPQueryFromSpec(ctx, query interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildLDAPQueryFromSpec", reflect.TypeOf((*MockLDAPClient)(nil).BuildLDAPQueryFromSpec), ctx, query)
 }
 
+// GetGroupMembers mocks base method.
+func (m *MockLDAPClient) GetGroupMembers(ctx context.Context, groupDN string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupMembers", ctx, groupDN)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupMembers indicates an expected call of GetGroupMembers.
+func (mr *MockLDAPClientMockRecorder) GetGroupMembers(ctx, groupDN interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembers", reflect.TypeOf((*MockLDAPClient)(nil).GetGroupMembers), ctx, groupDN)
+}
+
 // GetQueryMembers mocks base method.
 func (m *MockLDAPClient) GetQueryMembers(ctx context.Context, query string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -161,3 +233,46 @@ func (mr *MockLDAPClientMockRecorder) GetUserLDAPDataByEmail(ctx, email interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLDAPDataByEmail", reflect.TypeOf((*MockLDAPClient)(nil).GetUserLDAPDataByEmail), ctx, email)
 }
+
+// GetUsersLDAPData mocks base method.
+func (m *MockLDAPClient) GetUsersLDAPData(ctx context.Context, userIDs []string) (map[string]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersLDAPData", ctx, userIDs)
+	ret0, _ := ret[0].(map[string]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersLDAPData indicates an expected call of GetUsersLDAPData.
+func (mr *MockLDAPClientMockRecorder) GetUsersLDAPData(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersLDAPData", reflect.TypeOf((*MockLDAPClient)(nil).GetUsersLDAPData), ctx, userIDs)
+}
+
+// HealthCheck mocks base method.
+func (m *MockLDAPClient) HealthCheck(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockLDAPClientMockRecorder) HealthCheck(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockLDAPClient)(nil).HealthCheck), ctx)
+}
+
+// WatchUsers mocks base method.
+func (m *MockLDAPClient) WatchUsers(ctx context.Context, handler func(context.Context, []string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchUsers", ctx, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchUsers indicates an expected call of WatchUsers.
+func (mr *MockLDAPClientMockRecorder) WatchUsers(ctx, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUsers", reflect.TypeOf((*MockLDAPClient)(nil).WatchUsers), ctx, handler)
+}
@@ -34,7 +34,7 @@ func (l *LDAPConn) GetQueryMembers(ctx context.Context, query string) ([]string,
 		log.Error("LDAP connection is nil, cannot perform search")
 		return nil, errors.New("LDAP connection is nil")
 	}
-	resp, err := conn.Search(searchRequest)
+	resp, err := l.search(ctx, conn, searchRequest)
 	if err != nil {
 		log.WithError(err).Error("failed to search LDAP for query members")
 		return nil, err
@@ -266,3 +266,30 @@ func (suite *LDAPTestSuite) TestBuildLDAPQueryFromSpec_MixOperator() {
 	assertions.NoError(err)
 	assertions.Equal("(&(manager=uid=ticramer,ou=users,dc=redhat,dc=com)(!(employeeType=external employee)))", filter)
 }
+
+func (suite *LDAPTestSuite) TestGetQueryMembers_Paged() {
+	assertions := assert.New(suite.T())
+
+	searchResult := &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry("uid=user1,ou=users,dc=example,dc=com", map[string][]string{"uid": {"user1"}}),
+			ldap.NewEntry("uid=user2,ou=users,dc=example,dc=com", map[string][]string{"uid": {"user2"}}),
+		},
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).Times(0)
+	suite.ldapClient.EXPECT().SearchWithPaging(gomock.Any(), uint32(100)).Return(searchResult, nil).Times(1)
+
+	ldapConn := &LDAPConn{
+		conn:       suite.ldapClient,
+		baseUserDN: "ou=users,dc=example,dc=com",
+		server:     "ldap://ldap.com:389",
+		pageSize:   100,
+	}
+
+	resp, err := ldapConn.GetQueryMembers(suite.ctx, "(objectClass=person)")
+
+	assertions.NoError(err)
+	assertions.Equal([]string{"user1", "user2"}, resp)
+}
@@ -0,0 +1,131 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// referralTag is the context tag of the referral URLs of an LDAPResult
+const referralTag = 3
+
+// referralConn is a connection opened to follow a referral, closed once searched
+type referralConn interface {
+	LDAPConnClient
+	Close() error
+}
+
+// followReferrals follows the referrals of the search result: a search answered with a referral
+// is sent to the first server of the referral which answers it, and the continuation references
+// of a subtree search are searched and their entries added to the result. The referrals found on
+// the servers of the referrals are followed in turn, up to maxReferralHops in a row.
+func (l *LDAPConn) followReferrals(ctx context.Context, searchRequest *ldap.SearchRequest,
+	resp *ldap.SearchResult, err error, hop int, visited map[string]bool) (*ldap.SearchResult, error) {
+	if err != nil {
+		referrals := referralURLs(err)
+		if len(referrals) == 0 {
+			return resp, err
+		}
+		if hop > l.maxReferralHops {
+			return nil, ldap.NewError(ldap.LDAPResultReferralLimitExceeded,
+				fmt.Errorf("referral hop limit of %d exceeded", l.maxReferralHops))
+		}
+
+		var referralErr error
+		for _, referral := range referrals {
+			referralResp, err := l.searchReferral(ctx, searchRequest, referral, hop, visited)
+			if err == nil {
+				return referralResp, nil
+			}
+			referralErr = errors.Join(referralErr, err)
+		}
+		return nil, referralErr
+	}
+
+	if resp == nil || len(resp.Referrals) == 0 {
+		return resp, nil
+	}
+	if hop > l.maxReferralHops {
+		return nil, ldap.NewError(ldap.LDAPResultReferralLimitExceeded,
+			fmt.Errorf("referral hop limit of %d exceeded", l.maxReferralHops))
+	}
+
+	result := &ldap.SearchResult{Entries: resp.Entries, Controls: resp.Controls}
+	for _, referral := range resp.Referrals {
+		referralResp, err := l.searchReferral(ctx, searchRequest, referral, hop, visited)
+		if err != nil {
+			return nil, err
+		}
+		result.Entries = append(result.Entries, referralResp.Entries...)
+	}
+	return result, nil
+}
+
+// searchReferral sends the search request to the server of the referral URL, searching the DN of
+// the URL when it has one. A referral already followed by the search is a loop.
+func (l *LDAPConn) searchReferral(ctx context.Context, searchRequest *ldap.SearchRequest,
+	referral string, hop int, visited map[string]bool) (*ldap.SearchResult, error) {
+	log := logger.Logger(ctx).WithField("referral", referral).WithField("hop", hop)
+	if visited[referral] {
+		return nil, ldap.NewError(ldap.LDAPResultClientLoop, fmt.Errorf("referral loop on %s", referral))
+	}
+	visited[referral] = true
+
+	referralURL, err := url.Parse(referral)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP referral %q: %w", referral, err)
+	}
+
+	// the paging cookie is bound to the connection it was returned on
+	referralRequest := *searchRequest
+	referralRequest.Controls = nil
+	for _, control := range searchRequest.Controls {
+		if control.GetControlType() != ldap.ControlTypePaging {
+			referralRequest.Controls = append(referralRequest.Controls, control)
+		}
+	}
+	if dn := referralURL.Path; len(dn) > 1 {
+		referralRequest.BaseDN = dn[1:]
+	}
+
+	log.Info("following LDAP referral")
+	conn, err := l.dialReferral(referralURL.Scheme + "://" + referralURL.Host)
+	if err != nil {
+		log.WithError(err).Warn("failed to connect to the server of the LDAP referral")
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	resp, err := l.searchConn(conn, &referralRequest)
+	return l.followReferrals(ctx, &referralRequest, resp, err, hop+1, visited)
+}
+
+// referralURLs returns the URLs of the referral error of a search, nil for other errors
+func referralURLs(err error) []string {
+	var ldapErr *ldap.Error
+	if !errors.As(err, &ldapErr) || ldapErr.ResultCode != ldap.LDAPResultReferral || ldapErr.Packet == nil ||
+		len(ldapErr.Packet.Children) < 2 {
+		return nil
+	}
+
+	var referrals []string
+	// the referral follows the result code, the matched DN and the diagnostic message
+	response := ldapErr.Packet.Children[1]
+	for _, child := range response.Children[min(3, len(response.Children)):] {
+		if child.Tag != referralTag {
+			continue
+		}
+		for _, referral := range child.Children {
+			if value, ok := referral.Value.(string); ok && value != "" {
+				referrals = append(referrals, value)
+			}
+		}
+	}
+	return referrals
+}
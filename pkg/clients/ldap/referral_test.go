@@ -0,0 +1,113 @@
+package ldap
+
+import (
+	"errors"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeReferralConn is a referral connection searching with the mock client
+type fakeReferralConn struct {
+	*mocks.MockLDAPConnClient
+	closed bool
+}
+
+func (c *fakeReferralConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (suite *LDAPTestSuite) TestSearch_FollowsContinuationReferences() {
+	assertions := assert.New(suite.T())
+
+	referralClient := mocks.NewMockLDAPConnClient(suite.ctrl)
+	fakeConn := &fakeReferralConn{MockLDAPConnClient: referralClient}
+	var dialed []string
+	ldapConn := &LDAPConn{
+		conn:            suite.ldapClient,
+		baseUserDN:      "dc=example,dc=com",
+		maxReferralHops: 2,
+		dialReferral: func(server string) (referralConn, error) {
+			dialed = append(dialed, server)
+			return fakeConn, nil
+		},
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry("uid=alice,ou=users,dc=example,dc=com", map[string][]string{"uid": {"alice"}}),
+		},
+		Referrals: []string{"ldap://emea.example.com:389/ou=users,dc=emea,dc=example,dc=com??sub"},
+	}, nil).Times(1)
+	referralClient.EXPECT().Search(gomock.Any()).DoAndReturn(
+		func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			assertions.Equal("ou=users,dc=emea,dc=example,dc=com", req.BaseDN)
+			assertions.Equal("(objectClass=person)", req.Filter)
+			return &ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("uid=bob,ou=users,dc=emea,dc=example,dc=com", map[string][]string{"uid": {"bob"}}),
+			}}, nil
+		}).Times(1)
+
+	resp, err := ldapConn.GetQueryMembers(suite.ctx, "(objectClass=person)")
+
+	assertions.NoError(err)
+	assertions.Equal([]string{"alice", "bob"}, resp)
+	assertions.Equal([]string{"ldap://emea.example.com:389"}, dialed)
+	assertions.True(fakeConn.closed, "Expected the referral connection to be closed")
+}
+
+func (suite *LDAPTestSuite) TestSearch_ReferralHopLimit() {
+	assertions := assert.New(suite.T())
+
+	referralClient := mocks.NewMockLDAPConnClient(suite.ctrl)
+	ldapConn := &LDAPConn{
+		conn:            suite.ldapClient,
+		baseUserDN:      "dc=example,dc=com",
+		maxReferralHops: 1,
+		dialReferral: func(server string) (referralConn, error) {
+			return &fakeReferralConn{MockLDAPConnClient: referralClient}, nil
+		},
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{
+		Referrals: []string{"ldap://emea.example.com/dc=emea,dc=example,dc=com"},
+	}, nil).Times(1)
+	referralClient.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{
+		Referrals: []string{"ldap://apac.example.com/dc=apac,dc=example,dc=com"},
+	}, nil).Times(1)
+
+	_, err := ldapConn.GetQueryMembers(suite.ctx, "(objectClass=person)")
+
+	var ldapErr *ldap.Error
+	if assertions.True(errors.As(err, &ldapErr)) {
+		assertions.Equal(uint16(ldap.LDAPResultReferralLimitExceeded), ldapErr.ResultCode)
+	}
+}
+
+func (suite *LDAPTestSuite) TestSearch_ReferralsNotFollowed() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:       suite.ldapClient,
+		baseUserDN: "dc=example,dc=com",
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry("uid=alice,ou=users,dc=example,dc=com", map[string][]string{"uid": {"alice"}}),
+		},
+		Referrals: []string{"ldap://emea.example.com/dc=emea,dc=example,dc=com"},
+	}, nil).Times(1)
+
+	resp, err := ldapConn.GetQueryMembers(suite.ctx, "(objectClass=person)")
+
+	assertions.NoError(err)
+	assertions.Equal([]string{"alice"}, resp)
+	assertions.Nil(referralURLs(ldap.NewError(ldap.LDAPResultReferral, errors.New("referral"))))
+}
@@ -0,0 +1,129 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 200 * time.Millisecond
+	maxRetryBackoff       = 5 * time.Second
+)
+
+var (
+	ldapSearchRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "usernaut_ldap_search_retries_total",
+		Help: "Number of LDAP searches retried after a transient error, by reason",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ldapSearchRetries)
+}
+
+// LDAPRetry configures the retries of the searches failing with a transient error: the server
+// being busy or unavailable, a network error or a timeout
+type LDAPRetry struct {
+	// MaxRetries is the number of retries of a search, 3 by default, a negative value disables them
+	MaxRetries int `yaml:"maxRetries"`
+	// InitialBackoff is the wait before the first retry, doubled on each retry up to 5s, 200ms by
+	// default
+	InitialBackoff string `yaml:"initialBackoff"`
+}
+
+// retryPolicy is the parsed LDAPRetry config
+type retryPolicy struct {
+	maxRetries     int
+	initialBackoff time.Duration
+}
+
+// newRetryPolicy parses the LDAPRetry config
+func newRetryPolicy(retry LDAPRetry) (retryPolicy, error) {
+	policy := retryPolicy{maxRetries: retry.MaxRetries, initialBackoff: defaultInitialBackoff}
+	if policy.maxRetries == 0 {
+		policy.maxRetries = defaultMaxRetries
+	}
+	if policy.maxRetries < 0 {
+		policy.maxRetries = 0
+	}
+	if retry.InitialBackoff != "" {
+		backoff, err := time.ParseDuration(retry.InitialBackoff)
+		if err != nil {
+			return retryPolicy{}, fmt.Errorf("invalid LDAP retry initialBackoff %q: %w", retry.InitialBackoff, err)
+		}
+		policy.initialBackoff = backoff
+	}
+	return policy, nil
+}
+
+// backoff returns the wait before the retry of the attempt, starting at 0
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.initialBackoff << attempt
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+// retryReason returns the reason of the retry of a search failing with the error, an empty reason
+// when the error is not transient
+func retryReason(err error) string {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	var ldapErr *ldap.Error
+	if !errors.As(err, &ldapErr) {
+		return ""
+	}
+	switch ldapErr.ResultCode {
+	case ldap.LDAPResultBusy:
+		return "busy"
+	case ldap.LDAPResultUnavailable:
+		return "unavailable"
+	case ldap.LDAPResultTimeLimitExceeded:
+		return "timeout"
+	case ldap.ErrorNetwork:
+		return "network"
+	}
+	return ""
+}
+
+// searchWithRetry sends the search request and retries it with a backoff while it fails with a
+// transient error. The connection is re-established when it was closed by the error.
+func (l *LDAPConn) searchWithRetry(ctx context.Context, conn LDAPConnClient,
+	searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := l.searchConn(conn, searchRequest)
+		reason := retryReason(err)
+		if reason == "" || attempt >= l.retry.maxRetries {
+			return resp, err
+		}
+
+		backoff := l.retry.backoff(attempt)
+		logger.Logger(ctx).WithError(err).WithField("reason", reason).WithField("attempt", attempt+1).
+			WithField("backoff", backoff.String()).Warn("transient LDAP error, retrying the search")
+		ldapSearchRetries.WithLabelValues(reason).Inc()
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+
+		if newConn := l.getConn(); newConn != nil {
+			conn = newConn
+		}
+	}
+}
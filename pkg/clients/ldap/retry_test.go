@@ -0,0 +1,104 @@
+package ldap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// retryCount returns the number of search retries recorded for the reason
+func retryCount(t *testing.T, reason string) float64 {
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "usernaut_ldap_search_retries_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "reason" && label.GetValue() == reason {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy, err := newRetryPolicy(LDAPRetry{})
+	assert.NoError(t, err)
+	assert.Equal(t, retryPolicy{maxRetries: defaultMaxRetries, initialBackoff: defaultInitialBackoff}, policy)
+
+	policy, err = newRetryPolicy(LDAPRetry{MaxRetries: -1, InitialBackoff: "1s"})
+	assert.NoError(t, err)
+	assert.Equal(t, retryPolicy{maxRetries: 0, initialBackoff: time.Second}, policy)
+
+	_, err = newRetryPolicy(LDAPRetry{InitialBackoff: "soon"})
+	assert.Error(t, err)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{initialBackoff: time.Second}
+	assert.Equal(t, time.Second, policy.backoff(0))
+	assert.Equal(t, 4*time.Second, policy.backoff(2))
+	assert.Equal(t, maxRetryBackoff, policy.backoff(10))
+}
+
+func TestRetryReason(t *testing.T) {
+	assert.Equal(t, "busy", retryReason(ldap.NewError(ldap.LDAPResultBusy, errors.New("busy"))))
+	assert.Equal(t, "unavailable", retryReason(ldap.NewError(ldap.LDAPResultUnavailable, errors.New("unavailable"))))
+	assert.Equal(t, "network", retryReason(ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset"))))
+	assert.Empty(t, retryReason(ldap.NewError(ldap.LDAPResultNoSuchObject, errors.New("no such object"))))
+	assert.Empty(t, retryReason(nil))
+}
+
+func (suite *LDAPTestSuite) TestSearch_RetriesTransientErrors() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:       suite.ldapClient,
+		baseUserDN: "ou=users,dc=example,dc=com",
+		retry:      retryPolicy{maxRetries: 2, initialBackoff: time.Millisecond},
+	}
+	retries := retryCount(suite.T(), "busy")
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(3)
+	gomock.InOrder(
+		suite.ldapClient.EXPECT().Search(gomock.Any()).
+			Return(nil, ldap.NewError(ldap.LDAPResultBusy, errors.New("busy"))).Times(2),
+		suite.ldapClient.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			ldap.NewEntry("uid=alice,ou=users,dc=example,dc=com", map[string][]string{"uid": {"alice"}}),
+		}}, nil).Times(1),
+	)
+
+	resp, err := ldapConn.GetQueryMembers(suite.ctx, "(objectClass=person)")
+
+	assertions.NoError(err)
+	assertions.Equal([]string{"alice"}, resp)
+	assertions.Equal(retries+2, retryCount(suite.T(), "busy"))
+}
+
+func (suite *LDAPTestSuite) TestSearch_RetriesExhausted() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:       suite.ldapClient,
+		baseUserDN: "ou=users,dc=example,dc=com",
+		retry:      retryPolicy{maxRetries: 1, initialBackoff: time.Millisecond},
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(2)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).
+		Return(nil, ldap.NewError(ldap.LDAPResultUnavailable, errors.New("unavailable"))).Times(2)
+
+	_, err := ldapConn.GetQueryMembers(suite.ctx, "(objectClass=person)")
+
+	assertions.Error(err)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
@@ -13,6 +15,13 @@ var (
 	ErrNoUserFound = errors.New("no LDAP entries found for user")
 )
 
+const (
+	// uidAttribute is the attribute holding the userID of the LDAP users
+	uidAttribute = "uid"
+	// bulkLookupSize is the maximum number of users looked up by a single search of GetUsersLDAPData
+	bulkLookupSize = 100
+)
+
 // parseLDAPEntry is a helper method that extracts attribute values from an LDAP entry.
 func (l *LDAPConn) parseLDAPEntry(entry *ldap.Entry) map[string]interface{} {
 	userData := make(map[string]interface{})
@@ -30,6 +39,15 @@ func (l *LDAPConn) parseLDAPEntry(entry *ldap.Entry) map[string]interface{} {
 // It handles connection management, search execution, and result parsing.
 func (l *LDAPConn) executeSearch(ctx context.Context,
 	searchRequest *ldap.SearchRequest) (map[string]interface{}, error) {
+	entry, err := l.searchEntry(ctx, searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	return l.parseLDAPEntry(entry), nil
+}
+
+// searchEntry executes the provided search request and returns the first entry found
+func (l *LDAPConn) searchEntry(ctx context.Context, searchRequest *ldap.SearchRequest) (*ldap.Entry, error) {
 	log := logger.Logger(ctx).WithField("searchRequest", searchRequest)
 	conn := l.getConn()
 	if conn == nil {
@@ -37,12 +55,12 @@ func (l *LDAPConn) executeSearch(ctx context.Context,
 	}
 
 	// Ensure connection is bound before search (some LDAP servers require this)
-	err := conn.UnauthenticatedBind("")
+	err := l.bind(conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind before search: %w", err)
 	}
 
-	resp, err := conn.Search(searchRequest)
+	resp, err := l.search(ctx, conn, searchRequest)
 	if err != nil {
 		// Handle LDAP "No Such Object" error (code 32)
 		if ldapErr, ok := err.(*ldap.Error); ok {
@@ -59,7 +77,7 @@ func (l *LDAPConn) executeSearch(ctx context.Context,
 		return nil, ErrNoUserFound
 	}
 
-	return l.parseLDAPEntry(resp.Entries[0]), nil
+	return resp.Entries[0], nil
 }
 
 // GetUserLDAPData retrieves user data from LDAP using the userID (username).
@@ -94,6 +112,7 @@ func (l *LDAPConn) GetUserLDAPData(ctx context.Context, userID string) (map[stri
 
 // GetUserLDAPDataByEmail retrieves user data from LDAP using the email address.
 // It constructs a search request with a mail filter and performs a subtree search in baseDN.
+// It returns ErrUserInactive when the entry found is inactive according to the userStatus config.
 func (l *LDAPConn) GetUserLDAPDataByEmail(ctx context.Context, email string) (map[string]interface{}, error) {
 	log := logger.Logger(ctx).WithField("email", email)
 	log.Debug("fetching user LDAP data by email")
@@ -106,11 +125,11 @@ func (l *LDAPConn) GetUserLDAPDataByEmail(ctx context.Context, email string) (ma
 		l.baseUserDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		filter,
-		l.attributes,
+		l.userStatus.searchAttributes(l.attributes),
 		nil,
 	)
 
-	userData, err := l.executeSearch(ctx, searchRequest)
+	entry, err := l.searchEntry(ctx, searchRequest)
 	if err != nil {
 		if err == ErrNoUserFound {
 			log.Warn("no LDAP entries found for email")
@@ -120,6 +139,85 @@ func (l *LDAPConn) GetUserLDAPDataByEmail(ctx context.Context, email string) (ma
 		return nil, err
 	}
 
+	if l.userStatus.inactive(entry) {
+		log.WithField("dn", entry.DN).Info("LDAP entry of user is inactive")
+		return nil, ErrUserInactive
+	}
+
 	log.Debug("fetched user LDAP data by email")
-	return userData, nil
+	return l.parseLDAPEntry(entry), nil
+}
+
+// GetUsersLDAPData retrieves the LDAP data of the users by their userID, with one subtree search in
+// baseUserDN per chunk of bulkLookupSize users using an OR filter of their uid. The data is keyed by
+// userID, and the users which are not found in LDAP are missing from the result. When a search
+// fails, the data of the chunks searched so far is returned along with the error.
+func (l *LDAPConn) GetUsersLDAPData(ctx context.Context, userIDs []string) (map[string]map[string]interface{}, error) {
+	log := logger.Logger(ctx).WithField("users", len(userIDs))
+	log.Debug("fetching LDAP data of users")
+
+	attributes := l.attributes
+	if !slices.Contains(attributes, uidAttribute) {
+		attributes = append(slices.Clone(attributes), uidAttribute)
+	}
+
+	usersData := make(map[string]map[string]interface{}, len(userIDs))
+	for chunk := range slices.Chunk(userIDs, bulkLookupSize) {
+		var uidFilter strings.Builder
+		for _, userID := range chunk {
+			fmt.Fprintf(&uidFilter, "(%s=%s)", uidAttribute, ldap.EscapeFilter(userID))
+		}
+		filter := fmt.Sprintf("(&%s(|%s))", l.userSearchFilter, uidFilter.String())
+
+		searchRequest := ldap.NewSearchRequest(
+			l.baseUserDN,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			filter,
+			attributes,
+			nil,
+		)
+
+		entries, err := l.executeBulkSearch(ctx, searchRequest)
+		if err != nil {
+			log.WithError(err).Error("failed to search LDAP for users data")
+			return usersData, err
+		}
+		for _, entry := range entries {
+			userID := entry.GetAttributeValue(uidAttribute)
+			if userID == "" {
+				continue
+			}
+			usersData[userID] = l.parseLDAPEntry(entry)
+		}
+	}
+
+	if missing := len(userIDs) - len(usersData); missing > 0 {
+		log.WithField("missing", missing).Warn("no LDAP entries found for some users")
+	}
+	log.Debug("fetched LDAP data of users")
+	return usersData, nil
+}
+
+// executeBulkSearch executes the provided search request like executeSearch and returns all the
+// entries found, a search matching no entry is not an error.
+func (l *LDAPConn) executeBulkSearch(ctx context.Context, searchRequest *ldap.SearchRequest) ([]*ldap.Entry, error) {
+	conn := l.getConn()
+	if conn == nil {
+		return nil, errors.New("LDAP connection is nil")
+	}
+
+	// Ensure connection is bound before search (some LDAP servers require this)
+	if err := l.bind(conn); err != nil {
+		return nil, fmt.Errorf("failed to bind before search: %w", err)
+	}
+
+	resp, err := l.search(ctx, conn, searchRequest)
+	if err != nil {
+		var ldapErr *ldap.Error
+		if errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultNoSuchObject {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Entries, nil
 }
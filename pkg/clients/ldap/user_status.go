@@ -0,0 +1,86 @@
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	ErrUserInactive = errors.New("LDAP entry of user is inactive")
+)
+
+// LDAPUserStatus tells the inactive users apart in directories which keep the entries of the
+// disabled users. An entry is inactive when its Attribute has one of the InactiveValues, or when
+// it is under one of the InactiveDNs, e.g. an OU of the disabled accounts.
+type LDAPUserStatus struct {
+	Attribute      string   `yaml:"attribute"`
+	InactiveValues []string `yaml:"inactiveValues"`
+	InactiveDNs    []string `yaml:"inactiveDNs"`
+}
+
+// userStatus evaluates the LDAPUserStatus of the entries
+type userStatus struct {
+	attribute      string
+	inactiveValues []string
+	inactiveDNs    []*ldap.DN
+}
+
+// newUserStatus parses the LDAPUserStatus config
+func newUserStatus(status LDAPUserStatus) (userStatus, error) {
+	if status.Attribute == "" && len(status.InactiveValues) > 0 {
+		return userStatus{}, errors.New("LDAP userStatus inactiveValues require an attribute")
+	}
+	if status.Attribute != "" && len(status.InactiveValues) == 0 {
+		return userStatus{}, fmt.Errorf("LDAP userStatus attribute %s has no inactiveValues", status.Attribute)
+	}
+
+	parsed := userStatus{attribute: status.Attribute, inactiveValues: status.InactiveValues}
+	for _, inactiveDN := range status.InactiveDNs {
+		dn, err := ldap.ParseDN(inactiveDN)
+		if err != nil {
+			return userStatus{}, fmt.Errorf("invalid LDAP userStatus inactiveDN %q: %w", inactiveDN, err)
+		}
+		parsed.inactiveDNs = append(parsed.inactiveDNs, dn)
+	}
+	return parsed, nil
+}
+
+// searchAttributes returns the attributes to search for evaluating the status along with attributes
+func (s userStatus) searchAttributes(attributes []string) []string {
+	if s.attribute == "" || slices.Contains(attributes, s.attribute) {
+		return attributes
+	}
+	return append(slices.Clone(attributes), s.attribute)
+}
+
+// inactive reports whether the entry is the one of an inactive user. The values and DNs are
+// compared case-insensitively.
+func (s userStatus) inactive(entry *ldap.Entry) bool {
+	if s.attribute != "" {
+		for _, value := range entry.GetAttributeValues(s.attribute) {
+			if slices.ContainsFunc(s.inactiveValues, func(inactive string) bool {
+				return strings.EqualFold(inactive, value)
+			}) {
+				return true
+			}
+		}
+	}
+
+	if len(s.inactiveDNs) == 0 {
+		return false
+	}
+	dn, err := ldap.ParseDN(entry.DN)
+	if err != nil {
+		return false
+	}
+	for _, inactiveDN := range s.inactiveDNs {
+		if inactiveDN.AncestorOfFold(dn) {
+			return true
+		}
+	}
+	return false
+}
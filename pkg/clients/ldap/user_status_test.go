@@ -0,0 +1,73 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUserStatus(t *testing.T) {
+	_, err := newUserStatus(LDAPUserStatus{InactiveValues: []string{"terminated"}})
+	assert.Error(t, err, "Expected inactiveValues without attribute to be rejected")
+
+	_, err = newUserStatus(LDAPUserStatus{Attribute: "employeeType"})
+	assert.Error(t, err, "Expected attribute without inactiveValues to be rejected")
+
+	_, err = newUserStatus(LDAPUserStatus{InactiveDNs: []string{"not a dn"}})
+	assert.Error(t, err, "Expected invalid inactiveDNs to be rejected")
+
+	status, err := newUserStatus(LDAPUserStatus{})
+	assert.NoError(t, err)
+	assert.False(t, status.inactive(ldap.NewEntry("uid=alice,ou=users,dc=example,dc=com", nil)))
+}
+
+func TestUserStatusInactive(t *testing.T) {
+	status, err := newUserStatus(LDAPUserStatus{
+		Attribute:      "employeeType",
+		InactiveValues: []string{"Terminated"},
+		InactiveDNs:    []string{"ou=disabled,dc=example,dc=com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mail", "employeeType"}, status.searchAttributes([]string{"mail"}))
+
+	assert.False(t, status.inactive(ldap.NewEntry("uid=alice,ou=users,dc=example,dc=com",
+		map[string][]string{"employeeType": {"Employee"}})))
+	assert.True(t, status.inactive(ldap.NewEntry("uid=bob,ou=users,dc=example,dc=com",
+		map[string][]string{"employeeType": {"terminated"}})), "Expected the values to be compared case-insensitively")
+	assert.True(t, status.inactive(ldap.NewEntry("uid=carol,OU=Disabled,dc=example,dc=com", nil)),
+		"Expected the entries under an inactive DN to be inactive")
+}
+
+func (suite *LDAPTestSuite) TestGetUserLDAPDataByEmail_Inactive() {
+	assertions := assert.New(suite.T())
+
+	status, err := newUserStatus(LDAPUserStatus{Attribute: "employeeType", InactiveValues: []string{"terminated"}})
+	assertions.NoError(err)
+	ldapConn := &LDAPConn{
+		conn:             suite.ldapClient,
+		baseUserDN:       "ou=users,dc=example,dc=com",
+		userSearchFilter: "(objectClass=person)",
+		attributes:       []string{"mail", "uid"},
+		userStatus:       status,
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().UnauthenticatedBind("").Return(nil).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).DoAndReturn(
+		func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			assertions.Equal([]string{"mail", "uid", "employeeType"}, req.Attributes)
+			return &ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("uid=bob,ou=users,dc=example,dc=com", map[string][]string{
+					"mail":         {"bob@example.com"},
+					"employeeType": {"terminated"},
+				}),
+			}}, nil
+		}).Times(1)
+
+	resp, err := ldapConn.GetUserLDAPDataByEmail(suite.ctx, "bob@example.com")
+
+	assertions.ErrorIs(err, ErrUserInactive)
+	assertions.Nil(resp)
+}
@@ -3,6 +3,8 @@ package ldap
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-ldap/ldap/v3"
@@ -411,3 +413,69 @@ func (suite *LDAPTestSuite) TestGetUserLDAPDataByEmail_BindError() {
 	assertions.Contains(err.Error(), "failed to bind before search")
 	assertions.Nil(resp)
 }
+
+func (suite *LDAPTestSuite) TestGetUsersLDAPData() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:             suite.ldapClient,
+		baseUserDN:       "ou=users,dc=example,dc=com",
+		userSearchFilter: "(objectClass=uid)",
+		attributes:       []string{"mail"},
+	}
+
+	userIDs := make([]string, 0, bulkLookupSize+1)
+	for i := range bulkLookupSize + 1 {
+		userIDs = append(userIDs, fmt.Sprintf("user%d", i))
+	}
+
+	var filters []string
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(2)
+	suite.ldapClient.EXPECT().UnauthenticatedBind("").Return(nil).Times(2)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).DoAndReturn(
+		func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			filters = append(filters, req.Filter)
+			assertions.Equal("ou=users,dc=example,dc=com", req.BaseDN)
+			assertions.Equal([]string{"mail", "uid"}, req.Attributes)
+			if len(filters) > 1 {
+				return &ldap.SearchResult{}, nil
+			}
+			return &ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("uid=user1,ou=users,dc=example,dc=com", map[string][]string{
+					"uid":  {"user1"},
+					"mail": {"user1@example.com"},
+				}),
+			}}, nil
+		}).Times(2)
+
+	resp, err := ldapConn.GetUsersLDAPData(suite.ctx, userIDs)
+
+	assertions.NoError(err)
+	assertions.Equal(map[string]map[string]interface{}{
+		"user1": {"mail": "user1@example.com"},
+	}, resp)
+	assertions.Len(filters, 2)
+	assertions.True(strings.HasPrefix(filters[0], "(&(objectClass=uid)(|(uid=user0)(uid=user1)(uid=user2)"))
+	assertions.Equal("(&(objectClass=uid)(|(uid=user100)))", filters[1])
+}
+
+func (suite *LDAPTestSuite) TestGetUsersLDAPData_SearchError() {
+	assertions := assert.New(suite.T())
+
+	ldapConn := &LDAPConn{
+		conn:             suite.ldapClient,
+		baseUserDN:       "ou=users,dc=example,dc=com",
+		userSearchFilter: "(objectClass=uid)",
+		attributes:       []string{"mail", "uid"},
+	}
+
+	suite.ldapClient.EXPECT().IsClosing().Return(false).Times(1)
+	suite.ldapClient.EXPECT().UnauthenticatedBind("").Return(nil).Times(1)
+	suite.ldapClient.EXPECT().Search(gomock.Any()).
+		Return(nil, ldap.NewError(ldap.LDAPResultOperationsError, errors.New("search error"))).Times(1)
+
+	resp, err := ldapConn.GetUsersLDAPData(suite.ctx, []string{"testuser"})
+
+	assertions.Error(err)
+	assertions.Empty(resp)
+}
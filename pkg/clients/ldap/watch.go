@@ -0,0 +1,258 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// mailAttribute is the attribute holding the email of the LDAP users, the key of their cache entry
+	mailAttribute = "mail"
+	// watchBufferSize is the number of sync responses buffered while the handler runs
+	watchBufferSize = 64
+
+	defaultWatchReconnectBackoff = 30 * time.Second
+)
+
+// LDAPWatch configures the watch of the user entries with the content synchronization of RFC 4533
+// (syncrepl), which reports the users deleted or disabled in LDAP as they change instead of on the
+// next offboarding run. The server must support the sync request control.
+type LDAPWatch struct {
+	Enabled bool `yaml:"enabled"`
+	// ReconnectBackoff is the wait before watching again after the watch failed, 30s by default
+	ReconnectBackoff string `yaml:"reconnectBackoff"`
+}
+
+// syncConn is the connection of the watch
+type syncConn interface {
+	Syncrepl(ctx context.Context, searchRequest *ldap.SearchRequest, bufferSize int,
+		mode ldap.ControlSyncRequestMode, cookie []byte, reloadHint bool) ldap.Response
+	Close() error
+}
+
+// newWatchBackoff parses the reconnect backoff of the LDAPWatch config
+func newWatchBackoff(watch LDAPWatch) (time.Duration, error) {
+	if watch.ReconnectBackoff == "" {
+		return defaultWatchReconnectBackoff, nil
+	}
+	backoff, err := time.ParseDuration(watch.ReconnectBackoff)
+	if err != nil || backoff <= 0 {
+		return 0, fmt.Errorf("invalid LDAP watch reconnectBackoff %q", watch.ReconnectBackoff)
+	}
+	return backoff, nil
+}
+
+// userWatch is the state of the watch kept across its reconnects. The deleted entries are only
+// reported by their entryUUID, so the emails of the entries are recorded by entryUUID.
+type userWatch struct {
+	status userStatus
+	emails map[uuid.UUID]string
+	// cookie is the sync state of the server the watch resumes from
+	cookie []byte
+	// synced tells whether the initial refresh completed, its entries are recorded but not reported
+	synced bool
+	// present holds the entries reported during the refresh phase, the entries missing from it
+	// were deleted when the refresh ends with a refreshPresent message
+	present map[uuid.UUID]bool
+}
+
+// WatchUsers watches the user entries under baseUserDN with a refreshAndPersist sync search on a
+// dedicated connection, and calls the handler with the emails of the users deleted from LDAP or
+// whose entry became inactive according to the userStatus config. The users of the initial
+// refresh are only recorded. The watch reconnects after the reconnect backoff when it fails,
+// resuming from the last cookie, and returns when the context is canceled. The handler runs on the
+// watch goroutine, and the changes are buffered meanwhile.
+func (l *LDAPConn) WatchUsers(ctx context.Context, handler func(ctx context.Context, emails []string)) error {
+	if l.dialWatch == nil {
+		return errors.New("LDAP watch is not enabled")
+	}
+
+	log := logger.Logger(ctx).WithField("baseUserDN", l.baseUserDN)
+	watch := &userWatch{status: l.userStatus, emails: make(map[uuid.UUID]string)}
+	for {
+		err := l.watchUsers(ctx, watch, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultUnavailableCriticalExtension) {
+			return fmt.Errorf("LDAP server does not support the content synchronization: %w", err)
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultSyncRefreshRequired) {
+			// the cookie expired on the server, the next sync search refreshes all the entries
+			watch.cookie = nil
+		}
+		log.WithError(err).Warn("LDAP watch of the users stopped, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(l.watchBackoff):
+		}
+	}
+}
+
+// watchUsers runs a sync search of the watch until it fails or the context is canceled
+func (l *LDAPConn) watchUsers(ctx context.Context, watch *userWatch,
+	handler func(ctx context.Context, emails []string)) error {
+	conn, err := l.dialWatch()
+	if err != nil {
+		return fmt.Errorf("failed to connect the LDAP watch: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	filter := l.userSearchFilter
+	if filter == "" {
+		filter = "(objectClass=*)"
+	}
+	searchRequest := ldap.NewSearchRequest(
+		l.baseUserDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		l.userStatus.searchAttributes([]string{mailAttribute}),
+		nil,
+	)
+
+	// stops the goroutine of the sync search when the watch returns early
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watch.present = make(map[uuid.UUID]bool)
+	resp := conn.Syncrepl(searchCtx, searchRequest, watchBufferSize,
+		ldap.SyncRequestModeRefreshAndPersist, watch.cookie, false)
+	for resp.Next() {
+		if emails := watch.apply(resp.Entry(), resp.Controls()); len(emails) > 0 {
+			handler(ctx, emails)
+		}
+	}
+	if err := resp.Err(); err != nil {
+		return err
+	}
+	return errors.New("LDAP sync search ended by the server")
+}
+
+// apply records a response of the sync search, and returns the emails of the users it reports as
+// deleted or inactive
+func (w *userWatch) apply(entry *ldap.Entry, controls []ldap.Control) []string {
+	var emails []string
+	for _, control := range controls {
+		switch c := control.(type) {
+		case *ldap.ControlSyncState:
+			emails = append(emails, w.applyState(entry, c)...)
+		case *ldap.ControlSyncInfo:
+			emails = append(emails, w.applyInfo(c)...)
+		case *ldap.ControlSyncDone:
+			w.setCookie(c.Cookie)
+		}
+	}
+	return emails
+}
+
+// applyState records the state of an entry
+func (w *userWatch) applyState(entry *ldap.Entry, state *ldap.ControlSyncState) []string {
+	w.setCookie(state.Cookie)
+	id := state.EntryUUID
+	switch state.State {
+	case ldap.SyncStatePresent:
+		w.markPresent(id)
+		return nil
+	case ldap.SyncStateDelete:
+		return w.deleted([]uuid.UUID{id})
+	}
+
+	// the entry was added or modified
+	w.markPresent(id)
+	if entry == nil {
+		return nil
+	}
+	email := entry.GetAttributeValue(mailAttribute)
+	if email == "" {
+		delete(w.emails, id)
+		return nil
+	}
+	w.emails[id] = email
+	if w.synced && w.status.inactive(entry) {
+		return []string{email}
+	}
+	return nil
+}
+
+// applyInfo records a sync info message
+func (w *userWatch) applyInfo(info *ldap.ControlSyncInfo) []string {
+	switch info.Value {
+	case ldap.SyncInfoNewcookie:
+		w.setCookie(info.NewCookie.Cookie)
+	case ldap.SyncInfoRefreshDelete:
+		w.setCookie(info.RefreshDelete.Cookie)
+		if info.RefreshDelete.RefreshDone {
+			w.refreshDone()
+		}
+	case ldap.SyncInfoRefreshPresent:
+		w.setCookie(info.RefreshPresent.Cookie)
+		// the entries not reported present during the refresh were deleted
+		var missing []uuid.UUID
+		for id := range w.emails {
+			if !w.present[id] {
+				missing = append(missing, id)
+			}
+		}
+		emails := w.deleted(missing)
+		if info.RefreshPresent.RefreshDone {
+			w.refreshDone()
+		}
+		return emails
+	case ldap.SyncInfoSyncIdSet:
+		w.setCookie(info.SyncIdSet.Cookie)
+		if info.SyncIdSet.RefreshDeletes {
+			return w.deleted(info.SyncIdSet.SyncUUIDs)
+		}
+		for _, id := range info.SyncIdSet.SyncUUIDs {
+			w.markPresent(id)
+		}
+	}
+	return nil
+}
+
+// deleted forgets the entries, and returns the emails of the deleted users once the initial
+// refresh completed
+func (w *userWatch) deleted(ids []uuid.UUID) []string {
+	var emails []string
+	for _, id := range ids {
+		email, ok := w.emails[id]
+		if !ok {
+			continue
+		}
+		delete(w.emails, id)
+		if w.synced {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// markPresent records an entry reported during the refresh phase
+func (w *userWatch) markPresent(id uuid.UUID) {
+	if w.present != nil {
+		w.present[id] = true
+	}
+}
+
+// refreshDone ends the refresh phase, the changes are persisted from then on
+func (w *userWatch) refreshDone() {
+	w.synced = true
+	w.present = nil
+}
+
+// setCookie records the cookie of the server, a response without cookie keeps the previous one
+func (w *userWatch) setCookie(cookie []byte) {
+	if len(cookie) > 0 {
+		w.cookie = cookie
+	}
+}
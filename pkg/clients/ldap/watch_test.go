@@ -0,0 +1,216 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncResult is a response of a fake sync search
+type syncResult struct {
+	entry    *ldap.Entry
+	controls []ldap.Control
+}
+
+// fakeSyncResponse returns the results of a fake sync search, then fails with err
+type fakeSyncResponse struct {
+	results []syncResult
+	current syncResult
+	err     error
+	next    int
+}
+
+func (r *fakeSyncResponse) Entry() *ldap.Entry       { return r.current.entry }
+func (r *fakeSyncResponse) Referral() string         { return "" }
+func (r *fakeSyncResponse) Controls() []ldap.Control { return r.current.controls }
+func (r *fakeSyncResponse) Err() error               { return r.err }
+
+func (r *fakeSyncResponse) Next() bool {
+	if r.next >= len(r.results) {
+		return false
+	}
+	r.current = r.results[r.next]
+	r.next++
+	return true
+}
+
+// fakeSyncConn is a watch connection answering the sync searches with its response
+type fakeSyncConn struct {
+	resp   *fakeSyncResponse
+	cookie []byte
+	mode   ldap.ControlSyncRequestMode
+	req    *ldap.SearchRequest
+	closed bool
+}
+
+func (c *fakeSyncConn) Syncrepl(_ context.Context, searchRequest *ldap.SearchRequest, _ int,
+	mode ldap.ControlSyncRequestMode, cookie []byte, _ bool) ldap.Response {
+	c.req = searchRequest
+	c.mode = mode
+	c.cookie = cookie
+	return c.resp
+}
+
+func (c *fakeSyncConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func syncEntry(id uuid.UUID, state ldap.ControlSyncStateState, dn string, attributes map[string][]string) syncResult {
+	return syncResult{
+		entry:    ldap.NewEntry(dn, attributes),
+		controls: []ldap.Control{&ldap.ControlSyncState{State: state, EntryUUID: id}},
+	}
+}
+
+func syncInfo(info *ldap.ControlSyncInfo) syncResult {
+	return syncResult{controls: []ldap.Control{info}}
+}
+
+func (suite *LDAPTestSuite) TestWatchUsers_ReportsDeletedAndInactiveUsers() {
+	assertions := assert.New(suite.T())
+
+	status, err := newUserStatus(LDAPUserStatus{Attribute: "employeeType", InactiveValues: []string{"terminated"}})
+	assertions.NoError(err)
+
+	alice, bob := uuid.New(), uuid.New()
+	first := &fakeSyncConn{resp: &fakeSyncResponse{
+		results: []syncResult{
+			syncEntry(alice, ldap.SyncStateAdd, "uid=alice,ou=users,dc=example,dc=com",
+				map[string][]string{"mail": {"alice@example.com"}}),
+			syncEntry(bob, ldap.SyncStateAdd, "uid=bob,ou=users,dc=example,dc=com",
+				map[string][]string{"mail": {"bob@example.com"}}),
+			syncInfo(&ldap.ControlSyncInfo{
+				Value:         ldap.SyncInfoRefreshDelete,
+				RefreshDelete: &ldap.ControlSyncInfoRefreshDelete{Cookie: []byte("cookie-1"), RefreshDone: true},
+			}),
+			syncEntry(alice, ldap.SyncStateDelete, "uid=alice,ou=users,dc=example,dc=com", nil),
+			syncEntry(bob, ldap.SyncStateModify, "uid=bob,ou=users,dc=example,dc=com",
+				map[string][]string{"mail": {"bob@example.com"}, "employeeType": {"terminated"}}),
+		},
+		err: ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset")),
+	}}
+	second := &fakeSyncConn{resp: &fakeSyncResponse{
+		results: []syncResult{
+			syncInfo(&ldap.ControlSyncInfo{
+				Value: ldap.SyncInfoSyncIdSet,
+				SyncIdSet: &ldap.ControlSyncInfoSyncIdSet{
+					Cookie: []byte("cookie-2"), RefreshDeletes: true, SyncUUIDs: []uuid.UUID{bob},
+				},
+			}),
+		},
+	}}
+	conns := []*fakeSyncConn{first, second}
+
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+	ldapConn := &LDAPConn{
+		baseUserDN:       "ou=users,dc=example,dc=com",
+		userSearchFilter: "(objectClass=person)",
+		userStatus:       status,
+		watchBackoff:     time.Millisecond,
+		dialWatch: func() (syncConn, error) {
+			conn := conns[0]
+			conns = conns[1:]
+			return conn, nil
+		},
+	}
+
+	var reported [][]string
+	err = ldapConn.WatchUsers(ctx, func(_ context.Context, emails []string) {
+		reported = append(reported, emails)
+		if len(reported) == 3 {
+			cancel()
+		}
+	})
+
+	assertions.NoError(err)
+	assertions.Equal([][]string{{"alice@example.com"}, {"bob@example.com"}, {"bob@example.com"}}, reported)
+	assertions.Equal("ou=users,dc=example,dc=com", first.req.BaseDN)
+	assertions.Equal("(objectClass=person)", first.req.Filter)
+	assertions.Equal([]string{"mail", "employeeType"}, first.req.Attributes)
+	assertions.Equal(ldap.SyncRequestModeRefreshAndPersist, first.mode)
+	assertions.Nil(first.cookie, "Expected the first sync search to refresh all the entries")
+	assertions.Equal([]byte("cookie-1"), second.cookie, "Expected the watch to resume from the last cookie")
+	assertions.True(first.closed)
+	assertions.True(second.closed)
+}
+
+func (suite *LDAPTestSuite) TestWatchUsers_UnsupportedServer() {
+	assertions := assert.New(suite.T())
+
+	dials := 0
+	ldapConn := &LDAPConn{
+		baseUserDN:   "ou=users,dc=example,dc=com",
+		watchBackoff: time.Millisecond,
+		dialWatch: func() (syncConn, error) {
+			dials++
+			return &fakeSyncConn{resp: &fakeSyncResponse{
+				err: ldap.NewError(ldap.LDAPResultUnavailableCriticalExtension,
+					errors.New("critical extension is unavailable")),
+			}}, nil
+		},
+	}
+
+	err := ldapConn.WatchUsers(suite.ctx, func(context.Context, []string) {
+		assertions.Fail("Expected no users to be reported")
+	})
+
+	assertions.Error(err)
+	assertions.Contains(err.Error(), "does not support the content synchronization")
+	assertions.Equal(1, dials, "Expected the watch not to reconnect")
+}
+
+func (suite *LDAPTestSuite) TestWatchUsers_NotEnabled() {
+	assertions := assert.New(suite.T())
+
+	err := (&LDAPConn{}).WatchUsers(suite.ctx, func(context.Context, []string) {})
+
+	assertions.Error(err)
+}
+
+func (suite *LDAPTestSuite) TestUserWatch_RefreshPresentReportsMissingEntries() {
+	assertions := assert.New(suite.T())
+
+	alice, bob := uuid.New(), uuid.New()
+	watch := &userWatch{
+		emails:  map[uuid.UUID]string{alice: "alice@example.com", bob: "bob@example.com"},
+		synced:  true,
+		present: map[uuid.UUID]bool{},
+	}
+
+	emails := watch.apply(nil, []ldap.Control{&ldap.ControlSyncState{State: ldap.SyncStatePresent, EntryUUID: alice}})
+	assertions.Empty(emails)
+
+	emails = watch.apply(nil, []ldap.Control{&ldap.ControlSyncInfo{
+		Value:          ldap.SyncInfoRefreshPresent,
+		RefreshPresent: &ldap.ControlSyncInfoRefreshPresent{Cookie: []byte("cookie"), RefreshDone: true},
+	}})
+
+	assertions.Equal([]string{"bob@example.com"}, emails)
+	assertions.Equal(map[uuid.UUID]string{alice: "alice@example.com"}, watch.emails)
+	assertions.Equal([]byte("cookie"), watch.cookie)
+	assertions.Nil(watch.present)
+}
+
+func (suite *LDAPTestSuite) TestUserWatch_InitialRefreshIsNotReported() {
+	assertions := assert.New(suite.T())
+
+	alice := uuid.New()
+	watch := &userWatch{emails: map[uuid.UUID]string{}, present: map[uuid.UUID]bool{}}
+
+	result := syncEntry(alice, ldap.SyncStateAdd, "uid=alice,ou=users,dc=example,dc=com",
+		map[string][]string{"mail": {"alice@example.com"}})
+	emails := watch.apply(result.entry, result.controls)
+	assertions.Empty(emails)
+
+	result = syncEntry(alice, ldap.SyncStateDelete, "uid=alice,ou=users,dc=example,dc=com", nil)
+	emails = watch.apply(result.entry, result.controls)
+
+	assertions.Empty(emails, "Expected the changes of the initial refresh to be recorded only")
+	assertions.Empty(watch.emails)
+}
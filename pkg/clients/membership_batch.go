@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import "slices"
+
+// DefaultMembershipBatchSize is the largest number of users passed to a single membership call
+// when the backend doesn't configure membership_batch_size
+const DefaultMembershipBatchSize = 100
+
+// InBatches calls fn with consecutive batches of at most batchSize user IDs, in order, and stops at
+// the first error. The batches already applied are kept, the next reconcile only retries the rest.
+// A batchSize of 0 uses DefaultMembershipBatchSize.
+func InBatches(userIDs []string, batchSize int, fn func(batch []string) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultMembershipBatchSize
+	}
+	for batch := range slices.Chunk(userIDs, batchSize) {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
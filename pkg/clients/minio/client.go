@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// MinioClient manages the groups of the built-in identity provider of MinIO, their members and the
+// policies attached to them, through the admin API (madmin)
+type MinioClient struct {
+	client    heimdall.Doer
+	url       string
+	accessKey string
+	secretKey string
+	region    string
+	headers   map[string]string
+}
+
+func NewClient(minioAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*MinioClient, error) {
+
+	minioConfig := MinioConfig{}
+	if err := utils.MapToStruct(minioAppConfig, &minioConfig); err != nil {
+		return nil, err
+	}
+	if minioConfig.Endpoint == "" || minioConfig.AccessKey == "" || minioConfig.SecretKey == "" {
+		return nil, errors.New("minio configuration is missing required fields: endpoint, access_key or secret_key")
+	}
+	region := minioConfig.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	client, err := httpclient.InitializeClient(
+		"minio_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &MinioClient{
+		client:    client,
+		url:       strings.TrimSuffix(minioConfig.Endpoint, "/") + adminAPIPath,
+		accessKey: minioConfig.AccessKey,
+		secretKey: minioConfig.SecretKey,
+		region:    region,
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+		},
+	}, nil
+}
+
+// HealthCheck lists the groups, which needs the admin credentials
+func (mC *MinioClient) HealthCheck(ctx context.Context) error {
+	if _, err := mC.sendRequest(ctx, "/groups", http.MethodGet, nil, nil, "backend.minio.HealthCheck"); err != nil {
+		return fmt.Errorf("minio health check failed: %w", err)
+	}
+	return nil
+}
+
+// decode decodes the response of a MinIO request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode minio response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the signed request to the path of the admin API and returns the response
+// body, any response code other than 2xx is returned as a *request.ResponseError
+func (mC *MinioClient) sendRequest(ctx context.Context, path string, method string, query url.Values, body any,
+	methodName string) ([]byte, error) {
+
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	requestURL, err := url.Parse(mC.url + path)
+	if err != nil {
+		return nil, err
+	}
+	requestURL.RawQuery = query.Encode()
+
+	headers := signV4(method, requestURL, requestBody, mC.accessKey, mC.secretKey, mC.region, time.Now())
+	maps.Copy(headers, mC.headers)
+	resp, _, err := request.Send(ctx, mC.client, method, requestURL.String(), requestBody, headers, methodName,
+		"minio", errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the error code and message of an error response of the admin API
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	return errResp.Code, errResp.Message
+}
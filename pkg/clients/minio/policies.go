@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamPolicies is the group param property listing the policies attached to the group, e.g.
+// readwrite or a custom policy granting access to the buckets of the group
+const groupParamPolicies = "policies"
+
+// ReconcileGroupParams attaches exactly the policies of the policies group param to the group, its
+// members are granted the access of the policies. The other policies are detached from the group.
+func (mC *MinioClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamPolicies {
+		return fmt.Errorf("unsupported minio group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "minio")
+
+	group, err := mC.fetchGroup(ctx, teamID, "backend.minio.ReconcileGroupParams")
+	if err != nil {
+		log.WithError(err).Error("failed to fetch the minio group policies")
+		return err
+	}
+	if slices.Equal(policyNames(group.Policy), policyNames(strings.Join(groupParams.Value, ","))) {
+		return nil
+	}
+
+	// the policies of a group are replaced as a whole, no policy detaches all of them
+	policies := strings.Join(policyNames(strings.Join(groupParams.Value, ",")), ",")
+	log.WithField("policies", policies).Info("updating the policies of the minio group")
+	_, err = mC.sendRequest(ctx, "/set-user-or-group-policy", http.MethodPut, url.Values{
+		"policyName":  {policies},
+		"userOrGroup": {teamID},
+		"isGroup":     {"true"},
+	}, nil, "backend.minio.ReconcileGroupParams")
+	return err
+}
+
+// policyNames returns the sorted names of the comma separated policies
+func policyNames(policies string) []string {
+	var names []string
+	for _, name := range strings.Split(policies, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
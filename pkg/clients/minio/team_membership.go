@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the members of the group keyed by access key
+func (mC *MinioClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	group, err := mC.fetchGroup(ctx, teamID, "backend.minio.FetchTeamMembersByTeamID")
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch minio group members")
+		return nil, err
+	}
+	members := make(map[string]*structs.User, len(group.Members))
+	for _, member := range group.Members {
+		members[member] = &structs.User{ID: member, UserName: member}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group in one request
+func (mC *MinioClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.AddUserToTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("userIDs", userIDs).WithField("service", "minio")
+	log.Info("Add users to minio group")
+
+	if err := mC.updateGroupMembers(ctx, groupAddRemove{Group: teamID, Members: userIDs},
+		"backend.minio.AddUserToTeam"); err != nil {
+		log.WithError(err).Error("failed to add users to minio group")
+		return err
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the group in one request. An empty removal would
+// delete the group, so it is skipped.
+func (mC *MinioClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.RemoveUserFromTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("userIDs", userIDs).WithField("service", "minio")
+	log.Info("Remove users from minio group")
+
+	if err := mC.updateGroupMembers(ctx, groupAddRemove{Group: teamID, Members: userIDs, IsRemove: true},
+		"backend.minio.RemoveUserFromTeam"); err != nil {
+		log.WithError(err).Error("failed to remove users from minio group")
+		return err
+	}
+	return nil
+}
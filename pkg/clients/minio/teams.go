@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups keyed by name, the name of a MinIO group is its ID
+func (mC *MinioClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.FetchAllTeams")
+	defer span.Finish()
+
+	groups, err := mC.listGroups(ctx, "backend.minio.FetchAllTeams")
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch minio groups")
+		return nil, err
+	}
+	teams := make(map[string]structs.Team, len(groups))
+	for _, name := range groups {
+		teams[name] = structs.Team{ID: name, Name: name}
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the group by name
+func (mC *MinioClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.FetchTeamDetails")
+	defer span.Finish()
+
+	group, err := mC.fetchGroup(ctx, teamID, "backend.minio.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	return &structs.Team{ID: group.Name, Name: group.Name}, nil
+}
+
+// CreateTeam creates the group without members, MinIO groups have no description
+func (mC *MinioClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "minio")
+	log.Info("Create minio group")
+
+	if err := mC.updateGroupMembers(ctx, groupAddRemove{Group: team.Name, Members: []string{}},
+		"backend.minio.CreateTeam"); err != nil {
+		log.WithError(err).Error("failed to create minio group")
+		return nil, err
+	}
+	return &structs.Team{ID: team.Name, Name: team.Name}, nil
+}
+
+// DeleteTeamByID deletes the group and its policy attachments, MinIO only deletes groups without
+// members so the members are removed first
+func (mC *MinioClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "minio")
+	log.Info("Delete minio group")
+
+	group, err := mC.fetchGroup(ctx, teamID, "backend.minio.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("minio group not found, considering deletion successful")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(group.Members) > 0 {
+		if err := mC.updateGroupMembers(ctx, groupAddRemove{Group: teamID, Members: group.Members, IsRemove: true},
+			"backend.minio.DeleteTeamByID"); err != nil {
+			log.WithError(err).Error("failed to remove the members of the minio group")
+			return err
+		}
+	}
+	return mC.updateGroupMembers(ctx, groupAddRemove{Group: teamID, Members: []string{}, IsRemove: true},
+		"backend.minio.DeleteTeamByID")
+}
+
+func (mC *MinioClient) listGroups(ctx context.Context, methodName string) ([]string, error) {
+	resp, err := mC.sendRequest(ctx, "/groups", http.MethodGet, nil, nil, methodName)
+	if err != nil {
+		return nil, err
+	}
+	var groups []string
+	if err := decode(resp, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (mC *MinioClient) fetchGroup(ctx context.Context, name string, methodName string) (*groupDesc, error) {
+	resp, err := mC.sendRequest(ctx, "/group", http.MethodGet, url.Values{"group": {name}}, nil, methodName)
+	if err != nil {
+		return nil, err
+	}
+	var group groupDesc
+	if err := decode(resp, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// updateGroupMembers adds or removes the members of the group, an add creates the group and a
+// remove without members deletes it
+func (mC *MinioClient) updateGroupMembers(ctx context.Context, update groupAddRemove, methodName string) error {
+	_, err := mC.sendRequest(ctx, "/update-group-members", http.MethodPut, nil, update, methodName)
+	return err
+}
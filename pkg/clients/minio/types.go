@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+const (
+	// adminAPIPath is the path of the admin API of MinIO (madmin)
+	adminAPIPath = "/minio/admin/v3"
+	// defaultRegion is the region of the signatures when the backend has none
+	defaultRegion = "us-east-1"
+)
+
+// Statuses of the MinIO users
+const (
+	userStatusEnabled  = "enabled"
+	userStatusDisabled = "disabled"
+)
+
+// MinioConfig is the connection of a MinIO backend, read from the backend configuration
+type MinioConfig struct {
+	// Endpoint is the URL of the MinIO server, e.g. https://minio.example.com
+	Endpoint string `json:"endpoint"`
+	// AccessKey and SecretKey are the credentials of a user with the admin:* actions on the users,
+	// groups and policies
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	// Region is the region of the server, us-east-1 by default
+	Region string `json:"region"`
+}
+
+// groupDesc is a group of the MinIO built-in identity provider, its policy holds the comma
+// separated names of the policies attached to the group
+type groupDesc struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Members []string `json:"members"`
+	Policy  string   `json:"policy"`
+}
+
+// groupAddRemove adds members to a group, creating it, or removes members from a group, deleting
+// it when no members are given
+type groupAddRemove struct {
+	Group    string   `json:"group"`
+	Members  []string `json:"members"`
+	Status   string   `json:"groupStatus,omitempty"`
+	IsRemove bool     `json:"isRemove"`
+}
+
+// userInfo is a user of the MinIO built-in identity provider, identified by its access key
+type userInfo struct {
+	PolicyName string   `json:"policyName,omitempty"`
+	Status     string   `json:"status"`
+	MemberOf   []string `json:"memberOf,omitempty"`
+}
+
+// errorResponse is the error response of the MinIO admin API
+type errorResponse struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the members of the groups keyed by access key, MinIO users have no email.
+// The users list of the admin API is encrypted with the secret key of the admin, the users outside
+// of the groups are looked up by CreateUser instead.
+func (mC *MinioClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.FetchAllUsers")
+	defer span.Finish()
+
+	groups, err := mC.listGroups(ctx, "backend.minio.FetchAllUsers")
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch minio groups")
+		return nil, nil, err
+	}
+	usersByID := make(map[string]*structs.User)
+	for _, name := range groups {
+		group, err := mC.fetchGroup(ctx, name, "backend.minio.FetchAllUsers")
+		if err != nil {
+			logger.Logger(ctx).WithField("group", name).WithError(err).Error("failed to fetch minio group members")
+			return nil, nil, err
+		}
+		for _, member := range group.Members {
+			usersByID[member] = &structs.User{ID: member, UserName: member}
+		}
+	}
+	return usersByID, map[string]*structs.User{}, nil
+}
+
+// FetchUserDetails fetches the user by access key
+func (mC *MinioClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.FetchUserDetails")
+	defer span.Finish()
+
+	if _, err := mC.fetchUser(ctx, userID, "backend.minio.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return &structs.User{ID: userID, UserName: userID}, nil
+}
+
+// CreateUser looks the user up by its username, which is its access key. The users cannot be created
+// by usernaut as their secret key would have to be handed to them, they are created by the MinIO
+// admins. A user disabled by its offboarding is enabled again.
+func (mC *MinioClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("username", u.UserName).WithField("service", "minio")
+	log.Info("Look up minio user")
+
+	user, err := mC.fetchUser(ctx, u.UserName, "backend.minio.CreateUser")
+	if request.IsNotFound(err) {
+		return nil, fmt.Errorf("minio user %s not found, minio users are created by the minio admins: %w", u.UserName, err)
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to look up minio user")
+		return nil, err
+	}
+	if user.Status == userStatusDisabled {
+		log.Info("Enable minio user")
+		if err := mC.setUserStatus(ctx, u.UserName, userStatusEnabled, "backend.minio.CreateUser"); err != nil {
+			log.WithError(err).Error("failed to enable minio user")
+			return nil, err
+		}
+	}
+	return &structs.User{ID: u.UserName, UserName: u.UserName}, nil
+}
+
+// DeleteUser disables the user, which keeps its access key and secret key for its admins but
+// denies its requests. A user which does not exist is considered deleted.
+func (mC *MinioClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.minio.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "minio")
+	log.Info("Disable minio user")
+
+	err := mC.setUserStatus(ctx, userID, userStatusDisabled, "backend.minio.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("minio user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+func (mC *MinioClient) fetchUser(ctx context.Context, accessKey string, methodName string) (*userInfo, error) {
+	resp, err := mC.sendRequest(ctx, "/user-info", http.MethodGet, url.Values{"accessKey": {accessKey}}, nil, methodName)
+	if err != nil {
+		return nil, err
+	}
+	var user userInfo
+	if err := decode(resp, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (mC *MinioClient) setUserStatus(ctx context.Context, accessKey string, status string, methodName string) error {
+	_, err := mC.sendRequest(ctx, "/set-user-status", http.MethodPut,
+		url.Values{"accessKey": {accessKey}, "status": {status}}, nil, methodName)
+	return err
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// groupParamAppAssignments is the group param property listing the IDs of the apps the group is
+// assigned to
+const groupParamAppAssignments = "app_assignments"
+
+// ReconcileGroupParams assigns the group to exactly the apps of the app_assignments group param,
+// the members of the group are then assigned to the apps. The group is unassigned from the other
+// apps.
+func (oC *OktaClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamAppAssignments {
+		return fmt.Errorf("unsupported okta group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "okta")
+
+	var assigned []string
+	filter := url.QueryEscape(fmt.Sprintf(`group.id eq %q`, teamID))
+	err := forEachPage(ctx, oC, "/apps?filter="+filter, "backend.okta.ReconcileGroupParams", func(apps []App) error {
+		for _, app := range apps {
+			assigned = append(assigned, app.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, appID := range groupParams.Value {
+		if slices.Contains(assigned, appID) {
+			continue
+		}
+		log.WithField("appID", appID).Info("Assign okta group to app")
+		if _, err := oC.sendRequest(ctx, assignmentPath(appID, teamID), http.MethodPut, map[string]any{},
+			"backend.okta.ReconcileGroupParams"); err != nil {
+			if request.IsNotFound(err) {
+				return fmt.Errorf("okta app %s not found: %w", appID, err)
+			}
+			return err
+		}
+	}
+	for _, appID := range assigned {
+		if slices.Contains(groupParams.Value, appID) {
+			continue
+		}
+		log.WithField("appID", appID).Info("Unassign okta group from app")
+		if _, err := oC.sendRequest(ctx, assignmentPath(appID, teamID), http.MethodDelete, nil,
+			"backend.okta.ReconcileGroupParams"); err != nil && !request.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignmentPath returns the path of the assignment of the group to the app
+func assignmentPath(appID, teamID string) string {
+	return fmt.Sprintf("/apps/%s/groups/%s", url.PathEscape(appID), url.PathEscape(teamID))
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// OktaClient manages the groups of an Okta org, their members and their app assignments through
+// the Okta management API
+type OktaClient struct {
+	client      heimdall.Doer
+	url         string
+	headers     map[string]string
+	manageUsers bool
+}
+
+func NewClient(oktaAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*OktaClient, error) {
+
+	oktaConfig := OktaConfig{}
+	if err := utils.MapToStruct(oktaAppConfig, &oktaConfig); err != nil {
+		return nil, err
+	}
+	if oktaConfig.OrgURL == "" || oktaConfig.APIToken == "" {
+		return nil, errors.New("okta configuration is missing required fields: org_url or api_token")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"okta_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &OktaClient{
+		client: client,
+		url:    strings.TrimSuffix(oktaConfig.OrgURL, "/") + "/api/v1",
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+			"Accept":                       "application/json",
+			"Authorization":                "SSWS " + oktaConfig.APIToken,
+		},
+		manageUsers: oktaConfig.ManageUsers,
+	}, nil
+}
+
+// HealthCheck lists a single group, the Okta API is healthy when it answers with the API token
+func (oC *OktaClient) HealthCheck(ctx context.Context) error {
+	if _, err := oC.sendRequest(ctx, "/groups?limit=1", http.MethodGet, nil, "backend.okta.HealthCheck"); err != nil {
+		return fmt.Errorf("okta health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path and decodes its response
+func (oC *OktaClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := oC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of an Okta request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode okta response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the Okta API and returns the response body
+func (oC *OktaClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := oC.send(ctx, oC.url+path, method, body, methodName)
+	return resp, err
+}
+
+// send sends the request to the URL and returns the response body and headers, any response code
+// other than 2xx is returned as a *request.ResponseError
+func (oC *OktaClient) send(ctx context.Context, url string, method string, body any,
+	methodName string) ([]byte, http.Header, error) {
+	return request.SendJSON(ctx, oC.client, method, url, body, oC.headers, methodName, "okta", errorMessage)
+}
+
+// forEachPage calls fn with each page of the resources of the path, following the next links of
+// the cursor pagination of the Okta API
+func forEachPage[T any](ctx context.Context, oC *OktaClient, path string, methodName string,
+	fn func(page []T) error) error {
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	pageURL := fmt.Sprintf("%s%s%slimit=%d", oC.url, path, separator, pageSize)
+	for pageURL != "" {
+		resp, headers, err := oC.send(ctx, pageURL, http.MethodGet, nil, methodName)
+		if err != nil {
+			return err
+		}
+		var page []T
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		pageURL = nextLink(headers)
+	}
+	return nil
+}
+
+// nextLink returns the URL of the next page from the Link headers of a list response, or an empty
+// string for the last page
+func nextLink(headers http.Header) string {
+	for _, header := range headers.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if ok && strings.Contains(params, `rel="next"`) {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// errorMessage returns the Okta error code of an error response, e.g. E0000001 for an invalid
+// request, and its summary followed by the summaries of its causes
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	summaries := []string{errResp.ErrorSummary}
+	for _, cause := range errResp.ErrorCauses {
+		summaries = append(summaries, cause.ErrorSummary)
+	}
+	return errResp.ErrorCode, strings.Trim(strings.Join(summaries, ": "), ": ")
+}
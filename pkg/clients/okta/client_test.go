@@ -0,0 +1,264 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeOkta is an Okta org holding its users, group members and app assignments in memory
+type fakeOkta struct {
+	users       []User
+	members     map[string]bool
+	apps        []string
+	deactivated []string
+	deleted     []string
+}
+
+func (f *fakeOkta) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "SSWS token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errorCode": "E0000011", "errorSummary": "Invalid token provided"}`))
+		return
+	}
+
+	resource := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	switch {
+	case r.Method == http.MethodGet && resource == "/users" && r.URL.Query().Has("search"):
+		var users []User
+		for _, u := range f.users {
+			if r.URL.Query().Get("search") == fmt.Sprintf("profile.email eq %q", u.Profile.Email) {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, users)
+	case r.Method == http.MethodGet && resource == "/users":
+		// a page of a single user, with the cursor of the next one
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		if after+1 < len(f.users) {
+			w.Header().Add("Link", fmt.Sprintf(`<http://%s/api/v1/users?limit=200>; rel="self"`, r.Host))
+			w.Header().Add("Link", fmt.Sprintf(`<http://%s/api/v1/users?after=%d&limit=200>; rel="next"`, r.Host, after+1))
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, f.users[after:after+1])
+	case r.Method == http.MethodPost && resource == "/users":
+		var user User
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		for _, existing := range f.users {
+			if existing.Profile.Login == user.Profile.Login {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errorCode": "E0000001", "errorSummary": "Api validation failed: login",
+					"errorCauses": [{
+						"errorSummary": "login: An object with this field already exists in the current organization"}]}`))
+				return
+			}
+		}
+		user.ID = fmt.Sprintf("00u%d", len(f.users)+1)
+		user.Status = "ACTIVE"
+		f.users = append(f.users, user)
+		fakehttp.WriteJSON(w, http.StatusOK, user)
+	case r.Method == http.MethodPost && strings.HasSuffix(resource, "/lifecycle/deactivate"):
+		f.deactivated = append(f.deactivated, strings.Split(resource, "/")[2])
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodGet && resource == "/groups":
+		if r.URL.Query().Has("filter") && r.URL.Query().Get("filter") != `type eq "OKTA_GROUP"` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id": "00g1", "type": "OKTA_GROUP", "profile": {"name": "team-a", "description": "A"}}]`))
+	case r.Method == http.MethodPost && resource == "/groups":
+		var group Group
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		group.ID = "00g2"
+		fakehttp.WriteJSON(w, http.StatusOK, group)
+	case r.Method == http.MethodGet && resource == "/groups/00g1/users":
+		var users []User
+		for _, u := range f.users {
+			if f.members[u.ID] {
+				users = append(users, u)
+			}
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, users)
+	case strings.HasPrefix(resource, "/groups/00g1/users/"):
+		userID := strings.TrimPrefix(resource, "/groups/00g1/users/")
+		if r.Method == http.MethodPut {
+			f.members[userID] = true
+		} else {
+			delete(f.members, userID)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && resource == "/apps" && r.URL.Query().Get("filter") == `group.id eq "00g1"`:
+		apps := make([]App, 0, len(f.apps))
+		for _, appID := range f.apps {
+			apps = append(apps, App{ID: appID})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, apps)
+	case strings.HasPrefix(resource, "/apps/") && strings.HasSuffix(resource, "/groups/00g1"):
+		appID := strings.Split(resource, "/")[2]
+		if r.Method == http.MethodPut {
+			f.apps = append(f.apps, appID)
+			_, _ = w.Write([]byte(`{"id": "00g1"}`))
+			return
+		}
+		for i, assigned := range f.apps {
+			if assigned == appID {
+				f.apps = append(f.apps[:i], f.apps[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		f.deleted = append(f.deleted, resource)
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeOkta, connection map[string]interface{}) *OktaClient {
+	t.Helper()
+	if fake.members == nil {
+		fake.members = map[string]bool{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["org_url"] = server.URL + "/"
+	connection["api_token"] = "token"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"org_url": "https://example.okta.com"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "org_url or api_token")
+}
+
+func TestNextLink(t *testing.T) {
+	headers := http.Header{}
+	assert.Empty(t, nextLink(headers))
+
+	headers.Add("Link", `<https://example.okta.com/api/v1/users?limit=2>; rel="self", `+
+		`<https://example.okta.com/api/v1/users?after=00u2&limit=2>; rel="next"`)
+	assert.Equal(t, "https://example.okta.com/api/v1/users?after=00u2&limit=2", nextLink(headers))
+}
+
+func TestFetchAllUsersPages(t *testing.T) {
+	fake := &fakeOkta{users: []User{
+		{ID: "00u1", Profile: UserProfile{Login: "jdoe@example.com", Email: "jdoe@example.com", FirstName: "John",
+			LastName: "Doe"}},
+		{ID: "00u2", Profile: UserProfile{Login: "asmith@example.com", Email: "asmith@example.com"}},
+		{ID: "00u3", Profile: UserProfile{Login: "bob@example.com", Email: "bob@example.com"}},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 3)
+	assert.Equal(t, "00u3", byEmail["bob@example.com"].ID)
+	assert.Equal(t, "John Doe", byID["00u1"].DisplayName)
+}
+
+func TestCreateUser(t *testing.T) {
+	fake := &fakeOkta{users: []User{
+		{ID: "00u1", Status: "ACTIVE", Profile: UserProfile{Login: "jdoe@example.com", Email: "jdoe@example.com"}},
+		{ID: "00u2", Status: userStatusDeprovisioned, Profile: UserProfile{Login: "old@example.com",
+			Email: "old@example.com"}},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "00u1", user.ID)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "new", Email: "new@example.com"})
+	assert.ErrorContains(t, err, "okta user new@example.com not found")
+
+	client = newTestClient(t, fake, map[string]interface{}{"manage_users": true})
+	user, err = client.CreateUser(context.Background(), &structs.User{UserName: "new", Email: "new@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "00u3", user.ID)
+	assert.Equal(t, "new@example.com", user.UserName)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "old", Email: "old@example.com"})
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+
+	require.NoError(t, client.DeleteUser(context.Background(), "00u3"))
+	assert.Equal(t, []string{"00u3"}, fake.deactivated)
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeOkta{users: []User{{ID: "00u1"}, {ID: "00u2"}}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"team-a": {ID: "00g1", Name: "team-a", Description: "A"}}, teams)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "team-b", Description: "B"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "00g2", Name: "team-b", Description: "B"}, team)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "00g1", []string{"00u1", "00u2"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "00g1", []string{"00u2"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "00g1")
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Contains(t, members, "00u1")
+
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "00g2"))
+	// the users are only deactivated when the backend manages them
+	assert.NoError(t, client.DeleteUser(context.Background(), "00u1"))
+	assert.Equal(t, []string{"/groups/00g2"}, fake.deleted)
+	assert.Empty(t, fake.deactivated)
+}
+
+func TestReconcileAppAssignments(t *testing.T) {
+	fake := &fakeOkta{apps: []string{"0oa1", "0oa2"}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "00g1", structs.TeamParams{
+		Property: "app_assignments", Value: []string{"0oa2", "0oa3"},
+	}))
+	assert.Equal(t, []string{"0oa2", "0oa3"}, fake.apps)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "00g1", structs.TeamParams{
+		Property: "app_roles", Value: []string{"x"},
+	}), "unsupported okta group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeOkta{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "SSWS wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()),
+		"response code 401: E0000011: Invalid token provided")
+}
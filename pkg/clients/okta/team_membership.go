@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchTeamMembersByTeamID lists the members of the group by ID, keyed by ID
+func (oC *OktaClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	members := make(map[string]*structs.User)
+	err := forEachPage(ctx, oC, "/groups/"+url.PathEscape(teamID)+"/users", "backend.okta.FetchTeamMembersByTeamID",
+		func(users []User) error {
+			for _, u := range users {
+				members[u.ID] = userDetails(&u)
+			}
+			return nil
+		})
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch okta group members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group, Okta has no bulk membership endpoint
+func (oC *OktaClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.AddUserToTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "okta")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Add user to okta group")
+		_, err := oC.sendRequest(ctx, membershipPath(teamID, userID), http.MethodPut, nil, "backend.okta.AddUserToTeam")
+		if err != nil {
+			return fmt.Errorf("failed to add user %s to okta group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveUserFromTeam deletes the group memberships of the users one at a time, Okta answers 404 for
+// a user without membership, which is skipped
+func (oC *OktaClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.RemoveUserFromTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "okta")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Remove user from okta group")
+		_, err := oC.sendRequest(ctx, membershipPath(teamID, userID), http.MethodDelete, nil,
+			"backend.okta.RemoveUserFromTeam")
+		if err != nil && !request.IsNotFound(err) {
+			return fmt.Errorf("failed to remove user %s from okta group %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// membershipPath returns the path of the membership of the user in the group
+func membershipPath(teamID, userID string) string {
+	return fmt.Sprintf("/groups/%s/users/%s", url.PathEscape(teamID), url.PathEscape(userID))
+}
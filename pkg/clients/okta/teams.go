@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the Okta groups of the org, keyed by name. The groups imported from a
+// directory or an app are not managed by usernaut.
+func (oC *OktaClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := oC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch okta groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with each page of the Okta groups of the org, 200 groups at a time
+func (oC *OktaClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	filter := url.QueryEscape(fmt.Sprintf(`type eq %q`, groupTypeOkta))
+	return forEachPage(ctx, oC, "/groups?filter="+filter, "backend.okta.FetchAllTeams", func(groups []Group) error {
+		page := make([]structs.Team, 0, len(groups))
+		for _, group := range groups {
+			page = append(page, teamDetails(&group))
+		}
+		return fn(page)
+	})
+}
+
+// FetchTeamDetails fetches the group by ID
+func (oC *OktaClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.FetchTeamDetails")
+	defer span.Finish()
+
+	var group Group
+	if err := oC.get(ctx, "/groups/"+url.PathEscape(teamID), &group, "backend.okta.FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+	team := teamDetails(&group)
+	return &team, nil
+}
+
+// CreateTeam creates the Okta group with the description of the team
+func (oC *OktaClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "okta")
+	log.Info("Create okta group")
+
+	resp, err := oC.sendRequest(ctx, "/groups", http.MethodPost, &Group{Profile: GroupProfile{
+		Name:        team.Name,
+		Description: team.Description,
+	}}, "backend.okta.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create okta group")
+		return nil, err
+	}
+
+	var created Group
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no group ID in the okta create group response")
+	}
+	details := teamDetails(&created)
+	return &details, nil
+}
+
+// DeleteTeamByID deletes the group by ID, which unassigns it from its apps. A group which does
+// not exist is considered deleted.
+func (oC *OktaClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "okta")
+	log.Info("Delete okta group")
+
+	_, err := oC.sendRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.okta.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("okta group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// teamDetails converts the Okta group
+func teamDetails(g *Group) structs.Team {
+	return structs.Team{
+		ID:          g.ID,
+		Name:        g.Profile.Name,
+		Description: g.Profile.Description,
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+const (
+	// pageSize is the number of resources requested per list page
+	pageSize = 200
+	// groupTypeOkta is the type of the groups managed in Okta, as opposed to the groups imported
+	// from a directory or an app
+	groupTypeOkta = "OKTA_GROUP"
+	// userStatusDeprovisioned is the status of the deactivated users
+	userStatusDeprovisioned = "DEPROVISIONED"
+)
+
+// OktaConfig is the connection of an Okta backend, read from the backend configuration
+type OktaConfig struct {
+	// OrgURL is the URL of the Okta org, e.g. https://example.okta.com
+	OrgURL string `json:"org_url"`
+	// APIToken is the API token of an admin, sent with the SSWS scheme
+	APIToken string `json:"api_token"`
+	// ManageUsers creates the users missing from Okta and deactivates the offboarded users,
+	// otherwise the users are looked up by email and never changed
+	ManageUsers bool `json:"manage_users"`
+}
+
+// User is a user of the Okta org
+type User struct {
+	ID      string      `json:"id,omitempty"`
+	Status  string      `json:"status,omitempty"`
+	Profile UserProfile `json:"profile"`
+}
+
+// UserProfile is the profile of an Okta user, the login is usually the email
+type UserProfile struct {
+	Login       string `json:"login"`
+	Email       string `json:"email"`
+	FirstName   string `json:"firstName,omitempty"`
+	LastName    string `json:"lastName,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Group is a group of the Okta org
+type Group struct {
+	ID      string       `json:"id,omitempty"`
+	Type    string       `json:"type,omitempty"`
+	Profile GroupProfile `json:"profile"`
+}
+
+// GroupProfile is the profile of an Okta group
+type GroupProfile struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// App is an app integration of the Okta org
+type App struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+}
+
+// errorResponse is the body of the Okta API errors
+type errorResponse struct {
+	ErrorCode    string `json:"errorCode"`
+	ErrorSummary string `json:"errorSummary"`
+	ErrorCauses  []struct {
+		ErrorSummary string `json:"errorSummary"`
+	} `json:"errorCauses"`
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the users of the org which are not deprovisioned, keyed by ID and by email
+func (oC *OktaClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := oC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch okta users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the users of the org which are not deprovisioned,
+// 200 users at a time
+func (oC *OktaClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return forEachPage(ctx, oC, "/users", "backend.okta.FetchAllUsers", func(oktaUsers []User) error {
+		page := make([]*structs.User, 0, len(oktaUsers))
+		for _, u := range oktaUsers {
+			page = append(page, userDetails(&u))
+		}
+		return fn(page)
+	})
+}
+
+// FetchUserDetails fetches the user by ID or login
+func (oC *OktaClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := oC.get(ctx, "/users/"+url.PathEscape(userID), &user, "backend.okta.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser looks the user up by email, the users of an Okta org are usually provisioned by its
+// directory. The user is created and activated when it does not exist and the backend manages the
+// users, with the email as login.
+func (oC *OktaClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "okta")
+	if u.Email == "" {
+		return nil, fmt.Errorf("okta users are looked up by email, user %s has none", u.UserName)
+	}
+
+	var users []User
+	search := url.QueryEscape(fmt.Sprintf(`profile.email eq %q`, u.Email))
+	if err := oC.get(ctx, "/users?search="+search, &users, "backend.okta.CreateUser"); err != nil {
+		log.WithError(err).Error("failed to search okta user")
+		return nil, err
+	}
+	for _, user := range users {
+		if user.Status != userStatusDeprovisioned {
+			return userDetails(&user), nil
+		}
+	}
+	if !oC.manageUsers {
+		return nil, fmt.Errorf("okta user %s not found", u.Email)
+	}
+
+	log.Info("Create okta user")
+	resp, err := oC.sendRequest(ctx, "/users?activate=true", http.MethodPost, &User{Profile: UserProfile{
+		Login:       u.Email,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		DisplayName: u.DisplayName,
+	}}, "backend.okta.CreateUser")
+	if err != nil {
+		// the login of a deprovisioned user is still taken
+		var respErr *request.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest &&
+			strings.Contains(respErr.Message, "already exists") {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		log.WithError(err).Error("failed to create okta user")
+		return nil, err
+	}
+
+	var created User
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no user ID in the okta create user response")
+	}
+	return userDetails(&created), nil
+}
+
+// DeleteUser deactivates the user when the backend manages the users, which unassigns it from the
+// apps and groups. The deactivated users are kept by Okta until an admin deletes them. A user which
+// does not exist is considered deleted.
+func (oC *OktaClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.okta.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "okta")
+	if !oC.manageUsers {
+		log.Info("okta users are not managed, skipping user deletion")
+		return nil
+	}
+
+	log.Info("Deactivate okta user")
+	_, err := oC.sendRequest(ctx, "/users/"+url.PathEscape(userID)+"/lifecycle/deactivate", http.MethodPost, nil,
+		"backend.okta.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("okta user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// userDetails converts the Okta user, the login is the username of the user
+func userDetails(u *User) *structs.User {
+	displayName := u.Profile.DisplayName
+	if displayName == "" {
+		displayName = strings.TrimSpace(u.Profile.FirstName + " " + u.Profile.LastName)
+	}
+	return &structs.User{
+		ID:          u.ID,
+		UserName:    u.Profile.Login,
+		Email:       u.Profile.Email,
+		FirstName:   u.Profile.FirstName,
+		LastName:    u.Profile.LastName,
+		DisplayName: displayName,
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// OpenShiftClient writes the OpenShift Group objects of the teams and the role bindings of the
+// groups, to the cluster of the operator or to a remote cluster
+type OpenShiftClient struct {
+	client            dynamic.Interface
+	usernameFromEmail bool
+}
+
+func NewClient(openshiftAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig) (*OpenShiftClient, error) {
+
+	openshiftConfig := OpenShiftConfig{}
+	if err := utils.MapToStruct(openshiftAppConfig, &openshiftConfig); err != nil {
+		return nil, err
+	}
+	switch openshiftConfig.UsernameFrom {
+	case "", "username", usernameFromEmail:
+	default:
+		return nil, fmt.Errorf("invalid openshift username_from %q, supported: username, email", openshiftConfig.UsernameFrom)
+	}
+
+	restConfig, err := restConfig(openshiftConfig)
+	if err != nil {
+		return nil, err
+	}
+	// the requests of the client are recorded and throttled like the requests of the other backends
+	restConfig.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return httpclient.BackendTransport(rt, connectionPoolConfig)
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize kubernetes client: %w", err)
+	}
+	return &OpenShiftClient{
+		client:            client,
+		usernameFromEmail: openshiftConfig.UsernameFrom == usernameFromEmail,
+	}, nil
+}
+
+// restConfig returns the config of the cluster of the kubeconfig, or of the cluster of the
+// operator when there is no kubeconfig
+func restConfig(openshiftConfig OpenShiftConfig) (*rest.Config, error) {
+	if openshiftConfig.Kubeconfig == "" {
+		if openshiftConfig.Context != "" {
+			return ctrlconfig.GetConfigWithContext(openshiftConfig.Context)
+		}
+		return ctrlconfig.GetConfig()
+	}
+
+	kubeconfig, err := clientcmd.Load([]byte(openshiftConfig.Kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("invalid openshift kubeconfig: %w", err)
+	}
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*kubeconfig, openshiftConfig.Context,
+		&clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid openshift kubeconfig: %w", err)
+	}
+	return restConfig, nil
+}
+
+// HealthCheck lists a single group, the cluster is healthy when the credentials can read the groups
+func (oC *OpenShiftClient) HealthCheck(ctx context.Context) error {
+	if _, err := oC.client.Resource(groupsGVR).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return fmt.Errorf("openshift health check failed: %w", err)
+	}
+	return nil
+}
+
+// fromUnstructured converts the object read by the dynamic client
+func fromUnstructured(obj *unstructured.Unstructured, into any) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, into); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// toUnstructured converts the object to write with the dynamic client
+func toUnstructured(obj any) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// namespacePattern matches the namespace of the path of a namespaced object
+var namespacePattern = regexp.MustCompile(`/namespaces/[^/]+`)
+
+// fakeAPIServer is a Kubernetes API server holding its objects in memory, keyed by their path. It
+// is served over TLS, the credentials of a kubeconfig are only sent to https servers.
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	objects map[string]map[string]any
+	// conflicts is the number of updates rejected with a conflict before the next ones succeed
+	conflicts int
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Bearer token" {
+		f.status(w, http.StatusUnauthorized, "Unauthorized", "")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if obj, ok := f.objects[r.URL.Path]; ok {
+			_ = json.NewEncoder(w).Encode(obj)
+			return
+		}
+		if !f.isCollection(r.URL.Path) {
+			f.status(w, http.StatusNotFound, "NotFound", path.Base(r.URL.Path))
+			return
+		}
+		f.list(w, r)
+	case http.MethodPost:
+		var obj map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&obj)
+		name := obj["metadata"].(map[string]any)["name"].(string)
+		if _, exists := f.objects[r.URL.Path+"/"+name]; exists {
+			f.status(w, http.StatusConflict, "AlreadyExists", name)
+			return
+		}
+		obj["metadata"].(map[string]any)["resourceVersion"] = "1"
+		f.objects[r.URL.Path+"/"+name] = obj
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(obj)
+	case http.MethodPut:
+		var obj map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&obj)
+		current, ok := f.objects[r.URL.Path]
+		if !ok {
+			f.status(w, http.StatusNotFound, "NotFound", path.Base(r.URL.Path))
+			return
+		}
+		version := current["metadata"].(map[string]any)["resourceVersion"].(string)
+		if f.conflicts > 0 || obj["metadata"].(map[string]any)["resourceVersion"] != version {
+			f.conflicts--
+			// another writer updated the object
+			next, _ := strconv.Atoi(version)
+			current["metadata"].(map[string]any)["resourceVersion"] = strconv.Itoa(next + 1)
+			f.status(w, http.StatusConflict, "Conflict", path.Base(r.URL.Path))
+			return
+		}
+		next, _ := strconv.Atoi(version)
+		obj["metadata"].(map[string]any)["resourceVersion"] = strconv.Itoa(next + 1)
+		f.objects[r.URL.Path] = obj
+		_ = json.NewEncoder(w).Encode(obj)
+	case http.MethodDelete:
+		if _, ok := f.objects[r.URL.Path]; !ok {
+			f.status(w, http.StatusNotFound, "NotFound", path.Base(r.URL.Path))
+			return
+		}
+		delete(f.objects, r.URL.Path)
+		f.status(w, http.StatusOK, "", "")
+	}
+}
+
+// isCollection reports whether the path is the path of a list of objects
+func (f *fakeAPIServer) isCollection(p string) bool {
+	return strings.HasSuffix(p, "/groups") || strings.HasSuffix(p, "/users") || strings.HasSuffix(p, "rolebindings")
+}
+
+// list lists the objects of the collection, in all the namespaces for a collection without
+// namespace, matching the label selector
+func (f *fakeAPIServer) list(w http.ResponseWriter, r *http.Request) {
+	selector := r.URL.Query().Get("labelSelector")
+	items := []any{}
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		collection := path.Dir(key)
+		if collection != r.URL.Path && namespacePattern.ReplaceAllString(collection, "") != r.URL.Path {
+			continue
+		}
+		obj := f.objects[key]
+		if selector != "" {
+			label, value, _ := strings.Cut(selector, "=")
+			labels, _ := obj["metadata"].(map[string]any)["labels"].(map[string]any)
+			if labels[label] != value {
+				continue
+			}
+		}
+		items = append(items, obj)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"apiVersion": "v1", "kind": "List", "metadata": map[string]any{}, "items": items,
+	})
+}
+
+func (f *fakeAPIServer) status(w http.ResponseWriter, code int, reason, name string) {
+	w.WriteHeader(code)
+	status := "Failure"
+	if code == http.StatusOK {
+		status = "Success"
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"kind": "Status", "apiVersion": "v1", "metadata": map[string]any{}, "status": status,
+		"message": fmt.Sprintf("%s %s", reason, name), "reason": reason, "code": code,
+		"details": map[string]any{"name": name},
+	})
+}
+
+// group returns the Group object of the fake API server
+func (f *fakeAPIServer) group(name string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.objects["/apis/user.openshift.io/v1/groups/"+name]
+}
+
+func newTestClient(t *testing.T, fake *fakeAPIServer, connection map[string]interface{}) *OpenShiftClient {
+	t.Helper()
+	if fake.objects == nil {
+		fake.objects = map[string]map[string]any{}
+	}
+	server := httptest.NewTLSServer(fake)
+	t.Cleanup(server.Close)
+
+	connection["kubeconfig"] = fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+users:
+- name: usernaut
+  user:
+    token: token
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: usernaut
+current-context: remote
+`, server.URL)
+	client, err := NewClient(connection, httpclient.ConnectionPoolConfig{BackendName: t.Name(), BackendType: "openshift"})
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"username_from": "uid"}, httpclient.ConnectionPoolConfig{})
+	assert.ErrorContains(t, err, "invalid openshift username_from")
+
+	_, err = NewClient(map[string]interface{}{"kubeconfig": "not: [a kubeconfig"}, httpclient.ConnectionPoolConfig{})
+	assert.ErrorContains(t, err, "invalid openshift kubeconfig")
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeAPIServer{objects: map[string]map[string]any{
+		"/apis/user.openshift.io/v1/users/jdoe@example.com": {
+			"apiVersion": "user.openshift.io/v1", "kind": "User",
+			"metadata": map[string]any{"name": "jdoe@example.com"}, "fullName": "John Doe",
+		},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{"username_from": "email"})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 1)
+	assert.Equal(t, "John Doe", byEmail["jdoe@example.com"].DisplayName)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "asmith", Email: "asmith@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "asmith@example.com", user.ID)
+
+	client = newTestClient(t, fake, map[string]interface{}{})
+	user, err = client.CreateUser(context.Background(), &structs.User{UserName: "asmith", Email: "asmith@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "asmith", user.ID)
+	_, err = client.CreateUser(context.Background(), &structs.User{Email: "asmith@example.com"})
+	assert.ErrorContains(t, err, "no openshift username")
+	assert.NoError(t, client.DeleteUser(context.Background(), "asmith"))
+}
+
+func TestGroups(t *testing.T) {
+	fake := &fakeAPIServer{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "dataeng", Name: "dataeng", Description: "team for dataeng"}, team)
+	labels := fake.group("dataeng")["metadata"].(map[string]any)["labels"]
+	assert.Equal(t, map[string]any{"app.kubernetes.io/managed-by": "usernaut"}, labels)
+
+	// the existing group of the same name is returned
+	team, err = client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, "dataeng", team.ID)
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, teams, 1)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"jdoe", "asmith"}))
+	// a conflicting update is retried on the latest version of the group
+	fake.conflicts = 1
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"asmith", "bob"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"jdoe", "gone"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "bob")
+
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"asmith", "bob"}))
+	assert.Equal(t, []any{}, fake.group("dataeng")["users"])
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.Nil(t, fake.group("dataeng"))
+}
+
+func TestReconcileRoleBindings(t *testing.T) {
+	fake := &fakeAPIServer{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+	_, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng"})
+	require.NoError(t, err)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "role_bindings", Value: []string{"analytics/edit", "airflow/view", "cluster-reader"},
+	}))
+	binding := fake.objects["/apis/rbac.authorization.k8s.io/v1/namespaces/analytics/rolebindings/usernaut-dataeng-edit"]
+	require.NotNil(t, binding)
+	assert.Equal(t, []any{map[string]any{"kind": "Group", "apiGroup": "rbac.authorization.k8s.io", "name": "dataeng"}},
+		binding["subjects"])
+	assert.Equal(t, map[string]any{"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "edit"},
+		binding["roleRef"])
+	assert.Contains(t, fake.objects,
+		"/apis/rbac.authorization.k8s.io/v1/clusterrolebindings/usernaut-dataeng-cluster-reader")
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "role_bindings", Value: []string{"analytics/edit"},
+	}))
+	assert.Contains(t, fake.objects,
+		"/apis/rbac.authorization.k8s.io/v1/namespaces/analytics/rolebindings/usernaut-dataeng-edit")
+	assert.NotContains(t, fake.objects,
+		"/apis/rbac.authorization.k8s.io/v1/namespaces/airflow/rolebindings/usernaut-dataeng-view")
+	assert.NotContains(t, fake.objects,
+		"/apis/rbac.authorization.k8s.io/v1/clusterrolebindings/usernaut-dataeng-cluster-reader")
+
+	// the role bindings of the group are deleted with it
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "dataeng"))
+	assert.Empty(t, fake.objects)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "namespaces", Value: []string{"x"},
+	}), "unsupported openshift group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeAPIServer{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamRoleBindings is the group param property listing the cluster roles bound to the group
+const groupParamRoleBindings = "role_bindings"
+
+// roleBinding is a role binding of a group created by usernaut, a RoleBinding in its namespace or
+// a ClusterRoleBinding without namespace
+type roleBinding struct {
+	Name        string
+	Namespace   string
+	ClusterRole string
+}
+
+// key is the value of the role_bindings group param of the role binding
+func (b roleBinding) key() string {
+	if b.Namespace == "" {
+		return b.ClusterRole
+	}
+	return b.Namespace + "/" + b.ClusterRole
+}
+
+// ReconcileGroupParams binds exactly the cluster roles of the role_bindings group param to the
+// group, with a RoleBinding in the namespace for the values <namespace>/<cluster role> and a
+// ClusterRoleBinding for the values <cluster role>. The other role bindings of the group created
+// by usernaut are deleted.
+func (oC *OpenShiftClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamRoleBindings {
+		return fmt.Errorf("unsupported openshift group param: %s", groupParams.Property)
+	}
+	return oC.reconcileRoleBindings(ctx, teamID, groupParams.Value)
+}
+
+// reconcileRoleBindings creates the role bindings of the group missing from the desired ones and
+// deletes the role bindings of the group created by usernaut which are not desired
+func (oC *OpenShiftClient) reconcileRoleBindings(ctx context.Context, teamID string, desired []string) error {
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "openshift")
+
+	existing, err := oC.fetchRoleBindings(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	var existingKeys []string
+	for _, binding := range existing {
+		existingKeys = append(existingKeys, binding.key())
+		if slices.Contains(desired, binding.key()) {
+			continue
+		}
+		log.WithField("roleBinding", binding.key()).Info("Delete openshift group role binding")
+		if err := oC.deleteRoleBinding(ctx, binding); err != nil {
+			return fmt.Errorf("failed to delete role binding %s of openshift group %s: %w", binding.key(), teamID, err)
+		}
+	}
+
+	for _, value := range desired {
+		if slices.Contains(existingKeys, value) {
+			continue
+		}
+		binding := roleBinding{ClusterRole: value}
+		if namespace, clusterRole, found := strings.Cut(value, "/"); found {
+			binding = roleBinding{Namespace: namespace, ClusterRole: clusterRole}
+		}
+		binding.Name = fmt.Sprintf("usernaut-%s-%s", teamID, binding.ClusterRole)
+
+		log.WithField("roleBinding", value).Info("Create openshift group role binding")
+		if err := oC.createRoleBinding(ctx, teamID, binding); err != nil {
+			return fmt.Errorf("failed to bind %s to openshift group %s: %w", value, teamID, err)
+		}
+	}
+	return nil
+}
+
+// fetchRoleBindings lists the role bindings of the group created by usernaut, in all the namespaces
+func (oC *OpenShiftClient) fetchRoleBindings(ctx context.Context, teamID string) ([]roleBinding, error) {
+	options := metav1.ListOptions{LabelSelector: managedByLabel + "=" + managedByValue}
+
+	var bindings []roleBinding
+	roleBindings, err := oC.client.Resource(roleBindingsGVR).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range roleBindings.Items {
+		var binding rbacv1.RoleBinding
+		if err := fromUnstructured(&item, &binding); err != nil {
+			return nil, err
+		}
+		if binding.Annotations[groupAnnotation] == teamID {
+			bindings = append(bindings, roleBinding{Name: binding.Name, Namespace: binding.Namespace,
+				ClusterRole: binding.RoleRef.Name})
+		}
+	}
+
+	clusterRoleBindings, err := oC.client.Resource(clusterRoleBindingsGVR).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range clusterRoleBindings.Items {
+		var binding rbacv1.ClusterRoleBinding
+		if err := fromUnstructured(&item, &binding); err != nil {
+			return nil, err
+		}
+		if binding.Annotations[groupAnnotation] == teamID {
+			bindings = append(bindings, roleBinding{Name: binding.Name, ClusterRole: binding.RoleRef.Name})
+		}
+	}
+	return bindings, nil
+}
+
+// createRoleBinding binds the cluster role to the group, a role binding of the same name which
+// already exists is left as is
+func (oC *OpenShiftClient) createRoleBinding(ctx context.Context, teamID string, binding roleBinding) error {
+	meta := metav1.ObjectMeta{
+		Name:        binding.Name,
+		Namespace:   binding.Namespace,
+		Labels:      map[string]string{managedByLabel: managedByValue},
+		Annotations: map[string]string{groupAnnotation: teamID},
+	}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: teamID}}
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: binding.ClusterRole}
+
+	var obj any = &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+		ObjectMeta: meta,
+		Subjects:   subjects,
+		RoleRef:    roleRef,
+	}
+	if binding.Namespace != "" {
+		obj = &rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+			ObjectMeta: meta,
+			Subjects:   subjects,
+			RoleRef:    roleRef,
+		}
+	}
+	content, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	if binding.Namespace != "" {
+		_, err = oC.client.Resource(roleBindingsGVR).Namespace(binding.Namespace).Create(ctx, content, metav1.CreateOptions{})
+	} else {
+		_, err = oC.client.Resource(clusterRoleBindingsGVR).Create(ctx, content, metav1.CreateOptions{})
+	}
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// deleteRoleBinding deletes the role binding, a role binding which does not exist is considered
+// deleted
+func (oC *OpenShiftClient) deleteRoleBinding(ctx context.Context, binding roleBinding) error {
+	var err error
+	if binding.Namespace != "" {
+		err = oC.client.Resource(roleBindingsGVR).Namespace(binding.Namespace).Delete(ctx, binding.Name,
+			metav1.DeleteOptions{})
+	} else {
+		err = oC.client.Resource(clusterRoleBindingsGVR).Delete(ctx, binding.Name, metav1.DeleteOptions{})
+	}
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ot "github.com/opentracing/opentracing-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the users of the Group object by name, keyed by username
+func (oC *OpenShiftClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	group, err := oC.fetchGroup(ctx, teamID)
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch openshift group")
+		return nil, err
+	}
+	members := make(map[string]*structs.User, len(group.Users))
+	for _, name := range group.Users {
+		members[name] = oC.userDetails(&User{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the usernames to the users of the Group object
+func (oC *OpenShiftClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.AddUserToTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "openshift").
+		WithField("users", userIDs).Info("Add users to openshift group")
+	return oC.updateUsers(ctx, teamID, func(users []string) []string {
+		for _, userID := range userIDs {
+			if !slices.Contains(users, userID) {
+				users = append(users, userID)
+			}
+		}
+		return users
+	})
+}
+
+// RemoveUserFromTeam writes the Group object back without the usernames in a single update, the
+// usernames it does not list are left as they are
+func (oC *OpenShiftClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.RemoveUserFromTeam")
+	defer span.Finish()
+
+	logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "openshift").
+		WithField("users", userIDs).Info("Remove users from openshift group")
+	return oC.updateUsers(ctx, teamID, func(users []string) []string {
+		return slices.DeleteFunc(users, func(name string) bool {
+			return slices.Contains(userIDs, name)
+		})
+	})
+}
+
+// updateUsers replaces the users of the Group object with the users returned by update. The group
+// is read again and updated when another writer changed it in between.
+func (oC *OpenShiftClient) updateUsers(ctx context.Context, teamID string, update func(users []string) []string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		group, err := oC.fetchGroup(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		users := update(slices.Clone(group.Users))
+		if slices.Equal(users, group.Users) {
+			return nil
+		}
+		group.Users = users
+		if group.Users == nil {
+			group.Users = []string{}
+		}
+
+		obj, err := toUnstructured(group)
+		if err != nil {
+			return err
+		}
+		_, err = oC.client.Resource(groupsGVR).Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the users of openshift group %s: %w", teamID, err)
+	}
+	return nil
+}
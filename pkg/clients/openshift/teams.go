@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	"context"
+
+	ot "github.com/opentracing/opentracing-go"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllTeams lists the Group objects of the cluster, keyed by name
+func (oC *OpenShiftClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.FetchAllTeams")
+	defer span.Finish()
+
+	list, err := oC.client.Resource(groupsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch openshift groups")
+		return nil, err
+	}
+	teams := make(map[string]structs.Team, len(list.Items))
+	for _, item := range list.Items {
+		var group Group
+		if err := fromUnstructured(&item, &group); err != nil {
+			return nil, err
+		}
+		teams[group.Name] = teamDetails(&group)
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the Group object by name
+func (oC *OpenShiftClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.FetchTeamDetails")
+	defer span.Finish()
+
+	group, err := oC.fetchGroup(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	team := teamDetails(group)
+	return &team, nil
+}
+
+// CreateTeam creates the Group object of the team, labeled as managed by usernaut. The names of
+// the Group objects are their IDs, so an existing group of the same name is returned as is.
+func (oC *OpenShiftClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "openshift")
+	log.Info("Create openshift group")
+
+	group := &Group{
+		TypeMeta: metav1.TypeMeta{APIVersion: "user.openshift.io/v1", Kind: "Group"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   team.Name,
+			Labels: map[string]string{managedByLabel: managedByValue},
+		},
+		Users: []string{},
+	}
+	if team.Description != "" {
+		group.Annotations = map[string]string{descriptionAnnotation: team.Description}
+	}
+	obj, err := toUnstructured(group)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := oC.client.Resource(groupsGVR).Create(ctx, obj, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		log.Warn("openshift group already exists, using it")
+		return oC.FetchTeamDetails(ctx, team.Name)
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to create openshift group")
+		return nil, err
+	}
+	if err := fromUnstructured(created, group); err != nil {
+		return nil, err
+	}
+	details := teamDetails(group)
+	return &details, nil
+}
+
+// DeleteTeamByID deletes the Group object by name and the role bindings of the group created by
+// usernaut. A group which does not exist is considered deleted.
+func (oC *OpenShiftClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "openshift")
+
+	if err := oC.reconcileRoleBindings(ctx, teamID, nil); err != nil {
+		return err
+	}
+
+	log.Info("Delete openshift group")
+	err := oC.client.Resource(groupsGVR).Delete(ctx, teamID, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		log.Warn("openshift group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// fetchGroup fetches the Group object by name
+func (oC *OpenShiftClient) fetchGroup(ctx context.Context, teamID string) (*Group, error) {
+	obj, err := oC.client.Resource(groupsGVR).Get(ctx, teamID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var group Group
+	if err := fromUnstructured(obj, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// teamDetails converts the Group object, the name is the ID of the group
+func teamDetails(g *Group) structs.Team {
+	return structs.Team{
+		ID:          g.Name,
+		Name:        g.Name,
+		Description: g.Annotations[descriptionAnnotation],
+	}
+}
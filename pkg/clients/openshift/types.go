@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// managedByLabel marks the objects created by usernaut
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "usernaut"
+	// groupAnnotation holds the group bound by a role binding created by usernaut, group names do
+	// not always fit in a label value
+	groupAnnotation = "usernaut.dev/group"
+	// descriptionAnnotation holds the description of a Group object
+	descriptionAnnotation = "openshift.io/description"
+
+	// usernameFromEmail uses the email of the users as their username, for the identity providers
+	// whose username claim is the email
+	usernameFromEmail = "email"
+)
+
+var (
+	groupsGVR              = schema.GroupVersionResource{Group: "user.openshift.io", Version: "v1", Resource: "groups"}
+	usersGVR               = schema.GroupVersionResource{Group: "user.openshift.io", Version: "v1", Resource: "users"}
+	roleBindingsGVR        = rbacv1.SchemeGroupVersion.WithResource("rolebindings")
+	clusterRoleBindingsGVR = rbacv1.SchemeGroupVersion.WithResource("clusterrolebindings")
+)
+
+// OpenShiftConfig is the connection of an OpenShift backend, read from the backend configuration
+type OpenShiftConfig struct {
+	// Kubeconfig is the content of the kubeconfig of a remote cluster, the cluster of the operator
+	// is used when empty
+	Kubeconfig string `json:"kubeconfig"`
+	// Context is the context of the kubeconfig, its current context by default
+	Context string `json:"context"`
+	// UsernameFrom is the attribute of the users used as their OpenShift username, username by
+	// default or email
+	UsernameFrom string `json:"username_from"`
+}
+
+// Group is a user.openshift.io/v1 Group, the users are referenced by their username
+type Group struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Users             []string `json:"users"`
+}
+
+// User is a user.openshift.io/v1 User, created on the first sign-in of the user
+type User struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	FullName          string `json:"fullName,omitempty"`
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	ot "github.com/opentracing/opentracing-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers lists the OpenShift users who signed in to the cluster, keyed by username, and by
+// email when the usernames are the emails
+func (oC *OpenShiftClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.FetchAllUsers")
+	defer span.Finish()
+
+	list, err := oC.client.Resource(usersGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch openshift users")
+		return nil, nil, err
+	}
+
+	usersByID := make(map[string]*structs.User, len(list.Items))
+	usersByEmail := make(map[string]*structs.User)
+	for _, item := range list.Items {
+		var user User
+		if err := fromUnstructured(&item, &user); err != nil {
+			return nil, nil, err
+		}
+		details := oC.userDetails(&user)
+		usersByID[details.ID] = details
+		if details.Email != "" {
+			usersByEmail[details.Email] = details
+		}
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// FetchUserDetails fetches the OpenShift user by username
+func (oC *OpenShiftClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.openshift.FetchUserDetails")
+	defer span.Finish()
+
+	obj, err := oC.client.Resource(usersGVR).Get(ctx, userID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var user User
+	if err := fromUnstructured(obj, &user); err != nil {
+		return nil, err
+	}
+	return oC.userDetails(&user), nil
+}
+
+// CreateUser returns the OpenShift username of the user, its username or its email. The User
+// objects are created by OpenShift on the first sign-in, and the groups reference their users by
+// username whether they signed in yet or not.
+func (oC *OpenShiftClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	name := u.UserName
+	if oC.usernameFromEmail {
+		name = u.Email
+	}
+	if name == "" {
+		return nil, fmt.Errorf("user %s %s has no openshift username", u.UserName, u.Email)
+	}
+	return &structs.User{
+		ID:          name,
+		UserName:    name,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+	}, nil
+}
+
+// DeleteUser is a no-op, the User objects are managed by OpenShift and its identity providers. The
+// offboarded users are removed from the groups by the reconciles of their groups.
+func (oC *OpenShiftClient) DeleteUser(ctx context.Context, userID string) error {
+	logger.Logger(ctx).WithField("userID", userID).WithField("service", "openshift").
+		Info("openshift users are managed by the identity providers, skipping user deletion")
+	return nil
+}
+
+// userDetails converts the OpenShift user, the username is the ID of the user
+func (oC *OpenShiftClient) userDetails(u *User) *structs.User {
+	user := &structs.User{
+		ID:          u.Name,
+		UserName:    u.Name,
+		DisplayName: u.FullName,
+	}
+	if oC.usernameFromEmail {
+		user.Email = u.Name
+	}
+	return user
+}
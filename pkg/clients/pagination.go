@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/airflow"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/atlassian"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/bitbucket"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/databricks"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/dbtcloud"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/entraid"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fivetran"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/github"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/gitlab"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/keycloak"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/okta"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/slack"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/snowflake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// ErrStopPaging is returned by a page callback to stop the pagination early without an error
+var ErrStopPaging = errors.New("stop paging")
+
+// PagedClient is implemented by backends which stream their users and teams page by page, so that
+// the callers walking all of them (e.g. the cache preload) never hold more than a page in memory.
+// The pages are passed to the callback in the order of the backend API, and the pagination stops at
+// the first error of the callback, which is returned as is.
+type PagedClient interface {
+	// Calls fn with each page of the users onboarded over the platform
+	ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error
+	// Calls fn with each page of the teams on the backend
+	ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error
+}
+
+var (
+	_ PagedClient = (*snowflake.SnowflakeClient)(nil)
+	_ PagedClient = (*gitlab.GitlabClient)(nil)
+	_ PagedClient = (*fivetran.FivetranClient)(nil)
+	_ PagedClient = (*github.GithubClient)(nil)
+	_ PagedClient = (*keycloak.KeycloakClient)(nil)
+	_ PagedClient = (*okta.OktaClient)(nil)
+	_ PagedClient = (*entraid.EntraIDClient)(nil)
+	_ PagedClient = (*slack.SlackClient)(nil)
+	_ PagedClient = (*atlassian.AtlassianClient)(nil)
+	_ PagedClient = (*databricks.DatabricksClient)(nil)
+	_ PagedClient = (*dbtcloud.DbtCloudClient)(nil)
+	_ PagedClient = (*bitbucket.BitbucketClient)(nil)
+	_ PagedClient = (*airflow.AirflowClient)(nil)
+)
+
+// ForEachUserPage calls fn with each page of the users of the backend. The backends which are not a
+// PagedClient are fetched at once and passed as a single page. Returning ErrStopPaging from fn
+// stops the pagination and returns nil.
+func ForEachUserPage(ctx context.Context, backendClient Client, fn func(users []*structs.User) error) error {
+	pagedClient, ok := As[PagedClient](backendClient)
+	if !ok {
+		usersByID, _, err := backendClient.FetchAllUsers(ctx)
+		if err != nil {
+			return err
+		}
+		users := make([]*structs.User, 0, len(usersByID))
+		for _, user := range usersByID {
+			users = append(users, user)
+		}
+		return stopPaging(fn(users))
+	}
+	return stopPaging(pagedClient.ForEachUserPage(ctx, fn))
+}
+
+// ForEachTeamPage calls fn with each page of the teams of the backend, like ForEachUserPage
+func ForEachTeamPage(ctx context.Context, backendClient Client, fn func(teams []structs.Team) error) error {
+	pagedClient, ok := As[PagedClient](backendClient)
+	if !ok {
+		teamsByName, err := backendClient.FetchAllTeams(ctx)
+		if err != nil {
+			return err
+		}
+		teams := make([]structs.Team, 0, len(teamsByName))
+		for _, team := range teamsByName {
+			teams = append(teams, team)
+		}
+		return stopPaging(fn(teams))
+	}
+	return stopPaging(pagedClient.ForEachTeamPage(ctx, fn))
+}
+
+// stopPaging turns the early stop of a page callback into a success
+func stopPaging(err error) error {
+	if errors.Is(err, ErrStopPaging) {
+		return nil
+	}
+	return err
+}
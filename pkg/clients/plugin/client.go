@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+var (
+	connsMu sync.Mutex
+	// conns are shared by all the clients of a plugin, a connection is kept open per endpoint
+	conns = make(map[PluginConfig]*grpc.ClientConn)
+)
+
+// PluginClient is the client of a backend served by an external plugin process over gRPC
+type PluginClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewClient returns the client of the plugin at the endpoint of the backend connection
+func NewClient(pluginAppConfig map[string]interface{}) (*PluginClient, error) {
+	pluginConfig := PluginConfig{}
+	if err := utils.MapToStruct(pluginAppConfig, &pluginConfig); err != nil {
+		return nil, err
+	}
+	if pluginConfig.Endpoint == "" {
+		return nil, errors.New("missing required connection parameters for plugin backend")
+	}
+
+	conn, err := sharedConn(pluginConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &PluginClient{conn: conn}, nil
+}
+
+// sharedConn returns the connection to the plugin, creating it on first use. The connection
+// is established lazily and reconnects by itself when the plugin restarts.
+func sharedConn(pluginConfig PluginConfig) (*grpc.ClientConn, error) {
+	connsMu.Lock()
+	defer connsMu.Unlock()
+
+	if conn, ok := conns[pluginConfig]; ok {
+		return conn, nil
+	}
+	transportCredentials := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if pluginConfig.Insecure {
+		transportCredentials = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(pluginConfig.Endpoint,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the connection to plugin %s: %w", pluginConfig.Endpoint, err)
+	}
+	conns[pluginConfig] = conn
+	return conn, nil
+}
+
+// invoke calls the method of the plugin service
+func (pc *PluginClient) invoke(ctx context.Context, method string, req, resp any) error {
+	if err := pc.conn.Invoke(ctx, "/"+ServiceName+"/"+method, req, resp); err != nil {
+		return fmt.Errorf("plugin %s call failed: %w", method, err)
+	}
+	return nil
+}
+
+// HealthCheck calls the health check of the plugin, which checks its backend
+func (pc *PluginClient) HealthCheck(ctx context.Context) error {
+	return pc.invoke(ctx, "HealthCheck", &Empty{}, &Empty{})
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// fakeBackend is an in-memory backend served by the test plugin
+type fakeBackend struct {
+	users   map[string]*structs.User
+	teams   map[string]structs.Team
+	members map[string][]string
+	params  map[string]structs.TeamParams
+	healthy bool
+}
+
+func (b *fakeBackend) FetchAllUsers(context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	byEmail := make(map[string]*structs.User, len(b.users))
+	for _, user := range b.users {
+		byEmail[user.Email] = user
+	}
+	return b.users, byEmail, nil
+}
+
+func (b *fakeBackend) FetchUserDetails(_ context.Context, userID string) (*structs.User, error) {
+	user, ok := b.users[userID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (b *fakeBackend) CreateUser(_ context.Context, u *structs.User) (*structs.User, error) {
+	if _, exists := b.users["id-"+u.UserName]; exists {
+		return nil, fmt.Errorf("user %s: %w", u.UserName, structs.ErrUserAlreadyExists)
+	}
+	created := *u
+	created.ID = "id-" + u.UserName
+	b.users[created.ID] = &created
+	return &created, nil
+}
+
+func (b *fakeBackend) DeleteUser(_ context.Context, userID string) error {
+	delete(b.users, userID)
+	return nil
+}
+
+func (b *fakeBackend) FetchAllTeams(context.Context) (map[string]structs.Team, error) {
+	return b.teams, nil
+}
+
+func (b *fakeBackend) FetchTeamDetails(_ context.Context, teamID string) (*structs.Team, error) {
+	for _, team := range b.teams {
+		if team.ID == teamID {
+			return &team, nil
+		}
+	}
+	return nil, errors.New("team not found")
+}
+
+func (b *fakeBackend) CreateTeam(_ context.Context, team *structs.Team) (*structs.Team, error) {
+	created := *team
+	created.ID = "team-" + team.Name
+	b.teams[created.Name] = created
+	return &created, nil
+}
+
+func (b *fakeBackend) DeleteTeamByID(_ context.Context, teamID string) error {
+	for name, team := range b.teams {
+		if team.ID == teamID {
+			delete(b.teams, name)
+		}
+	}
+	return nil
+}
+
+func (b *fakeBackend) FetchTeamMembersByTeamID(_ context.Context, teamID string) (map[string]*structs.User, error) {
+	members := make(map[string]*structs.User)
+	for _, userID := range b.members[teamID] {
+		members[userID] = b.users[userID]
+	}
+	return members, nil
+}
+
+func (b *fakeBackend) ReconcileGroupParams(_ context.Context, teamID string, groupParams structs.TeamParams) error {
+	b.params[teamID] = groupParams
+	return nil
+}
+
+func (b *fakeBackend) AddUserToTeam(_ context.Context, teamID string, userIDs []string) error {
+	b.members[teamID] = append(b.members[teamID], userIDs...)
+	return nil
+}
+
+func (b *fakeBackend) RemoveUserFromTeam(_ context.Context, teamID string, userIDs []string) error {
+	remaining := make([]string, 0)
+	for _, member := range b.members[teamID] {
+		if !slices.Contains(userIDs, member) {
+			remaining = append(remaining, member)
+		}
+	}
+	b.members[teamID] = remaining
+	return nil
+}
+
+func (b *fakeBackend) HealthCheck(context.Context) error {
+	if !b.healthy {
+		return errors.New("backend unreachable")
+	}
+	return nil
+}
+
+// startPlugin serves the backend on a local port and returns a client of the plugin
+func startPlugin(t *testing.T, backend Backend) *PluginClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := NewServer(backend)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	client, err := NewClient(map[string]interface{}{
+		"endpoint": listener.Addr().String(),
+		"insecure": true,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{})
+	assert.Error(t, err, "the plugin endpoint is required")
+
+	first, err := NewClient(map[string]interface{}{"endpoint": "localhost:50051", "insecure": true})
+	require.NoError(t, err)
+	second, err := NewClient(map[string]interface{}{"endpoint": "localhost:50051", "insecure": true})
+	require.NoError(t, err)
+	assert.Same(t, first.conn, second.conn, "the clients of a plugin should share its connection")
+}
+
+func TestPluginClient(t *testing.T) {
+	backend := &fakeBackend{
+		users:   make(map[string]*structs.User),
+		teams:   make(map[string]structs.Team),
+		members: make(map[string][]string),
+		params:  make(map[string]structs.TeamParams),
+		healthy: true,
+	}
+	client := startPlugin(t, backend)
+	ctx := context.Background()
+
+	t.Run("Users", func(t *testing.T) {
+		user, err := client.CreateUser(ctx, &structs.User{UserName: "alice", Email: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "id-alice", user.ID)
+
+		_, err = client.CreateUser(ctx, &structs.User{UserName: "alice", Email: "alice@example.com"})
+		assert.ErrorIs(t, err, structs.ErrUserAlreadyExists, "the conflicts should be recoverable by the operator")
+
+		byID, byEmail, err := client.FetchAllUsers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", byID["id-alice"].UserName)
+		assert.Equal(t, "id-alice", byEmail["alice@example.com"].ID)
+
+		details, err := client.FetchUserDetails(ctx, "id-alice")
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", details.Email)
+
+		_, err = client.FetchUserDetails(ctx, "id-bob")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found", "the errors of the backend should reach the operator")
+	})
+
+	t.Run("Teams_And_Members", func(t *testing.T) {
+		team, err := client.CreateTeam(ctx, &structs.Team{Name: "data-team"})
+		require.NoError(t, err)
+		assert.Equal(t, "team-data-team", team.ID)
+
+		teams, err := client.FetchAllTeams(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, teams, "data-team")
+
+		require.NoError(t, client.AddUserToTeam(ctx, team.ID, []string{"id-alice", "id-carol"}))
+		require.NoError(t, client.RemoveUserFromTeam(ctx, team.ID, []string{"id-carol"}))
+		members, err := client.FetchTeamMembersByTeamID(ctx, team.ID)
+		require.NoError(t, err)
+		assert.Len(t, members, 1)
+		assert.Contains(t, members, "id-alice")
+
+		params := structs.TeamParams{Property: "access_level", Value: []string{"developer"}}
+		require.NoError(t, client.ReconcileGroupParams(ctx, team.ID, params))
+		assert.Equal(t, params, backend.params[team.ID])
+
+		require.NoError(t, client.DeleteTeamByID(ctx, team.ID))
+		require.NoError(t, client.DeleteUser(ctx, "id-alice"))
+		assert.Empty(t, backend.teams)
+		assert.Empty(t, backend.users)
+	})
+
+	t.Run("Health_Check", func(t *testing.T) {
+		assert.NoError(t, client.HealthCheck(ctx))
+		backend.healthy = false
+		assert.ErrorContains(t, client.HealthCheck(ctx), "backend unreachable")
+	})
+}
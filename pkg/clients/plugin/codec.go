@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import "encoding/json"
+
+// codecName is the content subtype of the plugin calls, sent as "application/grpc+json"
+const codecName = "json"
+
+// jsonCodec encodes the plugin messages as JSON, so that plugins are written without generated
+// protobuf code, in any language with a gRPC library supporting custom codecs
+type jsonCodec struct{}
+
+// Marshal implements encoding.Codec
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements encoding.Codec
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name implements encoding.Codec
+func (jsonCodec) Name() string {
+	return codecName
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// ServiceName is the gRPC service implemented by the backend plugins
+const ServiceName = "usernaut.plugin.v1.Backend"
+
+// Backend is the backend served by a plugin, it mirrors clients.Client so that any backend client
+// can be shipped as a plugin
+type Backend interface {
+	FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error)
+	FetchUserDetails(ctx context.Context, userID string) (*structs.User, error)
+	CreateUser(ctx context.Context, u *structs.User) (*structs.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+
+	FetchAllTeams(ctx context.Context) (map[string]structs.Team, error)
+	FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error)
+	CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error)
+	DeleteTeamByID(ctx context.Context, teamID string) error
+
+	FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error)
+	ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error
+	AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error
+	RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error
+
+	HealthCheck(ctx context.Context) error
+}
+
+// NewServer returns a gRPC server serving the backend as a plugin, to be started on the listener
+// of the plugin process with Serve
+func NewServer(backend Backend, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(jsonCodec{})}, opts...)...)
+	RegisterBackendServer(server, backend)
+	return server
+}
+
+// RegisterBackendServer registers the plugin service of the backend on the server, which must
+// use the JSON codec of the plugins
+func RegisterBackendServer(server grpc.ServiceRegistrar, backend Backend) {
+	server.RegisterService(&serviceDesc, backend)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Backend)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryMethod("FetchAllUsers", func(ctx context.Context, b Backend, _ *Empty) (*UsersResponse, error) {
+			byID, byEmail, err := b.FetchAllUsers(ctx)
+			return &UsersResponse{ByID: byID, ByEmail: byEmail}, err
+		}),
+		unaryMethod("FetchUserDetails", func(ctx context.Context, b Backend, req *UserRequest) (*structs.User, error) {
+			return b.FetchUserDetails(ctx, req.UserID)
+		}),
+		unaryMethod("CreateUser", func(ctx context.Context, b Backend, req *structs.User) (*structs.User, error) {
+			// the conflicts are reported with their status code, so that the client recovers them
+			user, err := b.CreateUser(ctx, req)
+			if errors.Is(err, structs.ErrUserAlreadyExists) {
+				return nil, status.Error(codes.AlreadyExists, err.Error())
+			}
+			return user, err
+		}),
+		unaryMethod("DeleteUser", func(ctx context.Context, b Backend, req *UserRequest) (*Empty, error) {
+			return &Empty{}, b.DeleteUser(ctx, req.UserID)
+		}),
+		unaryMethod("FetchAllTeams", func(ctx context.Context, b Backend, _ *Empty) (*TeamsResponse, error) {
+			teams, err := b.FetchAllTeams(ctx)
+			return &TeamsResponse{Teams: teams}, err
+		}),
+		unaryMethod("FetchTeamDetails", func(ctx context.Context, b Backend, req *TeamRequest) (*structs.Team, error) {
+			return b.FetchTeamDetails(ctx, req.TeamID)
+		}),
+		unaryMethod("CreateTeam", func(ctx context.Context, b Backend, req *structs.Team) (*structs.Team, error) {
+			return b.CreateTeam(ctx, req)
+		}),
+		unaryMethod("DeleteTeamByID", func(ctx context.Context, b Backend, req *TeamRequest) (*Empty, error) {
+			return &Empty{}, b.DeleteTeamByID(ctx, req.TeamID)
+		}),
+		unaryMethod("FetchTeamMembersByTeamID",
+			func(ctx context.Context, b Backend, req *TeamRequest) (*MembersResponse, error) {
+				members, err := b.FetchTeamMembersByTeamID(ctx, req.TeamID)
+				return &MembersResponse{Members: members}, err
+			}),
+		unaryMethod("ReconcileGroupParams", func(ctx context.Context, b Backend, req *GroupParamsRequest) (*Empty, error) {
+			return &Empty{}, b.ReconcileGroupParams(ctx, req.TeamID, req.Params)
+		}),
+		unaryMethod("AddUserToTeam", func(ctx context.Context, b Backend, req *MembershipRequest) (*Empty, error) {
+			return &Empty{}, b.AddUserToTeam(ctx, req.TeamID, req.UserIDs)
+		}),
+		unaryMethod("RemoveUserFromTeam", func(ctx context.Context, b Backend, req *MembershipRequest) (*Empty, error) {
+			return &Empty{}, b.RemoveUserFromTeam(ctx, req.TeamID, req.UserIDs)
+		}),
+		unaryMethod("HealthCheck", func(ctx context.Context, b Backend, _ *Empty) (*Empty, error) {
+			return &Empty{}, b.HealthCheck(ctx)
+		}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "usernaut/plugin/v1",
+}
+
+// unaryMethod describes a unary method of the plugin service calling the backend
+func unaryMethod[Req, Resp any](name string,
+	call func(ctx context.Context, backend Backend, req *Req) (*Resp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, dec func(any) error,
+			interceptor grpc.UnaryServerInterceptor) (any, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return call(ctx, srv.(Backend), req.(*Req))
+			}
+			if interceptor == nil {
+				return handler(ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/" + name}
+			return interceptor(ctx, req, info, handler)
+		},
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// FetchTeamMembersByTeamID returns the members of the team keyed by user ID
+func (pc *PluginClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	resp := &MembersResponse{}
+	if err := pc.invoke(ctx, "FetchTeamMembersByTeamID", &TeamRequest{TeamID: teamID}, resp); err != nil {
+		return nil, err
+	}
+	if resp.Members == nil {
+		resp.Members = make(map[string]*structs.User)
+	}
+	return resp.Members, nil
+}
+
+// AddUserToTeam adds the batch of members to the team, in a single call to the plugin
+func (pc *PluginClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return pc.invoke(ctx, "AddUserToTeam", &MembershipRequest{TeamID: teamID, UserIDs: userIDs}, &Empty{})
+}
+
+// RemoveUserFromTeam removes the batch of members from the team, in a single call to the plugin
+func (pc *PluginClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return pc.invoke(ctx, "RemoveUserFromTeam", &MembershipRequest{TeamID: teamID, UserIDs: userIDs}, &Empty{})
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// FetchAllTeams returns the teams of the plugin backend keyed by name
+func (pc *PluginClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	resp := &TeamsResponse{}
+	if err := pc.invoke(ctx, "FetchAllTeams", &Empty{}, resp); err != nil {
+		return nil, err
+	}
+	if resp.Teams == nil {
+		resp.Teams = make(map[string]structs.Team)
+	}
+	return resp.Teams, nil
+}
+
+// FetchTeamDetails returns the team of the plugin backend with the ID
+func (pc *PluginClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	team := &structs.Team{}
+	if err := pc.invoke(ctx, "FetchTeamDetails", &TeamRequest{TeamID: teamID}, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// CreateTeam creates the team in the plugin backend
+func (pc *PluginClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	created := &structs.Team{}
+	if err := pc.invoke(ctx, "CreateTeam", team, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// DeleteTeamByID deletes the team from the plugin backend
+func (pc *PluginClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	return pc.invoke(ctx, "DeleteTeamByID", &TeamRequest{TeamID: teamID}, &Empty{})
+}
+
+// ReconcileGroupParams applies the group param to the team of the plugin backend
+func (pc *PluginClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	return pc.invoke(ctx, "ReconcileGroupParams", &GroupParamsRequest{TeamID: teamID, Params: groupParams}, &Empty{})
+}
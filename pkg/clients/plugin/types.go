@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import "github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+
+// PluginConfig is the connection of a plugin backend
+type PluginConfig struct {
+	// Endpoint is the gRPC target of the plugin, like "localhost:50051" or "unix:///plugins/scim.sock"
+	Endpoint string `json:"endpoint"`
+	// Insecure disables the TLS of the connection, for plugins running as sidecars
+	Insecure bool `json:"insecure"`
+}
+
+// Empty is the message of the calls without parameters or results
+type Empty struct{}
+
+// UsersResponse holds the users of the backend keyed by ID and by email
+type UsersResponse struct {
+	ByID    map[string]*structs.User `json:"by_id"`
+	ByEmail map[string]*structs.User `json:"by_email"`
+}
+
+// UserRequest identifies a user of the backend
+type UserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// TeamsResponse holds the teams of the backend keyed by name
+type TeamsResponse struct {
+	Teams map[string]structs.Team `json:"teams"`
+}
+
+// TeamRequest identifies a team of the backend
+type TeamRequest struct {
+	TeamID string `json:"team_id"`
+}
+
+// MembersResponse holds the members of a team keyed by user ID
+type MembersResponse struct {
+	Members map[string]*structs.User `json:"members"`
+}
+
+// GroupParamsRequest holds a group param of a team
+type GroupParamsRequest struct {
+	TeamID string             `json:"team_id"`
+	Params structs.TeamParams `json:"params"`
+}
+
+// MembershipRequest holds a batch of members of a team
+type MembershipRequest struct {
+	TeamID  string   `json:"team_id"`
+	UserIDs []string `json:"user_ids"`
+}
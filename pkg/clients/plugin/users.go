@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// FetchAllUsers returns the users of the plugin backend keyed by ID and by email
+func (pc *PluginClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	resp := &UsersResponse{}
+	if err := pc.invoke(ctx, "FetchAllUsers", &Empty{}, resp); err != nil {
+		return nil, nil, err
+	}
+	if resp.ByID == nil {
+		resp.ByID = make(map[string]*structs.User)
+	}
+	if resp.ByEmail == nil {
+		resp.ByEmail = make(map[string]*structs.User)
+	}
+	return resp.ByID, resp.ByEmail, nil
+}
+
+// FetchUserDetails returns the user of the plugin backend with the ID
+func (pc *PluginClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	user := &structs.User{}
+	if err := pc.invoke(ctx, "FetchUserDetails", &UserRequest{UserID: userID}, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CreateUser creates the user in the plugin backend
+func (pc *PluginClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	user := &structs.User{}
+	if err := pc.invoke(ctx, "CreateUser", u, user); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser deletes the user from the plugin backend
+func (pc *PluginClient) DeleteUser(ctx context.Context, userID string) error {
+	return pc.invoke(ctx, "DeleteUser", &UserRequest{UserID: userID}, &Empty{})
+}
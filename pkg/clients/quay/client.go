@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// QuayClient manages the teams of a Quay organization, their members and their robot accounts
+// through the Quay API
+type QuayClient struct {
+	client           heimdall.Doer
+	url              string
+	org              string
+	headers          map[string]string
+	removeOrgMembers bool
+}
+
+func NewClient(quayAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*QuayClient, error) {
+
+	quayConfig := QuayConfig{}
+	if err := utils.MapToStruct(quayAppConfig, &quayConfig); err != nil {
+		return nil, err
+	}
+	if quayConfig.Organization == "" || quayConfig.Token == "" {
+		return nil, errors.New("quay configuration is missing required fields: organization or token")
+	}
+	baseURL := strings.TrimSuffix(quayConfig.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	client, err := httpclient.InitializeClient(
+		"quay_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &QuayClient{
+		client: client,
+		url:    baseURL + "/api/v1",
+		org:    quayConfig.Organization,
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/json",
+			"Accept":                       "application/json",
+			"Authorization":                "Bearer " + quayConfig.Token,
+		},
+		removeOrgMembers: quayConfig.RemoveOrgMembers,
+	}, nil
+}
+
+// HealthCheck fetches the organization, the Quay API is healthy when the token can read it
+func (qC *QuayClient) HealthCheck(ctx context.Context) error {
+	if _, err := qC.sendRequest(ctx, qC.orgPath(""), http.MethodGet, nil, "backend.quay.HealthCheck"); err != nil {
+		return fmt.Errorf("quay health check failed: %w", err)
+	}
+	return nil
+}
+
+// orgPath returns the path of the resource of the organization
+func (qC *QuayClient) orgPath(resource string) string {
+	return "/organization/" + url.PathEscape(qC.org) + resource
+}
+
+// get sends a GET request to the path and decodes its response
+func (qC *QuayClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := qC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a Quay request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode quay response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the Quay API and returns the response body, any
+// response code other than 2xx is returned as a *request.ResponseError
+func (qC *QuayClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, qC.client, method, qC.url+path, body, qC.headers, methodName,
+		"quay", errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the message of an error response of the Quay API
+func errorMessage(body []byte) (string, string) {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	for _, message := range []string{errResp.ErrorMessage, errResp.Detail, errResp.Message} {
+		if message != "" {
+			return "", message
+		}
+	}
+	return "", ""
+}
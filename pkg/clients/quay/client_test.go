@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeQuay is a Quay organization holding its users, teams and team members in memory
+type fakeQuay struct {
+	users   []string
+	teams   map[string]Team
+	members map[string][]Member
+	removed []string
+}
+
+func (f *fakeQuay) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"detail": "Unauthorized", "error_message": "Invalid token"}`))
+		return
+	}
+
+	resource := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	parts := strings.Split(resource, "/")
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(resource, "/users/"):
+		if !slices.Contains(f.users, parts[2]) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail": "Not Found", "error_message": "Not Found"}`))
+			return
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, User{Username: parts[2]})
+	case r.Method == http.MethodGet && resource == "/organization/myorg":
+		fakehttp.WriteJSON(w, http.StatusOK, Organization{Name: "myorg", Teams: f.teams})
+	case r.Method == http.MethodGet && resource == "/organization/myorg/members":
+		members := []Member{{Name: "myorg+ci", Kind: "robot", IsRobot: true}}
+		for _, user := range f.users {
+			members = append(members, Member{Name: user, Kind: "user"})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, membersResponse{Members: members})
+	case r.Method == http.MethodDelete && strings.HasPrefix(resource, "/organization/myorg/members/"):
+		f.removed = append(f.removed, parts[4])
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && len(parts) == 6 && parts[5] == "members":
+		if r.URL.Query().Get("includePending") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, membersResponse{Members: f.members[parts[4]]})
+	case len(parts) == 7 && parts[5] == "members":
+		team, name := parts[4], parts[6]
+		index := slices.IndexFunc(f.members[team], func(m Member) bool { return m.Name == name })
+		if r.Method == http.MethodPut {
+			if index < 0 {
+				member := Member{Name: name, Kind: "user"}
+				if strings.Contains(name, "+") {
+					member = Member{Name: name, Kind: "robot", IsRobot: true}
+				}
+				f.members[team] = append(f.members[team], member)
+			}
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		if index < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.members[team] = slices.Delete(f.members[team], index, index+1)
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 5 && parts[3] == "team":
+		if r.Method == http.MethodPut {
+			var team Team
+			_ = json.NewDecoder(r.Body).Decode(&team)
+			f.teams[parts[4]] = team
+			fakehttp.WriteJSON(w, http.StatusOK, team)
+			return
+		}
+		if _, ok := f.teams[parts[4]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.teams, parts[4])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeQuay, connection map[string]interface{}) *QuayClient {
+	t.Helper()
+	if fake.teams == nil {
+		fake.teams = map[string]Team{}
+	}
+	if fake.members == nil {
+		fake.members = map[string][]Member{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["base_url"] = server.URL + "/"
+	connection["organization"] = "myorg"
+	connection["token"] = "token"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"organization": "myorg"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "organization or token")
+}
+
+func TestUsers(t *testing.T) {
+	fake := &fakeQuay{users: []string{"jdoe", "asmith"}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+	assert.NotContains(t, byID, "myorg+ci")
+	assert.Empty(t, byEmail)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "jdoe", UserName: "jdoe", Email: "jdoe@example.com"}, user)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "new"})
+	assert.ErrorContains(t, err, "quay user new not found")
+
+	// the members are only removed from the organization when the backend removes them
+	require.NoError(t, client.DeleteUser(context.Background(), "jdoe"))
+	assert.Empty(t, fake.removed)
+	client = newTestClient(t, fake, map[string]interface{}{"remove_org_members": true})
+	require.NoError(t, client.DeleteUser(context.Background(), "jdoe"))
+	assert.Equal(t, []string{"jdoe"}, fake.removed)
+}
+
+func TestTeams(t *testing.T) {
+	fake := &fakeQuay{teams: map[string]Team{"owners": {Name: "owners", Role: "admin"}}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "dataeng", Description: "team for dataeng"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "dataeng", Name: "dataeng", Description: "team for dataeng", Role: "member"}, team)
+
+	_, err = client.CreateTeam(context.Background(), &structs.Team{Name: "builders", Role: "creator"})
+	require.NoError(t, err)
+	assert.Equal(t, "creator", fake.teams["builders"].Role)
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, teams, 3)
+	assert.Equal(t, "admin", teams["owners"].Role)
+
+	details, err := client.FetchTeamDetails(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Equal(t, "dataeng", details.ID)
+	_, err = client.FetchTeamDetails(context.Background(), "missing")
+	assert.True(t, request.IsNotFound(err))
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "builders"))
+	assert.NotContains(t, fake.teams, "builders")
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "builders"))
+}
+
+func TestMembershipSkipsRobots(t *testing.T) {
+	fake := &fakeQuay{members: map[string][]Member{"dataeng": {
+		{Name: "jdoe", Kind: "user"},
+		{Name: "myorg+ci", Kind: "robot", IsRobot: true},
+		{Name: "invited", Kind: "invite"},
+		{Kind: "invite"},
+	}}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "jdoe")
+	assert.Contains(t, members, "invited")
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "dataeng", []string{"asmith"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "dataeng", []string{"jdoe", "gone"}))
+	members, err = client.FetchTeamMembersByTeamID(context.Background(), "dataeng")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "asmith")
+}
+
+func TestReconcileRobotAccounts(t *testing.T) {
+	fake := &fakeQuay{members: map[string][]Member{"dataeng": {
+		{Name: "jdoe", Kind: "user"},
+		{Name: "myorg+ci", Kind: "robot", IsRobot: true},
+		{Name: "myorg+old", Kind: "robot", IsRobot: true},
+	}}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "robot_accounts", Value: []string{"ci", "myorg+deploy"},
+	}))
+	names := make([]string, 0, len(fake.members["dataeng"]))
+	for _, member := range fake.members["dataeng"] {
+		names = append(names, member.Name)
+	}
+	assert.Equal(t, []string{"jdoe", "myorg+ci", "myorg+deploy"}, names)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "dataeng", structs.TeamParams{
+		Property: "repositories", Value: []string{"x"},
+	}), "unsupported quay group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeQuay{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "Bearer wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "response code 401: Invalid token")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamRobotAccounts is the group param property listing the robot accounts of the
+// organization which are members of the team
+const groupParamRobotAccounts = "robot_accounts"
+
+// ReconcileGroupParams makes exactly the robot accounts of the robot_accounts group param members
+// of the team, so that they get the repository permissions of the team like its users. The robot
+// accounts are named by their short name or their full name, e.g. ci or myorg+ci.
+func (qC *QuayClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamRobotAccounts {
+		return fmt.Errorf("unsupported quay group param: %s", groupParams.Property)
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "quay")
+
+	desired := make([]string, 0, len(groupParams.Value))
+	for _, robot := range groupParams.Value {
+		desired = append(desired, qC.robotName(robot))
+	}
+
+	members, err := qC.fetchTeamMembers(ctx, teamID, "backend.quay.ReconcileGroupParams")
+	if err != nil {
+		return err
+	}
+	var robots []string
+	for _, member := range members {
+		if member.isRobot() {
+			robots = append(robots, member.Name)
+		}
+	}
+
+	for _, robot := range desired {
+		if slices.Contains(robots, robot) {
+			continue
+		}
+		log.WithField("robot", robot).Info("Add robot account to quay team")
+		if err := qC.addTeamMember(ctx, teamID, robot, "backend.quay.ReconcileGroupParams"); err != nil {
+			return fmt.Errorf("failed to add robot account %s to quay team %s: %w", robot, teamID, err)
+		}
+	}
+	for _, robot := range robots {
+		if slices.Contains(desired, robot) {
+			continue
+		}
+		log.WithField("robot", robot).Info("Remove robot account from quay team")
+		if err := qC.removeTeamMember(ctx, teamID, robot, "backend.quay.ReconcileGroupParams"); err != nil {
+			return fmt.Errorf("failed to remove robot account %s from quay team %s: %w", robot, teamID, err)
+		}
+	}
+	return nil
+}
+
+// robotName returns the full name of the robot account of the organization, <org>+<shortname>
+func (qC *QuayClient) robotName(robot string) string {
+	if strings.Contains(robot, "+") {
+		return robot
+	}
+	return qC.org + "+" + robot
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchTeamMembersByTeamID lists the user members of the team by name, keyed by username. The
+// users invited to the team are members, the robot accounts are managed by the robot_accounts
+// group param and left out.
+func (qC *QuayClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	teamMembers, err := qC.fetchTeamMembers(ctx, teamID, "backend.quay.FetchTeamMembersByTeamID")
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch quay team members")
+		return nil, err
+	}
+	members := make(map[string]*structs.User)
+	for _, member := range teamMembers {
+		if member.isRobot() || member.Name == "" {
+			continue
+		}
+		members[member.Name] = &structs.User{ID: member.Name, UserName: member.Name}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the team, Quay invites the users who are not members of the
+// organization yet when the organization requires it
+func (qC *QuayClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.AddUserToTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "quay")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Add user to quay team")
+		if err := qC.addTeamMember(ctx, teamID, userID, "backend.quay.AddUserToTeam"); err != nil {
+			return fmt.Errorf("failed to add user %s to quay team %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the team one at a time
+func (qC *QuayClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.RemoveUserFromTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "quay")
+	for _, userID := range userIDs {
+		log.WithField("userID", userID).Info("Remove user from quay team")
+		if err := qC.removeTeamMember(ctx, teamID, userID, "backend.quay.RemoveUserFromTeam"); err != nil {
+			return fmt.Errorf("failed to remove user %s from quay team %s: %w", userID, teamID, err)
+		}
+	}
+	return nil
+}
+
+// fetchTeamMembers lists the members of the team, its pending invitations included
+func (qC *QuayClient) fetchTeamMembers(ctx context.Context, teamID, methodName string) ([]Member, error) {
+	var resp membersResponse
+	if err := qC.get(ctx, qC.teamPath(teamID)+"/members?includePending=true", &resp, methodName); err != nil {
+		return nil, err
+	}
+	return resp.Members, nil
+}
+
+// addTeamMember adds the user or robot account to the team
+func (qC *QuayClient) addTeamMember(ctx context.Context, teamID, memberName, methodName string) error {
+	_, err := qC.sendRequest(ctx, qC.teamPath(teamID)+"/members/"+url.PathEscape(memberName), http.MethodPut,
+		nil, methodName)
+	return err
+}
+
+// removeTeamMember removes the user or robot account from the team, Quay answers 404 when the name
+// is not a member of the team
+func (qC *QuayClient) removeTeamMember(ctx context.Context, teamID, memberName, methodName string) error {
+	_, err := qC.sendRequest(ctx, qC.teamPath(teamID)+"/members/"+url.PathEscape(memberName), http.MethodDelete,
+		nil, methodName)
+	if request.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the teams of the organization, keyed by name
+func (qC *QuayClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.FetchAllTeams")
+	defer span.Finish()
+
+	org, err := qC.fetchOrganization(ctx, "backend.quay.FetchAllTeams")
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch quay teams")
+		return nil, err
+	}
+	teams := make(map[string]structs.Team, len(org.Teams))
+	for _, team := range org.Teams {
+		teams[team.Name] = teamDetails(&team)
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the team by name, the Quay API only lists the teams with their
+// organization
+func (qC *QuayClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.FetchTeamDetails")
+	defer span.Finish()
+
+	org, err := qC.fetchOrganization(ctx, "backend.quay.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	team, ok := org.Teams[teamID]
+	if !ok {
+		return nil, &request.ResponseError{Service: "quay", StatusCode: http.StatusNotFound,
+			Message: fmt.Sprintf("team %s not found", teamID)}
+	}
+	details := teamDetails(&team)
+	return &details, nil
+}
+
+// CreateTeam creates the team with the role of the team, member by default. The team name must
+// be lower case letters and digits, starting with a letter.
+func (qC *QuayClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "quay")
+	log.Info("Create quay team")
+
+	role := team.Role
+	if role == "" {
+		role = defaultTeamRole
+	}
+	resp, err := qC.sendRequest(ctx, qC.teamPath(team.Name), http.MethodPut, &Team{
+		Name:        team.Name,
+		Description: team.Description,
+		Role:        role,
+	}, "backend.quay.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create quay team")
+		return nil, err
+	}
+
+	var created Team
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	details := teamDetails(&created)
+	return &details, nil
+}
+
+// DeleteTeamByID deletes the team by name, which revokes its repository permissions. A team which
+// does not exist is considered deleted.
+func (qC *QuayClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "quay")
+	log.Info("Delete quay team")
+
+	_, err := qC.sendRequest(ctx, qC.teamPath(teamID), http.MethodDelete, nil, "backend.quay.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("quay team not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// fetchOrganization fetches the organization with its teams
+func (qC *QuayClient) fetchOrganization(ctx context.Context, methodName string) (*Organization, error) {
+	var org Organization
+	if err := qC.get(ctx, qC.orgPath(""), &org, methodName); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// teamPath returns the path of the team of the organization
+func (qC *QuayClient) teamPath(teamID string) string {
+	return qC.orgPath("/team/" + url.PathEscape(teamID))
+}
+
+// teamDetails converts the Quay team, the name is the ID of the team
+func teamDetails(t *Team) structs.Team {
+	return structs.Team{
+		ID:          t.Name,
+		Name:        t.Name,
+		Description: t.Description,
+		Role:        t.Role,
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+const (
+	// defaultBaseURL is the Quay.io registry, the API is served under /api/v1
+	defaultBaseURL = "https://quay.io"
+	// defaultTeamRole is the role of the teams created without a team role, a member of a
+	// member team only has the permissions granted to the team on the repositories
+	defaultTeamRole = "member"
+	// memberKindRobot is the kind of the robot account members of the organization and its teams
+	memberKindRobot = "robot"
+	// memberKindInvite is the kind of the pending invitations listed with the team members
+	memberKindInvite = "invite"
+)
+
+// QuayConfig is the connection of a Quay backend, read from the backend configuration
+type QuayConfig struct {
+	// BaseURL is the Quay registry, https://quay.io by default
+	BaseURL string `json:"base_url"`
+	// Organization holds the teams managed by usernaut
+	Organization string `json:"organization"`
+	// Token is the OAuth access token of an application of the organization, with the
+	// org:admin and user:read scopes
+	Token string `json:"token"`
+	// RemoveOrgMembers removes the offboarded users from the organization, which removes them
+	// from all its teams
+	RemoveOrgMembers bool `json:"remove_org_members"`
+}
+
+// User is a Quay user account
+type User struct {
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+	Verified bool   `json:"verified,omitempty"`
+}
+
+// Organization is a Quay organization with its teams, keyed by name
+type Organization struct {
+	Name  string          `json:"name"`
+	Teams map[string]Team `json:"teams"`
+}
+
+// Team is a team of the organization, identified by its name
+type Team struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Role        string `json:"role"`
+}
+
+// Member is a member of the organization or of a team: a user, a robot account or, for a team, a
+// pending invitation
+type Member struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	IsRobot bool   `json:"is_robot"`
+}
+
+// membersResponse is the response listing the members of the organization or of a team
+type membersResponse struct {
+	Members []Member `json:"members"`
+}
+
+// errorResponse is the body of the Quay API errors
+type errorResponse struct {
+	Message      string `json:"message"`
+	ErrorMessage string `json:"error_message"`
+	Detail       string `json:"detail"`
+}
+
+// isUser reports whether the member is a user account, rather than a robot account or an
+// invitation
+func (m *Member) isUser() bool {
+	return !m.isRobot() && m.Kind != memberKindInvite
+}
+
+// isRobot reports whether the member is a robot account
+func (m *Member) isRobot() bool {
+	return m.IsRobot || m.Kind == memberKindRobot
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the user members of the organization, keyed by username. Quay does not
+// disclose the email of the users, so none are keyed by email.
+func (qC *QuayClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.FetchAllUsers")
+	defer span.Finish()
+
+	var resp membersResponse
+	if err := qC.get(ctx, qC.orgPath("/members"), &resp, "backend.quay.FetchAllUsers"); err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch quay organization members")
+		return nil, nil, err
+	}
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	for _, member := range resp.Members {
+		if member.isUser() {
+			usersByID[member.Name] = &structs.User{ID: member.Name, UserName: member.Name}
+		}
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// FetchUserDetails fetches the Quay user by username
+func (qC *QuayClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := qC.get(ctx, "/users/"+url.PathEscape(userID), &user, "backend.quay.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser resolves the Quay user by its username, the Quay accounts are created when the users
+// first sign in and cannot be created for them
+func (qC *QuayClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("username", u.UserName).WithField("service", "quay")
+	log.Info("Resolve quay user")
+
+	user, err := qC.FetchUserDetails(ctx, u.UserName)
+	if err != nil {
+		if request.IsNotFound(err) {
+			return nil, fmt.Errorf("quay user %s not found, the user must sign in to quay first: %w", u.UserName, err)
+		}
+		log.WithError(err).Error("failed to fetch quay user")
+		return nil, err
+	}
+	if user.Email == "" {
+		user.Email = u.Email
+	}
+	return user, nil
+}
+
+// DeleteUser removes the user from the organization when the backend removes the members, which
+// removes it from all the teams and revokes its repository permissions. The Quay account itself
+// is never deleted. A user who is not a member is considered deleted.
+func (qC *QuayClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.quay.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "quay")
+	if !qC.removeOrgMembers {
+		log.Info("quay organization members are not removed, skipping user deletion")
+		return nil
+	}
+
+	log.Info("Remove quay organization member")
+	_, err := qC.sendRequest(ctx, qC.orgPath("/members/"+url.PathEscape(userID)), http.MethodDelete, nil,
+		"backend.quay.DeleteUser")
+	if request.IsNotFound(err) {
+		log.Warn("quay organization member not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// userDetails converts the Quay user, the username is the ID of the user
+func userDetails(u *User) *structs.User {
+	return &structs.User{
+		ID:       u.Username,
+		UserName: u.Username,
+		Email:    u.Email,
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/gojek/heimdall/v7"
@@ -41,6 +42,9 @@ type RoverConfig struct {
 	ServiceAccountName string `json:"service_account_name"`
 }
 
+// healthCheckGroup is the group looked up by HealthCheck
+const healthCheckGroup = "usernaut-health-check"
+
 func NewClient(roverAppConfig map[string]interface{},
 	connectionPoolConfig httpclient.ConnectionPoolConfig,
 	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*RoverClient, error) {
@@ -79,6 +83,21 @@ func NewClient(roverAppConfig map[string]interface{},
 	}, nil
 }
 
+// HealthCheck looks up a group which is not expected to exist, the API is healthy as long as it
+// answers without a server error or an authentication failure
+func (rC *RoverClient) HealthCheck(ctx context.Context) error {
+	_, respCode, err := rC.sendRequest(ctx, rC.url+"/v1/groups/"+healthCheckGroup,
+		http.MethodGet, nil, headers, "backend.redhatrover.HealthCheck")
+	if err != nil {
+		return fmt.Errorf("rover health check failed: %w", err)
+	}
+	if respCode >= http.StatusInternalServerError || respCode == http.StatusUnauthorized ||
+		respCode == http.StatusForbidden {
+		return fmt.Errorf("rover health check failed with response code: %s", http.StatusText(respCode))
+	}
+	return nil
+}
+
 func (rC *RoverClient) sendRequest(ctx context.Context, url string, method string, body interface{},
 	headers map[string]string, methodName string) ([]byte, int, error) {
 
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redhatrover
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// newTestClient returns a RoverClient sending its requests to the handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *RoverClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &RoverClient{
+		client:             server.Client(),
+		url:                server.URL,
+		serviceAccountName: "usernaut-sa",
+	}
+}
+
+func writeGroup(t *testing.T, w http.ResponseWriter, group RoverGroup) {
+	t.Helper()
+	w.WriteHeader(http.StatusOK)
+	require.NoError(t, json.NewEncoder(w).Encode(group))
+}
+
+func TestCreateTeam(t *testing.T) {
+	var created RoverGroup
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/groups", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &created))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	team, err := rC.CreateTeam(context.Background(), &structs.Team{Name: "data-team", Description: "Data team"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "data-team", Name: "data-team", Description: "Data team"}, team)
+	assert.Equal(t, "data-team", created.Name)
+	assert.Equal(t, MemberApprovalTypeSelfService, created.MemberApprovalType)
+	assert.Equal(t, []Member{{ID: "usernaut-sa", Type: MemberTypeServiceAccount}}, created.Owners)
+}
+
+func TestCreateTeamAlreadyExists(t *testing.T) {
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusForbidden)
+		case http.MethodGet:
+			assert.Equal(t, "/v1/groups/data-team", r.URL.Path)
+			writeGroup(t, w, RoverGroup{Name: "data-team", Description: "Existing team"})
+		}
+	})
+
+	team, err := rC.CreateTeam(context.Background(), &structs.Team{Name: "data-team", Description: "Data team"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "data-team", Name: "data-team", Description: "Existing team"}, team)
+}
+
+func TestCreateTeamForbidden(t *testing.T) {
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("not authorized"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := rC.CreateTeam(context.Background(), &structs.Team{Name: "data-team"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestFetchTeamDetails(t *testing.T) {
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/groups/data-team" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeGroup(t, w, RoverGroup{Name: "data-team", Description: "Data team"})
+	})
+
+	team, err := rC.FetchTeamDetails(context.Background(), "data-team")
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "data-team", Name: "data-team", Description: "Data team"}, team)
+
+	_, err = rC.FetchTeamDetails(context.Background(), "missing-team")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestFetchTeamMembersByTeamID(t *testing.T) {
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		writeGroup(t, w, RoverGroup{
+			Name: "data-team",
+			Members: []Member{
+				{ID: "alice", Type: MemberTypeUser},
+				{ID: "usernaut-sa", Type: MemberTypeServiceAccount},
+				{ID: "bob", Type: MemberTypeUser},
+			},
+		})
+	})
+
+	members, err := rC.FetchTeamMembersByTeamID(context.Background(), "data-team")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{
+		"alice": {ID: "alice"},
+		"bob":   {ID: "bob"},
+	}, members)
+}
+
+func TestModifyTeamMembers(t *testing.T) {
+	var requests []MemberModRequest
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/groups/data-team/membersMod", r.URL.Path)
+		var req MemberModRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	require.NoError(t, rC.AddUserToTeam(context.Background(), "data-team", []string{"alice", "bob"}))
+	require.NoError(t, rC.RemoveUserFromTeam(context.Background(), "data-team", []string{"carol"}))
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, []Member{{ID: "alice", Type: MemberTypeUser}, {ID: "bob", Type: MemberTypeUser}},
+		requests[0].Additions)
+	assert.Empty(t, requests[0].Deletions)
+	assert.Equal(t, []Member{{ID: "carol", Type: MemberTypeUser}}, requests[1].Deletions)
+	assert.Empty(t, requests[1].Additions)
+}
+
+func TestModifyTeamMembersFailure(t *testing.T) {
+	rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	err := rC.AddUserToTeam(context.Background(), "data-team", []string{"alice"})
+	assert.ErrorContains(t, err, http.StatusText(http.StatusBadRequest))
+}
+
+func TestDeleteTeamByID(t *testing.T) {
+	for name, tc := range map[string]struct {
+		respCode int
+		wantErr  bool
+	}{
+		"deleted":   {respCode: http.StatusNoContent},
+		"ok":        {respCode: http.StatusOK},
+		"not found": {respCode: http.StatusNotFound},
+		"failure":   {respCode: http.StatusInternalServerError, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rC := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, "/v1/groups/data-team", r.URL.Path)
+				w.WriteHeader(tc.respCode)
+			})
+
+			err := rC.DeleteTeamByID(context.Background(), "data-team")
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsers(t *testing.T) {
+	rC := &RoverClient{}
+
+	user, err := rC.CreateUser(context.Background(), &structs.User{UserName: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.ID)
+
+	user, err = rC.FetchUserDetails(context.Background(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.ID)
+
+	assert.NoError(t, rC.DeleteUser(context.Background(), "alice"))
+}
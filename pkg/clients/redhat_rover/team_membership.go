@@ -18,10 +18,9 @@ package redhatrover
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	ot "github.com/opentracing/opentracing-go"
 
@@ -37,26 +36,12 @@ func (rC *RoverClient) FetchTeamMembersByTeamID(ctx context.Context, teamID stri
 	log := logger.Logger(ctx)
 	log.Info("Fetching team member details from rover group")
 
-	resp, respCode, err := rC.sendRequest(ctx, rC.url+"/v1/groups/"+teamID,
-		http.MethodGet, nil,
-		headers, "backend.redhatrover.FetchTeamMembersByTeamID")
-
+	roverGroup, err := rC.fetchGroup(ctx, teamID, "backend.redhatrover.FetchTeamMembersByTeamID")
 	if err != nil {
 		log.WithError(err).Error("failed to fetch rover group members")
 		return nil, err
 	}
 
-	if respCode != http.StatusOK {
-		log.Error("failed to fetch rover group members")
-		return nil, errors.New("failed to fetch rover group members with response code: " + http.StatusText(respCode))
-	}
-
-	var roverGroup RoverGroup
-	if err := json.Unmarshal(resp, &roverGroup); err != nil {
-		log.WithError(err).Error("failed to decode rover group response")
-		return nil, errors.New("failed to decode rover group response: " + err.Error())
-	}
-
 	members := make(map[string]*structs.User)
 	for _, member := range roverGroup.Members {
 		if member.Type != MemberTypeUser {
@@ -100,7 +85,7 @@ func (rC *RoverClient) modify(
 	}
 
 	_, respCode, err := rC.sendRequest(ctx,
-		rC.url+"/v1/groups/"+teamID+"/membersMod",
+		rC.url+"/v1/groups/"+url.PathEscape(teamID)+"/membersMod",
 		http.MethodPost,
 		req,
 		headers,
@@ -128,7 +113,8 @@ func (rC *RoverClient) RemoveUserFromTeam(ctx context.Context, teamID string, us
 	return rC.modify(ctx, "backend.redhatrover.RemoveUserFromTeam", "remove", teamID, userIDs)
 }
 
+// ReconcileGroupParams is a no-op as Rover has no group params, the group settings are fixed when
+// the group is created
 func (rC *RoverClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
-	// TODO: Implement group parameter reconciliation for Red Hat Rover if applicable.
 	return nil
 }
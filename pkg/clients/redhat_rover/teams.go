@@ -18,6 +18,7 @@ package redhatrover
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -33,9 +34,50 @@ func (rC *RoverClient) FetchAllTeams(ctx context.Context) (map[string]structs.Te
 	return map[string]structs.Team{}, nil
 }
 
+// FetchTeamDetails fetches the rover group by its name, which is the teamID of the Rover teams
 func (rC *RoverClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
-	// Fetching team details is not supported as the teamID is the same as the teamName.
-	return nil, fmt.Errorf("fetching team details is not supported")
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.redhatrover.FetchTeamDetails")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "rover")
+
+	roverGroup, err := rC.fetchGroup(ctx, teamID, "backend.redhatrover.FetchTeamDetails")
+	if err != nil {
+		log.WithError(err).Error("failed to fetch rover group details")
+		return nil, err
+	}
+
+	return &structs.Team{
+		ID:          roverGroup.Name,
+		Name:        roverGroup.Name,
+		Description: roverGroup.Description,
+	}, nil
+}
+
+// fetchGroup fetches the rover group by its name
+func (rC *RoverClient) fetchGroup(ctx context.Context, groupName, methodName string) (*RoverGroup, error) {
+	resp, respCode, err := rC.sendRequest(ctx, rC.url+"/v1/groups/"+url.PathEscape(groupName),
+		http.MethodGet, nil, headers, methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	if respCode == http.StatusNotFound {
+		return nil, fmt.Errorf("rover group %s not found", groupName)
+	}
+	if respCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch rover group %s with response code: %s",
+			groupName, http.StatusText(respCode))
+	}
+
+	var roverGroup RoverGroup
+	if err := json.Unmarshal(resp, &roverGroup); err != nil {
+		return nil, fmt.Errorf("failed to decode rover group response: %w", err)
+	}
+	if roverGroup.Name == "" {
+		roverGroup.Name = groupName
+	}
+	return &roverGroup, nil
 }
 
 // CreateTeam creates a new team in Rover. If the team already exists, it returns the existing team details.
@@ -69,14 +111,15 @@ func (rC *RoverClient) CreateTeam(ctx context.Context, team *structs.Team) (*str
 		}
 	}
 
-	// API return 403 Forbidden if the group already exists.
+	// API return 403 Forbidden if the group already exists, the group is looked up to tell it
+	// apart from a request which is not authorized.
 	if respCode == http.StatusForbidden {
 		log.WithField("response", string(resp)).Warn("Rover group already exists, fetching existing group details")
-		return &structs.Team{
-			ID:          team.Name,
-			Name:        team.Name,
-			Description: team.Description,
-		}, nil
+		existing, err := rC.FetchTeamDetails(ctx, team.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rover group: %s", string(resp))
+		}
+		return existing, nil
 	}
 
 	if respCode != http.StatusCreated {
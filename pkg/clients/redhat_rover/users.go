@@ -28,13 +28,14 @@ func (rC *RoverClient) FetchAllUsers(ctx context.Context) (map[string]*structs.U
 }
 
 func (rC *RoverClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
-	// this doesn't need any implementation as Rover is the LDAP
-	return &structs.User{}, nil
+	// this doesn't need any implementation as Rover is the LDAP, the uid is the ID of the user
+	return &structs.User{ID: userID, UserName: userID}, nil
 }
 
 func (rC *RoverClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
 	// as rover is the LDAP, no need to create user here
-	// field UserName is used as ID in Rover
+	// field UserName is used as ID in Rover, the LDAP user always exists so no create request can
+	// conflict, and there is no structs.ErrUserAlreadyExists to report
 	return &structs.User{
 		ID: u.UserName,
 	}, nil
@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/store"
+)
+
+var (
+	responseStoreMu sync.RWMutex
+	// responseStore caches the responses of the backends with a response_cache_ttl, it is set once
+	// the store is created at startup
+	responseStore store.BackendResponseStoreInterface
+)
+
+// SetResponseStore sets the store of the responses cached for the backends with a response_cache_ttl,
+// the clients created from then on serve their teams and team members from it while fresh
+func SetResponseStore(s store.BackendResponseStoreInterface) {
+	responseStoreMu.Lock()
+	defer responseStoreMu.Unlock()
+	responseStore = s
+}
+
+// withConfiguredResponseCache wraps the client with the response cache of the backend, the client is
+// returned as is when the backend has no TTL or no response store is set
+func withConfiguredResponseCache(backendClient Client, backendKey, ttl string) (Client, error) {
+	if ttl == "" {
+		return backendClient, nil
+	}
+	duration, err := time.ParseDuration(ttl)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid response_cache_ttl %q, expected a positive duration like 2m", ttl)
+	}
+
+	responseStoreMu.RLock()
+	defer responseStoreMu.RUnlock()
+	if responseStore == nil {
+		return backendClient, nil
+	}
+	return WithResponseCache(backendClient, backendKey, responseStore, duration), nil
+}
+
+// WithResponseCache wraps the backend client to serve FetchAllTeams and FetchTeamMembersByTeamID
+// from the store while the responses are fresher than the TTL, so that the groups sharing a backend
+// and reconciled in bursts don't list the same teams again. The changes made through the client
+// invalidate the responses they affect, the changes made outside of usernaut are seen once the
+// responses expire.
+func WithResponseCache(backendClient Client, backendKey string, responses store.BackendResponseStoreInterface,
+	ttl time.Duration) Client {
+	return &cachingClient{
+		Client:     backendClient,
+		backendKey: backendKey,
+		responses:  responses,
+		ttl:        ttl,
+	}
+}
+
+// The caching client invalidates the members changed with a role or removed by an audit
+var (
+	_ Wrapper            = (*cachingClient)(nil)
+	_ TeamRoleClient     = (*cachingClient)(nil)
+	_ DirectMemberClient = (*cachingClient)(nil)
+)
+
+// cachingClient is the read-through cache of the teams and team members of a backend. The calls it
+// doesn't cache go to the wrapped client.
+type cachingClient struct {
+	Client
+	backendKey string
+	responses  store.BackendResponseStoreInterface
+	ttl        time.Duration
+}
+
+// Unwrap implements Wrapper
+func (c *cachingClient) Unwrap() Client {
+	return c.Client
+}
+
+// FetchAllTeams serves the teams from the store while fresh, the store errors fall back to the backend
+func (c *cachingClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("backend", c.backendKey)
+
+	teams, found, err := c.responses.GetTeams(ctx, c.backendKey)
+	if err != nil {
+		log.WithError(err).Warn("error reading the cached teams, fetching them from the backend")
+	} else if found {
+		return teams, nil
+	}
+
+	teams, err = c.Client.FetchAllTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.responses.SetTeams(ctx, c.backendKey, teams, c.ttl); err != nil {
+		log.WithError(err).Warn("error caching the teams")
+	}
+	return teams, nil
+}
+
+// FetchTeamMembersByTeamID serves the members from the store while fresh, the store errors fall back
+// to the backend
+func (c *cachingClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("backend", c.backendKey).WithField("team_id", teamID)
+
+	members, found, err := c.responses.GetTeamMembers(ctx, c.backendKey, teamID)
+	if err != nil {
+		log.WithError(err).Warn("error reading the cached team members, fetching them from the backend")
+	} else if found {
+		return members, nil
+	}
+
+	members, err = c.Client.FetchTeamMembersByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.responses.SetTeamMembers(ctx, c.backendKey, teamID, members, c.ttl); err != nil {
+		log.WithError(err).Warn("error caching the team members")
+	}
+	return members, nil
+}
+
+// The changes invalidate the cached responses even when they fail, they may be partially applied
+
+func (c *cachingClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	defer c.invalidateTeams(ctx)
+	return c.Client.CreateTeam(ctx, team)
+}
+
+func (c *cachingClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	defer c.invalidateTeams(ctx)
+	defer c.invalidateMembers(ctx, teamID)
+	return c.Client.DeleteTeamByID(ctx, teamID)
+}
+
+func (c *cachingClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	defer c.invalidateMembers(ctx, teamID)
+	return c.Client.AddUserToTeam(ctx, teamID, userIDs)
+}
+
+func (c *cachingClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	defer c.invalidateMembers(ctx, teamID)
+	return c.Client.RemoveUserFromTeam(ctx, teamID, userIDs)
+}
+
+// The role methods are only called on the caching client when the wrapped client implements
+// TeamRoleClient (see As)
+
+func (c *cachingClient) AddUserToTeamWithRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	roleClient, _ := As[TeamRoleClient](c.Client)
+	defer c.invalidateMembers(ctx, teamID)
+	return roleClient.AddUserToTeamWithRole(ctx, teamID, userIDs, role)
+}
+
+func (c *cachingClient) UpdateTeamMemberRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	roleClient, _ := As[TeamRoleClient](c.Client)
+	defer c.invalidateMembers(ctx, teamID)
+	return roleClient.UpdateTeamMemberRole(ctx, teamID, userIDs, role)
+}
+
+// The direct members are not cached, the audit always lists them from the backend
+
+func (c *cachingClient) FetchDirectTeamMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	directClient, _ := As[DirectMemberClient](c.Client)
+	return directClient.FetchDirectTeamMembers(ctx, teamID)
+}
+
+func (c *cachingClient) RemoveDirectTeamMembers(ctx context.Context, teamID string, userIDs []string) error {
+	directClient, _ := As[DirectMemberClient](c.Client)
+	defer c.invalidateMembers(ctx, teamID)
+	return directClient.RemoveDirectTeamMembers(ctx, teamID, userIDs)
+}
+
+// invalidateTeams removes the cached teams of the backend
+func (c *cachingClient) invalidateTeams(ctx context.Context) {
+	if err := c.responses.DeleteTeams(ctx, c.backendKey); err != nil {
+		logger.Logger(ctx).WithField("backend", c.backendKey).WithError(err).Warn("error invalidating the cached teams")
+	}
+}
+
+// invalidateMembers removes the cached members of the team
+func (c *cachingClient) invalidateMembers(ctx context.Context, teamID string) {
+	if err := c.responses.DeleteTeamMembers(ctx, c.backendKey, teamID); err != nil {
+		logger.Logger(ctx).WithField("backend", c.backendKey).WithField("team_id", teamID).WithError(err).
+			Warn("error invalidating the cached team members")
+	}
+}
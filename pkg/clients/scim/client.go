@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// SCIMClient provisions the users and groups of a service through its SCIM 2.0 API
+type SCIMClient struct {
+	client            heimdall.Doer
+	url               string
+	headers           map[string]string
+	pageSize          int
+	userNameAttribute string
+	deactivateUsers   bool
+}
+
+func NewClient(scimAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*SCIMClient, error) {
+
+	scimConfig := SCIMConfig{}
+	if err := utils.MapToStruct(scimAppConfig, &scimConfig); err != nil {
+		return nil, err
+	}
+	if scimConfig.BaseURL == "" || scimConfig.Token == "" {
+		return nil, errors.New("scim configuration is missing required fields: base_url or token")
+	}
+
+	pageSize := scimConfig.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	userNameAttribute := strings.ToLower(scimConfig.UserNameAttribute)
+	switch userNameAttribute {
+	case "":
+		userNameAttribute = UserNameAttributeEmail
+	case UserNameAttributeEmail, UserNameAttributeUsername:
+	default:
+		return nil, fmt.Errorf("unsupported scim user_name_attribute: %s", scimConfig.UserNameAttribute)
+	}
+
+	client, err := httpclient.InitializeClient(
+		"scim_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &SCIMClient{
+		client: client,
+		url:    strings.TrimSuffix(scimConfig.BaseURL, "/"),
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: contentType,
+			"Accept":                       contentType,
+			"Authorization":                "Bearer " + scimConfig.Token,
+		},
+		pageSize:          pageSize,
+		userNameAttribute: userNameAttribute,
+		deactivateUsers:   scimConfig.DeactivateUsers,
+	}, nil
+}
+
+// HealthCheck lists a single user, the SCIM API is healthy when it answers the authenticated list
+func (sC *SCIMClient) HealthCheck(ctx context.Context) error {
+	var page ListResponse[User]
+	if err := sC.get(ctx, "/Users?startIndex=1&count=1", &page, "backend.scim.HealthCheck"); err != nil {
+		return fmt.Errorf("scim health check failed: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request to the path and decodes its response
+func (sC *SCIMClient) get(ctx context.Context, path string, result any, methodName string) error {
+	resp, err := sC.sendRequest(ctx, path, http.MethodGet, nil, methodName)
+	if err != nil {
+		return err
+	}
+	return decode(resp, result)
+}
+
+// decode decodes the response of a SCIM request
+func decode(resp []byte, result any) error {
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode scim response: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends the request to the path of the SCIM API and returns the response body, any
+// response code other than 2xx is returned as a *request.ResponseError
+func (sC *SCIMClient) sendRequest(ctx context.Context, path string, method string, body any,
+	methodName string) ([]byte, error) {
+	resp, _, err := request.SendJSON(ctx, sC.client, method, sC.url+path, body, sC.headers, methodName,
+		"scim", errorMessage)
+	return resp, err
+}
+
+// errorMessage returns the SCIM error type and the detail of an error response, the type is e.g.
+// uniqueness for the conflicts
+func errorMessage(body []byte) (string, string) {
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", ""
+	}
+	return errResp.ScimType, errResp.Detail
+}
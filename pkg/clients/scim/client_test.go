@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeSCIM is a SCIM service holding the users and the group members in memory
+type fakeSCIM struct {
+	users   []User
+	members map[string][]Member
+	patches []PatchRequest
+	deleted []string
+}
+
+func (f *fakeSCIM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/scim/v2/Users":
+		startIndex, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		end := min(startIndex-1+count, len(f.users))
+		fakehttp.WriteJSON(w, http.StatusOK, ListResponse[User]{
+			Schemas:      []string{SchemaListResponse},
+			TotalResults: len(f.users),
+			StartIndex:   startIndex,
+			ItemsPerPage: end - startIndex + 1,
+			Resources:    f.users[startIndex-1 : end],
+		})
+	case r.Method == http.MethodPost && r.URL.Path == "/scim/v2/Users":
+		var user User
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		for _, existing := range f.users {
+			if existing.UserName == user.UserName {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = io.WriteString(w, `{"scimType": "uniqueness", "detail": "userName already exists", "status": "409"}`)
+				return
+			}
+		}
+		user.ID = fmt.Sprintf("u-%d", len(f.users)+1)
+		f.users = append(f.users, user)
+		fakehttp.WriteJSON(w, http.StatusCreated, user)
+	case r.Method == http.MethodDelete:
+		f.deleted = append(f.deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet && r.URL.Path == "/scim/v2/Groups/g-1":
+		fakehttp.WriteJSON(w, http.StatusOK, Group{ID: "g-1", DisplayName: "team-a", Members: f.members["g-1"]})
+	case r.Method == http.MethodPatch && r.URL.Path == "/scim/v2/Groups/g-1":
+		var patch PatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&patch)
+		f.patches = append(f.patches, patch)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakeSCIM, connection map[string]interface{}) *SCIMClient {
+	t.Helper()
+	server := fakehttp.NewServer(t, fake)
+
+	connection["base_url"] = server.URL + "/scim/v2/"
+	connection["token"] = "token"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"base_url": "http://localhost"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "base_url or token")
+
+	_, err = NewClient(map[string]interface{}{"base_url": "http://localhost", "token": "token",
+		"user_name_attribute": "uid"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "unsupported scim user_name_attribute")
+}
+
+func TestFetchAllUsersPages(t *testing.T) {
+	fake := &fakeSCIM{}
+	for i := 1; i <= 5; i++ {
+		fake.users = append(fake.users, User{
+			ID:       fmt.Sprintf("u-%d", i),
+			UserName: fmt.Sprintf("user%d@example.com", i),
+		})
+	}
+	fake.users[0].Emails = []Email{{Value: "other@example.com"}, {Value: "primary@example.com", Primary: true}}
+	client := newTestClient(t, fake, map[string]interface{}{"page_size": 2})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 5)
+	assert.Equal(t, "primary@example.com", byID["u-1"].Email)
+	assert.Equal(t, "u-5", byEmail["user5@example.com"].ID)
+}
+
+func TestCreateUser(t *testing.T) {
+	fake := &fakeSCIM{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	user, err := client.CreateUser(context.Background(), &structs.User{
+		UserName: "jdoe", Email: "jdoe@example.com", FirstName: "John", LastName: "Doe",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u-1", user.ID)
+	assert.Equal(t, "jdoe@example.com", user.UserName)
+	assert.Equal(t, "jdoe@example.com", user.Email)
+	require.Len(t, fake.users, 1)
+	assert.Equal(t, "jdoe", fake.users[0].ExternalID)
+	assert.Equal(t, "John Doe", fake.users[0].DisplayName)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	var respErr *request.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusConflict, respErr.StatusCode)
+	assert.Equal(t, "uniqueness", respErr.Code)
+	assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	fake := &fakeSCIM{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	assert.NoError(t, client.DeleteUser(context.Background(), "u-1"))
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "g-2"))
+	assert.Equal(t, []string{"/scim/v2/Users/u-1", "/scim/v2/Groups/g-2"}, fake.deleted)
+}
+
+func TestTeamMembership(t *testing.T) {
+	fake := &fakeSCIM{members: map[string][]Member{
+		"g-1": {{Value: "u-1", Type: "User"}, {Value: "g-2", Type: "Group"}, {Value: "u-2"}},
+	}}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g-1")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.NotContains(t, members, "g-2")
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "g-1", []string{"u-3", "u-4"}))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "g-1", []string{"u-1", "u-2"}))
+
+	require.Len(t, fake.patches, 2)
+	add := fake.patches[0].Operations
+	require.Len(t, add, 1)
+	assert.Equal(t, "add", add[0].Op)
+	assert.Equal(t, "members", add[0].Path)
+	assert.Len(t, add[0].Value, 2)
+
+	remove := fake.patches[1].Operations
+	require.Len(t, remove, 2)
+	assert.Equal(t, PatchOperation{Op: "remove", Path: `members[value eq "u-1"]`}, remove[0])
+	assert.Equal(t, []string{SchemaPatchOp}, fake.patches[1].Schemas)
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := newTestClient(t, &fakeSCIM{}, map[string]interface{}{})
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	client.headers["Authorization"] = "Bearer wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "response code 401")
+}
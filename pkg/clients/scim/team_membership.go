@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID fetches the user members of the group, keyed by ID
+func (sC *SCIMClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	var group Group
+	if err := sC.get(ctx, "/Groups/"+url.PathEscape(teamID)+"?attributes=members", &group,
+		"backend.scim.FetchTeamMembersByTeamID"); err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch scim group members")
+		return nil, err
+	}
+
+	members := make(map[string]*structs.User, len(group.Members))
+	for _, member := range group.Members {
+		// nested groups are members too, only the users are reconciled
+		if member.Type != "" && !strings.EqualFold(member.Type, "User") {
+			continue
+		}
+		members[member.Value] = &structs.User{
+			ID:          member.Value,
+			DisplayName: member.Display,
+		}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the batch of users to the group with a single PATCH add operation
+func (sC *SCIMClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.AddUserToTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	logger.Logger(ctx).WithField("teamID", teamID).Info("adding team users to the scim group")
+
+	members := make([]Member, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, Member{Value: userID})
+	}
+	return sC.patchGroup(ctx, teamID, []PatchOperation{{Op: "add", Path: "members", Value: members}},
+		"backend.scim.AddUserToTeam")
+}
+
+// RemoveUserFromTeam removes the batch of users from the group with a PATCH remove operation per
+// user, filtering the members by value as defined by RFC 7644
+func (sC *SCIMClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.RemoveUserFromTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	logger.Logger(ctx).WithField("teamID", teamID).Info("removing team users from the scim group")
+
+	operations := make([]PatchOperation, 0, len(userIDs))
+	for _, userID := range userIDs {
+		operations = append(operations, PatchOperation{
+			Op:   "remove",
+			Path: fmt.Sprintf("members[value eq %q]", userID),
+		})
+	}
+	return sC.patchGroup(ctx, teamID, operations, "backend.scim.RemoveUserFromTeam")
+}
+
+// patchGroup applies the PATCH operations to the group
+func (sC *SCIMClient) patchGroup(ctx context.Context, teamID string, operations []PatchOperation,
+	methodName string) error {
+	_, err := sC.sendRequest(ctx, "/Groups/"+url.PathEscape(teamID), http.MethodPatch, &PatchRequest{
+		Schemas:    []string{SchemaPatchOp},
+		Operations: operations,
+	}, methodName)
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to patch the scim group members")
+	}
+	return err
+}
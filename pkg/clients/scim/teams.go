@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllTeams lists the groups of the service without their members, keyed by display name
+func (sC *SCIMClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.FetchAllTeams")
+	defer span.Finish()
+
+	groups, err := listAll[Group](ctx, sC, "/Groups?excludedAttributes=members", "backend.scim.FetchAllTeams")
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch scim groups")
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(groups))
+	for _, group := range groups {
+		teams[group.DisplayName] = structs.Team{
+			ID:   group.ID,
+			Name: group.DisplayName,
+		}
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the group by ID
+func (sC *SCIMClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.FetchTeamDetails")
+	defer span.Finish()
+
+	var group Group
+	if err := sC.get(ctx, "/Groups/"+url.PathEscape(teamID)+"?excludedAttributes=members", &group,
+		"backend.scim.FetchTeamDetails"); err != nil {
+		return nil, err
+	}
+	return &structs.Team{
+		ID:   group.ID,
+		Name: group.DisplayName,
+	}, nil
+}
+
+// CreateTeam creates the group, SCIM groups have no description
+func (sC *SCIMClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "scim")
+	log.Info("Create scim group")
+
+	resp, err := sC.sendRequest(ctx, "/Groups", http.MethodPost, &Group{
+		Schemas:     []string{SchemaGroup},
+		DisplayName: team.Name,
+	}, "backend.scim.CreateTeam")
+	if err != nil {
+		log.WithError(err).Error("failed to create scim group")
+		return nil, err
+	}
+
+	var created Group
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no group ID in the scim create group response")
+	}
+	return &structs.Team{
+		ID:          created.ID,
+		Name:        team.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// DeleteTeamByID deletes the group by ID, a group which does not exist is considered deleted
+func (sC *SCIMClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "scim")
+	log.Info("Delete scim group")
+
+	_, err := sC.sendRequest(ctx, "/Groups/"+url.PathEscape(teamID), http.MethodDelete, nil,
+		"backend.scim.DeleteTeamByID")
+	if request.IsNotFound(err) {
+		log.Warn("scim group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// ReconcileGroupParams is a no-op, SCIM groups have no group params
+func (sC *SCIMClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+// SCIM 2.0 schemas (RFC 7643, RFC 7644)
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// Attributes of the users used as the SCIM userName
+const (
+	UserNameAttributeEmail    = "email"
+	UserNameAttributeUsername = "username"
+)
+
+const (
+	// contentType is the media type of the SCIM requests and responses
+	contentType = "application/scim+json"
+	// defaultPageSize is the number of resources of a list page when page_size is not configured
+	defaultPageSize = 100
+)
+
+// SCIMConfig is the connection of a SCIM backend, read from the backend configuration
+type SCIMConfig struct {
+	// BaseURL is the SCIM endpoint of the service, the resource paths /Users and /Groups are
+	// appended to it
+	BaseURL string `json:"base_url"`
+	// Token is the bearer token of the SCIM API
+	Token string `json:"token"`
+	// PageSize is the count of resources requested per list page
+	PageSize int `json:"page_size"`
+	// UserNameAttribute is the attribute of the user sent as the SCIM userName, email (default)
+	// or username
+	UserNameAttribute string `json:"user_name_attribute"`
+	// DeactivateUsers deactivates the deleted users (active: false) instead of deleting them, as
+	// some services do not allow deleting the users
+	DeactivateUsers bool `json:"deactivate_users"`
+}
+
+// User is a SCIM user resource
+type User struct {
+	Schemas     []string `json:"schemas,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// Name is the name of a SCIM user
+type Name struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	Formatted  string `json:"formatted,omitempty"`
+}
+
+// Email is an email address of a SCIM user
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Group is a SCIM group resource
+type Group struct {
+	Schemas     []string `json:"schemas,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members,omitempty"`
+}
+
+// Member is a member of a SCIM group
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// ListResponse is a page of the resources of a list request
+type ListResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+// PatchRequest is a SCIM PATCH request
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is an operation of a SCIM PATCH request
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// ErrorResponse is the error response of the SCIM API
+type ErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status,omitempty"`
+}
+
+// primaryEmail returns the primary email of the user, or its first email
+func (u *User) primaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+)
+
+// FetchAllUsers lists the users of the service, keyed by ID and by email
+func (sC *SCIMClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.FetchAllUsers")
+	defer span.Finish()
+
+	users, err := listAll[User](ctx, sC, "/Users", "backend.scim.FetchAllUsers")
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch scim users")
+		return nil, nil, err
+	}
+
+	usersByID := make(map[string]*structs.User, len(users))
+	usersByEmail := make(map[string]*structs.User, len(users))
+	for _, u := range users {
+		user := userDetails(&u)
+		usersByID[user.ID] = user
+		if user.Email != "" {
+			usersByEmail[user.Email] = user
+		}
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// FetchUserDetails fetches the user by ID
+func (sC *SCIMClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.FetchUserDetails")
+	defer span.Finish()
+
+	var user User
+	if err := sC.get(ctx, "/Users/"+url.PathEscape(userID), &user, "backend.scim.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&user), nil
+}
+
+// CreateUser provisions the user, whose SCIM userName is its email or username depending on the
+// user_name_attribute of the backend
+func (sC *SCIMClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "scim")
+	log.Info("Create scim user")
+
+	userName := u.Email
+	if sC.userNameAttribute == UserNameAttributeUsername {
+		userName = u.UserName
+	}
+	active := true
+	scimUser := &User{
+		Schemas:    []string{SchemaUser},
+		ExternalID: u.UserName,
+		UserName:   userName,
+		Name: &Name{
+			GivenName:  u.FirstName,
+			FamilyName: u.LastName,
+			Formatted:  strings.TrimSpace(u.FirstName + " " + u.LastName),
+		},
+		DisplayName: u.DisplayName,
+		Active:      &active,
+	}
+	if scimUser.DisplayName == "" {
+		scimUser.DisplayName = scimUser.Name.Formatted
+	}
+	if u.Email != "" {
+		scimUser.Emails = []Email{{Value: u.Email, Type: "work", Primary: true}}
+	}
+
+	resp, err := sC.sendRequest(ctx, "/Users", http.MethodPost, scimUser, "backend.scim.CreateUser")
+	if err != nil {
+		var respErr *request.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("%w: %w", structs.ErrUserAlreadyExists, err)
+		}
+		log.WithError(err).Error("failed to create scim user")
+		return nil, err
+	}
+
+	var created User
+	if err := decode(resp, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, errors.New("no user ID in the scim create user response")
+	}
+	user := userDetails(&created)
+	if user.Email == "" {
+		user.Email = u.Email
+	}
+	return user, nil
+}
+
+// DeleteUser deletes the user by ID, or deactivates it when the backend deactivates the users. A
+// user which does not exist is considered deleted.
+func (sC *SCIMClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.scim.DeleteUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("userID", userID).WithField("service", "scim")
+
+	var err error
+	if sC.deactivateUsers {
+		log.Info("Deactivate scim user")
+		patch := &PatchRequest{
+			Schemas:    []string{SchemaPatchOp},
+			Operations: []PatchOperation{{Op: "replace", Path: "active", Value: false}},
+		}
+		_, err = sC.sendRequest(ctx, "/Users/"+url.PathEscape(userID), http.MethodPatch, patch,
+			"backend.scim.DeleteUser")
+	} else {
+		log.Info("Delete scim user")
+		_, err = sC.sendRequest(ctx, "/Users/"+url.PathEscape(userID), http.MethodDelete, nil,
+			"backend.scim.DeleteUser")
+	}
+	if request.IsNotFound(err) {
+		log.Warn("scim user not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// userDetails converts the SCIM user, the SCIM userName is the username of the user
+func userDetails(u *User) *structs.User {
+	user := &structs.User{
+		ID:          u.ID,
+		UserName:    u.UserName,
+		Email:       u.primaryEmail(),
+		DisplayName: u.DisplayName,
+	}
+	if u.Name != nil {
+		user.FirstName = u.Name.GivenName
+		user.LastName = u.Name.FamilyName
+	}
+	// services using the email as userName may not return the emails
+	if user.Email == "" && strings.Contains(u.UserName, "@") {
+		user.Email = u.UserName
+	}
+	return user
+}
+
+// listAll fetches all the pages of the resources of the path, SCIM pages are indexed from 1
+func listAll[T any](ctx context.Context, sC *SCIMClient, path string, methodName string) ([]T, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	var resources []T
+	for startIndex := 1; ; {
+		var page ListResponse[T]
+		pagePath := fmt.Sprintf("%s%sstartIndex=%d&count=%d", path, separator, startIndex, sC.pageSize)
+		if err := sC.get(ctx, pagePath, &page, methodName); err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Resources...)
+
+		// services may return fewer resources than requested, the listing ends with an empty page
+		// or once all the results are read
+		if len(page.Resources) == 0 || len(resources) >= page.TotalResults {
+			return resources, nil
+		}
+		startIndex += len(page.Resources)
+	}
+}
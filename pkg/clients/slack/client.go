@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// SlackClient manages the user groups of a Slack workspace and their members through the Web API
+type SlackClient struct {
+	client  heimdall.Doer
+	url     string
+	headers map[string]string
+	teamID  string
+}
+
+func NewClient(slackAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*SlackClient, error) {
+
+	slackConfig := SlackConfig{}
+	if err := utils.MapToStruct(slackAppConfig, &slackConfig); err != nil {
+		return nil, err
+	}
+	if slackConfig.Token == "" {
+		return nil, errors.New("slack configuration is missing required field: token")
+	}
+	baseURL := strings.TrimSuffix(slackConfig.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	client, err := httpclient.InitializeClient(
+		"slack_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &SlackClient{
+		client: client,
+		url:    baseURL,
+		headers: map[string]string{
+			constants.ContentTypeHeaderKey: "application/x-www-form-urlencoded",
+			"Accept":                       "application/json",
+			"Authorization":                "Bearer " + slackConfig.Token,
+		},
+		teamID: slackConfig.TeamID,
+	}, nil
+}
+
+// HealthCheck tests the token, the Web API is healthy when it accepts it
+func (sC *SlackClient) HealthCheck(ctx context.Context) error {
+	var resp response
+	if err := sC.call(ctx, "auth.test", url.Values{}, &resp, "backend.slack.HealthCheck"); err != nil {
+		return fmt.Errorf("slack health check failed: %w", err)
+	}
+	return nil
+}
+
+// call calls the method of the Web API with the params and decodes its response, the errors of the
+// method and the responses with an error code are returned as a *ResponseError. The params are
+// sent as a form, which all the methods accept.
+func (sC *SlackClient) call(ctx context.Context, apiMethod string, params url.Values, result envelope,
+	methodName string) error {
+
+	if sC.teamID != "" && strings.HasPrefix(apiMethod, "usergroups.") {
+		params.Set("team_id", sC.teamID)
+	}
+
+	resp, _, err := request.Send(ctx, sC.client, http.MethodPost, sC.url+"/"+apiMethod, []byte(params.Encode()),
+		sC.headers, methodName, "slack", nil)
+	var httpErr *request.ResponseError
+	if errors.As(err, &httpErr) {
+		respErr := &ResponseError{Method: apiMethod, StatusCode: httpErr.StatusCode, Code: strings.TrimSpace(httpErr.Message)}
+		if httpErr.StatusCode == http.StatusTooManyRequests {
+			respErr.Code = "ratelimited"
+			respErr.RetryAfter = httpErr.Header.Get("Retry-After")
+		}
+		return respErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to decode slack response: %w", err)
+	}
+	if code := result.apiError(); code != "" {
+		return &ResponseError{Method: apiMethod, StatusCode: http.StatusOK, Code: code}
+	}
+	return nil
+}
+
+// ResponseError is an error of a Web API method, which Slack mostly reports in the body of a 200
+// response rather than with a response code
+type ResponseError struct {
+	Method     string
+	StatusCode int
+	// Code is the error of the method, e.g. no_such_subteam
+	Code string
+	// RetryAfter is the number of seconds to wait before calling the method again when it is rate
+	// limited
+	RetryAfter string
+}
+
+func (e *ResponseError) Error() string {
+	if e.RetryAfter != "" {
+		return fmt.Sprintf("slack method %s failed with response code %d: %s, retry after %ss",
+			e.Method, e.StatusCode, e.Code, e.RetryAfter)
+	}
+	return fmt.Sprintf("slack method %s failed with response code %d: %s", e.Method, e.StatusCode, e.Code)
+}
+
+// hasCode reports whether the error is a Web API error with one of the codes
+func hasCode(err error, codes ...string) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	for _, code := range codes {
+		if respErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
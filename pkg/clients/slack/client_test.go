@@ -0,0 +1,289 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// fakeSlack is a Slack workspace holding its users and user groups in memory
+type fakeSlack struct {
+	users   []User
+	groups  []UserGroup
+	members map[string][]string
+	// ratelimited makes the next call fail with a 429
+	ratelimited bool
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.ratelimited {
+		f.ratelimited = false
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer xoxb-token" {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+		return
+	}
+	_ = r.ParseForm()
+
+	switch strings.TrimPrefix(r.URL.Path, "/api/") {
+	case "auth.test":
+		f.reply(w, map[string]any{})
+	case "users.list":
+		// a page of a single user, with the cursor of the next one
+		cursor, _ := strconv.Atoi(r.Form.Get("cursor"))
+		next := ""
+		if cursor+1 < len(f.users) {
+			next = strconv.Itoa(cursor + 1)
+		}
+		f.reply(w, map[string]any{
+			"members":           f.users[cursor : cursor+1],
+			"response_metadata": map[string]any{"next_cursor": next},
+		})
+	case "users.lookupByEmail":
+		for _, u := range f.users {
+			if u.Profile.Email == r.Form.Get("email") {
+				f.reply(w, map[string]any{"user": u})
+				return
+			}
+		}
+		f.fail(w, "users_not_found")
+	case "usergroups.list":
+		groups := []UserGroup{}
+		for _, g := range f.groups {
+			if !g.disabled() || r.Form.Get("include_disabled") == "true" {
+				groups = append(groups, g)
+			}
+		}
+		f.reply(w, map[string]any{"usergroups": groups})
+	case "usergroups.create":
+		for _, g := range f.groups {
+			if g.Name == r.Form.Get("name") {
+				f.fail(w, "name_already_exists")
+				return
+			}
+		}
+		group := UserGroup{ID: fmt.Sprintf("S%d", len(f.groups)+1), Name: r.Form.Get("name"),
+			Handle: r.Form.Get("handle"), Description: r.Form.Get("description")}
+		f.groups = append(f.groups, group)
+		f.reply(w, map[string]any{"usergroup": group})
+	case "usergroups.update":
+		group := f.group(r.Form.Get("usergroup"))
+		group.Handle = r.Form.Get("handle")
+		f.reply(w, map[string]any{"usergroup": group})
+	case "usergroups.enable", "usergroups.disable":
+		group := f.group(r.Form.Get("usergroup"))
+		if group == nil {
+			f.fail(w, "no_such_subteam")
+			return
+		}
+		group.DateDelete = 0
+		if strings.HasSuffix(r.URL.Path, "disable") {
+			group.DateDelete = 1700000000
+		}
+		f.reply(w, map[string]any{"usergroup": group})
+	case "usergroups.users.list":
+		f.reply(w, map[string]any{"users": f.members[r.Form.Get("usergroup")]})
+	case "usergroups.users.update":
+		if r.Form.Get("users") == "" {
+			f.fail(w, "invalid_users")
+			return
+		}
+		f.members[r.Form.Get("usergroup")] = strings.Split(r.Form.Get("users"), ",")
+		f.reply(w, map[string]any{"usergroup": f.group(r.Form.Get("usergroup"))})
+	default:
+		f.fail(w, "unknown_method")
+	}
+}
+
+func (f *fakeSlack) group(id string) *UserGroup {
+	for i := range f.groups {
+		if f.groups[i].ID == id {
+			return &f.groups[i]
+		}
+	}
+	return nil
+}
+
+func (f *fakeSlack) reply(w http.ResponseWriter, body map[string]any) {
+	body["ok"] = true
+	fakehttp.WriteJSON(w, http.StatusOK, body)
+}
+
+func (f *fakeSlack) fail(w http.ResponseWriter, code string) {
+	fakehttp.WriteJSON(w, http.StatusOK, map[string]any{"ok": false, "error": code})
+}
+
+func newTestClient(t *testing.T, fake *fakeSlack) *SlackClient {
+	t.Helper()
+	if fake.members == nil {
+		fake.members = map[string][]string{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	client, err := NewClient(map[string]interface{}{"token": "xoxb-token", "base_url": server.URL + "/api/"},
+		fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"base_url": "https://slack.com/api"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "missing required field: token")
+}
+
+func TestHandleFor(t *testing.T) {
+	assert.Equal(t, "data-platform", handleFor("Data Platform"))
+	assert.Equal(t, "team_a.ops", handleFor("team_a.ops"))
+	assert.Equal(t, "a-very-long-team-name", handleFor("a very long team name for slack"))
+}
+
+func TestFetchAllUsersPages(t *testing.T) {
+	fake := &fakeSlack{users: []User{
+		{ID: "U1", Name: "jdoe", Profile: UserProfile{Email: "jdoe@example.com", FirstName: "John", LastName: "Doe",
+			RealName: "John Doe"}},
+		{ID: "U2", Name: "gone", Deleted: true, Profile: UserProfile{Email: "gone@example.com"}},
+		{ID: "B1", Name: "bot", IsBot: true},
+		{ID: "U3", Name: "bob", Profile: UserProfile{Email: "bob@example.com"}},
+	}}
+	client := newTestClient(t, fake)
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+	assert.Equal(t, "U3", byEmail["bob@example.com"].ID)
+	assert.Equal(t, "John Doe", byID["U1"].DisplayName)
+}
+
+func TestCreateUser(t *testing.T) {
+	fake := &fakeSlack{users: []User{
+		{ID: "U1", Name: "jdoe", Profile: UserProfile{Email: "jdoe@example.com"}},
+		{ID: "U2", Name: "gone", Deleted: true, Profile: UserProfile{Email: "gone@example.com"}},
+	}}
+	client := newTestClient(t, fake)
+
+	user, err := client.CreateUser(context.Background(), &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "U1", user.ID)
+
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "new", Email: "new@example.com"})
+	assert.ErrorContains(t, err, "slack user new@example.com not found")
+	_, err = client.CreateUser(context.Background(), &structs.User{UserName: "gone", Email: "gone@example.com"})
+	assert.ErrorContains(t, err, "deactivated")
+
+	assert.NoError(t, client.DeleteUser(context.Background(), "U1"))
+}
+
+func TestUserGroups(t *testing.T) {
+	fake := &fakeSlack{groups: []UserGroup{
+		{ID: "S1", Name: "team-a", Handle: "team-a", Description: "A"},
+		{ID: "S2", Name: "team-b", Handle: "team-b", DateDelete: 1700000000},
+	}}
+	client := newTestClient(t, fake)
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"team-a": {ID: "S1", Name: "team-a", Description: "A"}}, teams)
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "Team C", Description: "C"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "S3", Name: "Team C", Description: "C"}, team)
+	assert.Equal(t, "team-c", fake.groups[2].Handle)
+
+	// the disabled user group of the same name is enabled again
+	team, err = client.CreateTeam(context.Background(), &structs.Team{Name: "team-b"})
+	require.NoError(t, err)
+	assert.Equal(t, "S2", team.ID)
+	assert.False(t, fake.groups[1].disabled())
+
+	_, err = client.CreateTeam(context.Background(), &structs.Team{Name: "team-a"})
+	assert.ErrorContains(t, err, "already exists")
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "S1"))
+	assert.True(t, fake.groups[0].disabled())
+	assert.NoError(t, client.DeleteTeamByID(context.Background(), "S9"))
+}
+
+func TestMembership(t *testing.T) {
+	fake := &fakeSlack{
+		groups:  []UserGroup{{ID: "S1", Name: "team-a"}, {ID: "S2", Name: "team-b", DateDelete: 1700000000}},
+		members: map[string][]string{"S1": {"U1"}, "S2": {"U8", "U9"}},
+	}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "S1", []string{"U2", "U1", "U3"}))
+	assert.Equal(t, []string{"U1", "U2", "U3"}, fake.members["S1"])
+
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "S1", []string{"U2", "U4"}))
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "S1")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Contains(t, members, "U3")
+
+	// the user group is disabled rather than emptied
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "S1", []string{"U1", "U3"}))
+	assert.True(t, fake.groups[0].disabled())
+	members, err = client.FetchTeamMembersByTeamID(context.Background(), "S1")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	// a disabled user group is enabled with only the added users
+	require.NoError(t, client.AddUserToTeam(context.Background(), "S2", []string{"U1"}))
+	assert.False(t, fake.groups[1].disabled())
+	assert.Equal(t, []string{"U1"}, fake.members["S2"])
+}
+
+func TestReconcileHandle(t *testing.T) {
+	fake := &fakeSlack{groups: []UserGroup{{ID: "S1", Name: "team-a", Handle: "team-a"}}}
+	client := newTestClient(t, fake)
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "S1", structs.TeamParams{
+		Property: "handle", Value: []string{"data-oncall"},
+	}))
+	assert.Equal(t, "data-oncall", fake.groups[0].Handle)
+
+	assert.ErrorContains(t, client.ReconcileGroupParams(context.Background(), "S1", structs.TeamParams{
+		Property: "channels", Value: []string{"C1"},
+	}), "unsupported slack group param")
+}
+
+func TestHealthCheck(t *testing.T) {
+	fake := &fakeSlack{}
+	client := newTestClient(t, fake)
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	fake.ratelimited = true
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "ratelimited, retry after 30s")
+
+	client.headers["Authorization"] = "Bearer wrong"
+	assert.ErrorContains(t, client.HealthCheck(context.Background()), "invalid_auth")
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID lists the members of the user group by ID, keyed by ID. A disabled user
+// group has no members.
+func (sC *SlackClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.FetchTeamMembersByTeamID")
+	defer span.Finish()
+
+	group, err := sC.fetchUserGroup(ctx, teamID, "backend.slack.FetchTeamMembersByTeamID")
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch slack user group")
+		return nil, err
+	}
+	members := make(map[string]*structs.User)
+	if group.disabled() {
+		return members, nil
+	}
+
+	userIDs, err := sC.listMembers(ctx, teamID, "backend.slack.FetchTeamMembersByTeamID")
+	if err != nil {
+		logger.Logger(ctx).WithField("teamID", teamID).WithError(err).Error("failed to fetch slack user group members")
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		members[userID] = &structs.User{ID: userID}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the user group. The Web API replaces the whole member list, so
+// the users are added to the current members. A disabled user group is enabled with the users as
+// its only members.
+func (sC *SlackClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.AddUserToTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "slack")
+
+	group, err := sC.fetchUserGroup(ctx, teamID, "backend.slack.AddUserToTeam")
+	if err != nil {
+		return err
+	}
+
+	var members []string
+	if group.disabled() {
+		log.Info("Enable disabled slack user group")
+		var resp userGroupResponse
+		if err := sC.call(ctx, "usergroups.enable", url.Values{"usergroup": {teamID}}, &resp,
+			"backend.slack.AddUserToTeam"); err != nil {
+			return fmt.Errorf("failed to enable slack user group %s: %w", teamID, err)
+		}
+	} else {
+		members, err = sC.listMembers(ctx, teamID, "backend.slack.AddUserToTeam")
+		if err != nil {
+			return err
+		}
+	}
+	for _, userID := range userIDs {
+		if !slices.Contains(members, userID) {
+			members = append(members, userID)
+		}
+	}
+
+	log.WithField("users", userIDs).Info("Add users to slack user group")
+	if err := sC.updateMembers(ctx, teamID, members, "backend.slack.AddUserToTeam"); err != nil {
+		return fmt.Errorf("failed to add users to slack user group %s: %w", teamID, err)
+	}
+	return nil
+}
+
+// RemoveUserFromTeam sets the users of the user group to its members without the users, nothing is
+// updated when none of them is a member. Slack does not allow empty user groups, so the user group
+// is disabled when its last members are removed.
+func (sC *SlackClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.RemoveUserFromTeam")
+	defer span.Finish()
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "slack")
+
+	group, err := sC.fetchUserGroup(ctx, teamID, "backend.slack.RemoveUserFromTeam")
+	if err != nil {
+		return err
+	}
+	if group.disabled() {
+		return nil
+	}
+
+	members, err := sC.listMembers(ctx, teamID, "backend.slack.RemoveUserFromTeam")
+	if err != nil {
+		return err
+	}
+	remaining := slices.DeleteFunc(slices.Clone(members), func(userID string) bool {
+		return slices.Contains(userIDs, userID)
+	})
+	if len(remaining) == len(members) {
+		return nil
+	}
+
+	if len(remaining) == 0 {
+		log.Info("Disable slack user group, user groups cannot be emptied")
+		if err := sC.disableUserGroup(ctx, teamID, "backend.slack.RemoveUserFromTeam"); err != nil {
+			return fmt.Errorf("failed to disable slack user group %s: %w", teamID, err)
+		}
+		return nil
+	}
+
+	log.WithField("users", userIDs).Info("Remove users from slack user group")
+	if err := sC.updateMembers(ctx, teamID, remaining, "backend.slack.RemoveUserFromTeam"); err != nil {
+		return fmt.Errorf("failed to remove users from slack user group %s: %w", teamID, err)
+	}
+	return nil
+}
+
+// listMembers lists the IDs of the members of the user group
+func (sC *SlackClient) listMembers(ctx context.Context, teamID, methodName string) ([]string, error) {
+	var resp userGroupUsersResponse
+	if err := sC.call(ctx, "usergroups.users.list", url.Values{"usergroup": {teamID}, "include_disabled": {"true"}},
+		&resp, methodName); err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// updateMembers replaces the members of the user group
+func (sC *SlackClient) updateMembers(ctx context.Context, teamID string, userIDs []string, methodName string) error {
+	var resp userGroupResponse
+	return sC.call(ctx, "usergroups.users.update",
+		url.Values{"usergroup": {teamID}, "users": {strings.Join(userIDs, ",")}}, &resp, methodName)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// groupParamHandle is the group param property overriding the @handle of the user group
+const groupParamHandle = "handle"
+
+// FetchAllTeams lists the enabled user groups of the workspace, keyed by name
+func (sC *SlackClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.FetchAllTeams")
+	defer span.Finish()
+
+	teams := make(map[string]structs.Team)
+	err := sC.ForEachTeamPage(ctx, func(page []structs.Team) error {
+		for _, team := range page {
+			teams[team.Name] = team
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch slack user groups")
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ForEachTeamPage calls fn with the enabled user groups of the workspace, usergroups.list is not
+// paginated so they come in a single page
+func (sC *SlackClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	groups, err := sC.listUserGroups(ctx, false, "backend.slack.FetchAllTeams")
+	if err != nil {
+		return err
+	}
+	page := make([]structs.Team, 0, len(groups))
+	for _, group := range groups {
+		page = append(page, teamDetails(&group))
+	}
+	return fn(page)
+}
+
+// FetchTeamDetails fetches the user group by ID, the disabled user groups included
+func (sC *SlackClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.FetchTeamDetails")
+	defer span.Finish()
+
+	group, err := sC.fetchUserGroup(ctx, teamID, "backend.slack.FetchTeamDetails")
+	if err != nil {
+		return nil, err
+	}
+	team := teamDetails(group)
+	return &team, nil
+}
+
+// CreateTeam creates the user group with a handle derived from the name of the team. Slack cannot
+// delete user groups, so the disabled user group of the same name is enabled again instead.
+func (sC *SlackClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.CreateTeam")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("team", team.Name).WithField("service", "slack")
+	log.Info("Create slack user group")
+
+	params := url.Values{"name": {team.Name}, "handle": {handleFor(team.Name)}}
+	if team.Description != "" {
+		params.Set("description", team.Description)
+	}
+	var resp userGroupResponse
+	err := sC.call(ctx, "usergroups.create", params, &resp, "backend.slack.CreateTeam")
+	if hasCode(err, "name_already_exists") {
+		return sC.enableUserGroup(ctx, team.Name)
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to create slack user group")
+		return nil, err
+	}
+	details := teamDetails(&resp.UserGroup)
+	return &details, nil
+}
+
+// enableUserGroup enables the disabled user group of the name
+func (sC *SlackClient) enableUserGroup(ctx context.Context, name string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithField("team", name).WithField("service", "slack")
+
+	groups, err := sC.listUserGroups(ctx, true, "backend.slack.CreateTeam")
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.Name != name {
+			continue
+		}
+		if !group.disabled() {
+			return nil, fmt.Errorf("slack user group %s already exists", name)
+		}
+		log.WithField("teamID", group.ID).Info("Enable disabled slack user group")
+		var resp userGroupResponse
+		if err := sC.call(ctx, "usergroups.enable", url.Values{"usergroup": {group.ID}}, &resp,
+			"backend.slack.CreateTeam"); err != nil {
+			return nil, err
+		}
+		details := teamDetails(&resp.UserGroup)
+		return &details, nil
+	}
+	return nil, fmt.Errorf("slack user group %s already exists but was not found", name)
+}
+
+// DeleteTeamByID disables the user group, Slack cannot delete user groups. A user group which does
+// not exist is considered deleted.
+func (sC *SlackClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.DeleteTeamByID")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("teamID", teamID).WithField("service", "slack")
+	log.Info("Disable slack user group")
+
+	err := sC.disableUserGroup(ctx, teamID, "backend.slack.DeleteTeamByID")
+	if hasCode(err, "no_such_subteam") {
+		log.Warn("slack user group not found, considering deletion successful")
+		return nil
+	}
+	return err
+}
+
+// disableUserGroup disables the user group, a user group which is already disabled stays so
+func (sC *SlackClient) disableUserGroup(ctx context.Context, teamID, methodName string) error {
+	var resp userGroupResponse
+	err := sC.call(ctx, "usergroups.disable", url.Values{"usergroup": {teamID}}, &resp, methodName)
+	if hasCode(err, "already_disabled") {
+		return nil
+	}
+	return err
+}
+
+// ReconcileGroupParams sets the @handle of the user group to the handle group param
+func (sC *SlackClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.ReconcileGroupParams")
+	defer span.Finish()
+
+	if groupParams.Property != groupParamHandle {
+		return fmt.Errorf("unsupported slack group param: %s", groupParams.Property)
+	}
+	if len(groupParams.Value) != 1 {
+		return fmt.Errorf("slack group param %s takes a single handle", groupParamHandle)
+	}
+	handle := groupParams.Value[0]
+
+	group, err := sC.fetchUserGroup(ctx, teamID, "backend.slack.ReconcileGroupParams")
+	if err != nil {
+		return err
+	}
+	if group.Handle == handle {
+		return nil
+	}
+
+	logger.Logger(ctx).WithField("teamID", teamID).WithField("handle", handle).WithField("service", "slack").
+		Info("Update slack user group handle")
+	var resp userGroupResponse
+	return sC.call(ctx, "usergroups.update", url.Values{"usergroup": {teamID}, "handle": {handle}}, &resp,
+		"backend.slack.ReconcileGroupParams")
+}
+
+// listUserGroups lists the user groups of the workspace, with the disabled ones when includeDisabled
+func (sC *SlackClient) listUserGroups(ctx context.Context, includeDisabled bool,
+	methodName string) ([]UserGroup, error) {
+	params := url.Values{"include_disabled": {fmt.Sprint(includeDisabled)}}
+	var resp userGroupsResponse
+	if err := sC.call(ctx, "usergroups.list", params, &resp, methodName); err != nil {
+		return nil, err
+	}
+	return resp.UserGroups, nil
+}
+
+// fetchUserGroup finds the user group by ID, the Web API has no method fetching a single user group
+func (sC *SlackClient) fetchUserGroup(ctx context.Context, teamID, methodName string) (*UserGroup, error) {
+	groups, err := sC.listUserGroups(ctx, true, methodName)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.ID == teamID {
+			return &group, nil
+		}
+	}
+	return nil, &ResponseError{Method: "usergroups.list", StatusCode: http.StatusOK, Code: "no_such_subteam"}
+}
+
+// handleFor derives the @handle of the user group from its name: lower case, with dashes for the
+// other characters, at most 21 characters long
+func handleFor(name string) string {
+	handle := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return unicode.ToLower(r)
+		default:
+			return '-'
+		}
+	}, strings.TrimSpace(name))
+	if len(handle) > maxHandleLength {
+		handle = handle[:maxHandleLength]
+	}
+	return strings.Trim(handle, "-")
+}
+
+// teamDetails converts the Slack user group
+func teamDetails(g *UserGroup) structs.Team {
+	return structs.Team{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+const (
+	// defaultBaseURL is the Slack Web API
+	defaultBaseURL = "https://slack.com/api"
+	// pageSize is the number of users requested per page of users.list
+	pageSize = 200
+	// maxHandleLength is the longest handle of a user group
+	maxHandleLength = 21
+)
+
+// SlackConfig is the connection of a Slack backend, read from the backend configuration
+type SlackConfig struct {
+	// Token is a user or bot token with the usergroups:read, usergroups:write, users:read and
+	// users:read.email scopes
+	Token string `json:"token"`
+	// BaseURL is the Web API of Slack, https://slack.com/api by default
+	BaseURL string `json:"base_url"`
+	// TeamID is the workspace of the user groups, required with the org tokens of Enterprise Grid
+	TeamID string `json:"team_id"`
+}
+
+// User is a member of the workspace
+type User struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Deleted bool        `json:"deleted"`
+	IsBot   bool        `json:"is_bot"`
+	Profile UserProfile `json:"profile"`
+}
+
+// UserProfile is the profile of a Slack user
+type UserProfile struct {
+	Email       string `json:"email"`
+	RealName    string `json:"real_name"`
+	DisplayName string `json:"display_name"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+}
+
+// UserGroup is a user group of the workspace, mentioned by its @handle. The disabled user groups
+// have a deletion date.
+type UserGroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Handle      string `json:"handle"`
+	Description string `json:"description"`
+	DateDelete  int64  `json:"date_delete"`
+}
+
+// disabled reports whether the user group is disabled
+func (g *UserGroup) disabled() bool {
+	return g.DateDelete != 0
+}
+
+// response is the envelope of the Web API responses, the errors are reported with a 200
+type response struct {
+	OK               bool   `json:"ok"`
+	Error            string `json:"error"`
+	ResponseMetadata struct {
+		NextCursor string   `json:"next_cursor"`
+		Messages   []string `json:"messages"`
+	} `json:"response_metadata"`
+}
+
+type usersListResponse struct {
+	response
+	Members []User `json:"members"`
+}
+
+type userResponse struct {
+	response
+	User User `json:"user"`
+}
+
+type userGroupsResponse struct {
+	response
+	UserGroups []UserGroup `json:"usergroups"`
+}
+
+type userGroupResponse struct {
+	response
+	UserGroup UserGroup `json:"usergroup"`
+}
+
+type userGroupUsersResponse struct {
+	response
+	Users []string `json:"users"`
+}
+
+// envelope is implemented by the responses of the Web API, it returns the error of the response
+type envelope interface {
+	apiError() string
+}
+
+func (r *response) apiError() string {
+	if r.OK {
+		return ""
+	}
+	if r.Error == "" {
+		return "unknown_error"
+	}
+	return r.Error
+}
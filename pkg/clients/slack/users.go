@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchAllUsers lists the members of the workspace, keyed by ID and by email
+func (sC *SlackClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.FetchAllUsers")
+	defer span.Finish()
+
+	usersByID := make(map[string]*structs.User)
+	usersByEmail := make(map[string]*structs.User)
+	err := sC.ForEachUserPage(ctx, func(users []*structs.User) error {
+		for _, user := range users {
+			usersByID[user.ID] = user
+			if user.Email != "" {
+				usersByEmail[user.Email] = user
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger(ctx).WithError(err).Error("failed to fetch slack users")
+		return nil, nil, err
+	}
+	return usersByID, usersByEmail, nil
+}
+
+// ForEachUserPage calls fn with each page of the members of the workspace, 200 members at a time.
+// The deactivated users and the bots are skipped.
+func (sC *SlackClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	cursor := ""
+	for {
+		params := url.Values{"limit": {strconv.Itoa(pageSize)}}
+		if sC.teamID != "" {
+			params.Set("team_id", sC.teamID)
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		var resp usersListResponse
+		if err := sC.call(ctx, "users.list", params, &resp, "backend.slack.FetchAllUsers"); err != nil {
+			return err
+		}
+
+		page := make([]*structs.User, 0, len(resp.Members))
+		for _, u := range resp.Members {
+			if u.Deleted || u.IsBot {
+				continue
+			}
+			page = append(page, userDetails(&u))
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		cursor = resp.ResponseMetadata.NextCursor
+		if cursor == "" {
+			return nil
+		}
+	}
+}
+
+// FetchUserDetails fetches the member of the workspace by ID
+func (sC *SlackClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.FetchUserDetails")
+	defer span.Finish()
+
+	var resp userResponse
+	if err := sC.call(ctx, "users.info", url.Values{"user": {userID}}, &resp,
+		"backend.slack.FetchUserDetails"); err != nil {
+		return nil, err
+	}
+	return userDetails(&resp.User), nil
+}
+
+// CreateUser looks the member of the workspace up by email, the members join the workspace through
+// its SSO or an invitation and cannot be created through the Web API
+func (sC *SlackClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.slack.CreateUser")
+	defer span.Finish()
+
+	log := logger.Logger(ctx).WithField("email", u.Email).WithField("service", "slack")
+	if u.Email == "" {
+		return nil, fmt.Errorf("slack users are looked up by email, user %s has none", u.UserName)
+	}
+
+	var resp userResponse
+	err := sC.call(ctx, "users.lookupByEmail", url.Values{"email": {u.Email}}, &resp, "backend.slack.CreateUser")
+	if hasCode(err, "users_not_found") {
+		return nil, fmt.Errorf("slack user %s not found, the user must join the workspace first", u.Email)
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to look up slack user")
+		return nil, err
+	}
+	if resp.User.Deleted {
+		return nil, fmt.Errorf("slack user %s is deactivated", u.Email)
+	}
+	return userDetails(&resp.User), nil
+}
+
+// DeleteUser does not deactivate the member, the members of the workspace are managed by its SSO.
+// The member is removed from the user groups by the reconciliation of their teams.
+func (sC *SlackClient) DeleteUser(ctx context.Context, userID string) error {
+	logger.Logger(ctx).WithField("userID", userID).WithField("service", "slack").
+		Info("slack members are deactivated through the workspace SSO, skipping user deletion")
+	return nil
+}
+
+// userDetails converts the Slack user
+func userDetails(u *User) *structs.User {
+	return &structs.User{
+		ID:          u.ID,
+		UserName:    u.Name,
+		Email:       u.Profile.Email,
+		FirstName:   u.Profile.FirstName,
+		LastName:    u.Profile.LastName,
+		DisplayName: u.Profile.RealName,
+	}
+}
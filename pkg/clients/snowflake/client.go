@@ -43,14 +43,30 @@ func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionP
 	// Extract connection parameters
 	pat, _ := connection["pat"].(string)
 	baseURL, _ := connection["base_url"].(string)
+	disableOnDelete, _ := connection["disable_on_delete"].(bool)
+	disabledTag, _ := connection["disabled_tag"].(string)
+	defaultRole, _ := connection["default_role"].(string)
+	defaultWarehouse, _ := connection["default_warehouse"].(string)
+	defaultNamespace, _ := connection["default_namespace"].(string)
+	networkPolicyTag, _ := connection["network_policy_tag"].(string)
 
 	if pat == "" || baseURL == "" {
 		return nil, errors.New("missing required connection parameters for snowflake backend: pat and base_url are required")
 	}
+	if disableOnDelete && disabledTag == "" {
+		return nil, errors.New("missing required connection parameter for snowflake backend: " +
+			"disabled_tag is required with disable_on_delete")
+	}
 
 	config := SnowflakeConfig{
-		PAT:     pat,
-		BaseURL: baseURL,
+		PAT:              pat,
+		BaseURL:          baseURL,
+		DisableOnDelete:  disableOnDelete,
+		DisabledTag:      disabledTag,
+		DefaultRole:      defaultRole,
+		DefaultWarehouse: defaultWarehouse,
+		DefaultNamespace: defaultNamespace,
+		NetworkPolicyTag: networkPolicyTag,
 	}
 	client, err := httpclient.InitializeClient(
 		"snowflake",
@@ -69,6 +85,18 @@ func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionP
 	}, nil
 }
 
+// HealthCheck lists a single role to check that the API accepts the PAT
+func (c *SnowflakeClient) HealthCheck(ctx context.Context) error {
+	_, _, status, err := c.makeRequestWithHeader(ctx, "/api/v2/roles?showLimit=1", http.MethodGet, nil)
+	if err != nil {
+		return fmt.Errorf("snowflake health check failed: %w", err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("snowflake health check failed with status: %s", http.StatusText(status))
+	}
+	return nil
+}
+
 // prepareRequest creates and configures a request with common Snowflake headers
 func (c *SnowflakeClient) prepareRequest(ctx context.Context, endpoint, method string,
 	body interface{}) (request.IRequester, error) {
@@ -0,0 +1,255 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fake/fakehttp"
+)
+
+var (
+	setTagStatement             = regexp.MustCompile(`^ALTER USER (\S+) SET TAG (\S+) = '(.*)'$`)
+	unsetTagStatement           = regexp.MustCompile(`^ALTER USER (\S+) UNSET TAG (\S+)$`)
+	disabledStatement           = regexp.MustCompile(`^ALTER USER (\S+) SET DISABLED = (TRUE|FALSE)$`)
+	setNetworkPolicyStatement   = regexp.MustCompile(`^ALTER USER (\S+) SET NETWORK_POLICY = (\S+)$`)
+	unsetNetworkPolicyStatement = regexp.MustCompile(`^ALTER USER (\S+) UNSET NETWORK_POLICY$`)
+	getTagCall                  = regexp.MustCompile(`SYSTEM\$GET_TAG\('([^']*)', '([^']*)', 'USER'\)`)
+)
+
+// fakeSnowflake is a Snowflake account holding its users, their tags and the grants of the roles in
+// memory. It records the bodies of the users created and the statements of each request of the SQL API.
+type fakeSnowflake struct {
+	users map[string]SnowflakeUser
+	// tags are the values of each tag by user
+	tags     map[string]map[string]string
+	disabled map[string]bool
+	// networkPolicies are the network policies of the users
+	networkPolicies map[string]string
+	// grants are the users granted each role
+	grants map[string][]string
+	// listPageSize splits the users listed by a request in pages of the results endpoint when set
+	listPageSize int
+	// failListing is the request listing the users the account fails, counted from 1 across the
+	// users endpoint and the results endpoint, along with the requests after it
+	failListing int
+
+	listings int
+	results  map[string][]SnowflakeUser
+
+	created    []map[string]interface{}
+	statements [][]string
+	// failStatement is a statement the SQL API fails, along with the statements after it
+	failStatement string
+}
+
+func (f *fakeSnowflake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer pat" {
+		fakehttp.WriteJSON(w, http.StatusUnauthorized, map[string]string{"code": "390303", "message": "Invalid PAT"})
+		return
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(r.URL.Path, "/api/v2/users/"))
+	switch {
+	case r.Method == http.MethodGet &&
+		(r.URL.Path == "/api/v2/users" || strings.HasPrefix(r.URL.Path, "/api/v2/results/")):
+		f.listUsers(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/users":
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		f.created = append(f.created, payload)
+		userName, _ := payload["name"].(string)
+		email, _ := payload["email"].(string)
+		if _, exists := f.users[strings.ToLower(userName)]; exists {
+			fakehttp.WriteJSON(w, http.StatusConflict, map[string]string{"message": "User already exists"})
+			return
+		}
+		user := SnowflakeUser{Name: strings.ToUpper(userName), Email: email}
+		f.users[strings.ToLower(userName)] = user
+		fakehttp.WriteJSON(w, http.StatusCreated, user)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/users/"):
+		user, ok := f.users[name]
+		if !ok {
+			fakehttp.WriteJSON(w, http.StatusNotFound, map[string]string{"message": "User does not exist"})
+			return
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, user)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/users/"):
+		delete(f.users, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/roles/") &&
+		strings.HasSuffix(r.URL.Path, "/grants-of"):
+		role := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2/roles/"), "/grants-of")
+		grants := []SnowflakeGrant{}
+		for _, user := range f.grants[role] {
+			grants = append(grants, SnowflakeGrant{GrantedTo: "USER", GranteeName: strings.ToUpper(user)})
+		}
+		fakehttp.WriteJSON(w, http.StatusOK, grants)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/roles/"):
+		delete(f.grants, strings.TrimPrefix(r.URL.Path, "/api/v2/roles/"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && r.URL.Path == sqlStatementsEndpoint:
+		f.runStatements(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// listUsers lists the users in the order of their names from the name following fromName, at most
+// showLimit of them. The users past the first page of listPageSize users are served by the results
+// endpoint, linked from each page to the next one.
+func (f *fakeSnowflake) listUsers(w http.ResponseWriter, r *http.Request) {
+	f.listings++
+	if f.failListing > 0 && f.listings >= f.failListing {
+		fakehttp.WriteJSON(w, http.StatusBadRequest, map[string]string{"message": "Result not found"})
+		return
+	}
+
+	var listed []SnowflakeUser
+	if resultID, ok := strings.CutPrefix(r.URL.Path, "/api/v2/results/"); ok {
+		listed = f.results[resultID]
+	} else {
+		names := slices.Sorted(maps.Keys(f.users))
+		fromName := strings.ToLower(r.URL.Query().Get("fromName"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("showLimit"))
+		for _, name := range names {
+			if name > fromName && (limit == 0 || len(listed) < limit) {
+				listed = append(listed, f.users[name])
+			}
+		}
+	}
+
+	if f.listPageSize > 0 && len(listed) > f.listPageSize {
+		if f.results == nil {
+			f.results = map[string][]SnowflakeUser{}
+		}
+		resultID := strconv.Itoa(f.listings)
+		f.results[resultID] = listed[f.listPageSize:]
+		w.Header().Set("Link", fmt.Sprintf(`</api/v2/results/%s>; rel="next"`, resultID))
+		listed = listed[:f.listPageSize]
+	}
+	fakehttp.WriteJSON(w, http.StatusOK, listed)
+}
+
+// runStatements runs the statements of a SQL API request in order, until the failing statement
+func (f *fakeSnowflake) runStatements(w http.ResponseWriter, r *http.Request) {
+	var body sqlStatementRequest
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	statements := strings.Split(body.Statement, ";\n")
+	f.statements = append(f.statements, statements)
+
+	// Snowflake rejects the requests whose statement count differs from MULTI_STATEMENT_COUNT
+	count := 1
+	if value, ok := body.Parameters["MULTI_STATEMENT_COUNT"]; ok {
+		count, _ = strconv.Atoi(value)
+	}
+	if count != len(statements) {
+		fakehttp.WriteJSON(w, http.StatusUnprocessableEntity, map[string]string{
+			"code": "000008", "message": "Actual statement count did not match the desired statement count"})
+		return
+	}
+
+	data := [][]*string{}
+	for _, statement := range statements {
+		if statement == f.failStatement {
+			fakehttp.WriteJSON(w, http.StatusUnprocessableEntity, map[string]string{
+				"code": "002003", "message": "SQL compilation error: Object does not exist"})
+			return
+		}
+		if match := setTagStatement.FindStringSubmatch(statement); match != nil {
+			if f.tags[match[2]] == nil {
+				f.tags[match[2]] = map[string]string{}
+			}
+			f.tags[match[2]][strings.ToLower(match[1])] = match[3]
+		} else if match := unsetTagStatement.FindStringSubmatch(statement); match != nil {
+			delete(f.tags[match[2]], strings.ToLower(match[1]))
+		} else if match := disabledStatement.FindStringSubmatch(statement); match != nil {
+			f.disabled[strings.ToLower(match[1])] = match[2] == "TRUE"
+		} else if match := setNetworkPolicyStatement.FindStringSubmatch(statement); match != nil {
+			f.networkPolicies[strings.ToLower(match[1])] = match[2]
+		} else if match := unsetNetworkPolicyStatement.FindStringSubmatch(statement); match != nil {
+			delete(f.networkPolicies, strings.ToLower(match[1]))
+		} else if calls := getTagCall.FindAllStringSubmatch(statement, -1); strings.HasPrefix(statement, "SELECT ") {
+			row := make([]*string, 0, len(calls))
+			for _, call := range calls {
+				var value *string
+				if tag, ok := f.tags[call[1]][strings.ToLower(call[2])]; ok {
+					value = &tag
+				}
+				row = append(row, value)
+			}
+			data = append(data, row)
+		}
+	}
+	fakehttp.WriteJSON(w, http.StatusOK, sqlStatementResponse{
+		Code: "090001", Message: "Statement executed successfully.", Data: data,
+	})
+}
+
+// sqlStatements returns the statements of all the SQL API requests, in order
+func (f *fakeSnowflake) sqlStatements() []string {
+	var statements []string
+	for _, request := range f.statements {
+		statements = append(statements, request...)
+	}
+	return statements
+}
+
+func newTestClient(t *testing.T, fake *fakeSnowflake, connection map[string]interface{}) *SnowflakeClient {
+	t.Helper()
+	if fake.users == nil {
+		fake.users = map[string]SnowflakeUser{}
+	}
+	if fake.tags == nil {
+		fake.tags = map[string]map[string]string{}
+	}
+	if fake.disabled == nil {
+		fake.disabled = map[string]bool{}
+	}
+	if fake.networkPolicies == nil {
+		fake.networkPolicies = map[string]string{}
+	}
+	server := fakehttp.NewServer(t, fake)
+
+	connection["base_url"] = server.URL
+	connection["pat"] = "pat"
+	client, err := NewClient(connection, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"base_url": "https://account.snowflakecomputing.com"},
+		fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	assert.ErrorContains(t, err, "pat and base_url are required")
+
+	_, err = NewClient(map[string]interface{}{
+		"base_url": "https://account.snowflakecomputing.com", "pat": "pat", "disable_on_delete": true,
+	}, fakehttp.ConnectionPoolConfig(t), fakehttp.HystrixResiliencyConfig())
+	assert.ErrorContains(t, err, "disabled_tag is required with disable_on_delete")
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlStatementsEndpoint is the endpoint of the SQL API, running the statements the REST API has
+// no resource for
+const sqlStatementsEndpoint = "/api/v2/statements"
+
+// sqlStatementTimeout is how long in seconds Snowflake runs a statement before canceling it
+const sqlStatementTimeout = 60
+
+// unquotedIdentifier matches the identifiers Snowflake resolves without quotes, case-insensitively
+var unquotedIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// sqlStatementRequest is the body of a SQL API request
+type sqlStatementRequest struct {
+	Statement  string            `json:"statement"`
+	Timeout    int               `json:"timeout"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// sqlStatementResponse is the status of a statement returned by the SQL API, with the rows of its
+// result once it succeeded
+type sqlStatementResponse struct {
+	Code               string      `json:"code"`
+	Message            string      `json:"message"`
+	StatementHandle    string      `json:"statementHandle"`
+	StatementStatusURL string      `json:"statementStatusUrl"`
+	Data               [][]*string `json:"data"`
+}
+
+// sqlIdentifier returns the name as an identifier of a SQL statement. The names created through
+// the REST API are unquoted identifiers, the other names are quoted so that they can't alter the
+// statement.
+func sqlIdentifier(name string) string {
+	if unquotedIdentifier.MatchString(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlQualifiedIdentifier returns the name qualified by its database and schema, e.g. db.schema.tag,
+// as an identifier of a SQL statement
+func sqlQualifiedIdentifier(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = sqlIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// sqlLiteral returns the value as a string literal of a SQL statement
+func sqlLiteral(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(value) + "'"
+}
+
+// executeStatements runs the statements in a single request of the SQL API, polling their status
+// while Snowflake runs them asynchronously. The statements run in order and stop at the first
+// failing one, the statements before it stay applied.
+func (c *SnowflakeClient) executeStatements(ctx context.Context, statements ...string) error {
+	_, err := c.runStatements(ctx, statements...)
+	return err
+}
+
+// queryValue runs the query and returns the value of the first column of its first row, false when
+// the query returned no row or a NULL value
+func (c *SnowflakeClient) queryValue(ctx context.Context, query string) (string, bool, error) {
+	row, err := c.queryRow(ctx, query)
+	if err != nil {
+		return "", false, err
+	}
+	if len(row) == 0 || row[0] == nil {
+		return "", false, nil
+	}
+	return *row[0], true, nil
+}
+
+// queryRow runs the query and returns the values of the columns of its first row, nil when the query
+// returned no row. The NULL values are nil.
+func (c *SnowflakeClient) queryRow(ctx context.Context, query string) ([]*string, error) {
+	result, err := c.runStatements(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return nil, nil
+	}
+	return result.Data[0], nil
+}
+
+// runStatements runs the statements as executeStatements does and returns the response of the
+// SQL API with the rows of the result
+func (c *SnowflakeClient) runStatements(ctx context.Context, statements ...string) (*sqlStatementResponse,
+	error) {
+	body := sqlStatementRequest{Statement: strings.Join(statements, ";\n"), Timeout: sqlStatementTimeout}
+	if len(statements) > 1 {
+		body.Parameters = map[string]string{"MULTI_STATEMENT_COUNT": strconv.Itoa(len(statements))}
+	}
+	resp, _, status, err := c.makeRequestWithHeader(ctx, sqlStatementsEndpoint, http.MethodPost, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusAccepted {
+		var accepted sqlStatementResponse
+		if err := json.Unmarshal(resp, &accepted); err != nil {
+			return nil, fmt.Errorf("failed to parse statement response: %w", err)
+		}
+		if accepted.StatementStatusURL == "" {
+			return nil, fmt.Errorf("received 202 response but no statement status URL found")
+		}
+
+		pollCtx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+		defer cancel()
+		resp, _, status, err = c.pollForResults(pollCtx, accepted.StatementStatusURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result sqlStatementResponse
+	if status != http.StatusOK {
+		if err := json.Unmarshal(resp, &result); err == nil && result.Message != "" {
+			return nil, fmt.Errorf("failed to execute statements, status: %s, code: %s, message: %s",
+				http.StatusText(status), result.Code, result.Message)
+		}
+		return nil, fmt.Errorf("failed to execute statements, status: %s, body: %s", http.StatusText(status),
+			string(resp))
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse statement response: %w", err)
+	}
+	return &result, nil
+}
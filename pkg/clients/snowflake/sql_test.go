@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "alice", want: "alice"},
+		{name: "ALICE_2", want: "ALICE_2"},
+		{name: "_team$1", want: "_team$1"},
+		{name: "alice.smith", want: `"alice.smith"`},
+		{name: "alice-smith", want: `"alice-smith"`},
+		{name: "1team", want: `"1team"`},
+		{name: `al"ice`, want: `"al""ice"`},
+		{name: `alice"; DROP USER bob; --`, want: `"alice""; DROP USER bob; --"`},
+		{name: "josé", want: `"josé"`},
+		{name: "チーム", want: `"チーム"`},
+		{name: "", want: `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sqlIdentifier(tt.name))
+		})
+	}
+}
+
+func TestSQLQualifiedIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "governance.tags.usernaut_disabled", want: "governance.tags.usernaut_disabled"},
+		{name: "usernaut_disabled", want: "usernaut_disabled"},
+		{name: "governance.my tags.disabled", want: `governance."my tags".disabled`},
+		{name: `governance.ta"gs.disabled`, want: `governance."ta""gs".disabled`},
+		{name: "gouvernance.étiquettes.désactivé", want: `gouvernance."étiquettes"."désactivé"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sqlQualifiedIdentifier(tt.name))
+		})
+	}
+}
+
+func TestSQLLiteral(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "usernaut", want: "'usernaut'"},
+		{value: "o'brien", want: "'o''brien'"},
+		{value: `back\slash`, want: `'back\\slash'`},
+		{value: `\'; DROP USER bob; --`, want: `'\\''; DROP USER bob; --'`},
+		{value: "josé", want: "'josé'"},
+		{value: "", want: "''"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, sqlLiteral(tt.value))
+		})
+	}
+}
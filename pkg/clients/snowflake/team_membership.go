@@ -20,7 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
@@ -72,7 +72,8 @@ func (c *SnowflakeClient) processGrantsPage(resp []byte, members map[string]*str
 	return nil
 }
 
-// AddUserToTeam adds users to a team (grants role to users)
+// AddUserToTeam adds users to a team (grants role to users), with one GRANT ROLE statement per
+// user sent in a single SQL API request
 func (c *SnowflakeClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
 		"service":    "snowflake",
@@ -81,24 +82,24 @@ func (c *SnowflakeClient) AddUserToTeam(ctx context.Context, teamID string, user
 	})
 	log.Info("adding users to team")
 
-	for _, userID := range userIDs {
-		endpoint := fmt.Sprintf("/api/v2/users/%s/grants", userID)
-
-		resp, status, err := c.makeRoleRequest(ctx, teamID, endpoint)
-		if err != nil {
-			return fmt.Errorf("failed to add user %s to team %s: %w", userID, teamID, err)
-		}
+	if len(userIDs) == 0 {
+		return nil
+	}
 
-		if status != http.StatusOK && status != http.StatusCreated {
-			return fmt.Errorf("failed to add user %s to team %s, status: %s, body: %s",
-				userID, teamID, http.StatusText(status), string(resp))
-		}
+	statements := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		statements = append(statements, fmt.Sprintf("GRANT ROLE %s TO USER %s",
+			sqlIdentifier(teamID), sqlIdentifier(userID)))
+	}
+	if err := c.executeStatements(ctx, statements...); err != nil {
+		return fmt.Errorf("failed to add %d users to team %s: %w", len(userIDs), teamID, err)
 	}
 
 	return nil
 }
 
-// RemoveUserFromTeam removes users from a team (revokes role from users)
+// RemoveUserFromTeam removes users from a team (revokes role from users), with one REVOKE ROLE
+// statement per user sent in a single SQL API request
 func (c *SnowflakeClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
 		"service":    "snowflake",
@@ -107,39 +108,182 @@ func (c *SnowflakeClient) RemoveUserFromTeam(ctx context.Context, teamID string,
 	})
 	log.Info("removing users from team")
 
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	statements := make([]string, 0, len(userIDs))
 	for _, userID := range userIDs {
-		endpoint := fmt.Sprintf("/api/v2/users/%s/grants:revoke", userID)
+		statements = append(statements, fmt.Sprintf("REVOKE ROLE %s FROM USER %s",
+			sqlIdentifier(teamID), sqlIdentifier(userID)))
+	}
+	if err := c.executeStatements(ctx, statements...); err != nil {
+		return fmt.Errorf("failed to remove %d users from team %s: %w", len(userIDs), teamID, err)
+	}
 
-		resp, status, err := c.makeRoleRequest(ctx, teamID, endpoint)
-		if err != nil {
-			return fmt.Errorf("failed to remove user %s from team %s: %w", userID, teamID, err)
+	return nil
+}
+
+// ReconcileGroupParams checks the group params of the team. The network policy of the network_policy
+// group param applies to the members of the team rather than the role, it is set on them by
+// ReconcileMemberParams once the members of the team are reconciled.
+func (c *SnowflakeClient) ReconcileGroupParams(
+	ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "snowflake",
+		"teamID":      teamID,
+		"groupParams": groupParams,
+	})
+	log.Info("reconciling group params")
+
+	switch groupParams.Property {
+	case GroupParamNetworkPolicy:
+		if len(groupParams.Value) != 1 {
+			return fmt.Errorf("snowflake group param %s takes a single network policy", GroupParamNetworkPolicy)
 		}
+	default:
+		log.WithField("property", groupParams.Property).Warn("unsupported group property for snowflake backend")
+	}
+	return nil
+}
 
-		if status != http.StatusOK && status != http.StatusNoContent {
-			return fmt.Errorf("failed to remove user %s from team %s, status: %s, body: %s",
-				userID, teamID, http.StatusText(status), string(resp))
+// ReconcileMemberParams sets the network policy of the network_policy group param on the members of
+// the team and unsets it from the removed users, or from all the members once the group param is
+// dropped. The users whose network policy is set by the team carry the network_policy_tag set to the
+// team, the network policy set by another team or an administrator is only unset by its own team.
+// The members whose network policy is set by another team are reported as conflicting, after the
+// network policy of the other members is reconciled.
+func (c *SnowflakeClient) ReconcileMemberParams(ctx context.Context, teamID string,
+	groupParams []structs.TeamParams, memberIDs, removedIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":      "snowflake",
+		"teamID":       teamID,
+		"member_count": len(memberIDs),
+	})
+
+	networkPolicy, err := networkPolicyParam(groupParams)
+	if err != nil {
+		return err
+	}
+	if c.config.NetworkPolicyTag == "" {
+		if networkPolicy != "" {
+			return fmt.Errorf("missing required connection parameter for snowflake backend: "+
+				"network_policy_tag is required with the %s group param", GroupParamNetworkPolicy)
 		}
+		return nil
+	}
+	log.WithField("network_policy", networkPolicy).Info("reconciling the network policy of the team members")
+
+	owners, err := c.networkPolicyOwners(ctx, slices.Concat(memberIDs, removedIDs))
+	if err != nil {
+		return fmt.Errorf("failed to read the network policy tag of the members of team %s: %w", teamID, err)
 	}
 
+	var toSet, toUnset, conflicts []string
+	for _, userID := range memberIDs {
+		switch owner := owners[userID]; {
+		case networkPolicy == "":
+			if owner == teamID {
+				toUnset = append(toUnset, userID)
+			}
+		case owner == "" || owner == teamID:
+			toSet = append(toSet, userID)
+		default:
+			conflicts = append(conflicts, fmt.Sprintf("%s (team %s)", userID, owner))
+		}
+	}
+	for _, userID := range removedIDs {
+		if owners[userID] == teamID {
+			toUnset = append(toUnset, userID)
+		}
+	}
+
+	for batch := range slices.Chunk(toSet, networkPolicyBatchSize) {
+		if err := c.executeStatements(ctx, c.setNetworkPolicyStatements(networkPolicy, teamID, batch)...); err != nil {
+			return fmt.Errorf("failed to set network policy %s on the members of team %s: %w",
+				networkPolicy, teamID, err)
+		}
+	}
+	for batch := range slices.Chunk(toUnset, networkPolicyBatchSize) {
+		if err := c.executeStatements(ctx, c.unsetNetworkPolicyStatements(batch)...); err != nil {
+			return fmt.Errorf("failed to unset the network policy of team %s: %w", teamID, err)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		log.WithField("conflicts", conflicts).Warn("members have the network policy of another team")
+		return fmt.Errorf("conflicting network policies, the network policy of %s is set by another team",
+			strings.Join(conflicts, ", "))
+	}
 	return nil
 }
 
-// makeRoleRequest sends a role grant/revoke request for a user
-func (c *SnowflakeClient) makeRoleRequest(ctx context.Context, teamID, endpoint string) ([]byte, int, error) {
-	payload := map[string]interface{}{
-		"securable": map[string]string{
-			"name": teamID,
-		},
-		"securable_type": "ROLE",
-		"privileges":     []string{},
+// networkPolicyParam returns the network policy of the network_policy group params, empty without
+// them. The group params setting different network policies conflict.
+func networkPolicyParam(groupParams []structs.TeamParams) (string, error) {
+	networkPolicy := ""
+	for _, groupParam := range groupParams {
+		if groupParam.Property != GroupParamNetworkPolicy || len(groupParam.Value) == 0 {
+			continue
+		}
+		if networkPolicy != "" && !strings.EqualFold(networkPolicy, groupParam.Value[0]) {
+			return "", fmt.Errorf("conflicting network policies %s and %s in the %s group params",
+				networkPolicy, groupParam.Value[0], GroupParamNetworkPolicy)
+		}
+		networkPolicy = groupParam.Value[0]
 	}
+	return networkPolicy, nil
+}
+
+// networkPolicyBatchSize is the largest number of users whose network policy is read or set in one request
+const networkPolicyBatchSize = 100
 
-	resp, _, status, err := c.makeRequestWithPolling(ctx, endpoint, http.MethodPost, payload)
-	return resp, status, err
+// networkPolicyOwners returns the teams of the network policy tag of the users, the users without
+// the tag are left out
+func (c *SnowflakeClient) networkPolicyOwners(ctx context.Context, userIDs []string) (map[string]string, error) {
+	owners := make(map[string]string, len(userIDs))
+	tag := sqlLiteral(sqlQualifiedIdentifier(c.config.NetworkPolicyTag))
+	for batch := range slices.Chunk(userIDs, networkPolicyBatchSize) {
+		columns := make([]string, 0, len(batch))
+		for _, userID := range batch {
+			columns = append(columns, fmt.Sprintf("SYSTEM$GET_TAG(%s, %s, 'USER')", tag,
+				sqlLiteral(sqlIdentifier(userID))))
+		}
+		row, err := c.queryRow(ctx, "SELECT "+strings.Join(columns, ", "))
+		if err != nil {
+			return nil, err
+		}
+		for i, value := range row {
+			if i < len(batch) && value != nil && *value != "" {
+				owners[batch[i]] = *value
+			}
+		}
+	}
+	return owners, nil
 }
 
-func (c *SnowflakeClient) ReconcileGroupParams(
-	ctx context.Context, teamID string, groupParams structs.TeamParams) error {
-	// TODO: Implement group parameter reconciliation for Snowflake if applicable.
-	return nil
+// setNetworkPolicyStatements returns the statements setting the network policy of the team on the
+// users, along with the network policy tag
+func (c *SnowflakeClient) setNetworkPolicyStatements(networkPolicy, teamID string, userIDs []string) []string {
+	statements := make([]string, 0, 2*len(userIDs))
+	for _, userID := range userIDs {
+		statements = append(statements,
+			fmt.Sprintf("ALTER USER %s SET NETWORK_POLICY = %s", sqlIdentifier(userID), sqlIdentifier(networkPolicy)),
+			fmt.Sprintf("ALTER USER %s SET TAG %s = %s", sqlIdentifier(userID),
+				sqlQualifiedIdentifier(c.config.NetworkPolicyTag), sqlLiteral(teamID)))
+	}
+	return statements
+}
+
+// unsetNetworkPolicyStatements returns the statements unsetting the network policy of the users and
+// their network policy tag
+func (c *SnowflakeClient) unsetNetworkPolicyStatements(userIDs []string) []string {
+	statements := make([]string, 0, 2*len(userIDs))
+	for _, userID := range userIDs {
+		statements = append(statements,
+			fmt.Sprintf("ALTER USER %s UNSET NETWORK_POLICY", sqlIdentifier(userID)),
+			fmt.Sprintf("ALTER USER %s UNSET TAG %s", sqlIdentifier(userID),
+				sqlQualifiedIdentifier(c.config.NetworkPolicyTag)))
+	}
+	return statements
 }
@@ -0,0 +1,260 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+func TestAddUserToTeam(t *testing.T) {
+	fake := &fakeSnowflake{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "data-team", []string{"alice", "bob.smith"}))
+	assert.Equal(t, [][]string{{
+		`GRANT ROLE "data-team" TO USER alice`,
+		`GRANT ROLE "data-team" TO USER "bob.smith"`,
+	}}, fake.statements)
+}
+
+func TestRemoveUserFromTeam(t *testing.T) {
+	fake := &fakeSnowflake{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "data_team", []string{"alice", `b"ob`}))
+	assert.Equal(t, [][]string{{
+		"REVOKE ROLE data_team FROM USER alice",
+		`REVOKE ROLE data_team FROM USER "b""ob"`,
+	}}, fake.statements)
+}
+
+func TestTeamMembershipEmptyBatch(t *testing.T) {
+	fake := &fakeSnowflake{}
+	client := newTestClient(t, fake, map[string]interface{}{})
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "data_team", nil))
+	require.NoError(t, client.RemoveUserFromTeam(context.Background(), "data_team", []string{}))
+	assert.Empty(t, fake.statements)
+}
+
+// inBatches calls fn with the batches of user IDs the controller sends per membership_batch_size, as
+// clients.InBatches does
+func inBatches(userIDs []string, batchSize int, fn func(batch []string) error) error {
+	for batch := range slices.Chunk(userIDs, batchSize) {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTeamMembershipInBatches(t *testing.T) {
+	userIDs := []string{"u1", "u2", "u3", "u4", "u5"}
+
+	t.Run("sends one request per batch", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		require.NoError(t, inBatches(userIDs, 2, func(batch []string) error {
+			return client.AddUserToTeam(context.Background(), "data_team", batch)
+		}))
+		assert.Equal(t, [][]string{
+			{"GRANT ROLE data_team TO USER u1", "GRANT ROLE data_team TO USER u2"},
+			{"GRANT ROLE data_team TO USER u3", "GRANT ROLE data_team TO USER u4"},
+			{"GRANT ROLE data_team TO USER u5"},
+		}, fake.statements)
+
+		fake.statements = nil
+		require.NoError(t, inBatches(userIDs, 3, func(batch []string) error {
+			return client.RemoveUserFromTeam(context.Background(), "data_team", batch)
+		}))
+		assert.Equal(t, [][]string{
+			{"REVOKE ROLE data_team FROM USER u1", "REVOKE ROLE data_team FROM USER u2",
+				"REVOKE ROLE data_team FROM USER u3"},
+			{"REVOKE ROLE data_team FROM USER u4", "REVOKE ROLE data_team FROM USER u5"},
+		}, fake.statements)
+	})
+
+	t.Run("stops at the failing batch", func(t *testing.T) {
+		fake := &fakeSnowflake{failStatement: "GRANT ROLE data_team TO USER u3"}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		err := inBatches(userIDs, 2, func(batch []string) error {
+			return client.AddUserToTeam(context.Background(), "data_team", batch)
+		})
+		assert.ErrorContains(t, err, "failed to add 2 users to team data_team")
+		assert.ErrorContains(t, err, "Object does not exist")
+		assert.Len(t, fake.statements, 2)
+	})
+}
+
+// networkPolicyTag is the network policy tag of the clients setting the network policy of the teams
+const networkPolicyTag = "governance.tags.usernaut_network_policy"
+
+// networkPolicyParams are the group params of a team setting the network policy
+func networkPolicyParams(networkPolicy string) []structs.TeamParams {
+	return []structs.TeamParams{{Property: GroupParamNetworkPolicy, Value: []string{networkPolicy}}}
+}
+
+func TestReconcileGroupParamsNetworkPolicy(t *testing.T) {
+	fake := &fakeSnowflake{}
+	client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+	require.NoError(t, client.ReconcileGroupParams(context.Background(), "data_team", networkPolicyParams("CORP_VPN")[0]))
+	assert.Empty(t, fake.statements, "the network policy is set with the members")
+
+	err := client.ReconcileGroupParams(context.Background(), "data_team", structs.TeamParams{
+		Property: GroupParamNetworkPolicy, Value: []string{"CORP_VPN", "OFFICE"},
+	})
+	assert.ErrorContains(t, err, "takes a single network policy")
+}
+
+func TestReconcileMemberParams(t *testing.T) {
+	t.Run("sets the network policy on the members", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+		require.NoError(t, client.ReconcileMemberParams(context.Background(), "data_team",
+			networkPolicyParams("CORP_VPN"), []string{"alice", "bob"}, nil))
+		assert.Equal(t, [][]string{
+			{"SELECT SYSTEM$GET_TAG('governance.tags.usernaut_network_policy', 'alice', 'USER'), " +
+				"SYSTEM$GET_TAG('governance.tags.usernaut_network_policy', 'bob', 'USER')"},
+			{
+				"ALTER USER alice SET NETWORK_POLICY = CORP_VPN",
+				"ALTER USER alice SET TAG governance.tags.usernaut_network_policy = 'data_team'",
+				"ALTER USER bob SET NETWORK_POLICY = CORP_VPN",
+				"ALTER USER bob SET TAG governance.tags.usernaut_network_policy = 'data_team'",
+			},
+		}, fake.statements)
+		assert.Equal(t, map[string]string{"alice": "CORP_VPN", "bob": "CORP_VPN"}, fake.networkPolicies)
+	})
+
+	t.Run("sets the network policy in batches", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+		memberIDs := make([]string, 0, networkPolicyBatchSize+1)
+		for i := range networkPolicyBatchSize + 1 {
+			memberIDs = append(memberIDs, fmt.Sprintf("user_%03d", i))
+		}
+
+		require.NoError(t, client.ReconcileMemberParams(context.Background(), "data_team",
+			networkPolicyParams("CORP_VPN"), memberIDs, nil))
+		require.Len(t, fake.statements, 4)
+		assert.Len(t, fake.statements[0], 1)
+		assert.Len(t, fake.statements[1], 1)
+		assert.Len(t, fake.statements[2], 2*networkPolicyBatchSize)
+		assert.Equal(t, []string{
+			"ALTER USER user_100 SET NETWORK_POLICY = CORP_VPN",
+			"ALTER USER user_100 SET TAG governance.tags.usernaut_network_policy = 'data_team'",
+		}, fake.statements[3])
+		assert.Len(t, fake.networkPolicies, networkPolicyBatchSize+1)
+	})
+
+	t.Run("unsets the network policy of the team from the removed users", func(t *testing.T) {
+		fake := &fakeSnowflake{
+			tags: map[string]map[string]string{networkPolicyTag: {"alice": "data_team", "bob": "data_team",
+				"carol": "other_team"}},
+			networkPolicies: map[string]string{"alice": "CORP_VPN", "bob": "CORP_VPN", "carol": "OFFICE"},
+		}
+		client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+		require.NoError(t, client.ReconcileMemberParams(context.Background(), "data_team",
+			networkPolicyParams("CORP_VPN"), []string{"alice"}, []string{"bob", "carol"}))
+		assert.Equal(t, []string{
+			"ALTER USER bob UNSET NETWORK_POLICY",
+			"ALTER USER bob UNSET TAG governance.tags.usernaut_network_policy",
+		}, fake.statements[2])
+		assert.Equal(t, map[string]string{"alice": "CORP_VPN", "carol": "OFFICE"}, fake.networkPolicies)
+		assert.Equal(t, map[string]string{"alice": "data_team", "carol": "other_team"}, fake.tags[networkPolicyTag])
+	})
+
+	t.Run("unsets the network policy of the team once the group param is dropped", func(t *testing.T) {
+		fake := &fakeSnowflake{
+			tags:            map[string]map[string]string{networkPolicyTag: {"alice": "data_team", "carol": "other_team"}},
+			networkPolicies: map[string]string{"alice": "CORP_VPN", "bob": "ADMIN_SET", "carol": "OFFICE"},
+		}
+		client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+		require.NoError(t, client.ReconcileMemberParams(context.Background(), "data_team", nil,
+			[]string{"alice", "bob", "carol"}, nil))
+		assert.Equal(t, []string{
+			"ALTER USER alice UNSET NETWORK_POLICY",
+			"ALTER USER alice UNSET TAG governance.tags.usernaut_network_policy",
+		}, fake.statements[1])
+		assert.Equal(t, map[string]string{"bob": "ADMIN_SET", "carol": "OFFICE"}, fake.networkPolicies)
+	})
+
+	t.Run("flags the members with the network policy of another team", func(t *testing.T) {
+		fake := &fakeSnowflake{
+			tags:            map[string]map[string]string{networkPolicyTag: {"bob": "other_team"}},
+			networkPolicies: map[string]string{"bob": "OFFICE"},
+		}
+		client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+		err := client.ReconcileMemberParams(context.Background(), "data_team",
+			networkPolicyParams("CORP_VPN"), []string{"alice", "bob"}, nil)
+		assert.ErrorContains(t, err, "conflicting network policies, the network policy of bob (team other_team) "+
+			"is set by another team")
+		assert.Equal(t, map[string]string{"alice": "CORP_VPN", "bob": "OFFICE"}, fake.networkPolicies)
+		assert.Equal(t, "other_team", fake.tags[networkPolicyTag]["bob"])
+	})
+
+	t.Run("rejects conflicting group params", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+		err := client.ReconcileMemberParams(context.Background(), "data_team",
+			append(networkPolicyParams("CORP_VPN"), networkPolicyParams("OFFICE")...), []string{"alice"}, nil)
+		assert.ErrorContains(t, err, "conflicting network policies CORP_VPN and OFFICE")
+		assert.Empty(t, fake.statements)
+	})
+
+	t.Run("requires the network policy tag", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		err := client.ReconcileMemberParams(context.Background(), "data_team",
+			networkPolicyParams("CORP_VPN"), []string{"alice"}, nil)
+		assert.ErrorContains(t, err, "network_policy_tag is required with the network_policy group param")
+
+		require.NoError(t, client.ReconcileMemberParams(context.Background(), "data_team", nil,
+			[]string{"alice"}, []string{"bob"}))
+		assert.Empty(t, fake.statements)
+	})
+}
+
+func TestDeleteTeamUnsetsNetworkPolicy(t *testing.T) {
+	fake := &fakeSnowflake{
+		grants:          map[string][]string{"data_team": {"alice", "bob"}},
+		tags:            map[string]map[string]string{networkPolicyTag: {"alice": "data_team", "bob": "other_team"}},
+		networkPolicies: map[string]string{"alice": "CORP_VPN", "bob": "OFFICE"},
+	}
+	client := newTestClient(t, fake, map[string]interface{}{"network_policy_tag": networkPolicyTag})
+
+	require.NoError(t, client.DeleteTeamByID(context.Background(), "data_team"))
+	assert.NotContains(t, fake.grants, "data_team")
+	assert.Equal(t, map[string]string{"bob": "OFFICE"}, fake.networkPolicies)
+	assert.Equal(t, map[string]string{"bob": "other_team"}, fake.tags[networkPolicyTag])
+}
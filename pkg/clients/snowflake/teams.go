@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
@@ -48,21 +50,45 @@ func (c *SnowflakeClient) FetchAllTeams(ctx context.Context) (map[string]structs
 	return teams, nil
 }
 
+// ForEachTeamPage calls fn with each page of the roles
+func (c *SnowflakeClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	return c.fetchAllWithPagination(ctx, "/api/v2/roles", func(resp []byte) error {
+		page, err := parseTeamsPage(resp)
+		if err != nil {
+			return err
+		}
+		return fn(page)
+	})
+}
+
 func (c *SnowflakeClient) processTeamsPage(resp []byte, teams map[string]structs.Team) error {
+	page, err := parseTeamsPage(resp)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range page {
+		teams[team.Name] = team
+	}
+
+	return nil
+}
+
+// parseTeamsPage converts a page of roles to teams named after the lowercased role
+func parseTeamsPage(resp []byte) ([]structs.Team, error) {
 	var roles []SnowflakeRole
 	if err := json.Unmarshal(resp, &roles); err != nil {
-		return fmt.Errorf("failed to parse roles response: %w", err)
+		return nil, fmt.Errorf("failed to parse roles response: %w", err)
 	}
 
+	page := make([]structs.Team, 0, len(roles))
 	for _, role := range roles {
-		team := structs.Team{
+		page = append(page, structs.Team{
 			ID:   strings.ToLower(role.Name),
 			Name: strings.ToLower(role.Name),
-		}
-		teams[strings.ToLower(role.Name)] = team
+		})
 	}
-
-	return nil
+	return page, nil
 }
 
 // CreateTeam creates a new role in Snowflake using REST API
@@ -127,6 +153,18 @@ func (c *SnowflakeClient) DeleteTeamByID(ctx context.Context, teamID string) err
 	})
 
 	log.Info("deleting team")
+
+	// The network policy set by the team is unset from its members before the role goes away
+	if c.config.NetworkPolicyTag != "" {
+		members, err := c.FetchTeamMembersByTeamID(ctx, teamID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the members of role: %w", err)
+		}
+		if err := c.ReconcileMemberParams(ctx, teamID, nil, nil, slices.Sorted(maps.Keys(members))); err != nil {
+			return err
+		}
+	}
+
 	endpoint := fmt.Sprintf("/api/v2/roles/%s", teamID)
 
 	resp, _, status, err := c.makeRequestWithPolling(ctx, endpoint, http.MethodDelete, nil)
@@ -22,8 +22,25 @@ import "github.com/gojek/heimdall/v7"
 type SnowflakeConfig struct {
 	PAT     string
 	BaseURL string
+	// DisableOnDelete disables the deleted users instead of dropping them, so that the objects they
+	// own and their history are kept
+	DisableOnDelete bool
+	// DisabledTag is the tag, qualified by its database and schema, set on the users disabled on
+	// delete so that only they are enabled again when created again
+	DisabledTag string
+	// DefaultRole, DefaultWarehouse and DefaultNamespace are the defaults of the session of the
+	// users created, empty values keep the defaults of the account
+	DefaultRole      string
+	DefaultWarehouse string
+	DefaultNamespace string
+	// NetworkPolicyTag is the tag, qualified by its database and schema, set to the team on the users
+	// whose network policy is set by the network_policy group param of the team
+	NetworkPolicyTag string
 }
 
+// GroupParamNetworkPolicy is the group param of the network policy set on the users of the team
+const GroupParamNetworkPolicy = "network_policy"
+
 // SnowflakeClient is the client for interacting with Snowflake REST API
 type SnowflakeClient struct {
 	config *SnowflakeConfig
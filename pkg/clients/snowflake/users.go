@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
@@ -51,117 +52,80 @@ func snowflakeUserToStruct(user SnowflakeUser) *structs.User {
 // Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
 func (c *SnowflakeClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
 	map[string]*structs.User, error) {
-	resultByID, resultByEmail, _, err := c.FetchAllUsersWithCursor(ctx)
-	return resultByID, resultByEmail, err
-}
-
-// FetchAllUsersWithCursor fetches users and also returns the last user name for cursor-based pagination.
-// This is used for async continuation after preload.
-func (c *SnowflakeClient) FetchAllUsersWithCursor(ctx context.Context) (
-	map[string]*structs.User, map[string]*structs.User, string, error) {
 	log := logger.Logger(ctx).WithField("service", "snowflake")
 
 	log.Info("fetching all users")
 	resultByID := make(map[string]*structs.User)
 	resultByEmail := make(map[string]*structs.User)
-	var lastUserName string
-
-	// Use the generic pagination helper with a closure that processes user pages
-	err := c.fetchAllWithPagination(ctx, "/api/v2/users", func(resp []byte) error {
-		var users []SnowflakeUser
-		if err := json.Unmarshal(resp, &users); err != nil {
-			return fmt.Errorf("failed to parse users response: %w", err)
-		}
 
+	err := c.ForEachUserPage(ctx, func(users []*structs.User) error {
 		for _, user := range users {
-			structUser := snowflakeUserToStruct(user)
-			resultByID[structUser.ID] = structUser
-			if structUser.Email != "" {
-				resultByEmail[structUser.Email] = structUser
+			resultByID[user.ID] = user
+			if user.Email != "" {
+				resultByEmail[user.Email] = user
 			}
-			lastUserName = user.Name // Track last user for cursor
 		}
 		return nil
 	})
-
 	if err != nil {
 		log.WithError(err).Error("error fetching list of users")
-		return nil, nil, "", err
+		return nil, nil, err
 	}
 
-	log.WithFields(logrus.Fields{
-		"total_user_count": len(resultByID),
-		"last_user":        lastUserName,
-	}).Info("found users")
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
 
-	return resultByID, resultByEmail, lastUserName, nil
+// ForEachUserPage calls fn with each page of the users, see StreamUsers
+func (c *SnowflakeClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	return c.StreamUsers(ctx, "", func(users []*structs.User, _ string) error {
+		return fn(users)
+	})
 }
 
-// FetchRemainingUsersAsync continues fetching users from where preload stopped.
-// It uses the fromName cursor to resume pagination and sends users to the returned channel.
-// The channel is closed when all users are fetched or on error.
-// This reuses the existing fetchAllWithPagination for each batch.
-func (c *SnowflakeClient) FetchRemainingUsersAsync(ctx context.Context,
-	fromName string) (<-chan *structs.User, <-chan error) {
-	userChan := make(chan *structs.User, 1000)
-	errChan := make(chan error, 1)
-
-	go func() {
-		defer close(userChan)
-		defer close(errChan)
-
-		log := logger.Logger(ctx).WithField("service", "snowflake")
-		cursor := fromName
-
-		for {
-			// Build endpoint with cursor to get next batch
-			endpoint := fmt.Sprintf("/api/v2/users?showLimit=%d&fromName=%s", snowflakeUsersPageLimit, cursor)
-			log.WithField("endpoint", endpoint).Info("fetching next user batch")
-
-			var batchCount int
-			var newCursor string
-
-			// Reuse existing fetchAllWithPagination (handles 202 polling + Link header pages)
-			err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
-				var users []SnowflakeUser
-				if err := json.Unmarshal(resp, &users); err != nil {
-					return fmt.Errorf("failed to parse users: %w", err)
-				}
-
-				for _, user := range users {
-					select {
-					case userChan <- snowflakeUserToStruct(user):
-						batchCount++
-						newCursor = user.Name
-					case <-ctx.Done():
-						return ctx.Err()
-					}
-				}
-				return nil
-			})
+// StreamUsers calls fn with each page of the users whose name follows fromName, all the users when
+// it is empty, in the order of their names. fn is also given the name of the last user of the page,
+// the cursor to resume the walk from, so that the callers streaming a large account can checkpoint
+// it. The users endpoint returns at most snowflakeUsersPageLimit users, the batches past the limit
+// are fetched from the name of the last user.
+func (c *SnowflakeClient) StreamUsers(ctx context.Context, fromName string,
+	fn func(users []*structs.User, cursor string) error) error {
+	log := logger.Logger(ctx).WithField("service", "snowflake")
+	cursor := fromName
+	for {
+		endpoint := fmt.Sprintf("/api/v2/users?showLimit=%d", snowflakeUsersPageLimit)
+		if cursor != "" {
+			endpoint += "&fromName=" + url.QueryEscape(cursor)
+		}
+		log.WithField("endpoint", endpoint).Debug("fetching user batch")
 
-			if err != nil {
-				log.WithError(err).Error("error fetching user batch")
-				errChan <- err
-				return
+		var batchCount int
+		err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
+			var users []SnowflakeUser
+			if err := json.Unmarshal(resp, &users); err != nil {
+				return fmt.Errorf("failed to parse users response: %w", err)
 			}
-
-			log.WithFields(logrus.Fields{
-				"batch_count": batchCount,
-				"new_cursor":  newCursor,
-			}).Info("processed user batch")
-
-			// If fewer than page limit users, we've reached the end
-			if batchCount < snowflakeUsersPageLimit {
-				log.Info("all remaining users fetched")
-				return
+			if len(users) == 0 {
+				return nil
 			}
 
-			cursor = newCursor
+			page := make([]*structs.User, 0, len(users))
+			for _, user := range users {
+				page = append(page, snowflakeUserToStruct(user))
+			}
+			batchCount += len(users)
+			cursor = users[len(users)-1].Name
+			return fn(page, cursor)
+		})
+		if err != nil {
+			return err
 		}
-	}()
 
-	return userChan, errChan
+		// If fewer than page limit users, we've reached the end
+		if batchCount < snowflakeUsersPageLimit {
+			return nil
+		}
+	}
 }
 
 // CreateUser creates a new user in Snowflake using REST API
@@ -192,6 +156,15 @@ func (c *SnowflakeClient) CreateUser(ctx context.Context, user *structs.User) (*
 	if user.DisplayName != "" {
 		payload["displayName"] = user.DisplayName
 	}
+	if c.config.DefaultRole != "" {
+		payload["default_role"] = c.config.DefaultRole
+	}
+	if c.config.DefaultWarehouse != "" {
+		payload["default_warehouse"] = c.config.DefaultWarehouse
+	}
+	if c.config.DefaultNamespace != "" {
+		payload["default_namespace"] = c.config.DefaultNamespace
+	}
 
 	resp, _, status, err := c.makeRequestWithPolling(ctx, endpoint, http.MethodPost, payload)
 	if err != nil {
@@ -201,7 +174,32 @@ func (c *SnowflakeClient) CreateUser(ctx context.Context, user *structs.User) (*
 
 	if status == http.StatusConflict {
 		log.WithField("status", status).Info("user already exists, fetching user details")
-		return c.FetchUserDetails(ctx, user.UserName)
+		existingUser, err := c.FetchUserDetails(ctx, userName)
+		if err != nil {
+			return nil, err
+		}
+		// Another person holding the name is a conflict, the user with the same email is looked up instead
+		if !strings.EqualFold(existingUser.Email, user.Email) {
+			return nil, fmt.Errorf("%w: user %s has another email", structs.ErrUserAlreadyExists, userName)
+		}
+		// Only the users usernaut disabled when they were deleted are enabled again, the users
+		// disabled by the administrators stay disabled
+		if c.config.DisableOnDelete {
+			disabled, err := c.isDisabledOnDelete(ctx, existingUser.ID)
+			if err != nil {
+				log.WithError(err).Error("error reading the disabled tag of user")
+				return nil, err
+			}
+			if !disabled {
+				log.Info("user was not disabled by usernaut, leaving it as is")
+				return existingUser, nil
+			}
+			if err := c.enableUser(ctx, existingUser.ID); err != nil {
+				log.WithError(err).Error("error enabling user")
+				return nil, err
+			}
+		}
+		return existingUser, nil
 	}
 
 	if status != http.StatusOK && status != http.StatusCreated {
@@ -250,6 +248,16 @@ func (c *SnowflakeClient) DeleteUser(ctx context.Context, userID string) error {
 		"userID":  userID,
 	})
 
+	if c.config.DisableOnDelete {
+		log.Debug("disabling user instead of dropping it")
+		if err := c.disableUser(ctx, userID); err != nil {
+			log.WithError(err).Error("error disabling user")
+			return fmt.Errorf("failed to disable user: %w", err)
+		}
+		log.Info("user disabled successfully")
+		return nil
+	}
+
 	log.Debug("deleting user")
 	endpoint := fmt.Sprintf("/api/v2/users/%s", userID)
 
@@ -269,3 +277,32 @@ func (c *SnowflakeClient) DeleteUser(ctx context.Context, userID string) error {
 	}).Info("user deleted successfully")
 	return nil
 }
+
+// disabledTagValue is the value of the disabled tag set on the users usernaut disabled
+const disabledTagValue = "usernaut"
+
+// disableUser disables the user and sets the disabled tag on it, a disabled user can't log in but
+// keeps the objects it owns
+func (c *SnowflakeClient) disableUser(ctx context.Context, userID string) error {
+	return c.executeStatements(ctx,
+		fmt.Sprintf("ALTER USER %s SET TAG %s = %s", sqlIdentifier(userID),
+			sqlQualifiedIdentifier(c.config.DisabledTag), sqlLiteral(disabledTagValue)),
+		fmt.Sprintf("ALTER USER %s SET DISABLED = TRUE", sqlIdentifier(userID)))
+}
+
+// enableUser enables the user disabled by disableUser and unsets its disabled tag
+func (c *SnowflakeClient) enableUser(ctx context.Context, userID string) error {
+	return c.executeStatements(ctx,
+		fmt.Sprintf("ALTER USER %s SET DISABLED = FALSE", sqlIdentifier(userID)),
+		fmt.Sprintf("ALTER USER %s UNSET TAG %s", sqlIdentifier(userID), sqlQualifiedIdentifier(c.config.DisabledTag)))
+}
+
+// isDisabledOnDelete reports whether the user carries the disabled tag set by disableUser
+func (c *SnowflakeClient) isDisabledOnDelete(ctx context.Context, userID string) (bool, error) {
+	value, ok, err := c.queryValue(ctx, fmt.Sprintf("SELECT SYSTEM$GET_TAG(%s, %s, 'USER')",
+		sqlLiteral(sqlQualifiedIdentifier(c.config.DisabledTag)), sqlLiteral(sqlIdentifier(userID))))
+	if err != nil {
+		return false, err
+	}
+	return ok && value == disabledTagValue, nil
+}
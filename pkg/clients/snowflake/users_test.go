@@ -0,0 +1,272 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// disabledTag is the disabled tag of the clients disabling the deleted users
+const disabledTag = "governance.tags.usernaut_disabled"
+
+// disableOnDelete is the connection of a client disabling the deleted users
+func disableOnDelete() map[string]interface{} {
+	return map[string]interface{}{"disable_on_delete": true, "disabled_tag": disabledTag}
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Run("drops the user", func(t *testing.T) {
+		fake := &fakeSnowflake{users: map[string]SnowflakeUser{"alice": {Name: "ALICE", Email: "alice@example.com"}}}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		require.NoError(t, client.DeleteUser(context.Background(), "alice"))
+		assert.NotContains(t, fake.users, "alice")
+		assert.Empty(t, fake.statements)
+	})
+
+	t.Run("disables and tags the user instead of dropping it", func(t *testing.T) {
+		fake := &fakeSnowflake{users: map[string]SnowflakeUser{"alice": {Name: "ALICE", Email: "alice@example.com"}}}
+		client := newTestClient(t, fake, disableOnDelete())
+
+		require.NoError(t, client.DeleteUser(context.Background(), "alice"))
+		assert.Contains(t, fake.users, "alice")
+		assert.Equal(t, [][]string{{
+			"ALTER USER alice SET TAG governance.tags.usernaut_disabled = 'usernaut'",
+			"ALTER USER alice SET DISABLED = TRUE",
+		}}, fake.statements)
+		assert.True(t, fake.disabled["alice"])
+		assert.Equal(t, "usernaut", fake.tags[disabledTag]["alice"])
+	})
+
+	t.Run("fails when the user can't be disabled", func(t *testing.T) {
+		fake := &fakeSnowflake{failStatement: "ALTER USER alice SET DISABLED = TRUE"}
+		client := newTestClient(t, fake, disableOnDelete())
+
+		err := client.DeleteUser(context.Background(), "alice")
+		assert.ErrorContains(t, err, "failed to disable user")
+		assert.False(t, fake.disabled["alice"])
+	})
+}
+
+func TestCreateUserExistingDisabledUser(t *testing.T) {
+	t.Run("enables again the user disabled by usernaut", func(t *testing.T) {
+		fake := &fakeSnowflake{users: map[string]SnowflakeUser{"alice": {Name: "ALICE", Email: "alice@example.com"}}}
+		client := newTestClient(t, fake, disableOnDelete())
+		require.NoError(t, client.DeleteUser(context.Background(), "alice"))
+		fake.statements = nil
+
+		user, err := client.CreateUser(context.Background(), &structs.User{UserName: "alice", Email: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", user.ID)
+		assert.Equal(t, [][]string{
+			{"SELECT SYSTEM$GET_TAG('governance.tags.usernaut_disabled', 'alice', 'USER')"},
+			{"ALTER USER alice SET DISABLED = FALSE", "ALTER USER alice UNSET TAG governance.tags.usernaut_disabled"},
+		}, fake.statements)
+		assert.False(t, fake.disabled["alice"])
+		assert.NotContains(t, fake.tags[disabledTag], "alice")
+	})
+
+	t.Run("leaves the user disabled by someone else disabled", func(t *testing.T) {
+		fake := &fakeSnowflake{
+			users:    map[string]SnowflakeUser{"bob": {Name: "BOB", Email: "bob@example.com"}},
+			disabled: map[string]bool{"bob": true},
+		}
+		client := newTestClient(t, fake, disableOnDelete())
+
+		user, err := client.CreateUser(context.Background(), &structs.User{UserName: "bob", Email: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "bob", user.ID)
+		assert.Equal(t, []string{"SELECT SYSTEM$GET_TAG('governance.tags.usernaut_disabled', 'bob', 'USER')"},
+			fake.sqlStatements())
+		assert.True(t, fake.disabled["bob"])
+	})
+
+	t.Run("leaves the existing user as is without disable_on_delete", func(t *testing.T) {
+		fake := &fakeSnowflake{
+			users:    map[string]SnowflakeUser{"bob": {Name: "BOB", Email: "bob@example.com"}},
+			disabled: map[string]bool{"bob": true},
+		}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		user, err := client.CreateUser(context.Background(), &structs.User{UserName: "bob", Email: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "bob", user.ID)
+		assert.Empty(t, fake.statements)
+		assert.True(t, fake.disabled["bob"])
+	})
+
+	t.Run("does not adopt the user of another person holding the name", func(t *testing.T) {
+		fake := &fakeSnowflake{
+			users: map[string]SnowflakeUser{"carol": {Name: "CAROL", Email: "carol@other.example.com"}},
+			tags:  map[string]map[string]string{disabledTag: {"carol": disabledTagValue}},
+		}
+		client := newTestClient(t, fake, disableOnDelete())
+
+		_, err := client.CreateUser(context.Background(), &structs.User{UserName: "carol", Email: "carol@example.com"})
+		assert.ErrorIs(t, err, structs.ErrUserAlreadyExists)
+		assert.Empty(t, fake.statements)
+	})
+}
+
+func TestCreateUserDefaults(t *testing.T) {
+	t.Run("sets the configured defaults as is", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{
+			"default_role":      "ANALYST",
+			"default_warehouse": `"Reporting WH"`,
+			"default_namespace": `analytics."Public Data"`,
+		})
+
+		user, err := client.CreateUser(context.Background(), &structs.User{
+			UserName: "alice-smith", Email: "alice@example.com", FirstName: "Alice", LastName: "Smith",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "alice_smith", user.ID)
+		require.Len(t, fake.created, 1)
+		assert.Equal(t, map[string]interface{}{
+			"name":                    "alice_smith",
+			"email":                   "alice@example.com",
+			"login_name":              "alice-smith",
+			"first_name":              "Alice",
+			"last_name":               "Smith",
+			"default_secondary_roles": defaultSecondaryRoles,
+			"default_role":            "ANALYST",
+			"default_warehouse":       `"Reporting WH"`,
+			"default_namespace":       `analytics."Public Data"`,
+		}, fake.created[0])
+	})
+
+	t.Run("keeps the defaults of the account when not configured", func(t *testing.T) {
+		fake := &fakeSnowflake{}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		_, err := client.CreateUser(context.Background(), &structs.User{UserName: "bob", Email: "bob@example.com"})
+		require.NoError(t, err)
+		require.Len(t, fake.created, 1)
+		assert.NotContains(t, fake.created[0], "default_role")
+		assert.NotContains(t, fake.created[0], "default_warehouse")
+		assert.NotContains(t, fake.created[0], "default_namespace")
+		assert.Equal(t, defaultSecondaryRoles, fake.created[0]["default_secondary_roles"])
+	})
+}
+
+// accountUsers returns count users named user_00000, user_00001... by their lowercased name
+func accountUsers(count int) map[string]SnowflakeUser {
+	users := make(map[string]SnowflakeUser, count)
+	for i := range count {
+		name := fmt.Sprintf("user_%05d", i)
+		users[name] = SnowflakeUser{Name: strings.ToUpper(name), Email: name + "@example.com"}
+	}
+	return users
+}
+
+// streamedPage is a page of the users streamed by StreamUsers, with its cursor
+type streamedPage struct {
+	userIDs []string
+	cursor  string
+}
+
+// streamUsers streams the users from fromName and returns the pages streamed before the error
+func streamUsers(client *SnowflakeClient, fromName string) ([]streamedPage, error) {
+	var pages []streamedPage
+	err := client.StreamUsers(context.Background(), fromName, func(users []*structs.User, cursor string) error {
+		page := streamedPage{cursor: cursor}
+		for _, user := range users {
+			page.userIDs = append(page.userIDs, user.ID)
+		}
+		pages = append(pages, page)
+		return nil
+	})
+	return pages, err
+}
+
+func TestStreamUsers(t *testing.T) {
+	t.Run("streams the pages with the cursor of their last user", func(t *testing.T) {
+		fake := &fakeSnowflake{users: accountUsers(5), listPageSize: 2}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		pages, err := streamUsers(client, "")
+		require.NoError(t, err)
+		assert.Equal(t, []streamedPage{
+			{userIDs: []string{"user_00000", "user_00001"}, cursor: "USER_00001"},
+			{userIDs: []string{"user_00002", "user_00003"}, cursor: "USER_00003"},
+			{userIDs: []string{"user_00004"}, cursor: "USER_00004"},
+		}, pages)
+	})
+
+	t.Run("resumes from the cursor", func(t *testing.T) {
+		fake := &fakeSnowflake{users: accountUsers(5), listPageSize: 2}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		pages, err := streamUsers(client, "USER_00002")
+		require.NoError(t, err)
+		assert.Equal(t, []streamedPage{
+			{userIDs: []string{"user_00003", "user_00004"}, cursor: "USER_00004"},
+		}, pages)
+	})
+
+	t.Run("fetches the users past the page limit from the last user", func(t *testing.T) {
+		fake := &fakeSnowflake{users: accountUsers(snowflakeUsersPageLimit + 1), listPageSize: 6000}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		pages, err := streamUsers(client, "")
+		require.NoError(t, err)
+		require.Len(t, pages, 3)
+		assert.Len(t, pages[0].userIDs, 6000)
+		assert.Len(t, pages[1].userIDs, snowflakeUsersPageLimit-6000)
+		assert.Equal(t, "USER_09999", pages[1].cursor)
+		assert.Equal(t, streamedPage{userIDs: []string{"user_10000"}, cursor: "USER_10000"}, pages[2])
+	})
+
+	t.Run("stops at the page failing to be fetched", func(t *testing.T) {
+		fake := &fakeSnowflake{users: accountUsers(5), listPageSize: 2, failListing: 2}
+		client := newTestClient(t, fake, map[string]interface{}{})
+
+		pages, err := streamUsers(client, "")
+		assert.ErrorContains(t, err, "unexpected status during pagination")
+		assert.Equal(t, []streamedPage{
+			{userIDs: []string{"user_00000", "user_00001"}, cursor: "USER_00001"},
+		}, pages)
+	})
+
+	t.Run("stops at the error of the page callback", func(t *testing.T) {
+		fake := &fakeSnowflake{users: accountUsers(5), listPageSize: 2}
+		client := newTestClient(t, fake, map[string]interface{}{})
+		errStore := errors.New("store unavailable")
+
+		var cursors []string
+		err := client.StreamUsers(context.Background(), "", func(_ []*structs.User, cursor string) error {
+			cursors = append(cursors, cursor)
+			if len(cursors) == 2 {
+				return errStore
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, errStore)
+		assert.Equal(t, []string{"USER_00001", "USER_00003"}, cursors)
+		assert.Equal(t, 2, fake.listings, "the pages after the failing callback are not fetched")
+	})
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// operationTimeouts are the parsed timeouts of a backend, 0 doesn't bound the call
+type operationTimeouts struct {
+	defaultTimeout time.Duration
+	createTeam     time.Duration
+	addMembers     time.Duration
+	fetchUsers     time.Duration
+}
+
+// parseTimeouts parses the timeouts of the backend config, the operations without their own timeout
+// get the default one
+func parseTimeouts(cfg config.BackendTimeouts) (operationTimeouts, error) {
+	timeouts := operationTimeouts{}
+	for _, timeout := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"default", cfg.Default, &timeouts.defaultTimeout},
+		{"create_team", cfg.CreateTeam, &timeouts.createTeam},
+		{"add_members", cfg.AddMembers, &timeouts.addMembers},
+		{"fetch_users", cfg.FetchUsers, &timeouts.fetchUsers},
+	} {
+		if timeout.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(timeout.value)
+		if err != nil || duration <= 0 {
+			return timeouts, fmt.Errorf("invalid %s timeout %q, expected a positive duration like 30s",
+				timeout.name, timeout.value)
+		}
+		*timeout.dest = duration
+	}
+	for _, dest := range []*time.Duration{&timeouts.createTeam, &timeouts.addMembers, &timeouts.fetchUsers} {
+		if *dest == 0 {
+			*dest = timeouts.defaultTimeout
+		}
+	}
+	return timeouts, nil
+}
+
+// isZero reports whether none of the calls is bounded
+func (t operationTimeouts) isZero() bool {
+	return t == operationTimeouts{}
+}
+
+// WithTimeouts wraps the backend client to run each of its calls under the deadline of the timeouts
+// of the backend, so that a hung backend API can't stall the reconcile. The client is returned as is
+// when the backend has no timeouts.
+func WithTimeouts(backendClient Client, cfg config.BackendTimeouts) (Client, error) {
+	timeouts, err := parseTimeouts(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if timeouts.isZero() {
+		return backendClient, nil
+	}
+	return &timeoutClient{client: backendClient, timeouts: timeouts}, nil
+}
+
+// The timeout client decorates the capabilities of the clients it wraps
+var (
+	_ Wrapper             = (*timeoutClient)(nil)
+	_ TeamRoleClient      = (*timeoutClient)(nil)
+	_ NestedTeamClient    = (*timeoutClient)(nil)
+	_ DependentClient     = (*timeoutClient)(nil)
+	_ MembershipPublisher = (*timeoutClient)(nil)
+	_ PagedClient         = (*timeoutClient)(nil)
+	_ DirectMemberClient  = (*timeoutClient)(nil)
+	_ MemberParamsClient  = (*timeoutClient)(nil)
+)
+
+// timeoutClient bounds the calls of the client it wraps with the timeouts of the backend
+type timeoutClient struct {
+	client   Client
+	timeouts operationTimeouts
+}
+
+// withTimeout returns the context of a call bounded by the timeout, ctx when the timeout is 0
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Unwrap implements Wrapper
+func (c *timeoutClient) Unwrap() Client {
+	return c.client
+}
+
+func (c *timeoutClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.fetchUsers)
+	defer cancel()
+	return c.client.FetchAllUsers(ctx)
+}
+
+func (c *timeoutClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.FetchUserDetails(ctx, userID)
+}
+
+func (c *timeoutClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.CreateUser(ctx, u)
+}
+
+func (c *timeoutClient) DeleteUser(ctx context.Context, userID string) error {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.DeleteUser(ctx, userID)
+}
+
+func (c *timeoutClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.FetchAllTeams(ctx)
+}
+
+func (c *timeoutClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.FetchTeamDetails(ctx, teamID)
+}
+
+func (c *timeoutClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.createTeam)
+	defer cancel()
+	return c.client.CreateTeam(ctx, team)
+}
+
+func (c *timeoutClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.DeleteTeamByID(ctx, teamID)
+}
+
+func (c *timeoutClient) FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.FetchTeamMembersByTeamID(ctx, teamID)
+}
+
+func (c *timeoutClient) ReconcileGroupParams(ctx context.Context, teamID string, groupParams structs.TeamParams) error {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.ReconcileGroupParams(ctx, teamID, groupParams)
+}
+
+func (c *timeoutClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	ctx, cancel := withTimeout(ctx, c.timeouts.addMembers)
+	defer cancel()
+	return c.client.AddUserToTeam(ctx, teamID, userIDs)
+}
+
+func (c *timeoutClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.RemoveUserFromTeam(ctx, teamID, userIDs)
+}
+
+func (c *timeoutClient) HealthCheck(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return c.client.HealthCheck(ctx)
+}
+
+// The capability methods are only called on the timeout client when the wrapped client implements
+// the capability (see As)
+
+func (c *timeoutClient) AddUserToTeamWithRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	roleClient, _ := As[TeamRoleClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.addMembers)
+	defer cancel()
+	return roleClient.AddUserToTeamWithRole(ctx, teamID, userIDs, role)
+}
+
+func (c *timeoutClient) UpdateTeamMemberRole(ctx context.Context, teamID string, userIDs []string, role string) error {
+	roleClient, _ := As[TeamRoleClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return roleClient.UpdateTeamMemberRole(ctx, teamID, userIDs, role)
+}
+
+func (c *timeoutClient) FetchNestedTeams(ctx context.Context, teamID string) ([]string, error) {
+	nestedClient, _ := As[NestedTeamClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return nestedClient.FetchNestedTeams(ctx, teamID)
+}
+
+func (c *timeoutClient) AddNestedTeams(ctx context.Context, teamID string, nestedTeamIDs []string) error {
+	nestedClient, _ := As[NestedTeamClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.addMembers)
+	defer cancel()
+	return nestedClient.AddNestedTeams(ctx, teamID, nestedTeamIDs)
+}
+
+func (c *timeoutClient) RemoveNestedTeams(ctx context.Context, teamID string, nestedTeamIDs []string) error {
+	nestedClient, _ := As[NestedTeamClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return nestedClient.RemoveNestedTeams(ctx, teamID, nestedTeamIDs)
+}
+
+func (c *timeoutClient) ConfigureDependency(ctx context.Context, dependsOn config.Dependant,
+	groupName string) (bool, error) {
+	dependentClient, _ := As[DependentClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return dependentClient.ConfigureDependency(ctx, dependsOn, groupName)
+}
+
+func (c *timeoutClient) PublishMembership(ctx context.Context, membership structs.TeamMembership) error {
+	publisher, _ := As[MembershipPublisher](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return publisher.PublishMembership(ctx, membership)
+}
+
+func (c *timeoutClient) FetchDirectTeamMembers(ctx context.Context, teamID string) (map[string]*structs.User, error) {
+	directClient, _ := As[DirectMemberClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return directClient.FetchDirectTeamMembers(ctx, teamID)
+}
+
+func (c *timeoutClient) RemoveDirectTeamMembers(ctx context.Context, teamID string, userIDs []string) error {
+	directClient, _ := As[DirectMemberClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return directClient.RemoveDirectTeamMembers(ctx, teamID, userIDs)
+}
+
+func (c *timeoutClient) ReconcileMemberParams(ctx context.Context, teamID string, groupParams []structs.TeamParams,
+	memberIDs, removedIDs []string) error {
+	paramsClient, _ := As[MemberParamsClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.addMembers)
+	defer cancel()
+	return paramsClient.ReconcileMemberParams(ctx, teamID, groupParams, memberIDs, removedIDs)
+}
+
+// The pages are streamed under the timeout of the whole walk, page callbacks included
+
+func (c *timeoutClient) ForEachUserPage(ctx context.Context, fn func(users []*structs.User) error) error {
+	pagedClient, _ := As[PagedClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.fetchUsers)
+	defer cancel()
+	return pagedClient.ForEachUserPage(ctx, fn)
+}
+
+func (c *timeoutClient) ForEachTeamPage(ctx context.Context, fn func(teams []structs.Team) error) error {
+	pagedClient, _ := As[PagedClient](c.client)
+	ctx, cancel := withTimeout(ctx, c.timeouts.defaultTimeout)
+	defer cancel()
+	return pagedClient.ForEachTeamPage(ctx, fn)
+}
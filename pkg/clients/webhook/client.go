@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/constants"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+var (
+	membershipsMu sync.Mutex
+	// memberships are the members of the teams delivered by the last membership events, shared by
+	// all the clients of a backend so that the next reconcile diffs against them. They are kept in
+	// memory, the first event after a restart reports all the members as added.
+	memberships = make(map[string][]string)
+)
+
+// WebhookClient pushes the membership of the teams to a webhook, for the systems usernaut does not
+// integrate with. The users and teams are identified by their email and name.
+type WebhookClient struct {
+	client    heimdall.Doer
+	backend   string
+	url       string
+	secret    []byte
+	headers   map[string]string
+	healthURL string
+}
+
+func NewClient(webhookAppConfig map[string]interface{},
+	connectionPoolConfig httpclient.ConnectionPoolConfig,
+	hystrixResiliencyConfig httpclient.HystrixResiliencyConfig) (*WebhookClient, error) {
+
+	webhookConfig := WebhookConfig{}
+	if err := utils.MapToStruct(webhookAppConfig, &webhookConfig); err != nil {
+		return nil, err
+	}
+	if webhookConfig.URL == "" {
+		return nil, errors.New("webhook configuration is missing required field: url")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"webhook_"+connectionPoolConfig.BackendName,
+		connectionPoolConfig,
+		hystrixResiliencyConfig,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	headers := map[string]string{constants.ContentTypeHeaderKey: "application/json"}
+	for key, value := range webhookConfig.Headers {
+		headers[key] = value
+	}
+
+	return &WebhookClient{
+		client:    client,
+		backend:   connectionPoolConfig.BackendName,
+		url:       webhookConfig.URL,
+		secret:    []byte(webhookConfig.Secret),
+		headers:   headers,
+		healthURL: webhookConfig.HealthURL,
+	}, nil
+}
+
+// HealthCheck requests the health URL of the webhook, the webhook is considered healthy without one
+// as the receivers may only accept the events
+func (wC *WebhookClient) HealthCheck(ctx context.Context) error {
+	if wC.healthURL == "" {
+		return nil
+	}
+	req, err := request.NewRequest(ctx, http.MethodGet, wC.healthURL, nil)
+	if err != nil {
+		return err
+	}
+	req.SetHeaders(wC.headers)
+	_, respCode, err := req.MakeRequest(wC.client, "backend.webhook.HealthCheck", "webhook")
+	if err != nil {
+		return fmt.Errorf("webhook health check failed: %w", err)
+	}
+	if respCode < http.StatusOK || respCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook health check failed with response code: %s", http.StatusText(respCode))
+	}
+	return nil
+}
+
+// send POSTs the event to the webhook, signed with the secret of the webhook
+func (wC *WebhookClient) send(ctx context.Context, eventName string, event any) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string, len(wC.headers)+2)
+	for key, value := range wC.headers {
+		headers[key] = value
+	}
+	headers[EventHeader] = eventName
+	if len(wC.secret) > 0 {
+		headers[SignatureHeader] = Sign(wC.secret, body)
+	}
+
+	req, err := request.NewRequest(ctx, http.MethodPost, wC.url, body)
+	if err != nil {
+		return err
+	}
+	req.SetHeaders(headers)
+
+	resp, respCode, err := req.MakeRequest(wC.client, "backend.webhook."+eventName, "webhook")
+	if err != nil {
+		return fmt.Errorf("failed to send the %s event to the webhook: %w", eventName, err)
+	}
+	if respCode < http.StatusOK || respCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook rejected the %s event with response code %d: %s", eventName, respCode, string(resp))
+	}
+	return nil
+}
+
+// Sign returns the signature of the body sent in the SignatureHeader, receivers verify the events
+// by comparing it with the signature of the body they received
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// membershipKey is the key of the delivered membership of the team
+func (wC *WebhookClient) membershipKey(teamID string) string {
+	return wC.backend + "/" + teamID
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// receivedEvent is an event received by the test webhook
+type receivedEvent struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func newTestClient(t *testing.T, status *int) (*WebhookClient, *[]receivedEvent) {
+	t.Helper()
+	events := &[]receivedEvent{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*events = append(*events, receivedEvent{
+			event:     r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      body,
+		})
+		w.WriteHeader(*status)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(map[string]interface{}{"url": server.URL, "secret": "s3cr3t"},
+		httpclient.ConnectionPoolConfig{Timeout: 1000, KeepAliveTimeout: 1000, MaxIdleConnections: 1, BackendName: t.Name()},
+		httpclient.HystrixResiliencyConfig{MaxConcurrentRequests: 10, RequestVolumeThreshold: 100,
+			CircuitBreakerSleepWindow: 1000, ErrorPercentThreshold: 100, CircuitBreakerTimeout: 1000})
+	require.NoError(t, err)
+	return client, events
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "url")
+}
+
+func TestPublishMembership(t *testing.T) {
+	status := http.StatusOK
+	client, events := newTestClient(t, &status)
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "team-a"})
+	require.NoError(t, err)
+	user, err := client.CreateUser(ctx, &structs.User{UserName: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.ID)
+
+	members, err := client.FetchTeamMembersByTeamID(ctx, team.ID)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	require.NoError(t, client.PublishMembership(ctx, structs.TeamMembership{
+		GroupName: "team-a",
+		TeamID:    team.ID,
+		Members:   []string{user.ID},
+		Added:     []string{user.ID},
+	}))
+
+	require.Len(t, *events, 1)
+	received := (*events)[0]
+	assert.Equal(t, EventMembership, received.event)
+	assert.Equal(t, Sign([]byte("s3cr3t"), received.body), received.signature)
+
+	var event MembershipEvent
+	require.NoError(t, json.Unmarshal(received.body, &event))
+	assert.Equal(t, "team-a", event.GroupName)
+	assert.Equal(t, t.Name(), event.Backend)
+	assert.Equal(t, []string{"alice@example.com"}, event.Members)
+	assert.Equal(t, []string{"alice@example.com"}, event.Added)
+	assert.Equal(t, []string{}, event.Removed)
+
+	// the delivered membership is the base of the next diff
+	members, err = client.FetchTeamMembersByTeamID(ctx, team.ID)
+	require.NoError(t, err)
+	assert.Contains(t, members, "alice@example.com")
+}
+
+func TestPublishMembershipRejected(t *testing.T) {
+	status := http.StatusBadRequest
+	client, _ := newTestClient(t, &status)
+	ctx := context.Background()
+
+	err := client.PublishMembership(ctx, structs.TeamMembership{TeamID: "team-b", Members: []string{"bob@example.com"}})
+	assert.ErrorContains(t, err, "response code 400")
+
+	// the membership which was not delivered is sent again with the same changes
+	members, err := client.FetchTeamMembersByTeamID(ctx, "team-b")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func TestDeleteTeam(t *testing.T) {
+	status := http.StatusNoContent
+	client, events := newTestClient(t, &status)
+	ctx := context.Background()
+
+	require.NoError(t, client.PublishMembership(ctx, structs.TeamMembership{TeamID: "team-c",
+		Members: []string{"carol@example.com"}}))
+	require.NoError(t, client.DeleteTeamByID(ctx, "team-c"))
+	require.NoError(t, client.DeleteUser(ctx, "carol@example.com"))
+
+	require.Len(t, *events, 3)
+	var deletion DeletionEvent
+	require.NoError(t, json.Unmarshal((*events)[1].body, &deletion))
+	assert.Equal(t, DeletionEvent{Event: EventTeamDeleted, Backend: t.Name(), Team: "team-c",
+		Timestamp: deletion.Timestamp}, deletion)
+	assert.Equal(t, EventUserDeleted, (*events)[2].event)
+
+	members, err := client.FetchTeamMembersByTeamID(ctx, "team-c")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
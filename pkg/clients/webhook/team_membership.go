@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// FetchTeamMembersByTeamID returns the members of the team delivered by its last membership event
+func (wC *WebhookClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	membershipsMu.Lock()
+	defer membershipsMu.Unlock()
+
+	delivered := memberships[wC.membershipKey(teamID)]
+	members := make(map[string]*structs.User, len(delivered))
+	for _, userID := range delivered {
+		members[userID] = &structs.User{ID: userID, Email: userID}
+	}
+	return members, nil
+}
+
+// AddUserToTeam is a no-op, the added users are sent with the membership of the team
+func (wC *WebhookClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return nil
+}
+
+// RemoveUserFromTeam is a no-op, the removed users are sent with the membership of the team
+func (wC *WebhookClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return nil
+}
+
+// PublishMembership sends the membership event of the team. The membership is recorded once the
+// webhook accepted it, so that an event which failed is sent again with the same changes.
+func (wC *WebhookClient) PublishMembership(ctx context.Context, membership structs.TeamMembership) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.webhook.PublishMembership")
+	defer span.Finish()
+
+	event := &MembershipEvent{
+		Event:     EventMembership,
+		Backend:   wC.backend,
+		GroupName: membership.GroupName,
+		Team:      membership.TeamID,
+		Members:   nonNil(membership.Members),
+		Added:     nonNil(membership.Added),
+		Removed:   nonNil(membership.Removed),
+		Timestamp: time.Now().UTC(),
+	}
+	if err := wC.send(ctx, EventMembership, event); err != nil {
+		return err
+	}
+	logger.Logger(ctx).WithField("team", membership.TeamID).WithField("members", len(event.Members)).
+		Info("sent the team membership to the webhook")
+
+	membershipsMu.Lock()
+	defer membershipsMu.Unlock()
+	memberships[wC.membershipKey(membership.TeamID)] = slices.Clone(event.Members)
+	return nil
+}
+
+// nonNil returns an empty slice for nil, so that the lists of the events are never null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+func (wC *WebhookClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	// the teams are identified by their name, there is nothing to adopt
+	return map[string]structs.Team{}, nil
+}
+
+func (wC *WebhookClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	return &structs.Team{ID: teamID, Name: teamID}, nil
+}
+
+// CreateTeam identifies the team by its name in the events, nothing is sent to the webhook until its
+// membership is published
+func (wC *WebhookClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	return &structs.Team{
+		ID:          team.Name,
+		Name:        team.Name,
+		Description: team.Description,
+	}, nil
+}
+
+// DeleteTeamByID sends the team_deleted event of the team and forgets its membership
+func (wC *WebhookClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.webhook.DeleteTeamByID")
+	defer span.Finish()
+
+	if err := wC.send(ctx, EventTeamDeleted, &DeletionEvent{
+		Event:     EventTeamDeleted,
+		Backend:   wC.backend,
+		Team:      teamID,
+		Timestamp: time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+
+	membershipsMu.Lock()
+	defer membershipsMu.Unlock()
+	delete(memberships, wC.membershipKey(teamID))
+	return nil
+}
+
+// ReconcileGroupParams is a no-op, the webhook has no group params
+func (wC *WebhookClient) ReconcileGroupParams(ctx context.Context, teamID string,
+	groupParams structs.TeamParams) error {
+	return nil
+}
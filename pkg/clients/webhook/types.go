@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import "time"
+
+// Events POSTed to the webhook
+const (
+	EventMembership  = "membership"
+	EventTeamDeleted = "team_deleted"
+	EventUserDeleted = "user_deleted"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 signature of the body of the events, as
+	// sha256=<hex digest>, when the webhook has a secret
+	SignatureHeader = "X-Usernaut-Signature"
+	// EventHeader holds the event of the body
+	EventHeader = "X-Usernaut-Event"
+)
+
+// WebhookConfig is the connection of a webhook backend, read from the backend configuration
+type WebhookConfig struct {
+	// URL receives the events as JSON POST requests
+	URL string `json:"url"`
+	// Secret signs the body of the events
+	Secret string `json:"secret"`
+	// Headers are sent with every event, e.g. an authorization header
+	Headers map[string]string `json:"headers"`
+	// HealthURL is requested with GET by the health checks, the webhook is not checked when unset
+	HealthURL string `json:"health_url"`
+}
+
+// MembershipEvent is the membership of a team, sent on every reconcile of the team. Added and
+// Removed are the changes since the previous event delivered for the team by the operator.
+type MembershipEvent struct {
+	Event     string    `json:"event"`
+	Backend   string    `json:"backend"`
+	GroupName string    `json:"group_name"`
+	Team      string    `json:"team"`
+	Members   []string  `json:"members"`
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeletionEvent is sent when a team is deleted or a user is offboarded
+type DeletionEvent struct {
+	Event     string    `json:"event"`
+	Backend   string    `json:"backend"`
+	Team      string    `json:"team,omitempty"`
+	User      string    `json:"user,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
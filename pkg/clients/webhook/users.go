@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+func (wC *WebhookClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	// the webhook has no users of its own
+	return make(map[string]*structs.User), make(map[string]*structs.User), nil
+}
+
+func (wC *WebhookClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	// the users are identified by their email
+	return &structs.User{ID: userID, Email: userID}, nil
+}
+
+// CreateUser identifies the user by its email in the events, nothing is sent to the webhook
+func (wC *WebhookClient) CreateUser(ctx context.Context, u *structs.User) (*structs.User, error) {
+	return &structs.User{
+		ID:       u.Email,
+		UserName: u.UserName,
+		Email:    u.Email,
+	}, nil
+}
+
+// DeleteUser sends the user_deleted event of the offboarded user
+func (wC *WebhookClient) DeleteUser(ctx context.Context, userID string) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "backend.webhook.DeleteUser")
+	defer span.Finish()
+
+	return wC.send(ctx, EventUserDeleted, &DeletionEvent{
+		Event:     EventUserDeleted,
+		Backend:   wC.backend,
+		User:      userID,
+		Timestamp: time.Now().UTC(),
+	})
+}
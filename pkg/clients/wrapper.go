@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+// Wrapper is implemented by the decorators of a backend client, e.g. the client bounding the calls
+// with the backend timeouts. The capabilities of a wrapped client are looked up with As.
+type Wrapper interface {
+	Client
+	// Returns the wrapped client
+	Unwrap() Client
+}
+
+// As returns the client as the capability interface T, e.g. TeamRoleClient, when the backend client
+// implements it under its wrappers. A wrapper implementing T itself is returned for the capabilities
+// of the client it wraps, so that it decorates their calls too, otherwise the wrapped client is.
+func As[T any](backendClient Client) (T, bool) {
+	if wrapper, ok := backendClient.(Wrapper); ok {
+		capability, ok := As[T](wrapper.Unwrap())
+		if !ok {
+			return capability, false
+		}
+		if decorated, ok := backendClient.(T); ok {
+			return decorated, true
+		}
+		return capability, true
+	}
+	capability, ok := backendClient.(T)
+	return capability, ok
+}
@@ -4,4 +4,13 @@ const (
 	ContentTypeHeaderKey = "Content-Type"
 	// force reconcile label constant
 	ForceReconcileLabel = "operator.dataverse.redhat.com/force-reconcile"
+	// ApprovedByAnnotation names the approver of a group awaiting an approval
+	ApprovedByAnnotation = "operator.dataverse.redhat.com/approved-by"
+	// PreventDeletionAnnotation set to "true" keeps a deleted group and its backend teams until it is removed
+	PreventDeletionAnnotation = "usernaut.dev/prevent-deletion"
+
+	// GroupDefaultsConfigMapName is the per-namespace ConfigMap read by the Group mutating webhook
+	GroupDefaultsConfigMapName = "usernaut-group-defaults"
+	// GroupDefaultsConfigMapKey is the data key holding the YAML defaults in that ConfigMap
+	GroupDefaultsConfigMapKey = "defaults.yaml"
 )
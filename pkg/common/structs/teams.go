@@ -27,3 +27,13 @@ func (t *Team) GetDescription() string {
 func (t *Team) GetRole() string {
 	return t.Role
 }
+
+// TeamMembership is the membership of a team resolved by a reconcile, with the changes applied to it.
+// The members are backend user IDs.
+type TeamMembership struct {
+	GroupName string
+	TeamID    string
+	Members   []string
+	Added     []string
+	Removed   []string
+}
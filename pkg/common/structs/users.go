@@ -1,5 +1,11 @@
 package structs
 
+import "errors"
+
+// ErrUserAlreadyExists is wrapped by the backend clients when CreateUser fails because the user
+// already exists in the backend, the reconcile then looks the user up instead of failing
+var ErrUserAlreadyExists = errors.New("user already exists in the backend")
+
 type User struct {
 	ID          string `json:"id,omitempty"`
 	UserName    string `json:"username,omitempty"`
@@ -17,11 +17,20 @@ limitations under the License.
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
 	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/redhat-data-and-ai/usernaut/pkg/tracing"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Config represents the top-level configuration structure
@@ -33,12 +42,15 @@ type AppConfig struct {
 	Pattern                             map[string][]PatternEntry `yaml:"pattern"`
 	UsernautUserOffboardingInterval     string                    `yaml:"usernautUserOffboardingInterval"`
 	OffboardUserExclusionListConfigPath string                    `yaml:"offboardUserExclusionListConfigPath"`
+	GroupMembershipExpiryInterval       string                    `yaml:"groupMembershipExpiryInterval"`
 	HttpClient                          struct {
 		ConnectionPoolConfig    httpclient.ConnectionPoolConfig    `yaml:"connectionPoolConfig"`
 		HystrixResiliencyConfig httpclient.HystrixResiliencyConfig `yaml:"hystrixResiliencyConfig"`
 	} `yaml:"httpClient"`
 	APIServer        APIServerConfig               `yaml:"apiServer"`
 	ControllerConfig ControllerConfig              `yaml:"controllerConfig"`
+	BackendSelectors []BackendSelector             `yaml:"backendSelectors"`
+	Tracing          tracing.Config                `yaml:"tracing"`
 	BackendMap       map[string]map[string]Backend `yaml:"-"`
 }
 
@@ -51,6 +63,92 @@ type APIServerConfig struct {
 // ControllerConfig represents controller-specific configuration
 type ControllerConfig struct {
 	MaxConcurrentReconciles int `yaml:"maxConcurrentReconciles"`
+	// MaxConcurrentBackends bounds how many backends of a single group are processed in parallel
+	MaxConcurrentBackends int `yaml:"maxConcurrentBackends"`
+	// ResyncInterval is how often a group is reconciled without spec changes, correcting manual
+	// edits of the backend teams. A duration like "8h", defaults to 8h when not set.
+	ResyncInterval string `yaml:"resyncInterval"`
+	// BackendRetryBaseDelay is the delay before a failed backend of a group is first retried, doubled
+	// on each consecutive failure up to BackendRetryMaxDelay. Durations, default to 30s and 1h.
+	BackendRetryBaseDelay string `yaml:"backendRetryBaseDelay"`
+	BackendRetryMaxDelay  string `yaml:"backendRetryMaxDelay"`
+	// RateLimiter tunes the workqueue rate limiter of the Group and User controllers
+	RateLimiter RateLimiterConfig `yaml:"rateLimiter"`
+	// MassRemovalGuard refuses reconciles removing too many members of a backend team
+	MassRemovalGuard MassRemovalGuardConfig `yaml:"massRemovalGuard"`
+	// Approval holds new groups and large membership changes until an approver annotates the group
+	Approval ApprovalConfig `yaml:"approval"`
+	// Sharding splits the groups between several operator replicas
+	Sharding ShardingConfig `yaml:"sharding"`
+	// ShutdownGracePeriod is how long the in-flight reconciles and periodic tasks may run on shutdown
+	// to reach a checkpoint before they are canceled. A duration, defaults to 30s.
+	ShutdownGracePeriod string `yaml:"shutdownGracePeriod"`
+	// HealthCheck checks LDAP and the enabled backends for the readiness probe and the metrics
+	HealthCheck HealthCheckConfig `yaml:"healthCheck"`
+}
+
+// HealthCheckConfig configures the health checks of LDAP and the enabled backends. Interval and
+// Timeout are durations, they default to 1m and 10s. IgnoreInReadiness keeps the replica ready while
+// a backend is unhealthy, the health is still exported as metrics and by the API server.
+type HealthCheckConfig struct {
+	Interval          string `yaml:"interval"`
+	Timeout           string `yaml:"timeout"`
+	IgnoreInReadiness bool   `yaml:"ignoreInReadiness"`
+}
+
+// ShardingConfig splits the reconciliation of the groups between Shards replicas, each group being
+// owned by the shard its group name hashes to. A replica reads its shard from the SHARD_INDEX
+// environment variable, or from the ordinal of its StatefulSet pod name. 0 or 1 disables the sharding.
+type ShardingConfig struct {
+	Shards int `yaml:"shards"`
+}
+
+// ApprovalConfig decides which reconciles wait for the approved-by annotation of the group before
+// changing the backend teams. A zero value disables the approvals.
+type ApprovalConfig struct {
+	// RequireForNewGroups holds the groups never reconciled successfully
+	RequireForNewGroups bool `yaml:"requireForNewGroups"`
+	// MaxMemberChanges is the maximum number of members added and removed without an approval
+	MaxMemberChanges int `yaml:"maxMemberChanges"`
+	// Approvers are the users allowed to set the approved-by annotation of a group
+	Approvers []string `yaml:"approvers"`
+	// ApproverGroups are the Kubernetes groups whose users are allowed to set the approved-by annotation
+	ApproverGroups []string `yaml:"approverGroups"`
+}
+
+// Enabled tells whether some reconciles wait for an approval
+func (a ApprovalConfig) Enabled() bool {
+	return a.RequireForNewGroups || a.MaxMemberChanges > 0
+}
+
+// IsApprover tells whether the Kubernetes user, or one of its groups, is allowed to approve a group
+func (a ApprovalConfig) IsApprover(username string, groups []string) bool {
+	if slices.Contains(a.Approvers, username) {
+		return true
+	}
+	return slices.ContainsFunc(groups, func(group string) bool {
+		return slices.Contains(a.ApproverGroups, group)
+	})
+}
+
+// MassRemovalGuardConfig bounds the members removed from a backend team in a single reconcile, which
+// protects the teams against LDAP outages or bad spec edits. A zero value disables the threshold.
+// The force reconcile label of a group overrides the guard.
+type MassRemovalGuardConfig struct {
+	// MaxRemovalPercent is the maximum share of the team members removed at once
+	MaxRemovalPercent int `yaml:"maxRemovalPercent"`
+	// MaxRemovals is the maximum number of team members removed at once
+	MaxRemovals int `yaml:"maxRemovals"`
+}
+
+// RateLimiterConfig configures the rate limiter of a controller workqueue. Failed requests are retried
+// with a per-item exponential backoff from BaseDelay to MaxDelay, and all requests are limited to QPS
+// with bursts of Burst. Unset values use the controller-runtime defaults (5ms, 1000s, 10 and 100).
+type RateLimiterConfig struct {
+	BaseDelay string `yaml:"baseDelay"`
+	MaxDelay  string `yaml:"maxDelay"`
+	QPS       int    `yaml:"qps"`
+	Burst     int    `yaml:"burst"`
 }
 
 type CORSConfig struct {
@@ -67,10 +165,12 @@ type AuthConfig struct {
 	BasicUsers []BasicUser `yaml:"basic_users" mapstructure:"basic_users"`
 }
 
-// PatternEntry represents the input and output pattern of group names
+// PatternEntry represents the input and output pattern of group names.
+// Patterns with a higher priority are tried first, patterns with the same priority in configuration order.
 type PatternEntry struct {
-	Input  string `yaml:"input"`
-	Output string `yaml:"output"`
+	Input    string `yaml:"input"`
+	Output   string `yaml:"output"`
+	Priority int    `yaml:"priority,omitempty"`
 }
 
 // App represents the application configuration
@@ -88,6 +188,126 @@ type Backend struct {
 	Enabled    bool                   `yaml:"enabled"`
 	DependsOn  Dependant              `yaml:"depends_on,omitempty" mapstructure:"depends_on,omitempty"`
 	Connection map[string]interface{} `yaml:"connection"`
+	// UsernameNormalization adapts the usernames to the constraints of the backend when users are created
+	UsernameNormalization UsernameNormalization `yaml:"username_normalization,omitempty" mapstructure:"username_normalization,omitempty"` //nolint:lll
+	// RateLimit throttles the API requests sent to the backend by all the reconciles
+	RateLimit BackendRateLimit `yaml:"rate_limit,omitempty" mapstructure:"rate_limit,omitempty"`
+	// MembershipBatchSize is the largest number of users added to or removed from a team in a single
+	// membership call, defaults to 100
+	MembershipBatchSize int `yaml:"membership_batch_size,omitempty" mapstructure:"membership_batch_size,omitempty"`
+	// DefaultRoles are the roles given to the users and teams created in the backend, on the backends
+	// assigning one at creation (e.g. the Fivetran account and team roles)
+	DefaultRoles BackendDefaultRoles `yaml:"default_roles,omitempty" mapstructure:"default_roles,omitempty"`
+	// Timeouts bound the calls of the backend client, so that a hung backend API can't stall a reconcile
+	Timeouts BackendTimeouts `yaml:"timeouts,omitempty" mapstructure:"timeouts,omitempty"`
+	// ResponseCacheTTL is how long the teams and team members fetched from the backend are served
+	// from the cache, a duration like "2m". Empty disables the response cache.
+	ResponseCacheTTL string `yaml:"response_cache_ttl,omitempty" mapstructure:"response_cache_ttl,omitempty"`
+	// DirectMemberAudit audits the members added directly to the teams whose membership is managed by
+	// the dependency of the backend, e.g. gitlab with LDAP sync: "report" lists them in the status of
+	// the group and "remove" also removes them. Empty disables the audit.
+	DirectMemberAudit string `yaml:"direct_member_audit,omitempty" mapstructure:"direct_member_audit,omitempty"`
+}
+
+// Modes of Backend.DirectMemberAudit
+const (
+	DirectMemberAuditReport = "report"
+	DirectMemberAuditRemove = "remove"
+)
+
+// BackendTimeouts are the deadlines of the calls of a backend client, as durations like "30s".
+// Default applies to the calls without their own timeout, and an empty timeout doesn't bound the call.
+type BackendTimeouts struct {
+	Default    string `yaml:"default"`
+	CreateTeam string `yaml:"create_team" mapstructure:"create_team"`
+	AddMembers string `yaml:"add_members" mapstructure:"add_members"`
+	FetchUsers string `yaml:"fetch_users" mapstructure:"fetch_users"`
+}
+
+// BackendDefaultRoles are the roles of the users and teams created in a backend, the groups override
+// them with the user_role and team_role group params. Empty roles fall back to the default of the
+// backend type, Account Reviewer on Fivetran.
+type BackendDefaultRoles struct {
+	User string `yaml:"user"`
+	Team string `yaml:"team"`
+	// Member is the team role of the members without an explicit role, on the backends with team
+	// member roles, e.g. developer on GitLab
+	Member string `yaml:"member"`
+}
+
+// BackendRateLimit is a token bucket refilled at RequestsPerSecond and holding up to Burst requests,
+// Burst defaults to one second of requests. A zero value disables the rate limiting.
+type BackendRateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second" mapstructure:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// UsernameNormalization rewrites the LDAP uid into a username the backend accepts. The rules are
+// applied in order: lowercasing, replacing the invalid characters, then truncating. A zero value
+// keeps the uid as is.
+type UsernameNormalization struct {
+	Lowercase bool `yaml:"lowercase"`
+	// InvalidCharacters is a regular expression matching the characters to replace, e.g. "[^a-z0-9_]"
+	InvalidCharacters string `yaml:"invalid_characters" mapstructure:"invalid_characters"`
+	// Replacement replaces each match of InvalidCharacters, they are removed when empty
+	Replacement string `yaml:"replacement"`
+	// MaxLength truncates the usernames to this number of characters, 0 disables the truncation. The
+	// truncated usernames end with Replacement and a hash of the full username, so that the uids
+	// sharing a prefix don't collide.
+	MaxLength int `yaml:"max_length" mapstructure:"max_length"`
+
+	// invalidCharacters is InvalidCharacters compiled when the configuration is loaded
+	invalidCharacters *regexp.Regexp
+}
+
+// truncationHashLength is the number of hex characters of the hash ending the truncated usernames
+const truncationHashLength = 6
+
+// compile compiles the expression of the invalid characters
+func (n *UsernameNormalization) compile() error {
+	if n.InvalidCharacters == "" {
+		return nil
+	}
+	invalid, err := regexp.Compile(n.InvalidCharacters)
+	if err != nil {
+		return err
+	}
+	n.invalidCharacters = invalid
+	return nil
+}
+
+// Normalize applies the normalization rules to the username
+func (n UsernameNormalization) Normalize(username string) string {
+	if n.Lowercase {
+		username = strings.ToLower(username)
+	}
+	if n.InvalidCharacters != "" {
+		invalid := n.invalidCharacters
+		if invalid == nil {
+			// rules built without loading the configuration are compiled on each call
+			invalid, _ = regexp.Compile(n.InvalidCharacters)
+		}
+		if invalid != nil {
+			username = invalid.ReplaceAllString(username, n.Replacement)
+		}
+	}
+	return n.truncate(username)
+}
+
+// truncate truncates the username to MaxLength characters, replacing its end with a hash of the full
+// username
+func (n UsernameNormalization) truncate(username string) string {
+	runes := []rune(username)
+	if n.MaxLength <= 0 || len(runes) <= n.MaxLength {
+		return username
+	}
+	sum := sha256.Sum256([]byte(username))
+	hash := hex.EncodeToString(sum[:])[:truncationHashLength]
+	suffix := []rune(n.Replacement + hash)
+	if len(suffix) >= n.MaxLength {
+		return hash[:min(n.MaxLength, len(hash))]
+	}
+	return string(runes[:n.MaxLength-len(suffix)]) + string(suffix)
 }
 
 type Dependant struct {
@@ -95,6 +315,146 @@ type Dependant struct {
 	Type string `yaml:"type"`
 }
 
+// Deletion policies of the backend selectors
+const (
+	// SelectorDeletionPolicyRetain keeps the team of a backend the selector stops attaching to a group
+	SelectorDeletionPolicyRetain = "Retain"
+	// SelectorDeletionPolicyDelete deletes it like the team of a backend removed from the group spec
+	SelectorDeletionPolicyDelete = "Delete"
+)
+
+// BackendSelector attaches backends to every group whose labels match the selector,
+// in addition to the backends listed in the group spec
+type BackendSelector struct {
+	// Selector is a label selector like "tier=data" or "env in (prod,staging)"
+	Selector string       `yaml:"selector"`
+	Backends []BackendRef `yaml:"backends"`
+	// DeletionPolicy decides what happens to the team of a backend once the selector stops attaching
+	// it to a group, Retain (default) or Delete
+	DeletionPolicy string `yaml:"deletionPolicy"`
+}
+
+// BackendRef identifies a configured backend by name and type
+type BackendRef struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// SelectedBackends returns the backends attached by the backend selectors matching the labels
+// of a group, in configuration order and without duplicates. Invalid selectors never match,
+// they are rejected when the configuration is loaded.
+func (c *AppConfig) SelectedBackends(groupLabels map[string]string) []BackendRef {
+	selected := make([]BackendRef, 0)
+	for _, backendSelector := range c.BackendSelectors {
+		selector, err := labels.Parse(backendSelector.Selector)
+		if err != nil || !selector.Matches(labels.Set(groupLabels)) {
+			continue
+		}
+		for _, backend := range backendSelector.Backends {
+			if !slices.Contains(selected, backend) {
+				selected = append(selected, backend)
+			}
+		}
+	}
+	return selected
+}
+
+// SelectorDeletionPolicy returns the deletion policy of a backend attached to a group by the backend
+// selectors matching its labels, Delete only when a matching selector attaching it has the Delete policy
+func (c *AppConfig) SelectorDeletionPolicy(groupLabels map[string]string, backend BackendRef) string {
+	for _, backendSelector := range c.BackendSelectors {
+		if backendSelector.DeletionPolicy != SelectorDeletionPolicyDelete ||
+			!slices.Contains(backendSelector.Backends, backend) {
+			continue
+		}
+		selector, err := labels.Parse(backendSelector.Selector)
+		if err == nil && selector.Matches(labels.Set(groupLabels)) {
+			return SelectorDeletionPolicyDelete
+		}
+	}
+	return SelectorDeletionPolicyRetain
+}
+
+// validateBackendSelectors checks that the backend selectors parse and reference configured backends
+func (c *AppConfig) validateBackendSelectors() error {
+	for _, backendSelector := range c.BackendSelectors {
+		if _, err := labels.Parse(backendSelector.Selector); err != nil {
+			return fmt.Errorf("invalid backend selector %q: %w", backendSelector.Selector, err)
+		}
+		switch backendSelector.DeletionPolicy {
+		case "", SelectorDeletionPolicyRetain, SelectorDeletionPolicyDelete:
+		default:
+			return fmt.Errorf("invalid deletion policy %q of backend selector %q, expected Retain or Delete",
+				backendSelector.DeletionPolicy, backendSelector.Selector)
+		}
+		for _, backend := range backendSelector.Backends {
+			if _, ok := c.BackendMap[backend.Type][backend.Name]; !ok {
+				return fmt.Errorf("backend selector %q references unknown backend %s/%s",
+					backendSelector.Selector, backend.Type, backend.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateUsernameNormalizations checks the username normalization rules of the backends
+func (c *AppConfig) validateUsernameNormalizations() error {
+	for i := range c.Backends {
+		backend := &c.Backends[i]
+		normalization := &backend.UsernameNormalization
+		if err := normalization.compile(); err != nil {
+			return fmt.Errorf("invalid username normalization of backend %s/%s: %w", backend.Type, backend.Name, err)
+		}
+		if normalization.MaxLength < 0 {
+			return fmt.Errorf("invalid username normalization of backend %s/%s: max_length must not be negative",
+				backend.Type, backend.Name)
+		}
+	}
+	return nil
+}
+
+// validateRateLimits checks the rate limits of the backends
+func (c *AppConfig) validateRateLimits() error {
+	for _, backend := range c.Backends {
+		if backend.RateLimit.RequestsPerSecond < 0 || backend.RateLimit.Burst < 0 {
+			return fmt.Errorf("invalid rate limit of backend %s/%s: requests_per_second and burst must not be negative",
+				backend.Type, backend.Name)
+		}
+	}
+	return nil
+}
+
+// validateApproval checks that some users are allowed to approve the groups held for an approval
+func (c *AppConfig) validateApproval() error {
+	approval := c.ControllerConfig.Approval
+	if approval.Enabled() && len(approval.Approvers) == 0 && len(approval.ApproverGroups) == 0 {
+		return errors.New("invalid approval config: approvers or approverGroups must be set when approvals are enabled")
+	}
+	return nil
+}
+
+// validateSharding checks that the shards share their cache, which only the redis cache driver does
+func (c *AppConfig) validateSharding() error {
+	if c.ControllerConfig.Sharding.Shards > 1 && c.Cache.Driver != cache.DriverRedis {
+		return fmt.Errorf("invalid sharding config: %d shards require the %s cache driver, got %q",
+			c.ControllerConfig.Sharding.Shards, cache.DriverRedis, c.Cache.Driver)
+	}
+	return nil
+}
+
+// validateDirectMemberAudits checks the direct member audit modes of the backends
+func (c *AppConfig) validateDirectMemberAudits() error {
+	for _, backend := range c.Backends {
+		switch backend.DirectMemberAudit {
+		case "", DirectMemberAuditReport, DirectMemberAuditRemove:
+		default:
+			return fmt.Errorf("invalid direct_member_audit %q of backend %s/%s: must be %s or %s",
+				backend.DirectMemberAudit, backend.Type, backend.Name, DirectMemberAuditReport, DirectMemberAuditRemove)
+		}
+	}
+	return nil
+}
+
 func (b *Backend) GetStringConnection(name string, defaultValue string) string {
 	if val, ok := b.Connection[name].(string); ok {
 		return val
@@ -112,6 +472,11 @@ func LoadConfig(env string) (*AppConfig, error) {
 		return nil, err
 	}
 
+	// the expressions are compiled into the backends copied to the map
+	if err := config.validateUsernameNormalizations(); err != nil {
+		return nil, err
+	}
+
 	// convert backends to a map for easier access
 	config.BackendMap = make(map[string]map[string]Backend)
 	for _, backend := range config.Backends {
@@ -121,6 +486,22 @@ func LoadConfig(env string) (*AppConfig, error) {
 		config.BackendMap[backend.Type][backend.Name] = backend
 	}
 
+	if err := config.validateBackendSelectors(); err != nil {
+		return nil, err
+	}
+	if err := config.validateRateLimits(); err != nil {
+		return nil, err
+	}
+	if err := config.validateDirectMemberAudits(); err != nil {
+		return nil, err
+	}
+	if err := config.validateApproval(); err != nil {
+		return nil, err
+	}
+	if err := config.validateSharding(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -189,3 +190,162 @@ func TestLoadConfigFromPathHTTPError(t *testing.T) {
 	assert.Contains(t, err.Error(), "HTTP 404")
 	assert.Contains(t, err.Error(), "Not Found")
 }
+
+func TestSelectedBackends(t *testing.T) {
+	appConfig := &AppConfig{
+		BackendSelectors: []BackendSelector{
+			{Selector: "tier=data", Backends: []BackendRef{{Name: "prod", Type: "snowflake"}}},
+			{Selector: "tier in (data,analytics),env!=dev", Backends: []BackendRef{
+				{Name: "prod", Type: "snowflake"},
+				{Name: "fivetran", Type: "fivetran"},
+			}},
+		},
+	}
+
+	assert.Equal(t, []BackendRef{{Name: "prod", Type: "snowflake"}, {Name: "fivetran", Type: "fivetran"}},
+		appConfig.SelectedBackends(map[string]string{"tier": "data"}))
+	assert.Equal(t, []BackendRef{{Name: "prod", Type: "snowflake"}},
+		appConfig.SelectedBackends(map[string]string{"tier": "data", "env": "dev"}))
+	assert.Empty(t, appConfig.SelectedBackends(map[string]string{"tier": "web"}))
+	assert.Empty(t, appConfig.SelectedBackends(nil))
+}
+
+func TestSelectorDeletionPolicy(t *testing.T) {
+	snowflake := BackendRef{Name: "prod", Type: "snowflake"}
+	appConfig := &AppConfig{
+		BackendSelectors: []BackendSelector{
+			{Selector: "tier=data", Backends: []BackendRef{snowflake}},
+			{Selector: "env=prod", Backends: []BackendRef{snowflake}, DeletionPolicy: SelectorDeletionPolicyDelete},
+		},
+	}
+
+	assert.Equal(t, SelectorDeletionPolicyRetain,
+		appConfig.SelectorDeletionPolicy(map[string]string{"tier": "data"}, snowflake))
+	assert.Equal(t, SelectorDeletionPolicyDelete,
+		appConfig.SelectorDeletionPolicy(map[string]string{"tier": "data", "env": "prod"}, snowflake))
+	assert.Equal(t, SelectorDeletionPolicyRetain,
+		appConfig.SelectorDeletionPolicy(map[string]string{"env": "prod"}, BackendRef{Name: "fivetran", Type: "fivetran"}))
+}
+
+func TestValidateBackendSelectors(t *testing.T) {
+	appConfig := &AppConfig{
+		BackendMap: map[string]map[string]Backend{
+			"snowflake": {"prod": {Name: "prod", Type: "snowflake"}},
+		},
+		BackendSelectors: []BackendSelector{
+			{Selector: "tier=data", Backends: []BackendRef{{Name: "prod", Type: "snowflake"}}},
+		},
+	}
+	require.NoError(t, appConfig.validateBackendSelectors())
+
+	appConfig.BackendSelectors[0].Backends = append(appConfig.BackendSelectors[0].Backends,
+		BackendRef{Name: "missing", Type: "gitlab"})
+	assert.ErrorContains(t, appConfig.validateBackendSelectors(), "unknown backend gitlab/missing")
+
+	appConfig.BackendSelectors[0].Selector = "tier in data"
+	assert.ErrorContains(t, appConfig.validateBackendSelectors(), "invalid backend selector")
+
+	appConfig.BackendSelectors[0] = BackendSelector{Selector: "tier=data", DeletionPolicy: "Orphan"}
+	assert.ErrorContains(t, appConfig.validateBackendSelectors(), "invalid deletion policy")
+}
+
+func TestUsernameNormalization(t *testing.T) {
+	assert.Equal(t, "John.Doe-1", UsernameNormalization{}.Normalize("John.Doe-1"))
+
+	normalization := UsernameNormalization{
+		Lowercase:         true,
+		InvalidCharacters: "[^a-z0-9_]",
+		Replacement:       "_",
+		MaxLength:         12,
+	}
+	assert.Equal(t, "john_doe_1", normalization.Normalize("John.Doe-1"))
+	assert.Equal(t, "jdoe", normalization.Normalize("jdoe"))
+
+	// truncated usernames sharing a prefix get different hash suffixes
+	truncated := normalization.Normalize("JohnDoe-Smith")
+	assert.Len(t, truncated, 12)
+	assert.Regexp(t, `^johnd_[0-9a-f]{6}$`, truncated)
+	assert.NotEqual(t, truncated, normalization.Normalize("JohnDoe-Smithers"))
+	normalization.MaxLength = 4
+	assert.Regexp(t, `^[0-9a-f]{4}$`, normalization.Normalize("John.Doe-1"))
+
+	normalization = UsernameNormalization{InvalidCharacters: `\.`}
+	assert.Equal(t, "JohnDoe", normalization.Normalize("John.Doe"))
+}
+
+func TestValidateUsernameNormalizations(t *testing.T) {
+	appConfig := &AppConfig{
+		Backends: []Backend{
+			{Name: "prod", Type: "snowflake", UsernameNormalization: UsernameNormalization{
+				Lowercase: true, InvalidCharacters: "[^a-z0-9_]", Replacement: "_", MaxLength: 255,
+			}},
+			{Name: "fivetran", Type: "fivetran"},
+		},
+	}
+	require.NoError(t, appConfig.validateUsernameNormalizations())
+	assert.NotNil(t, appConfig.Backends[0].UsernameNormalization.invalidCharacters,
+		"Expected the invalid characters to be compiled once with the configuration")
+
+	appConfig.Backends[1].UsernameNormalization.MaxLength = -1
+	assert.ErrorContains(t, appConfig.validateUsernameNormalizations(), "max_length must not be negative")
+
+	appConfig.Backends[0].UsernameNormalization.InvalidCharacters = "[a-z"
+	assert.ErrorContains(t, appConfig.validateUsernameNormalizations(), "backend snowflake/prod")
+}
+
+func TestValidateRateLimits(t *testing.T) {
+	appConfig := &AppConfig{
+		Backends: []Backend{
+			{Name: "gitlab", Type: "gitlab", RateLimit: BackendRateLimit{RequestsPerSecond: 5, Burst: 10}},
+			{Name: "fivetran", Type: "fivetran"},
+		},
+	}
+	require.NoError(t, appConfig.validateRateLimits())
+
+	appConfig.Backends[1].RateLimit.Burst = -1
+	assert.ErrorContains(t, appConfig.validateRateLimits(), "invalid rate limit of backend fivetran/fivetran")
+}
+
+func TestValidateDirectMemberAudits(t *testing.T) {
+	appConfig := &AppConfig{
+		Backends: []Backend{
+			{Name: "gitlab", Type: "gitlab", DirectMemberAudit: DirectMemberAuditRemove},
+			{Name: "fivetran", Type: "fivetran"},
+		},
+	}
+	require.NoError(t, appConfig.validateDirectMemberAudits())
+
+	appConfig.Backends[1].DirectMemberAudit = "delete"
+	assert.ErrorContains(t, appConfig.validateDirectMemberAudits(),
+		`invalid direct_member_audit "delete" of backend fivetran/fivetran`)
+}
+
+func TestValidateApproval(t *testing.T) {
+	appConfig := &AppConfig{}
+	require.NoError(t, appConfig.validateApproval())
+
+	appConfig.ControllerConfig.Approval.MaxMemberChanges = 20
+	assert.ErrorContains(t, appConfig.validateApproval(), "approvers or approverGroups must be set")
+
+	appConfig.ControllerConfig.Approval.ApproverGroups = []string{"usernaut-approvers"}
+	require.NoError(t, appConfig.validateApproval())
+}
+
+func TestApprovalIsApprover(t *testing.T) {
+	approval := ApprovalConfig{Approvers: []string{"jsmith"}, ApproverGroups: []string{"usernaut-approvers"}}
+
+	assert.True(t, approval.IsApprover("jsmith", nil))
+	assert.True(t, approval.IsApprover("alice", []string{"system:authenticated", "usernaut-approvers"}))
+	assert.False(t, approval.IsApprover("alice", []string{"system:authenticated"}))
+}
+
+func TestValidateSharding(t *testing.T) {
+	appConfig := &AppConfig{Cache: cache.Config{Driver: cache.DriverMemory}}
+	require.NoError(t, appConfig.validateSharding())
+
+	appConfig.ControllerConfig.Sharding.Shards = 3
+	assert.ErrorContains(t, appConfig.validateSharding(), `3 shards require the redis cache driver, got "memory"`)
+
+	appConfig.Cache.Driver = cache.DriverRedis
+	require.NoError(t, appConfig.validateSharding())
+}
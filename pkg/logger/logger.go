@@ -38,6 +38,12 @@ func AddValueToContextLogger(ctx context.Context, key string, value interface{})
 	return context.WithValue(ctx, RequestIdKey, log.WithField(key, value))
 }
 
+// WithLogger returns a copy of context carrying the given logger, so that
+// Logger(ctx) returns it along with all of its fields
+func WithLogger(ctx context.Context, log *logrus.Entry) context.Context {
+	return context.WithValue(ctx, RequestIdKey, log)
+}
+
 // Init initializes logrus
 func Init() {
 	log := logrus.StandardLogger()
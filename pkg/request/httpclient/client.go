@@ -22,20 +22,63 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"time"
 
 	"github.com/gojek/heimdall/v7"
 	"github.com/gojek/heimdall/v7/hystrix"
 
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"golang.org/x/time/rate"
 )
 
 type ConnectionPoolConfig struct {
-	Timeout            int    `yaml:"timeout"`          // in milliseconds
-	KeepAliveTimeout   int    `yaml:"keepAliveTimeout"` // in milliseconds
-	MaxIdleConnections int    `yaml:"maxIdleConnections"`
-	PrivateKeyPath     string `yaml:"-"`
-	CertPath           string `yaml:"-"`
+	Timeout            int `yaml:"timeout"`          // in milliseconds
+	KeepAliveTimeout   int `yaml:"keepAliveTimeout"` // in milliseconds
+	MaxIdleConnections int `yaml:"maxIdleConnections"`
+	// ProxyURL is the egress proxy of the requests, the proxy of the environment (HTTPS_PROXY,
+	// NO_PROXY...) is used when empty
+	ProxyURL string `yaml:"proxyURL"`
+	// CABundlePath is a PEM file of the certificate authorities trusted on top of the system ones
+	CABundlePath   string `yaml:"caBundlePath"`
+	PrivateKeyPath string `yaml:"-"`
+	CertPath       string `yaml:"-"`
+	// RateLimiter throttles the requests of the backend, shared by its clients (see SharedRateLimiter)
+	RateLimiter *rate.Limiter `yaml:"-"`
+	// BackendName and BackendType label the request metrics of the backend (see WithMetrics)
+	BackendName string `yaml:"-"`
+	BackendType string `yaml:"-"`
+}
+
+// ConnectionOverrides are the transport settings of a backend connection, read from the http
+// section of its connection. The settings left empty keep the value of the pool config.
+type ConnectionOverrides struct {
+	Timeout            int    `json:"timeout"`            // in milliseconds
+	KeepAliveTimeout   int    `json:"keep_alive_timeout"` // in milliseconds
+	MaxIdleConnections int    `json:"max_idle_connections"`
+	ProxyURL           string `json:"proxy_url"`
+	CABundlePath       string `json:"ca_bundle"`
+}
+
+// WithOverrides returns the pool config with the non-empty settings of the overrides
+func (c ConnectionPoolConfig) WithOverrides(overrides ConnectionOverrides) ConnectionPoolConfig {
+	if overrides.Timeout > 0 {
+		c.Timeout = overrides.Timeout
+	}
+	if overrides.KeepAliveTimeout > 0 {
+		c.KeepAliveTimeout = overrides.KeepAliveTimeout
+	}
+	if overrides.MaxIdleConnections > 0 {
+		c.MaxIdleConnections = overrides.MaxIdleConnections
+	}
+	if overrides.ProxyURL != "" {
+		c.ProxyURL = overrides.ProxyURL
+	}
+	if overrides.CABundlePath != "" {
+		c.CABundlePath = overrides.CABundlePath
+	}
+	return c
 }
 
 type HystrixResiliencyConfig struct {
@@ -56,37 +99,100 @@ type HystrixResiliencyConfig struct {
 	CircuitBreakerTimeout int `yaml:"circuitBreakerTimeout"`
 }
 
-// InitializeClient initialises the client
-func InitializeClient(hystrixCommand string, connectionPoolConfig ConnectionPoolConfig,
-	hystrixConfig HystrixResiliencyConfig, retriable heimdall.Retriable,
-	retryCount int, fallbackFunc func(error) error) (*hystrix.Client, error) {
-	// for http conn pool
-	transport := &http.Transport{
-		DialContext: (&net.Dialer{
-			KeepAlive: time.Duration(connectionPoolConfig.KeepAliveTimeout) * time.Millisecond,
-		}).DialContext,
-		MaxIdleConnsPerHost:   connectionPoolConfig.MaxIdleConnections,
-		MaxIdleConns:          connectionPoolConfig.MaxIdleConnections,
-		IdleConnTimeout:       time.Duration(connectionPoolConfig.KeepAliveTimeout) * time.Millisecond,
-		TLSHandshakeTimeout:   time.Duration(connectionPoolConfig.Timeout) * time.Millisecond,
-		ExpectContinueTimeout: time.Duration(connectionPoolConfig.Timeout) * time.Millisecond,
-	}
-
-	if len(connectionPoolConfig.PrivateKeyPath) > 0 && len(connectionPoolConfig.CertPath) > 0 {
-		cert, err := tls.LoadX509KeyPair(connectionPoolConfig.CertPath, connectionPoolConfig.PrivateKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load certificate and key: %w", err)
+// BackendTransport wraps the transport with the metrics, the tracing and the rate limit of the
+// backend of the pool config. The metrics and spans are recorded past the rate limit, so they
+// measure the backend latency without the time spent waiting for a token.
+func BackendTransport(base http.RoundTripper, cfg ConnectionPoolConfig) http.RoundTripper {
+	return WithRateLimit(WithMetrics(WithTracing(base, cfg.BackendName, cfg.BackendType),
+		cfg.BackendName, cfg.BackendType), cfg.RateLimiter)
+}
+
+// NewTransport returns the transport of the pool config: its timeouts, idle connections, proxy and
+// trusted certificate authorities. The settings left empty keep the defaults of http.DefaultTransport.
+func NewTransport(connectionPoolConfig ConnectionPoolConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if connectionPoolConfig.Timeout > 0 {
+		timeout := time.Duration(connectionPoolConfig.Timeout) * time.Millisecond
+		dialer.Timeout = timeout
+		transport.TLSHandshakeTimeout = timeout
+		transport.ExpectContinueTimeout = timeout
+	}
+	if connectionPoolConfig.KeepAliveTimeout > 0 {
+		keepAlive := time.Duration(connectionPoolConfig.KeepAliveTimeout) * time.Millisecond
+		dialer.KeepAlive = keepAlive
+		transport.IdleConnTimeout = keepAlive
+	}
+	transport.DialContext = dialer.DialContext
+	if connectionPoolConfig.MaxIdleConnections > 0 {
+		transport.MaxIdleConns = connectionPoolConfig.MaxIdleConnections
+		transport.MaxIdleConnsPerHost = connectionPoolConfig.MaxIdleConnections
+	}
+
+	if connectionPoolConfig.ProxyURL != "" {
+		proxyURL, err := url.Parse(connectionPoolConfig.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", connectionPoolConfig.ProxyURL)
 		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	hasClientCert := len(connectionPoolConfig.PrivateKeyPath) > 0 && len(connectionPoolConfig.CertPath) > 0
+	if !hasClientCert && connectionPoolConfig.CABundlePath == "" {
+		return transport, nil
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load system cert pool: %w", err)
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = certPool
 
-		certPool, err := x509.SystemCertPool()
+	if connectionPoolConfig.CABundlePath != "" {
+		bundle, err := os.ReadFile(connectionPoolConfig.CABundlePath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load system cert pool: %w", err)
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
 		}
+		if !certPool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificate found in CA bundle %s", connectionPoolConfig.CABundlePath)
+		}
+	}
 
-		transport.TLSClientConfig = &tls.Config{
-			RootCAs:      certPool,
-			Certificates: []tls.Certificate{cert},
+	if hasClientCert {
+		cert, err := tls.LoadX509KeyPair(connectionPoolConfig.CertPath, connectionPoolConfig.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate and key: %w", err)
 		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+	return transport, nil
+}
+
+// NewHTTPClient returns a plain HTTP client sending the requests with the transport of the pool
+// config wrapped by BackendTransport, for the backend SDKs taking an *http.Client
+func NewHTTPClient(connectionPoolConfig ConnectionPoolConfig) (*http.Client, error) {
+	transport, err := NewTransport(connectionPoolConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: BackendTransport(transport, connectionPoolConfig),
+		Timeout:   time.Duration(connectionPoolConfig.Timeout) * time.Millisecond,
+	}, nil
+}
+
+// InitializeClient initialises the client
+func InitializeClient(hystrixCommand string, connectionPoolConfig ConnectionPoolConfig,
+	hystrixConfig HystrixResiliencyConfig, retriable heimdall.Retriable,
+	retryCount int, fallbackFunc func(error) error) (*hystrix.Client, error) {
+	// for http conn pool
+	transport, err := NewTransport(connectionPoolConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	if retriable == nil {
@@ -95,7 +201,7 @@ func InitializeClient(hystrixCommand string, connectionPoolConfig ConnectionPool
 
 	options := []hystrix.Option{
 		hystrix.WithHTTPClient(&http.Client{
-			Transport: &nethttp.Transport{RoundTripper: transport},
+			Transport: &nethttp.Transport{RoundTripper: BackendTransport(transport, connectionPoolConfig)},
 		}),
 		hystrix.WithHTTPTimeout(time.Duration(connectionPoolConfig.Timeout) * time.Millisecond),
 		hystrix.WithCommandName(hystrixCommand),
@@ -17,10 +17,12 @@ limitations under the License.
 package httpclient
 
 import (
+	"encoding/pem"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -263,3 +265,72 @@ func TestClientIntegration(t *testing.T) {
 
 	assert.Equal(t, "success", string(body))
 }
+
+func TestNewTransport(t *testing.T) {
+	t.Run("applies the pool config", func(t *testing.T) {
+		transport, err := NewTransport(ConnectionPoolConfig{
+			Timeout:            2000,
+			KeepAliveTimeout:   5000,
+			MaxIdleConnections: 7,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Second, transport.TLSHandshakeTimeout)
+		assert.Equal(t, 5*time.Second, transport.IdleConnTimeout)
+		assert.Equal(t, 7, transport.MaxIdleConnsPerHost)
+		assert.Nil(t, transport.TLSClientConfig.RootCAs)
+	})
+
+	t.Run("sends the requests through the proxy", func(t *testing.T) {
+		transport, err := NewTransport(ConnectionPoolConfig{ProxyURL: "http://proxy.example.com:3128"})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+		require.NoError(t, err)
+		proxyURL, err := transport.Proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, "proxy.example.com:3128", proxyURL.Host)
+	})
+
+	t.Run("rejects an invalid proxy URL", func(t *testing.T) {
+		_, err := NewTransport(ConnectionPoolConfig{ProxyURL: "proxy.example.com"})
+		assert.ErrorContains(t, err, "invalid proxy URL")
+	})
+
+	t.Run("trusts the CA bundle", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundlePath, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.Certificate().Raw,
+		}), 0o600))
+
+		client, err := NewHTTPClient(ConnectionPoolConfig{Timeout: 1000, CABundlePath: bundlePath})
+		require.NoError(t, err)
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("rejects a CA bundle without certificates", func(t *testing.T) {
+		bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundlePath, []byte("not a certificate"), 0o600))
+
+		_, err := NewTransport(ConnectionPoolConfig{CABundlePath: bundlePath})
+		assert.ErrorContains(t, err, "no certificate found in CA bundle")
+	})
+}
+
+func TestWithOverrides(t *testing.T) {
+	poolCfg := ConnectionPoolConfig{Timeout: 1000, KeepAliveTimeout: 5000, MaxIdleConnections: 10}
+	overridden := poolCfg.WithOverrides(ConnectionOverrides{Timeout: 3000, ProxyURL: "http://proxy:3128"})
+
+	assert.Equal(t, 3000, overridden.Timeout)
+	assert.Equal(t, 5000, overridden.KeepAliveTimeout)
+	assert.Equal(t, 10, overridden.MaxIdleConnections)
+	assert.Equal(t, "http://proxy:3128", overridden.ProxyURL)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// requestErrorCode is the code label of the requests which got no response
+const requestErrorCode = "error"
+
+var (
+	backendRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "usernaut_backend_requests_total",
+		Help: "Number of HTTP requests sent to the backends, retries included",
+	}, []string{"backend_name", "backend_type", "method", "code"})
+
+	backendRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "usernaut_backend_request_duration_seconds",
+		Help:    "Latency of the HTTP requests sent to the backends",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"backend_name", "backend_type", "method", "code"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(backendRequests, backendRequestDuration)
+}
+
+// metricsTransport records the count and latency of the requests of a backend
+type metricsTransport struct {
+	base        http.RoundTripper
+	backendName string
+	backendType string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	code := requestErrorCode
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	backendRequests.WithLabelValues(t.backendName, t.backendType, req.Method, code).Inc()
+	backendRequestDuration.WithLabelValues(t.backendName, t.backendType, req.Method, code).
+		Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// WithMetrics wraps the transport to record its requests under the name and type of the backend,
+// with the code label "error" for the requests which got no response. The transport is returned
+// as is when the backend is not set, and defaults to http.DefaultTransport.
+func WithMetrics(base http.RoundTripper, backendName, backendType string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if backendName == "" && backendType == "" {
+		return base
+	}
+	return &metricsTransport{base: base, backendName: backendName, backendType: backendType}
+}
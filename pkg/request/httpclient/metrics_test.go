@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// requestCount returns the number of requests recorded with the labels
+func requestCount(t *testing.T, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "usernaut_backend_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestWithMetrics(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	assert.Equal(t, http.DefaultTransport, WithMetrics(nil, "", ""))
+
+	client := &http.Client{Transport: WithMetrics(nil, "metrics-test", "gitlab")}
+	for range 2 {
+		resp, err := client.Post(testServer.URL, "application/json", nil)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	assert.Equal(t, 2.0, requestCount(t, map[string]string{
+		"backend_name": "metrics-test",
+		"backend_type": "gitlab",
+		"method":       http.MethodPost,
+		"code":         "404",
+	}))
+
+	// Requests without a response are recorded with the error code
+	testServer.Close()
+	_, err := client.Get(testServer.URL)
+	require.Error(t, err)
+	assert.Equal(t, 1.0, requestCount(t, map[string]string{
+		"backend_name": "metrics-test",
+		"backend_type": "gitlab",
+		"method":       http.MethodGet,
+		"code":         requestErrorCode,
+	}))
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	rateLimitersMu sync.Mutex
+	// rateLimiters are shared by all the clients created for a key, so that the clients of the
+	// concurrent reconciles draw from the same token bucket
+	rateLimiters = make(map[string]*rate.Limiter)
+)
+
+// SharedRateLimiter returns the token bucket rate limiter of the key, allowing requestsPerSecond
+// with bursts of burst requests. The limiter is created on first use and updated when the limits
+// change. A burst of 0 defaults to one second of requests. Returns nil when requestsPerSecond is
+// not positive, which disables the rate limiting.
+func SharedRateLimiter(key string, requestsPerSecond float64, burst int) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(requestsPerSecond))
+	}
+
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+
+	limiter, ok := rateLimiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		rateLimiters[key] = limiter
+		return limiter
+	}
+	if limiter.Limit() != rate.Limit(requestsPerSecond) {
+		limiter.SetLimit(rate.Limit(requestsPerSecond))
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+// rateLimitedTransport waits for a token of the limiter before each request, retries included
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// WithRateLimit wraps the transport to send its requests at the rate of the limiter. The transport
+// is returned as is when the limiter is nil, and defaults to http.DefaultTransport.
+func WithRateLimit(base http.RoundTripper, limiter *rate.Limiter) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if limiter == nil {
+		return base
+	}
+	return &rateLimitedTransport{base: base, limiter: limiter}
+}
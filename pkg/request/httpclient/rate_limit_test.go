@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestSharedRateLimiter(t *testing.T) {
+	assert.Nil(t, SharedRateLimiter("test/disabled", 0, 5), "no rate limiting without requests per second")
+
+	limiter := SharedRateLimiter("test/shared", 2.5, 0)
+	require.NotNil(t, limiter)
+	assert.Equal(t, rate.Limit(2.5), limiter.Limit())
+	assert.Equal(t, 3, limiter.Burst(), "burst defaults to one second of requests")
+
+	// The clients of the same key share the limiter, which follows the configured limits
+	updated := SharedRateLimiter("test/shared", 10, 20)
+	assert.Same(t, limiter, updated)
+	assert.Equal(t, rate.Limit(10), limiter.Limit())
+	assert.Equal(t, 20, limiter.Burst())
+
+	assert.NotSame(t, limiter, SharedRateLimiter("test/other", 10, 20))
+}
+
+func TestWithRateLimit(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	assert.Equal(t, http.DefaultTransport, WithRateLimit(nil, nil))
+
+	// One request is allowed at once, the next one waits for the bucket to refill
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	client := &http.Client{Transport: WithRateLimit(nil, limiter)}
+
+	resp, err := client.Get(testServer.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.Error(t, err, "the request should not wait past its deadline for a token")
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracing wraps the transport to trace its requests as child spans of the span of the request
+// context, named after the backend type and the HTTP method. The trace context is not propagated
+// to the backends. The transport is returned as is when the backend is not set, and defaults to
+// http.DefaultTransport.
+func WithTracing(base http.RoundTripper, backendName, backendType string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if backendName == "" && backendType == "" {
+		return base
+	}
+	return otelhttp.NewTransport(base,
+		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator()),
+		otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+			return backendType + " " + req.Method
+		}),
+		otelhttp.WithSpanOptions(trace.WithAttributes(
+			attribute.String("backend.name", backendName),
+			attribute.String("backend.type", backendType),
+		)),
+	)
+}
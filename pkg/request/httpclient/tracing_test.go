@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanRecorder keeps the names of the ended spans
+type spanRecorder struct {
+	sdktrace.SpanProcessor
+	names []string
+}
+
+func (r *spanRecorder) OnEnd(span sdktrace.ReadOnlySpan) {
+	r.names = append(r.names, span.Name())
+}
+
+func TestWithTracing(t *testing.T) {
+	var traceparent string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	recorder := &spanRecorder{SpanProcessor: sdktrace.NewSimpleSpanProcessor(nil)}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	assert.Equal(t, http.DefaultTransport, WithTracing(nil, "", ""))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "Group.Reconcile")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: WithTracing(nil, "tracing-test", "fivetran")}).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	span.End()
+
+	assert.Equal(t, []string{"fivetran GET", "Group.Reconcile"}, recorder.names)
+	assert.Empty(t, traceparent, "the trace context should not be sent to the backend")
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gojek/heimdall/v7"
+)
+
+// ResponseError is a response of a backend with a response code other than 2xx
+type ResponseError struct {
+	// Service is the name of the backend, e.g. quay
+	Service    string
+	StatusCode int
+	// Code is the error code of the response for the backends having one, e.g. E0000001 for Okta
+	Code    string
+	Message string
+	// Header is the header of the response, e.g. with the Retry-After of a throttled request
+	Header http.Header
+}
+
+func (e *ResponseError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s request failed with response code %d: %s: %s", e.Service, e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s request failed with response code %d: %s", e.Service, e.StatusCode, e.Message)
+}
+
+// ErrorParser returns the error code and message of the body of a response with an error code, the
+// body itself is the message when the parser returns none
+type ErrorParser func(body []byte) (code string, message string)
+
+// NewResponseError returns the error of the response of the backend, parsed with parse when set
+func NewResponseError(service string, statusCode int, header http.Header, body []byte,
+	parse ErrorParser) *ResponseError {
+	respErr := &ResponseError{Service: service, StatusCode: statusCode, Header: header}
+	if parse != nil {
+		respErr.Code, respErr.Message = parse(body)
+	}
+	if respErr.Message == "" {
+		respErr.Message = string(body)
+	}
+	return respErr
+}
+
+// HasStatus reports whether the error is a *ResponseError with one of the response codes
+func HasStatus(err error, statusCodes ...int) bool {
+	var respErr *ResponseError
+	return errors.As(err, &respErr) && slices.Contains(statusCodes, respErr.StatusCode)
+}
+
+// IsNotFound reports whether the error is a response for a missing resource
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether the error is a response for a resource which already exists
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
+
+// SendJSON sends the request with the JSON encoding of the body, when set, to the URL and returns
+// the response body and headers. Any response code other than 2xx is returned as a *ResponseError
+// parsed with parse.
+func SendJSON(ctx context.Context, client heimdall.Doer, method string, url string, body any,
+	headers map[string]string, methodName string, serviceName string, parse ErrorParser) ([]byte, http.Header, error) {
+
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return Send(ctx, client, method, url, requestBody, headers, methodName, serviceName, parse)
+}
+
+// Send sends the request with the body to the URL and returns the response body and headers. Any
+// response code other than 2xx is returned as a *ResponseError parsed with parse.
+func Send(ctx context.Context, client heimdall.Doer, method string, url string, requestBody []byte,
+	headers map[string]string, methodName string, serviceName string, parse ErrorParser) ([]byte, http.Header, error) {
+
+	req, err := NewRequest(ctx, method, url, requestBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.SetHeaders(headers)
+
+	resp, respHeaders, respCode, err := req.MakeRequestWithHeader(client, methodName, serviceName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s request failed: %w", serviceName, err)
+	}
+	if respCode < http.StatusOK || respCode >= http.StatusMultipleChoices {
+		return nil, nil, NewResponseError(serviceName, respCode, respHeaders, resp, parse)
+	}
+	return resp, respHeaders, nil
+}
@@ -0,0 +1,77 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func errorMessage(body []byte) (string, string) {
+	var errResp struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &errResp)
+	return errResp.Code, errResp.Message
+}
+
+func TestSendJSON(t *testing.T) {
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		body, _ := io.ReadAll(req.Body)
+		return req.Method == http.MethodPost && string(body) == `{"name":"team-a"}` &&
+			req.Header.Get("Authorization") == "Bearer token"
+	})).Return(&http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": {"/teams/1"}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"id":"1"}`)),
+	}, nil)
+
+	resp, headers, err := SendJSON(context.Background(), mockClient, http.MethodPost, exampleURL,
+		map[string]string{"name": "team-a"}, map[string]string{"Authorization": "Bearer token"},
+		"TestMethod", "test", errorMessage)
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(resp))
+	assert.Equal(t, "/teams/1", headers.Get("Location"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendResponseError(t *testing.T) {
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"2"}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"code":"throttled","message":"slow down"}`)),
+	}, nil).Once()
+	mockClient.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString("not found")),
+	}, nil).Once()
+
+	_, _, err := Send(context.Background(), mockClient, http.MethodGet, exampleURL, nil, nil,
+		"TestMethod", "test", errorMessage)
+
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "throttled", respErr.Code)
+	assert.Equal(t, "2", respErr.Header.Get("Retry-After"))
+	assert.EqualError(t, err, "test request failed with response code 429: throttled: slow down")
+	assert.True(t, HasStatus(err, http.StatusServiceUnavailable, http.StatusTooManyRequests))
+	assert.False(t, IsNotFound(err))
+
+	_, _, err = Send(context.Background(), mockClient, http.MethodGet, exampleURL, nil, nil,
+		"TestMethod", "test", nil)
+
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsConflict(err))
+	assert.EqualError(t, err, "test request failed with response code 404: not found",
+		"Expected the body to be the message of a response without one")
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// BackendResponseStore handles the backend responses cached by the read-through client decorator
+// Key format: "response:<backendKey>:teams" and "response:<backendKey>:members:<teamID>"
+// Value: JSON of the teams by name, or of the members by ID
+// NOTE: Each operation reads or writes a single key, the cache clients are safe for concurrent use
+type BackendResponseStore struct {
+	cache cache.Cache
+}
+
+// newBackendResponseStore creates a new BackendResponseStore instance
+func newBackendResponseStore(c cache.Cache) *BackendResponseStore {
+	return &BackendResponseStore{
+		cache: c,
+	}
+}
+
+// teamsKey returns the prefixed cache key for the teams of a backend
+func (s *BackendResponseStore) teamsKey(backendKey string) string {
+	return "response:" + backendKey + ":teams"
+}
+
+// membersKey returns the prefixed cache key for the members of a team of a backend
+func (s *BackendResponseStore) membersKey(backendKey, teamID string) string {
+	return "response:" + backendKey + ":members:" + teamID
+}
+
+// GetTeams returns the teams of the backend and whether they were found in cache
+func (s *BackendResponseStore) GetTeams(ctx context.Context, backendKey string) (map[string]structs.Team, bool, error) {
+	var teams map[string]structs.Team
+	found, err := s.get(ctx, s.teamsKey(backendKey), &teams)
+	return teams, found, err
+}
+
+// SetTeams stores the teams of the backend until the TTL expires
+func (s *BackendResponseStore) SetTeams(ctx context.Context, backendKey string, teams map[string]structs.Team,
+	ttl time.Duration) error {
+	return s.set(ctx, s.teamsKey(backendKey), teams, ttl)
+}
+
+// DeleteTeams removes the teams of the backend
+func (s *BackendResponseStore) DeleteTeams(ctx context.Context, backendKey string) error {
+	return s.cache.Delete(ctx, s.teamsKey(backendKey))
+}
+
+// GetTeamMembers returns the members of the team and whether they were found in cache
+func (s *BackendResponseStore) GetTeamMembers(ctx context.Context, backendKey, teamID string) (
+	map[string]*structs.User, bool, error) {
+	var members map[string]*structs.User
+	found, err := s.get(ctx, s.membersKey(backendKey, teamID), &members)
+	return members, found, err
+}
+
+// SetTeamMembers stores the members of the team until the TTL expires
+func (s *BackendResponseStore) SetTeamMembers(ctx context.Context, backendKey, teamID string,
+	members map[string]*structs.User, ttl time.Duration) error {
+	return s.set(ctx, s.membersKey(backendKey, teamID), members, ttl)
+}
+
+// DeleteTeamMembers removes the members of the team
+func (s *BackendResponseStore) DeleteTeamMembers(ctx context.Context, backendKey, teamID string) error {
+	return s.cache.Delete(ctx, s.membersKey(backendKey, teamID))
+}
+
+// get unmarshals the value of the key into target, it reports false when the key is not in cache
+func (s *BackendResponseStore) get(ctx context.Context, key string, target interface{}) (bool, error) {
+	val, err := s.cache.Get(ctx, key)
+	if err != nil {
+		// Response not cached or expired (not an error condition)
+		return false, nil
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("invalid backend response stored for %s", key)
+	}
+	if err := json.Unmarshal([]byte(str), target); err != nil {
+		return false, fmt.Errorf("failed to unmarshal backend response: %w", err)
+	}
+	return true, nil
+}
+
+// set stores the JSON of the value under the key until the TTL expires
+func (s *BackendResponseStore) set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backend response: %w", err)
+	}
+	if err := s.cache.Set(ctx, key, string(data), ttl); err != nil {
+		return fmt.Errorf("failed to set backend response in cache: %w", err)
+	}
+	return nil
+}
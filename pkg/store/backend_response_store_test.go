@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBackendResponseStore(t *testing.T) *BackendResponseStore {
+	t.Helper()
+	c, err := inmemory.NewCache(&inmemory.Config{
+		DefaultExpiration: 300,
+		CleanupInterval:   600,
+	})
+	require.NoError(t, err)
+	return newBackendResponseStore(c)
+}
+
+func TestBackendResponseStore_Teams(t *testing.T) {
+	store := setupBackendResponseStore(t)
+	ctx := context.Background()
+
+	_, found, err := store.GetTeams(ctx, "gitlab_gitlab")
+	require.NoError(t, err)
+	assert.False(t, found, "teams not cached yet")
+
+	teams := map[string]structs.Team{"team-a": {ID: "1", Name: "team-a"}}
+	require.NoError(t, store.SetTeams(ctx, "gitlab_gitlab", teams, time.Minute))
+
+	cached, found, err := store.GetTeams(ctx, "gitlab_gitlab")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, teams, cached)
+
+	require.NoError(t, store.DeleteTeams(ctx, "gitlab_gitlab"))
+	_, found, err = store.GetTeams(ctx, "gitlab_gitlab")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestBackendResponseStore_TeamMembers(t *testing.T) {
+	store := setupBackendResponseStore(t)
+	ctx := context.Background()
+
+	members := map[string]*structs.User{"42": {ID: "42", Email: "alice@example.com"}}
+	require.NoError(t, store.SetTeamMembers(ctx, "gitlab_gitlab", "1", members, time.Minute))
+
+	cached, found, err := store.GetTeamMembers(ctx, "gitlab_gitlab", "1")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, members, cached)
+
+	_, found, err = store.GetTeamMembers(ctx, "gitlab_gitlab", "2")
+	require.NoError(t, err)
+	assert.False(t, found, "members are cached per team")
+
+	require.NoError(t, store.DeleteTeamMembers(ctx, "gitlab_gitlab", "1"))
+	_, found, err = store.GetTeamMembers(ctx, "gitlab_gitlab", "1")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestBackendResponseStore_Expiration(t *testing.T) {
+	store := setupBackendResponseStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.SetTeams(ctx, "gitlab_gitlab", map[string]structs.Team{}, 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	_, found, err := store.GetTeams(ctx, "gitlab_gitlab")
+	require.NoError(t, err)
+	assert.False(t, found, "expired responses are not fresh")
+}
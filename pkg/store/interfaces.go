@@ -1,6 +1,11 @@
 package store
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
 
 // UserStoreInterface defines operations for user-related cache operations
 // This interface enables mocking in tests and follows the dependency inversion principle
@@ -133,6 +138,65 @@ type UserGroupsStoreInterface interface {
 	Exists(ctx context.Context, email string) (bool, error)
 }
 
+// UserUIDStoreInterface defines operations for the uid-to-email index of the LDAP users
+// Key format: "uid:<uid>"
+// The index detects the users whose email changed in LDAP, whose cache entries are keyed by email
+type UserUIDStoreInterface interface {
+	// GetEmail returns the email stored for the uid
+	// Returns an empty string if the uid is not found in cache
+	GetEmail(ctx context.Context, uid string) (string, error)
+
+	// GetUID returns the uid whose email is stored as the given email
+	// Returns an empty string if no uid has the email
+	GetUID(ctx context.Context, email string) (string, error)
+
+	// SetEmail stores the email of the uid, replacing any previous email
+	SetEmail(ctx context.Context, uid, email string) error
+
+	// Delete removes the uid entry entirely
+	Delete(ctx context.Context, uid string) error
+}
+
+// BackendResponseStoreInterface defines operations for the responses of the backends cached by the
+// read-through client decorator
+// Key format: "response:<backendKey>:teams" and "response:<backendKey>:members:<teamID>"
+// The entries expire after their TTL, an entry found in cache is fresh
+type BackendResponseStoreInterface interface {
+	// GetTeams returns the teams of the backend and whether they were found in cache
+	GetTeams(ctx context.Context, backendKey string) (map[string]structs.Team, bool, error)
+
+	// SetTeams stores the teams of the backend until the TTL expires
+	SetTeams(ctx context.Context, backendKey string, teams map[string]structs.Team, ttl time.Duration) error
+
+	// DeleteTeams removes the teams of the backend
+	DeleteTeams(ctx context.Context, backendKey string) error
+
+	// GetTeamMembers returns the members of the team and whether they were found in cache
+	GetTeamMembers(ctx context.Context, backendKey, teamID string) (map[string]*structs.User, bool, error)
+
+	// SetTeamMembers stores the members of the team until the TTL expires
+	SetTeamMembers(ctx context.Context, backendKey, teamID string, members map[string]*structs.User,
+		ttl time.Duration) error
+
+	// DeleteTeamMembers removes the members of the team
+	DeleteTeamMembers(ctx context.Context, backendKey, teamID string) error
+}
+
+// PreloadStoreInterface defines operations for the checkpoints of the cache preloads
+// Key format: "preload:<backendKey>"
+// A checkpoint is the cursor after the last page of users stored, the preload resumes from it
+type PreloadStoreInterface interface {
+	// GetCheckpoint returns the cursor checkpointed for the backend
+	// Returns an empty string if the backend has no checkpoint
+	GetCheckpoint(ctx context.Context, backendKey string) (string, error)
+
+	// SetCheckpoint stores the cursor of the backend, replacing any previous checkpoint
+	SetCheckpoint(ctx context.Context, backendKey, cursor string) error
+
+	// DeleteCheckpoint removes the checkpoint of the backend once its preload completed
+	DeleteCheckpoint(ctx context.Context, backendKey string) error
+}
+
 // StoreInterface is the main interface that combines all store operations
 // This is the primary interface that should be used by consumers
 type StoreInterface interface {
@@ -147,4 +211,13 @@ type StoreInterface interface {
 
 	// GetUserGroupsStore returns the user groups store operations
 	GetUserGroupsStore() UserGroupsStoreInterface
+
+	// GetUserUIDStore returns the uid-to-email index operations
+	GetUserUIDStore() UserUIDStoreInterface
+
+	// GetBackendResponseStore returns the cached backend responses operations
+	GetBackendResponseStore() BackendResponseStoreInterface
+
+	// GetPreloadStore returns the cache preload checkpoints operations
+	GetPreloadStore() PreloadStoreInterface
 }
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+)
+
+// PreloadStore holds the checkpoints of the cache preloads streaming the users of a backend
+// Key format: "preload:<backendKey>"
+// Value: cursor of the backend after the last page of users stored
+// NOTE: This store does NOT handle locking - callers must ensure proper synchronization
+type PreloadStore struct {
+	cache cache.Cache
+}
+
+// newPreloadStore creates a new PreloadStore instance
+func newPreloadStore(c cache.Cache) *PreloadStore {
+	return &PreloadStore{
+		cache: c,
+	}
+}
+
+// checkpointKey returns the prefixed cache key for the checkpoint of a backend
+func (s *PreloadStore) checkpointKey(backendKey string) string {
+	return "preload:" + backendKey
+}
+
+// GetCheckpoint returns the cursor checkpointed for the backend
+// Returns an empty string if the backend has no checkpoint
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *PreloadStore) GetCheckpoint(ctx context.Context, backendKey string) (string, error) {
+	val, err := s.cache.Get(ctx, s.checkpointKey(backendKey))
+	if err != nil {
+		// No checkpoint, return empty string (not an error condition)
+		return "", nil
+	}
+
+	cursor, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid preload checkpoint stored for backend %s", backendKey)
+	}
+	return cursor, nil
+}
+
+// SetCheckpoint stores the cursor of the backend, replacing any previous checkpoint
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *PreloadStore) SetCheckpoint(ctx context.Context, backendKey, cursor string) error {
+	if err := s.cache.Set(ctx, s.checkpointKey(backendKey), cursor, cache.NoExpiration); err != nil {
+		return fmt.Errorf("failed to set preload checkpoint in cache: %w", err)
+	}
+	return nil
+}
+
+// DeleteCheckpoint removes the checkpoint of the backend once its preload completed
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *PreloadStore) DeleteCheckpoint(ctx context.Context, backendKey string) error {
+	return s.cache.Delete(ctx, s.checkpointKey(backendKey))
+}
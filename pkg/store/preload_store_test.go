@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPreloadStore(t *testing.T) *PreloadStore {
+	t.Helper()
+	c, err := inmemory.NewCache(&inmemory.Config{
+		DefaultExpiration: 300,
+		CleanupInterval:   600,
+	})
+	require.NoError(t, err)
+	return newPreloadStore(c)
+}
+
+func TestPreloadStore_Checkpoint(t *testing.T) {
+	store := setupPreloadStore(t)
+	ctx := context.Background()
+
+	cursor, err := store.GetCheckpoint(ctx, "snowflake_snowflake")
+	require.NoError(t, err)
+	assert.Empty(t, cursor, "backend without checkpoint returns an empty cursor")
+
+	require.NoError(t, store.SetCheckpoint(ctx, "snowflake_snowflake", "ALICE"))
+	require.NoError(t, store.SetCheckpoint(ctx, "snowflake_snowflake", "BOB"))
+	cursor, err = store.GetCheckpoint(ctx, "snowflake_snowflake")
+	require.NoError(t, err)
+	assert.Equal(t, "BOB", cursor)
+
+	require.NoError(t, store.DeleteCheckpoint(ctx, "snowflake_snowflake"))
+	cursor, err = store.GetCheckpoint(ctx, "snowflake_snowflake")
+	require.NoError(t, err)
+	assert.Empty(t, cursor)
+
+	// Deleting a missing checkpoint is not an error
+	assert.NoError(t, store.DeleteCheckpoint(ctx, "unknown"))
+}
@@ -1,6 +1,9 @@
 package store
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 )
 
@@ -12,22 +15,103 @@ type Store struct {
 	Team       TeamStoreInterface  // For preload with transformed team names
 	Group      GroupStoreInterface // For reconciliation with original group names
 	UserGroups UserGroupsStoreInterface
+	UserUID    UserUIDStoreInterface
+	// BackendResponses holds the responses of the backends cached by the read-through client decorator
+	BackendResponses BackendResponseStoreInterface
+	// Preload holds the checkpoints of the cache preloads streaming the users of the backends
+	Preload PreloadStoreInterface
 }
 
 // New creates a new Store instance with all sub-stores initialized
 func New(cache cache.Cache) *Store {
 	return &Store{
-		User:       newUserStore(cache),
-		Team:       newTeamStore(cache),
-		Group:      newGroupStore(cache),
-		UserGroups: newUserGroupsStore(cache),
+		User:             newUserStore(cache),
+		Team:             newTeamStore(cache),
+		Group:            newGroupStore(cache),
+		UserGroups:       newUserGroupsStore(cache),
+		UserUID:          newUserUIDStore(cache),
+		BackendResponses: newBackendResponseStore(cache),
+		Preload:          newPreloadStore(cache),
 	}
 }
 
 // Compile-time interface compliance checks
 var (
-	_ UserStoreInterface       = (*UserStore)(nil)
-	_ TeamStoreInterface       = (*TeamStore)(nil)
-	_ GroupStoreInterface      = (*GroupStore)(nil)
-	_ UserGroupsStoreInterface = (*UserGroupsStore)(nil)
+	_ UserStoreInterface            = (*UserStore)(nil)
+	_ TeamStoreInterface            = (*TeamStore)(nil)
+	_ GroupStoreInterface           = (*GroupStore)(nil)
+	_ UserGroupsStoreInterface      = (*UserGroupsStore)(nil)
+	_ UserUIDStoreInterface         = (*UserUIDStore)(nil)
+	_ BackendResponseStoreInterface = (*BackendResponseStore)(nil)
+	_ PreloadStoreInterface         = (*PreloadStore)(nil)
 )
+
+// RenameUser migrates the cache entries of a user whose email changed from oldEmail to newEmail:
+// the backend IDs, the user's groups and the group members. Backend IDs already stored for the
+// new email are kept.
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *Store) RenameUser(ctx context.Context, oldEmail, newEmail string) error {
+	if oldEmail == newEmail {
+		return nil
+	}
+
+	backends, err := s.User.GetBackends(ctx, oldEmail)
+	if err != nil {
+		return err
+	}
+	newBackends, err := s.User.GetBackends(ctx, newEmail)
+	if err != nil {
+		return err
+	}
+	for backendKey, backendID := range backends {
+		if _, exists := newBackends[backendKey]; exists {
+			continue
+		}
+		if err := s.User.SetBackend(ctx, newEmail, backendKey, backendID); err != nil {
+			return err
+		}
+	}
+
+	groups, err := s.UserGroups.GetGroups(ctx, oldEmail)
+	if err != nil {
+		return err
+	}
+	for _, groupName := range groups {
+		if err := s.UserGroups.AddGroup(ctx, newEmail, groupName); err != nil {
+			return err
+		}
+		members, err := s.Group.GetMembers(ctx, groupName)
+		if err != nil {
+			return err
+		}
+		if err := s.Group.SetMembers(ctx, groupName, renameMember(members, oldEmail, newEmail)); err != nil {
+			return err
+		}
+	}
+
+	// The old entries are only removed once everything is migrated, so that a failed
+	// migration is retried on the next reconcile
+	if err := s.UserGroups.Delete(ctx, oldEmail); err != nil {
+		return fmt.Errorf("failed to delete user groups of %s: %w", oldEmail, err)
+	}
+	if err := s.User.Delete(ctx, oldEmail); err != nil {
+		return fmt.Errorf("failed to delete user %s: %w", oldEmail, err)
+	}
+	return nil
+}
+
+// renameMember replaces oldEmail with newEmail in the members, without duplicating newEmail
+func renameMember(members []string, oldEmail, newEmail string) []string {
+	renamed := make([]string, 0, len(members))
+	seen := false
+	for _, member := range members {
+		if member == oldEmail || member == newEmail {
+			if seen {
+				continue
+			}
+			member, seen = newEmail, true
+		}
+		renamed = append(renamed, member)
+	}
+	return renamed
+}
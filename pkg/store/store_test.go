@@ -29,6 +29,7 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, store.Team)
 	assert.NotNil(t, store.Group)
 	assert.NotNil(t, store.UserGroups)
+	assert.NotNil(t, store.UserUID)
 }
 
 func TestStore_InterfaceCompliance(t *testing.T) {
@@ -123,3 +124,47 @@ func TestStore_KeyNamespacing(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "id2", groupBackends["backend1_backend1"].ID)
 }
+
+func TestStore_RenameUser(t *testing.T) {
+	c, err := inmemory.NewCache(&inmemory.Config{
+		DefaultExpiration: 300,
+		CleanupInterval:   600,
+	})
+	require.NoError(t, err)
+
+	store := New(c)
+	ctx := testContext(t)
+	oldEmail, newEmail := "alice.old@example.com", "alice@example.com"
+
+	require.NoError(t, store.User.SetBackend(ctx, oldEmail, "fivetran_prod", "user_123"))
+	require.NoError(t, store.User.SetBackend(ctx, oldEmail, "gitlab_prod", "user_456"))
+	// A backend ID already stored for the new email is kept
+	require.NoError(t, store.User.SetBackend(ctx, newEmail, "gitlab_prod", "user_789"))
+	require.NoError(t, store.UserGroups.AddGroup(ctx, oldEmail, "data-team"))
+	require.NoError(t, store.UserGroups.AddGroup(ctx, oldEmail, "ml-team"))
+	require.NoError(t, store.Group.SetMembers(ctx, "data-team", []string{oldEmail, "bob@example.com"}))
+	require.NoError(t, store.Group.SetMembers(ctx, "ml-team", []string{oldEmail, newEmail}))
+
+	require.NoError(t, store.RenameUser(ctx, oldEmail, newEmail))
+
+	backends, err := store.User.GetBackends(ctx, newEmail)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"fivetran_prod": "user_123", "gitlab_prod": "user_789"}, backends)
+	exists, err := store.User.Exists(ctx, oldEmail)
+	require.NoError(t, err)
+	assert.False(t, exists, "old user entry should be removed")
+
+	groups, err := store.UserGroups.GetGroups(ctx, newEmail)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"data-team", "ml-team"}, groups)
+	exists, err = store.UserGroups.Exists(ctx, oldEmail)
+	require.NoError(t, err)
+	assert.False(t, exists, "old user groups entry should be removed")
+
+	members, err := store.Group.GetMembers(ctx, "data-team")
+	require.NoError(t, err)
+	assert.Equal(t, []string{newEmail, "bob@example.com"}, members)
+	members, err = store.Group.GetMembers(ctx, "ml-team")
+	require.NoError(t, err)
+	assert.Equal(t, []string{newEmail}, members, "new email should not be duplicated")
+}
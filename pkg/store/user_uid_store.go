@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+)
+
+// UserUIDStore handles the uid-to-email index of the LDAP users
+// Key format: "uid:<uid>"
+// Value: email of the user when it was last reconciled
+// NOTE: This store does NOT handle locking - callers must ensure proper synchronization
+type UserUIDStore struct {
+	cache cache.Cache
+}
+
+// newUserUIDStore creates a new UserUIDStore instance
+func newUserUIDStore(c cache.Cache) *UserUIDStore {
+	return &UserUIDStore{
+		cache: c,
+	}
+}
+
+// uidKey returns the prefixed cache key for a user's uid
+func (s *UserUIDStore) uidKey(uid string) string {
+	return "uid:" + uid
+}
+
+// GetEmail returns the email stored for the uid
+// Returns an empty string if the uid is not found in cache
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *UserUIDStore) GetEmail(ctx context.Context, uid string) (string, error) {
+	val, err := s.cache.Get(ctx, s.uidKey(uid))
+	if err != nil {
+		// Uid not found, return empty string (not an error condition)
+		return "", nil
+	}
+
+	email, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid email stored for uid %s", uid)
+	}
+	return email, nil
+}
+
+// GetUID returns the uid whose email is stored as the given email, the index is searched as it is
+// keyed by uid. Returns an empty string if no uid has the email.
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *UserUIDStore) GetUID(ctx context.Context, email string) (string, error) {
+	results, err := s.cache.GetByPattern(ctx, s.uidKey("*"))
+	if err != nil {
+		return "", fmt.Errorf("failed to search the user uids: %w", err)
+	}
+	for key, value := range results {
+		if stored, ok := value.(string); ok && strings.EqualFold(stored, email) {
+			return strings.TrimPrefix(key, s.uidKey("")), nil
+		}
+	}
+	return "", nil
+}
+
+// SetEmail stores the email of the uid, replacing any previous email
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *UserUIDStore) SetEmail(ctx context.Context, uid, email string) error {
+	if err := s.cache.Set(ctx, s.uidKey(uid), email, cache.NoExpiration); err != nil {
+		return fmt.Errorf("failed to set user uid in cache: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the uid entry entirely
+// NOTE: Caller must hold appropriate lock if concurrent access is possible
+func (s *UserUIDStore) Delete(ctx context.Context, uid string) error {
+	return s.cache.Delete(ctx, s.uidKey(uid))
+}
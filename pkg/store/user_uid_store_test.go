@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUserUIDStore(t *testing.T) *UserUIDStore {
+	t.Helper()
+	c, err := inmemory.NewCache(&inmemory.Config{
+		DefaultExpiration: 300,
+		CleanupInterval:   600,
+	})
+	require.NoError(t, err)
+	return newUserUIDStore(c)
+}
+
+func TestUserUIDStore_GetEmail(t *testing.T) {
+	store := setupUserUIDStore(t)
+	ctx := context.Background()
+
+	email, err := store.GetEmail(ctx, "alice")
+	require.NoError(t, err)
+	assert.Empty(t, email, "unknown uid returns an empty email")
+
+	require.NoError(t, store.SetEmail(ctx, "alice", "alice@example.com"))
+	email, err = store.GetEmail(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", email)
+
+	require.NoError(t, store.SetEmail(ctx, "alice", "alice.new@example.com"))
+	email, err = store.GetEmail(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice.new@example.com", email)
+}
+
+func TestUserUIDStore_GetUID(t *testing.T) {
+	store := setupUserUIDStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.SetEmail(ctx, "alice", "alice@example.com"))
+	require.NoError(t, store.SetEmail(ctx, "bob", "bob@example.com"))
+
+	uid, err := store.GetUID(ctx, "Bob@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", uid)
+
+	uid, err = store.GetUID(ctx, "carol@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, uid, "unknown email returns an empty uid")
+}
+
+func TestUserUIDStore_Delete(t *testing.T) {
+	store := setupUserUIDStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.SetEmail(ctx, "alice", "alice@example.com"))
+	require.NoError(t, store.Delete(ctx, "alice"))
+
+	email, err := store.GetEmail(ctx, "alice")
+	require.NoError(t, err)
+	assert.Empty(t, email)
+
+	// Deleting an unknown uid is not an error
+	assert.NoError(t, store.Delete(ctx, "unknown"))
+}
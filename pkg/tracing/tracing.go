@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer of the usernaut spans
+const instrumentationName = "github.com/redhat-data-and-ai/usernaut"
+
+// Config configures the export of the traces to an OTLP collector
+type Config struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the host:port of the OTLP gRPC collector, defaults to the
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost:4317
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables the TLS of the connection to the collector
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio is the share of the reconciles traced between 0 and 1, 0 traces all of them
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// Init sets up the global tracer provider exporting the spans over OTLP. The spans of the Redis
+// cache and of the backend requests are exported with the reconcile spans. It returns the function
+// flushing the spans on shutdown, a no-op when the tracing is disabled.
+func Init(ctx context.Context, cfg Config, serviceName, serviceVersion string) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracegrpc.Option{}
+	if cfg.Endpoint != "" {
+		options = append(options, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if cfg.SampleRatio > 0 && cfg.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(serviceVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the usernaut tracer, a child of the span of the context if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, recording the error when not nil
+func End(span trace.Span, err error) {
+	if err != nil && span.IsRecording() {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
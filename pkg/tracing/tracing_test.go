@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingExporter keeps the exported spans in memory
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (e *recordingExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestInitDisabled(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{}, "usernaut", "0.0.1")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestStartAndEnd(t *testing.T) {
+	exporter := &recordingExporter{}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := Start(context.Background(), "Group.Reconcile", attribute.String("group", "usernaut/test"))
+	_, child := Start(ctx, "ldap.search")
+	End(child, errors.New("LDAP server unavailable"))
+	End(parent, nil)
+
+	require.Len(t, exporter.spans, 2)
+	childSpan, parentSpan := exporter.spans[0], exporter.spans[1]
+
+	assert.Equal(t, "ldap.search", childSpan.Name())
+	assert.Equal(t, parentSpan.SpanContext().TraceID(), childSpan.SpanContext().TraceID(),
+		"the spans of a reconcile should share its trace")
+	assert.Equal(t, parentSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, childSpan.Status().Code)
+	assert.Equal(t, "LDAP server unavailable", childSpan.Status().Description)
+
+	assert.Equal(t, "Group.Reconcile", parentSpan.Name())
+	assert.Equal(t, codes.Unset, parentSpan.Status().Code)
+	assert.Contains(t, parentSpan.Attributes(), attribute.String("group", "usernaut/test"))
+}
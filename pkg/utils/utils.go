@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -283,13 +285,17 @@ func processGroupName(outputTemplate string, matches []string) (string, error) {
 	return result, nil
 }
 
+// GetTransformedGroupName transforms the group name with the patterns configured for the backend type
 func GetTransformedGroupName(cfg *config.AppConfig, typeName, inputStr string) (string, error) {
-	patterns, ok := cfg.Pattern[typeName]
-	if !ok {
-		patterns = cfg.Pattern["default"]
-	}
+	return GetTransformedBackendGroupName(cfg, typeName, "", inputStr)
+}
 
-	for _, p := range patterns {
+// GetTransformedBackendGroupName transforms the group name for the backend with the given type and name.
+// The patterns configured for the backend name (keyed "<type>/<name>") are tried before the patterns of
+// the backend type, which fall back to the default patterns when the type has none. Patterns are tried
+// by descending priority, then in configuration order, and the first match wins.
+func GetTransformedBackendGroupName(cfg *config.AppConfig, typeName, backendName, inputStr string) (string, error) {
+	for _, p := range backendPatterns(cfg, typeName, backendName) {
 		re, err := regexp.Compile(p.Input)
 		if err != nil {
 			return "", fmt.Errorf("invalid regex pattern: %s", p.Input)
@@ -304,6 +310,28 @@ func GetTransformedGroupName(cfg *config.AppConfig, typeName, inputStr string) (
 	return "", fmt.Errorf("no matching pattern found for backend type %s and input string is %s", typeName, inputStr)
 }
 
+// backendPatterns returns the patterns to try for a backend, in the order they are tried
+func backendPatterns(cfg *config.AppConfig, typeName, backendName string) []config.PatternEntry {
+	typePatterns, ok := cfg.Pattern[typeName]
+	if !ok {
+		typePatterns = cfg.Pattern["default"]
+	}
+	typePatterns = slices.Clone(typePatterns)
+	slices.SortStableFunc(typePatterns, byPriority)
+	if backendName == "" {
+		return typePatterns
+	}
+
+	namePatterns := slices.Clone(cfg.Pattern[typeName+"/"+backendName])
+	slices.SortStableFunc(namePatterns, byPriority)
+	return append(namePatterns, typePatterns...)
+}
+
+// byPriority orders the patterns with the highest priority first
+func byPriority(a, b config.PatternEntry) int {
+	return cmp.Compare(b.Priority, a.Priority)
+}
+
 // sanitizeGroupNameFallback produces a conservative backend-style identifier when no pattern
 // matches: lowercase ASCII letters and digits, hyphens/underscores normalized to a single
 // underscore, other runes replaced with underscores, no leading/trailing underscores,
@@ -371,6 +399,16 @@ func GetTransformedGroupNameOrFallback(cfg *config.AppConfig, typeName, inputStr
 	return transformedName
 }
 
+// GetTransformedBackendGroupNameOrFallback is GetTransformedGroupNameOrFallback with the patterns
+// configured for the backend name taking precedence over the ones of the backend type.
+func GetTransformedBackendGroupNameOrFallback(cfg *config.AppConfig, typeName, backendName, inputStr string) string {
+	transformedName, err := GetTransformedBackendGroupName(cfg, typeName, backendName, inputStr)
+	if err != nil {
+		return sanitizeGroupNameFallback(inputStr)
+	}
+	return transformedName
+}
+
 // StandardizeNameForBackend standardizes a user's first or last name for systems (e.g. Fivetran)
 // that do not support certain special characters. It replaces period (.), parenthesis (( )), and comma (,)
 // with a space, then collapses multiple spaces and trims.
@@ -360,6 +360,84 @@ func TestGetTransformGroupName(t *testing.T) {
 	}
 }
 
+func TestGetTransformedBackendGroupName(t *testing.T) {
+	mockCfg := &config.AppConfig{
+		Pattern: map[string][]config.PatternEntry{
+			"gitlab": {
+				{
+					Input:  `dataverse-([a-z0-9-]+)`,
+					Output: "$1",
+				},
+				{
+					Input:    `dataverse-platform-([a-z0-9]+)`,
+					Output:   "platform/$1",
+					Priority: 10,
+				},
+			},
+			"gitlab/internal": {
+				{
+					Input:  `dataverse-source-([a-z0-9]+)`,
+					Output: "internal-$1",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		backendName string
+		input       string
+		output      string
+		wantErr     bool
+	}{
+		{
+			name:   "higher priority pattern wins over configuration order",
+			input:  "dataverse-platform-admin",
+			output: "platform/admin",
+		},
+		{
+			name:   "first matching pattern wins with the same priority",
+			input:  "dataverse-source-sfsales",
+			output: "source-sfsales",
+		},
+		{
+			name:        "backend name patterns are tried before the type patterns",
+			backendName: "internal",
+			input:       "dataverse-source-sfsales",
+			output:      "internal-sfsales",
+		},
+		{
+			name:        "type patterns apply when no backend name pattern matches",
+			backendName: "internal",
+			input:       "dataverse-platform-admin",
+			output:      "platform/admin",
+		},
+		{
+			name:        "backend name patterns do not apply to other backends",
+			backendName: "public",
+			input:       "dataverse-source-sfsales",
+			output:      "source-sfsales",
+		},
+		{
+			name:    "no matching pattern",
+			input:   "No_Mapping",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTransformedBackendGroupName(mockCfg, "gitlab", tt.backendName, tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.output, got)
+		})
+	}
+}
+
 func TestGetTransformedGroupNameOrFallback(t *testing.T) {
 	mockCfg := &config.AppConfig{
 		Pattern: map[string][]config.PatternEntry{